@@ -13,6 +13,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/iot v1.69.5
 	github.com/aws/aws-sdk-go-v2/service/iotdataplane v1.32.6
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.88.4
+	github.com/eclipse/paho.mqtt.golang v1.5.1
 	github.com/gin-gonic/gin v1.11.0
 	github.com/go-ozzo/ozzo-validation/v4 v4.3.0
 	github.com/go-viper/mapstructure/v2 v2.4.0
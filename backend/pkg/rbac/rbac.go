@@ -25,6 +25,10 @@ const (
 	scopeContextKey        scopeContextKeyType = 0
 	ScopeHeader                                = "X-MEN-RBAC-Inventory-Groups"
 	ScopeReleaseTagsHeader                     = "X-MEN-RBAC-Releases-Tags"
+
+	// DeviceGroupSeparator separates the names of nested device groups;
+	// a scope including a group includes the groups nested in it.
+	DeviceGroupSeparator = "/"
 )
 
 type Scope struct {
@@ -0,0 +1,51 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package command
+
+const (
+	// MessageTypeRun requests the device to execute a command. The body
+	// MUST contain a RunCommand object. The session ID identifies the
+	// execution and stays the same if the request is re-sent, so the
+	// device can safely discard duplicates.
+	MessageTypeRun = "run"
+	// MessageTypeStdout carries a chunk of the command's standard output.
+	MessageTypeStdout = "stdout"
+	// MessageTypeStderr carries a chunk of the command's standard error.
+	MessageTypeStderr = "stderr"
+	// MessageTypeExit is sent when the command terminates. The body MUST
+	// contain an ExitStatus object.
+	MessageTypeExit = "exit"
+	// MessageTypeError is returned if the device could not execute the
+	// command. The body MUST contain an Error object.
+	MessageTypeError = "error"
+)
+
+// RunCommand is the body of a MessageTypeRun message.
+type RunCommand struct {
+	// Command is the command line to execute.
+	Command string `msgpack:"command" json:"command"`
+	// Timeout is the number of seconds after which the device MUST
+	// terminate the command.
+	Timeout uint `msgpack:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// ExitStatus is the body of a MessageTypeExit message.
+type ExitStatus struct {
+	// ExitCode is the exit code of the command.
+	ExitCode int `msgpack:"exit_code" json:"exit_code"`
+	// TimedOut is set if the command was terminated because it exceeded
+	// the timeout.
+	TimedOut bool `msgpack:"timed_out,omitempty" json:"timed_out,omitempty"`
+}
@@ -32,6 +32,8 @@ const (
 	ProtoTypePortForward
 	// ProtoTypeMenderClient is used for communication with the Mender client.
 	ProtoTypeMenderClient
+	// ProtoTypeCommand is used for non-interactive command execution.
+	ProtoTypeCommand
 
 	// ProtoTypeControl is a reserved proto type for session control messages.
 	ProtoTypeControl ProtoType = 0xFFFF
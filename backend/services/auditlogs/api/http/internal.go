@@ -0,0 +1,94 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+)
+
+// InternalAPI is a namespace for the internal API handlers.
+type InternalAPI APIHandler
+
+func (api *InternalAPI) Alive(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+func (api *InternalAPI) Health(c *gin.Context) {
+	err := api.App.HealthCheck(c.Request.Context())
+	if err != nil {
+		rest.RenderError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (api *InternalAPI) DeleteTenant(c *gin.Context) {
+	ctx := c.Request.Context()
+	tenantID := c.Param(pathParamTenantID)
+
+	err := api.App.DeleteTenant(ctx, tenantID)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateAuditLog receives the audit log entries submitted by the
+// emit_auditlog workflow.
+func (api *InternalAPI) CreateAuditLog(c *gin.Context) {
+	var log model.AuditLog
+	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
+		Tenant: c.Param(pathParamTenantID),
+	})
+	c.Request = c.Request.WithContext(ctx)
+
+	err := c.ShouldBindJSON(&log)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	// The identifier is always assigned by the service.
+	log.ID = ""
+	if log.EventTS.IsZero() {
+		log.EventTS = time.Now()
+	}
+	if err = log.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request body"),
+		)
+		return
+	}
+
+	err = api.App.CreateAuditLog(ctx, &log)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.Status(http.StatusCreated)
+}
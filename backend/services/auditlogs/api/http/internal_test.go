@@ -0,0 +1,167 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	mapp "github.com/mendersoftware/mender-server/services/auditlogs/app/mocks"
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+)
+
+var contextMatcher = mock.MatchedBy(func(_ context.Context) bool { return true })
+
+func TestAlive(t *testing.T) {
+	router := NewRouter(new(mapp.App))
+	req, _ := http.NewRequest("GET", URIInternal+URIAlive, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHealth(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("HealthCheck", contextMatcher).Return(errors.New("mongo down")).Once()
+	app.On("HealthCheck", contextMatcher).Return(nil).Once()
+	router := NewRouter(app)
+
+	req, _ := http.NewRequest("GET", URIInternal+URIHealth, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestDeleteTenant(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("DeleteTenant", contextMatcher, "tenant").Return(nil)
+	router := NewRouter(app)
+
+	req, _ := http.NewRequest("DELETE", URIInternal+"/tenants/tenant", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestCreateAuditLog(t *testing.T) {
+	t.Parallel()
+	validLog := map[string]interface{}{
+		"action": "accept_device",
+		"actor": map[string]interface{}{
+			"id":    "user-id",
+			"type":  "user",
+			"email": "user@example.com",
+		},
+		"object": map[string]interface{}{
+			"id":   "device-id",
+			"type": "device",
+		},
+		"time": "2026-01-02T03:04:05Z",
+	}
+	testCases := []struct {
+		Name string
+
+		Body interface{}
+		App  func(t *testing.T) *mapp.App
+
+		Status int
+	}{
+		{
+			Name: "ok",
+
+			Body: validLog,
+			App: func(t *testing.T) *mapp.App {
+				app := new(mapp.App)
+				app.On("CreateAuditLog",
+					mock.MatchedBy(func(ctx context.Context) bool {
+						id := identity.FromContext(ctx)
+						return assert.NotNil(t, id) &&
+							assert.Equal(t, "tenant", id.Tenant)
+					}),
+					mock.MatchedBy(func(log *model.AuditLog) bool {
+						return log.Action == "accept_device" &&
+							log.Actor.Email == "user@example.com" &&
+							log.Object.ID == "device-id"
+					}),
+				).Return(nil)
+				return app
+			},
+			Status: http.StatusCreated,
+		},
+		{
+			Name: "error, malformed body",
+
+			Body:   "not an audit log",
+			App:    func(t *testing.T) *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, invalid body",
+
+			Body: map[string]interface{}{
+				"action": "accept_device",
+			},
+			App:    func(t *testing.T) *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, internal error",
+
+			Body: validLog,
+			App: func(t *testing.T) *mapp.App {
+				app := new(mapp.App)
+				app.On("CreateAuditLog", contextMatcher, mock.Anything).
+					Return(errors.New("mongo down"))
+				return app
+			},
+			Status: http.StatusInternalServerError,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t)
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			body, _ := json.Marshal(tc.Body)
+			req, _ := http.NewRequest("POST",
+				URIInternal+"/tenants/tenant/logs",
+				bytes.NewReader(body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.Status, w.Code)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+)
+
+const (
+	hdrTotalCount = "X-Total-Count"
+
+	paramActorID    = "actor_id"
+	paramActorType  = "actor_type"
+	paramActorEmail = "actor_email"
+	paramObjectID   = "object_id"
+	paramObjectType = "object_type"
+	paramAction     = "action"
+	paramStartDate  = "start_date"
+	paramEndDate    = "end_date"
+	paramSort       = "sort"
+	paramFormat     = "format"
+	paramLimit      = "limit"
+
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// API errors
+var (
+	ErrMissingUserAuthentication = errors.New(
+		"missing or non-user identity in the authorization headers",
+	)
+	errInvalidFormat = errors.New(`format must be either "json" or "csv"`)
+)
+
+var csvHeader = []string{
+	"id", "time", "action",
+	"actor_id", "actor_type", "actor_email",
+	"object_id", "object_type",
+	"change", "meta",
+}
+
+// ManagementAPI is a namespace for the management API handlers.
+type ManagementAPI APIHandler
+
+// parseTime accepts either a RFC3339 timestamp or a Unix timestamp in seconds.
+func parseTime(param, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		t := time.Unix(secs, 0).UTC()
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, rest.ErrQueryParmInvalid(param, value)
+	}
+	return &t, nil
+}
+
+func parseFilter(c *gin.Context) (model.AuditLogFilter, error) {
+	var err error
+	filter := model.AuditLogFilter{
+		ActorID:    c.Query(paramActorID),
+		ActorType:  c.Query(paramActorType),
+		ActorEmail: c.Query(paramActorEmail),
+		ObjectID:   c.Query(paramObjectID),
+		ObjectType: c.Query(paramObjectType),
+		Action:     c.Query(paramAction),
+		Sort:       c.DefaultQuery(paramSort, model.SortDescending),
+	}
+	filter.StartDate, err = parseTime(paramStartDate, c.Query(paramStartDate))
+	if err != nil {
+		return filter, err
+	}
+	filter.EndDate, err = parseTime(paramEndDate, c.Query(paramEndDate))
+	if err != nil {
+		return filter, err
+	}
+	return filter, filter.Validate()
+}
+
+func requireUser(c *gin.Context) bool {
+	idata := identity.FromContext(c.Request.Context())
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return false
+	}
+	return true
+}
+
+// SearchAuditLogs responds to GET /logs
+func (api *ManagementAPI) SearchAuditLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+	if !requireUser(c) {
+		return
+	}
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	filter, err := parseFilter(c)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	filter.Skip = (page - 1) * perPage
+	filter.Limit = perPage
+
+	logs, count, err := api.App.SearchAuditLogs(ctx, filter)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	hints := rest.NewPagingHints().
+		SetPage(page).
+		SetPerPage(perPage).
+		SetTotalCount(count)
+	links, err := rest.MakePagingHeaders(c.Request, hints)
+	if err == nil {
+		for _, link := range links {
+			c.Writer.Header().Add("Link", link)
+		}
+	}
+	c.Writer.Header().Set(hdrTotalCount, strconv.FormatInt(count, 10))
+	c.JSON(http.StatusOK, logs)
+}
+
+// ExportAuditLogs responds to GET /logs/export and streams all matching
+// entries as a JSON array or as CSV.
+func (api *ManagementAPI) ExportAuditLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+	if !requireUser(c) {
+		return
+	}
+
+	format := c.DefaultQuery(paramFormat, formatJSON)
+	if format != formatJSON && format != formatCSV {
+		rest.RenderError(c, http.StatusBadRequest, errInvalidFormat)
+		return
+	}
+	filter, err := parseFilter(c)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	if limit := c.Query(paramLimit); limit != "" {
+		filter.Limit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil || filter.Limit < 1 {
+			rest.RenderError(c, http.StatusBadRequest, rest.ErrQueryParmLimit(paramLimit))
+			return
+		}
+	}
+
+	var (
+		written bool
+		write   func(*model.AuditLog) error
+		finish  func() error
+	)
+	start := func(contentType string) {
+		written = true
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition",
+			`attachment; filename="auditlogs.`+format+`"`)
+		c.Status(http.StatusOK)
+	}
+	switch format {
+	case formatCSV:
+		w := csv.NewWriter(c.Writer)
+		write = func(log *model.AuditLog) error {
+			if !written {
+				start("text/csv")
+				if err := w.Write(csvHeader); err != nil {
+					return err
+				}
+			}
+			var meta string
+			if len(log.MetaData) > 0 {
+				b, _ := json.Marshal(log.MetaData)
+				meta = string(b)
+			}
+			return w.Write([]string{
+				log.ID, log.EventTS.Format(time.RFC3339Nano), log.Action,
+				log.Actor.ID, string(log.Actor.Type), log.Actor.Email,
+				log.Object.ID, log.Object.Type,
+				log.Change, meta,
+			})
+		}
+		finish = func() error {
+			if !written {
+				start("text/csv")
+				_ = w.Write(csvHeader)
+			}
+			w.Flush()
+			return w.Error()
+		}
+	default:
+		enc := json.NewEncoder(c.Writer)
+		write = func(log *model.AuditLog) error {
+			sep := ","
+			if !written {
+				start("application/json")
+				sep = "["
+			}
+			if _, err := c.Writer.WriteString(sep); err != nil {
+				return err
+			}
+			return enc.Encode(log)
+		}
+		finish = func() error {
+			var err error
+			if !written {
+				start("application/json")
+				_, err = c.Writer.WriteString("[]")
+			} else {
+				_, err = c.Writer.WriteString("]")
+			}
+			return err
+		}
+	}
+
+	err = api.App.ExportAuditLogs(ctx, filter, write)
+	if err == nil {
+		err = finish()
+	}
+	if err != nil {
+		if !written {
+			rest.RenderInternalError(c, err)
+			return
+		}
+		// The response is partially written; leave the document
+		// unterminated so the client can tell it is incomplete.
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
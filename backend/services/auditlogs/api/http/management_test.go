@@ -0,0 +1,341 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	mapp "github.com/mendersoftware/mender-server/services/auditlogs/app/mocks"
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+)
+
+func GenerateJWT(id identity.Identity) string {
+	JWT := base64.RawURLEncoding.EncodeToString(
+		[]byte(`{"alg":"HS256","typ":"JWT"}`),
+	)
+	b, _ := json.Marshal(id)
+	JWT = JWT + "." + base64.RawURLEncoding.EncodeToString(b)
+	hash := hmac.New(sha256.New, []byte("hmac-sha256-secret"))
+	JWT = JWT + "." + base64.RawURLEncoding.EncodeToString(
+		hash.Sum([]byte(JWT)),
+	)
+	return JWT
+}
+
+var (
+	userJWT = GenerateJWT(identity.Identity{
+		Subject: "user-id",
+		Tenant:  "tenant",
+		IsUser:  true,
+	})
+	deviceJWT = GenerateJWT(identity.Identity{
+		Subject:  "device-id",
+		Tenant:   "tenant",
+		IsDevice: true,
+	})
+	testLogs = []model.AuditLog{{
+		ID:     "1",
+		Action: "accept_device",
+		Actor: model.Actor{
+			ID:    "user-id",
+			Type:  model.ActorUser,
+			Email: "user@example.com",
+		},
+		Object: model.Object{
+			ID:   "device-id",
+			Type: "device",
+		},
+		MetaData: map[string][]string{"reason": {"manual"}},
+		EventTS:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, {
+		ID:     "2",
+		Action: "login",
+		Actor: model.Actor{
+			ID:   "user-id",
+			Type: model.ActorUser,
+		},
+		Object: model.Object{
+			ID:   "user-id",
+			Type: "user",
+		},
+		Change:  "logged in, with password",
+		EventTS: time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+	}}
+)
+
+func TestSearchAuditLogs(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		Name string
+
+		Query string
+		JWT   string
+		App   func() *mapp.App
+
+		Status     int
+		TotalCount string
+	}{
+		{
+			Name: "ok",
+
+			Query: "?actor_id=user-id&object_type=device&action=accept_device" +
+				"&start_date=2026-01-01T00:00:00Z&sort=asc&page=2&per_page=10",
+			JWT: userJWT,
+			App: func() *mapp.App {
+				app := new(mapp.App)
+				app.On("SearchAuditLogs", contextMatcher, model.AuditLogFilter{
+					ActorID:    "user-id",
+					ObjectType: "device",
+					Action:     "accept_device",
+					StartDate:  &start,
+					Sort:       model.SortAscending,
+					Skip:       10,
+					Limit:      10,
+				}).Return(testLogs, int64(12), nil)
+				return app
+			},
+			Status:     http.StatusOK,
+			TotalCount: "12",
+		},
+		{
+			Name: "ok, unix timestamps",
+
+			Query: "?start_date=1767225600&end_date=1767225600",
+			JWT:   userJWT,
+			App: func() *mapp.App {
+				app := new(mapp.App)
+				app.On("SearchAuditLogs", contextMatcher, model.AuditLogFilter{
+					StartDate: &start,
+					EndDate:   &start,
+					Sort:      model.SortDescending,
+					Limit:     20,
+				}).Return([]model.AuditLog{}, int64(0), nil)
+				return app
+			},
+			Status:     http.StatusOK,
+			TotalCount: "0",
+		},
+		{
+			Name: "error, device token",
+
+			JWT:    deviceJWT,
+			App:    func() *mapp.App { return new(mapp.App) },
+			Status: http.StatusUnauthorized,
+		},
+		{
+			Name: "error, bad start date",
+
+			Query:  "?start_date=yesterday",
+			JWT:    userJWT,
+			App:    func() *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, bad time range",
+
+			Query:  "?start_date=1767225600&end_date=1767225599",
+			JWT:    userJWT,
+			App:    func() *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, bad paging",
+
+			Query:  "?page=0",
+			JWT:    userJWT,
+			App:    func() *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, internal error",
+
+			JWT: userJWT,
+			App: func() *mapp.App {
+				app := new(mapp.App)
+				app.On("SearchAuditLogs", contextMatcher, mock.Anything).
+					Return(nil, int64(-1), errors.New("mongo down"))
+				return app
+			},
+			Status: http.StatusInternalServerError,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App()
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			req, _ := http.NewRequest("GET", URIManagement+URILogs+tc.Query, nil)
+			req.Header.Set("Authorization", "Bearer "+tc.JWT)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.Status == http.StatusOK {
+				assert.Equal(t, tc.TotalCount, w.Header().Get(hdrTotalCount))
+			}
+		})
+	}
+}
+
+func exportLogs(logs []model.AuditLog) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(2).(func(*model.AuditLog) error)
+		for i := range logs {
+			if e := fn(&logs[i]); e != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestExportAuditLogs(t *testing.T) {
+	t.Parallel()
+	fnType := mock.AnythingOfType("func(*model.AuditLog) error")
+	testCases := []struct {
+		Name string
+
+		Query string
+		App   func() *mapp.App
+
+		Status      int
+		ContentType string
+		Body        string
+	}{
+		{
+			Name: "ok, json",
+
+			Query: "?action=accept_device&limit=5",
+			App: func() *mapp.App {
+				app := new(mapp.App)
+				app.On("ExportAuditLogs", contextMatcher, model.AuditLogFilter{
+					Action: "accept_device",
+					Sort:   model.SortDescending,
+					Limit:  5,
+				}, fnType).
+					Run(exportLogs(testLogs)).
+					Return(nil)
+				return app
+			},
+			Status:      http.StatusOK,
+			ContentType: "application/json",
+			Body: func() string {
+				b, _ := json.Marshal(testLogs)
+				return string(b)
+			}(),
+		},
+		{
+			Name: "ok, json empty",
+
+			App: func() *mapp.App {
+				app := new(mapp.App)
+				app.On("ExportAuditLogs", contextMatcher, mock.Anything, fnType).
+					Return(nil)
+				return app
+			},
+			Status:      http.StatusOK,
+			ContentType: "application/json",
+			Body:        "[]",
+		},
+		{
+			Name: "ok, csv",
+
+			Query: "?format=csv",
+			App: func() *mapp.App {
+				app := new(mapp.App)
+				app.On("ExportAuditLogs", contextMatcher, mock.Anything, fnType).
+					Run(exportLogs(testLogs)).
+					Return(nil)
+				return app
+			},
+			Status:      http.StatusOK,
+			ContentType: "text/csv",
+			Body: "id,time,action,actor_id,actor_type,actor_email," +
+				"object_id,object_type,change,meta\n" +
+				"1,2026-01-02T03:04:05Z,accept_device,user-id,user,user@example.com," +
+				"device-id,device,,\"{\"\"reason\"\":[\"\"manual\"\"]}\"\n" +
+				"2,2026-01-02T03:00:00Z,login,user-id,user,," +
+				"user-id,user,\"logged in, with password\",\n",
+		},
+		{
+			Name: "error, invalid format",
+
+			Query:  "?format=xml",
+			App:    func() *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, invalid limit",
+
+			Query:  "?limit=-1",
+			App:    func() *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, internal error",
+
+			App: func() *mapp.App {
+				app := new(mapp.App)
+				app.On("ExportAuditLogs", contextMatcher, mock.Anything, fnType).
+					Return(errors.New("mongo down"))
+				return app
+			},
+			Status: http.StatusInternalServerError,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App()
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			req, _ := http.NewRequest("GET", URIManagement+URILogsExport+tc.Query, nil)
+			req.Header.Set("Authorization", "Bearer "+userJWT)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.Status != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tc.ContentType, w.Header().Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(
+				w.Header().Get("Content-Disposition"), "attachment",
+			))
+			if tc.ContentType == "application/json" {
+				assert.JSONEq(t, tc.Body, w.Body.String())
+			} else {
+				assert.Equal(t, tc.Body, w.Body.String())
+			}
+		})
+	}
+}
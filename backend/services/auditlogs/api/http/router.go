@@ -0,0 +1,105 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/requestsize"
+	"github.com/mendersoftware/mender-server/pkg/routing"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/app"
+	aconfig "github.com/mendersoftware/mender-server/services/auditlogs/config"
+)
+
+// API URL used by the HTTP router
+const (
+	pathParamTenantID = "tenant_id"
+
+	URIInternal   = "/api/internal/v1/auditlogs"
+	URIManagement = "/api/management/v1/auditlogs"
+
+	URITenant     = "/tenants/:tenant_id"
+	URITenantLogs = "/tenants/:tenant_id/logs"
+
+	URILogs       = "/logs"
+	URILogsExport = "/logs/export"
+
+	URIAlive  = "/alive"
+	URIHealth = "/health"
+)
+
+type APIHandler struct {
+	App app.App
+}
+
+func NewAPIHandler(app app.App) *APIHandler {
+	return &APIHandler{
+		App: app,
+	}
+}
+
+type Config struct {
+	MaxRequestSize int64
+}
+
+func NewConfig() *Config {
+	return &Config{
+		MaxRequestSize: aconfig.SettingMaxRequestSizeDefault,
+	}
+}
+
+type Option func(c *Config)
+
+func SetMaxRequestSize(size int64) Option {
+	return func(c *Config) {
+		c.MaxRequestSize = size
+	}
+}
+
+// NewRouter initializes a new gin.Engine as a http.Handler
+func NewRouter(app app.App, options ...Option) http.Handler {
+	config := NewConfig()
+	for _, option := range options {
+		if option != nil {
+			option(config)
+		}
+	}
+
+	router := routing.NewGinRouter()
+	router.Use(requestsize.Middleware(config.MaxRequestSize))
+
+	apiHandler := NewAPIHandler(app)
+
+	intrnlAPI := (*InternalAPI)(apiHandler)
+	intrnlGrp := router.Group(URIInternal)
+
+	intrnlGrp.GET(URIAlive, intrnlAPI.Alive)
+	intrnlGrp.GET(URIHealth, intrnlAPI.Health)
+
+	intrnlGrp.DELETE(URITenant, intrnlAPI.DeleteTenant)
+	intrnlGrp.POST(URITenantLogs, intrnlAPI.CreateAuditLog)
+
+	mgmtAPI := (*ManagementAPI)(apiHandler)
+	mgmtGrp := router.Group(URIManagement)
+
+	// identity middleware for collecting JWT claims into request Context.
+	mgmtGrp.Use(identity.Middleware())
+	mgmtGrp.GET(URILogs, mgmtAPI.SearchAuditLogs)
+	mgmtGrp.GET(URILogsExport, mgmtAPI.ExportAuditLogs)
+
+	return router
+}
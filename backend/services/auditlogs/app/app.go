@@ -0,0 +1,122 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+	"github.com/mendersoftware/mender-server/services/auditlogs/store"
+)
+
+const (
+	// DefaultExportLimit caps the number of entries in a single export.
+	DefaultExportLimit = 100000
+)
+
+// App interface describes app objects
+//
+//nolint:lll
+//go:generate ../../../utils/mockgen.sh
+type App interface {
+	HealthCheck(ctx context.Context) error
+
+	DeleteTenant(ctx context.Context, tenantID string) error
+
+	// CreateAuditLog stores an audit log entry for the tenant in the context.
+	CreateAuditLog(ctx context.Context, log *model.AuditLog) error
+	// SearchAuditLogs returns a page of entries and the total number of matches.
+	SearchAuditLogs(ctx context.Context, filter model.AuditLogFilter) ([]model.AuditLog, int64, error)
+	// ExportAuditLogs calls fn for every matching entry up to the export limit.
+	ExportAuditLogs(ctx context.Context, filter model.AuditLogFilter, fn func(*model.AuditLog) error) error
+}
+
+// app is an app object
+type app struct {
+	store store.DataStore
+	Config
+}
+
+type Config struct {
+	// ExportLimit is the maximum number of entries a single export
+	// may return.
+	ExportLimit int64
+}
+
+// New initializes a new auditlogs App
+func New(ds store.DataStore, config ...Config) App {
+	conf := Config{
+		ExportLimit: DefaultExportLimit,
+	}
+	for _, cfgIn := range config {
+		if cfgIn.ExportLimit > 0 {
+			conf.ExportLimit = cfgIn.ExportLimit
+		}
+	}
+	return &app{
+		store:  ds,
+		Config: conf,
+	}
+}
+
+// HealthCheck performs a health check and returns an error if it fails
+func (a *app) HealthCheck(ctx context.Context) error {
+	return a.store.Ping(ctx)
+}
+
+func (a *app) DeleteTenant(ctx context.Context, tenantID string) error {
+	return a.store.DeleteTenant(ctx, tenantID)
+}
+
+func (a *app) CreateAuditLog(ctx context.Context, log *model.AuditLog) error {
+	if log.ID == "" {
+		log.ID = uuid.NewString()
+	}
+	if log.EventTS.IsZero() {
+		log.EventTS = time.Now()
+	}
+	log.EventTS = log.EventTS.UTC()
+	return a.store.InsertAuditLog(ctx, log)
+}
+
+func (a *app) SearchAuditLogs(
+	ctx context.Context,
+	filter model.AuditLogFilter,
+) ([]model.AuditLog, int64, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, -1, err
+	}
+	return a.store.FindAuditLogs(ctx, filter)
+}
+
+func (a *app) ExportAuditLogs(
+	ctx context.Context,
+	filter model.AuditLogFilter,
+	fn func(*model.AuditLog) error,
+) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	filter.Skip = 0
+	if filter.Limit <= 0 || filter.Limit > a.ExportLimit {
+		filter.Limit = a.ExportLimit
+	}
+	err := a.store.IterateAuditLogs(ctx, filter, fn)
+	return errors.Wrap(err, "failed to export audit logs")
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+	"github.com/mendersoftware/mender-server/services/auditlogs/store/mocks"
+)
+
+func TestHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+	ds.On("Ping", ctx).Return(errors.New("connection refused"))
+
+	err := New(ds).HealthCheck(ctx)
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestDeleteTenant(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+	ds.On("DeleteTenant", ctx, "tenant").Return(nil)
+
+	assert.NoError(t, New(ds).DeleteTenant(ctx, "tenant"))
+}
+
+func TestCreateAuditLog(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+	ds.On("InsertAuditLog", ctx, mock.MatchedBy(func(log *model.AuditLog) bool {
+		return log.ID != "" &&
+			!log.EventTS.IsZero() &&
+			log.EventTS.Location() == time.UTC
+	})).Return(nil)
+
+	err := New(ds).CreateAuditLog(ctx, &model.AuditLog{
+		Action: "login",
+		Actor: model.Actor{
+			ID:   "user",
+			Type: model.ActorUser,
+		},
+		Object: model.Object{
+			ID:   "user",
+			Type: "user",
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestSearchAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+	filter := model.AuditLogFilter{ActorID: "user", Limit: 20}
+	logs := []model.AuditLog{{ID: "1"}}
+	ds.On("FindAuditLogs", ctx, filter).Return(logs, int64(1), nil)
+
+	app := New(ds)
+	res, count, err := app.SearchAuditLogs(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, logs, res)
+	assert.Equal(t, int64(1), count)
+
+	_, _, err = app.SearchAuditLogs(ctx, model.AuditLogFilter{
+		StartDate: &now,
+		EndDate:   &before,
+	})
+	assert.ErrorIs(t, err, model.ErrInvalidTimeRange)
+}
+
+func TestExportAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+
+	ds.On("IterateAuditLogs", ctx,
+		model.AuditLogFilter{Action: "login", Limit: 10},
+		mock.AnythingOfType("func(*model.AuditLog) error"),
+	).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*model.AuditLog) error)
+		_ = fn(&model.AuditLog{ID: "1"})
+	}).Return(nil).Once()
+	ds.On("IterateAuditLogs", ctx,
+		model.AuditLogFilter{Action: "logout", Limit: 10},
+		mock.AnythingOfType("func(*model.AuditLog) error"),
+	).Return(errors.New("internal error")).Once()
+
+	app := New(ds, Config{ExportLimit: 10})
+	var ids []string
+	err := app.ExportAuditLogs(ctx,
+		model.AuditLogFilter{Action: "login", Skip: 20, Limit: 100},
+		func(log *model.AuditLog) error {
+			ids = append(ids, log.ID)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, ids)
+
+	err = app.ExportAuditLogs(ctx,
+		model.AuditLogFilter{Action: "logout"},
+		func(log *model.AuditLog) error { return nil },
+	)
+	assert.EqualError(t, err, "failed to export audit logs: internal error")
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// ActorType describes who performed the audited action.
+type ActorType string
+
+const (
+	ActorUser   ActorType = "user"
+	ActorDevice ActorType = "device"
+	ActorSystem ActorType = "system"
+)
+
+// Actor identifies the user, device or system component which performed
+// the audited action.
+type Actor struct {
+	ID             string    `json:"id" bson:"id"`
+	Type           ActorType `json:"type" bson:"type"`
+	Email          string    `json:"email,omitempty" bson:"email,omitempty"`
+	DeviceIdentity string    `json:"identity_data,omitempty" bson:"identity_data,omitempty"`
+}
+
+func (a Actor) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ID, validation.Required),
+		validation.Field(&a.Type,
+			validation.Required,
+			validation.In(ActorUser, ActorDevice, ActorSystem),
+		),
+		validation.Field(&a.Email, is.EmailFormat),
+	)
+}
+
+// Object identifies the resource the audited action was performed on.
+// The object type is not restricted since new services may introduce
+// resources of their own.
+type Object struct {
+	ID   string `json:"id" bson:"id"`
+	Type string `json:"type" bson:"type"`
+}
+
+func (o Object) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.ID, validation.Required),
+		validation.Field(&o.Type, validation.Required),
+	)
+}
+
+// AuditLog is a single entry in the audit trail.
+type AuditLog struct {
+	ID       string              `json:"id" bson:"_id"`
+	Action   string              `json:"action" bson:"action"`
+	Actor    Actor               `json:"actor" bson:"actor"`
+	Object   Object              `json:"object" bson:"object"`
+	Change   string              `json:"change,omitempty" bson:"change,omitempty"`
+	MetaData map[string][]string `json:"meta,omitempty" bson:"meta,omitempty"`
+	EventTS  time.Time           `json:"time" bson:"time"`
+}
+
+func (l AuditLog) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Action, validation.Required),
+		validation.Field(&l.Actor),
+		validation.Field(&l.Object),
+		validation.Field(&l.EventTS, validation.Required),
+	)
+}
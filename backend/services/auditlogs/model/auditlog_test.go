@@ -0,0 +1,185 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		AuditLog AuditLog
+		Error    bool
+	}{
+		{
+			Name: "ok",
+			AuditLog: AuditLog{
+				Action: "login",
+				Actor: Actor{
+					ID:    "user-id",
+					Type:  ActorUser,
+					Email: "user@example.com",
+				},
+				Object: Object{
+					ID:   "user-id",
+					Type: "user",
+				},
+				EventTS: time.Now(),
+			},
+		},
+		{
+			Name: "error, missing action",
+			AuditLog: AuditLog{
+				Actor: Actor{
+					ID:   "user-id",
+					Type: ActorUser,
+				},
+				Object: Object{
+					ID:   "device-id",
+					Type: "device",
+				},
+				EventTS: time.Now(),
+			},
+			Error: true,
+		},
+		{
+			Name: "error, invalid actor type",
+			AuditLog: AuditLog{
+				Action: "accept_device",
+				Actor: Actor{
+					ID:   "user-id",
+					Type: "robot",
+				},
+				Object: Object{
+					ID:   "device-id",
+					Type: "device",
+				},
+				EventTS: time.Now(),
+			},
+			Error: true,
+		},
+		{
+			Name: "error, invalid actor email",
+			AuditLog: AuditLog{
+				Action: "accept_device",
+				Actor: Actor{
+					ID:    "user-id",
+					Type:  ActorUser,
+					Email: "not an email",
+				},
+				Object: Object{
+					ID:   "device-id",
+					Type: "device",
+				},
+				EventTS: time.Now(),
+			},
+			Error: true,
+		},
+		{
+			Name: "error, missing object",
+			AuditLog: AuditLog{
+				Action: "accept_device",
+				Actor: Actor{
+					ID:   "user-id",
+					Type: ActorUser,
+				},
+				EventTS: time.Now(),
+			},
+			Error: true,
+		},
+		{
+			Name: "error, missing time",
+			AuditLog: AuditLog{
+				Action: "accept_device",
+				Actor: Actor{
+					ID:   "user-id",
+					Type: ActorUser,
+				},
+				Object: Object{
+					ID:   "device-id",
+					Type: "device",
+				},
+			},
+			Error: true,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.AuditLog.Validate()
+			if tc.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuditLogFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+
+	testCases := []struct {
+		Name   string
+		Filter AuditLogFilter
+		Error  error
+	}{
+		{
+			Name: "ok, empty",
+		},
+		{
+			Name: "ok",
+			Filter: AuditLogFilter{
+				ActorID:   "user-id",
+				Action:    "login",
+				StartDate: &yesterday,
+				EndDate:   &now,
+				Sort:      SortAscending,
+				Limit:     20,
+			},
+		},
+		{
+			Name: "error, invalid time range",
+			Filter: AuditLogFilter{
+				StartDate: &now,
+				EndDate:   &yesterday,
+			},
+			Error: ErrInvalidTimeRange,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.Filter.Validate()
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+	assert.Error(t, AuditLogFilter{Sort: "random"}.Validate())
+}
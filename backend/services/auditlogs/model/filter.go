@@ -0,0 +1,64 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+var ErrInvalidTimeRange = errors.New("end_date must not be before start_date")
+
+// AuditLogFilter holds the search criteria for audit log queries.
+// Empty fields match all entries.
+type AuditLogFilter struct {
+	ActorID    string
+	ActorType  string
+	ActorEmail string
+	ObjectID   string
+	ObjectType string
+	Action     string
+
+	StartDate *time.Time
+	EndDate   *time.Time
+
+	// Sort is the ordering by event time, defaults to descending.
+	Sort string
+
+	Skip  int64
+	Limit int64
+}
+
+func (f AuditLogFilter) Validate() error {
+	err := validation.ValidateStruct(&f,
+		validation.Field(&f.Sort, validation.In(SortAscending, SortDescending)),
+		validation.Field(&f.Skip, validation.Min(int64(0))),
+		validation.Field(&f.Limit, validation.Min(int64(0))),
+	)
+	if err != nil {
+		return err
+	}
+	if f.StartDate != nil && f.EndDate != nil && f.EndDate.Before(*f.StartDate) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package store
+
+import (
+	"context"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+)
+
+// DataStore interface for DataStore services
+//
+//nolint:lll - skip line length check for interface declaration.
+//go:generate ../../../utils/mockgen.sh
+type DataStore interface {
+	Ping(ctx context.Context) error
+	Close(ctx context.Context) error
+
+	// Migrate applies the migrations up to the given version and
+	// (re)applies the configured retention period.
+	Migrate(ctx context.Context, version string, automigrate bool) error
+
+	// MigrateLatest calls Migrate with the latest schema version.
+	MigrateLatest(ctx context.Context) error
+
+	// DeleteTenant removes all the data for a given tenant
+	DeleteTenant(ctx context.Context, tenantID string) error
+
+	// InsertAuditLog stores a new audit log entry for the tenant in the context.
+	InsertAuditLog(ctx context.Context, log *model.AuditLog) error
+
+	// FindAuditLogs returns the page of audit log entries matching the
+	// filter together with the total number of matching entries.
+	FindAuditLogs(ctx context.Context, filter model.AuditLogFilter) ([]model.AuditLog, int64, error)
+
+	// IterateAuditLogs calls fn for every audit log entry matching the
+	// filter; iteration stops at the first error returned by fn.
+	IterateAuditLogs(ctx context.Context, filter model.AuditLogFilter, fn func(*model.AuditLog) error) error
+}
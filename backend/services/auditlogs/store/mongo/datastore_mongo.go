@@ -0,0 +1,331 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+)
+
+const (
+	// CollAuditLogs refers to the collection name for audit log entries
+	CollAuditLogs = "auditlogs"
+	// fields
+	fieldID         = "_id"
+	fieldAction     = "action"
+	fieldActorID    = "actor.id"
+	fieldActorType  = "actor.type"
+	fieldActorEmail = "actor.email"
+	fieldObjectID   = "object.id"
+	fieldObjectType = "object.type"
+	fieldTime       = "time"
+
+	// indexRetention is the name of the TTL index enforcing the
+	// retention period.
+	indexRetention = "time_ttl"
+
+	codeNamespaceNotFound = 26
+)
+
+type MongoStoreConfig struct {
+	// MongoURL holds the URL to the MongoDB server.
+	MongoURL *url.URL
+	// TLSConfig holds optional tls configuration options for connecting
+	// to the MongoDB server.
+	TLSConfig *tls.Config
+	// Username holds the user id credential for authenticating with the
+	// MongoDB server.
+	Username string
+	// Password holds the password credential for authenticating with the
+	// MongoDB server.
+	Password string
+
+	// DbName contains the name of the auditlogs database.
+	DbName string
+
+	// Retention is the time audit log entries are kept; zero or
+	// negative values keep entries forever.
+	Retention time.Duration
+}
+
+// newClient returns a mongo client
+func newClient(ctx context.Context, config MongoStoreConfig) (*mongo.Client, error) {
+	clientOptions := mopts.Client()
+	if config.MongoURL == nil {
+		return nil, errors.New("mongo: missing URL")
+	}
+	clientOptions.ApplyURI(config.MongoURL.String())
+
+	if config.Username != "" {
+		credentials := mopts.Credential{
+			Username: config.Username,
+		}
+		if config.Password != "" {
+			credentials.Password = config.Password
+			credentials.PasswordSet = true
+		}
+		clientOptions.SetAuth(credentials)
+	}
+
+	if config.TLSConfig != nil {
+		clientOptions.SetTLSConfig(config.TLSConfig)
+	}
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to connect with server")
+	}
+
+	// Validate connection
+	if err = client.Ping(ctx, nil); err != nil {
+		return nil, errors.Wrap(err, "mongo: error reaching mongo server")
+	}
+
+	return client, nil
+}
+
+// MongoStore is the data storage service
+type MongoStore struct {
+	// client holds the reference to the client used to communicate with the
+	// mongodb server.
+	client *mongo.Client
+
+	config MongoStoreConfig
+}
+
+// NewMongoStore connects to the database and returns the mongo data store
+func NewMongoStore(ctx context.Context, config MongoStoreConfig) (*MongoStore, error) {
+	dbClient, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return NewMongoStoreWithClient(dbClient, config), nil
+}
+
+// NewMongoStoreWithClient returns the mongo data store using an existing client
+func NewMongoStoreWithClient(client *mongo.Client, config MongoStoreConfig) *MongoStore {
+	if config.DbName == "" {
+		config.DbName = DbName
+	}
+	return &MongoStore{
+		client: client,
+		config: config,
+	}
+}
+
+func (db *MongoStore) Database(ctx context.Context, opt ...*mopts.DatabaseOptions) *mongo.Database {
+	return db.client.Database(mstore.DbFromContext(ctx, db.config.DbName), opt...)
+}
+
+// Ping verifies the connection to the database
+func (db *MongoStore) Ping(ctx context.Context) error {
+	res := db.client.
+		Database(db.config.DbName).
+		RunCommand(ctx, bson.M{"ping": 1})
+	return res.Err()
+}
+
+// Close disconnects the client
+func (db *MongoStore) Close(ctx context.Context) error {
+	err := db.client.Disconnect(ctx)
+	return err
+}
+
+func (db *MongoStore) InsertAuditLog(ctx context.Context, log *model.AuditLog) error {
+	if err := log.Validate(); err != nil {
+		return err
+	}
+	collLogs := db.Database(ctx).Collection(CollAuditLogs)
+
+	_, err := collLogs.InsertOne(ctx, mstore.WithTenantID(ctx, log))
+	return errors.Wrap(err, "mongo: failed to store audit log")
+}
+
+func filterToQuery(ctx context.Context, filter model.AuditLogFilter) bson.D {
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	query := bson.D{{Key: mstore.FieldTenantID, Value: tenantID}}
+	for _, field := range []struct {
+		key   string
+		value string
+	}{
+		{key: fieldActorID, value: filter.ActorID},
+		{key: fieldActorType, value: filter.ActorType},
+		{key: fieldActorEmail, value: filter.ActorEmail},
+		{key: fieldObjectID, value: filter.ObjectID},
+		{key: fieldObjectType, value: filter.ObjectType},
+		{key: fieldAction, value: filter.Action},
+	} {
+		if field.value != "" {
+			query = append(query, bson.E{Key: field.key, Value: field.value})
+		}
+	}
+	if filter.StartDate != nil || filter.EndDate != nil {
+		timeRange := bson.D{}
+		if filter.StartDate != nil {
+			timeRange = append(timeRange, bson.E{Key: "$gte", Value: *filter.StartDate})
+		}
+		if filter.EndDate != nil {
+			timeRange = append(timeRange, bson.E{Key: "$lte", Value: *filter.EndDate})
+		}
+		query = append(query, bson.E{Key: fieldTime, Value: timeRange})
+	}
+	return query
+}
+
+func findOptions(filter model.AuditLogFilter) *mopts.FindOptions {
+	order := -1
+	if filter.Sort == model.SortAscending {
+		order = 1
+	}
+	opts := mopts.Find().
+		SetSort(bson.D{
+			{Key: fieldTime, Value: order},
+			{Key: fieldID, Value: order},
+		}).
+		SetProjection(bson.D{{Key: mstore.FieldTenantID, Value: 0}})
+	if filter.Skip > 0 {
+		opts.SetSkip(filter.Skip)
+	}
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+	return opts
+}
+
+func (db *MongoStore) FindAuditLogs(
+	ctx context.Context,
+	filter model.AuditLogFilter,
+) ([]model.AuditLog, int64, error) {
+	collLogs := db.Database(ctx).Collection(CollAuditLogs)
+	query := filterToQuery(ctx, filter)
+
+	count, err := collLogs.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to count audit logs")
+	}
+
+	cur, err := collLogs.Find(ctx, query, findOptions(filter))
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to query audit logs")
+	}
+	logs := []model.AuditLog{}
+	if err = cur.All(ctx, &logs); err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to decode audit logs")
+	}
+	return logs, count, nil
+}
+
+func (db *MongoStore) IterateAuditLogs(
+	ctx context.Context,
+	filter model.AuditLogFilter,
+	fn func(*model.AuditLog) error,
+) error {
+	collLogs := db.Database(ctx).Collection(CollAuditLogs)
+
+	cur, err := collLogs.Find(ctx, filterToQuery(ctx, filter), findOptions(filter))
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to query audit logs")
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var log model.AuditLog
+		if err = cur.Decode(&log); err != nil {
+			return errors.Wrap(err, "mongo: failed to decode audit log")
+		}
+		if err = fn(&log); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(cur.Err(), "mongo: failed to iterate audit logs")
+}
+
+func (db *MongoStore) DeleteTenant(ctx context.Context, tenantID string) error {
+	collLogs := db.Database(ctx).Collection(CollAuditLogs)
+	_, err := collLogs.DeleteMany(ctx, bson.D{{Key: mstore.FieldTenantID, Value: tenantID}})
+	return errors.Wrap(err, "mongo: failed to delete tenant audit logs")
+}
+
+// ensureRetention creates, updates or drops the TTL index on the event
+// time to match the configured retention period.
+func (db *MongoStore) ensureRetention(ctx context.Context) error {
+	collLogs := db.Database(ctx).Collection(CollAuditLogs)
+	var (
+		indexes []struct {
+			Name               string `bson:"name"`
+			ExpireAfterSeconds *int64 `bson:"expireAfterSeconds"`
+		}
+		current *int64
+		exists  bool
+	)
+	cur, err := collLogs.Indexes().List(ctx)
+	if err == nil {
+		err = cur.All(ctx, &indexes)
+	}
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == codeNamespaceNotFound {
+		// The collection does not exist yet.
+		err = nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to list indexes")
+	}
+	for _, idx := range indexes {
+		if idx.Name == indexRetention {
+			exists = true
+			current = idx.ExpireAfterSeconds
+			break
+		}
+	}
+
+	expire := int64(db.config.Retention / time.Second)
+	switch {
+	case expire <= 0:
+		if exists {
+			_, err = collLogs.Indexes().DropOne(ctx, indexRetention)
+		}
+	case !exists:
+		_, err = collLogs.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: fieldTime, Value: 1}},
+			Options: mopts.Index().
+				SetName(indexRetention).
+				SetExpireAfterSeconds(int32(expire)),
+		})
+	case current == nil || *current != expire:
+		err = db.Database(ctx).RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: CollAuditLogs},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: indexRetention},
+				{Key: "expireAfterSeconds", Value: expire},
+			}},
+		}).Err()
+	}
+	return errors.Wrap(err, "mongo: failed to apply retention period")
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/auditlogs/model"
+)
+
+func newTestStore(t *testing.T, retention time.Duration) *MongoStore {
+	if testing.Short() {
+		t.Skip("skipping mongo test in short mode")
+	}
+	db.Wipe()
+	ds := NewMongoStoreWithClient(db.Client(), MongoStoreConfig{
+		DbName:    DbName,
+		Retention: retention,
+	})
+	require.NoError(t, ds.MigrateLatest(context.Background()))
+	return ds
+}
+
+func makeAuditLog(action, actorID, objectID string, ts time.Time) *model.AuditLog {
+	return &model.AuditLog{
+		ID:     uuid.NewString(),
+		Action: action,
+		Actor: model.Actor{
+			ID:    actorID,
+			Type:  model.ActorUser,
+			Email: actorID + "@example.com",
+		},
+		Object: model.Object{
+			ID:   objectID,
+			Type: "device",
+		},
+		EventTS: ts,
+	}
+}
+
+func TestFindAuditLogs(t *testing.T) {
+	ds := newTestStore(t, 0)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant1",
+	})
+	ctxOther := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant2",
+	})
+	logs := []*model.AuditLog{
+		makeAuditLog("accept_device", "alice", "dev1", now.Add(-3*time.Hour)),
+		makeAuditLog("reject_device", "alice", "dev2", now.Add(-2*time.Hour)),
+		makeAuditLog("accept_device", "bob", "dev3", now.Add(-time.Hour)),
+	}
+	for _, log := range logs {
+		require.NoError(t, ds.InsertAuditLog(ctx, log))
+	}
+	require.NoError(t, ds.InsertAuditLog(ctxOther,
+		makeAuditLog("accept_device", "alice", "dev1", now)))
+
+	assert.Error(t, ds.InsertAuditLog(ctx, &model.AuditLog{ID: uuid.NewString()}))
+
+	start := now.Add(-150 * time.Minute)
+	testCases := []struct {
+		Name   string
+		Filter model.AuditLogFilter
+		IDs    []string
+		Count  int64
+	}{
+		{
+			Name:  "all, newest first",
+			IDs:   []string{logs[2].ID, logs[1].ID, logs[0].ID},
+			Count: 3,
+		},
+		{
+			Name:   "ascending with limit",
+			Filter: model.AuditLogFilter{Sort: model.SortAscending, Limit: 2},
+			IDs:    []string{logs[0].ID, logs[1].ID},
+			Count:  3,
+		},
+		{
+			Name:   "by actor",
+			Filter: model.AuditLogFilter{ActorID: "alice"},
+			IDs:    []string{logs[1].ID, logs[0].ID},
+			Count:  2,
+		},
+		{
+			Name:   "by action and object",
+			Filter: model.AuditLogFilter{Action: "accept_device", ObjectID: "dev3"},
+			IDs:    []string{logs[2].ID},
+			Count:  1,
+		},
+		{
+			Name:   "by actor email",
+			Filter: model.AuditLogFilter{ActorEmail: "bob@example.com"},
+			IDs:    []string{logs[2].ID},
+			Count:  1,
+		},
+		{
+			Name:   "by time range",
+			Filter: model.AuditLogFilter{StartDate: &start, Skip: 1},
+			IDs:    []string{logs[1].ID},
+			Count:  2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			res, count, err := ds.FindAuditLogs(ctx, tc.Filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Count, count)
+			ids := make([]string, len(res))
+			for i, log := range res {
+				ids[i] = log.ID
+			}
+			assert.Equal(t, tc.IDs, ids)
+
+			ids = ids[:0]
+			err = ds.IterateAuditLogs(ctx, tc.Filter, func(log *model.AuditLog) error {
+				ids = append(ids, log.ID)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.IDs, ids)
+		})
+	}
+
+	require.NoError(t, ds.DeleteTenant(ctx, "tenant1"))
+	_, count, err := ds.FindAuditLogs(ctx, model.AuditLogFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	_, count, err = ds.FindAuditLogs(ctxOther, model.AuditLogFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestEnsureRetention(t *testing.T) {
+	ds := newTestStore(t, time.Hour)
+	ctx := context.Background()
+
+	getExpire := func() (int64, bool) {
+		cur, err := ds.Database(ctx).
+			Collection(CollAuditLogs).
+			Indexes().
+			List(ctx)
+		require.NoError(t, err)
+		var indexes []bson.M
+		require.NoError(t, cur.All(ctx, &indexes))
+		for _, idx := range indexes {
+			if idx["name"] == indexRetention {
+				switch v := idx["expireAfterSeconds"].(type) {
+				case int32:
+					return int64(v), true
+				case int64:
+					return v, true
+				}
+			}
+		}
+		return 0, false
+	}
+	expire, ok := getExpire()
+	require.True(t, ok)
+	assert.Equal(t, int64(3600), expire)
+
+	ds.config.Retention = 2 * time.Hour
+	require.NoError(t, ds.MigrateLatest(ctx))
+	expire, ok = getExpire()
+	require.True(t, ok)
+	assert.Equal(t, int64(7200), expire)
+
+	ds.config.Retention = 0
+	require.NoError(t, ds.MigrateLatest(ctx))
+	_, ok = getExpire()
+	assert.False(t, ok)
+}
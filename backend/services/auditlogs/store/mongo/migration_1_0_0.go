@@ -0,0 +1,59 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+)
+
+type migration_1_0_0 struct {
+	client *mongo.Client
+	db     string
+}
+
+// Up creates the indexes backing the audit log search filters.
+func (m *migration_1_0_0) Up(from migrate.Version) error {
+	ctx := context.Background()
+	indexes := make([]mongo.IndexModel, 0, 3)
+	for _, field := range []string{"", fieldActorID, fieldObjectID} {
+		keys := bson.D{{Key: mstore.FieldTenantID, Value: 1}}
+		name := mstore.FieldTenantID
+		if field != "" {
+			keys = append(keys, bson.E{Key: field, Value: 1})
+			name += "_" + field
+		}
+		keys = append(keys, bson.E{Key: fieldTime, Value: -1})
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    keys,
+			Options: mopts.Index().SetName(name + "_" + fieldTime),
+		})
+	}
+	_, err := m.client.Database(m.db).
+		Collection(CollAuditLogs).
+		Indexes().
+		CreateMany(ctx, indexes)
+	return err
+}
+
+func (m *migration_1_0_0) Version() migrate.Version {
+	return migrate.MakeVersion(1, 0, 0)
+}
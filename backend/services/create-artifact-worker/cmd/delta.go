@@ -0,0 +1,371 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mendersoftware/mender-server/services/create-artifact-worker/client"
+	"github.com/mendersoftware/mender-server/services/create-artifact-worker/config"
+	mlog "github.com/mendersoftware/mender-server/services/create-artifact-worker/log"
+)
+
+const (
+	argSourceArtifactName   = "source-artifact-name"
+	argGetSourceArtifactUri = "get-source-artifact-uri"
+	argGetTargetArtifactUri = "get-target-artifact-uri"
+
+	// deltaUpdateType is the update module applying the VCDIFF patch
+	// on top of the currently installed rootfs-image.
+	deltaUpdateType = "rootfs-image-delta"
+)
+
+type deltaArgs struct {
+	Depends        map[string]string `json:"depends"`
+	Provides       map[string]string `json:"provides"`
+	ClearsProvides []string          `json:"clears_provides"`
+}
+
+var deltaCmd = &cobra.Command{
+	Use:   "delta",
+	Short: "Generate a binary delta update between two rootfs-image artifacts.",
+	Long: "\nBesides command line args, supports the following env vars:\n\n" +
+		"CREATE_ARTIFACT_SKIPVERIFY skip ssl verification (default: false)\n" +
+		"CREATE_ARTIFACT_WORKDIR working dir for processing (default: /var)\n" +
+		"CREATE_ARTIFACT_DEPLOYMENTS_URL internal deployments service url\n",
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := NewDeltaCmd(cmd, args)
+		if err != nil {
+			mlog.Error(err.Error())
+			os.Exit(1)
+		}
+
+		err = c.Run()
+		if err != nil {
+			mlog.Error(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	deltaCmd.Flags().String(argArtifactName, "", "artifact name (the target artifact name)")
+	_ = deltaCmd.MarkFlagRequired(argArtifactName)
+
+	deltaCmd.Flags().String(argArtifactId, "", "artifact id")
+	_ = deltaCmd.MarkFlagRequired(argArtifactId)
+
+	deltaCmd.Flags().String(
+		argSourceArtifactName,
+		"",
+		"name of the artifact the delta applies to",
+	)
+	_ = deltaCmd.MarkFlagRequired(argSourceArtifactName)
+
+	deltaCmd.Flags().String(
+		argGetSourceArtifactUri,
+		"",
+		"pre-signed s3 url to the source artifact (GET)",
+	)
+	_ = deltaCmd.MarkFlagRequired(argGetSourceArtifactUri)
+
+	deltaCmd.Flags().String(
+		argGetTargetArtifactUri,
+		"",
+		"pre-signed s3 url to the target artifact (GET)",
+	)
+	_ = deltaCmd.MarkFlagRequired(argGetTargetArtifactUri)
+
+	deltaCmd.Flags().String(argTenantId, "", "tenant id")
+	_ = deltaCmd.MarkFlagRequired(argTenantId)
+
+	deltaCmd.Flags().String(argDeviceType, "", "device type")
+	_ = deltaCmd.MarkFlagRequired(argDeviceType)
+
+	// json string of specific args: depends, provides and clears provides
+	deltaCmd.Flags().String(
+		argArgs,
+		"",
+		"specific args in json form: {\"depends\":{<KEY>:<VALUE>},"+
+			" \"provides\":{<KEY>:<VALUE>},"+
+			" \"clears_provides\":[<PATTERN>]}",
+	)
+
+	deltaCmd.Flags().String(argDescription, "", "artifact description")
+}
+
+type DeltaCmd struct {
+	DeploymentsUrl string
+	SkipVerify     bool
+	Workdir        string
+
+	ArtifactName         string
+	SourceArtifactName   string
+	Description          string
+	DeviceTypes          []string
+	ArtifactId           string
+	GetSourceArtifactUri string
+	GetTargetArtifactUri string
+	Args                 string
+	TenantId             string
+
+	// type-specific args
+	Depends        map[string]string
+	Provides       map[string]string
+	ClearsProvides []string
+}
+
+func NewDeltaCmd(cmd *cobra.Command, args []string) (*DeltaCmd, error) {
+	c := &DeltaCmd{}
+
+	if err := c.init(cmd); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *DeltaCmd) init(cmd *cobra.Command) error {
+	c.DeploymentsUrl = viper.GetString(config.CfgDeploymentsUrl)
+	c.SkipVerify = viper.GetBool(config.CfgSkipVerify)
+	c.Workdir = viper.GetString(config.CfgWorkDir)
+
+	flags := map[string]*string{
+		argArtifactName:         &c.ArtifactName,
+		argSourceArtifactName:   &c.SourceArtifactName,
+		argDescription:          &c.Description,
+		argArtifactId:           &c.ArtifactId,
+		argGetSourceArtifactUri: &c.GetSourceArtifactUri,
+		argGetTargetArtifactUri: &c.GetTargetArtifactUri,
+		argTenantId:             &c.TenantId,
+		argArgs:                 &c.Args,
+	}
+	for name, value := range flags {
+		arg, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return err
+		}
+		*value = arg
+	}
+
+	arg, err := cmd.Flags().GetString(argDeviceType)
+	if err != nil {
+		return err
+	}
+	c.DeviceTypes = strings.Split(arg, ",")
+
+	return nil
+}
+
+func (c *DeltaCmd) Validate() error {
+	if err := config.ValidAbsPath(c.Workdir); err != nil {
+		return errors.Wrap(err, "invalid workdir")
+	}
+
+	if c.ArtifactName == c.SourceArtifactName {
+		return errors.New("source and target artifact names must differ")
+	}
+
+	if c.Args != "" {
+		var args deltaArgs
+		err := json.Unmarshal([]byte(c.Args), &args)
+		if err != nil {
+			return errors.Wrap(err, "can't parse 'args'")
+		}
+		c.Depends = args.Depends
+		c.Provides = args.Provides
+		c.ClearsProvides = args.ClearsProvides
+	}
+
+	return nil
+}
+
+func (c *DeltaCmd) Run() error {
+	mlog.Info("running delta artifact generation:\n%s", c.dumpArgs())
+	mlog.Info("config:\n%s", config.Dump())
+
+	cd, err := client.NewDeployments(c.DeploymentsUrl, c.SkipVerify)
+	if err != nil {
+		return errors.New("failed to configure 'deployments' client")
+	}
+
+	cs3 := client.NewStorage(c.SkipVerify)
+
+	ctx := context.Background()
+
+	mlog.Verbose("creating temp dir at", c.Workdir)
+
+	workDir, err := os.MkdirTemp(c.Workdir, "delta")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temp dir under workdir %s", c.Workdir)
+	}
+	defer func() {
+		err := os.RemoveAll(workDir)
+		if err != nil {
+			mlog.Error("failed to remove temp working dir %s: %v", workDir, err.Error())
+		}
+	}()
+
+	sourceImage, err := c.fetchRootfs(ctx, cs3, c.GetSourceArtifactUri, workDir, "source")
+	if err != nil {
+		return err
+	}
+	targetImage, err := c.fetchRootfs(ctx, cs3, c.GetTargetArtifactUri, workDir, "target")
+	if err != nil {
+		return err
+	}
+
+	patch := filepath.Join(workDir, c.ArtifactId+".vcdiff")
+
+	mlog.Verbose("computing binary delta %s", patch)
+
+	err = runTool("xdelta3", "-e", "-9", "-f", "-s", sourceImage, targetImage, patch)
+	if err != nil {
+		return err
+	}
+
+	// make the filename unique by naming it after the artifact
+	outfile := filepath.Join(workDir, c.ArtifactId+"-generated")
+
+	mlog.Verbose("generating output artifact %s", outfile)
+
+	err = runTool("mender-artifact", c.writeArgs(patch, outfile)...)
+	if err != nil {
+		return err
+	}
+
+	mlog.Verbose("uploading generated artifact")
+	err = cd.UploadArtifactInternal(ctx, outfile, c.ArtifactId, c.TenantId, c.Description)
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload generated artifact")
+	}
+
+	return nil
+}
+
+// fetchRootfs downloads the artifact and extracts its rootfs-image
+// payload, returning the path to the filesystem image.
+func (c *DeltaCmd) fetchRootfs(
+	ctx context.Context,
+	cs3 client.Storage,
+	uri, workDir, name string,
+) (string, error) {
+	artifact := filepath.Join(workDir, name+".mender")
+
+	mlog.Verbose("downloading %s artifact to %s", name, artifact)
+
+	err := cs3.Download(ctx, uri, artifact)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to download %s artifact at %s", name, uri)
+	}
+
+	payloadDir := filepath.Join(workDir, name)
+	err = runTool("mender-artifact", "dump", "--files", payloadDir, artifact)
+	if err != nil {
+		return "", err
+	}
+
+	files, err := os.ReadDir(payloadDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %s artifact payload", name)
+	}
+	var images []string
+	for _, f := range files {
+		if f.Type().IsRegular() {
+			images = append(images, filepath.Join(payloadDir, f.Name()))
+		}
+	}
+	if len(images) != 1 {
+		return "", errors.Errorf(
+			"%s artifact must contain exactly one payload file, found %d",
+			name, len(images),
+		)
+	}
+
+	return images[0], os.Remove(artifact)
+}
+
+// writeArgs returns the mender-artifact arguments writing the delta
+// artifact with the given patch as payload.
+func (c *DeltaCmd) writeArgs(patch, outfile string) []string {
+	args := []string{
+		"write", "module-image",
+		"-T", deltaUpdateType,
+		"-n", c.ArtifactName,
+		"-o", outfile,
+		"--artifact-name-depends", c.SourceArtifactName,
+		"--no-default-software-version",
+		"--no-default-clears-provides",
+	}
+	for _, deviceType := range c.DeviceTypes {
+		args = append(args, "-t", deviceType)
+	}
+	for _, key := range sortedKeys(c.Depends) {
+		args = append(args, "--depends", key+":"+c.Depends[key])
+	}
+	for _, key := range sortedKeys(c.Provides) {
+		args = append(args, "--provides", key+":"+c.Provides[key])
+	}
+	for _, pattern := range c.ClearsProvides {
+		args = append(args, "--clears-provides", pattern)
+	}
+	return append(args, "-f", patch)
+}
+
+func (c *DeltaCmd) dumpArgs() string {
+	return dumpArg(argArtifactName, c.ArtifactName) +
+		dumpArg(argSourceArtifactName, c.SourceArtifactName) +
+		dumpArg(argDescription, c.Description) +
+		dumpArg(argArtifactId, c.ArtifactId) +
+		dumpArg(argDeviceType, strings.Join(c.DeviceTypes, ",")) +
+		dumpArg(argTenantId, c.TenantId) +
+		dumpArg(argGetSourceArtifactUri, c.GetSourceArtifactUri) +
+		dumpArg(argGetTargetArtifactUri, c.GetTargetArtifactUri) +
+		dumpArg(argArgs, c.Args)
+}
+
+func runTool(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+
+	std, err := cmd.CombinedOutput()
+	mlog.Info(string(std))
+	if err != nil {
+		return errors.Wrapf(err, "%s exited with error %s", name, std)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/mendersoftware/mender-server/services/deployments/app"
 	dconfig "github.com/mendersoftware/mender-server/services/deployments/config"
 	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
 	"github.com/mendersoftware/mender-server/services/deployments/store"
 	"github.com/mendersoftware/mender-server/services/deployments/utils"
 )
@@ -75,6 +76,7 @@ const (
 	ParamTenantID     = "tenant_id"
 	ParamName         = "name"
 	ParamTag          = "tag"
+	ParamChannel      = "channel"
 	ParamDescription  = "description"
 	ParamPage         = "page"
 	ParamPerPage      = "per_page"
@@ -149,6 +151,10 @@ type Config struct {
 	// related to releases; helpful in performing long-running maintenance and data
 	// migrations on the artifacts and releases collections.
 	DisableNewReleasesFeature bool
+
+	// LocalStorage is the storage serving the signed requests generated
+	// by the filesystem storage backend.
+	LocalStorage storage.ObjectStorage
 }
 
 func NewConfig() *Config {
@@ -211,6 +217,11 @@ func (conf *Config) SetMaxRequestSize(size int64) *Config {
 	return conf
 }
 
+func (conf *Config) SetLocalStorage(objStore storage.ObjectStorage) *Config {
+	conf.LocalStorage = objStore
+	return conf
+}
+
 type DeploymentsApiHandlers struct {
 	view   RESTView
 	store  store.DataStore
@@ -250,6 +261,9 @@ func NewDeploymentsApiHandlers(
 		if c.MaxRequestSize > 0 {
 			conf.MaxRequestSize = c.MaxRequestSize
 		}
+		if c.LocalStorage != nil {
+			conf.LocalStorage = c.LocalStorage
+		}
 		conf.DisableNewReleasesFeature = c.DisableNewReleasesFeature
 		conf.EnableDirectUpload = c.EnableDirectUpload
 		conf.EnableDirectUploadSkipVerify = c.EnableDirectUploadSkipVerify
@@ -296,6 +310,7 @@ func getReleaseOrImageFilter(r *http.Request, version listReleasesVersion,
 		for i, t := range filter.Tags {
 			filter.Tags[i] = strings.ToLower(t)
 		}
+		filter.Channel = q.Get(ParamChannel)
 	}
 
 	if paginated {
@@ -829,7 +844,9 @@ func (d *DeploymentsApiHandlers) newImageWithContext(
 	default:
 		d.view.RenderInternalError(c, err)
 		return
-	case app.ErrModelArtifactNotUnique:
+	case app.ErrModelArtifactNotUnique,
+		app.ErrModelArtifactNotSigned, app.ErrModelArtifactSignatureInvalid,
+		app.ErrModelArtifactPayloadNotVerified:
 		d.view.RenderError(c, cause, http.StatusUnprocessableEntity)
 		return
 	case app.ErrModelParsingArtifactFailed:
@@ -1113,12 +1130,12 @@ func (d *DeploymentsApiHandlers) createDeployment(
 	}
 
 	id, err := d.app.CreateDeployment(ctx, constructor)
-	switch err {
+	switch errors.Cause(err) {
 	case nil:
 		location := fmt.Sprintf("%s/%s", ApiUrlManagement+ApiUrlManagementDeployments, id)
 		c.Writer.Header().Add("Location", location)
 		c.Status(http.StatusCreated)
-	case app.ErrNoArtifact:
+	case app.ErrNoArtifact, app.ErrReleaseChannelRestricted:
 		d.view.RenderError(c, err, http.StatusUnprocessableEntity)
 	case app.ErrNoDevices:
 		d.view.RenderError(c, err, http.StatusBadRequest)
@@ -1145,6 +1162,47 @@ func (d *DeploymentsApiHandlers) DeployToGroup(c *gin.Context) {
 	d.createDeployment(c, ctx, group)
 }
 
+func (d *DeploymentsApiHandlers) previewDeployment(c *gin.Context, group string) {
+	constructor, err := d.getDeploymentConstructorFromBody(c, group)
+	if err != nil {
+		d.view.RenderError(
+			c,
+			errors.Wrap(err, "Validating request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	preview, err := d.app.PreviewDeployment(c.Request.Context(), constructor)
+	switch errors.Cause(err) {
+	case nil:
+		d.view.RenderSuccessGet(c, preview)
+	case app.ErrNoArtifact:
+		d.view.RenderError(c, err, http.StatusUnprocessableEntity)
+	case app.ErrNoDevices:
+		d.view.RenderError(c, err, http.StatusBadRequest)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+// PreviewDeployment evaluates the deployment compatibility with the
+// targeted devices without creating it.
+func (d *DeploymentsApiHandlers) PreviewDeployment(c *gin.Context) {
+	d.previewDeployment(c, "")
+}
+
+// PreviewDeploymentToGroup evaluates the compatibility of a deployment to
+// the group devices without creating it.
+func (d *DeploymentsApiHandlers) PreviewDeploymentToGroup(c *gin.Context) {
+	group := c.Param("name")
+	if len(group) < 1 {
+		d.view.RenderError(c, ErrMissingGroupName, http.StatusBadRequest)
+		return
+	}
+	d.previewDeployment(c, group)
+}
+
 // parseDeviceConfigurationDeploymentPathParams parses expected params
 // and check if the params are not empty
 func parseDeviceConfigurationDeploymentPathParams(c *gin.Context) (string, string, string, error) {
@@ -1387,6 +1445,50 @@ func (d *DeploymentsApiHandlers) AbortDeployment(c *gin.Context) {
 	d.view.RenderEmptySuccessResponse(c)
 }
 
+// RollbackDeployment creates the deployments reinstalling on the devices
+// of the deployment the artifact they ran before it.
+func (d *DeploymentsApiHandlers) RollbackDeployment(c *gin.Context) {
+	id := c.Param("id")
+	if !govalidator.IsUUID(id) {
+		d.view.RenderError(c, ErrIDNotUUID, http.StatusBadRequest)
+		return
+	}
+
+	// the request body is optional
+	var request model.DeploymentRollbackRequest
+	if c.Request.Body != nil {
+		err := c.ShouldBindJSON(&request)
+		if err != nil && err != io.EOF {
+			d.view.RenderError(c,
+				errors.Wrap(err, "malformed request body"),
+				http.StatusBadRequest,
+			)
+			return
+		}
+	}
+	if err := request.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	rollback, err := d.app.RollbackDeployment(c.Request.Context(), id, request)
+	switch cause := errors.Cause(err); cause {
+	case nil:
+		c.JSON(http.StatusCreated, rollback)
+	case app.ErrRollbackIncomplete:
+		// some deployments were created: report them along with the error
+		c.JSON(http.StatusInternalServerError, rollback)
+	case app.ErrModelDeploymentNotFound:
+		d.view.RenderErrorNotFound(c)
+	case app.ErrRollbackConfigurationDeployment:
+		d.view.RenderError(c, cause, http.StatusBadRequest)
+	case app.ErrRollbackNoDevices, app.ErrReleaseChannelRestricted:
+		d.view.RenderError(c, err, http.StatusUnprocessableEntity)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
 func (d *DeploymentsApiHandlers) GetDeploymentForDevice(c *gin.Context) {
 	var (
 		installed *model.InstalledDeviceDeployment
@@ -1970,13 +2072,37 @@ func (d *DeploymentsApiHandlers) listDeviceDeployments(ctx context.Context,
 		}
 	}
 
+	lq, page, perPage, err := parseListDeviceDeploymentsQuery(c, did, IDs)
+	if err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	deps, totalCount, err := d.app.GetDeviceDeploymentListForDevice(ctx, lq)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	err = addDeviceDeploymentsPagingHeaders(c, page, perPage,
+		lq.Skip+len(deps), totalCount)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	d.view.RenderSuccessGet(c, deps)
+}
+
+// parseListDeviceDeploymentsQuery parses the paging and status parameters
+// of the device deployments lists
+func parseListDeviceDeploymentsQuery(c *gin.Context, did string, IDs []string) (
+	store.ListQueryDeviceDeployments, int64, int64, error) {
 	page, perPage, err := rest.ParsePagingParameters(c.Request)
 	if err == nil && perPage > MaximumPerPageListDeviceDeployments {
 		err = rest.ErrQueryParmLimit(ParamPerPage)
 	}
 	if err != nil {
-		d.view.RenderError(c, err, http.StatusBadRequest)
-		return
+		return store.ListQueryDeviceDeployments{}, 0, 0, err
 	}
 
 	lq := store.ListQueryDeviceDeployments{
@@ -1989,35 +2115,55 @@ func (d *DeploymentsApiHandlers) listDeviceDeployments(ctx context.Context,
 		lq.Status = &status
 	}
 	if err = lq.Validate(); err != nil {
-		d.view.RenderError(c, err, http.StatusBadRequest)
-		return
+		return store.ListQueryDeviceDeployments{}, 0, 0, err
 	}
+	return lq, page, perPage, nil
+}
 
-	deps, totalCount, err := d.app.GetDeviceDeploymentListForDevice(ctx, lq)
-	if err != nil {
-		d.view.RenderInternalError(c, err)
-		return
-	}
+// addDeviceDeploymentsPagingHeaders sets the total count and link headers,
+// end is the index following the last item of the page
+func addDeviceDeploymentsPagingHeaders(c *gin.Context, page, perPage int64,
+	end, totalCount int) error {
 	c.Writer.Header().Add(hdrTotalCount, strconv.FormatInt(int64(totalCount), 10))
 
-	hasNext := totalCount > lq.Skip+len(deps)
-
 	hints := rest.NewPagingHints().
 		SetPage(page).
 		SetPerPage(perPage).
-		SetHasNext(hasNext).
+		SetHasNext(totalCount > end).
 		SetTotalCount(int64(totalCount))
 
 	links, err := rest.MakePagingHeaders(c.Request, hints)
 	if err != nil {
-		rest.RenderInternalError(c, err)
-		return
+		return err
 	}
 	for _, l := range links {
 		c.Writer.Header().Add(hdrLink, l)
 	}
+	return nil
+}
 
-	d.view.RenderSuccessGet(c, deps)
+func (d *DeploymentsApiHandlers) GetDeviceDeploymentTimeline(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	lq, page, perPage, err := parseListDeviceDeploymentsQuery(c, c.Param("id"), nil)
+	if err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	timeline, totalCount, err := d.app.GetDeviceDeploymentTimeline(ctx, lq)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	err = addDeviceDeploymentsPagingHeaders(c, page, perPage,
+		lq.Skip+len(timeline), totalCount)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	d.view.RenderSuccessGet(c, timeline)
 }
 
 func (d *DeploymentsApiHandlers) AbortDeviceDeploymentsInternal(c *gin.Context) {
@@ -2066,6 +2212,16 @@ func (d *DeploymentsApiHandlers) ProvisionTenantsHandler(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+func (d *DeploymentsApiHandlers) DeleteTenantHandler(c *gin.Context) {
+	err := d.app.DeleteTenant(c.Request.Context(), c.Param("tenant"))
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (d *DeploymentsApiHandlers) DeploymentsPerTenantHandler(
 	c *gin.Context,
 ) {
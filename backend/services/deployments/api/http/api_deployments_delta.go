@@ -0,0 +1,59 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+// GenerateDeltaImage is the handler requesting the generation of a binary
+// delta artifact between two existing rootfs-image artifacts.
+func (d *DeploymentsApiHandlers) GenerateDeltaImage(c *gin.Context) {
+	var request model.GenerateDeltaImageRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	if err := request.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	imgID, err := d.app.GenerateDeltaImage(c.Request.Context(), &request)
+	cause := errors.Cause(err)
+	switch cause {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessPost(c, imgID)
+	case app.ErrImageMetaNotFound:
+		d.view.RenderErrorNotFound(c)
+	case app.ErrModelArtifactNotUnique:
+		d.view.RenderError(c, cause, http.StatusUnprocessableEntity)
+	case app.ErrModelDeltaUnsupportedArtifact,
+		app.ErrModelDeltaSameArtifactName,
+		app.ErrModelDeltaIncompatibleDeviceTypes:
+		d.view.RenderError(c, cause, http.StatusBadRequest)
+	}
+}
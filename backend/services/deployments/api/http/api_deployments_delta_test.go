@@ -0,0 +1,133 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestGenerateDeltaImage(t *testing.T) {
+	t.Parallel()
+
+	const artifactID = "0c13a0e6-6b63-475d-8260-ee42a590e8ff"
+	validRequest := model.GenerateDeltaImageRequest{
+		SourceArtifactID: "b5e5e6b1-0a3e-4a4c-9b0e-0c2b3a4d5e6f",
+		TargetArtifactID: "c6f6f7c2-1b4f-4b5d-8c1f-1d3c4b5e6f70",
+	}
+	testCases := map[string]struct {
+		body     interface{}
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			body:       validRequest,
+			statusCode: http.StatusCreated,
+		},
+		"error, malformed body": {
+			body:       "foo",
+			statusCode: http.StatusBadRequest,
+			error: "malformed request body: json: cannot unmarshal string " +
+				"into Go value of type model.GenerateDeltaImageRequest",
+		},
+		"error, invalid request": {
+			body: model.GenerateDeltaImageRequest{
+				SourceArtifactID: validRequest.SourceArtifactID,
+			},
+			statusCode: http.StatusBadRequest,
+			error:      "target_artifact_id: cannot be blank.",
+		},
+		"error, artifact not found": {
+			body:       validRequest,
+			appError:   app.ErrImageMetaNotFound,
+			statusCode: http.StatusNotFound,
+			error:      "Resource not found",
+		},
+		"error, unsupported artifact": {
+			body:       validRequest,
+			appError:   app.ErrModelDeltaUnsupportedArtifact,
+			statusCode: http.StatusBadRequest,
+			error:      app.ErrModelDeltaUnsupportedArtifact.Error(),
+		},
+		"error, delta exists": {
+			body:       validRequest,
+			appError:   app.ErrModelArtifactNotUnique,
+			statusCode: http.StatusUnprocessableEntity,
+			error:      app.ErrModelArtifactNotUnique.Error(),
+		},
+		"error, internal": {
+			body:       validRequest,
+			appError:   errors.New("failed to start workflow: generate_delta"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.appError != nil || tc.statusCode == http.StatusCreated {
+				app.On("GenerateDeltaImage",
+					h.ContextMatcher(),
+					&validRequest,
+				).Return(artifactID, tc.appError)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementArtifactsGenerateDelta, d.GenerateDeltaImage)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost" + ApiUrlManagementArtifactsGenerateDelta,
+				Body:   tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				var body struct {
+					Error string `json:"error"`
+				}
+				if assert.NoError(t, json.Unmarshal(recorded.Recorder.Body.Bytes(), &body)) {
+					assert.Equal(t, tc.error, body.Error)
+				}
+			} else {
+				assert.Equal(t,
+					ApiUrlManagementArtifactsGenerateDelta+"/"+artifactID,
+					recorded.Recorder.Header().Get("Location"),
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	mt "github.com/mendersoftware/mender-server/pkg/testing"
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	deployments_testing "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestGetDeviceDeploymentTimeline(t *testing.T) {
+	t.Parallel()
+
+	const deviceID = "device"
+	created := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	timeline := []model.DeviceDeploymentTimelineItem{{
+		Id:             "id",
+		DeploymentId:   "deployment",
+		DeploymentName: "deployment",
+		ArtifactName:   "artifact",
+		Status:         model.DeviceDeploymentStatusPending,
+		Created:        &created,
+		States: []model.DeviceDeploymentTimelineState{{
+			Status:  model.DeviceDeploymentStatusPending,
+			Started: created,
+		}},
+	}}
+
+	testCases := []struct {
+		Name  string
+		Query string
+
+		AppQuery    *store.ListQueryDeviceDeployments
+		AppTimeline []model.DeviceDeploymentTimelineItem
+		AppError    error
+
+		ResponseCode int
+		Body         interface{}
+	}{
+		{
+			Name: "ok",
+			AppQuery: &store.ListQueryDeviceDeployments{
+				DeviceID: deviceID,
+				Limit:    MaximumPerPageListDeviceDeployments,
+			},
+			AppTimeline:  timeline,
+			ResponseCode: http.StatusOK,
+			Body:         timeline,
+		},
+		{
+			Name:  "ok, status and paging",
+			Query: "?status=finished&page=2&per_page=5",
+			AppQuery: &store.ListQueryDeviceDeployments{
+				DeviceID: deviceID,
+				Skip:     5,
+				Limit:    5,
+				Status:   func(s string) *string { return &s }("finished"),
+			},
+			AppTimeline:  []model.DeviceDeploymentTimelineItem{},
+			ResponseCode: http.StatusOK,
+			Body:         []model.DeviceDeploymentTimelineItem{},
+		},
+		{
+			Name:         "error, per page too large",
+			Query:        "?per_page=21",
+			ResponseCode: http.StatusBadRequest,
+			Body: deployments_testing.RestError(
+				"invalid per_page query: value must be a non-zero positive integer",
+			),
+		},
+		{
+			Name:         "error, invalid status",
+			Query:        "?status=dummy",
+			ResponseCode: http.StatusBadRequest,
+			Body:         deployments_testing.RestError("status: must be a valid value"),
+		},
+		{
+			Name: "error, app error",
+			AppQuery: &store.ListQueryDeviceDeployments{
+				DeviceID: deviceID,
+				Limit:    MaximumPerPageListDeviceDeployments,
+			},
+			AppError:     errors.New("some error"),
+			ResponseCode: http.StatusInternalServerError,
+			Body:         deployments_testing.RestError("internal error"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.AppQuery != nil {
+				app.On("GetDeviceDeploymentTimeline",
+					mock.MatchedBy(func(_ interface{}) bool { return true }),
+					*tc.AppQuery,
+				).Return(tc.AppTimeline, len(tc.AppTimeline), tc.AppError)
+			}
+
+			restView := new(view.RESTView)
+			d := NewDeploymentsApiHandlers(nil, restView, app)
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementDeploymentsDeviceTimeline,
+				d.GetDeviceDeploymentTimeline)
+			url := "http://localhost" + strings.ReplaceAll(
+				ApiUrlManagementDeploymentsDeviceTimeline, ":id", deviceID,
+			) + tc.Query
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path:   url,
+			})
+
+			checker := mt.NewJSONResponse(tc.ResponseCode,
+				map[string]string{
+					"Content-Type": "application/json; charset=utf-8",
+				},
+				tc.Body)
+
+			recorded := restutil.RunRequest(t, router, req)
+
+			mt.CheckHTTPResponse(t, checker, recorded)
+		})
+	}
+}
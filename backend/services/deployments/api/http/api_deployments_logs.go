@@ -0,0 +1,105 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+const (
+	ParamDeploymentLogDeploymentID = "deployment_id"
+	ParamDeploymentLogDeviceID     = "device_id"
+	ParamDeploymentLogLevel        = "level"
+	ParamDeploymentLogText         = "q"
+)
+
+// parseDeploymentLogQuery parses the paging and search parameters of the
+// deployment logs
+func parseDeploymentLogQuery(c *gin.Context) (
+	store.DeploymentLogQuery, int64, int64, error) {
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err == nil && perPage > MaximumPerPage {
+		err = rest.ErrQueryParmLimit(ParamPerPage)
+	}
+	if err != nil {
+		return store.DeploymentLogQuery{}, 0, 0, err
+	}
+
+	values := c.Request.URL.Query()
+	query := store.DeploymentLogQuery{
+		Skip:         int((page - 1) * perPage),
+		Limit:        int(perPage),
+		DeploymentID: values.Get(ParamDeploymentLogDeploymentID),
+		DeviceID:     values.Get(ParamDeploymentLogDeviceID),
+		Text:         values.Get(ParamDeploymentLogText),
+	}
+	for _, level := range values[ParamDeploymentLogLevel] {
+		if level = model.NormalizeLogLevel(level); level != "" {
+			query.Levels = append(query.Levels, level)
+		}
+	}
+	if err = query.Validate(); err != nil {
+		return store.DeploymentLogQuery{}, 0, 0, err
+	}
+	return query, page, perPage, nil
+}
+
+// SearchDeploymentLogs searches the lines of the device deployment logs of
+// a deployment, or of all the deployments.
+func (d *DeploymentsApiHandlers) SearchDeploymentLogs(c *gin.Context) {
+	query, page, perPage, err := parseDeploymentLogQuery(c)
+	if err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	entries, totalCount, err := d.app.SearchDeploymentLogs(c.Request.Context(), query)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	err = addDeviceDeploymentsPagingHeaders(c, page, perPage,
+		query.Skip+len(entries), totalCount)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+
+	d.view.RenderSuccessGet(c, entries)
+}
+
+// ClusterDeploymentLogs groups the identical lines of the device deployment
+// logs, the ones logged by the most devices first.
+func (d *DeploymentsApiHandlers) ClusterDeploymentLogs(c *gin.Context) {
+	query, _, _, err := parseDeploymentLogQuery(c)
+	if err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	clusters, err := d.app.ClusterDeploymentLogs(c.Request.Context(), query)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+
+	d.view.RenderSuccessGet(c, clusters)
+}
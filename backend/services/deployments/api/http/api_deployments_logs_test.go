@@ -0,0 +1,228 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	mt "github.com/mendersoftware/mender-server/pkg/testing"
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	deployments_testing "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestSearchDeploymentLogs(t *testing.T) {
+	t.Parallel()
+
+	entries := []model.DeploymentLogEntry{{
+		DeviceID:     "device",
+		DeploymentID: "deployment",
+		Timestamp:    time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		Level:        model.LogLevelError,
+		Message:      "No space left on device",
+	}}
+
+	testCases := []struct {
+		Name  string
+		Query string
+
+		AppQuery   *store.DeploymentLogQuery
+		AppEntries []model.DeploymentLogEntry
+		AppError   error
+
+		ResponseCode int
+		Body         interface{}
+	}{
+		{
+			Name: "ok",
+			AppQuery: &store.DeploymentLogQuery{
+				Limit: DefaultPerPage,
+			},
+			AppEntries:   entries,
+			ResponseCode: http.StatusOK,
+			Body:         entries,
+		},
+		{
+			Name: "ok, search and paging",
+			Query: "?deployment_id=deployment&level=ERROR&level=crit" +
+				"&q=no+space&page=2&per_page=50",
+			AppQuery: &store.DeploymentLogQuery{
+				Skip:         50,
+				Limit:        50,
+				DeploymentID: "deployment",
+				Levels:       []string{model.LogLevelError, model.LogLevelCritical},
+				Text:         "no space",
+			},
+			AppEntries:   []model.DeploymentLogEntry{},
+			ResponseCode: http.StatusOK,
+			Body:         []model.DeploymentLogEntry{},
+		},
+		{
+			Name:         "error, per page too large",
+			Query:        "?per_page=501",
+			ResponseCode: http.StatusBadRequest,
+			Body: deployments_testing.RestError(
+				`parameter "per_page" above limit (max: 500)`,
+			),
+		},
+		{
+			Name: "error, app error",
+			AppQuery: &store.DeploymentLogQuery{
+				Limit: DefaultPerPage,
+			},
+			AppError:     errors.New("some error"),
+			ResponseCode: http.StatusInternalServerError,
+			Body:         deployments_testing.RestError("internal error"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.AppQuery != nil {
+				app.On("SearchDeploymentLogs",
+					mock.MatchedBy(func(_ interface{}) bool { return true }),
+					*tc.AppQuery,
+				).Return(tc.AppEntries, len(tc.AppEntries), tc.AppError)
+			}
+
+			restView := new(view.RESTView)
+			d := NewDeploymentsApiHandlers(nil, restView, app)
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementDeploymentsLogs, d.SearchDeploymentLogs)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path:   "http://localhost" + ApiUrlManagementDeploymentsLogs + tc.Query,
+			})
+
+			checker := mt.NewJSONResponse(tc.ResponseCode,
+				map[string]string{
+					"Content-Type": "application/json; charset=utf-8",
+				},
+				tc.Body)
+
+			recorded := restutil.RunRequest(t, router, req)
+
+			mt.CheckHTTPResponse(t, checker, recorded)
+		})
+	}
+}
+
+func TestClusterDeploymentLogs(t *testing.T) {
+	t.Parallel()
+
+	clusters := &model.DeploymentLogClusters{
+		DeviceCount: 300,
+		Clusters: []model.DeploymentLogCluster{{
+			Message:     "No space left on device",
+			Level:       model.LogLevelError,
+			Count:       560,
+			DeviceCount: 280,
+			Devices:     []string{"device"},
+			FirstSeen:   time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+			LastSeen:    time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	testCases := []struct {
+		Name  string
+		Query string
+
+		AppQuery    *store.DeploymentLogQuery
+		AppClusters *model.DeploymentLogClusters
+		AppError    error
+
+		ResponseCode int
+		Body         interface{}
+	}{
+		{
+			Name:  "ok",
+			Query: "?deployment_id=deployment&per_page=10",
+			AppQuery: &store.DeploymentLogQuery{
+				Limit:        10,
+				DeploymentID: "deployment",
+			},
+			AppClusters:  clusters,
+			ResponseCode: http.StatusOK,
+			Body:         clusters,
+		},
+		{
+			Name:         "error, invalid paging",
+			Query:        "?page=0",
+			ResponseCode: http.StatusBadRequest,
+			Body: deployments_testing.RestError(
+				"invalid page query: value must be a non-zero positive integer",
+			),
+		},
+		{
+			Name: "error, app error",
+			AppQuery: &store.DeploymentLogQuery{
+				Limit: DefaultPerPage,
+			},
+			AppError:     errors.New("some error"),
+			ResponseCode: http.StatusInternalServerError,
+			Body:         deployments_testing.RestError("internal error"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.AppQuery != nil {
+				app.On("ClusterDeploymentLogs",
+					mock.MatchedBy(func(_ interface{}) bool { return true }),
+					*tc.AppQuery,
+				).Return(tc.AppClusters, tc.AppError)
+			}
+
+			restView := new(view.RESTView)
+			d := NewDeploymentsApiHandlers(nil, restView, app)
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementDeploymentsLogsClusters, d.ClusterDeploymentLogs)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path: "http://localhost" + ApiUrlManagementDeploymentsLogsClusters +
+					tc.Query,
+			})
+
+			checker := mt.NewJSONResponse(tc.ResponseCode,
+				map[string]string{
+					"Content-Type": "application/json; charset=utf-8",
+				},
+				tc.Body)
+
+			recorded := restutil.RunRequest(t, router, req)
+
+			mt.CheckHTTPResponse(t, checker, recorded)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	mt "github.com/mendersoftware/mender-server/pkg/testing"
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	deployments_testing "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestRollbackDeployment(t *testing.T) {
+	t.Parallel()
+
+	const deploymentID = "a2e3f5b4-8d7c-4a0e-9e1f-3b2c1d0e9f8a"
+	rollback := &model.DeploymentRollback{
+		DeploymentID: deploymentID,
+		Deployments: []model.DeploymentRollbackItem{{
+			ID:           "f826484e-1157-4109-af21-304e6d711560",
+			ArtifactName: "v1",
+			Devices:      []string{"device-1"},
+		}},
+		SkippedDevices: []model.DeploymentRollbackSkippedDevice{{
+			DeviceID: "device-2",
+			Reason:   model.RollbackSkipNoPrevious,
+		}},
+	}
+
+	testCases := []struct {
+		Name         string
+		DeploymentID string
+		Body         interface{}
+
+		AppRequest  *model.DeploymentRollbackRequest
+		AppRollback *model.DeploymentRollback
+		AppError    error
+
+		ResponseCode int
+		ResponseBody interface{}
+	}{{
+		Name:         "ok",
+		DeploymentID: deploymentID,
+		AppRequest:   &model.DeploymentRollbackRequest{},
+		AppRollback:  rollback,
+		ResponseCode: http.StatusCreated,
+		ResponseBody: rollback,
+	}, {
+		Name:         "ok, with name",
+		DeploymentID: deploymentID,
+		Body:         map[string]string{"name": "undo"},
+		AppRequest:   &model.DeploymentRollbackRequest{Name: "undo"},
+		AppRollback:  rollback,
+		ResponseCode: http.StatusCreated,
+		ResponseBody: rollback,
+	}, {
+		Name:         "error, invalid deployment ID",
+		DeploymentID: "dummy",
+		ResponseCode: http.StatusBadRequest,
+		ResponseBody: deployments_testing.RestError(ErrIDNotUUID.Error()),
+	}, {
+		Name:         "error, malformed body",
+		DeploymentID: deploymentID,
+		Body:         []string{"name"},
+		ResponseCode: http.StatusBadRequest,
+		ResponseBody: deployments_testing.RestError(
+			"malformed request body: json: cannot unmarshal array into Go value " +
+				"of type model.DeploymentRollbackRequest",
+		),
+	}, {
+		Name:         "error, deployment not found",
+		DeploymentID: deploymentID,
+		AppRequest:   &model.DeploymentRollbackRequest{},
+		AppError:     app.ErrModelDeploymentNotFound,
+		ResponseCode: http.StatusNotFound,
+		ResponseBody: deployments_testing.RestError(view.ErrNotFound.Error()),
+	}, {
+		Name:         "error, no devices to roll back",
+		DeploymentID: deploymentID,
+		AppRequest:   &model.DeploymentRollbackRequest{},
+		AppError:     app.ErrRollbackNoDevices,
+		ResponseCode: http.StatusUnprocessableEntity,
+		ResponseBody: deployments_testing.RestError(app.ErrRollbackNoDevices.Error()),
+	}, {
+		Name:         "error, rollback incomplete",
+		DeploymentID: deploymentID,
+		AppRequest:   &model.DeploymentRollbackRequest{},
+		AppRollback:  rollback,
+		AppError:     app.ErrRollbackIncomplete,
+		ResponseCode: http.StatusInternalServerError,
+		ResponseBody: rollback,
+	}, {
+		Name:         "error, internal error",
+		DeploymentID: deploymentID,
+		AppRequest:   &model.DeploymentRollbackRequest{},
+		AppError:     errors.New("some error"),
+		ResponseCode: http.StatusInternalServerError,
+		ResponseBody: deployments_testing.RestError("internal error"),
+	}}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.AppRequest != nil {
+				app.On("RollbackDeployment",
+					mock.MatchedBy(func(_ interface{}) bool { return true }),
+					tc.DeploymentID,
+					*tc.AppRequest,
+				).Return(tc.AppRollback, tc.AppError)
+			}
+
+			restView := new(view.RESTView)
+			d := NewDeploymentsApiHandlers(nil, restView, app)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementDeploymentsRollback, d.RollbackDeployment)
+			url := "http://localhost" + strings.ReplaceAll(
+				ApiUrlManagementDeploymentsRollback, ":id", tc.DeploymentID,
+			)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "POST",
+				Path:   url,
+				Body:   tc.Body,
+			})
+
+			checker := mt.NewJSONResponse(tc.ResponseCode,
+				map[string]string{
+					"Content-Type": "application/json; charset=utf-8",
+				},
+				tc.ResponseBody)
+
+			recorded := restutil.RunRequest(t, router, req)
+
+			mt.CheckHTTPResponse(t, checker, recorded)
+		})
+	}
+}
@@ -703,6 +703,141 @@ func TestPostDeploymentToGroup(t *testing.T) {
 	}
 }
 
+func TestPreviewDeployment(t *testing.T) {
+	t.Parallel()
+
+	preview := model.NewDeploymentPreview("bar")
+	preview.Add(model.DeploymentPreviewDevice{
+		ID:           "f826484e-1157-4109-af21-304e6d711560",
+		DeviceType:   "rpi4",
+		ArtifactName: "foo",
+	})
+	preview.Add(model.DeploymentPreviewDevice{
+		ID:           "1c8a1b4d-6d5f-4a34-9f40-f6bb5b0d8b9e",
+		DeviceType:   "qemu",
+		ArtifactName: "foo",
+		Reason:       model.PreviewReasonDeviceTypeMismatch,
+	})
+
+	testCases := []struct {
+		Name       string
+		InputBody  interface{}
+		InputGroup string
+
+		AppPreview   *model.DeploymentPreview
+		AppError     error
+		ResponseCode int
+		ResponseBody interface{}
+	}{{
+		Name: "ok, device list",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+			Devices: []string{
+				"f826484e-1157-4109-af21-304e6d711560",
+				"1c8a1b4d-6d5f-4a34-9f40-f6bb5b0d8b9e",
+			},
+		},
+		AppPreview:   preview,
+		ResponseCode: http.StatusOK,
+		ResponseBody: preview,
+	}, {
+		Name: "ok, group",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+		},
+		InputGroup:   "baz",
+		AppPreview:   preview,
+		ResponseCode: http.StatusOK,
+		ResponseBody: preview,
+	}, {
+		Name: "error: no devices",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+		},
+		ResponseCode: http.StatusBadRequest,
+		ResponseBody: rest.Error{
+			Err:       "Validating request body: Invalid deployments definition: provide list of devices or set all_devices flag",
+			RequestID: "test",
+		},
+	}, {
+		Name: "error: no artifact",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+			AllDevices:   true,
+		},
+		AppError:     app.ErrNoArtifact,
+		ResponseCode: http.StatusUnprocessableEntity,
+		ResponseBody: rest.Error{
+			Err:       app.ErrNoArtifact.Error(),
+			RequestID: "test",
+		},
+	}, {
+		Name: "error: app error: no devices",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+		},
+		InputGroup:   "baz",
+		AppError:     app.ErrNoDevices,
+		ResponseCode: http.StatusBadRequest,
+		ResponseBody: rest.Error{
+			Err:       app.ErrNoDevices.Error(),
+			RequestID: "test",
+		},
+	}, {
+		Name: "error: internal",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+			AllDevices:   true,
+		},
+		AppError:     errors.New("some error"),
+		ResponseCode: http.StatusInternalServerError,
+		ResponseBody: rest.Error{
+			Err:       "internal error",
+			RequestID: "test",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			appMock := &mapp.App{}
+			defer appMock.AssertExpectations(t)
+			if tc.AppPreview != nil || tc.AppError != nil {
+				appMock.On("PreviewDeployment",
+					mock.MatchedBy(func(ctx context.Context) bool { return true }),
+					mock.MatchedBy(func(c *model.DeploymentConstructor) bool {
+						return c.Group == tc.InputGroup
+					}),
+				).Return(tc.AppPreview, tc.AppError)
+			}
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementDeploymentsPreview, d.PreviewDeployment)
+			router.POST(ApiUrlManagementDeploymentsGroupPreview, d.PreviewDeploymentToGroup)
+
+			path := ApiUrlManagementDeploymentsPreview
+			if tc.InputGroup != "" {
+				path = strings.Replace(
+					ApiUrlManagementDeploymentsGroupPreview, ":name", tc.InputGroup, 1,
+				)
+			}
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "POST",
+				Path:   "http://localhost" + path,
+				Body:   tc.InputBody,
+			})
+
+			recorded := restutil.RunRequest(t, router, req)
+			checker := mt.NewJSONResponse(tc.ResponseCode, nil, tc.ResponseBody)
+			mt.CheckHTTPResponse(t, checker, recorded)
+		})
+	}
+}
+
 func TestControllerPostConfigurationDeployment(t *testing.T) {
 
 	t.Parallel()
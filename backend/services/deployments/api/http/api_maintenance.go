@@ -0,0 +1,97 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func (d *DeploymentsApiHandlers) GetMaintenanceWindows(c *gin.Context) {
+	windows, err := d.app.GetMaintenanceWindows(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, windows)
+}
+
+func (d *DeploymentsApiHandlers) CreateMaintenanceWindow(c *gin.Context) {
+	var window model.MaintenanceWindow
+	if err := c.ShouldBindJSON(&window); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	if err := window.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	err := d.app.CreateMaintenanceWindow(c.Request.Context(), &window)
+	switch cause := errors.Cause(err); cause {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessPost(c, window.Name)
+	case app.ErrMaintenanceWindowExists:
+		d.view.RenderError(c, cause, http.StatusConflict)
+	}
+}
+
+func (d *DeploymentsApiHandlers) UpdateMaintenanceWindow(c *gin.Context) {
+	var window model.MaintenanceWindow
+	if err := c.ShouldBindJSON(&window); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	window.Name = c.Param(ParamName)
+	if err := window.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	err := d.app.UpdateMaintenanceWindow(c.Request.Context(), &window)
+	switch errors.Cause(err) {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessPut(c)
+	case app.ErrMaintenanceWindowNotFound:
+		d.view.RenderErrorNotFound(c)
+	}
+}
+
+func (d *DeploymentsApiHandlers) DeleteMaintenanceWindow(c *gin.Context) {
+	err := d.app.DeleteMaintenanceWindow(c.Request.Context(), c.Param(ParamName))
+	switch errors.Cause(err) {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessDelete(c)
+	case app.ErrMaintenanceWindowNotFound:
+		d.view.RenderErrorNotFound(c)
+	}
+}
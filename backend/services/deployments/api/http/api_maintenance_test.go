@@ -0,0 +1,217 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestCreateMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	validRequest := model.MaintenanceWindow{
+		Name:     "oslo",
+		Group:    "oslo",
+		Days:     []string{"mon", "tue", "wed", "thu", "fri"},
+		Start:    "01:00",
+		End:      "04:00",
+		Timezone: "Europe/Oslo",
+	}
+
+	testCases := map[string]struct {
+		body     interface{}
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			body:       validRequest,
+			statusCode: http.StatusCreated,
+		},
+		"error, malformed body": {
+			body:       "foo",
+			statusCode: http.StatusBadRequest,
+			error: "malformed request body: json: cannot unmarshal string " +
+				"into Go value of type model.MaintenanceWindow",
+		},
+		"error, invalid time zone": {
+			body: model.MaintenanceWindow{
+				Name:     "oslo",
+				Group:    "oslo",
+				Days:     []string{"mon"},
+				Start:    "01:00",
+				End:      "04:00",
+				Timezone: "Europe/Nowhere",
+			},
+			statusCode: http.StatusBadRequest,
+			error:      `timezone: invalid time zone "Europe/Nowhere".`,
+		},
+		"error, no target": {
+			body: model.MaintenanceWindow{
+				Name:  "oslo",
+				Days:  []string{"mon"},
+				Start: "01:00",
+				End:   "04:00",
+			},
+			statusCode: http.StatusBadRequest,
+			error:      model.ErrMaintenanceWindowTarget.Error(),
+		},
+		"error, window exists": {
+			body:       validRequest,
+			appError:   app.ErrMaintenanceWindowExists,
+			statusCode: http.StatusConflict,
+			error:      app.ErrMaintenanceWindowExists.Error(),
+		},
+		"error, internal": {
+			body:       validRequest,
+			appError:   errors.New("failed to store the maintenance window"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := &mapp.App{}
+			defer appMock.AssertExpectations(t)
+			if tc.appError != nil || tc.statusCode == http.StatusCreated {
+				appMock.On("CreateMaintenanceWindow",
+					h.ContextMatcher(),
+					&validRequest,
+				).Return(tc.appError)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementMaintenanceWindows, d.CreateMaintenanceWindow)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost" + ApiUrlManagementMaintenanceWindows,
+				Body:   tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			} else {
+				assert.Equal(t,
+					ApiUrlManagementMaintenanceWindows+"/oslo",
+					recorded.Recorder.Header().Get("Location"),
+				)
+			}
+		})
+	}
+}
+
+func TestUpdateMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	validRequest := model.MaintenanceWindow{
+		Tag:        &model.MaintenanceWindowTag{Name: "site", Value: "lab"},
+		Days:       []string{"sat"},
+		Start:      "22:00",
+		End:        "02:00",
+		GateReboot: true,
+	}
+
+	testCases := map[string]struct {
+		body     interface{}
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			body:       validRequest,
+			statusCode: http.StatusNoContent,
+		},
+		"error, invalid day": {
+			body: model.MaintenanceWindow{
+				Group: "lab",
+				Days:  []string{"someday"},
+				Start: "22:00",
+				End:   "02:00",
+			},
+			statusCode: http.StatusBadRequest,
+			error: `days: (0: invalid day "someday": must be one of ` +
+				`mon, tue, wed, thu, fri, sat, sun.).`,
+		},
+		"error, not found": {
+			body:       validRequest,
+			appError:   app.ErrMaintenanceWindowNotFound,
+			statusCode: http.StatusNotFound,
+			error:      "Resource not found",
+		},
+		"error, internal": {
+			body:       validRequest,
+			appError:   errors.New("failed to update the maintenance window"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := &mapp.App{}
+			defer appMock.AssertExpectations(t)
+			if tc.appError != nil || tc.statusCode == http.StatusNoContent {
+				appMock.On("UpdateMaintenanceWindow",
+					h.ContextMatcher(),
+					mock.MatchedBy(func(window *model.MaintenanceWindow) bool {
+						return window.Name == "lab" && window.GateReboot
+					}),
+				).Return(tc.appError)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock)
+			router := setUpTestRouter()
+			router.PUT(ApiUrlManagementMaintenanceWindowsName, d.UpdateMaintenanceWindow)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPut,
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementMaintenanceWindowsName, ":name", "lab", 1,
+				),
+				Body: tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			}
+		})
+	}
+}
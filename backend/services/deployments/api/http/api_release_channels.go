@@ -0,0 +1,160 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func (d *DeploymentsApiHandlers) GetReleaseChannels(c *gin.Context) {
+	channels, err := d.app.GetReleaseChannels(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, channels)
+}
+
+func (d *DeploymentsApiHandlers) CreateReleaseChannel(c *gin.Context) {
+	var channel model.ReleaseChannel
+	if err := c.ShouldBindJSON(&channel); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	if err := channel.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	err := d.app.CreateReleaseChannel(c.Request.Context(), &channel)
+	switch cause := errors.Cause(err); cause {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessPost(c, channel.Name)
+	case app.ErrReleaseChannelExists:
+		d.view.RenderError(c, cause, http.StatusConflict)
+	}
+}
+
+func (d *DeploymentsApiHandlers) UpdateReleaseChannel(c *gin.Context) {
+	var channel model.ReleaseChannel
+	if err := c.ShouldBindJSON(&channel); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	channel.Name = c.Param(ParamName)
+	if err := channel.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	err := d.app.UpdateReleaseChannel(c.Request.Context(), &channel)
+	switch errors.Cause(err) {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessPut(c)
+	case app.ErrReleaseChannelNotFound:
+		d.view.RenderErrorNotFound(c)
+	}
+}
+
+func (d *DeploymentsApiHandlers) DeleteReleaseChannel(c *gin.Context) {
+	err := d.app.DeleteReleaseChannel(c.Request.Context(), c.Param(ParamName))
+	switch cause := errors.Cause(err); cause {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessDelete(c)
+	case app.ErrReleaseChannelNotFound:
+		d.view.RenderErrorNotFound(c)
+	case app.ErrReleaseChannelInUse:
+		d.view.RenderError(c, cause, http.StatusConflict)
+	}
+}
+
+func (d *DeploymentsApiHandlers) PromoteRelease(c *gin.Context) {
+	var request model.ReleasePromotionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	if err := request.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	release, err := d.app.PromoteRelease(c.Request.Context(), c.Param(ParamName), request)
+	d.renderReleasePromotion(c, release, err)
+}
+
+func (d *DeploymentsApiHandlers) ApproveReleasePromotion(c *gin.Context) {
+	release, err := d.app.ApproveReleasePromotion(c.Request.Context(), c.Param(ParamName))
+	d.renderReleasePromotion(c, release, err)
+}
+
+func (d *DeploymentsApiHandlers) CancelReleasePromotion(c *gin.Context) {
+	err := d.app.CancelReleasePromotion(c.Request.Context(), c.Param(ParamName))
+	if err != nil {
+		d.renderReleasePromotion(c, nil, err)
+		return
+	}
+	d.view.RenderSuccessDelete(c)
+}
+
+// renderReleasePromotion renders the release after a promotion request:
+// 202 Accepted if the promotion is waiting for approvals, 200 OK otherwise.
+func (d *DeploymentsApiHandlers) renderReleasePromotion(
+	c *gin.Context,
+	release *model.Release,
+	err error,
+) {
+	switch cause := errors.Cause(err); cause {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		if release.Promotion != nil {
+			c.JSON(http.StatusAccepted, release)
+		} else {
+			d.view.RenderSuccessGet(c, release)
+		}
+	case app.ErrReleaseNotFound,
+		app.ErrReleaseChannelNotFound,
+		app.ErrReleasePromotionNotFound:
+		d.view.RenderError(c, cause, http.StatusNotFound)
+	case app.ErrReleasePromotionForbidden:
+		d.view.RenderError(c, cause, http.StatusForbidden)
+	case app.ErrReleaseAlreadyInChannel,
+		app.ErrReleasePromotionPending,
+		app.ErrReleasePromotionApproved:
+		d.view.RenderError(c, cause, http.StatusConflict)
+	}
+}
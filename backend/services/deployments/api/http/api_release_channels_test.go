@@ -0,0 +1,338 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestCreateReleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	validRequest := model.ReleaseChannel{
+		Name:              "production",
+		Rank:              1,
+		RequiredApprovals: 2,
+		Groups:            []string{"production"},
+	}
+
+	testCases := map[string]struct {
+		body     interface{}
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			body:       validRequest,
+			statusCode: http.StatusCreated,
+		},
+		"error, malformed body": {
+			body:       "foo",
+			statusCode: http.StatusBadRequest,
+			error: "malformed request body: json: cannot unmarshal string " +
+				"into Go value of type model.ReleaseChannel",
+		},
+		"error, invalid channel": {
+			body:       model.ReleaseChannel{Name: "Production"},
+			statusCode: http.StatusBadRequest,
+			error:      "name: " + model.ErrChannelNameInvalid.Error() + ".",
+		},
+		"error, channel exists": {
+			body:       validRequest,
+			appError:   app.ErrReleaseChannelExists,
+			statusCode: http.StatusConflict,
+			error:      app.ErrReleaseChannelExists.Error(),
+		},
+		"error, internal": {
+			body:       validRequest,
+			appError:   errors.New("failed to store the release channel"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.appError != nil || tc.statusCode == http.StatusCreated {
+				app.On("CreateReleaseChannel",
+					h.ContextMatcher(),
+					&validRequest,
+				).Return(tc.appError)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementV2ReleaseChannels, d.CreateReleaseChannel)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost" + ApiUrlManagementV2ReleaseChannels,
+				Body:   tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			} else {
+				assert.Equal(t,
+					ApiUrlManagementV2ReleaseChannels+"/production",
+					recorded.Recorder.Header().Get("Location"),
+				)
+			}
+		})
+	}
+}
+
+func TestDeleteReleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			statusCode: http.StatusNoContent,
+		},
+		"error, not found": {
+			appError:   app.ErrReleaseChannelNotFound,
+			statusCode: http.StatusNotFound,
+			error:      "Resource not found",
+		},
+		"error, in use": {
+			appError:   app.ErrReleaseChannelInUse,
+			statusCode: http.StatusConflict,
+			error:      app.ErrReleaseChannelInUse.Error(),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			app.On("DeleteReleaseChannel", h.ContextMatcher(), "production").
+				Return(tc.appError)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.DELETE(ApiUrlManagementV2ReleaseChannelsName, d.DeleteReleaseChannel)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodDelete,
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementV2ReleaseChannelsName, ":name", "production", 1,
+				),
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			}
+		})
+	}
+}
+
+func TestPromoteRelease(t *testing.T) {
+	t.Parallel()
+
+	request := model.ReleasePromotionRequest{Channel: "production"}
+
+	testCases := map[string]struct {
+		body       interface{}
+		appRelease *model.Release
+		appError   error
+
+		statusCode int
+		error      string
+	}{
+		"ok, promoted": {
+			body:       request,
+			appRelease: &model.Release{Name: "release", Channel: "production"},
+			statusCode: http.StatusOK,
+		},
+		"ok, pending approvals": {
+			body: request,
+			appRelease: &model.Release{
+				Name:      "release",
+				Promotion: &model.ReleasePromotion{Channel: "production"},
+			},
+			statusCode: http.StatusAccepted,
+		},
+		"error, no channel": {
+			body:       model.ReleasePromotionRequest{},
+			statusCode: http.StatusBadRequest,
+			error:      "channel: cannot be blank.",
+		},
+		"error, release not found": {
+			body:       request,
+			appError:   app.ErrReleaseNotFound,
+			statusCode: http.StatusNotFound,
+			error:      app.ErrReleaseNotFound.Error(),
+		},
+		"error, promotion pending": {
+			body:       request,
+			appError:   app.ErrReleasePromotionPending,
+			statusCode: http.StatusConflict,
+			error:      app.ErrReleasePromotionPending.Error(),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.appRelease != nil || tc.appError != nil {
+				app.On("PromoteRelease", h.ContextMatcher(), "release", request).
+					Return(tc.appRelease, tc.appError)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementV2ReleasePromotion, d.PromoteRelease)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementV2ReleasePromotion, ":name", "release", 1,
+				),
+				Body: tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			}
+		})
+	}
+}
+
+func TestApproveReleasePromotion(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		appRelease *model.Release
+		appError   error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			appRelease: &model.Release{Name: "release", Channel: "production"},
+			statusCode: http.StatusOK,
+		},
+		"error, forbidden": {
+			appError:   app.ErrReleasePromotionForbidden,
+			statusCode: http.StatusForbidden,
+			error:      app.ErrReleasePromotionForbidden.Error(),
+		},
+		"error, no promotion": {
+			appError:   app.ErrReleasePromotionNotFound,
+			statusCode: http.StatusNotFound,
+			error:      app.ErrReleasePromotionNotFound.Error(),
+		},
+		"error, internal": {
+			appError:   errors.New("failed to approve the release promotion"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			app.On("ApproveReleasePromotion", h.ContextMatcher(), "release").
+				Return(tc.appRelease, tc.appError)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementV2ReleaseApprovals, d.ApproveReleasePromotion)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementV2ReleaseApprovals, ":name", "release", 1,
+				),
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			}
+		})
+	}
+}
+
+func TestCreateDeploymentReleaseChannelRestricted(t *testing.T) {
+	t.Parallel()
+
+	restricted := errors.WithMessage(app.ErrReleaseChannelRestricted,
+		`group "production" only accepts releases promoted to the channel "production"`)
+	appMock := &mapp.App{}
+	defer appMock.AssertExpectations(t)
+	appMock.On("CreateDeployment",
+		h.ContextMatcher(),
+		mock.AnythingOfType("*model.DeploymentConstructor"),
+	).Return("", restricted)
+
+	d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock)
+	router := setUpTestRouter()
+	router.POST(ApiUrlManagementDeployments, d.PostDeployment)
+
+	req := rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: http.MethodPost,
+		Path:   "http://localhost" + ApiUrlManagementDeployments,
+		Body: model.DeploymentConstructor{
+			Name:         "deployment",
+			ArtifactName: "release",
+			Devices:      []string{"b532b01a-9313-404f-8d19-e7fcbe5cc347"},
+		},
+	})
+	recorded := restutil.RunRequest(t, router, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorded.Recorder.Code)
+	assertErrorBody(t, recorded, restricted.Error())
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func (d *DeploymentsApiHandlers) GetRetentionPolicy(c *gin.Context) {
+	policy, err := d.app.GetRetentionPolicy(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, policy)
+}
+
+func (d *DeploymentsApiHandlers) SetRetentionPolicy(c *gin.Context) {
+	var policy model.RetentionPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	if err := policy.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+	if err := d.app.SetRetentionPolicy(c.Request.Context(), &policy); err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetRetentionReport lists the artifacts the retention policy would delete
+// without deleting them.
+func (d *DeploymentsApiHandlers) GetRetentionReport(c *gin.Context) {
+	report, err := d.app.ApplyRetentionPolicy(c.Request.Context(), true)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, report)
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestSetRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	validPolicy := model.RetentionPolicy{KeepReleases: 5, UnusedDays: 30}
+
+	testCases := map[string]struct {
+		body     interface{}
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			body:       validPolicy,
+			statusCode: http.StatusNoContent,
+		},
+		"error, invalid policy": {
+			body:       model.RetentionPolicy{UnusedDays: -1},
+			statusCode: http.StatusBadRequest,
+			error:      "unused_days: must be no less than 0.",
+		},
+		"error, internal": {
+			body:       validPolicy,
+			appError:   errors.New("failed to store the retention policy"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.appError != nil || tc.statusCode == http.StatusNoContent {
+				app.On("SetRetentionPolicy", h.ContextMatcher(), &validPolicy).
+					Return(tc.appError)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.PUT(ApiUrlManagementRetentionPolicy, d.SetRetentionPolicy)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPut,
+				Path:   "http://localhost" + ApiUrlManagementRetentionPolicy,
+				Body:   tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			}
+		})
+	}
+}
+
+func TestGetRetentionReport(t *testing.T) {
+	t.Parallel()
+
+	report := &model.RetentionReport{
+		Policy: model.RetentionPolicy{UnusedDays: 30},
+		DryRun: true,
+		Artifacts: []model.RetentionCandidate{{
+			ID:          "0c13a0e6-6b63-475d-8260-ee42a590e8ff",
+			Name:        "app-1",
+			DeviceTypes: []string{"pi"},
+			Size:        100,
+			Reason:      model.RetentionReasonUnused,
+		}},
+		TotalSize: 100,
+	}
+
+	app := &mapp.App{}
+	defer app.AssertExpectations(t)
+	app.On("ApplyRetentionPolicy", h.ContextMatcher(), true).Return(report, nil)
+
+	d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+	router := setUpTestRouter()
+	router.GET(ApiUrlManagementRetentionReport, d.GetRetentionReport)
+
+	req := rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: http.MethodGet,
+		Path:   "http://localhost" + ApiUrlManagementRetentionReport,
+	})
+	recorded := restutil.RunRequest(t, router, req)
+
+	assert.Equal(t, http.StatusOK, recorded.Recorder.Code)
+	var body model.RetentionReport
+	if assert.NoError(t, json.Unmarshal(recorded.Recorder.Body.Bytes(), &body)) {
+		assert.Equal(t, *report, body)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func (d *DeploymentsApiHandlers) GetSigningKeys(c *gin.Context) {
+	keys, err := d.app.GetSigningKeys(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, keys)
+}
+
+func (d *DeploymentsApiHandlers) CreateSigningKey(c *gin.Context) {
+	var request model.NewSigningKey
+	if err := c.ShouldBindJSON(&request); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	if err := request.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	key, err := d.app.CreateSigningKey(c.Request.Context(), &request)
+	switch cause := errors.Cause(err); cause {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessPost(c, key.ID)
+	case app.ErrSigningKeyExists:
+		d.view.RenderError(c, cause, http.StatusConflict)
+	case model.ErrSigningKeyInvalidPEM, model.ErrSigningKeyType,
+		model.ErrSigningKeyCurve:
+		d.view.RenderError(c, cause, http.StatusBadRequest)
+	}
+}
+
+func (d *DeploymentsApiHandlers) DeleteSigningKey(c *gin.Context) {
+	err := d.app.DeleteSigningKey(c.Request.Context(), c.Param("id"))
+	switch errors.Cause(err) {
+	default:
+		d.view.RenderInternalError(c, err)
+	case nil:
+		d.view.RenderSuccessDelete(c)
+	case app.ErrSigningKeyNotFound:
+		d.view.RenderErrorNotFound(c)
+	}
+}
+
+func (d *DeploymentsApiHandlers) GetSignatureSettings(c *gin.Context) {
+	settings, err := d.app.GetSignatureSettings(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, settings)
+}
+
+func (d *DeploymentsApiHandlers) SetSignatureSettings(c *gin.Context) {
+	var settings model.SignatureSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		d.view.RenderError(c,
+			errors.Wrap(err, "malformed request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	if err := d.app.SetSignatureSettings(c.Request.Context(), &settings); err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mt "github.com/mendersoftware/mender-server/pkg/testing"
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func assertErrorBody(t *testing.T, recorded *mt.Recorded, expected string) {
+	var body struct {
+		Error string `json:"error"`
+	}
+	if assert.NoError(t, json.Unmarshal(recorded.Recorder.Body.Bytes(), &body)) {
+		assert.Equal(t, expected, body.Error)
+	}
+}
+
+func TestCreateSigningKey(t *testing.T) {
+	t.Parallel()
+
+	const keyID = "0c13a0e6-6b63-475d-8260-ee42a590e8ff"
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	validRequest := model.NewSigningKey{
+		Name:      "release key",
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+
+	testCases := map[string]struct {
+		body     interface{}
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			body:       validRequest,
+			statusCode: http.StatusCreated,
+		},
+		"error, malformed body": {
+			body:       "foo",
+			statusCode: http.StatusBadRequest,
+			error: "malformed request body: json: cannot unmarshal string " +
+				"into Go value of type model.NewSigningKey",
+		},
+		"error, invalid key": {
+			body: model.NewSigningKey{
+				Name:      "release key",
+				PublicKey: "not a key",
+			},
+			statusCode: http.StatusBadRequest,
+			error:      "public_key: " + model.ErrSigningKeyInvalidPEM.Error() + ".",
+		},
+		"error, key exists": {
+			body:       validRequest,
+			appError:   app.ErrSigningKeyExists,
+			statusCode: http.StatusConflict,
+			error:      app.ErrSigningKeyExists.Error(),
+		},
+		"error, internal": {
+			body:       validRequest,
+			appError:   errors.New("failed to store the signing key"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			if tc.appError != nil || tc.statusCode == http.StatusCreated {
+				app.On("CreateSigningKey",
+					h.ContextMatcher(),
+					&validRequest,
+				).Return(&model.SigningKey{ID: keyID}, tc.appError)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementSigningKeys, d.CreateSigningKey)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost" + ApiUrlManagementSigningKeys,
+				Body:   tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			} else {
+				assert.Equal(t,
+					ApiUrlManagementSigningKeys+"/"+keyID,
+					recorded.Recorder.Header().Get("Location"),
+				)
+			}
+		})
+	}
+}
+
+func TestDeleteSigningKey(t *testing.T) {
+	t.Parallel()
+
+	const keyID = "0c13a0e6-6b63-475d-8260-ee42a590e8ff"
+	testCases := map[string]struct {
+		appError error
+
+		statusCode int
+		error      string
+	}{
+		"ok": {
+			statusCode: http.StatusNoContent,
+		},
+		"error, not found": {
+			appError:   app.ErrSigningKeyNotFound,
+			statusCode: http.StatusNotFound,
+			error:      "Resource not found",
+		},
+		"error, internal": {
+			appError:   errors.New("failed to delete the signing key"),
+			statusCode: http.StatusInternalServerError,
+			error:      "internal error",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			app.On("DeleteSigningKey", h.ContextMatcher(), keyID).
+				Return(tc.appError)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.DELETE(ApiUrlManagementSigningKeysId, d.DeleteSigningKey)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodDelete,
+				Path:   "http://localhost" + ApiUrlManagementSigningKeys + "/" + keyID,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.statusCode, recorded.Recorder.Code)
+			if tc.error != "" {
+				assertErrorBody(t, recorded, tc.error)
+			}
+		})
+	}
+}
+
+func TestSignatureSettings(t *testing.T) {
+	t.Parallel()
+
+	settings := &model.SignatureSettings{RequireSignature: true}
+
+	app := &mapp.App{}
+	defer app.AssertExpectations(t)
+	app.On("SetSignatureSettings", h.ContextMatcher(), settings).Return(nil)
+	app.On("GetSignatureSettings", h.ContextMatcher()).Return(settings, nil)
+
+	d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+	router := setUpTestRouter()
+	router.PUT(ApiUrlManagementSignatureSettings, d.SetSignatureSettings)
+	router.GET(ApiUrlManagementSignatureSettings, d.GetSignatureSettings)
+
+	req := rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: http.MethodPut,
+		Path:   "http://localhost" + ApiUrlManagementSignatureSettings,
+		Body:   settings,
+	})
+	recorded := restutil.RunRequest(t, router, req)
+	assert.Equal(t, http.StatusNoContent, recorded.Recorder.Code)
+
+	req = rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: http.MethodGet,
+		Path:   "http://localhost" + ApiUrlManagementSignatureSettings,
+	})
+	recorded = restutil.RunRequest(t, router, req)
+	assert.Equal(t, http.StatusOK, recorded.Recorder.Code)
+	assert.JSONEq(t,
+		`{"require_signed_artifacts":true}`,
+		recorded.Recorder.Body.String(),
+	)
+
+	req = rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: http.MethodPut,
+		Path:   "http://localhost" + ApiUrlManagementSignatureSettings,
+		Body:   "foo",
+	})
+	recorded = restutil.RunRequest(t, router, req)
+	assert.Equal(t, http.StatusBadRequest, recorded.Recorder.Code)
+}
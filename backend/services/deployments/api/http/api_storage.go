@@ -0,0 +1,142 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/filesystem"
+)
+
+// verifyStorageRequest validates the signature of the requests generated
+// by the filesystem storage backend and returns the object path.
+func (d *DeploymentsApiHandlers) verifyStorageRequest(c *gin.Context) (string, bool) {
+	sig := model.NewRequestSignature(c.Request, d.config.PresignSecret)
+	if err := sig.Validate(); err != nil {
+		switch cause := errors.Cause(err); cause {
+		case model.ErrLinkExpired:
+			d.view.RenderError(c, cause, http.StatusForbidden)
+		default:
+			d.view.RenderError(c,
+				errors.Wrap(err, "invalid request parameters"),
+				http.StatusBadRequest,
+			)
+		}
+		return "", false
+	}
+	if !sig.VerifyHMAC256() {
+		d.view.RenderError(c,
+			errors.New("signature invalid"),
+			http.StatusForbidden,
+		)
+		return "", false
+	}
+	return strings.TrimPrefix(c.Param("path"), "/"), true
+}
+
+func (d *DeploymentsApiHandlers) renderStorageError(c *gin.Context, err error) {
+	switch errors.Cause(err) {
+	case storage.ErrObjectNotFound:
+		d.view.RenderErrorNotFound(c)
+	case filesystem.ErrInvalidPath:
+		d.view.RenderError(c, err, http.StatusBadRequest)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+func (d *DeploymentsApiHandlers) DownloadStorageObject(c *gin.Context) {
+	objectPath, ok := d.verifyStorageRequest(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	info, err := d.config.LocalStorage.StatObject(ctx, objectPath)
+	if err != nil {
+		d.renderStorageError(c, err)
+		return
+	}
+	obj, err := d.config.LocalStorage.GetObject(ctx, objectPath)
+	if err != nil {
+		d.renderStorageError(c, err)
+		return
+	}
+	defer obj.Close()
+
+	hdr := c.Writer.Header()
+	hdr.Set("Content-Type", app.ArtifactContentType)
+	if filename := c.Query(filesystem.ParamFilename); filename != "" {
+		hdr.Set("Content-Disposition", mime.FormatMediaType(
+			"attachment", map[string]string{"filename": filename},
+		))
+	}
+	if seeker, ok := obj.(io.ReadSeeker); ok {
+		// Supports range requests for resuming interrupted downloads.
+		var modTime time.Time
+		if info.LastModified != nil {
+			modTime = *info.LastModified
+		}
+		http.ServeContent(c.Writer, c.Request, "", modTime, seeker)
+		return
+	}
+	if info.Size != nil {
+		hdr.Set("Content-Length", strconv.FormatInt(*info.Size, 10))
+	}
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, obj)
+}
+
+func (d *DeploymentsApiHandlers) UploadStorageObject(c *gin.Context) {
+	objectPath, ok := d.verifyStorageRequest(c)
+	if !ok {
+		return
+	}
+	defer c.Request.Body.Close()
+	err := d.config.LocalStorage.PutObject(c.Request.Context(), objectPath, c.Request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			d.view.RenderError(c, ErrModelArtifactFileTooLarge, http.StatusRequestEntityTooLarge)
+			return
+		}
+		d.renderStorageError(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (d *DeploymentsApiHandlers) DeleteStorageObject(c *gin.Context) {
+	objectPath, ok := d.verifyStorageRequest(c)
+	if !ok {
+		return
+	}
+	err := d.config.LocalStorage.DeleteObject(c.Request.Context(), objectPath)
+	if err != nil {
+		d.renderStorageError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
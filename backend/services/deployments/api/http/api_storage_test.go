@@ -0,0 +1,132 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/filesystem"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+)
+
+func TestStorageObjects(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	objectsURL, _ := url.Parse(FMTStorageObjectsURL("https", "localhost"))
+	objStore, err := filesystem.New(context.Background(), filesystem.NewOptions().
+		SetRootDir(t.TempDir()).
+		SetURL(objectsURL).
+		SetSecret(secret),
+	)
+	require.NoError(t, err)
+
+	cfg := NewConfig().
+		SetPresignSecret(secret).
+		SetLocalStorage(objStore)
+	d := NewDeploymentsApiHandlers(nil, new(view.RESTView), nil, cfg)
+	router := setUpTestRouter()
+	NewStorageObjectRoutes(router.Group("."), d, cfg)
+
+	do := func(link *model.Link, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(link.Method, link.Uri, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	ctx := context.Background()
+	const objectPath = "tenant/artifact"
+
+	// upload
+	link, err := objStore.PutRequest(ctx, objectPath, time.Minute, true)
+	require.NoError(t, err)
+	w := do(link, "artifact")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the link cannot be used for other objects
+	tampered := *link
+	tampered.Uri = strings.Replace(link.Uri, objectPath, "tenant/other", 1)
+	w = do(&tampered, "artifact")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	_, err = objStore.StatObject(ctx, "tenant/other")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	// download
+	link, err = objStore.GetRequest(ctx, objectPath, "artifact.mender", time.Minute, true)
+	require.NoError(t, err)
+	w = do(link, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "artifact", w.Body.String())
+	assert.Equal(t, `attachment; filename=artifact.mender`, w.Header().Get("Content-Disposition"))
+
+	// range request
+	req, _ := http.NewRequest(http.MethodGet, link.Uri, nil)
+	req.Header.Set("Range", "bytes=4-")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "fact", w.Body.String())
+
+	// expired link
+	u, _ := url.Parse(link.Uri)
+	expiredReq, _ := http.NewRequest(http.MethodGet, u.String(), nil)
+	sig := model.NewRequestSignature(expiredReq, secret)
+	sig.SetExpire(time.Now().Add(-time.Minute))
+	expired := &model.Link{Method: http.MethodGet, Uri: sig.PresignURL()}
+	w = do(expired, "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// missing signature
+	w = do(&model.Link{
+		Method: http.MethodGet,
+		Uri:    objectsURL.String() + "/" + objectPath,
+	}, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// delete
+	link, err = objStore.DeleteRequest(ctx, objectPath, time.Minute, true)
+	require.NoError(t, err)
+	w = do(link, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = do(link, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestStorageObjectsDisabled(t *testing.T) {
+	t.Parallel()
+
+	d := NewDeploymentsApiHandlers(nil, new(view.RESTView), nil)
+	router := setUpTestRouter()
+	NewStorageObjectRoutes(router.Group("."), d, NewConfig())
+
+	req, _ := http.NewRequest(http.MethodGet,
+		"http://localhost"+ApiUrlDevices+ApiUrlDevicesStorageObjects+"/tenant/artifact",
+		nil,
+	)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
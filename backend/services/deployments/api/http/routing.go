@@ -45,6 +45,7 @@ const (
 	ApiUrlManagementArtifacts               = "/artifacts"
 	ApiUrlManagementArtifactsList           = "/artifacts/list"
 	ApiUrlManagementArtifactsGenerate       = "/artifacts/generate"
+	ApiUrlManagementArtifactsGenerateDelta  = "/artifacts/generate/delta"
 	ApiUrlManagementArtifactsDirectUpload   = "/artifacts/directupload"
 	ApiUrlManagementArtifactsCompleteUpload = ApiUrlManagementArtifactsDirectUpload +
 		"/:id/complete"
@@ -54,27 +55,47 @@ const (
 	ApiUrlManagementDeployments                   = "/deployments"
 	ApiUrlManagementMultipleDeploymentsStatistics = "/deployments/statistics/list"
 	ApiUrlManagementDeploymentsGroup              = "/deployments/group/:name"
+	ApiUrlManagementDeploymentsPreview            = "/deployments/preview"
+	ApiUrlManagementDeploymentsGroupPreview       = "/deployments/group/:name/preview"
 	ApiUrlManagementDeploymentsId                 = "/deployments/:id"
 	ApiUrlManagementDeploymentsStatistics         = "/deployments/:id/statistics"
 	ApiUrlManagementDeploymentsStatus             = "/deployments/:id/status"
+	ApiUrlManagementDeploymentsRollback           = "/deployments/:id/rollback"
 	ApiUrlManagementDeploymentsDevices            = "/deployments/:id/devices"
 	ApiUrlManagementDeploymentsDevicesList        = "/deployments/:id/devices/list"
 	ApiUrlManagementDeploymentsLog                = "/deployments/:id/devices/:devid/log"
 	ApiUrlManagementDeploymentsDeviceId           = "/deployments/devices/:id"
 	ApiUrlManagementDeploymentsDeviceHistory      = "/deployments/devices/:id/history"
+	ApiUrlManagementDeploymentsDeviceTimeline     = "/deployments/devices/:id/timeline"
 	ApiUrlManagementDeploymentsDeviceList         = "/deployments/:id/device_list"
+	ApiUrlManagementDeploymentsLogs               = "/deployments/logs"
+	ApiUrlManagementDeploymentsLogsClusters       = "/deployments/logs/clusters"
 
 	ApiUrlManagementReleases     = "/deployments/releases"
 	ApiUrlManagementReleasesList = "/deployments/releases/list"
 
 	ApiUrlManagementLimitsName = "/limits/:name"
 
+	ApiUrlManagementSigningKeys       = "/signing_keys"
+	ApiUrlManagementSigningKeysId     = "/signing_keys/:id"
+	ApiUrlManagementSignatureSettings = "/settings/signatures"
+
+	ApiUrlManagementRetentionPolicy = "/settings/retention"
+	ApiUrlManagementRetentionReport = "/retention/report"
+
+	ApiUrlManagementMaintenanceWindows     = "/maintenance_windows"
+	ApiUrlManagementMaintenanceWindowsName = ApiUrlManagementMaintenanceWindows + "/:name"
+
 	ApiUrlManagementV2                      = "/api/management/v2/deployments"
 	ApiUrlManagementV2Releases              = "/deployments/releases"
 	ApiUrlManagementV2ReleasesName          = ApiUrlManagementV2Releases + "/:name"
 	ApiUrlManagementV2ReleaseTags           = ApiUrlManagementV2Releases + "/:name/tags"
 	ApiUrlManagementV2ReleaseAllTags        = "/releases/all/tags"
 	ApiUrlManagementV2ReleaseAllUpdateTypes = "/releases/all/types"
+	ApiUrlManagementV2ReleasePromotion      = ApiUrlManagementV2Releases + "/:name/promotion"
+	ApiUrlManagementV2ReleaseApprovals      = ApiUrlManagementV2ReleasePromotion + "/approvals"
+	ApiUrlManagementV2ReleaseChannels       = "/releases/channels"
+	ApiUrlManagementV2ReleaseChannelsName   = ApiUrlManagementV2ReleaseChannels + "/:name"
 	ApiUrlManagementV2Deployments           = "/deployments"
 
 	ApiUrlDevicesDeploymentsNext  = "/device/deployments/next"
@@ -82,10 +103,13 @@ const (
 	ApiUrlDevicesDeploymentsLog   = "/device/deployments/:id/log"
 	ApiUrlDevicesDownloadConfig   = "/download/configuration" +
 		"/:deployment_id/:device_type/:device_id"
+	ApiUrlDevicesStorageObjects = "/storage/objects"
+	ApiUrlDevicesStorageObject  = ApiUrlDevicesStorageObjects + "/*path"
 
 	ApiUrlInternalAlive                          = "/alive"
 	ApiUrlInternalHealth                         = "/health"
 	ApiUrlInternalTenants                        = "/tenants"
+	ApiUrlInternalTenant                         = "/tenants/:tenant"
 	ApiUrlInternalTenantDeployments              = "/tenants/:tenant/deployments"
 	ApiUrlInternalTenantDeploymentsDevices       = "/tenants/:tenant/deployments/devices"
 	ApiUrlInternalTenantDeploymentsDevice        = "/tenants/:tenant/deployments/devices/:id"
@@ -114,6 +138,9 @@ func NewRouter(
 	cfg *Config,
 ) http.Handler {
 	router := routing.NewMinimalGinRouter()
+	// nested group names contain slashes, which must be escaped in the
+	// path parameters
+	router.UseRawPath = true
 	// Create and configure API handlers
 	//
 	// Encode base64 secret in either std or URL encoding ignoring padding.
@@ -132,6 +159,7 @@ func NewRouter(
 	withAuth.Use(identity.Middleware())
 
 	NewImagesResourceRoutes(withAuth, deploymentsHandlers, cfg)
+	NewStorageObjectRoutes(publicAPIs, deploymentsHandlers, cfg)
 
 	// The rest of the public APIs does not need custom request size limits
 	publicAPIs.Use(requestsize.Middleware(cfg.MaxRequestSize))
@@ -139,6 +167,9 @@ func NewRouter(
 
 	NewDeploymentsResourceRoutes(publicAPIs, deploymentsHandlers)
 	NewLimitsResourceRoutes(withAuth, deploymentsHandlers)
+	SignaturesRoutes(withAuth, deploymentsHandlers)
+	RetentionRoutes(withAuth, deploymentsHandlers)
+	MaintenanceWindowsRoutes(withAuth, deploymentsHandlers)
 	InternalRoutes(internalAPIs, deploymentsHandlers)
 	ReleasesRoutes(withAuth, deploymentsHandlers)
 
@@ -181,7 +212,8 @@ func NewImagesResourceRoutes(router *gin.RouterGroup,
 			POST(ApiUrlManagementArtifactsGenerate,
 				generateDataSizeLimit, controller.GenerateImage)
 		mgmtV1.Group(".").Use(contenttype.CheckJSON()).
-			PUT(ApiUrlManagementArtifactsId, controller.EditImage)
+			PUT(ApiUrlManagementArtifactsId, controller.EditImage).
+			POST(ApiUrlManagementArtifactsGenerateDelta, controller.GenerateDeltaImage)
 
 	} else {
 		mgmtV1.DELETE(ApiUrlManagementArtifactsId, ServiceUnavailable)
@@ -190,6 +222,7 @@ func NewImagesResourceRoutes(router *gin.RouterGroup,
 			POST(ApiUrlManagementArtifacts, ServiceUnavailable).
 			POST(ApiUrlManagementArtifactsGenerate, ServiceUnavailable)
 		mgmtV1.PUT(ApiUrlManagementArtifactsId, ServiceUnavailable)
+		mgmtV1.POST(ApiUrlManagementArtifactsGenerateDelta, ServiceUnavailable)
 
 	}
 	if !controller.config.DisableNewReleasesFeature && cfg.EnableDirectUpload {
@@ -232,8 +265,16 @@ func NewDeploymentsResourceRoutes(router *gin.RouterGroup, controller *Deploymen
 		controller.GetDeploymentLogForDevice)
 	mgmtV1.GET(ApiUrlManagementDeploymentsDeviceId,
 		controller.ListDeviceDeployments)
+	mgmtV1.GET(ApiUrlManagementDeploymentsDeviceTimeline,
+		controller.GetDeviceDeploymentTimeline)
 	mgmtV1.GET(ApiUrlManagementDeploymentsDeviceList,
 		controller.GetDeploymentDeviceList)
+	mgmtV1.GET(ApiUrlManagementDeploymentsLogs, controller.SearchDeploymentLogs)
+	mgmtV1.GET(ApiUrlManagementDeploymentsLogsClusters,
+		controller.ClusterDeploymentLogs)
+
+	// the rollback request body is optional
+	mgmtV1.POST(ApiUrlManagementDeploymentsRollback, controller.RollbackDeployment)
 
 	mgmtV1.DELETE(ApiUrlManagementDeploymentsDeviceId,
 		controller.AbortDeviceDeployments)
@@ -243,6 +284,9 @@ func NewDeploymentsResourceRoutes(router *gin.RouterGroup, controller *Deploymen
 	mgmtV1.Group(".").Use(contenttype.CheckJSON()).
 		POST(ApiUrlManagementDeployments, controller.PostDeployment).
 		POST(ApiUrlManagementDeploymentsGroup, controller.DeployToGroup).
+		POST(ApiUrlManagementDeploymentsPreview, controller.PreviewDeployment).
+		POST(ApiUrlManagementDeploymentsGroupPreview,
+			controller.PreviewDeploymentToGroup).
 		POST(ApiUrlManagementMultipleDeploymentsStatistics,
 			controller.GetDeploymentsStats).
 		PUT(ApiUrlManagementDeploymentsStatus, controller.AbortDeployment)
@@ -266,6 +310,23 @@ func NewDeploymentsResourceRoutes(router *gin.RouterGroup, controller *Deploymen
 
 }
 
+// NewStorageObjectRoutes serves the signed requests generated by the
+// filesystem storage backend.
+func NewStorageObjectRoutes(router *gin.RouterGroup,
+	controller *DeploymentsApiHandlers, cfg *Config) {
+
+	if controller == nil || controller.config.LocalStorage == nil {
+		return
+	}
+	devices := router.Group(ApiUrlDevices)
+
+	devices.GET(ApiUrlDevicesStorageObject, controller.DownloadStorageObject)
+	devices.PUT(ApiUrlDevicesStorageObject,
+		requestsize.Middleware(cfg.MaxImageSize),
+		controller.UploadStorageObject)
+	devices.DELETE(ApiUrlDevicesStorageObject, controller.DeleteStorageObject)
+}
+
 func NewLimitsResourceRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
 
 	if controller == nil {
@@ -277,6 +338,45 @@ func NewLimitsResourceRoutes(router *gin.RouterGroup, controller *DeploymentsApi
 
 }
 
+func SignaturesRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
+	if controller == nil {
+		return
+	}
+	mgmtV1 := router.Group(ApiUrlManagement)
+
+	mgmtV1.GET(ApiUrlManagementSigningKeys, controller.GetSigningKeys)
+	mgmtV1.DELETE(ApiUrlManagementSigningKeysId, controller.DeleteSigningKey)
+	mgmtV1.GET(ApiUrlManagementSignatureSettings, controller.GetSignatureSettings)
+	mgmtV1.Group(".").Use(contenttype.CheckJSON()).
+		POST(ApiUrlManagementSigningKeys, controller.CreateSigningKey).
+		PUT(ApiUrlManagementSignatureSettings, controller.SetSignatureSettings)
+}
+
+func RetentionRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
+	if controller == nil {
+		return
+	}
+	mgmtV1 := router.Group(ApiUrlManagement)
+
+	mgmtV1.GET(ApiUrlManagementRetentionPolicy, controller.GetRetentionPolicy)
+	mgmtV1.GET(ApiUrlManagementRetentionReport, controller.GetRetentionReport)
+	mgmtV1.Group(".").Use(contenttype.CheckJSON()).
+		PUT(ApiUrlManagementRetentionPolicy, controller.SetRetentionPolicy)
+}
+
+func MaintenanceWindowsRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
+	if controller == nil {
+		return
+	}
+	mgmtV1 := router.Group(ApiUrlManagement)
+
+	mgmtV1.GET(ApiUrlManagementMaintenanceWindows, controller.GetMaintenanceWindows)
+	mgmtV1.DELETE(ApiUrlManagementMaintenanceWindowsName, controller.DeleteMaintenanceWindow)
+	mgmtV1.Group(".").Use(contenttype.CheckJSON()).
+		POST(ApiUrlManagementMaintenanceWindows, controller.CreateMaintenanceWindow).
+		PUT(ApiUrlManagementMaintenanceWindowsName, controller.UpdateMaintenanceWindow)
+}
+
 func InternalRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
 	if controller == nil {
 		return
@@ -303,6 +403,7 @@ func InternalRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers)
 	router.Use(requestid.Middleware())
 
 	router.POST(ApiUrlInternalTenants, controller.ProvisionTenantsHandler)
+	router.DELETE(ApiUrlInternalTenant, controller.DeleteTenantHandler)
 	router.GET(ApiUrlInternalTenantDeployments, controller.DeploymentsPerTenantHandler)
 	router.GET(ApiUrlInternalTenantDeploymentsDevices,
 		controller.ListDeviceDeploymentsByIDsInternal)
@@ -353,6 +454,15 @@ func ReleasesRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers)
 			PUT(ApiUrlManagementV2ReleaseTags, controller.PutReleaseTags).
 			PATCH(ApiUrlManagementV2ReleasesName, controller.PatchRelease)
 
+		mgmtV2.GET(ApiUrlManagementV2ReleaseChannels, controller.GetReleaseChannels)
+		mgmtV2.DELETE(ApiUrlManagementV2ReleaseChannelsName, controller.DeleteReleaseChannel)
+		mgmtV2.POST(ApiUrlManagementV2ReleaseApprovals, controller.ApproveReleasePromotion)
+		mgmtV2.DELETE(ApiUrlManagementV2ReleasePromotion, controller.CancelReleasePromotion)
+		mgmtV2.Group(".").Use(contenttype.CheckJSON()).
+			POST(ApiUrlManagementV2ReleaseChannels, controller.CreateReleaseChannel).
+			PUT(ApiUrlManagementV2ReleaseChannelsName, controller.UpdateReleaseChannel).
+			POST(ApiUrlManagementV2ReleasePromotion, controller.PromoteRelease)
+
 	}
 }
 
@@ -365,6 +475,12 @@ func FMTConfigURL(scheme, hostname, deploymentID, deviceType, deviceID string) s
 	return scheme + "://" + hostname + ApiUrlDevices + repl.Replace(ApiUrlDevicesDownloadConfig)
 }
 
+// FMTStorageObjectsURL returns the URL serving the objects stored by the
+// filesystem storage backend.
+func FMTStorageObjectsURL(scheme, hostname string) string {
+	return scheme + "://" + hostname + ApiUrlDevices + ApiUrlDevicesStorageObjects
+}
+
 func ServiceUnavailable(c *gin.Context) {
 	c.Status(http.StatusServiceUnavailable)
 }
@@ -0,0 +1,72 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+)
+
+func TestDeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		AppError     error
+		ResponseCode int
+	}{
+		{
+			Name:         "ok",
+			ResponseCode: http.StatusNoContent,
+		},
+		{
+			Name:         "error: app error",
+			AppError:     errors.New("some error"),
+			ResponseCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			app := &mapp.App{}
+			defer app.AssertExpectations(t)
+			app.On("DeleteTenant",
+				mock.MatchedBy(func(context.Context) bool { return true }),
+				"foo",
+			).Return(tc.AppError)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), app)
+			router := setUpTestRouter()
+			router.DELETE(ApiUrlInternalTenant, d.DeleteTenantHandler)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodDelete,
+				Path:   "http://localhost/tenants/foo",
+			})
+			recorded := restutil.RunRequest(t, router, req)
+			assert.Equal(t, tc.ResponseCode, recorded.Recorder.Code)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"path"
 	"reflect"
@@ -56,6 +57,7 @@ const (
 	InventoryGroupScope              = "system"
 	InventoryIdentityScope           = "identity"
 	InventoryGroupAttributeName      = "group"
+	InventoryGroupsAttributeName     = "groups"
 	InventoryStatusAttributeName     = "status"
 	InventoryIdAttributeName         = "id"
 	InventoryStatusAccepted          = "accepted"
@@ -116,11 +118,43 @@ type App interface {
 	// limits
 	GetLimit(ctx context.Context, name string) (*model.Limit, error)
 	ProvisionTenant(ctx context.Context, tenant_id string) error
+	DeleteTenant(ctx context.Context, tenantID string) error
 
 	// Storage Settings
 	GetStorageSettings(ctx context.Context) (*model.StorageSettings, error)
 	SetStorageSettings(ctx context.Context, storageSettings *model.StorageSettings) error
 
+	// Artifact signatures
+	GetSigningKeys(ctx context.Context) ([]model.SigningKey, error)
+	CreateSigningKey(ctx context.Context, request *model.NewSigningKey) (*model.SigningKey, error)
+	DeleteSigningKey(ctx context.Context, id string) error
+	GetSignatureSettings(ctx context.Context) (*model.SignatureSettings, error)
+	SetSignatureSettings(ctx context.Context, settings *model.SignatureSettings) error
+
+	// Release channels
+	GetReleaseChannels(ctx context.Context) ([]model.ReleaseChannel, error)
+	CreateReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error
+	UpdateReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error
+	DeleteReleaseChannel(ctx context.Context, name string) error
+	PromoteRelease(
+		ctx context.Context,
+		releaseName string,
+		request model.ReleasePromotionRequest,
+	) (*model.Release, error)
+	ApproveReleasePromotion(ctx context.Context, releaseName string) (*model.Release, error)
+	CancelReleasePromotion(ctx context.Context, releaseName string) error
+
+	// Artifact retention
+	GetRetentionPolicy(ctx context.Context) (*model.RetentionPolicy, error)
+	SetRetentionPolicy(ctx context.Context, policy *model.RetentionPolicy) error
+	ApplyRetentionPolicy(ctx context.Context, dryRun bool) (*model.RetentionReport, error)
+
+	// Maintenance windows
+	GetMaintenanceWindows(ctx context.Context) ([]model.MaintenanceWindow, error)
+	CreateMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error
+	UpdateMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error
+	DeleteMaintenanceWindow(ctx context.Context, name string) error
+
 	// images
 	ListImages(
 		ctx context.Context,
@@ -149,6 +183,8 @@ type App interface {
 		multipartUploadMsg *model.MultipartUploadMsg) (string, error)
 	GenerateImage(ctx context.Context,
 		multipartUploadMsg *model.MultipartGenerateImageMsg) (string, error)
+	GenerateDeltaImage(ctx context.Context,
+		request *model.GenerateDeltaImageRequest) (string, error)
 	GenerateConfigurationImage(
 		ctx context.Context,
 		deviceType string,
@@ -160,9 +196,13 @@ type App interface {
 	// deployments
 	CreateDeployment(ctx context.Context,
 		constructor *model.DeploymentConstructor) (string, error)
+	PreviewDeployment(ctx context.Context,
+		constructor *model.DeploymentConstructor) (*model.DeploymentPreview, error)
 	GetDeployment(ctx context.Context, deploymentID string) (*model.Deployment, error)
 	IsDeploymentFinished(ctx context.Context, deploymentID string) (bool, error)
 	AbortDeployment(ctx context.Context, deploymentID string) error
+	RollbackDeployment(ctx context.Context, deploymentID string,
+		request model.DeploymentRollbackRequest) (*model.DeploymentRollback, error)
 	GetDeploymentStats(ctx context.Context, deploymentID string) (model.Stats, error)
 	GetDeploymentsStats(ctx context.Context,
 		deploymentIDs ...string) ([]*model.DeploymentStats, error)
@@ -178,12 +218,18 @@ type App interface {
 		query store.ListQuery) ([]model.DeviceDeployment, int, error)
 	GetDeviceDeploymentListForDevice(ctx context.Context,
 		query store.ListQueryDeviceDeployments) ([]model.DeviceDeploymentListItem, int, error)
+	GetDeviceDeploymentTimeline(ctx context.Context,
+		query store.ListQueryDeviceDeployments) ([]model.DeviceDeploymentTimelineItem, int, error)
 	LookupDeployment(ctx context.Context,
 		query model.Query) ([]*model.Deployment, int64, error)
 	SaveDeviceDeploymentLog(ctx context.Context, deviceID string,
 		deploymentID string, logs []model.LogMessage) error
 	GetDeviceDeploymentLog(ctx context.Context,
 		deviceID, deploymentID string) (*model.DeploymentLog, error)
+	SearchDeploymentLogs(ctx context.Context,
+		query store.DeploymentLogQuery) ([]model.DeploymentLogEntry, int, error)
+	ClusterDeploymentLogs(ctx context.Context,
+		query store.DeploymentLogQuery) (*model.DeploymentLogClusters, error)
 	AbortDeviceDeployments(ctx context.Context, deviceID string) error
 	DeleteDeviceDeploymentsHistory(ctx context.Context, deviceId string) error
 	DecommissionDevice(ctx context.Context, deviceID string) error
@@ -217,6 +263,9 @@ type Deployments struct {
 	workflowsClient workflows.Client
 	inventoryClient inventory.Client
 	reportingClient reporting.Client
+	haveAuditLogs   bool
+
+	maintenanceDevices *maintenanceDeviceCache
 }
 
 // Compile-time check
@@ -233,6 +282,9 @@ func NewDeployments(
 		objectStorage:   objectStorage,
 		workflowsClient: workflows.NewClient(),
 		inventoryClient: inventory.NewClient(),
+		haveAuditLogs:   withAuditLogs,
+
+		maintenanceDevices: newMaintenanceDeviceCache(),
 	}
 }
 
@@ -324,6 +376,29 @@ func (d *Deployments) ProvisionTenant(ctx context.Context, tenant_id string) err
 	return nil
 }
 
+// DeleteTenant removes the artifact files and the database of the tenant.
+func (d *Deployments) DeleteTenant(ctx context.Context, tenantID string) error {
+	ctx = identity.WithContext(ctx, &identity.Identity{Tenant: tenantID})
+	ctx, err := d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get storage settings")
+	}
+	imageIDs, err := d.db.ListImageIDs(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list images")
+	}
+	for _, imageID := range imageIDs {
+		imagePath := model.ImagePathFromContext(ctx, imageID)
+		if err := d.objectStorage.DeleteObject(ctx, imagePath); err != nil {
+			return errors.Wrap(err, "Deleting image file")
+		}
+	}
+	if err := d.db.DeleteTenant(ctx, tenantID); err != nil {
+		return errors.Wrap(err, "failed to delete tenant")
+	}
+	return nil
+}
+
 // CreateImage parses artifact and uploads artifact file to the file storage - in parallel,
 // and creates image structure in the system.
 // Returns image ID and nil on success.
@@ -408,12 +483,18 @@ func (d *Deployments) handleArtifact(ctx context.Context,
 
 	// parse artifact
 	// artifact library reads all the data from the given reader
-	metaArtifactConstructor, err := getMetaFromArchive(&tee, skipVerify)
+	metaArtifactConstructor, artifactSig, err := getMetaFromArchive(&tee, skipVerify)
 	if err != nil {
 		_ = pW.CloseWithError(err)
 		<-ch
 		return artifactID, errors.Wrap(ErrModelParsingArtifactFailed, err.Error())
 	}
+	signature, err := d.verifyArtifactSignature(ctx, artifactSig, !skipVerify)
+	if err != nil {
+		_ = pW.CloseWithError(err)
+		<-ch
+		return artifactID, err
+	}
 	validMetadata := false
 	if skipVerify && metadata != nil {
 		// this means we got files and metadata separately
@@ -459,6 +540,10 @@ func (d *Deployments) handleArtifact(ctx context.Context,
 		metaArtifactConstructor,
 		size,
 	)
+	image.Signature = signature
+	if id := identity.FromContext(ctx); id != nil && id.IsUser {
+		image.UploadedBy = id.Subject
+	}
 
 	// save image structure in the system
 	if err = d.db.InsertImage(ctx, image); err != nil {
@@ -1002,15 +1087,23 @@ func getUpdateFiles(uFiles []*handlers.DataFile) ([]model.UpdateFile, error) {
 	return files, nil
 }
 
-func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, error) {
+func getMetaFromArchive(
+	r *io.Reader,
+	skipVerify bool,
+) (*model.ArtifactMeta, *artifactSignature, error) {
 	metaArtifact := model.NewArtifactMeta()
 
 	aReader := areader.NewReader(*r)
 
-	// There is no signature verification here.
-	// It is just simple check if artifact is signed or not.
+	// The signature is only captured here and verified against
+	// the tenant's signing keys once the artifact is parsed.
+	var signature *artifactSignature
 	aReader.VerifySignatureCallback = func(message, sig []byte) error {
 		metaArtifact.Signed = true
+		signature = &artifactSignature{
+			message:   append([]byte(nil), message...),
+			signature: append([]byte(nil), sig...),
+		}
 		return nil
 	}
 
@@ -1018,12 +1111,12 @@ func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, err
 	if skipVerify {
 		err = aReader.ReadArtifactHeaders()
 		if err != nil {
-			return nil, errors.Wrap(err, "reading artifact error")
+			return nil, nil, errors.Wrap(err, "reading artifact error")
 		}
 	} else {
 		err = aReader.ReadArtifact()
 		if err != nil {
-			return nil, errors.Wrap(err, "reading artifact error")
+			return nil, nil, errors.Wrap(err, "reading artifact error")
 		}
 	}
 
@@ -1034,13 +1127,13 @@ func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, err
 	if metaArtifact.Info.Version == 3 {
 		metaArtifact.Depends, err = aReader.MergeArtifactDepends()
 		if err != nil {
-			return nil, errors.Wrap(err,
+			return nil, nil, errors.Wrap(err,
 				"error parsing version 3 artifact")
 		}
 
 		metaArtifact.Provides, err = aReader.MergeArtifactProvides()
 		if err != nil {
-			return nil, errors.Wrap(err,
+			return nil, nil, errors.Wrap(err,
 				"error parsing version 3 artifact")
 		}
 
@@ -1050,12 +1143,12 @@ func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, err
 	for _, p := range aReader.GetHandlers() {
 		uFiles, err := getUpdateFiles(p.GetUpdateFiles())
 		if err != nil {
-			return nil, errors.Wrap(err, "Cannot get update files:")
+			return nil, nil, errors.Wrap(err, "Cannot get update files:")
 		}
 
 		uMetadata, err := p.GetUpdateMetaData()
 		if err != nil {
-			return nil, errors.Wrap(err, "Cannot get update metadata")
+			return nil, nil, errors.Wrap(err, "Cannot get update metadata")
 		}
 
 		metaArtifact.Updates = append(
@@ -1069,7 +1162,7 @@ func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, err
 			})
 	}
 
-	return metaArtifact, nil
+	return metaArtifact, signature, nil
 }
 
 func getArtifactIDs(artifacts []*model.Image) []string {
@@ -1245,16 +1338,56 @@ func (d *Deployments) CreateDeployment(ctx context.Context,
 		deployment.Groups = groups
 	}
 
+	if err := d.checkReleaseChannel(ctx, constructor, deployment.Groups); err != nil {
+		return "", err
+	}
+
 	if err := d.db.InsertDeployment(ctx, deployment); err != nil {
 		if err == mongo.ErrConflictingDeployment {
 			return "", ErrConflictingDeployment
 		}
 		return "", errors.Wrap(err, "Storing deployment data")
 	}
+	d.submitAuditLog(ctx, workflows.ActionCreateDeployment, deployment.Id,
+		fmt.Sprintf("Created deployment %q of artifact %q to %d devices",
+			deployment.Name, deployment.ArtifactName, deployment.MaxDevices))
 
 	return deployment.Id, nil
 }
 
+// submitAuditLog records a user action on a deployment in the audit trail.
+// Failures are logged rather than returned since the action has already
+// taken effect.
+func (d *Deployments) submitAuditLog(
+	ctx context.Context,
+	action workflows.Action,
+	deploymentID string,
+	change string,
+) {
+	id := identity.FromContext(ctx)
+	if !d.haveAuditLogs || id == nil || !id.IsUser {
+		return
+	}
+	err := d.workflowsClient.SubmitAuditLog(ctx, workflows.AuditLog{
+		Action: action,
+		Actor: workflows.Actor{
+			ID:   id.Subject,
+			Type: workflows.ActorUser,
+		},
+		Object: workflows.Object{
+			ID:   deploymentID,
+			Type: workflows.ObjectDeployment,
+		},
+		Change:  change,
+		EventTS: time.Now(),
+	})
+	if err != nil {
+		log.FromContext(ctx).
+			Errorf("failed to submit audit log for deployment %s: %s",
+				deploymentID, err.Error())
+	}
+}
+
 func (d *Deployments) getDeploymentGroups(
 	ctx context.Context,
 	devices []string,
@@ -1504,6 +1637,8 @@ func (d *Deployments) createDeviceDeploymentWithStatus(
 func (d *Deployments) GetDeploymentForDeviceWithCurrent(ctx context.Context, deviceID string,
 	request *model.DeploymentNextRequest) (*model.DeploymentInstructions, error) {
 
+	l := log.FromContext(ctx)
+
 	deployment, deviceDeployment, err := d.getDeploymentForDevice(ctx, deviceID)
 	if err != nil {
 		return nil, ErrModelInternal
@@ -1511,11 +1646,35 @@ func (d *Deployments) GetDeploymentForDeviceWithCurrent(ctx context.Context, dev
 		return nil, nil
 	}
 
+	// deployments start only within the device maintenance windows
+	if deviceDeployment.Status == model.DeviceDeploymentStatusPending {
+		applies, open, err := d.checkMaintenanceWindows(ctx, deviceID, false)
+		if err != nil {
+			l.Errorf("failed to check the device maintenance windows: %s", err)
+			return nil, ErrModelInternal
+		} else if applies && !open {
+			return nil, nil
+		}
+	}
+
 	err = d.saveDeviceDeploymentRequest(ctx, deviceID, deviceDeployment, request)
 	if err != nil {
 		return nil, err
 	}
-	return d.getDeploymentInstructions(ctx, deployment, deviceDeployment, request)
+	instructions, err := d.getDeploymentInstructions(
+		ctx, deployment, deviceDeployment, request,
+	)
+	if err != nil || instructions == nil ||
+		!request.UpdateControlMap ||
+		deployment.Type == model.DeploymentTypeConfiguration {
+		return instructions, err
+	}
+	instructions.UpdateControlMap, err = d.rebootControlMap(ctx, deviceDeployment)
+	if err != nil {
+		l.Errorf("failed to check the device maintenance windows: %s", err)
+		return nil, ErrModelInternal
+	}
+	return instructions, nil
 }
 
 func (d *Deployments) getDeploymentInstructions(
@@ -1944,6 +2103,15 @@ func (d *Deployments) SaveDeviceDeploymentLog(ctx context.Context, deviceID stri
 	if err := d.db.SaveDeviceDeploymentLog(ctx, dlog); err != nil {
 		return err
 	}
+	// The log is saved: failing to index it only leaves it out of the
+	// log search.
+	err := d.db.ReplaceDeploymentLogEntries(ctx, deviceID, deploymentID,
+		model.NewDeploymentLogEntries(dlog))
+	if err != nil {
+		log.FromContext(ctx).
+			Errorf("failed to index the deployment log of device %s: %s",
+				deviceID, err.Error())
+	}
 
 	return d.db.UpdateDeviceDeploymentLogAvailability(ctx,
 		deviceID, deploymentID, true)
@@ -1988,6 +2156,8 @@ func (d *Deployments) AbortDeployment(ctx context.Context, deploymentID string)
 		deploymentID, model.DeploymentStatusFinished, time.Now()); err != nil {
 		return errors.Wrap(err, "failed to update deployment status")
 	}
+	d.submitAuditLog(ctx, workflows.ActionAbortDeployment, deploymentID,
+		"Aborted deployment")
 
 	return nil
 }
@@ -0,0 +1,195 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+// Errors expected from App interface
+var (
+	ErrModelDeltaUnsupportedArtifact = errors.New(
+		"delta generation is supported only for " +
+			model.DeltaSourceUpdateType + " artifacts",
+	)
+	ErrModelDeltaSameArtifactName = errors.New(
+		"source and target artifacts must have different names",
+	)
+	ErrModelDeltaIncompatibleDeviceTypes = errors.New(
+		"source and target artifacts have no device type in common",
+	)
+)
+
+// providesExcludedFromDelta are the target artifact provides which are
+// set by the artifact header itself rather than copied to the delta.
+var providesExcludedFromDelta = map[string]struct{}{
+	"artifact_name":  {},
+	"artifact_group": {},
+}
+
+// GenerateDeltaImage starts the workflow generating a binary delta
+// artifact which updates devices running the source artifact to the
+// target artifact. The delta is uploaded with the target artifact name,
+// hence it becomes part of the target release and it is picked
+// automatically for devices reporting the source artifact name.
+// Returns the ID of the artifact going to be generated.
+func (d *Deployments) GenerateDeltaImage(
+	ctx context.Context,
+	request *model.GenerateDeltaImageRequest,
+) (string, error) {
+	source, err := d.getDeltaImage(ctx, request.SourceArtifactID)
+	if err != nil {
+		return "", err
+	}
+	target, err := d.getDeltaImage(ctx, request.TargetArtifactID)
+	if err != nil {
+		return "", err
+	}
+	if source.ArtifactMeta.Name == target.ArtifactMeta.Name {
+		return "", ErrModelDeltaSameArtifactName
+	}
+
+	deviceTypes := intersectDeviceTypes(
+		source.ArtifactMeta.DeviceTypesCompatible,
+		target.ArtifactMeta.DeviceTypesCompatible,
+	)
+	if len(deviceTypes) == 0 {
+		return "", ErrModelDeltaIncompatibleDeviceTypes
+	}
+
+	// a delta is unique as long as there is no other delta between
+	// the same artifacts for any of the device types
+	images, err := d.db.ImagesByName(ctx, target.ArtifactMeta.Name)
+	if err != nil {
+		return "", errors.Wrap(err, "Fail to check if artifact is unique")
+	}
+	for _, image := range images {
+		if image.ArtifactMeta.DependsOnArtifactName(source.ArtifactMeta.Name) &&
+			len(intersectDeviceTypes(
+				image.ArtifactMeta.DeviceTypesCompatible, deviceTypes,
+			)) > 0 {
+			return "", ErrModelArtifactNotUnique
+		}
+	}
+
+	args, err := json.Marshal(deltaImageArgs(source, target))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to serialize delta arguments")
+	}
+
+	ctx, err = d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return "", err
+	}
+	sourceLink, err := d.objectStorage.GetRequest(
+		ctx,
+		model.ImagePathFromContext(ctx, source.Id),
+		source.ArtifactMeta.Name+model.ArtifactFileSuffix,
+		DefaultImageGenerationLinkExpire,
+		false,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Generating download link for the source artifact")
+	}
+	targetLink, err := d.objectStorage.GetRequest(
+		ctx,
+		model.ImagePathFromContext(ctx, target.Id),
+		target.ArtifactMeta.Name+model.ArtifactFileSuffix,
+		DefaultImageGenerationLinkExpire,
+		false,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Generating download link for the target artifact")
+	}
+
+	uid, _ := uuid.NewRandom()
+	msg := &model.GenerateDeltaImageMsg{
+		ArtifactID:            uid.String(),
+		Name:                  target.ArtifactMeta.Name,
+		SourceArtifactName:    source.ArtifactMeta.Name,
+		Description:           request.Description,
+		DeviceTypesCompatible: deviceTypes,
+		GetSourceArtifactURI:  sourceLink.Uri,
+		GetTargetArtifactURI:  targetLink.Uri,
+		Args:                  string(args),
+	}
+	if id := identity.FromContext(ctx); id != nil {
+		msg.TenantID = id.Tenant
+	}
+	if err := d.workflowsClient.StartGenerateDelta(ctx, msg); err != nil {
+		return "", err
+	}
+
+	return msg.ArtifactID, nil
+}
+
+// getDeltaImage returns the image with the given ID if it can be used
+// as source or target of a delta artifact.
+func (d *Deployments) getDeltaImage(ctx context.Context, id string) (*model.Image, error) {
+	image, err := d.db.FindImageByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "Searching for image with specified ID")
+	} else if image == nil {
+		return nil, ErrImageMetaNotFound
+	}
+	if image.ArtifactMeta.UpdateType() != model.DeltaSourceUpdateType ||
+		image.ArtifactMeta.Depends[model.ArtifactDependsArtifactName] != nil {
+		return nil, ErrModelDeltaUnsupportedArtifact
+	}
+	return image, nil
+}
+
+func deltaImageArgs(source, target *model.Image) *model.GenerateDeltaImageArgs {
+	args := &model.GenerateDeltaImageArgs{
+		Depends:        map[string]string{},
+		Provides:       map[string]string{},
+		ClearsProvides: target.ArtifactMeta.ClearsProvides,
+	}
+	// the patch applies only on top of the exact source filesystem
+	prefix := model.DeltaSourceUpdateType + "."
+	for key, value := range source.ArtifactMeta.Provides {
+		if strings.HasPrefix(key, prefix) {
+			args.Depends[key] = value
+		}
+	}
+	for key, value := range target.ArtifactMeta.Provides {
+		if _, excluded := providesExcludedFromDelta[key]; !excluded {
+			args.Provides[key] = value
+		}
+	}
+	return args
+}
+
+func intersectDeviceTypes(a, b []string) []string {
+	result := []string{}
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				result = append(result, x)
+				break
+			}
+		}
+	}
+	return result
+}
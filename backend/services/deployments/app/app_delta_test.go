@@ -0,0 +1,242 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	workflows_mocks "github.com/mendersoftware/mender-server/services/deployments/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	fs_mocks "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func makeDeltaTestImage(id, name, updateType string, deviceTypes ...string) *model.Image {
+	return &model.Image{
+		Id:        id,
+		ImageMeta: &model.ImageMeta{},
+		ArtifactMeta: &model.ArtifactMeta{
+			Name:                  name,
+			DeviceTypesCompatible: deviceTypes,
+			Updates: []model.Update{{
+				TypeInfo: model.ArtifactUpdateTypeInfo{Type: &updateType},
+			}},
+			Provides: map[string]string{
+				"artifact_name":         name,
+				"rootfs-image.checksum": name + "-checksum",
+				"rootfs-image.version":  name,
+			},
+			Depends: map[string]interface{}{
+				"device_type": deviceTypes,
+			},
+			ClearsProvides: []string{"rootfs-image.*"},
+		},
+	}
+}
+
+func TestGenerateDeltaImage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sourceID = "b5e5e6b1-0a3e-4a4c-9b0e-0c2b3a4d5e6f"
+		targetID = "c6f6f7c2-1b4f-4b5d-8c1f-1d3c4b5e6f70"
+	)
+	request := &model.GenerateDeltaImageRequest{
+		SourceArtifactID: sourceID,
+		TargetArtifactID: targetID,
+		Description:      "delta v1 to v2",
+	}
+	source := makeDeltaTestImage(sourceID, "v1", "rootfs-image", "foo", "bar")
+	target := makeDeltaTestImage(targetID, "v2", "rootfs-image", "bar", "baz")
+
+	existingDelta := makeDeltaTestImage(
+		"d7a7a8d3-2c5a-4c6e-9d2a-2e4d5c6f7a81", "v2", model.DeltaUpdateType, "bar",
+	)
+	existingDelta.ArtifactMeta.Depends[model.ArtifactDependsArtifactName] = []interface{}{"v1"}
+
+	testCases := map[string]struct {
+		source    *model.Image
+		target    *model.Image
+		sourceErr error
+		images    []*model.Image
+		linkErr   error
+		flowErr   error
+
+		err string
+	}{
+		"ok": {
+			source: source,
+			target: target,
+			images: []*model.Image{target},
+		},
+		"ok, delta for a different source exists": {
+			source: source,
+			target: target,
+			images: []*model.Image{target, func() *model.Image {
+				img := makeDeltaTestImage(
+					"e8b8b9e4-3d6b-4d7f-8e3b-3f5e6d7a8b92", "v2",
+					model.DeltaUpdateType, "bar",
+				)
+				img.ArtifactMeta.Depends[model.ArtifactDependsArtifactName] = "v0"
+				return img
+			}()},
+		},
+		"error, source not found": {
+			err: ErrImageMetaNotFound.Error(),
+		},
+		"error, looking up source": {
+			sourceErr: errors.New("internal error"),
+			err:       "Searching for image with specified ID: internal error",
+		},
+		"error, unsupported update type": {
+			source: makeDeltaTestImage(sourceID, "v1", "single-file", "foo"),
+			target: target,
+			err:    ErrModelDeltaUnsupportedArtifact.Error(),
+		},
+		"error, source is a delta": {
+			source: existingDelta,
+			target: target,
+			err:    ErrModelDeltaUnsupportedArtifact.Error(),
+		},
+		"error, same artifact name": {
+			source: source,
+			target: makeDeltaTestImage(targetID, "v1", "rootfs-image", "foo"),
+			err:    ErrModelDeltaSameArtifactName.Error(),
+		},
+		"error, incompatible device types": {
+			source: source,
+			target: makeDeltaTestImage(targetID, "v2", "rootfs-image", "baz"),
+			err:    ErrModelDeltaIncompatibleDeviceTypes.Error(),
+		},
+		"error, delta already exists": {
+			source: source,
+			target: target,
+			images: []*model.Image{target, existingDelta},
+			err:    ErrModelArtifactNotUnique.Error(),
+		},
+		"error, generating link": {
+			source:  source,
+			target:  target,
+			images:  []*model.Image{target},
+			linkErr: errors.New("storage error"),
+			err:     "Generating download link for the source artifact: storage error",
+		},
+		"error, starting workflow": {
+			source:  source,
+			target:  target,
+			images:  []*model.Image{target},
+			flowErr: errors.New("failed to start workflow: generate_delta"),
+			err:     "failed to start workflow: generate_delta",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: "tenant",
+			})
+			db := &mocks.DataStore{}
+			fs := &fs_mocks.ObjectStorage{}
+			workflowsClient := &workflows_mocks.Client{}
+			d := NewDeployments(db, fs, 0, false)
+			d.SetWorkflowsClient(workflowsClient)
+			defer db.AssertExpectations(t)
+			defer fs.AssertExpectations(t)
+			defer workflowsClient.AssertExpectations(t)
+
+			db.On("FindImageByID", ctx, sourceID).
+				Return(tc.source, tc.sourceErr)
+			if tc.source == nil ||
+				tc.source.ArtifactMeta.UpdateType() != "rootfs-image" ||
+				tc.source.ArtifactMeta.Depends[model.ArtifactDependsArtifactName] != nil {
+				_, err := d.GenerateDeltaImage(ctx, request)
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			db.On("FindImageByID", ctx, targetID).
+				Return(tc.target, nil)
+			if tc.images != nil {
+				db.On("ImagesByName", ctx, tc.target.ArtifactMeta.Name).
+					Return(tc.images, nil)
+			}
+			if tc.err == "" || tc.linkErr != nil || tc.flowErr != nil {
+				db.On("GetStorageSettings", ctx).Return(nil, nil)
+				fs.On("GetRequest",
+					h.ContextMatcher(),
+					"tenant/"+sourceID,
+					"v1.mender",
+					DefaultImageGenerationLinkExpire,
+					false,
+				).Return(&model.Link{Uri: "http://source"}, tc.linkErr)
+			}
+			if tc.err == "" || tc.flowErr != nil {
+				fs.On("GetRequest",
+					h.ContextMatcher(),
+					"tenant/"+targetID,
+					"v2.mender",
+					DefaultImageGenerationLinkExpire,
+					false,
+				).Return(&model.Link{Uri: "http://target"}, nil)
+				workflowsClient.On("StartGenerateDelta",
+					h.ContextMatcher(),
+					mock.MatchedBy(func(msg *model.GenerateDeltaImageMsg) bool {
+						var args model.GenerateDeltaImageArgs
+						err := json.Unmarshal([]byte(msg.Args), &args)
+						return assert.NoError(t, err) &&
+							assert.NotEmpty(t, msg.ArtifactID) &&
+							assert.Equal(t, "v2", msg.Name) &&
+							assert.Equal(t, "v1", msg.SourceArtifactName) &&
+							assert.Equal(t, request.Description, msg.Description) &&
+							assert.Equal(t, []string{"bar"}, msg.DeviceTypesCompatible) &&
+							assert.Equal(t, "http://source", msg.GetSourceArtifactURI) &&
+							assert.Equal(t, "http://target", msg.GetTargetArtifactURI) &&
+							assert.Equal(t, "tenant", msg.TenantID) &&
+							assert.Equal(t, model.GenerateDeltaImageArgs{
+								Depends: map[string]string{
+									"rootfs-image.checksum": "v1-checksum",
+									"rootfs-image.version":  "v1",
+								},
+								Provides: map[string]string{
+									"rootfs-image.checksum": "v2-checksum",
+									"rootfs-image.version":  "v2",
+								},
+								ClearsProvides: []string{"rootfs-image.*"},
+							}, args)
+					}),
+				).Return(tc.flowErr)
+			}
+
+			artifactID, err := d.GenerateDeltaImage(ctx, request)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Empty(t, artifactID)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, artifactID)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+// SearchDeploymentLogs searches the parsed lines of the device deployment
+// logs.
+func (d *Deployments) SearchDeploymentLogs(
+	ctx context.Context,
+	query store.DeploymentLogQuery,
+) ([]model.DeploymentLogEntry, int, error) {
+	entries, count, err := d.db.SearchDeploymentLogEntries(ctx, query)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to search the deployment logs")
+	}
+	return entries, count, nil
+}
+
+// ClusterDeploymentLogs groups the identical lines of the device deployment
+// logs, counting the devices which logged each of them. Unless the query
+// selects the levels, only the error lines are clustered.
+func (d *Deployments) ClusterDeploymentLogs(
+	ctx context.Context,
+	query store.DeploymentLogQuery,
+) (*model.DeploymentLogClusters, error) {
+	if len(query.Levels) == 0 {
+		query.Levels = model.LogErrorLevels
+	}
+	clusters, err := d.db.ClusterDeploymentLogEntries(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to cluster the deployment logs")
+	}
+	return clusters, nil
+}
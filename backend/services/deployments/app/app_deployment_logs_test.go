@@ -0,0 +1,150 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+)
+
+func TestSaveDeviceDeploymentLogIndexesEntries(t *testing.T) {
+	t.Parallel()
+
+	const deploymentID = "b3f6e2e6-1b4c-4a55-a4a1-b1f5c3d0e4a2"
+	ctx := context.Background()
+	now := time.Now().UTC()
+	messages := []model.LogMessage{{
+		Timestamp: &now,
+		Level:     "info",
+		Message:   "ERROR: No space left on device",
+	}}
+
+	testCases := map[string]struct {
+		replaceErr error
+		err        error
+	}{
+		"ok": {},
+		"ok, indexing is best effort": {
+			replaceErr: errors.New("connection refused"),
+		},
+		"error, log availability": {
+			err: errors.New("internal error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("HasDeploymentForDevice", ctx, deploymentID, "device").
+				Return(true, nil)
+			db.On("SaveDeviceDeploymentLog", ctx, model.DeploymentLog{
+				DeviceID:     "device",
+				DeploymentID: deploymentID,
+				Messages:     messages,
+			}).Return(nil)
+			db.On("ReplaceDeploymentLogEntries", ctx, "device", deploymentID,
+				[]model.DeploymentLogEntry{{
+					DeviceID:     "device",
+					DeploymentID: deploymentID,
+					Timestamp:    now,
+					Level:        model.LogLevelError,
+					Message:      "No space left on device",
+				}},
+			).Return(tc.replaceErr)
+			db.On("UpdateDeviceDeploymentLogAvailability", ctx,
+				"device", deploymentID, true).Return(tc.err)
+
+			err := NewDeployments(db, nil, 0, false).
+				SaveDeviceDeploymentLog(ctx, "device", deploymentID, messages)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clusters := &model.DeploymentLogClusters{
+		DeviceCount: 300,
+		Clusters: []model.DeploymentLogCluster{{
+			Message:     "No space left on device",
+			Level:       model.LogLevelError,
+			Count:       280,
+			DeviceCount: 280,
+		}},
+	}
+
+	testCases := map[string]struct {
+		query  store.DeploymentLogQuery
+		levels []string
+		dbErr  error
+		err    error
+	}{
+		"ok, error levels by default": {
+			query:  store.DeploymentLogQuery{DeploymentID: "deployment", Limit: 20},
+			levels: model.LogErrorLevels,
+		},
+		"ok, levels": {
+			query: store.DeploymentLogQuery{
+				Levels: []string{model.LogLevelWarning},
+				Limit:  20,
+			},
+			levels: []string{model.LogLevelWarning},
+		},
+		"error": {
+			query:  store.DeploymentLogQuery{Limit: 20},
+			levels: model.LogErrorLevels,
+			dbErr:  errors.New("connection refused"),
+			err:    errors.New("failed to cluster the deployment logs: connection refused"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query := tc.query
+			query.Levels = tc.levels
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			if tc.dbErr != nil {
+				db.On("ClusterDeploymentLogEntries", ctx, query).Return(nil, tc.dbErr)
+			} else {
+				db.On("ClusterDeploymentLogEntries", ctx, query).Return(clusters, nil)
+			}
+
+			result, err := NewDeployments(db, nil, 0, false).
+				ClusterDeploymentLogs(ctx, tc.query)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, clusters, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+// PreviewDeployment resolves the devices targeted by the deployment
+// constructor and evaluates, without creating the deployment, which of
+// them would be updated, which already run the artifact and which are
+// incompatible with every artifact in the release.
+func (d *Deployments) PreviewDeployment(
+	ctx context.Context,
+	constructor *model.DeploymentConstructor,
+) (*model.DeploymentPreview, error) {
+	l := log.FromContext(ctx)
+
+	if constructor == nil {
+		return nil, ErrModelMissingInput
+	}
+	if err := constructor.ValidateNew(); err != nil {
+		return nil, errors.Wrap(err, "Validating deployment")
+	}
+
+	id := identity.FromContext(ctx)
+	if id == nil {
+		l.Error("identity not present in the context")
+		return nil, ErrModelInternal
+	}
+
+	artifacts, err := d.db.ImagesByName(ctx, constructor.ArtifactName)
+	if err != nil {
+		return nil, errors.Wrap(err, "Finding artifact with given name")
+	}
+	if len(artifacts) == 0 {
+		return nil, ErrNoArtifact
+	}
+
+	searchParams := model.SearchParams{
+		Page:    1,
+		PerPage: PerPageInventoryDevices,
+	}
+	if len(constructor.Devices) > 0 {
+		searchParams.DeviceIDs = constructor.Devices
+	} else {
+		searchParams.Filters = []model.FilterPredicate{
+			{
+				Scope:     InventoryIdentityScope,
+				Attribute: InventoryStatusAttributeName,
+				Type:      "$eq",
+				Value:     InventoryStatusAccepted,
+			},
+		}
+		if len(constructor.Group) > 0 {
+			searchParams.Filters = append(
+				searchParams.Filters,
+				model.FilterPredicate{
+					Scope:     InventoryGroupScope,
+					Attribute: InventoryGroupAttributeName,
+					Type:      "$eq",
+					Value:     constructor.Group,
+				})
+		}
+	}
+
+	preview := model.NewDeploymentPreview(constructor.ArtifactName)
+	found := make(map[string]struct{})
+	for {
+		devices, count, err := d.search(ctx, id.Tenant, searchParams)
+		if err != nil {
+			l.Errorf("error searching for devices: %s", err)
+			return nil, ErrModelInternal
+		}
+		for i := range devices {
+			found[devices[i].ID] = struct{}{}
+			preview.Add(previewDevice(constructor, artifacts, &devices[i]))
+		}
+		if len(devices) < 1 || len(found) >= count {
+			break
+		}
+		searchParams.Page++
+	}
+
+	// devices explicitly targeted which are unknown to the inventory
+	for _, deviceID := range constructor.Devices {
+		if _, ok := found[deviceID]; ok {
+			continue
+		}
+		found[deviceID] = struct{}{}
+		preview.Add(model.DeploymentPreviewDevice{
+			ID:      deviceID,
+			Reason:  model.PreviewReasonNoInventory,
+			Details: "the device has not reported its inventory",
+		})
+	}
+	if preview.DeviceCount == 0 {
+		return nil, ErrNoDevices
+	}
+
+	return preview, nil
+}
+
+// previewDevice evaluates the outcome of the deployment for the device,
+// matching the checks the device deployment is subject to when the
+// device polls for the update.
+func previewDevice(
+	constructor *model.DeploymentConstructor,
+	artifacts []*model.Image,
+	device *model.InvDevice,
+) model.DeploymentPreviewDevice {
+	attrs := device.InventoryAttributes()
+	dev := model.DeploymentPreviewDevice{
+		ID:           device.ID,
+		DeviceType:   attrs[model.AttrNameDeviceType],
+		ArtifactName: attrs[model.AttrNameArtifactName],
+	}
+	if dev.DeviceType == "" {
+		dev.Reason = model.PreviewReasonNoInventory
+		dev.Details = "the device has not reported its device type"
+		return dev
+	}
+	if !constructor.ForceInstallation && dev.ArtifactName == constructor.ArtifactName {
+		dev.Reason = model.PreviewReasonAlreadyInstalled
+		dev.Details = "the device already runs the artifact"
+		return dev
+	}
+
+	// report the mismatching depends of an artifact compatible with the
+	// device type over a device type mismatch
+	for _, artifact := range artifacts {
+		if artifact.ArtifactMeta == nil {
+			continue
+		}
+		reason, details := artifact.ArtifactMeta.CheckCompatibility(attrs)
+		if reason == "" {
+			dev.Reason, dev.Details = "", ""
+			return dev
+		}
+		if dev.Reason != model.PreviewReasonDependsMismatch {
+			dev.Reason, dev.Details = reason, details
+		}
+	}
+	return dev
+}
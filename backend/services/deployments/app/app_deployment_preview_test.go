@@ -0,0 +1,253 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	inventory_mocks "github.com/mendersoftware/mender-server/services/deployments/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+)
+
+func previewInvDevice(id string, attrs map[string]interface{}) model.InvDevice {
+	dev := model.InvDevice{ID: id}
+	for name, value := range attrs {
+		dev.Attributes = append(dev.Attributes, model.DeviceAttribute{
+			Name:  name,
+			Value: value,
+			Scope: model.AttrScopeInventory,
+		})
+	}
+	return dev
+}
+
+func TestPreviewDeployment(t *testing.T) {
+	t.Parallel()
+
+	artifacts := []*model.Image{{
+		Id: "rpi",
+		ArtifactMeta: &model.ArtifactMeta{
+			Name:                  "release-2",
+			DeviceTypesCompatible: []string{"rpi3", "rpi4"},
+			Depends: map[string]interface{}{
+				"device_type":   []interface{}{"rpi3", "rpi4"},
+				"artifact_name": []interface{}{"release-1"},
+			},
+		},
+	}, {
+		Id: "qemu",
+		ArtifactMeta: &model.ArtifactMeta{
+			Name:                  "release-2",
+			DeviceTypesCompatible: []string{"qemu"},
+			Depends: map[string]interface{}{
+				"device_type": "qemu",
+			},
+		},
+	}}
+	devices := []model.InvDevice{
+		previewInvDevice("update-rpi", map[string]interface{}{
+			"device_type":   "rpi4",
+			"artifact_name": "release-1",
+		}),
+		previewInvDevice("update-qemu", map[string]interface{}{
+			"device_type":   "qemu",
+			"artifact_name": "release-0",
+		}),
+		previewInvDevice("installed", map[string]interface{}{
+			"device_type":   "qemu",
+			"artifact_name": "release-2",
+		}),
+		previewInvDevice("depends", map[string]interface{}{
+			"device_type":   "rpi3",
+			"artifact_name": "release-0",
+		}),
+		previewInvDevice("device-type", map[string]interface{}{
+			"device_type":   "beaglebone",
+			"artifact_name": "release-1",
+		}),
+		previewInvDevice("no-inventory", nil),
+	}
+
+	testCases := map[string]struct {
+		Constructor *model.DeploymentConstructor
+
+		Artifacts   []*model.Image
+		SearchPages [][]model.InvDevice
+		SearchErr   error
+
+		Update           []string
+		AlreadyInstalled []string
+		Incompatible     map[string]string
+		Error            error
+	}{
+		"ok, all devices": {
+			Constructor: &model.DeploymentConstructor{
+				Name:         "preview",
+				ArtifactName: "release-2",
+				AllDevices:   true,
+			},
+			Artifacts:        artifacts,
+			SearchPages:      [][]model.InvDevice{devices},
+			Update:           []string{"update-rpi", "update-qemu"},
+			AlreadyInstalled: []string{"installed"},
+			Incompatible: map[string]string{
+				"depends":      model.PreviewReasonDependsMismatch,
+				"device-type":  model.PreviewReasonDeviceTypeMismatch,
+				"no-inventory": model.PreviewReasonNoInventory,
+			},
+		},
+		"ok, force installation, multiple pages": {
+			Constructor: &model.DeploymentConstructor{
+				Name:              "preview",
+				ArtifactName:      "release-2",
+				Group:             "production",
+				ForceInstallation: true,
+			},
+			Artifacts:   artifacts,
+			SearchPages: [][]model.InvDevice{devices[:2], devices[2:3]},
+			Update:      []string{"update-rpi", "update-qemu", "installed"},
+		},
+		"ok, device list with unknown device": {
+			Constructor: &model.DeploymentConstructor{
+				Name:         "preview",
+				ArtifactName: "release-2",
+				Devices:      []string{"update-rpi", "unknown"},
+			},
+			Artifacts:   artifacts,
+			SearchPages: [][]model.InvDevice{devices[:1]},
+			Update:      []string{"update-rpi"},
+			Incompatible: map[string]string{
+				"unknown": model.PreviewReasonNoInventory,
+			},
+		},
+		"error, no artifact": {
+			Constructor: &model.DeploymentConstructor{
+				Name:         "preview",
+				ArtifactName: "release-2",
+				AllDevices:   true,
+			},
+			Error: ErrNoArtifact,
+		},
+		"error, no devices": {
+			Constructor: &model.DeploymentConstructor{
+				Name:         "preview",
+				ArtifactName: "release-2",
+				Group:        "empty",
+			},
+			Artifacts:   artifacts,
+			SearchPages: [][]model.InvDevice{{}},
+			Error:       ErrNoDevices,
+		},
+		"error, search": {
+			Constructor: &model.DeploymentConstructor{
+				Name:         "preview",
+				ArtifactName: "release-2",
+				AllDevices:   true,
+			},
+			Artifacts: artifacts,
+			SearchErr: errors.New("connection refused"),
+			Error:     ErrModelInternal,
+		},
+		"error, invalid constructor": {
+			Constructor: &model.DeploymentConstructor{
+				Name:         "preview",
+				ArtifactName: "release-2",
+			},
+			Error: model.ErrInvalidDeploymentDefinitionNoDevices,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: "tenant",
+			})
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			if tc.Artifacts != nil || tc.Error == ErrNoArtifact {
+				db.On("ImagesByName", ctx, tc.Constructor.ArtifactName).
+					Return(tc.Artifacts, nil)
+			}
+
+			inv := &inventory_mocks.Client{}
+			defer inv.AssertExpectations(t)
+			count := 0
+			for _, page := range tc.SearchPages {
+				count += len(page)
+			}
+			for i, page := range tc.SearchPages {
+				inv.On("Search", ctx, "tenant",
+					mock.MatchedBy(func(params model.SearchParams) bool {
+						if len(tc.Constructor.Devices) > 0 &&
+							!assert.Equal(t, tc.Constructor.Devices, params.DeviceIDs) {
+							return false
+						}
+						return params.Page == i+1
+					}),
+				).Return(page, count, nil).Once()
+			}
+			if tc.SearchErr != nil {
+				inv.On("Search", ctx, "tenant", mock.AnythingOfType("model.SearchParams")).
+					Return(nil, 0, tc.SearchErr)
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			d.SetInventoryClient(inv)
+
+			preview, err := d.PreviewDeployment(ctx, tc.Constructor)
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := func(group model.DeploymentPreviewGroup) []string {
+				ret := []string{}
+				for _, dev := range group.Devices {
+					ret = append(ret, dev.ID)
+				}
+				return ret
+			}
+			if tc.Update == nil {
+				tc.Update = []string{}
+			}
+			if tc.AlreadyInstalled == nil {
+				tc.AlreadyInstalled = []string{}
+			}
+			assert.Equal(t, tc.Update, ids(preview.Update))
+			assert.Equal(t, tc.AlreadyInstalled, ids(preview.AlreadyInstalled))
+			reasons := map[string]int{}
+			for _, dev := range preview.Incompatible.Devices {
+				assert.Equal(t, tc.Incompatible[dev.ID], dev.Reason, dev.ID)
+				assert.NotEmpty(t, dev.Details)
+				reasons[dev.Reason]++
+			}
+			assert.Len(t, preview.Incompatible.Devices, len(tc.Incompatible))
+			assert.Equal(t, reasons, preview.IncompatibleReasons)
+			assert.Equal(t,
+				len(tc.Update)+len(tc.AlreadyInstalled)+len(tc.Incompatible),
+				preview.DeviceCount,
+			)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+var (
+	ErrRollbackConfigurationDeployment = errors.New(
+		"configuration deployments cannot be rolled back",
+	)
+	ErrRollbackNoDevices = errors.New(
+		"none of the devices of the deployment can be rolled back",
+	)
+	ErrRollbackIncomplete = errors.New(
+		"some of the rollback deployments could not be created",
+	)
+)
+
+// RollbackDeployment creates deployments reinstalling on the devices of the
+// deployment the artifact they ran before it. Only the devices which
+// finished the deployment, successfully or not, and were not deployed to
+// since then are rolled back; the previous artifact of a device is the one
+// of its latest successful deployment created before the deployment. The
+// devices are grouped by their previous artifact, and each group gets a
+// deployment linked to the rolled back deployment. All the deployments are
+// validated before creating any of them; if one still fails to be created,
+// the rollback created so far is returned with ErrRollbackIncomplete.
+func (d *Deployments) RollbackDeployment(
+	ctx context.Context,
+	deploymentID string,
+	request model.DeploymentRollbackRequest,
+) (*model.DeploymentRollback, error) {
+	deployment, err := d.db.FindDeploymentByID(ctx, deploymentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Searching for deployment by ID")
+	} else if deployment == nil {
+		return nil, ErrModelDeploymentNotFound
+	} else if deployment.Type == model.DeploymentTypeConfiguration {
+		return nil, ErrRollbackConfigurationDeployment
+	}
+
+	deviceDeployments, err := d.db.GetDeviceStatusesForDeployment(ctx, deploymentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the device deployments")
+	}
+
+	rollback := &model.DeploymentRollback{
+		DeploymentID:   deploymentID,
+		Deployments:    []model.DeploymentRollbackItem{},
+		SkippedDevices: []model.DeploymentRollbackSkippedDevice{},
+	}
+	skip := func(deviceID, reason string) {
+		rollback.SkippedDevices = append(rollback.SkippedDevices,
+			model.DeploymentRollbackSkippedDevice{DeviceID: deviceID, Reason: reason})
+	}
+
+	var devices []string
+	for _, dd := range deviceDeployments {
+		switch dd.Status {
+		case model.DeviceDeploymentStatusSuccess, model.DeviceDeploymentStatusFailure:
+			devices = append(devices, dd.DeviceId)
+		default:
+			if dd.Status.Active() {
+				skip(dd.DeviceId, model.RollbackSkipNotFinished)
+			} else {
+				skip(dd.DeviceId, model.RollbackSkipNotInstalled)
+			}
+		}
+	}
+
+	previous := make(map[string][]string)
+	for start := 0; start < len(devices); start += MaxDeviceArrayLength {
+		end := start + MaxDeviceArrayLength
+		if end > len(devices) {
+			end = len(devices)
+		}
+		batch, err := d.previousArtifacts(ctx, deployment, devices[start:end], skip)
+		if err != nil {
+			return nil, err
+		}
+		for artifactName, batchDevices := range batch {
+			previous[artifactName] = append(previous[artifactName], batchDevices...)
+		}
+	}
+
+	artifactNames := make([]string, 0, len(previous))
+	for artifactName := range previous {
+		artifactNames = append(artifactNames, artifactName)
+	}
+	sort.Strings(artifactNames)
+	constructors := make([]*model.DeploymentConstructor, 0, len(artifactNames))
+	for _, artifactName := range artifactNames {
+		devices := previous[artifactName]
+		sort.Strings(devices)
+		constructor := &model.DeploymentConstructor{
+			Name:         request.DeploymentName(deployment),
+			ArtifactName: artifactName,
+			Devices:      devices,
+			RollbackOf:   deploymentID,
+		}
+		err := d.validateRollbackDeployment(ctx, constructor)
+		if errors.Is(err, ErrNoArtifact) {
+			for _, device := range devices {
+				skip(device, model.RollbackSkipNoArtifact)
+			}
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err,
+				"cannot roll back to the artifact %q", artifactName)
+		}
+		constructors = append(constructors, constructor)
+	}
+
+	for i, constructor := range constructors {
+		id, err := d.CreateDeployment(ctx, constructor)
+		if errors.Is(err, ErrNoArtifact) {
+			// the artifact was deleted in the meantime
+			for _, device := range constructor.Devices {
+				skip(device, model.RollbackSkipNoArtifact)
+			}
+			continue
+		} else if err != nil {
+			err = errors.Wrapf(err, "failed to create the deployment of the artifact %q",
+				constructor.ArtifactName)
+			if len(rollback.Deployments) == 0 {
+				return nil, err
+			}
+			log.FromContext(ctx).Error(err)
+			rollback.Error = err.Error()
+			for _, notCreated := range constructors[i:] {
+				for _, device := range notCreated.Devices {
+					skip(device, model.RollbackSkipNotCreated)
+				}
+			}
+			return rollback, ErrRollbackIncomplete
+		}
+		rollback.Deployments = append(rollback.Deployments, model.DeploymentRollbackItem{
+			ID:           id,
+			ArtifactName: constructor.ArtifactName,
+			Devices:      constructor.Devices,
+		})
+	}
+	if len(rollback.Deployments) == 0 {
+		return rollback, ErrRollbackNoDevices
+	}
+	return rollback, nil
+}
+
+// validateRollbackDeployment runs the checks of CreateDeployment which may
+// reject a rollback deployment, without creating it.
+func (d *Deployments) validateRollbackDeployment(
+	ctx context.Context,
+	constructor *model.DeploymentConstructor,
+) error {
+	if err := constructor.Validate(); err != nil {
+		return errors.Wrap(err, "Validating deployment")
+	}
+	artifacts, err := d.db.ImagesByName(ctx, constructor.ArtifactName)
+	if err != nil {
+		return errors.Wrap(err, "Finding artifact with given name")
+	} else if len(artifacts) == 0 {
+		return ErrNoArtifact
+	}
+	groups, err := d.getDeploymentGroups(ctx, constructor.Devices)
+	if err != nil {
+		return err
+	}
+	return d.checkReleaseChannel(ctx, constructor, groups)
+}
+
+// previousArtifacts groups the devices by the artifact they ran before the
+// deployment; the devices which cannot be rolled back are skipped.
+func (d *Deployments) previousArtifacts(
+	ctx context.Context,
+	deployment *model.Deployment,
+	devices []string,
+	skip func(deviceID, reason string),
+) (map[string][]string, error) {
+	lastStatuses, err := d.db.GetLastDeviceDeploymentStatus(ctx, devices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the last device deployment statuses")
+	}
+	newer := make(map[string]bool, len(lastStatuses))
+	for _, lastStatus := range lastStatuses {
+		if lastStatus.DeploymentId != deployment.Id {
+			newer[lastStatus.DeviceId] = true
+		}
+	}
+
+	var candidates []string
+	for _, device := range devices {
+		if newer[device] {
+			skip(device, model.RollbackSkipNewerDeployment)
+		} else {
+			candidates = append(candidates, device)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	successful, err := d.db.GetLastSuccessfulDeviceDeployments(ctx,
+		candidates, *deployment.Created)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the previous device deployments")
+	}
+	artifacts := make(map[string]string, len(successful))
+	for _, dd := range successful {
+		if dd.Image != nil && dd.Image.ArtifactMeta != nil &&
+			dd.Image.ArtifactMeta.Name != deployment.ArtifactName {
+			artifacts[dd.DeviceId] = dd.Image.ArtifactMeta.Name
+		}
+	}
+
+	previous := make(map[string][]string)
+	for _, device := range candidates {
+		artifactName, ok := artifacts[device]
+		if !ok {
+			skip(device, model.RollbackSkipNoPrevious)
+			continue
+		}
+		previous[artifactName] = append(previous[artifactName], device)
+	}
+	return previous, nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	inventory_mocks "github.com/mendersoftware/mender-server/services/deployments/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+)
+
+func rollbackDeviceDeployment(
+	device string,
+	status model.DeviceDeploymentStatus,
+	artifact string,
+) model.DeviceDeployment {
+	dd := model.DeviceDeployment{
+		DeviceId: device,
+		Status:   status,
+	}
+	if artifact != "" {
+		dd.Image = &model.Image{ArtifactMeta: &model.ArtifactMeta{Name: artifact}}
+	}
+	return dd
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	t.Parallel()
+
+	const deploymentID = "a2e3f5b4-8d7c-4a0e-9e1f-3b2c1d0e9f8a"
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	deployment := &model.Deployment{
+		Id:      deploymentID,
+		Created: &created,
+		DeploymentConstructor: &model.DeploymentConstructor{
+			Name:         "v3",
+			ArtifactName: "v3",
+		},
+	}
+
+	testCases := map[string]struct {
+		Request            model.DeploymentRollbackRequest
+		Deployment         *model.Deployment
+		DeviceDeployments  []model.DeviceDeployment
+		LastStatuses       []model.DeviceDeploymentLastStatus
+		Successful         []model.DeviceDeployment
+		MissingArtifacts   []string
+		Channels           []model.ReleaseChannel
+		Releases           map[string]*model.Release
+		InsertErrors       []error
+		ExpectedRollback   *model.DeploymentRollback
+		ExpectedDeployment map[string][]string
+		Error              error
+	}{
+		"ok": {
+			Deployment: deployment,
+			DeviceDeployments: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v3"),
+				rollbackDeviceDeployment("device-2", model.DeviceDeploymentStatusFailure, "v3"),
+				rollbackDeviceDeployment("device-3", model.DeviceDeploymentStatusSuccess, "v3"),
+				rollbackDeviceDeployment("device-4", model.DeviceDeploymentStatusSuccess, "v3"),
+				rollbackDeviceDeployment("device-5", model.DeviceDeploymentStatusSuccess, "v3"),
+				rollbackDeviceDeployment("device-6", model.DeviceDeploymentStatusDownloading, "v3"),
+				rollbackDeviceDeployment("device-7", model.DeviceDeploymentStatusNoArtifact, ""),
+				rollbackDeviceDeployment("device-8", model.DeviceDeploymentStatusSuccess, "v3"),
+			},
+			LastStatuses: []model.DeviceDeploymentLastStatus{
+				{DeviceId: "device-1", DeploymentId: deploymentID},
+				{DeviceId: "device-2", DeploymentId: deploymentID},
+				{DeviceId: "device-4", DeploymentId: "newer"},
+			},
+			Successful: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v2"),
+				rollbackDeviceDeployment("device-2", model.DeviceDeploymentStatusSuccess, "v2"),
+				rollbackDeviceDeployment("device-3", model.DeviceDeploymentStatusSuccess, "v1"),
+				rollbackDeviceDeployment("device-8", model.DeviceDeploymentStatusSuccess, "v0"),
+			},
+			MissingArtifacts: []string{"v0"},
+			ExpectedDeployment: map[string][]string{
+				"v1": {"device-3"},
+				"v2": {"device-1", "device-2"},
+			},
+			ExpectedRollback: &model.DeploymentRollback{
+				DeploymentID: deploymentID,
+				Deployments: []model.DeploymentRollbackItem{{
+					ArtifactName: "v1",
+					Devices:      []string{"device-3"},
+				}, {
+					ArtifactName: "v2",
+					Devices:      []string{"device-1", "device-2"},
+				}},
+				SkippedDevices: []model.DeploymentRollbackSkippedDevice{
+					{DeviceID: "device-6", Reason: model.RollbackSkipNotFinished},
+					{DeviceID: "device-7", Reason: model.RollbackSkipNotInstalled},
+					{DeviceID: "device-4", Reason: model.RollbackSkipNewerDeployment},
+					{DeviceID: "device-5", Reason: model.RollbackSkipNoPrevious},
+					{DeviceID: "device-8", Reason: model.RollbackSkipNoArtifact},
+				},
+			},
+		},
+		"error, release channel restriction": {
+			Deployment: deployment,
+			DeviceDeployments: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v3"),
+				rollbackDeviceDeployment("device-2", model.DeviceDeploymentStatusSuccess, "v3"),
+			},
+			Successful: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v1"),
+				rollbackDeviceDeployment("device-2", model.DeviceDeploymentStatusSuccess, "v2"),
+			},
+			Channels: []model.ReleaseChannel{{
+				Name:   "production",
+				Rank:   1,
+				Groups: []string{"production"},
+			}},
+			// v2 was never promoted: nothing is created, not even the
+			// deployment of v1
+			Releases: map[string]*model.Release{
+				"v1": {Name: "v1", Channel: "production"},
+				"v2": {Name: "v2"},
+			},
+			ExpectedDeployment: map[string][]string{},
+			Error:              ErrReleaseChannelRestricted,
+		},
+		"error, deployment not created": {
+			Deployment: deployment,
+			DeviceDeployments: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v3"),
+				rollbackDeviceDeployment("device-2", model.DeviceDeploymentStatusSuccess, "v3"),
+				rollbackDeviceDeployment("device-3", model.DeviceDeploymentStatusSuccess, "v3"),
+			},
+			Successful: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v1"),
+				rollbackDeviceDeployment("device-2", model.DeviceDeploymentStatusSuccess, "v2"),
+				rollbackDeviceDeployment("device-3", model.DeviceDeploymentStatusSuccess, "v2"),
+			},
+			ExpectedDeployment: map[string][]string{
+				"v1": {"device-1"},
+				"v2": {"device-2", "device-3"},
+			},
+			InsertErrors: []error{nil, errors.New("connection reset")},
+			ExpectedRollback: &model.DeploymentRollback{
+				DeploymentID: deploymentID,
+				Deployments: []model.DeploymentRollbackItem{{
+					ArtifactName: "v1",
+					Devices:      []string{"device-1"},
+				}},
+				SkippedDevices: []model.DeploymentRollbackSkippedDevice{
+					{DeviceID: "device-2", Reason: model.RollbackSkipNotCreated},
+					{DeviceID: "device-3", Reason: model.RollbackSkipNotCreated},
+				},
+				Error: `failed to create the deployment of the artifact "v2": ` +
+					"Storing deployment data: connection reset",
+			},
+			Error: ErrRollbackIncomplete,
+		},
+		"error, deployment not found": {
+			Error: ErrModelDeploymentNotFound,
+		},
+		"error, configuration deployment": {
+			Deployment: &model.Deployment{
+				Id:                    deploymentID,
+				Type:                  model.DeploymentTypeConfiguration,
+				DeploymentConstructor: &model.DeploymentConstructor{},
+			},
+			Error: ErrRollbackConfigurationDeployment,
+		},
+		"error, no devices to roll back": {
+			Deployment: deployment,
+			DeviceDeployments: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v3"),
+			},
+			Successful: []model.DeviceDeployment{
+				rollbackDeviceDeployment("device-1", model.DeviceDeploymentStatusSuccess, "v3"),
+			},
+			Error: ErrRollbackNoDevices,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("FindDeploymentByID", ctx, deploymentID).Return(tc.Deployment, nil)
+			if tc.Deployment != nil && tc.Deployment.Type != model.DeploymentTypeConfiguration {
+				db.On("GetDeviceStatusesForDeployment", ctx, deploymentID).
+					Return(tc.DeviceDeployments, nil)
+				db.On("GetLastDeviceDeploymentStatus", ctx, mock.Anything).
+					Return(tc.LastStatuses, nil)
+				db.On("GetLastSuccessfulDeviceDeployments", ctx, mock.Anything, created).
+					Return(tc.Successful, nil)
+			}
+			for _, artifact := range tc.MissingArtifacts {
+				db.On("ImagesByName", ctx, artifact).Return(nil, nil)
+			}
+			for artifact := range tc.ExpectedDeployment {
+				db.On("ImagesByName", ctx, artifact).Return([]*model.Image{{
+					Id:           "img-" + artifact,
+					ArtifactMeta: &model.ArtifactMeta{Name: artifact},
+				}}, nil)
+			}
+			channels := tc.Channels
+			if channels == nil {
+				channels = []model.ReleaseChannel{}
+			}
+			db.On("GetReleaseChannels", ctx).Return(channels, nil).Maybe()
+			for name, release := range tc.Releases {
+				db.On("ImagesByName", ctx, name).Return([]*model.Image{{
+					Id:           "img-" + name,
+					ArtifactMeta: &model.ArtifactMeta{Name: name},
+				}}, nil).Maybe()
+				db.On("GetRelease", ctx, name).Return(release, nil).Maybe()
+			}
+			insertErrors := tc.InsertErrors
+			if insertErrors == nil {
+				insertErrors = make([]error, len(tc.ExpectedDeployment))
+			}
+			for _, insertErr := range insertErrors {
+				db.On("InsertDeployment", ctx, mock.MatchedBy(func(d *model.Deployment) bool {
+					return assert.Equal(t, deploymentID, d.RollbackOf) &&
+						assert.Equal(t, "Rollback of v3", d.Name) &&
+						assert.Equal(t, tc.ExpectedDeployment[d.ArtifactName], d.DeviceList)
+				})).Return(insertErr).Once()
+			}
+
+			groups := []string{}
+			if len(tc.Channels) > 0 {
+				groups = []string{"production"}
+			}
+			inv := &inventory_mocks.Client{}
+			inv.On("GetDeviceGroups", ctx, mock.Anything, mock.Anything).
+				Return(groups, nil).Maybe()
+
+			d := NewDeployments(db, nil, 0, false)
+			d.SetInventoryClient(inv)
+
+			rollback, err := d.RollbackDeployment(ctx, deploymentID, tc.Request)
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				if tc.ExpectedRollback == nil {
+					return
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			for i := range rollback.Deployments {
+				assert.NotEmpty(t, rollback.Deployments[i].ID)
+				rollback.Deployments[i].ID = ""
+			}
+			assert.Equal(t, tc.ExpectedRollback, rollback)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+// GetDeviceDeploymentTimeline returns the timeline of the deployments to a
+// device: the artifact, the deployment, the duration of each state and the
+// end of the log of failed deployments.
+func (d *Deployments) GetDeviceDeploymentTimeline(ctx context.Context,
+	query store.ListQueryDeviceDeployments) ([]model.DeviceDeploymentTimelineItem, int, error) {
+	items, totalCount, err := d.GetDeviceDeploymentListForDevice(ctx, query)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	timeline := make([]model.DeviceDeploymentTimelineItem, 0, len(items))
+	for _, item := range items {
+		entry := model.NewDeviceDeploymentTimelineItem(item.Deployment, item.Device)
+		if item.Device.Status == model.DeviceDeploymentStatusFailure &&
+			item.Device.IsLogAvailable {
+			deploymentLog, err := d.db.GetDeviceDeploymentLog(ctx,
+				item.Device.DeviceId, item.Device.DeploymentId)
+			if err != nil {
+				return nil, -1, errors.Wrap(err, "retrieving the deployment log")
+			}
+			if deploymentLog != nil {
+				messages := deploymentLog.Messages
+				if n := len(messages) - model.DeviceDeploymentTimelineLogLength; n > 0 {
+					messages = messages[n:]
+				}
+				entry.Log = messages
+			}
+		}
+		timeline = append(timeline, entry)
+	}
+
+	return timeline, totalCount, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+)
+
+func TestGetDeviceDeploymentTimeline(t *testing.T) {
+	t.Parallel()
+
+	const deviceID = "device"
+	created := time.Now().UTC().Add(-time.Hour)
+	finished := created.Add(time.Minute)
+
+	messages := make([]model.LogMessage, model.DeviceDeploymentTimelineLogLength+5)
+	for i := range messages {
+		messages[i] = model.LogMessage{
+			Timestamp: &created,
+			Level:     "info",
+			Message:   fmt.Sprintf("message %d", i),
+		}
+	}
+
+	deviceDeployments := []model.DeviceDeployment{{
+		Id:             "1",
+		DeviceId:       deviceID,
+		DeploymentId:   "deployment-1",
+		Status:         model.DeviceDeploymentStatusFailure,
+		Created:        &created,
+		Finished:       &finished,
+		IsLogAvailable: true,
+	}, {
+		Id:           "2",
+		DeviceId:     deviceID,
+		DeploymentId: "deployment-2",
+		Status:       model.DeviceDeploymentStatusSuccess,
+		Created:      &created,
+		Finished:     &finished,
+	}}
+	deployments := []*model.Deployment{{
+		Id: "deployment-1",
+		DeploymentConstructor: &model.DeploymentConstructor{
+			Name:         "deployment 1",
+			ArtifactName: "artifact-1",
+		},
+	}}
+
+	testCases := map[string]struct {
+		LogErr error
+
+		Count int
+		Log   []model.LogMessage
+		Error error
+	}{
+		"ok": {
+			Count: 2,
+			Log:   messages[5:],
+		},
+		"error, deployment log": {
+			LogErr: errors.New("internal error"),
+			Error:  errors.New("retrieving the deployment log: internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			query := store.ListQueryDeviceDeployments{
+				DeviceID: deviceID,
+				Limit:    20,
+			}
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+
+			db.On("GetDeviceDeploymentsForDevice", ctx, query).
+				Return(deviceDeployments, 2, nil)
+			db.On("FindDeployments", ctx, mock.AnythingOfType("model.Query")).
+				Return(deployments, int64(0), nil)
+			var deploymentLog *model.DeploymentLog
+			if tc.LogErr == nil {
+				deploymentLog = &model.DeploymentLog{
+					DeviceID:     deviceID,
+					DeploymentID: "deployment-1",
+					Messages:     messages,
+				}
+			}
+			db.On("GetDeviceDeploymentLog", ctx, deviceID, "deployment-1").
+				Return(deploymentLog, tc.LogErr)
+
+			d := NewDeployments(db, nil, 0, false)
+			timeline, count, err := d.GetDeviceDeploymentTimeline(ctx, query)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Count, count)
+			if assert.Len(t, timeline, 2) {
+				assert.Equal(t, "deployment 1", timeline[0].DeploymentName)
+				assert.Equal(t, "artifact-1", timeline[0].ArtifactName)
+				assert.Equal(t, tc.Log, timeline[0].Log)
+				assert.Empty(t, timeline[1].DeploymentName)
+				assert.Nil(t, timeline[1].Log)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+const (
+	InventoryTagsScope = "tags"
+)
+
+// Errors expected from App interface
+var (
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+	ErrMaintenanceWindowExists   = errors.New(
+		"a maintenance window with the same name already exists",
+	)
+)
+
+func (d *Deployments) GetMaintenanceWindows(
+	ctx context.Context,
+) ([]model.MaintenanceWindow, error) {
+	windows, err := d.db.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get maintenance windows")
+	}
+	return windows, nil
+}
+
+func (d *Deployments) CreateMaintenanceWindow(
+	ctx context.Context,
+	window *model.MaintenanceWindow,
+) error {
+	now := time.Now().UTC()
+	window.Created = &now
+	window.Modified = &now
+	err := d.db.InsertMaintenanceWindow(ctx, window)
+	if errors.Is(err, mongo.ErrMaintenanceWindowConflict) {
+		return ErrMaintenanceWindowExists
+	} else if err != nil {
+		return errors.Wrap(err, "failed to store the maintenance window")
+	}
+	return nil
+}
+
+func (d *Deployments) UpdateMaintenanceWindow(
+	ctx context.Context,
+	window *model.MaintenanceWindow,
+) error {
+	current, err := d.db.GetMaintenanceWindow(ctx, window.Name)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrMaintenanceWindowNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get the maintenance window")
+	}
+	now := time.Now().UTC()
+	window.Created = current.Created
+	window.Modified = &now
+	err = d.db.UpdateMaintenanceWindow(ctx, window)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrMaintenanceWindowNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to update the maintenance window")
+	}
+	return nil
+}
+
+func (d *Deployments) DeleteMaintenanceWindow(ctx context.Context, name string) error {
+	err := d.db.DeleteMaintenanceWindow(ctx, name)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrMaintenanceWindowNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to delete the maintenance window")
+	}
+	return nil
+}
+
+// checkMaintenanceWindows tells whether any maintenance window applies to
+// the device and whether one of them is currently open. With rebootOnly
+// set, only the windows gating the reboot are considered. The inventory
+// is searched for the device only if the tenant has windows, and the
+// result is cached for maintenanceDeviceCacheTTL.
+func (d *Deployments) checkMaintenanceWindows(
+	ctx context.Context,
+	deviceID string,
+	rebootOnly bool,
+) (applies bool, open bool, err error) {
+	windows, err := d.db.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed to get maintenance windows")
+	}
+	if rebootOnly {
+		gating := make([]model.MaintenanceWindow, 0, len(windows))
+		for _, window := range windows {
+			if window.GateReboot {
+				gating = append(gating, window)
+			}
+		}
+		windows = gating
+	}
+	if len(windows) == 0 {
+		return false, false, nil
+	}
+
+	device, err := d.getMaintenanceDevice(ctx, deviceID)
+	if err != nil {
+		return false, false, err
+	}
+	applies, open = maintenanceWindowsOpen(windows, device, time.Now())
+	return applies, open, nil
+}
+
+// getMaintenanceDevice returns the attributes of the device selecting its
+// maintenance windows, from the cache or the inventory.
+func (d *Deployments) getMaintenanceDevice(
+	ctx context.Context,
+	deviceID string,
+) (*maintenanceDevice, error) {
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	key := tenantID + "/" + deviceID
+	now := time.Now()
+	if device := d.maintenanceDevices.get(key, now); device != nil {
+		return device, nil
+	}
+
+	devices, _, err := d.search(ctx, tenantID, model.SearchParams{
+		Page:      1,
+		PerPage:   1,
+		DeviceIDs: []string{deviceID},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the device inventory")
+	}
+	var invDevice *model.InvDevice
+	for i := range devices {
+		if devices[i].ID == deviceID {
+			invDevice = &devices[i]
+			break
+		}
+	}
+	device := newMaintenanceDevice(invDevice)
+	d.maintenanceDevices.put(key, device, now)
+	return device, nil
+}
+
+// maintenanceDevice holds the inventory attributes of a device selecting
+// its maintenance windows.
+type maintenanceDevice struct {
+	groups   []string
+	tags     map[string]string
+	timezone string
+}
+
+// newMaintenanceDevice returns the attributes of the inventory device
+// selecting its maintenance windows; device is nil if the device is not
+// in the inventory.
+func newMaintenanceDevice(device *model.InvDevice) *maintenanceDevice {
+	md := &maintenanceDevice{
+		tags: map[string]string{},
+	}
+	if device == nil {
+		return md
+	}
+	md.groups = inventoryDeviceGroups(device)
+	for _, attr := range device.Attributes {
+		value, ok := attr.Value.(string)
+		if !ok {
+			continue
+		}
+		switch {
+		case attr.Scope == InventoryTagsScope:
+			md.tags[attr.Name] = value
+		case attr.Scope == model.AttrScopeInventory &&
+			attr.Name == model.AttrNameTimezone:
+			md.timezone = value
+		}
+	}
+	return md
+}
+
+// maintenanceWindowsOpen tells whether any of the windows applies to the
+// device and whether one of them is open at the given time.
+func maintenanceWindowsOpen(
+	windows []model.MaintenanceWindow,
+	device *maintenanceDevice,
+	at time.Time,
+) (applies bool, open bool) {
+	for _, window := range windows {
+		if !window.AppliesTo(device.groups, device.tags) {
+			continue
+		}
+		applies = true
+		if window.IsOpen(at, window.Location(device.timezone)) {
+			return true, true
+		}
+	}
+	return applies, false
+}
+
+const (
+	// maintenanceDeviceCacheTTL is how long the maintenance attributes of
+	// a device are cached: changes of the groups, tags or time zone of a
+	// device apply to its maintenance windows after at most this delay.
+	maintenanceDeviceCacheTTL = 5 * time.Minute
+	// maintenanceDeviceCacheSize is the maximum number of cached devices.
+	maintenanceDeviceCacheSize = 100000
+)
+
+// maintenanceDeviceCache caches the maintenance attributes of the devices
+// polling for deployments, sparing an inventory search per poll.
+type maintenanceDeviceCache struct {
+	lock    sync.Mutex
+	devices map[string]cachedMaintenanceDevice
+}
+
+type cachedMaintenanceDevice struct {
+	device  *maintenanceDevice
+	expires time.Time
+}
+
+func newMaintenanceDeviceCache() *maintenanceDeviceCache {
+	return &maintenanceDeviceCache{
+		devices: make(map[string]cachedMaintenanceDevice),
+	}
+}
+
+// get returns the cached device, or nil if not cached or expired.
+func (c *maintenanceDeviceCache) get(key string, now time.Time) *maintenanceDevice {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, ok := c.devices[key]
+	if !ok || !now.Before(cached.expires) {
+		return nil
+	}
+	return cached.device
+}
+
+func (c *maintenanceDeviceCache) put(key string, device *maintenanceDevice, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.devices) >= maintenanceDeviceCacheSize {
+		for k, cached := range c.devices {
+			if !now.Before(cached.expires) {
+				delete(c.devices, k)
+			}
+		}
+		if len(c.devices) >= maintenanceDeviceCacheSize {
+			c.devices = make(map[string]cachedMaintenanceDevice)
+		}
+	}
+	c.devices[key] = cachedMaintenanceDevice{
+		device:  device,
+		expires: now.Add(maintenanceDeviceCacheTTL),
+	}
+}
+
+// rebootControlMap returns the update control map pausing the device
+// before rebooting while the maintenance windows gating the reboot are
+// closed, or nil if no such window applies to the device.
+func (d *Deployments) rebootControlMap(
+	ctx context.Context,
+	deviceDeployment *model.DeviceDeployment,
+) (*model.UpdateControlMap, error) {
+	applies, open, err := d.checkMaintenanceWindows(ctx, deviceDeployment.DeviceId, true)
+	if err != nil || !applies {
+		return nil, err
+	}
+	state := model.UpdateControlState{
+		Action: model.UpdateControlActionContinue,
+	}
+	if !open {
+		// a device losing contact with the server must not reboot
+		// outside of its maintenance window
+		state = model.UpdateControlState{
+			Action:      model.UpdateControlActionPause,
+			OnMapExpire: model.UpdateControlActionFail,
+		}
+	}
+	return &model.UpdateControlMap{
+		ID: deviceDeployment.DeploymentId,
+		States: map[string]model.UpdateControlState{
+			model.UpdateControlStateArtifactRebootEnter: state,
+		},
+	}, nil
+}
+
+// inventoryDeviceGroups returns the primary and additional groups of the
+// inventory device.
+func inventoryDeviceGroups(device *model.InvDevice) []string {
+	var groups []string
+	for _, attr := range device.Attributes {
+		if attr.Scope != InventoryGroupScope {
+			continue
+		}
+		switch attr.Name {
+		case InventoryGroupAttributeName:
+			if group, ok := attr.Value.(string); ok {
+				groups = append(groups, group)
+			}
+		case InventoryGroupsAttributeName:
+			groups = append(groups, stringValues(attr.Value)...)
+		}
+	}
+	return groups
+}
+
+// stringValues returns the string elements of an array attribute value.
+func stringValues(value interface{}) []string {
+	var values []string
+	switch v := value.(type) {
+	case []string:
+		values = append(values, v...)
+	case []interface{}:
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,422 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	inventory_mocks "github.com/mendersoftware/mender-server/services/deployments/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+// windowAt returns a window which is either always open or closed now.
+func windowAt(name, group string, open, gateReboot bool) model.MaintenanceWindow {
+	days := []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	if !open {
+		days = []string{days[(time.Now().UTC().Weekday()+3)%7]}
+	}
+	return model.MaintenanceWindow{
+		Name:       name,
+		Group:      group,
+		Days:       days,
+		Start:      "00:00",
+		End:        "00:00",
+		GateReboot: gateReboot,
+	}
+}
+
+func TestMaintenanceWindowsOpen(t *testing.T) {
+	t.Parallel()
+
+	oslo, _ := time.LoadLocation("Europe/Oslo")
+	// Monday 02:30 in Oslo, 00:30 UTC
+	at := time.Date(2026, 10, 19, 2, 30, 0, 0, oslo)
+	windows := []model.MaintenanceWindow{{
+		Name:     "oslo",
+		Group:    "oslo",
+		Days:     []string{"mon"},
+		Start:    "01:00",
+		End:      "04:00",
+		Timezone: "Europe/Oslo",
+	}, {
+		Name:  "lab",
+		Tag:   &model.MaintenanceWindowTag{Name: "site", Value: "lab"},
+		Days:  []string{"mon"},
+		Start: "03:00",
+		End:   "05:00",
+	}}
+	device := func(attrs ...model.DeviceAttribute) *model.InvDevice {
+		return &model.InvDevice{ID: "device", Attributes: attrs}
+	}
+
+	testCases := map[string]struct {
+		device  *model.InvDevice
+		applies bool
+		open    bool
+	}{
+		"no window applies": {
+			device: device(model.DeviceAttribute{
+				Name: "group", Scope: "system", Value: "bergen",
+			}),
+		},
+		"device not in inventory": {},
+		"group window open": {
+			device: device(model.DeviceAttribute{
+				Name: "group", Scope: "system", Value: "oslo",
+			}),
+			applies: true,
+			open:    true,
+		},
+		"window of additional nested group open": {
+			device: device(model.DeviceAttribute{
+				Name: "group", Scope: "system", Value: "bergen",
+			}, model.DeviceAttribute{
+				Name: "groups", Scope: "system", Value: []interface{}{"oslo/east"},
+			}),
+			applies: true,
+			open:    true,
+		},
+		"group window closed in device time zone": {
+			device: device(model.DeviceAttribute{
+				Name: "group", Scope: "system", Value: "oslo",
+			}, model.DeviceAttribute{
+				Name: "timezone", Scope: "inventory", Value: "America/New_York",
+			}),
+			applies: true,
+		},
+		"tag window closed": {
+			device: device(model.DeviceAttribute{
+				Name: "site", Scope: "tags", Value: "lab",
+			}),
+			applies: true,
+		},
+		"one of the windows open": {
+			device: device(model.DeviceAttribute{
+				Name: "site", Scope: "tags", Value: "lab",
+			}, model.DeviceAttribute{
+				Name: "group", Scope: "system", Value: "oslo",
+			}),
+			applies: true,
+			open:    true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			applies, open := maintenanceWindowsOpen(windows,
+				newMaintenanceDevice(tc.device), at)
+			assert.Equal(t, tc.applies, applies)
+			assert.Equal(t, tc.open, open)
+		})
+	}
+}
+
+func TestGetDeploymentForDeviceOutsideMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Subject: "device",
+		Tenant:  "tenant",
+	})
+	deployment := &model.Deployment{Id: "deployment"}
+	deviceDeployment := &model.DeviceDeployment{
+		Id:           "device-deployment",
+		DeviceId:     "device",
+		DeploymentId: deployment.Id,
+		Status:       model.DeviceDeploymentStatusPending,
+	}
+
+	db := &mocks.DataStore{}
+	defer db.AssertExpectations(t)
+	db.On("FindOldestActiveDeviceDeployment", ctx, "device").
+		Return(deviceDeployment, nil)
+	db.On("FindDeploymentByID", ctx, deployment.Id).Return(deployment, nil)
+	db.On("GetMaintenanceWindows", ctx).Return([]model.MaintenanceWindow{
+		windowAt("oslo", "oslo", false, false),
+	}, nil)
+
+	inv := &inventory_mocks.Client{}
+	defer inv.AssertExpectations(t)
+	inv.On("Search", ctx, "tenant", model.SearchParams{
+		Page:      1,
+		PerPage:   1,
+		DeviceIDs: []string{"device"},
+	}).Return([]model.InvDevice{{
+		ID: "device",
+		Attributes: []model.DeviceAttribute{{
+			Name: "group", Scope: "system", Value: "oslo",
+		}},
+	}}, 1, nil)
+
+	d := NewDeployments(db, nil, 0, false)
+	d.SetInventoryClient(inv)
+
+	instructions, err := d.GetDeploymentForDeviceWithCurrent(ctx, "device",
+		&model.DeploymentNextRequest{
+			DeviceProvides: &model.InstalledDeviceDeployment{
+				ArtifactName: "release-1",
+				DeviceType:   "rpi4",
+			},
+		})
+	assert.NoError(t, err)
+	assert.Nil(t, instructions)
+}
+
+func TestRebootControlMap(t *testing.T) {
+	t.Parallel()
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant",
+	})
+	deviceDeployment := &model.DeviceDeployment{
+		DeviceId:     "device",
+		DeploymentId: "deployment",
+		Status:       model.DeviceDeploymentStatusPauseBeforeReboot,
+	}
+
+	testCases := map[string]struct {
+		windows   []model.MaintenanceWindow
+		searchErr error
+
+		controlMap *model.UpdateControlMap
+		err        error
+	}{
+		"no gating window": {
+			windows: []model.MaintenanceWindow{
+				windowAt("oslo", "oslo", false, false),
+			},
+		},
+		"gating window closed": {
+			windows: []model.MaintenanceWindow{
+				windowAt("oslo", "oslo", false, true),
+			},
+			controlMap: &model.UpdateControlMap{
+				ID: "deployment",
+				States: map[string]model.UpdateControlState{
+					model.UpdateControlStateArtifactRebootEnter: {
+						Action:      model.UpdateControlActionPause,
+						OnMapExpire: model.UpdateControlActionFail,
+					},
+				},
+			},
+		},
+		"gating window open": {
+			windows: []model.MaintenanceWindow{
+				windowAt("oslo", "oslo", true, true),
+			},
+			controlMap: &model.UpdateControlMap{
+				ID: "deployment",
+				States: map[string]model.UpdateControlState{
+					model.UpdateControlStateArtifactRebootEnter: {
+						Action: model.UpdateControlActionContinue,
+					},
+				},
+			},
+		},
+		"gating window of another group": {
+			windows: []model.MaintenanceWindow{
+				windowAt("bergen", "bergen", false, true),
+			},
+		},
+		"error, inventory": {
+			windows: []model.MaintenanceWindow{
+				windowAt("oslo", "oslo", false, true),
+			},
+			searchErr: errors.New("connection refused"),
+			err:       errors.New("failed to get the device inventory: connection refused"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetMaintenanceWindows", ctx).Return(tc.windows, nil)
+
+			inv := &inventory_mocks.Client{}
+			inv.On("Search", ctx, "tenant", mock.AnythingOfType("model.SearchParams")).
+				Return([]model.InvDevice{{
+					ID: "device",
+					Attributes: []model.DeviceAttribute{{
+						Name: "group", Scope: "system", Value: "oslo",
+					}},
+				}}, 1, tc.searchErr).
+				Maybe()
+
+			d := NewDeployments(db, nil, 0, false)
+			d.SetInventoryClient(inv)
+
+			controlMap, err := d.rebootControlMap(ctx, deviceDeployment)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.controlMap, controlMap)
+		})
+	}
+}
+
+func TestCheckMaintenanceWindowsInventoryLookups(t *testing.T) {
+	t.Parallel()
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant",
+	})
+
+	db := &mocks.DataStore{}
+	defer db.AssertExpectations(t)
+	db.On("GetMaintenanceWindows", ctx).Return(nil, nil).Once()
+	db.On("GetMaintenanceWindows", ctx).Return([]model.MaintenanceWindow{
+		windowAt("oslo", "oslo", true, true),
+	}, nil)
+
+	// the device is searched once, only when the tenant has windows
+	inv := &inventory_mocks.Client{}
+	defer inv.AssertExpectations(t)
+	inv.On("Search", ctx, "tenant", model.SearchParams{
+		Page:      1,
+		PerPage:   1,
+		DeviceIDs: []string{"device"},
+	}).Return([]model.InvDevice{{
+		ID: "device",
+		Attributes: []model.DeviceAttribute{{
+			Name: "group", Scope: "system", Value: "oslo",
+		}},
+	}}, 1, nil).Once()
+
+	d := NewDeployments(db, nil, 0, false)
+	d.SetInventoryClient(inv)
+
+	applies, _, err := d.checkMaintenanceWindows(ctx, "device", false)
+	assert.NoError(t, err)
+	assert.False(t, applies)
+	for _, rebootOnly := range []bool{false, true, false} {
+		applies, open, err := d.checkMaintenanceWindows(ctx, "device", rebootOnly)
+		assert.NoError(t, err)
+		assert.True(t, applies)
+		assert.True(t, open)
+	}
+}
+
+func TestMaintenanceDeviceCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	device := &maintenanceDevice{groups: []string{"oslo"}}
+	cache := newMaintenanceDeviceCache()
+	assert.Nil(t, cache.get("tenant/device", now))
+
+	cache.put("tenant/device", device, now)
+	assert.Equal(t, device, cache.get("tenant/device", now))
+	assert.Nil(t, cache.get("other/device", now))
+	assert.Nil(t, cache.get("tenant/device", now.Add(maintenanceDeviceCacheTTL)))
+
+	var noCache *maintenanceDeviceCache
+	noCache.put("tenant/device", device, now)
+	assert.Nil(t, noCache.get("tenant/device", now))
+}
+
+func TestCreateMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		insertErr error
+		err       error
+	}{
+		"ok": {},
+		"error, conflict": {
+			insertErr: mongo.ErrMaintenanceWindowConflict,
+			err:       ErrMaintenanceWindowExists,
+		},
+		"error, internal": {
+			insertErr: errors.New("connection refused"),
+			err:       errors.New("failed to store the maintenance window: connection refused"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			window := windowAt("oslo", "oslo", true, false)
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("InsertMaintenanceWindow", ctx,
+				mock.MatchedBy(func(w *model.MaintenanceWindow) bool {
+					return w.Created != nil && w.Modified != nil
+				}),
+			).Return(tc.insertErr)
+
+			err := NewDeployments(db, nil, 0, false).CreateMaintenanceWindow(ctx, &window)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	created := time.Now().Add(-time.Hour).UTC()
+	testCases := map[string]struct {
+		getErr error
+		err    error
+	}{
+		"ok": {},
+		"error, not found": {
+			getErr: store.ErrNotFound,
+			err:    ErrMaintenanceWindowNotFound,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			window := windowAt("oslo", "oslo", true, true)
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			if tc.getErr != nil {
+				db.On("GetMaintenanceWindow", ctx, "oslo").Return(nil, tc.getErr)
+			} else {
+				db.On("GetMaintenanceWindow", ctx, "oslo").
+					Return(&model.MaintenanceWindow{Name: "oslo", Created: &created}, nil)
+				db.On("UpdateMaintenanceWindow", ctx,
+					mock.MatchedBy(func(w *model.MaintenanceWindow) bool {
+						return w.Created.Equal(created) && w.GateReboot
+					}),
+				).Return(nil)
+			}
+
+			err := NewDeployments(db, nil, 0, false).UpdateMaintenanceWindow(ctx, &window)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
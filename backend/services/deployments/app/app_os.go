@@ -106,6 +106,7 @@ func (d *Deployments) assignArtifact(
 			ctx,
 			deployment.Artifacts,
 			installed.DeviceType,
+			installed.ArtifactName,
 		)
 		if err != nil {
 			return errors.Wrap(err, "assigning artifact to device deployment")
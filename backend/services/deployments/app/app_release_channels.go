@@ -0,0 +1,411 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+// Errors expected from App interface
+var (
+	ErrReleaseChannelNotFound = errors.New("release channel not found")
+	ErrReleaseChannelExists   = errors.New("a release channel with the same name already exists")
+	ErrReleaseChannelInUse    = errors.New(
+		"the release channel has releases promoted or pending promotion to it",
+	)
+	ErrReleaseAlreadyInChannel   = errors.New("the release is already in the channel")
+	ErrReleasePromotionPending   = errors.New("the release has a pending promotion")
+	ErrReleasePromotionNotFound  = errors.New("the release has no pending promotion")
+	ErrReleasePromotionForbidden = errors.New(
+		"the promotion can only be approved by users other than the uploaders of the release",
+	)
+	ErrReleasePromotionApproved = errors.New("the promotion was already approved by the user")
+	ErrReleaseChannelRestricted = errors.New(
+		"the release was not promoted to the channel required by the target devices",
+	)
+)
+
+func (d *Deployments) GetReleaseChannels(ctx context.Context) ([]model.ReleaseChannel, error) {
+	channels, err := d.db.GetReleaseChannels(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get release channels")
+	}
+	return channels, nil
+}
+
+func (d *Deployments) CreateReleaseChannel(
+	ctx context.Context,
+	channel *model.ReleaseChannel,
+) error {
+	now := time.Now().UTC()
+	channel.Created = &now
+	channel.Modified = &now
+	if channel.Groups == nil {
+		channel.Groups = []string{}
+	}
+	err := d.db.InsertReleaseChannel(ctx, channel)
+	if errors.Is(err, mongo.ErrReleaseChannelConflict) {
+		return ErrReleaseChannelExists
+	} else if err != nil {
+		return errors.Wrap(err, "failed to store the release channel")
+	}
+	return nil
+}
+
+func (d *Deployments) UpdateReleaseChannel(
+	ctx context.Context,
+	channel *model.ReleaseChannel,
+) error {
+	current, err := d.db.GetReleaseChannel(ctx, channel.Name)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrReleaseChannelNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get the release channel")
+	}
+	now := time.Now().UTC()
+	channel.Created = current.Created
+	channel.Modified = &now
+	if channel.Groups == nil {
+		channel.Groups = []string{}
+	}
+	err = d.db.UpdateReleaseChannel(ctx, channel)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrReleaseChannelNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to update the release channel")
+	}
+	return nil
+}
+
+func (d *Deployments) DeleteReleaseChannel(ctx context.Context, name string) error {
+	count, err := d.db.CountReleasesInChannel(ctx, name)
+	if err != nil {
+		return errors.Wrap(err, "failed to count the releases in the channel")
+	} else if count > 0 {
+		return ErrReleaseChannelInUse
+	}
+	err = d.db.DeleteReleaseChannel(ctx, name)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrReleaseChannelNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to delete the release channel")
+	}
+	return nil
+}
+
+// PromoteRelease moves the release to the requested channel. If the
+// channel ranks higher than the current channel of the release and
+// requires approvals, the promotion is left pending until approved.
+func (d *Deployments) PromoteRelease(
+	ctx context.Context,
+	releaseName string,
+	request model.ReleasePromotionRequest,
+) (*model.Release, error) {
+	release, err := d.GetRelease(ctx, releaseName)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := d.GetReleaseChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target := findReleaseChannel(channels, request.Channel)
+	if target == nil {
+		return nil, ErrReleaseChannelNotFound
+	} else if release.Channel == target.Name {
+		return nil, ErrReleaseAlreadyInChannel
+	} else if release.Promotion != nil {
+		return nil, ErrReleasePromotionPending
+	}
+
+	current := findReleaseChannel(channels, release.Channel)
+	if target.RequiredApprovals == 0 ||
+		(current != nil && current.Rank >= target.Rank) {
+		err = d.db.SetReleaseChannel(ctx, releaseName, target.Name)
+		if err != nil {
+			return nil, d.releasePromotionError(err)
+		}
+		release.Channel = target.Name
+		return release, nil
+	}
+
+	promotion := &model.ReleasePromotion{
+		Channel:   target.Name,
+		Requested: time.Now().UTC(),
+		Approvals: []model.PromotionApproval{},
+	}
+	if id := identity.FromContext(ctx); id != nil && id.IsUser {
+		promotion.RequestedBy = id.Subject
+	}
+	err = d.db.SetReleasePromotion(ctx, releaseName, promotion)
+	if err != nil {
+		return nil, d.releasePromotionError(err)
+	}
+	release.Promotion = promotion
+	return release, nil
+}
+
+// ApproveReleasePromotion records the approval of the pending promotion of
+// the release by the current user; the release is moved to the channel as
+// soon as the promotion collects the approvals required by the channel.
+func (d *Deployments) ApproveReleasePromotion(
+	ctx context.Context,
+	releaseName string,
+) (*model.Release, error) {
+	id := identity.FromContext(ctx)
+	if id == nil || !id.IsUser || id.Subject == "" {
+		return nil, ErrReleasePromotionForbidden
+	}
+	release, err := d.GetRelease(ctx, releaseName)
+	if err != nil {
+		return nil, err
+	} else if release.Promotion == nil {
+		return nil, ErrReleasePromotionNotFound
+	}
+	for _, uploader := range release.Uploaders() {
+		if uploader == id.Subject {
+			return nil, ErrReleasePromotionForbidden
+		}
+	}
+	if release.Promotion.IsApprovedBy(id.Subject) {
+		return nil, ErrReleasePromotionApproved
+	}
+	target, err := d.db.GetReleaseChannel(ctx, release.Promotion.Channel)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrReleaseChannelNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get the release channel")
+	}
+
+	promotion, err := d.db.AddReleasePromotionApproval(ctx,
+		releaseName,
+		release.Promotion.Channel,
+		model.PromotionApproval{
+			UserID:   id.Subject,
+			Approved: time.Now().UTC(),
+		},
+	)
+	if errors.Is(err, store.ErrNotFound) {
+		// the promotion changed or was approved concurrently
+		return nil, ErrReleasePromotionApproved
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to approve the release promotion")
+	}
+	release.Promotion = promotion
+	if len(promotion.Approvals) >= target.RequiredApprovals {
+		err = d.db.SetReleaseChannel(ctx, releaseName, target.Name)
+		if err != nil {
+			return nil, d.releasePromotionError(err)
+		}
+		release.Channel = target.Name
+		release.Promotion = nil
+	}
+	return release, nil
+}
+
+// CancelReleasePromotion discards the pending promotion of the release.
+func (d *Deployments) CancelReleasePromotion(ctx context.Context, releaseName string) error {
+	release, err := d.GetRelease(ctx, releaseName)
+	if err != nil {
+		return err
+	} else if release.Promotion == nil {
+		return ErrReleasePromotionNotFound
+	}
+	err = d.db.SetReleasePromotion(ctx, releaseName, nil)
+	if err != nil {
+		return d.releasePromotionError(err)
+	}
+	return nil
+}
+
+func (d *Deployments) releasePromotionError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrReleaseNotFound
+	}
+	return errors.Wrap(err, "failed to update the release")
+}
+
+func findReleaseChannel(channels []model.ReleaseChannel, name string) *model.ReleaseChannel {
+	for i := range channels {
+		if channels[i].Name == name {
+			return &channels[i]
+		}
+	}
+	return nil
+}
+
+// checkReleaseChannel verifies that the release was promoted to the
+// channels required by the groups of the target devices. Deployments to
+// all the devices must satisfy every channel restricting groups.
+func (d *Deployments) checkReleaseChannel(
+	ctx context.Context,
+	constructor *model.DeploymentConstructor,
+	groups []string,
+) error {
+	channels, err := d.GetReleaseChannels(ctx)
+	if err != nil {
+		return err
+	}
+	restricted := false
+	for _, channel := range channels {
+		if len(channel.Groups) > 0 {
+			restricted = true
+			break
+		}
+	}
+	if !restricted {
+		return nil
+	}
+
+	if !constructor.AllDevices && len(groups) == 0 && len(constructor.Devices) > 1 {
+		groups, err = d.getDevicesGroups(ctx, constructor.Devices)
+		if err != nil {
+			return err
+		}
+	}
+
+	rank := -1
+	release, err := d.db.GetRelease(ctx, constructor.ArtifactName)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return errors.Wrap(err, "failed to get the release")
+	} else if release != nil {
+		if current := findReleaseChannel(channels, release.Channel); current != nil {
+			rank = current.Rank
+		}
+	}
+	for _, channel := range channels {
+		if rank >= channel.Rank {
+			continue
+		}
+		for _, group := range channel.Groups {
+			targets := constructor.AllDevices || targetsGroup(constructor, groups, group)
+			if !targets && constructor.Group != "" {
+				// the devices of the group may be members of the
+				// restricted group through their additional groups
+				targets, err = d.groupHasMembersIn(ctx, constructor.Group, group)
+				if err != nil {
+					return err
+				}
+			}
+			if targets {
+				return errors.WithMessagef(ErrReleaseChannelRestricted,
+					"group %q only accepts releases promoted to the channel %q",
+					group, channel.Name,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// getDevicesGroups returns the groups of the devices, searching the
+// inventory for the devices in batches.
+func (d *Deployments) getDevicesGroups(
+	ctx context.Context,
+	devices []string,
+) ([]string, error) {
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	var groups []string
+	for start := 0; start < len(devices); start += PerPageInventoryDevices {
+		batch := devices[start:min(start+PerPageInventoryDevices, len(devices))]
+		found, _, err := d.search(ctx, tenantID, model.SearchParams{
+			Page:      1,
+			PerPage:   len(batch),
+			DeviceIDs: batch,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the groups of the devices")
+		}
+		for i := range found {
+			for _, group := range inventoryDeviceGroups(&found[i]) {
+				if !containsString(groups, group) {
+					groups = append(groups, group)
+				}
+			}
+		}
+	}
+	return groups, nil
+}
+
+// groupHasMembersIn returns true if any device of the group is a member
+// of the other group, or of one of its nested groups.
+func (d *Deployments) groupHasMembersIn(
+	ctx context.Context,
+	group, other string,
+) (bool, error) {
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	_, count, err := d.search(ctx, tenantID, model.SearchParams{
+		Page:    1,
+		PerPage: 1,
+		Filters: []model.FilterPredicate{{
+			Scope:     InventoryGroupScope,
+			Attribute: InventoryGroupAttributeName,
+			Type:      "$eq",
+			Value:     group,
+		}, {
+			Scope:     InventoryGroupScope,
+			Attribute: InventoryGroupAttributeName,
+			Type:      "$eq",
+			Value:     other,
+		}},
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to search the devices of the group")
+	}
+	return count > 0, nil
+}
+
+// targetsGroup returns true if the deployment targets devices in the group:
+// either devices in the group or in one of its nested groups, or a group
+// the group is nested in.
+func targetsGroup(
+	constructor *model.DeploymentConstructor,
+	groups []string,
+	group string,
+) bool {
+	if constructor.Group != "" && model.InGroup(group, constructor.Group) {
+		return true
+	}
+	for _, g := range groups {
+		if model.InGroup(g, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,524 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	inventory_mocks "github.com/mendersoftware/mender-server/services/deployments/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+var testReleaseChannels = []model.ReleaseChannel{{
+	Name: "dev",
+	Rank: 0,
+}, {
+	Name:              "staging",
+	Rank:              1,
+	RequiredApprovals: 1,
+	Groups:            []string{"staging"},
+}, {
+	Name:              "production",
+	Rank:              2,
+	RequiredApprovals: 2,
+	Groups:            []string{"production"},
+}}
+
+func TestCreateReleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		InsertErr error
+		Error     error
+	}{
+		"ok": {},
+		"error, conflict": {
+			InsertErr: mongo.ErrReleaseChannelConflict,
+			Error:     ErrReleaseChannelExists,
+		},
+		"error, internal": {
+			InsertErr: errors.New("internal error"),
+			Error:     errors.New("failed to store the release channel: internal error"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("InsertReleaseChannel", ctx,
+				mock.MatchedBy(func(c *model.ReleaseChannel) bool {
+					return c.Created != nil && c.Modified != nil && c.Groups != nil
+				}),
+			).Return(tc.InsertErr)
+
+			d := NewDeployments(db, nil, 0, false)
+			err := d.CreateReleaseChannel(ctx, &model.ReleaseChannel{Name: "dev"})
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeleteReleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		Count     int64
+		CountErr  error
+		DeleteErr error
+		Error     error
+	}{
+		"ok": {},
+		"error, in use": {
+			Count: 1,
+			Error: ErrReleaseChannelInUse,
+		},
+		"error, not found": {
+			DeleteErr: store.ErrNotFound,
+			Error:     ErrReleaseChannelNotFound,
+		},
+		"error, count": {
+			CountErr: errors.New("internal error"),
+			Error: errors.New(
+				"failed to count the releases in the channel: internal error",
+			),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("CountReleasesInChannel", ctx, "dev").
+				Return(tc.Count, tc.CountErr)
+			if tc.Count == 0 && tc.CountErr == nil {
+				db.On("DeleteReleaseChannel", ctx, "dev").
+					Return(tc.DeleteErr)
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			err := d.DeleteReleaseChannel(ctx, "dev")
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPromoteRelease(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		Release *model.Release
+		Channel string
+
+		SetChannel   bool
+		SetPromotion bool
+
+		Error error
+	}{
+		"ok, no approvals required": {
+			Release:    &model.Release{Name: "release"},
+			Channel:    "dev",
+			SetChannel: true,
+		},
+		"ok, demoted without approvals": {
+			Release:    &model.Release{Name: "release", Channel: "production"},
+			Channel:    "staging",
+			SetChannel: true,
+		},
+		"ok, pending approvals": {
+			Release:      &model.Release{Name: "release", Channel: "dev"},
+			Channel:      "staging",
+			SetPromotion: true,
+		},
+		"error, channel not found": {
+			Release: &model.Release{Name: "release"},
+			Channel: "qa",
+			Error:   ErrReleaseChannelNotFound,
+		},
+		"error, already in channel": {
+			Release: &model.Release{Name: "release", Channel: "dev"},
+			Channel: "dev",
+			Error:   ErrReleaseAlreadyInChannel,
+		},
+		"error, promotion pending": {
+			Release: &model.Release{
+				Name:      "release",
+				Channel:   "dev",
+				Promotion: &model.ReleasePromotion{Channel: "staging"},
+			},
+			Channel: "production",
+			Error:   ErrReleasePromotionPending,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Subject: "user",
+				IsUser:  true,
+			})
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetRelease", ctx, "release").Return(tc.Release, nil)
+			db.On("GetReleaseChannels", ctx).Return(testReleaseChannels, nil)
+			if tc.SetChannel {
+				db.On("SetReleaseChannel", ctx, "release", tc.Channel).Return(nil)
+			}
+			if tc.SetPromotion {
+				db.On("SetReleasePromotion", ctx, "release",
+					mock.MatchedBy(func(p *model.ReleasePromotion) bool {
+						return p.Channel == tc.Channel && p.RequestedBy == "user"
+					}),
+				).Return(nil)
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			release, err := d.PromoteRelease(ctx, "release",
+				model.ReleasePromotionRequest{Channel: tc.Channel})
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.SetChannel {
+				assert.Equal(t, tc.Channel, release.Channel)
+				assert.Nil(t, release.Promotion)
+			} else if assert.NotNil(t, release.Promotion) {
+				assert.Equal(t, tc.Channel, release.Promotion.Channel)
+			}
+		})
+	}
+}
+
+func TestApproveReleasePromotion(t *testing.T) {
+	t.Parallel()
+
+	newRelease := func(approvals ...string) *model.Release {
+		promotion := &model.ReleasePromotion{
+			Channel:   "production",
+			Approvals: []model.PromotionApproval{},
+		}
+		for _, userID := range approvals {
+			promotion.Approvals = append(promotion.Approvals,
+				model.PromotionApproval{UserID: userID})
+		}
+		return &model.Release{
+			Name:      "release",
+			Channel:   "staging",
+			Artifacts: []model.Image{{UploadedBy: "uploader"}},
+			Promotion: promotion,
+		}
+	}
+
+	testCases := map[string]struct {
+		Identity *identity.Identity
+		Release  *model.Release
+
+		Approvals  []string
+		ApproveErr error
+
+		Promoted bool
+		Error    error
+	}{
+		"ok, pending": {
+			Identity:  &identity.Identity{Subject: "user", IsUser: true},
+			Release:   newRelease(),
+			Approvals: []string{"user"},
+		},
+		"ok, promoted": {
+			Identity:  &identity.Identity{Subject: "user", IsUser: true},
+			Release:   newRelease("other"),
+			Approvals: []string{"other", "user"},
+			Promoted:  true,
+		},
+		"error, not a user": {
+			Identity: &identity.Identity{Subject: "device", IsDevice: true},
+			Error:    ErrReleasePromotionForbidden,
+		},
+		"error, uploader": {
+			Identity: &identity.Identity{Subject: "uploader", IsUser: true},
+			Release:  newRelease(),
+			Error:    ErrReleasePromotionForbidden,
+		},
+		"error, already approved": {
+			Identity: &identity.Identity{Subject: "user", IsUser: true},
+			Release:  newRelease("user"),
+			Error:    ErrReleasePromotionApproved,
+		},
+		"error, approved concurrently": {
+			Identity:   &identity.Identity{Subject: "user", IsUser: true},
+			Release:    newRelease(),
+			ApproveErr: store.ErrNotFound,
+			Error:      ErrReleasePromotionApproved,
+		},
+		"error, no promotion": {
+			Identity: &identity.Identity{Subject: "user", IsUser: true},
+			Release:  &model.Release{Name: "release"},
+			Error:    ErrReleasePromotionNotFound,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := identity.WithContext(context.Background(), tc.Identity)
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			if tc.Release != nil {
+				db.On("GetRelease", ctx, "release").Return(tc.Release, nil)
+			}
+			if tc.Approvals != nil || tc.ApproveErr != nil {
+				db.On("GetReleaseChannel", ctx, "production").
+					Return(&testReleaseChannels[2], nil)
+				promotion := &model.ReleasePromotion{Channel: "production"}
+				for _, userID := range tc.Approvals {
+					promotion.Approvals = append(promotion.Approvals,
+						model.PromotionApproval{UserID: userID})
+				}
+				db.On("AddReleasePromotionApproval", ctx, "release", "production",
+					mock.MatchedBy(func(a model.PromotionApproval) bool {
+						return a.UserID == tc.Identity.Subject
+					}),
+				).Return(promotion, tc.ApproveErr)
+			}
+			if tc.Promoted {
+				db.On("SetReleaseChannel", ctx, "release", "production").
+					Return(nil)
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			release, err := d.ApproveReleasePromotion(ctx, "release")
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.Promoted {
+				assert.Equal(t, "production", release.Channel)
+				assert.Nil(t, release.Promotion)
+			} else if assert.NotNil(t, release.Promotion) {
+				assert.Len(t, release.Promotion.Approvals, len(tc.Approvals))
+			}
+		})
+	}
+}
+
+func TestCheckReleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		Channels    []model.ReleaseChannel
+		Release     *model.Release
+		Constructor *model.DeploymentConstructor
+		Groups      []string
+		// Members is the number of devices of the deployment group
+		// in each of the restricted groups
+		Members map[string]int
+		Devices []model.InvDevice
+
+		Error error
+	}{
+		"ok, no restricted channels": {
+			Channels: testReleaseChannels[:1],
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				AllDevices:   true,
+			},
+		},
+		"ok, unrestricted group": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Group:        "dev",
+			},
+			Groups: []string{"dev"},
+		},
+		"ok, promoted to a higher channel": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release", Channel: "production"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Group:        "staging",
+			},
+			Groups: []string{"staging"},
+		},
+		"error, restricted group": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release", Channel: "staging"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Group:        "production",
+			},
+			Groups: []string{"production"},
+			Error:  ErrReleaseChannelRestricted,
+		},
+		"error, nested restricted group": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release", Channel: "staging"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Group:        "production/eu",
+			},
+			Groups: []string{"production/eu"},
+			Error:  ErrReleaseChannelRestricted,
+		},
+		"error, parent of restricted group": {
+			Channels: []model.ReleaseChannel{{
+				Name:   "production",
+				Rank:   2,
+				Groups: []string{"europe/production"},
+			}},
+			Release: &model.Release{Name: "release"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Group:        "europe",
+			},
+			Groups: []string{"europe"},
+			Error:  ErrReleaseChannelRestricted,
+		},
+		"error, group member of a restricted group": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release", Channel: "staging"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Group:        "dev",
+			},
+			Groups:  []string{"dev"},
+			Members: map[string]int{"production": 1},
+			Error:   ErrReleaseChannelRestricted,
+		},
+		"ok, devices in unrestricted groups": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release", Channel: "staging"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Devices:      []string{"device-1", "device-2"},
+			},
+			Devices: []model.InvDevice{{
+				ID: "device-1",
+				Attributes: []model.DeviceAttribute{{
+					Scope: InventoryGroupScope,
+					Name:  InventoryGroupAttributeName,
+					Value: "staging",
+				}},
+			}, {
+				ID: "device-2",
+			}},
+		},
+		"error, devices in a restricted group": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release", Channel: "staging"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				Devices:      []string{"device-1", "device-2"},
+			},
+			Devices: []model.InvDevice{{
+				ID: "device-1",
+				Attributes: []model.DeviceAttribute{{
+					Scope: InventoryGroupScope,
+					Name:  InventoryGroupAttributeName,
+					Value: "dev",
+				}},
+			}, {
+				ID: "device-2",
+				Attributes: []model.DeviceAttribute{{
+					Scope: InventoryGroupScope,
+					Name:  InventoryGroupsAttributeName,
+					Value: []interface{}{"dev", "production/eu"},
+				}},
+			}},
+			Error: ErrReleaseChannelRestricted,
+		},
+		"error, all devices": {
+			Channels: testReleaseChannels,
+			Release:  &model.Release{Name: "release", Channel: "staging"},
+			Constructor: &model.DeploymentConstructor{
+				ArtifactName: "release",
+				AllDevices:   true,
+			},
+			Error: ErrReleaseChannelRestricted,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetReleaseChannels", ctx).Return(tc.Channels, nil)
+			if tc.Release != nil {
+				db.On("GetRelease", ctx, "release").Return(tc.Release, nil)
+			}
+
+			inv := &inventory_mocks.Client{}
+			defer inv.AssertExpectations(t)
+			if len(tc.Constructor.Devices) > 1 {
+				// the groups of all the devices are fetched at once
+				inv.On("Search", ctx, "", model.SearchParams{
+					Page:      1,
+					PerPage:   len(tc.Constructor.Devices),
+					DeviceIDs: tc.Constructor.Devices,
+				}).Return(tc.Devices, len(tc.Devices), nil).Once()
+			}
+			for _, channel := range tc.Channels {
+				for _, group := range channel.Groups {
+					group := group
+					inv.On("Search", ctx, "", mock.MatchedBy(
+						func(params model.SearchParams) bool {
+							return len(params.Filters) == 2 &&
+								params.Filters[0].Value == tc.Constructor.Group &&
+								params.Filters[1].Value == group
+						})).
+						Return(nil, tc.Members[group], nil).
+						Maybe()
+				}
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			d.SetInventoryClient(inv)
+			err := d.checkReleaseChannel(ctx, tc.Constructor, tc.Groups)
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
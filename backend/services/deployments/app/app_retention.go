@@ -0,0 +1,311 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/client/workflows"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+// RetentionAuditActor identifies the retention policy in the audit trail.
+const RetentionAuditActor = "deployments-retention"
+
+func (d *Deployments) GetRetentionPolicy(ctx context.Context) (*model.RetentionPolicy, error) {
+	policy, err := d.db.GetRetentionPolicy(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the retention policy")
+	} else if policy == nil {
+		policy = &model.RetentionPolicy{}
+	}
+	return policy, nil
+}
+
+func (d *Deployments) SetRetentionPolicy(
+	ctx context.Context,
+	policy *model.RetentionPolicy,
+) error {
+	err := d.db.SetRetentionPolicy(ctx, policy)
+	if err != nil {
+		return errors.Wrap(err, "failed to store the retention policy")
+	}
+	return nil
+}
+
+// ApplyRetentionPolicy deletes the artifacts selected by the retention
+// policy of the tenant; in a dry run the artifacts are only reported.
+func (d *Deployments) ApplyRetentionPolicy(
+	ctx context.Context,
+	dryRun bool,
+) (*model.RetentionReport, error) {
+	policy, err := d.GetRetentionPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &model.RetentionReport{
+		Policy:    *policy,
+		DryRun:    dryRun,
+		Artifacts: []model.RetentionCandidate{},
+	}
+	if !policy.Enabled() {
+		return report, nil
+	}
+	candidates, err := d.retentionCandidates(ctx, *policy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	l := log.FromContext(ctx)
+	for _, candidate := range candidates {
+		if !dryRun {
+			err = d.DeleteImage(ctx, candidate.ID)
+			if errors.Is(err, ErrModelImageInActiveDeployment) ||
+				errors.Is(err, ErrImageMetaNotFound) {
+				// a deployment or a user beat us to it
+				continue
+			} else if err != nil {
+				l.Errorf("retention: failed to delete the artifact %s: %s",
+					candidate.ID, err.Error())
+				continue
+			}
+			d.submitRetentionAuditLog(ctx, candidate)
+		}
+		report.Artifacts = append(report.Artifacts, candidate)
+		report.TotalSize += candidate.Size
+	}
+	return report, nil
+}
+
+// retentionCandidates selects the artifacts to delete according to the
+// policy, leaving out the artifacts installed on devices or used by
+// active deployments.
+func (d *Deployments) retentionCandidates(
+	ctx context.Context,
+	policy model.RetentionPolicy,
+	now time.Time,
+) ([]model.RetentionCandidate, error) {
+	images, _, err := d.db.ListImages(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the artifacts")
+	}
+	installedIDs, err := d.db.GetInstalledArtifactIDs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the installed artifacts")
+	}
+	installed := make(map[string]bool, len(installedIDs))
+	for _, id := range installedIDs {
+		installed[id] = true
+	}
+	lastUsed, err := d.db.GetArtifactsLastUsed(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the last use of the artifacts")
+	}
+	kept := keptReleases(images, policy.KeepReleases)
+	unusedSince := now.AddDate(0, 0, -policy.UnusedDays)
+
+	var candidates []model.RetentionCandidate
+	for _, image := range images {
+		if image.ArtifactMeta == nil || installed[image.Id] {
+			continue
+		}
+		isKept := false
+		for _, deviceType := range image.DeviceTypesCompatible {
+			if kept[deviceType][image.Name] {
+				isKept = true
+				break
+			}
+		}
+		if isKept {
+			continue
+		}
+
+		candidate := model.RetentionCandidate{
+			ID:          image.Id,
+			Name:        image.Name,
+			DeviceTypes: image.DeviceTypesCompatible,
+			Size:        image.Size,
+			Modified:    image.Modified,
+			Reason:      model.RetentionReasonReleaseLimit,
+		}
+		used := image.Modified
+		if t, ok := lastUsed[image.Id]; ok {
+			candidate.LastUsed = &t
+			used = &t
+		}
+		if policy.UnusedDays > 0 {
+			if used == nil || used.After(unusedSince) {
+				continue
+			}
+			candidate.Reason = model.RetentionReasonUnused
+		}
+
+		active, err := d.ImageUsedInActiveDeployment(ctx, image.Id)
+		if err != nil {
+			return nil, err
+		} else if active {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// keptReleases returns the names of the most recent releases to keep for
+// each device type; a release is as recent as its latest artifact.
+func keptReleases(images []*model.Image, keep int) map[string]map[string]bool {
+	kept := make(map[string]map[string]bool)
+	if keep <= 0 {
+		return kept
+	}
+	modified := make(map[string]map[string]time.Time)
+	for _, image := range images {
+		if image.ArtifactMeta == nil {
+			continue
+		}
+		var t time.Time
+		if image.Modified != nil {
+			t = *image.Modified
+		}
+		for _, deviceType := range image.DeviceTypesCompatible {
+			releases, ok := modified[deviceType]
+			if !ok {
+				releases = make(map[string]time.Time)
+				modified[deviceType] = releases
+			}
+			if latest, ok := releases[image.Name]; !ok || t.After(latest) {
+				releases[image.Name] = t
+			}
+		}
+	}
+	for deviceType, releases := range modified {
+		names := make([]string, 0, len(releases))
+		for name := range releases {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			ti, tj := releases[names[i]], releases[names[j]]
+			if !ti.Equal(tj) {
+				return ti.After(tj)
+			}
+			return names[i] > names[j]
+		})
+		if len(names) > keep {
+			names = names[:keep]
+		}
+		kept[deviceType] = make(map[string]bool, len(names))
+		for _, name := range names {
+			kept[deviceType][name] = true
+		}
+	}
+	return kept
+}
+
+// submitRetentionAuditLog records the deletion of an artifact by the
+// retention policy in the audit trail.
+func (d *Deployments) submitRetentionAuditLog(
+	ctx context.Context,
+	candidate model.RetentionCandidate,
+) {
+	if !d.haveAuditLogs {
+		return
+	}
+	err := d.workflowsClient.SubmitAuditLog(ctx, workflows.AuditLog{
+		Action: workflows.ActionDeleteArtifact,
+		Actor: workflows.Actor{
+			ID:   RetentionAuditActor,
+			Type: workflows.ActorSystem,
+		},
+		Object: workflows.Object{
+			ID:   candidate.ID,
+			Type: workflows.ObjectArtifact,
+		},
+		Change: fmt.Sprintf("Deleted artifact %q by the retention policy (%s)",
+			candidate.Name, candidate.Reason),
+		EventTS: time.Now(),
+	})
+	if err != nil {
+		log.FromContext(ctx).
+			Errorf("failed to submit audit log for artifact %s: %s",
+				candidate.ID, err.Error())
+	}
+}
+
+// EnforceRetentionPolicies applies the retention policies of all the
+// tenants every interval; an interval of 0 runs a single iteration.
+func (d *Deployments) EnforceRetentionPolicies(
+	ctx context.Context,
+	interval time.Duration,
+	dryRun bool,
+) error {
+	var tc <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tc = ticker.C
+	}
+	for {
+		if err := d.enforceRetentionPolicies(ctx, dryRun); err != nil {
+			return err
+		}
+		if tc == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tc:
+		}
+	}
+}
+
+func (d *Deployments) enforceRetentionPolicies(ctx context.Context, dryRun bool) error {
+	l := log.FromContext(ctx)
+	dbs, err := d.db.GetTenantDbs()
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve tenant DBs")
+	} else if len(dbs) == 0 {
+		dbs = []string{mongo.DbName}
+	}
+	for _, db := range dbs {
+		tenantCtx := ctx
+		if tenant := mstore.TenantFromDbName(db, mongo.DbName); tenant != "" {
+			tenantCtx = identity.WithContext(ctx, &identity.Identity{
+				Tenant: tenant,
+			})
+		}
+		report, err := d.ApplyRetentionPolicy(tenantCtx, dryRun)
+		if err != nil {
+			l.Errorf("retention: failed to apply the policy of DB %s: %s",
+				db, err.Error())
+			continue
+		}
+		if len(report.Artifacts) > 0 {
+			l.Infof("retention: DB %s: %d artifacts (%d bytes) deleted, dry run: %t",
+				db, len(report.Artifacts), report.TotalSize, dryRun)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/deployments/client/workflows"
+	workflows_mocks "github.com/mendersoftware/mender-server/services/deployments/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	fs_mocks "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func newRetentionImage(id, name string, modified time.Time, deviceTypes ...string) *model.Image {
+	return &model.Image{
+		Id:        id,
+		ImageMeta: &model.ImageMeta{},
+		ArtifactMeta: &model.ArtifactMeta{
+			Name:                  name,
+			DeviceTypesCompatible: deviceTypes,
+		},
+		Size:     100,
+		Modified: &modified,
+	}
+}
+
+func TestKeptReleases(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	images := []*model.Image{
+		newRetentionImage("1", "app-1", now.Add(-3*time.Hour), "pi", "bbb"),
+		newRetentionImage("2", "app-2", now.Add(-2*time.Hour), "pi"),
+		newRetentionImage("3", "app-3", now.Add(-time.Hour), "pi"),
+		newRetentionImage("4", "app-1", now, "pi"),
+	}
+
+	assert.Empty(t, keptReleases(images, 0))
+	assert.Equal(t, map[string]map[string]bool{
+		"pi":  {"app-1": true, "app-3": true},
+		"bbb": {"app-1": true},
+	}, keptReleases(images, 2))
+}
+
+func TestApplyRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	lastUsed := now.AddDate(0, 0, -40)
+	images := []*model.Image{
+		newRetentionImage("installed", "app-0", now.AddDate(0, 0, -200), "pi"),
+		newRetentionImage("unused", "app-1", now.AddDate(0, 0, -100), "pi"),
+		newRetentionImage("used", "app-2", now.AddDate(0, 0, -50), "pi"),
+		newRetentionImage("active", "app-3", now.AddDate(0, 0, -45), "pi"),
+		newRetentionImage("latest", "app-4", now.AddDate(0, 0, -1), "pi"),
+	}
+
+	testCases := map[string]struct {
+		Policy *model.RetentionPolicy
+		DryRun bool
+
+		Deleted []string
+	}{
+		"ok, no policy": {},
+		"ok, dry run": {
+			Policy:  &model.RetentionPolicy{KeepReleases: 1, UnusedDays: 30},
+			DryRun:  true,
+			Deleted: []string{"unused", "used"},
+		},
+		"ok, unused days": {
+			Policy:  &model.RetentionPolicy{UnusedDays: 60},
+			Deleted: []string{"unused"},
+		},
+		"ok, release limit": {
+			Policy:  &model.RetentionPolicy{KeepReleases: 2},
+			Deleted: []string{"unused", "used"},
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			fs := &fs_mocks.ObjectStorage{}
+			defer fs.AssertExpectations(t)
+			wf := &workflows_mocks.Client{}
+			defer wf.AssertExpectations(t)
+
+			db.On("GetRetentionPolicy", ctx).Return(tc.Policy, nil)
+			if tc.Policy != nil {
+				db.On("ListImages", ctx, (*model.ReleaseOrImageFilter)(nil)).
+					Return(images, len(images), nil)
+				db.On("GetInstalledArtifactIDs", ctx).
+					Return([]string{"installed"}, nil)
+				db.On("GetArtifactsLastUsed", ctx).
+					Return(map[string]time.Time{"used": lastUsed}, nil)
+				db.On("ExistUnfinishedByArtifactId", h.ContextMatcher(), "active").
+					Return(true, nil).Maybe()
+				db.On("ExistUnfinishedByArtifactId", h.ContextMatcher(),
+					mock.AnythingOfType("string")).
+					Return(false, nil).Maybe()
+			}
+			if !tc.DryRun {
+				for _, id := range tc.Deleted {
+					var image *model.Image
+					for _, i := range images {
+						if i.Id == id {
+							image = i
+						}
+					}
+					db.On("FindImageByID", ctx, id).Return(image, nil)
+					db.On("DeleteImage", h.ContextMatcher(), id).Return(nil)
+					db.On("UpdateReleaseArtifacts", h.ContextMatcher(),
+						(*model.Image)(nil), image, image.Name).
+						Return(nil)
+					fs.On("DeleteObject", h.ContextMatcher(), id).Return(nil)
+					wf.On("SubmitAuditLog", ctx,
+						mock.MatchedBy(func(log workflows.AuditLog) bool {
+							return log.Action == workflows.ActionDeleteArtifact &&
+								log.Actor.Type == workflows.ActorSystem &&
+								log.Object.ID == id &&
+								log.Object.Type == workflows.ObjectArtifact
+						})).
+						Return(nil)
+				}
+				db.On("GetStorageSettings", ctx).Return(nil, nil).Maybe()
+			}
+
+			d := NewDeployments(db, fs, 0, true)
+			d.SetWorkflowsClient(wf)
+			report, err := d.ApplyRetentionPolicy(ctx, tc.DryRun)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.DryRun, report.DryRun)
+			ids := []string{}
+			for _, candidate := range report.Artifacts {
+				ids = append(ids, candidate.ID)
+			}
+			if tc.Deleted == nil {
+				tc.Deleted = []string{}
+			}
+			assert.Equal(t, tc.Deleted, ids)
+			assert.Equal(t, int64(100*len(tc.Deleted)), report.TotalSize)
+		})
+	}
+}
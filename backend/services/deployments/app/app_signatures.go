@@ -0,0 +1,165 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+// Errors expected from App interface
+var (
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+	ErrSigningKeyExists   = errors.New("a signing key with the same public key already exists")
+
+	ErrModelArtifactNotSigned = errors.New(
+		"artifact is not signed; signed artifacts are required",
+	)
+	ErrModelArtifactSignatureInvalid = errors.New(
+		"artifact signature does not match any of the signing keys",
+	)
+	ErrModelArtifactPayloadNotVerified = errors.New(
+		"artifact payload was not verified against the signed manifest; " +
+			"signed artifacts are required",
+	)
+)
+
+// artifactSignature is the signature of an artifact captured while
+// parsing the artifact together with the signed message.
+type artifactSignature struct {
+	message   []byte
+	signature []byte
+}
+
+func (d *Deployments) GetSigningKeys(ctx context.Context) ([]model.SigningKey, error) {
+	keys, err := d.db.GetSigningKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get signing keys")
+	}
+	return keys, nil
+}
+
+func (d *Deployments) CreateSigningKey(
+	ctx context.Context,
+	request *model.NewSigningKey,
+) (*model.SigningKey, error) {
+	key, err := model.NewSigningKeyFromRequest(uuid.NewString(), request)
+	if err != nil {
+		return nil, err
+	}
+	key.Created = time.Now().UTC()
+	err = d.db.InsertSigningKey(ctx, key)
+	if errors.Is(err, mongo.ErrSigningKeyConflict) {
+		return nil, ErrSigningKeyExists
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to store the signing key")
+	}
+	return key, nil
+}
+
+func (d *Deployments) DeleteSigningKey(ctx context.Context, id string) error {
+	err := d.db.DeleteSigningKey(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrSigningKeyNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to delete the signing key")
+	}
+	return nil
+}
+
+func (d *Deployments) GetSignatureSettings(
+	ctx context.Context,
+) (*model.SignatureSettings, error) {
+	settings, err := d.db.GetSignatureSettings(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get signature settings")
+	} else if settings == nil {
+		settings = &model.SignatureSettings{}
+	}
+	return settings, nil
+}
+
+func (d *Deployments) SetSignatureSettings(
+	ctx context.Context,
+	settings *model.SignatureSettings,
+) error {
+	if err := d.db.SetSignatureSettings(ctx, settings); err != nil {
+		return errors.Wrap(err, "failed to save signature settings")
+	}
+	return nil
+}
+
+// verifyArtifactSignature verifies the artifact signature against the
+// tenant's signing keys. If the tenant requires signed artifacts, artifacts
+// which are not signed or whose signature cannot be verified are rejected.
+// The signature covers the manifest only: unless payloadVerified is set,
+// the payload was not checked against the manifest checksums, so the
+// artifact is never recorded as verified and is rejected if signed
+// artifacts are required.
+func (d *Deployments) verifyArtifactSignature(
+	ctx context.Context,
+	sig *artifactSignature,
+	payloadVerified bool,
+) (*model.ImageSignature, error) {
+	settings, err := d.GetSignatureSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		if settings.RequireSignature {
+			return nil, ErrModelArtifactNotSigned
+		}
+		return &model.ImageSignature{
+			Status: model.ImageSignatureStatusUnsigned,
+		}, nil
+	}
+	keys, err := d.GetSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		err = key.Verify(sig.message, sig.signature)
+		if err == nil && !payloadVerified {
+			if settings.RequireSignature {
+				return nil, ErrModelArtifactPayloadNotVerified
+			}
+			return &model.ImageSignature{
+				Status: model.ImageSignatureStatusUnverified,
+			}, nil
+		} else if err == nil {
+			return &model.ImageSignature{
+				Status: model.ImageSignatureStatusVerified,
+				KeyID:  key.ID,
+			}, nil
+		}
+		log.FromContext(ctx).
+			Debugf("artifact signature not verified by key %s: %s", key.ID, err)
+	}
+	if settings.RequireSignature {
+		return nil, ErrModelArtifactSignatureInvalid
+	}
+	return &model.ImageSignature{
+		Status: model.ImageSignatureStatusUnverified,
+	}, nil
+}
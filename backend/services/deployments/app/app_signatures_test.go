@@ -0,0 +1,329 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mendersoftware/mender-artifact/artifact"
+	"github.com/mendersoftware/mender-artifact/awriter"
+	"github.com/mendersoftware/mender-artifact/handlers"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func newTestSigner(t *testing.T) (artifact.Signer, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	signer, err := artifact.NewPKISigner(
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+	)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return signer, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+}
+
+func writeTestArtifact(t *testing.T, signer artifact.Signer) []byte {
+	updateFile := filepath.Join(t.TempDir(), "rootfs")
+	require.NoError(t, os.WriteFile(updateFile, []byte("rootfs"), 0600))
+
+	var buf bytes.Buffer
+	var aw *awriter.Writer
+	if signer != nil {
+		aw = awriter.NewWriterSigned(&buf, artifact.NewCompressorNone(), signer)
+	} else {
+		aw = awriter.NewWriter(&buf, artifact.NewCompressorNone())
+	}
+	updateType := "rootfs-image"
+	err := aw.WriteArtifact(&awriter.WriteArtifactArgs{
+		Format:  "mender",
+		Version: 3,
+		Devices: []string{"dev"},
+		Name:    "artifact",
+		Updates: &awriter.Updates{
+			Updates: []handlers.Composer{handlers.NewRootfsV3(updateFile)},
+		},
+		Provides: &artifact.ArtifactProvides{ArtifactName: "artifact"},
+		Depends:  &artifact.ArtifactDepends{CompatibleDevices: []string{"dev"}},
+		TypeInfoV3: &artifact.TypeInfoV3{
+			Type: &updateType,
+		},
+	})
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestGetMetaFromArchiveSignature(t *testing.T) {
+	t.Parallel()
+
+	signer, publicKey := newTestSigner(t)
+	key, err := model.NewSigningKeyFromRequest("key", &model.NewSigningKey{
+		Name:      "key",
+		PublicKey: publicKey,
+	})
+	require.NoError(t, err)
+
+	var r io.Reader = bytes.NewReader(writeTestArtifact(t, signer))
+	meta, sig, err := getMetaFromArchive(&r, false)
+	require.NoError(t, err)
+	assert.True(t, meta.Signed)
+	if assert.NotNil(t, sig) {
+		assert.NoError(t, key.Verify(sig.message, sig.signature))
+	}
+
+	r = bytes.NewReader(writeTestArtifact(t, nil))
+	meta, sig, err = getMetaFromArchive(&r, false)
+	require.NoError(t, err)
+	assert.False(t, meta.Signed)
+	assert.Nil(t, sig)
+}
+
+func TestVerifyArtifactSignature(t *testing.T) {
+	t.Parallel()
+
+	signer, publicKey := newTestSigner(t)
+	key, err := model.NewSigningKeyFromRequest("key", &model.NewSigningKey{
+		Name:      "key",
+		PublicKey: publicKey,
+	})
+	require.NoError(t, err)
+	_, otherPublicKey := newTestSigner(t)
+	otherKey, err := model.NewSigningKeyFromRequest("other", &model.NewSigningKey{
+		Name:      "other",
+		PublicKey: otherPublicKey,
+	})
+	require.NoError(t, err)
+
+	message := []byte("manifest")
+	signature, err := signer.Sign(message)
+	require.NoError(t, err)
+	sig := &artifactSignature{message: message, signature: signature}
+
+	testCases := map[string]struct {
+		settings *model.SignatureSettings
+		keys     []model.SigningKey
+		sig      *artifactSignature
+		// payloadUnchecked is set for direct uploads skipping the
+		// verification of the payload
+		payloadUnchecked bool
+
+		result *model.ImageSignature
+		err    error
+	}{
+		"ok, unsigned": {
+			result: &model.ImageSignature{
+				Status: model.ImageSignatureStatusUnsigned,
+			},
+		},
+		"ok, verified": {
+			keys: []model.SigningKey{*otherKey, *key},
+			sig:  sig,
+			result: &model.ImageSignature{
+				Status: model.ImageSignatureStatusVerified,
+				KeyID:  key.ID,
+			},
+		},
+		"ok, payload not verified": {
+			keys:             []model.SigningKey{*key},
+			sig:              sig,
+			payloadUnchecked: true,
+			result: &model.ImageSignature{
+				Status: model.ImageSignatureStatusUnverified,
+			},
+		},
+		"ok, unverified": {
+			keys: []model.SigningKey{*otherKey},
+			sig:  sig,
+			result: &model.ImageSignature{
+				Status: model.ImageSignatureStatusUnverified,
+			},
+		},
+		"ok, required and verified": {
+			settings: &model.SignatureSettings{RequireSignature: true},
+			keys:     []model.SigningKey{*key},
+			sig:      sig,
+			result: &model.ImageSignature{
+				Status: model.ImageSignatureStatusVerified,
+				KeyID:  key.ID,
+			},
+		},
+		"error, required and payload not verified": {
+			settings:         &model.SignatureSettings{RequireSignature: true},
+			keys:             []model.SigningKey{*key},
+			sig:              sig,
+			payloadUnchecked: true,
+			err:              ErrModelArtifactPayloadNotVerified,
+		},
+		"error, required and unsigned": {
+			settings: &model.SignatureSettings{RequireSignature: true},
+			err:      ErrModelArtifactNotSigned,
+		},
+		"error, required and unverified": {
+			settings: &model.SignatureSettings{RequireSignature: true},
+			keys:     []model.SigningKey{*otherKey},
+			sig:      sig,
+			err:      ErrModelArtifactSignatureInvalid,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			db.On("GetSignatureSettings", ctx).Return(tc.settings, nil)
+			if tc.sig != nil {
+				db.On("GetSigningKeys", ctx).Return(tc.keys, nil)
+			}
+			d := NewDeployments(db, nil, 0, false)
+
+			result, err := d.verifyArtifactSignature(ctx, tc.sig, !tc.payloadUnchecked)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.result, result)
+			}
+		})
+	}
+}
+
+func TestCreateSigningKey(t *testing.T) {
+	t.Parallel()
+
+	_, publicKey := newTestSigner(t)
+
+	testCases := map[string]struct {
+		request *model.NewSigningKey
+		dbErr   error
+
+		err error
+	}{
+		"ok": {
+			request: &model.NewSigningKey{Name: "key", PublicKey: publicKey},
+		},
+		"error, invalid key": {
+			request: &model.NewSigningKey{Name: "key", PublicKey: "key"},
+			err:     model.ErrSigningKeyInvalidPEM,
+		},
+		"error, conflict": {
+			request: &model.NewSigningKey{Name: "key", PublicKey: publicKey},
+			dbErr:   mongo.ErrSigningKeyConflict,
+			err:     ErrSigningKeyExists,
+		},
+		"error, db": {
+			request: &model.NewSigningKey{Name: "key", PublicKey: publicKey},
+			dbErr:   errors.New("db error"),
+			err:     errors.New("failed to store the signing key: db error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := mocks.NewDataStore(t)
+			if tc.err != model.ErrSigningKeyInvalidPEM {
+				db.On("InsertSigningKey", h.ContextMatcher(),
+					mock.MatchedBy(func(key *model.SigningKey) bool {
+						return key.Name == tc.request.Name &&
+							key.Type == model.SigningKeyTypeECDSA &&
+							!key.Created.IsZero()
+					}),
+				).Return(tc.dbErr)
+			}
+			d := NewDeployments(db, nil, 0, false)
+
+			key, err := d.CreateSigningKey(context.Background(), tc.request)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				assert.Nil(t, key)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, key.ID)
+				assert.Equal(t, tc.request.PublicKey, key.PublicKey)
+			}
+		})
+	}
+}
+
+func TestDeleteSigningKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		dbErr error
+		err   error
+	}{
+		"ok": {},
+		"error, not found": {
+			dbErr: store.ErrNotFound,
+			err:   ErrSigningKeyNotFound,
+		},
+		"error, db": {
+			dbErr: errors.New("db error"),
+			err:   errors.New("failed to delete the signing key: db error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			db.On("DeleteSigningKey", ctx, "key").Return(tc.dbErr)
+			d := NewDeployments(db, nil, 0, false)
+
+			err := d.DeleteSigningKey(ctx, "key")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetSignatureSettings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := mocks.NewDataStore(t)
+	db.On("GetSignatureSettings", ctx).Return(nil, nil).Once()
+	db.On("GetSignatureSettings", ctx).
+		Return(&model.SignatureSettings{RequireSignature: true}, nil).Once()
+	d := NewDeployments(db, nil, 0, false)
+
+	settings, err := d.GetSignatureSettings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.SignatureSettings{}, settings)
+
+	settings, err = d.GetSignatureSettings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.SignatureSettings{RequireSignature: true}, settings)
+}
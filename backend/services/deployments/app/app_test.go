@@ -326,6 +326,9 @@ func TestDeploymentModelCreateDeployment(t *testing.T) {
 							Depends: map[string]interface{}{},
 						}, artifactSize)},
 					testCase.InputImagesByNameError)
+			db.On("GetReleaseChannels", ctx).
+				Return([]model.ReleaseChannel{}, nil).
+				Maybe()
 
 			fs := &fs_mocks.ObjectStorage{}
 			ds := NewDeployments(&db, fs, 0, false)
@@ -1201,6 +1204,76 @@ func TestAbortDeployment(t *testing.T) {
 	}
 }
 
+func TestAbortDeploymentAuditLog(t *testing.T) {
+	t.Parallel()
+
+	const deploymentID = "f826484e-1157-4109-af21-304e6d711561"
+	testCases := map[string]struct {
+		Identity      *identity.Identity
+		HaveAuditLogs bool
+		SubmitError   error
+		Submit        bool
+	}{
+		"user with audit logs": {
+			Identity:      &identity.Identity{Subject: "user-id", Tenant: "tenant", IsUser: true},
+			HaveAuditLogs: true,
+			Submit:        true,
+		},
+		"submit error is not propagated": {
+			Identity:      &identity.Identity{Subject: "user-id", Tenant: "tenant", IsUser: true},
+			HaveAuditLogs: true,
+			Submit:        true,
+			SubmitError:   errors.New("workflows down"),
+		},
+		"audit logs disabled": {
+			Identity: &identity.Identity{Subject: "user-id", Tenant: "tenant", IsUser: true},
+		},
+		"no user identity": {
+			HaveAuditLogs: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := mocks.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("AbortDeviceDeployments", h.ContextMatcher(), deploymentID).
+				Return(nil)
+			db.On("AggregateDeviceDeploymentByStatus", h.ContextMatcher(), deploymentID).
+				Return(model.Stats{}, nil)
+			db.On("UpdateStats", h.ContextMatcher(), deploymentID,
+				mock.AnythingOfType("model.Stats")).
+				Return(nil)
+			db.On("SetDeploymentStatus", h.ContextMatcher(), deploymentID,
+				model.DeploymentStatusFinished, mock.AnythingOfType("time.Time")).
+				Return(nil)
+
+			wf := &workflows_mocks.Client{}
+			defer wf.AssertExpectations(t)
+			if tc.Submit {
+				wf.On("SubmitAuditLog", h.ContextMatcher(),
+					mock.MatchedBy(func(log workflows.AuditLog) bool {
+						return log.Action == workflows.ActionAbortDeployment &&
+							log.Actor.ID == tc.Identity.Subject &&
+							log.Actor.Type == workflows.ActorUser &&
+							log.Object.ID == deploymentID &&
+							log.Object.Type == workflows.ObjectDeployment
+					})).
+					Return(tc.SubmitError)
+			}
+
+			ds := NewDeployments(&db, nil, 0, tc.HaveAuditLogs)
+			ds.SetWorkflowsClient(wf)
+			ctx := context.Background()
+			if tc.Identity != nil {
+				ctx = identity.WithContext(ctx, tc.Identity)
+			}
+
+			assert.NoError(t, ds.AbortDeployment(ctx, deploymentID))
+		})
+	}
+}
+
 func TestDeleteDeviceDeploymentsHistory(t *testing.T) {
 	t.Parallel()
 	f := false
@@ -70,6 +70,114 @@ func (_m *App) AbortDeviceDeployments(ctx context.Context, deviceID string) erro
 	return r0
 }
 
+// ApplyRetentionPolicy provides a mock function with given fields: ctx, dryRun
+func (_m *App) ApplyRetentionPolicy(ctx context.Context, dryRun bool) (*model.RetentionReport, error) {
+	ret := _m.Called(ctx, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyRetentionPolicy")
+	}
+
+	var r0 *model.RetentionReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) (*model.RetentionReport, error)); ok {
+		return rf(ctx, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) *model.RetentionReport); ok {
+		r0 = rf(ctx, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RetentionReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApproveReleasePromotion provides a mock function with given fields: ctx, releaseName
+func (_m *App) ApproveReleasePromotion(ctx context.Context, releaseName string) (*model.Release, error) {
+	ret := _m.Called(ctx, releaseName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReleasePromotion")
+	}
+
+	var r0 *model.Release
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Release, error)); ok {
+		return rf(ctx, releaseName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Release); ok {
+		r0 = rf(ctx, releaseName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, releaseName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelReleasePromotion provides a mock function with given fields: ctx, releaseName
+func (_m *App) CancelReleasePromotion(ctx context.Context, releaseName string) error {
+	ret := _m.Called(ctx, releaseName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReleasePromotion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, releaseName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClusterDeploymentLogs provides a mock function with given fields: ctx, query
+func (_m *App) ClusterDeploymentLogs(ctx context.Context, query store.DeploymentLogQuery) (*model.DeploymentLogClusters, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClusterDeploymentLogs")
+	}
+
+	var r0 *model.DeploymentLogClusters
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) (*model.DeploymentLogClusters, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) *model.DeploymentLogClusters); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeploymentLogClusters)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeploymentLogQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CompleteUpload provides a mock function with given fields: ctx, intentID, skipVerify, metadata
 func (_m *App) CompleteUpload(ctx context.Context, intentID string, skipVerify bool, metadata *model.DirectUploadMetadata) error {
 	ret := _m.Called(ctx, intentID, skipVerify, metadata)
@@ -172,6 +280,72 @@ func (_m *App) CreateImage(ctx context.Context, multipartUploadMsg *model.Multip
 	return r0, r1
 }
 
+// CreateMaintenanceWindow provides a mock function with given fields: ctx, window
+func (_m *App) CreateMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error {
+	ret := _m.Called(ctx, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMaintenanceWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MaintenanceWindow) error); ok {
+		r0 = rf(ctx, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateReleaseChannel provides a mock function with given fields: ctx, channel
+func (_m *App) CreateReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error {
+	ret := _m.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReleaseChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseChannel) error); ok {
+		r0 = rf(ctx, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSigningKey provides a mock function with given fields: ctx, request
+func (_m *App) CreateSigningKey(ctx context.Context, request *model.NewSigningKey) (*model.SigningKey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSigningKey")
+	}
+
+	var r0 *model.SigningKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.NewSigningKey) (*model.SigningKey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.NewSigningKey) *model.SigningKey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SigningKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.NewSigningKey) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DecommissionDevice provides a mock function with given fields: ctx, deviceID
 func (_m *App) DecommissionDevice(ctx context.Context, deviceID string) error {
 	ret := _m.Called(ctx, deviceID)
@@ -226,6 +400,42 @@ func (_m *App) DeleteImage(ctx context.Context, imageID string) error {
 	return r0
 }
 
+// DeleteMaintenanceWindow provides a mock function with given fields: ctx, name
+func (_m *App) DeleteMaintenanceWindow(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMaintenanceWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReleaseChannel provides a mock function with given fields: ctx, name
+func (_m *App) DeleteReleaseChannel(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReleaseChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteReleases provides a mock function with given fields: ctx, releaseNames
 func (_m *App) DeleteReleases(ctx context.Context, releaseNames []string) ([]string, error) {
 	ret := _m.Called(ctx, releaseNames)
@@ -256,6 +466,42 @@ func (_m *App) DeleteReleases(ctx context.Context, releaseNames []string) ([]str
 	return r0, r1
 }
 
+// DeleteSigningKey provides a mock function with given fields: ctx, id
+func (_m *App) DeleteSigningKey(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSigningKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTenant provides a mock function with given fields: ctx, tenantID
+func (_m *App) DeleteTenant(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTenant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DownloadLink provides a mock function with given fields: ctx, imageID, expire
 func (_m *App) DownloadLink(ctx context.Context, imageID string, expire time.Duration) (*model.Link, error) {
 	ret := _m.Called(ctx, imageID, expire)
@@ -344,6 +590,34 @@ func (_m *App) GenerateConfigurationImage(ctx context.Context, deviceType string
 	return r0, r1
 }
 
+// GenerateDeltaImage provides a mock function with given fields: ctx, request
+func (_m *App) GenerateDeltaImage(ctx context.Context, request *model.GenerateDeltaImageRequest) (string, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateDeltaImage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GenerateDeltaImageRequest) (string, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GenerateDeltaImageRequest) string); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GenerateDeltaImageRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateImage provides a mock function with given fields: ctx, multipartUploadMsg
 func (_m *App) GenerateImage(ctx context.Context, multipartUploadMsg *model.MultipartGenerateImageMsg) (string, error) {
 	ret := _m.Called(ctx, multipartUploadMsg)
@@ -594,6 +868,43 @@ func (_m *App) GetDeviceDeploymentLog(ctx context.Context, deviceID string, depl
 	return r0, r1
 }
 
+// GetDeviceDeploymentTimeline provides a mock function with given fields: ctx, query
+func (_m *App) GetDeviceDeploymentTimeline(ctx context.Context, query store.ListQueryDeviceDeployments) ([]model.DeviceDeploymentTimelineItem, int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceDeploymentTimeline")
+	}
+
+	var r0 []model.DeviceDeploymentTimelineItem
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListQueryDeviceDeployments) ([]model.DeviceDeploymentTimelineItem, int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListQueryDeviceDeployments) []model.DeviceDeploymentTimelineItem); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DeviceDeploymentTimelineItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListQueryDeviceDeployments) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, store.ListQueryDeviceDeployments) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetDeviceStatusesForDeployment provides a mock function with given fields: ctx, deploymentID
 func (_m *App) GetDeviceStatusesForDeployment(ctx context.Context, deploymentID string) ([]model.DeviceDeployment, error) {
 	ret := _m.Called(ctx, deploymentID)
@@ -721,6 +1032,36 @@ func (_m *App) GetLimit(ctx context.Context, name string) (*model.Limit, error)
 	return r0, r1
 }
 
+// GetMaintenanceWindows provides a mock function with given fields: ctx
+func (_m *App) GetMaintenanceWindows(ctx context.Context) ([]model.MaintenanceWindow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaintenanceWindows")
+	}
+
+	var r0 []model.MaintenanceWindow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.MaintenanceWindow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.MaintenanceWindow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MaintenanceWindow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRelease provides a mock function with given fields: ctx, releaseName
 func (_m *App) GetRelease(ctx context.Context, releaseName string) (*model.Release, error) {
 	ret := _m.Called(ctx, releaseName)
@@ -751,6 +1092,36 @@ func (_m *App) GetRelease(ctx context.Context, releaseName string) (*model.Relea
 	return r0, r1
 }
 
+// GetReleaseChannels provides a mock function with given fields: ctx
+func (_m *App) GetReleaseChannels(ctx context.Context) ([]model.ReleaseChannel, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReleaseChannels")
+	}
+
+	var r0 []model.ReleaseChannel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.ReleaseChannel, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.ReleaseChannel); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReleaseChannel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReleasesUpdateTypes provides a mock function with given fields: ctx
 func (_m *App) GetReleasesUpdateTypes(ctx context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
@@ -781,6 +1152,96 @@ func (_m *App) GetReleasesUpdateTypes(ctx context.Context) ([]string, error) {
 	return r0, r1
 }
 
+// GetRetentionPolicy provides a mock function with given fields: ctx
+func (_m *App) GetRetentionPolicy(ctx context.Context) (*model.RetentionPolicy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRetentionPolicy")
+	}
+
+	var r0 *model.RetentionPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.RetentionPolicy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.RetentionPolicy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RetentionPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSignatureSettings provides a mock function with given fields: ctx
+func (_m *App) GetSignatureSettings(ctx context.Context) (*model.SignatureSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSignatureSettings")
+	}
+
+	var r0 *model.SignatureSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.SignatureSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.SignatureSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SignatureSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSigningKeys provides a mock function with given fields: ctx
+func (_m *App) GetSigningKeys(ctx context.Context) ([]model.SigningKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSigningKeys")
+	}
+
+	var r0 []model.SigningKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.SigningKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.SigningKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SigningKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageSettings provides a mock function with given fields: ctx
 func (_m *App) GetStorageSettings(ctx context.Context) (*model.StorageSettings, error) {
 	ret := _m.Called(ctx)
@@ -1019,6 +1480,66 @@ func (_m *App) LookupDeployment(ctx context.Context, query model.Query) ([]*mode
 	return r0, r1, r2
 }
 
+// PreviewDeployment provides a mock function with given fields: ctx, constructor
+func (_m *App) PreviewDeployment(ctx context.Context, constructor *model.DeploymentConstructor) (*model.DeploymentPreview, error) {
+	ret := _m.Called(ctx, constructor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewDeployment")
+	}
+
+	var r0 *model.DeploymentPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeploymentConstructor) (*model.DeploymentPreview, error)); ok {
+		return rf(ctx, constructor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeploymentConstructor) *model.DeploymentPreview); ok {
+		r0 = rf(ctx, constructor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeploymentPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.DeploymentConstructor) error); ok {
+		r1 = rf(ctx, constructor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromoteRelease provides a mock function with given fields: ctx, releaseName, request
+func (_m *App) PromoteRelease(ctx context.Context, releaseName string, request model.ReleasePromotionRequest) (*model.Release, error) {
+	ret := _m.Called(ctx, releaseName, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PromoteRelease")
+	}
+
+	var r0 *model.Release
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.ReleasePromotionRequest) (*model.Release, error)); ok {
+		return rf(ctx, releaseName, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.ReleasePromotionRequest) *model.Release); ok {
+		r0 = rf(ctx, releaseName, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.ReleasePromotionRequest) error); ok {
+		r1 = rf(ctx, releaseName, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProvisionTenant provides a mock function with given fields: ctx, tenant_id
 func (_m *App) ProvisionTenant(ctx context.Context, tenant_id string) error {
 	ret := _m.Called(ctx, tenant_id)
@@ -1055,6 +1576,36 @@ func (_m *App) ReplaceReleaseTags(ctx context.Context, releaseName string, tags
 	return r0
 }
 
+// RollbackDeployment provides a mock function with given fields: ctx, deploymentID, request
+func (_m *App) RollbackDeployment(ctx context.Context, deploymentID string, request model.DeploymentRollbackRequest) (*model.DeploymentRollback, error) {
+	ret := _m.Called(ctx, deploymentID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackDeployment")
+	}
+
+	var r0 *model.DeploymentRollback
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeploymentRollbackRequest) (*model.DeploymentRollback, error)); ok {
+		return rf(ctx, deploymentID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeploymentRollbackRequest) *model.DeploymentRollback); ok {
+		r0 = rf(ctx, deploymentID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeploymentRollback)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.DeploymentRollbackRequest) error); ok {
+		r1 = rf(ctx, deploymentID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveDeviceDeploymentLog provides a mock function with given fields: ctx, deviceID, deploymentID, logs
 func (_m *App) SaveDeviceDeploymentLog(ctx context.Context, deviceID string, deploymentID string, logs []model.LogMessage) error {
 	ret := _m.Called(ctx, deviceID, deploymentID, logs)
@@ -1073,6 +1624,79 @@ func (_m *App) SaveDeviceDeploymentLog(ctx context.Context, deviceID string, dep
 	return r0
 }
 
+// SearchDeploymentLogs provides a mock function with given fields: ctx, query
+func (_m *App) SearchDeploymentLogs(ctx context.Context, query store.DeploymentLogQuery) ([]model.DeploymentLogEntry, int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchDeploymentLogs")
+	}
+
+	var r0 []model.DeploymentLogEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) ([]model.DeploymentLogEntry, int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) []model.DeploymentLogEntry); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DeploymentLogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeploymentLogQuery) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, store.DeploymentLogQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetRetentionPolicy provides a mock function with given fields: ctx, policy
+func (_m *App) SetRetentionPolicy(ctx context.Context, policy *model.RetentionPolicy) error {
+	ret := _m.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRetentionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RetentionPolicy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSignatureSettings provides a mock function with given fields: ctx, settings
+func (_m *App) SetSignatureSettings(ctx context.Context, settings *model.SignatureSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSignatureSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SignatureSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetStorageSettings provides a mock function with given fields: ctx, storageSettings
 func (_m *App) SetStorageSettings(ctx context.Context, storageSettings *model.StorageSettings) error {
 	ret := _m.Called(ctx, storageSettings)
@@ -1127,6 +1751,24 @@ func (_m *App) UpdateDeviceDeploymentStatus(ctx context.Context, deploymentID st
 	return r0
 }
 
+// UpdateMaintenanceWindow provides a mock function with given fields: ctx, window
+func (_m *App) UpdateMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error {
+	ret := _m.Called(ctx, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMaintenanceWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MaintenanceWindow) error); ok {
+		r0 = rf(ctx, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRelease provides a mock function with given fields: ctx, releaseName, release
 func (_m *App) UpdateRelease(ctx context.Context, releaseName string, release model.ReleasePatch) error {
 	ret := _m.Called(ctx, releaseName, release)
@@ -1145,6 +1787,24 @@ func (_m *App) UpdateRelease(ctx context.Context, releaseName string, release mo
 	return r0
 }
 
+// UpdateReleaseChannel provides a mock function with given fields: ctx, channel
+func (_m *App) UpdateReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error {
+	ret := _m.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReleaseChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseChannel) error); ok {
+		r0 = rf(ctx, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UploadLink provides a mock function with given fields: ctx, expire, skipVerify
 func (_m *App) UploadLink(ctx context.Context, expire time.Duration, skipVerify bool) (*model.UploadLink, error) {
 	ret := _m.Called(ctx, expire, skipVerify)
@@ -236,6 +236,8 @@ func TestGetDeploymentForDeviceWithCurrent(t *testing.T) {
 		mock.AnythingOfType("model.DeviceDeployment"),
 	).Return(nil)
 
+	db.On("GetMaintenanceWindows", ctx).Return([]model.MaintenanceWindow{}, nil)
+
 	ds := NewDeployments(&db, fs, 0, false)
 
 	_, err = ds.GetDeploymentForDeviceWithCurrent(ctx, devId, request)
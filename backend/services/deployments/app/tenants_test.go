@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
 	fs_mocks "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
 
 	mstore "github.com/mendersoftware/mender-server/services/deployments/store/mocks"
@@ -73,3 +75,79 @@ func TestProvisionTenant(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteTenant(t *testing.T) {
+	ctxMatcher := mock.MatchedBy(func(ctx context.Context) bool {
+		id := identity.FromContext(ctx)
+		return id != nil && id.Tenant == "foo"
+	})
+	testCases := []struct {
+		name string
+
+		settingsErr error
+		listErr     error
+		deleteErr   error
+		storeErr    error
+
+		err error
+	}{
+		{
+			name: "ok",
+		},
+		{
+			name:        "error, storage settings",
+			settingsErr: errors.New("connection failed"),
+			err:         errors.New("failed to get storage settings: connection failed"),
+		},
+		{
+			name:    "error, listing images",
+			listErr: errors.New("connection failed"),
+			err:     errors.New("failed to list images: connection failed"),
+		},
+		{
+			name:      "error, deleting image file",
+			deleteErr: errors.New("access denied"),
+			err:       errors.New("Deleting image file: access denied"),
+		},
+		{
+			name:     "error, dropping database",
+			storeErr: errors.New("connection failed"),
+			err:      errors.New("failed to delete tenant: connection failed"),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			fs := &fs_mocks.ObjectStorage{}
+			defer fs.AssertExpectations(t)
+
+			db.On("GetStorageSettings", ctxMatcher).Return(nil, tc.settingsErr)
+			if tc.settingsErr == nil {
+				db.On("ListImageIDs", ctxMatcher).
+					Return([]string{"image-1", "image-2"}, tc.listErr)
+			}
+			if tc.settingsErr == nil && tc.listErr == nil {
+				fs.On("DeleteObject", ctxMatcher, "foo/image-1").
+					Return(tc.deleteErr).Once()
+				if tc.deleteErr == nil {
+					fs.On("DeleteObject", ctxMatcher, "foo/image-2").
+						Return(nil).Once()
+					db.On("DeleteTenant", ctxMatcher, "foo").Return(tc.storeErr)
+				}
+			}
+
+			d := NewDeployments(db, fs, 0, false)
+
+			err := d.DeleteTenant(context.Background(), "foo")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -38,9 +38,11 @@ import (
 const (
 	healthURL                          = "/api/v1/health"
 	generateArtifactURL                = "/api/v1/workflow/generate_artifact"
+	generateDeltaURL                   = "/api/v1/workflow/generate_delta"
 	reindexReportingURL                = "/api/v1/workflow/reindex_reporting"
 	reindexReportingDeploymentURL      = "/api/v1/workflow/reindex_reporting_deployment"
 	reindexReportingDeploymentBatchURL = "/api/v1/workflow/reindex_reporting_deployment/batch"
+	auditlogsURL                       = "/api/v1/workflow/emit_auditlog"
 	defaultTimeout                     = 5 * time.Second
 )
 
@@ -59,9 +61,11 @@ type Client interface {
 		ctx context.Context,
 		multipartGenerateImageMsg *model.MultipartGenerateImageMsg,
 	) error
+	StartGenerateDelta(ctx context.Context, msg *model.GenerateDeltaImageMsg) error
 	StartReindexReporting(c context.Context, device string) error
 	StartReindexReportingDeployment(c context.Context, device, deployment, id string) error
 	StartReindexReportingDeploymentBatch(c context.Context, info []DeviceDeploymentShortInfo) error
+	SubmitAuditLog(ctx context.Context, log AuditLog) error
 }
 
 // NewClient returns a new workflows client
@@ -145,6 +149,39 @@ func (c *client) StartGenerateArtifact(
 	return nil
 }
 
+func (c *client) StartGenerateDelta(
+	ctx context.Context,
+	msg *model.GenerateDeltaImageMsg,
+) error {
+	l := log.FromContext(ctx)
+	l.Debugf("Submit generate delta: tenantID=%s, artifactID=%s",
+		msg.TenantID, msg.ArtifactID)
+
+	payload, _ := json.Marshal(msg)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST", c.baseURL+generateDeltaURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start workflow: generate_delta")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			body = []byte("<failed to read>")
+		}
+		l.Errorf("generate delta failed with status %v, response text: %s",
+			res.StatusCode, body)
+		return errors.New("failed to start workflow: generate_delta")
+	}
+	return nil
+}
+
 func (c *client) StartReindexReporting(ctx context.Context, device string) error {
 	if _, ok := ctx.Deadline(); !ok {
 		var cancel context.CancelFunc
@@ -304,3 +341,56 @@ func (c *client) StartReindexReportingDeploymentBatch(ctx context.Context,
 		rsp.Status,
 	)
 }
+
+func (c *client) SubmitAuditLog(ctx context.Context, log AuditLog) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	if log.EventTS.IsZero() {
+		log.EventTS = time.Now()
+	}
+	if err := log.Validate(); err != nil {
+		return errors.Wrap(err, "workflows: invalid AuditLog entry")
+	}
+	tenantID := ""
+	if ident := identity.FromContext(ctx); ident != nil {
+		tenantID = ident.Tenant
+	}
+	wflow := AuditWorkflow{
+		RequestID: requestid.FromContext(ctx),
+		TenantID:  tenantID,
+		AuditLog:  log,
+	}
+	payload, _ := json.Marshal(wflow)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		c.baseURL+auditlogsURL,
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err, "workflows: error preparing HTTP request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to submit auditlog")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 300 {
+		return nil
+	}
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return errors.New(`workflows: workflow "emit_auditlog" not defined`)
+	}
+
+	return errors.Errorf(
+		"workflows: unexpected HTTP status from workflows service: %s",
+		rsp.Status,
+	)
+}
@@ -206,6 +206,60 @@ func TestGenerateArtifactSuccessful(t *testing.T) {
 	assert.Equal(t, "artifact_id", multipartGenerateImage.ArtifactID)
 }
 
+func TestGenerateDelta(t *testing.T) {
+	t.Parallel()
+
+	msg := &model.GenerateDeltaImageMsg{
+		ArtifactID:            "artifact_id",
+		Name:                  "rootfs v2",
+		SourceArtifactName:    "rootfs v1",
+		DeviceTypesCompatible: []string{"Beagle Bone"},
+		GetSourceArtifactURI:  "http://source",
+		GetTargetArtifactURI:  "http://target",
+		TenantID:              "tenant_id",
+		Args:                  `{"provides":{"rootfs-image.version":"v2"}}`,
+	}
+	testCases := map[string]struct {
+		code int
+		err  string
+	}{
+		"ok": {
+			code: http.StatusCreated,
+		},
+		"error": {
+			code: http.StatusBadRequest,
+			err:  "failed to start workflow: generate_delta",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, generateDeltaURL, r.URL.Path)
+					assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+					var received model.GenerateDeltaImageMsg
+					err := json.NewDecoder(r.Body).Decode(&received)
+					assert.NoError(t, err)
+					assert.Equal(t, *msg, received)
+					w.WriteHeader(tc.code)
+				}))
+			defer srv.Close()
+
+			workflowsClient := NewClient().(*client)
+			workflowsClient.baseURL = srv.URL
+
+			err := workflowsClient.StartGenerateDelta(context.Background(), msg)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func mockServerReindex(t *testing.T, tenant, device, reqid string, code int) (*httptest.Server, error) {
 	h := func(w http.ResponseWriter, r *http.Request) {
 		if code != http.StatusOK {
@@ -556,3 +610,85 @@ func TestReindexDeploymentBatchWorkflow(t *testing.T) {
 		})
 	}
 }
+
+func TestSubmitAuditLog(t *testing.T) {
+	t.Parallel()
+
+	validLog := AuditLog{
+		Action: ActionAbortDeployment,
+		Actor: Actor{
+			ID:   "user-id",
+			Type: ActorUser,
+		},
+		Object: Object{
+			ID:   "deployment-id",
+			Type: ObjectDeployment,
+		},
+	}
+	testCases := []struct {
+		name string
+
+		log  AuditLog
+		code int
+
+		err error
+	}{
+		{
+			name: "ok",
+			log:  validLog,
+			code: http.StatusCreated,
+		},
+		{
+			name: "invalid log",
+			log:  AuditLog{Action: ActionAbortDeployment},
+			err: errors.New("workflows: invalid AuditLog entry: " +
+				"actor: (id: cannot be blank; type: cannot be blank.); " +
+				"object: (id: cannot be blank; type: cannot be blank.)."),
+		},
+		{
+			name: "404",
+			log:  validLog,
+			code: http.StatusNotFound,
+			err:  errors.New(`workflows: workflow "emit_auditlog" not defined`),
+		},
+		{
+			name: "500",
+			log:  validLog,
+			code: http.StatusInternalServerError,
+			err:  errors.New(`workflows: unexpected HTTP status from workflows service: 500 Internal Server Error`),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, auditlogsURL, r.URL.Path)
+				var wflow AuditWorkflow
+				err := json.NewDecoder(r.Body).Decode(&wflow)
+				assert.NoError(t, err)
+				assert.Equal(t, "tenant", wflow.TenantID)
+				assert.Equal(t, "reqid", wflow.RequestID)
+				assert.Equal(t, tc.log.Action, wflow.AuditLog.Action)
+				assert.False(t, wflow.AuditLog.EventTS.IsZero())
+				w.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			ctx := requestid.WithContext(context.Background(), "reqid")
+			ctx = identity.WithContext(ctx, &identity.Identity{
+				Tenant: "tenant",
+			})
+
+			client := NewClient().(*client)
+			client.baseURL = srv.URL
+
+			err := client.SubmitAuditLog(ctx, tc.log)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -66,6 +66,24 @@ func (_m *Client) StartGenerateArtifact(ctx context.Context, multipartGenerateIm
 	return r0
 }
 
+// StartGenerateDelta provides a mock function with given fields: ctx, msg
+func (_m *Client) StartGenerateDelta(ctx context.Context, msg *model.GenerateDeltaImageMsg) error {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartGenerateDelta")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GenerateDeltaImageMsg) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StartReindexReporting provides a mock function with given fields: c, device
 func (_m *Client) StartReindexReporting(c context.Context, device string) error {
 	ret := _m.Called(c, device)
@@ -120,6 +138,24 @@ func (_m *Client) StartReindexReportingDeploymentBatch(c context.Context, info [
 	return r0
 }
 
+// SubmitAuditLog provides a mock function with given fields: ctx, log
+func (_m *Client) SubmitAuditLog(ctx context.Context, log workflows.AuditLog) error {
+	ret := _m.Called(ctx, log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitAuditLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, workflows.AuditLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
@@ -14,6 +14,12 @@
 
 package workflows
 
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 const (
 	ServiceDeployments = "deployments"
 )
@@ -33,3 +39,84 @@ type ReindexDeploymentWorkflow struct {
 	ID           string `json:"id"`
 	Service      string `json:"service"`
 }
+
+type AuditWorkflow struct {
+	RequestID string   `json:"request_id"`
+	TenantID  string   `json:"tenant_id"`
+	AuditLog  AuditLog `json:"auditlog"`
+}
+
+type Action string
+
+const (
+	ActionCreateDeployment Action = "create_deployment"
+	ActionAbortDeployment  Action = "abort_deployment"
+	ActionDeleteArtifact   Action = "delete_artifact"
+)
+
+type ActorType string
+
+const (
+	ActorUser   ActorType = "user"
+	ActorSystem ActorType = "system"
+)
+
+type Actor struct {
+	ID    string    `json:"id"`
+	Type  ActorType `json:"type"`
+	Email string    `json:"email,omitempty"`
+}
+
+func (a Actor) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ID, validation.Required),
+		validation.Field(&a.Type,
+			validation.In(ActorUser, ActorSystem),
+			validation.Required,
+		),
+	)
+}
+
+type ObjectType string
+
+const (
+	ObjectDeployment ObjectType = "deployment"
+	ObjectArtifact   ObjectType = "artifact"
+)
+
+type Object struct {
+	ID   string     `json:"id"`
+	Type ObjectType `json:"type"`
+}
+
+func (o Object) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.ID, validation.Required),
+		validation.Field(&o.Type,
+			validation.Required,
+			validation.In(ObjectDeployment, ObjectArtifact),
+		),
+	)
+}
+
+type AuditLog struct {
+	Action   Action              `json:"action"`
+	Actor    Actor               `json:"actor"`
+	Object   Object              `json:"object"`
+	Change   string              `json:"change,omitempty"`
+	MetaData map[string][]string `json:"meta,omitempty"`
+	EventTS  time.Time           `json:"time,omitempty"`
+}
+
+func (l AuditLog) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Actor, validation.Required),
+		validation.Field(&l.Action, validation.In(
+			ActionCreateDeployment,
+			ActionAbortDeployment,
+			ActionDeleteArtifact,
+		), validation.Required),
+		validation.Field(&l.Object, validation.Required),
+		validation.Field(&l.EventTS, validation.Required),
+	)
+}
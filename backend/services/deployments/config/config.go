@@ -62,6 +62,11 @@ const (
 	SettingsStorageUploadExpireSeconds          = SettingStorage + ".upload_expire_seconds"
 	SettingsStorageUploadExpireSecondsDefault   = 3600
 
+	SettingStorageFilesystem               = SettingStorage + ".filesystem"
+	SettingStorageFilesystemRootDir        = SettingStorageFilesystem + ".root_dir"
+	SettingStorageFilesystemRootDirDefault = "/var/lib/deployments/artifacts"
+	SettingStorageFilesystemInternalURI    = SettingStorageFilesystem + ".internal_uri"
+
 	SettingsAws                       = "aws"
 	SettingAwsS3Region                = SettingsAws + ".region"
 	SettingAwsS3RegionDefault         = "us-east-1"
@@ -90,6 +95,13 @@ const (
 	SettingAzureSharedKeyAccountKey = SettingAzureSharedKey + ".account_key"
 	SettingAzureSharedKeyURI        = SettingAzureSharedKey + ".uri"
 
+	SettingGCSInterop            = "gcs_interop"
+	SettingGCSInteropURI         = SettingGCSInterop + ".uri"
+	SettingGCSInteropExternalURI = SettingGCSInterop + ".external_uri"
+	SettingGCSInteropAuth        = SettingGCSInterop + ".auth"
+	SettingGCSInteropAuthKeyId   = SettingGCSInteropAuth + ".key"
+	SettingGCSInteropAuthSecret  = SettingGCSInteropAuth + ".secret"
+
 	SettingMongo        = "mongo-url"
 	SettingMongoDefault = "mongodb://mongo-deployments:27017"
 
@@ -114,6 +126,11 @@ const (
 	SettingReportingAddr        = "reporting_addr"
 	SettingReportingAddrDefault = ""
 
+	// SettingEnableAudit enables submitting audit logs for deployment
+	// events through the emit_auditlog workflow.
+	SettingEnableAudit        = "enable_audit"
+	SettingEnableAuditDefault = false
+
 	SettingInventoryTimeout        = "inventory_timeout"
 	SettingInventoryTimeoutDefault = 10
 
@@ -158,8 +175,10 @@ const (
 )
 
 const (
-	StorageTypeAWS   = "aws"
-	StorageTypeAzure = "azure"
+	StorageTypeAWS        = "aws"
+	StorageTypeAzure      = "azure"
+	StorageTypeGCSInterop = "gcs_interop"
+	StorageTypeFilesystem = "filesystem"
 )
 
 const (
@@ -213,10 +232,23 @@ func ValidateHttps(c config.Reader) error {
 }
 
 func ValidateStorage(c config.Reader) error {
-	svc := c.GetString(SettingDefaultStorage)
-	if svc != StorageTypeAWS && svc != StorageTypeAzure {
+	switch svc := c.GetString(SettingDefaultStorage); svc {
+	case StorageTypeAWS, StorageTypeAzure:
+	case StorageTypeGCSInterop:
+		for _, key := range []string{SettingGCSInteropAuthKeyId, SettingGCSInteropAuthSecret} {
+			if c.GetString(key) == "" {
+				return MissingOptionError(key)
+			}
+		}
+	case StorageTypeFilesystem:
+		// The links to the objects are served by the service itself
+		// and cannot rely on the X-Forwarded-Host header.
+		if c.GetString(SettingPresignHost) == "" {
+			return MissingOptionError(SettingPresignHost)
+		}
+	default:
 		return fmt.Errorf(
-			`setting "%s" (%s) must be one of "aws" or "azure"`,
+			`setting "%s" (%s) must be one of "aws", "azure", "gcs_interop" or "filesystem"`,
 			SettingDefaultStorage, svc,
 		)
 	}
@@ -303,6 +335,7 @@ var (
 		{Key: SettingsStorageDownloadExpireSeconds,
 			Value: SettingsStorageDownloadExpireSecondsDefault},
 		{Key: SettingsStorageUploadExpireSeconds, Value: SettingsStorageUploadExpireSecondsDefault},
+		{Key: SettingStorageFilesystemRootDir, Value: SettingStorageFilesystemRootDirDefault},
 		{Key: SettingMongo, Value: SettingMongoDefault},
 		{Key: SettingDbSSL, Value: SettingDbSSLDefault},
 		{Key: SettingDbSSLSkipVerify, Value: SettingDbSSLSkipVerifyDefault},
@@ -310,6 +343,7 @@ var (
 		{Key: SettingsAwsTagArtifact, Value: SettingsAwsTagArtifactDefault},
 		{Key: SettingInventoryAddr, Value: SettingInventoryAddrDefault},
 		{Key: SettingReportingAddr, Value: SettingReportingAddrDefault},
+		{Key: SettingEnableAudit, Value: SettingEnableAuditDefault},
 		{Key: SettingInventoryTimeout, Value: SettingInventoryTimeoutDefault},
 		{Key: SettingPresignAlgorithm, Value: SettingPresignAlgorithmDefault},
 		{Key: SettingPresignSecret, Value: SettingPresignSecretDefault},
@@ -135,6 +135,25 @@ func doMain(args []string) {
 			},
 			Action: cmdStorageDaemon,
 		},
+		{
+			Name:  "retention-daemon",
+			Usage: "Start retention daemon deleting artifacts according to the tenants' retention policies",
+			Flags: []cli.Flag{
+				cli.DurationFlag{
+					Name: "interval",
+					Usage: "Time interval to apply the retention policies; " +
+						"a value of 0 runs the daemon for one " +
+						"iteration and terminates (cron mode).",
+					Value: 0,
+				},
+				cli.BoolFlag{
+					Name: "dry-run",
+					Usage: "Do not delete any artifact," +
+						" just log the artifacts to delete.",
+				},
+			},
+			Action: cmdRetentionDaemon,
+		},
 		{
 			Name:  "version",
 			Usage: "Show version information",
@@ -438,3 +457,23 @@ func reindexDeploymentsReporting(
 	}
 	return nil
 }
+
+func cmdRetentionDaemon(args *cli.Context) error {
+	ctx := context.Background()
+	objectStorage, err := SetupObjectStorage(ctx)
+	if err != nil {
+		return err
+	}
+	mgo, err := mongo.NewMongoClient(ctx, config.Config)
+	if err != nil {
+		return err
+	}
+	database := mongo.NewDataStoreMongoWithClient(mgo)
+	app := app.NewDeployments(database, objectStorage, 0,
+		config.Config.GetBool(dconfig.SettingEnableAudit))
+	return app.EnforceRetentionPolicies(
+		ctx,
+		args.Duration("interval"),
+		args.Bool("dry-run"),
+	)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DeltaSourceUpdateType is the only update type supported as source
+	// and target of a delta artifact.
+	DeltaSourceUpdateType = "rootfs-image"
+	// DeltaUpdateType is the update type of the generated delta artifacts;
+	// the payload is a VCDIFF patch against the source rootfs-image.
+	DeltaUpdateType = "rootfs-image-delta"
+
+	// ArtifactDependsArtifactName is the artifact_depends key listing the
+	// artifact names a delta artifact can be applied on top of.
+	ArtifactDependsArtifactName = "artifact_name"
+)
+
+// GenerateDeltaImageRequest is the request to generate a binary delta
+// artifact updating devices running the source artifact to the target.
+type GenerateDeltaImageRequest struct {
+	SourceArtifactID string `json:"source_artifact_id"`
+	TargetArtifactID string `json:"target_artifact_id"`
+	Description      string `json:"description,omitempty"`
+}
+
+func (r GenerateDeltaImageRequest) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.SourceArtifactID, validation.Required, is.UUID),
+		validation.Field(&r.TargetArtifactID, validation.Required, is.UUID),
+		validation.Field(&r.Description, lengthLessThan4096),
+	)
+	if err != nil {
+		return err
+	}
+	if r.SourceArtifactID == r.TargetArtifactID {
+		return errors.New("source and target artifacts must be different")
+	}
+	return nil
+}
+
+// GenerateDeltaImageMsg is the input of the generate_delta workflow
+// consumed by the create-artifact-worker.
+type GenerateDeltaImageMsg struct {
+	ArtifactID            string   `json:"artifact_id"`
+	Name                  string   `json:"name"`
+	SourceArtifactName    string   `json:"source_artifact_name"`
+	Description           string   `json:"description"`
+	DeviceTypesCompatible []string `json:"device_types_compatible"`
+	GetSourceArtifactURI  string   `json:"get_source_artifact_uri"`
+	GetTargetArtifactURI  string   `json:"get_target_artifact_uri"`
+	TenantID              string   `json:"tenant_id"`
+	Args                  string   `json:"args"`
+}
+
+// GenerateDeltaImageArgs are the type-specific arguments passed to the
+// worker as a JSON document in GenerateDeltaImageMsg.Args.
+type GenerateDeltaImageArgs struct {
+	// Depends are the artifact depends of the delta besides
+	// the source artifact name, e.g. the source rootfs checksum.
+	Depends map[string]string `json:"depends,omitempty"`
+	// Provides are the artifact provides copied from the target.
+	Provides map[string]string `json:"provides,omitempty"`
+	// ClearsProvides are the clears_artifact_provides copied from the target.
+	ClearsProvides []string `json:"clears_provides,omitempty"`
+}
+
+// UpdateType returns the type of the single update in the artifact,
+// or an empty string if the artifact contains none or multiple updates.
+func (am *ArtifactMeta) UpdateType() string {
+	if am == nil || len(am.Updates) != 1 ||
+		am.Updates[0].TypeInfo.Type == nil {
+		return ""
+	}
+	return *am.Updates[0].TypeInfo.Type
+}
+
+// DependsOnArtifactName returns true if the artifact explicitly depends
+// on the given artifact name being installed on the device.
+func (am *ArtifactMeta) DependsOnArtifactName(name string) bool {
+	if am == nil {
+		return false
+	}
+	return dependsValueMatches(am.Depends[ArtifactDependsArtifactName], name)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGenerateDeltaImageRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		request GenerateDeltaImageRequest
+		err     string
+	}{
+		"ok": {
+			request: GenerateDeltaImageRequest{
+				SourceArtifactID: validUUIDv4,
+				TargetArtifactID: "0c13a0e6-6b63-475d-8260-ee42a590e8ff",
+				Description:      "delta",
+			},
+		},
+		"error, missing source": {
+			request: GenerateDeltaImageRequest{
+				TargetArtifactID: validUUIDv4,
+			},
+			err: "source_artifact_id: cannot be blank.",
+		},
+		"error, invalid target": {
+			request: GenerateDeltaImageRequest{
+				SourceArtifactID: validUUIDv4,
+				TargetArtifactID: "foo",
+			},
+			err: "target_artifact_id: must be a valid UUID.",
+		},
+		"error, same artifact": {
+			request: GenerateDeltaImageRequest{
+				SourceArtifactID: validUUIDv4,
+				TargetArtifactID: validUUIDv4,
+			},
+			err: "source and target artifacts must be different",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.request.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestArtifactMetaUpdateType(t *testing.T) {
+	t.Parallel()
+
+	rootfs := "rootfs-image"
+	assert.Equal(t, "", (*ArtifactMeta)(nil).UpdateType())
+	assert.Equal(t, "", (&ArtifactMeta{}).UpdateType())
+	assert.Equal(t, rootfs, (&ArtifactMeta{Updates: []Update{{
+		TypeInfo: ArtifactUpdateTypeInfo{Type: &rootfs},
+	}}}).UpdateType())
+	assert.Equal(t, "", (&ArtifactMeta{Updates: []Update{{
+		TypeInfo: ArtifactUpdateTypeInfo{Type: &rootfs},
+	}, {
+		TypeInfo: ArtifactUpdateTypeInfo{Type: &rootfs},
+	}}}).UpdateType())
+}
+
+func TestArtifactMetaDependsOnArtifactName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		depends interface{}
+		result  bool
+	}{
+		"string": {
+			depends: "v1",
+			result:  true,
+		},
+		"string, no match": {
+			depends: "v2",
+		},
+		"string slice": {
+			depends: []string{"v0", "v1"},
+			result:  true,
+		},
+		"interface slice": {
+			depends: []interface{}{"v0", "v1"},
+			result:  true,
+		},
+		"bson array": {
+			depends: primitive.A{"v1"},
+			result:  true,
+		},
+		"bson array, no match": {
+			depends: primitive.A{"v0", 1},
+		},
+		"missing": {},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			am := &ArtifactMeta{Depends: map[string]interface{}{}}
+			if tc.depends != nil {
+				am.Depends[ArtifactDependsArtifactName] = tc.depends
+			}
+			assert.Equal(t, tc.result, am.DependsOnArtifactName("v1"))
+		})
+	}
+}
@@ -90,6 +90,9 @@ type DeploymentConstructor struct {
 
 	// When set the deployment will be created for all accepted devices from a given group
 	Group string `json:"-" bson:"-"`
+
+	// RollbackOf is the ID of the deployment rolled back by this deployment
+	RollbackOf string `json:"-" bson:"rollback_of,omitempty"`
 }
 
 // Validate checks structure according to valid tags
@@ -260,8 +263,9 @@ func (d *Deployment) MarshalJSON() ([]byte, error) {
 
 	slim := struct {
 		*Alias
-		Devices []string       `json:"devices,omitempty"`
-		Type    DeploymentType `json:"type,omitempty"`
+		Devices    []string       `json:"devices,omitempty"`
+		Type       DeploymentType `json:"type,omitempty"`
+		RollbackOf string         `json:"rollback_of,omitempty"`
 	}{
 		Alias:   (*Alias)(d),
 		Devices: nil,
@@ -270,6 +274,9 @@ func (d *Deployment) MarshalJSON() ([]byte, error) {
 	if slim.Type == "" {
 		slim.Type = DeploymentTypeSoftware
 	}
+	if d.DeploymentConstructor != nil {
+		slim.RollbackOf = d.RollbackOf
+	}
 	slim.Statistics.Status = slim.Stats
 
 	return json.Marshal(&slim)
@@ -25,4 +25,28 @@ type DeploymentInstructions struct {
 	ID       string                         `json:"id"`
 	Artifact ArtifactDeploymentInstructions `json:"artifact"`
 	Type     DeploymentType                 `json:"-"`
+	// UpdateControlMap is sent to the devices supporting update control
+	// maps to pause or continue the deployment in given states.
+	UpdateControlMap *UpdateControlMap `json:"update_control_map,omitempty"`
+}
+
+const (
+	UpdateControlStateArtifactRebootEnter = "ArtifactReboot_Enter"
+
+	UpdateControlActionContinue = "continue"
+	UpdateControlActionPause    = "pause"
+	UpdateControlActionFail     = "fail"
+)
+
+// UpdateControlMap controls the state transitions of the deployment on
+// the device; the ID is the ID of the deployment.
+type UpdateControlMap struct {
+	ID       string                        `json:"id"`
+	Priority int                           `json:"priority"`
+	States   map[string]UpdateControlState `json:"states"`
+}
+
+type UpdateControlState struct {
+	Action      string `json:"action"`
+	OnMapExpire string `json:"on_map_expire,omitempty"`
 }
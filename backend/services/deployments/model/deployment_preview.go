@@ -0,0 +1,199 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// DeploymentPreviewSampleSize is the maximum number of devices listed
+	// for each outcome of a deployment preview.
+	DeploymentPreviewSampleSize = 10
+
+	// Inventory attributes the deployment compatibility is evaluated on.
+	AttrNameDeviceType   = "device_type"
+	AttrNameArtifactName = "artifact_name"
+	AttrScopeInventory   = "inventory"
+)
+
+// Reasons for a device to be skipped by a deployment.
+const (
+	PreviewReasonAlreadyInstalled   = "already_installed"
+	PreviewReasonNoInventory        = "no_inventory"
+	PreviewReasonDeviceTypeMismatch = "device_type_mismatch"
+	PreviewReasonDependsMismatch    = "depends_mismatch"
+)
+
+// DeploymentPreviewDevice describes the outcome of a deployment for a
+// single device.
+type DeploymentPreviewDevice struct {
+	ID           string `json:"id"`
+	DeviceType   string `json:"device_type,omitempty"`
+	ArtifactName string `json:"artifact_name,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	Details      string `json:"details,omitempty"`
+}
+
+// DeploymentPreviewGroup counts the devices sharing the same outcome and
+// keeps a sample of them.
+type DeploymentPreviewGroup struct {
+	Count   int                       `json:"count"`
+	Devices []DeploymentPreviewDevice `json:"devices"`
+}
+
+// Add accounts the device in the group, keeping at most
+// DeploymentPreviewSampleSize devices in the sample.
+func (g *DeploymentPreviewGroup) Add(dev DeploymentPreviewDevice) {
+	g.Count++
+	if len(g.Devices) < DeploymentPreviewSampleSize {
+		g.Devices = append(g.Devices, dev)
+	}
+}
+
+// DeploymentPreview is the result of a deployment dry-run: it tells which
+// of the targeted devices would be updated, which would be skipped because
+// they already run the artifact and which are incompatible with it.
+type DeploymentPreview struct {
+	ArtifactName     string                 `json:"artifact_name"`
+	DeviceCount      int                    `json:"device_count"`
+	Update           DeploymentPreviewGroup `json:"update"`
+	AlreadyInstalled DeploymentPreviewGroup `json:"already_installed"`
+	Incompatible     DeploymentPreviewGroup `json:"incompatible"`
+	// IncompatibleReasons counts the incompatible devices by reason.
+	IncompatibleReasons map[string]int `json:"incompatible_reasons"`
+}
+
+func NewDeploymentPreview(artifactName string) *DeploymentPreview {
+	return &DeploymentPreview{
+		ArtifactName:        artifactName,
+		Update:              DeploymentPreviewGroup{Devices: []DeploymentPreviewDevice{}},
+		AlreadyInstalled:    DeploymentPreviewGroup{Devices: []DeploymentPreviewDevice{}},
+		Incompatible:        DeploymentPreviewGroup{Devices: []DeploymentPreviewDevice{}},
+		IncompatibleReasons: map[string]int{},
+	}
+}
+
+// Add accounts the device in the group matching its reason.
+func (p *DeploymentPreview) Add(dev DeploymentPreviewDevice) {
+	p.DeviceCount++
+	switch dev.Reason {
+	case "":
+		p.Update.Add(dev)
+	case PreviewReasonAlreadyInstalled:
+		p.AlreadyInstalled.Add(dev)
+	default:
+		p.Incompatible.Add(dev)
+		p.IncompatibleReasons[dev.Reason]++
+	}
+}
+
+// InventoryAttributes returns the string attributes reported by the
+// device in the inventory scope, such as device_type, artifact_name and
+// the artifact provides.
+func (d *InvDevice) InventoryAttributes() map[string]string {
+	attrs := make(map[string]string, len(d.Attributes))
+	for _, attr := range d.Attributes {
+		if attr.Scope != AttrScopeInventory {
+			continue
+		}
+		if value, ok := attr.Value.(string); ok {
+			attrs[attr.Name] = value
+		}
+	}
+	return attrs
+}
+
+// CheckCompatibility checks whether the artifact can be installed on a
+// device reporting the given inventory attributes; when it cannot, it
+// returns the reason and a human readable explanation.
+func (am *ArtifactMeta) CheckCompatibility(attrs map[string]string) (string, string) {
+	deviceType := attrs[AttrNameDeviceType]
+	compatible := false
+	for _, dt := range am.DeviceTypesCompatible {
+		if dt == deviceType {
+			compatible = true
+			break
+		}
+	}
+	if !compatible {
+		return PreviewReasonDeviceTypeMismatch, fmt.Sprintf(
+			"device type %q is not in the artifact compatible device types: %s",
+			deviceType, strings.Join(am.DeviceTypesCompatible, ", "),
+		)
+	}
+
+	keys := make([]string, 0, len(am.Depends))
+	for key := range am.Depends {
+		if key != AttrNameDeviceType {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := attrs[key]
+		if !ok {
+			return PreviewReasonDependsMismatch, fmt.Sprintf(
+				"the artifact depends on %q which the device does not provide", key,
+			)
+		}
+		if !dependsValueMatches(am.Depends[key], value) {
+			return PreviewReasonDependsMismatch, fmt.Sprintf(
+				"the artifact depends on %s in [%s], the device provides %q",
+				key, strings.Join(dependsValues(am.Depends[key]), ", "), value,
+			)
+		}
+	}
+	return "", ""
+}
+
+// dependsValues returns the accepted values of an artifact depends; the
+// value is either a single string or a list of strings.
+func dependsValues(depends interface{}) []string {
+	switch values := depends.(type) {
+	case string:
+		return []string{values}
+	case []string:
+		return values
+	case []interface{}:
+		return stringValues(values)
+	case primitive.A:
+		return stringValues(values)
+	}
+	return nil
+}
+
+func stringValues(values []interface{}) []string {
+	ret := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+func dependsValueMatches(depends interface{}, value string) bool {
+	for _, v := range dependsValues(depends) {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
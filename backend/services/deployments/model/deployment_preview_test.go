@@ -0,0 +1,106 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestArtifactMetaCheckCompatibility(t *testing.T) {
+	t.Parallel()
+
+	meta := &ArtifactMeta{
+		Name:                  "release-2",
+		DeviceTypesCompatible: []string{"rpi3", "rpi4"},
+		Depends: map[string]interface{}{
+			"device_type":          primitive.A{"rpi3", "rpi4"},
+			"artifact_name":        primitive.A{"release-1"},
+			"rootfs-image.version": "v1",
+		},
+	}
+	testCases := map[string]struct {
+		Attrs  map[string]string
+		Reason string
+	}{
+		"ok": {
+			Attrs: map[string]string{
+				"device_type":          "rpi4",
+				"artifact_name":        "release-1",
+				"rootfs-image.version": "v1",
+			},
+		},
+		"device type mismatch": {
+			Attrs: map[string]string{
+				"device_type":          "qemu",
+				"artifact_name":        "release-1",
+				"rootfs-image.version": "v1",
+			},
+			Reason: PreviewReasonDeviceTypeMismatch,
+		},
+		"depends mismatch": {
+			Attrs: map[string]string{
+				"device_type":          "rpi3",
+				"artifact_name":        "release-0",
+				"rootfs-image.version": "v1",
+			},
+			Reason: PreviewReasonDependsMismatch,
+		},
+		"depends not provided": {
+			Attrs: map[string]string{
+				"device_type":   "rpi3",
+				"artifact_name": "release-1",
+			},
+			Reason: PreviewReasonDependsMismatch,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			reason, details := meta.CheckCompatibility(tc.Attrs)
+			assert.Equal(t, tc.Reason, reason)
+			if tc.Reason == "" {
+				assert.Empty(t, details)
+			} else {
+				assert.NotEmpty(t, details)
+			}
+		})
+	}
+}
+
+func TestDeploymentPreviewAdd(t *testing.T) {
+	t.Parallel()
+
+	preview := NewDeploymentPreview("release-2")
+	for i := 0; i < DeploymentPreviewSampleSize+5; i++ {
+		preview.Add(DeploymentPreviewDevice{ID: fmt.Sprintf("device-%d", i)})
+	}
+	preview.Add(DeploymentPreviewDevice{ID: "a", Reason: PreviewReasonAlreadyInstalled})
+	preview.Add(DeploymentPreviewDevice{ID: "b", Reason: PreviewReasonDependsMismatch})
+	preview.Add(DeploymentPreviewDevice{ID: "c", Reason: PreviewReasonDependsMismatch})
+	preview.Add(DeploymentPreviewDevice{ID: "d", Reason: PreviewReasonNoInventory})
+
+	assert.Equal(t, DeploymentPreviewSampleSize+9, preview.DeviceCount)
+	assert.Equal(t, DeploymentPreviewSampleSize+5, preview.Update.Count)
+	assert.Len(t, preview.Update.Devices, DeploymentPreviewSampleSize)
+	assert.Equal(t, 1, preview.AlreadyInstalled.Count)
+	assert.Equal(t, 3, preview.Incompatible.Count)
+	assert.Equal(t, map[string]int{
+		PreviewReasonDependsMismatch: 2,
+		PreviewReasonNoInventory:     1,
+	}, preview.IncompatibleReasons)
+}
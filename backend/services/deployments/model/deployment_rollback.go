@@ -0,0 +1,89 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// DeploymentRollbackNameFormat is the format of the default name of the
+// deployments rolling back a deployment.
+const DeploymentRollbackNameFormat = "Rollback of %s"
+
+// DeploymentRollbackRequest is the request to roll back a deployment.
+type DeploymentRollbackRequest struct {
+	// Name of the rollback deployments, defaults to "Rollback of <name>"
+	Name string `json:"name,omitempty"`
+}
+
+func (r DeploymentRollbackRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, lengthLessThan4096),
+	)
+}
+
+// DeploymentName returns the name of the rollback deployments.
+func (r DeploymentRollbackRequest) DeploymentName(deployment *Deployment) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	name := fmt.Sprintf(DeploymentRollbackNameFormat, deployment.Name)
+	if len(name) > 4096 {
+		name = name[:4096]
+	}
+	return name
+}
+
+// DeploymentRollback is the result of rolling back a deployment: the
+// devices are grouped by the artifact they ran before the deployment,
+// and a deployment is created for each of the artifacts.
+type DeploymentRollback struct {
+	// DeploymentID is the ID of the rolled back deployment
+	DeploymentID string `json:"deployment_id"`
+	// Deployments are the rollback deployments
+	Deployments []DeploymentRollbackItem `json:"deployments"`
+	// SkippedDevices are the devices of the deployment which could not
+	// be rolled back
+	SkippedDevices []DeploymentRollbackSkippedDevice `json:"skipped_devices"`
+	// Error is the reason why some of the rollback deployments could not
+	// be created; their devices are skipped.
+	Error string `json:"error,omitempty"`
+}
+
+// DeploymentRollbackItem is a deployment rolling back the devices to the
+// artifact they ran before.
+type DeploymentRollbackItem struct {
+	ID           string   `json:"id"`
+	ArtifactName string   `json:"artifact_name"`
+	Devices      []string `json:"devices"`
+}
+
+// Reasons for skipping devices when rolling back a deployment
+const (
+	RollbackSkipNotFinished     = "not_finished"
+	RollbackSkipNotInstalled    = "not_installed"
+	RollbackSkipNewerDeployment = "newer_deployment"
+	RollbackSkipNoPrevious      = "no_previous_artifact"
+	RollbackSkipNoArtifact      = "artifact_not_found"
+	RollbackSkipNotCreated      = "deployment_not_created"
+)
+
+// DeploymentRollbackSkippedDevice is a device which could not be rolled back.
+type DeploymentRollbackSkippedDevice struct {
+	DeviceID string `json:"id"`
+	Reason   string `json:"reason"`
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentRollbackRequest(t *testing.T) {
+	t.Parallel()
+
+	deployment := &Deployment{
+		DeploymentConstructor: &DeploymentConstructor{Name: "release"},
+	}
+	assert.NoError(t, DeploymentRollbackRequest{}.Validate())
+	assert.Equal(t, "Rollback of release",
+		DeploymentRollbackRequest{}.DeploymentName(deployment))
+	assert.Equal(t, "undo",
+		DeploymentRollbackRequest{Name: "undo"}.DeploymentName(deployment))
+
+	deployment.Name = strings.Repeat("a", 4096)
+	assert.Len(t, DeploymentRollbackRequest{}.DeploymentName(deployment), 4096)
+	assert.Error(t, DeploymentRollbackRequest{Name: deployment.Name + "a"}.Validate())
+}
+
+func TestDeploymentMarshalJSONRollbackOf(t *testing.T) {
+	t.Parallel()
+
+	deployment, err := NewDeploymentFromConstructor(&DeploymentConstructor{
+		Name:         "rollback",
+		ArtifactName: "v1",
+		RollbackOf:   "a2e3f5b4-8d7c-4a0e-9e1f-3b2c1d0e9f8a",
+	})
+	assert.NoError(t, err)
+	b, err := json.Marshal(deployment)
+	assert.NoError(t, err)
+	var out map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, "a2e3f5b4-8d7c-4a0e-9e1f-3b2c1d0e9f8a", out["rollback_of"])
+}
@@ -251,6 +251,16 @@ type DeviceDeployment struct {
 
 	// Device reported substate
 	SubState string `json:"substate,omitempty" bson:"substate,omitempty"`
+
+	// Status changes reported by the device
+	StatusHistory []DeviceDeploymentStatusChange `json:"-" bson:"status_history,omitempty"`
+}
+
+// DeviceDeploymentStatusChange records when the device reported a status
+type DeviceDeploymentStatusChange struct {
+	Status    DeviceDeploymentStatus `json:"status" bson:"status"`
+	SubState  string                 `json:"substate,omitempty" bson:"substate,omitempty"`
+	Timestamp time.Time              `json:"timestamp" bson:"timestamp"`
 }
 
 func NewDeviceDeployment(deviceId, deploymentId string) *DeviceDeployment {
@@ -0,0 +1,199 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Log levels of the deployment log entries
+const (
+	LogLevelDebug    = "debug"
+	LogLevelInfo     = "info"
+	LogLevelWarning  = "warning"
+	LogLevelError    = "error"
+	LogLevelCritical = "critical"
+	LogLevelFatal    = "fatal"
+	LogLevelPanic    = "panic"
+)
+
+var (
+	// LogErrorLevels are the levels of the log entries reporting errors.
+	LogErrorLevels = []string{
+		LogLevelError,
+		LogLevelCritical,
+		LogLevelFatal,
+		LogLevelPanic,
+	}
+
+	logLevelAliases = map[string]string{
+		"dbg":      LogLevelDebug,
+		"debug":    LogLevelDebug,
+		"info":     LogLevelInfo,
+		"notice":   LogLevelInfo,
+		"warn":     LogLevelWarning,
+		"warning":  LogLevelWarning,
+		"err":      LogLevelError,
+		"error":    LogLevelError,
+		"crit":     LogLevelCritical,
+		"critical": LogLevelCritical,
+		"fatal":    LogLevelFatal,
+		"panic":    LogLevelPanic,
+	}
+
+	// logLevelPrefix matches the messages starting with the log level,
+	// e.g. "ERROR: message" or "[error] message"
+	logLevelPrefix = regexp.MustCompile(
+		`^(?:\[([A-Za-z]+)\]|([A-Za-z]+):)\s*(.+)$`,
+	)
+)
+
+// DeploymentLogEntry is a line of a device deployment log, with the level,
+// timestamp and message parsed out of the log message.
+type DeploymentLogEntry struct {
+	DeviceID     string    `json:"device_id" bson:"deviceid"`
+	DeploymentID string    `json:"deployment_id" bson:"deploymentid"`
+	Timestamp    time.Time `json:"timestamp" bson:"timestamp"`
+	Level        string    `json:"level" bson:"level"`
+	Message      string    `json:"message" bson:"message"`
+}
+
+// NewDeploymentLogEntries parses the messages of the deployment log.
+func NewDeploymentLogEntries(log DeploymentLog) []DeploymentLogEntry {
+	entries := make([]DeploymentLogEntry, 0, len(log.Messages))
+	for _, msg := range log.Messages {
+		entry := ParseLogMessage(msg)
+		if entry.Message == "" {
+			continue
+		}
+		entry.DeviceID = log.DeviceID
+		entry.DeploymentID = log.DeploymentID
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// ParseLogMessage extracts the level, timestamp and message of a log
+// message. The messages forwarded by the client from other programs often
+// carry their own level and timestamp, either in logfmt format
+// (time=... level=... msg=...) or as a level prefix (ERROR: ...); these
+// take precedence over the ones reported with the message.
+func ParseLogMessage(msg LogMessage) DeploymentLogEntry {
+	entry := DeploymentLogEntry{
+		Level:   NormalizeLogLevel(msg.Level),
+		Message: strings.TrimSpace(msg.Message),
+	}
+	if msg.Timestamp != nil {
+		entry.Timestamp = msg.Timestamp.UTC()
+	}
+
+	if fields, ok := parseLogfmt(entry.Message); ok {
+		if message, ok := fields["msg"]; ok {
+			entry.Message = strings.TrimSpace(message)
+			if level := NormalizeLogLevel(fields["level"]); level != "" {
+				entry.Level = level
+			}
+			for _, key := range []string{"time", "ts"} {
+				if ts, err := time.Parse(time.RFC3339Nano, fields[key]); err == nil {
+					entry.Timestamp = ts.UTC()
+					break
+				}
+			}
+		}
+	} else if m := logLevelPrefix.FindStringSubmatch(entry.Message); m != nil {
+		prefix := m[1] + m[2]
+		if level, ok := logLevelAliases[strings.ToLower(prefix)]; ok {
+			entry.Level = level
+			entry.Message = m[3]
+		}
+	}
+	return entry
+}
+
+// NormalizeLogLevel maps the log level aliases to the log levels; unknown
+// levels are returned in lower case.
+func NormalizeLogLevel(level string) string {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if normalized, ok := logLevelAliases[level]; ok {
+		return normalized
+	}
+	return level
+}
+
+// parseLogfmt parses the key=value pairs of a logfmt line; it fails if any
+// part of the line is not a key=value pair.
+func parseLogfmt(s string) (map[string]string, bool) {
+	fields := make(map[string]string)
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimLeft(s, " ") {
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 || strings.ContainsAny(s[:eq], " \"") {
+			return nil, false
+		}
+		key := s[:eq]
+		s = s[eq+1:]
+		if strings.HasPrefix(s, `"`) {
+			end := 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, false
+			}
+			value, err := strconv.Unquote(s[:end+1])
+			if err != nil {
+				return nil, false
+			}
+			fields[key] = value
+			s = s[end+1:]
+		} else {
+			end := strings.IndexByte(s, ' ')
+			if end < 0 {
+				end = len(s)
+			}
+			fields[key] = s[:end]
+			s = s[end:]
+		}
+	}
+	return fields, len(fields) > 0
+}
+
+// DeploymentLogCluster groups the log entries of the devices with the
+// same level and message.
+type DeploymentLogCluster struct {
+	Message string `json:"message" bson:"message"`
+	Level   string `json:"level" bson:"level"`
+	// Count is the number of log entries with the message
+	Count int `json:"count" bson:"count"`
+	// DeviceCount is the number of devices which logged the message
+	DeviceCount int `json:"device_count" bson:"device_count"`
+	// Devices are some of the devices which logged the message
+	Devices   []string  `json:"devices" bson:"devices"`
+	FirstSeen time.Time `json:"first_seen" bson:"first_seen"`
+	LastSeen  time.Time `json:"last_seen" bson:"last_seen"`
+}
+
+// DeploymentLogClusters are the clusters of the log messages matching a
+// search, sorted by decreasing number of devices.
+type DeploymentLogClusters struct {
+	// DeviceCount is the number of devices with log entries matching the
+	// search
+	DeviceCount int                    `json:"device_count"`
+	Clusters    []DeploymentLogCluster `json:"clusters"`
+}
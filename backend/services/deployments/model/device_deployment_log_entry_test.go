@@ -0,0 +1,133 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogMessage(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		msg   LogMessage
+		entry DeploymentLogEntry
+	}{
+		"plain message": {
+			msg: LogMessage{Timestamp: &ts, Level: "ERROR", Message: " write failed \n"},
+			entry: DeploymentLogEntry{
+				Timestamp: ts,
+				Level:     LogLevelError,
+				Message:   "write failed",
+			},
+		},
+		"logfmt message": {
+			msg: LogMessage{
+				Timestamp: &ts,
+				Level:     "info",
+				Message: `time="2026-10-01T11:59:58Z" level=error ` +
+					`msg="write /data/rootfs: No space left on device"`,
+			},
+			entry: DeploymentLogEntry{
+				Timestamp: ts.Add(-2 * time.Second),
+				Level:     LogLevelError,
+				Message:   "write /data/rootfs: No space left on device",
+			},
+		},
+		"logfmt message with escaped quotes": {
+			msg: LogMessage{
+				Timestamp: &ts,
+				Level:     "info",
+				Message:   `level=warn msg="invalid \"foo\" value" count=2`,
+			},
+			entry: DeploymentLogEntry{
+				Timestamp: ts,
+				Level:     LogLevelWarning,
+				Message:   `invalid "foo" value`,
+			},
+		},
+		"key value pairs without message": {
+			msg: LogMessage{Timestamp: &ts, Level: "info", Message: "free=0 used=100"},
+			entry: DeploymentLogEntry{
+				Timestamp: ts,
+				Level:     LogLevelInfo,
+				Message:   "free=0 used=100",
+			},
+		},
+		"level prefix": {
+			msg: LogMessage{Timestamp: &ts, Level: "info", Message: "ERROR: checksum mismatch"},
+			entry: DeploymentLogEntry{
+				Timestamp: ts,
+				Level:     LogLevelError,
+				Message:   "checksum mismatch",
+			},
+		},
+		"bracketed level prefix": {
+			msg: LogMessage{Timestamp: &ts, Level: "info", Message: "[crit] kernel panic"},
+			entry: DeploymentLogEntry{
+				Timestamp: ts,
+				Level:     LogLevelCritical,
+				Message:   "kernel panic",
+			},
+		},
+		"unknown prefix": {
+			msg: LogMessage{Timestamp: &ts, Level: "info", Message: "Installing: rootfs-image"},
+			entry: DeploymentLogEntry{
+				Timestamp: ts,
+				Level:     LogLevelInfo,
+				Message:   "Installing: rootfs-image",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.entry, ParseLogMessage(tc.msg))
+		})
+	}
+}
+
+func TestNewDeploymentLogEntries(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	entries := NewDeploymentLogEntries(DeploymentLog{
+		DeviceID:     "device",
+		DeploymentID: "deployment",
+		Messages: []LogMessage{
+			{Timestamp: &ts, Level: "info", Message: "starting"},
+			{Timestamp: &ts, Level: "info", Message: "  "},
+			{Timestamp: &ts, Level: "error", Message: "failed"},
+		},
+	})
+	assert.Equal(t, []DeploymentLogEntry{{
+		DeviceID:     "device",
+		DeploymentID: "deployment",
+		Timestamp:    ts,
+		Level:        LogLevelInfo,
+		Message:      "starting",
+	}, {
+		DeviceID:     "device",
+		DeploymentID: "deployment",
+		Timestamp:    ts,
+		Level:        LogLevelError,
+		Message:      "failed",
+	}}, entries)
+}
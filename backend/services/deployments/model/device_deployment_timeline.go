@@ -0,0 +1,133 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// DeviceDeploymentTimelineLogLength is the number of log messages included
+// in the timeline of failed device deployments
+const DeviceDeploymentTimelineLogLength = 10
+
+// DeviceDeploymentTimelineState is a state of a device deployment; the
+// duration is in seconds and is not set for the current or the final state
+type DeviceDeploymentTimelineState struct {
+	Status   DeviceDeploymentStatus `json:"status"`
+	SubState string                 `json:"substate,omitempty"`
+	Started  time.Time              `json:"started"`
+	Finished *time.Time             `json:"finished,omitempty"`
+	Duration *float64               `json:"duration,omitempty"`
+}
+
+// DeviceDeploymentTimelineItem describes a deployment to a device: what was
+// installed, by which deployment, and how long each state took
+type DeviceDeploymentTimelineItem struct {
+	Id             string                          `json:"id"`
+	DeploymentId   string                          `json:"deployment_id"`
+	DeploymentName string                          `json:"deployment_name,omitempty"`
+	ArtifactName   string                          `json:"artifact_name,omitempty"`
+	Status         DeviceDeploymentStatus          `json:"status"`
+	Created        *time.Time                      `json:"created"`
+	Started        *time.Time                      `json:"started,omitempty"`
+	Finished       *time.Time                      `json:"finished,omitempty"`
+	Duration       *float64                        `json:"duration,omitempty"`
+	States         []DeviceDeploymentTimelineState `json:"states"`
+	Log            []LogMessage                    `json:"log,omitempty"`
+}
+
+// NewDeviceDeploymentTimelineItem builds the timeline of the device
+// deployment from its status history; the deployment is optional.
+func NewDeviceDeploymentTimelineItem(
+	deployment *Deployment,
+	dd *DeviceDeployment,
+) DeviceDeploymentTimelineItem {
+	item := DeviceDeploymentTimelineItem{
+		Id:           dd.Id,
+		DeploymentId: dd.DeploymentId,
+		Status:       dd.Status,
+		Created:      dd.Created,
+		Started:      dd.Started,
+		Finished:     dd.Finished,
+	}
+	if deployment != nil && deployment.DeploymentConstructor != nil {
+		item.DeploymentName = deployment.Name
+		item.ArtifactName = deployment.ArtifactName
+	}
+	if dd.Image != nil && dd.Image.ArtifactMeta != nil {
+		item.ArtifactName = dd.Image.ArtifactMeta.Name
+	}
+	if dd.Finished != nil {
+		start := dd.Started
+		if start == nil {
+			start = dd.Created
+		}
+		if start != nil {
+			item.Duration = durationSeconds(*start, *dd.Finished)
+		}
+	}
+	item.States = timelineStates(dd)
+	return item
+}
+
+func timelineStates(dd *DeviceDeployment) []DeviceDeploymentTimelineState {
+	changes := dd.StatusHistory
+	if len(changes) == 0 {
+		// device deployments created before the status history was
+		// recorded only know the current status
+		change := DeviceDeploymentStatusChange{
+			Status:   dd.Status,
+			SubState: dd.SubState,
+		}
+		switch {
+		case dd.Finished != nil && IsDeviceDeploymentStatusFinished(dd.Status):
+			change.Timestamp = *dd.Finished
+		case dd.Started != nil:
+			change.Timestamp = *dd.Started
+		}
+		if !change.Timestamp.IsZero() {
+			changes = []DeviceDeploymentStatusChange{change}
+		}
+	}
+
+	states := make([]DeviceDeploymentTimelineState, 0, len(changes)+1)
+	if dd.Created != nil {
+		states = append(states, DeviceDeploymentTimelineState{
+			Status:  DeviceDeploymentStatusPending,
+			Started: *dd.Created,
+		})
+	}
+	for _, change := range changes {
+		if n := len(states); n > 0 {
+			finished := change.Timestamp
+			states[n-1].Finished = &finished
+			states[n-1].Duration = durationSeconds(states[n-1].Started, finished)
+		}
+		states = append(states, DeviceDeploymentTimelineState{
+			Status:   change.Status,
+			SubState: change.SubState,
+			Started:  change.Timestamp,
+		})
+	}
+	return states
+}
+
+func durationSeconds(start, end time.Time) *float64 {
+	duration := end.Sub(start).Seconds()
+	if duration < 0 {
+		duration = 0
+	}
+	return &duration
+}
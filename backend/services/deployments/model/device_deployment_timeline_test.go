@@ -0,0 +1,169 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeviceDeploymentTimelineItem(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	started := created.Add(time.Minute)
+	downloaded := started.Add(2 * time.Minute)
+	finished := downloaded.Add(30 * time.Second)
+	float := func(f float64) *float64 { return &f }
+	timeRef := func(t time.Time) *time.Time { return &t }
+
+	testCases := map[string]struct {
+		Deployment       *Deployment
+		DeviceDeployment *DeviceDeployment
+
+		Item DeviceDeploymentTimelineItem
+	}{
+		"ok, status history": {
+			Deployment: &Deployment{
+				Id: "deployment",
+				DeploymentConstructor: &DeploymentConstructor{
+					Name:         "release 1",
+					ArtifactName: "release-1",
+				},
+			},
+			DeviceDeployment: &DeviceDeployment{
+				Id:           "id",
+				DeploymentId: "deployment",
+				Status:       DeviceDeploymentStatusSuccess,
+				Created:      &created,
+				Started:      &started,
+				Finished:     &finished,
+				Image: &Image{
+					ArtifactMeta: &ArtifactMeta{Name: "release-1-device"},
+				},
+				StatusHistory: []DeviceDeploymentStatusChange{{
+					Status:    DeviceDeploymentStatusDownloading,
+					Timestamp: started,
+				}, {
+					Status:    DeviceDeploymentStatusInstalling,
+					SubState:  "ArtifactInstall",
+					Timestamp: downloaded,
+				}, {
+					Status:    DeviceDeploymentStatusSuccess,
+					Timestamp: finished,
+				}},
+			},
+
+			Item: DeviceDeploymentTimelineItem{
+				Id:             "id",
+				DeploymentId:   "deployment",
+				DeploymentName: "release 1",
+				ArtifactName:   "release-1-device",
+				Status:         DeviceDeploymentStatusSuccess,
+				Created:        &created,
+				Started:        &started,
+				Finished:       &finished,
+				Duration:       float(150),
+				States: []DeviceDeploymentTimelineState{{
+					Status:   DeviceDeploymentStatusPending,
+					Started:  created,
+					Finished: timeRef(started),
+					Duration: float(60),
+				}, {
+					Status:   DeviceDeploymentStatusDownloading,
+					Started:  started,
+					Finished: timeRef(downloaded),
+					Duration: float(120),
+				}, {
+					Status:   DeviceDeploymentStatusInstalling,
+					SubState: "ArtifactInstall",
+					Started:  downloaded,
+					Finished: timeRef(finished),
+					Duration: float(30),
+				}, {
+					Status:  DeviceDeploymentStatusSuccess,
+					Started: finished,
+				}},
+			},
+		},
+		"ok, no status history": {
+			Deployment: &Deployment{
+				Id: "deployment",
+				DeploymentConstructor: &DeploymentConstructor{
+					Name:         "release 1",
+					ArtifactName: "release-1",
+				},
+			},
+			DeviceDeployment: &DeviceDeployment{
+				Id:           "id",
+				DeploymentId: "deployment",
+				Status:       DeviceDeploymentStatusFailure,
+				Created:      &created,
+				Finished:     &finished,
+			},
+
+			Item: DeviceDeploymentTimelineItem{
+				Id:             "id",
+				DeploymentId:   "deployment",
+				DeploymentName: "release 1",
+				ArtifactName:   "release-1",
+				Status:         DeviceDeploymentStatusFailure,
+				Created:        &created,
+				Finished:       &finished,
+				Duration:       float(210),
+				States: []DeviceDeploymentTimelineState{{
+					Status:   DeviceDeploymentStatusPending,
+					Started:  created,
+					Finished: timeRef(finished),
+					Duration: float(210),
+				}, {
+					Status:  DeviceDeploymentStatusFailure,
+					Started: finished,
+				}},
+			},
+		},
+		"ok, pending": {
+			DeviceDeployment: &DeviceDeployment{
+				Id:           "id",
+				DeploymentId: "deployment",
+				Status:       DeviceDeploymentStatusPending,
+				Created:      &created,
+			},
+
+			Item: DeviceDeploymentTimelineItem{
+				Id:           "id",
+				DeploymentId: "deployment",
+				Status:       DeviceDeploymentStatusPending,
+				Created:      &created,
+				States: []DeviceDeploymentTimelineState{{
+					Status:  DeviceDeploymentStatusPending,
+					Started: created,
+				}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			item := NewDeviceDeploymentTimelineItem(tc.Deployment, tc.DeviceDeployment)
+			assert.Equal(t, tc.Item, item)
+		})
+	}
+}
@@ -190,6 +190,12 @@ type Image struct {
 	// Artifact total size
 	Size int64 `json:"size" bson:"size" valid:"-"`
 
+	// Signature verification result, set on upload
+	Signature *ImageSignature `json:"signature,omitempty" bson:"signature,omitempty" valid:"-"`
+
+	// UploadedBy is the ID of the user who uploaded the artifact
+	UploadedBy string `json:"uploaded_by,omitempty" bson:"uploaded_by,omitempty" valid:"-"`
+
 	// Last modification time, including image upload time
 	Modified *time.Time `json:"modified" valid:"-"`
 }
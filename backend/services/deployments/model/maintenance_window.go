@@ -0,0 +1,227 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaintenanceWindowMaxDays is the number of days a window can recur on.
+	MaintenanceWindowMaxDays = 7
+
+	// AttrNameTimezone is the inventory attribute holding the IANA time
+	// zone of the device; when reported it overrides the window time zone.
+	AttrNameTimezone = "timezone"
+
+	maintenanceWindowTimeLayout = "15:04"
+)
+
+var (
+	validWindowName = regexp.MustCompile("^[a-z0-9][a-z0-9_.-]*$")
+
+	ErrMaintenanceWindowTarget = errors.New(
+		"exactly one of group or tag must be set",
+	)
+	ErrMaintenanceWindowNameInvalid = errors.New(
+		"maintenance window names must start with a lower case letter or " +
+			"a digit and contain only lower case letters, digits, '-', '_' and '.'",
+	)
+
+	// maintenanceWindowDays maps the accepted day names to weekdays
+	maintenanceWindowDays = map[string]time.Weekday{
+		"mon": time.Monday,
+		"tue": time.Tuesday,
+		"wed": time.Wednesday,
+		"thu": time.Thursday,
+		"fri": time.Friday,
+		"sat": time.Saturday,
+		"sun": time.Sunday,
+	}
+)
+
+// MaintenanceWindowTag selects the devices with the inventory tag set to
+// the given value.
+type MaintenanceWindowTag struct {
+	Name  string `json:"name" bson:"name"`
+	Value string `json:"value" bson:"value"`
+}
+
+func (t MaintenanceWindowTag) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Name, validation.Required, validation.Length(1, 1024)),
+		validation.Field(&t.Value, validation.Length(0, 1024)),
+	)
+}
+
+// MaintenanceWindow is a recurring time frame when the devices in a group,
+// or with a tag, are allowed to start deployments; for example
+// "Mon-Fri 01:00-04:00 Europe/Oslo". A window ending before its start
+// time ends on the following day.
+type MaintenanceWindow struct {
+	Name string `json:"name" bson:"_id"`
+
+	Group string                `json:"group,omitempty" bson:"group,omitempty"`
+	Tag   *MaintenanceWindowTag `json:"tag,omitempty" bson:"tag,omitempty"`
+
+	// Days are the week days the window starts on: mon, tue, ..., sun.
+	Days []string `json:"days" bson:"days"`
+	// Start and End are the local times of the window in HH:MM format.
+	Start string `json:"start" bson:"start"`
+	End   string `json:"end" bson:"end"`
+	// Timezone is the IANA name of the time zone of the window, UTC
+	// if not set.
+	Timezone string `json:"timezone,omitempty" bson:"timezone,omitempty"`
+
+	// GateReboot pauses the devices in the pause_before_rebooting state
+	// until the window opens.
+	GateReboot bool `json:"gate_reboot" bson:"gate_reboot"`
+
+	Created  *time.Time `json:"created,omitempty" bson:"created,omitempty"`
+	Modified *time.Time `json:"modified,omitempty" bson:"modified,omitempty"`
+}
+
+func (w MaintenanceWindow) Validate() error {
+	err := validation.ValidateStruct(&w,
+		validation.Field(&w.Name, validation.Required, validation.Length(1, 64),
+			validation.Match(validWindowName).ErrorObject(
+				validation.NewError("validation_window_name",
+					ErrMaintenanceWindowNameInvalid.Error()),
+			),
+		),
+		validation.Field(&w.Group, validation.Length(0, 1024),
+			validation.Match(validGroupName).ErrorObject(
+				validation.NewError("validation_group_name", ErrGroupNameInvalid.Error()),
+			),
+		),
+		validation.Field(&w.Tag),
+		validation.Field(&w.Days, validation.Required,
+			validation.Length(1, MaintenanceWindowMaxDays),
+			validation.Each(validation.By(validateWindowDay)),
+		),
+		validation.Field(&w.Start, validation.Required, validation.By(validateWindowTime)),
+		validation.Field(&w.End, validation.Required, validation.By(validateWindowTime)),
+		validation.Field(&w.Timezone, validation.By(validateTimezone)),
+	)
+	if err != nil {
+		return err
+	}
+	if (w.Group == "") == (w.Tag == nil) {
+		return ErrMaintenanceWindowTarget
+	}
+	return nil
+}
+
+func validateWindowDay(value interface{}) error {
+	day, _ := value.(string)
+	if _, ok := maintenanceWindowDays[strings.ToLower(day)]; !ok {
+		return fmt.Errorf("invalid day %q: must be one of mon, tue, wed, thu, fri, sat, sun",
+			day)
+	}
+	return nil
+}
+
+func validateWindowTime(value interface{}) error {
+	s, _ := value.(string)
+	if _, err := time.Parse(maintenanceWindowTimeLayout, s); err != nil {
+		return fmt.Errorf("invalid time %q: must be in HH:MM format", s)
+	}
+	return nil
+}
+
+func validateTimezone(value interface{}) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(s); err != nil {
+		return fmt.Errorf("invalid time zone %q", s)
+	}
+	return nil
+}
+
+// AppliesTo returns true if the window applies to a device in the given
+// groups with the given tags; a group window also applies to the devices
+// in the nested groups.
+func (w MaintenanceWindow) AppliesTo(groups []string, tags map[string]string) bool {
+	if w.Tag != nil {
+		value, ok := tags[w.Tag.Name]
+		return ok && value == w.Tag.Value
+	}
+	for _, group := range groups {
+		if InGroup(group, w.Group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Location returns the time zone the window is evaluated in: the device
+// time zone when known, otherwise the window time zone.
+func (w MaintenanceWindow) Location(deviceTimezone string) *time.Location {
+	if deviceTimezone != "" {
+		if loc, err := time.LoadLocation(deviceTimezone); err == nil {
+			return loc
+		}
+	}
+	if w.Timezone != "" {
+		if loc, err := time.LoadLocation(w.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// IsOpen returns true if the time falls within the window evaluated in
+// the given time zone.
+func (w MaintenanceWindow) IsOpen(t time.Time, loc *time.Location) bool {
+	start, err := time.Parse(maintenanceWindowTimeLayout, w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(maintenanceWindowTimeLayout, w.End)
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+	startMin := start.Hour()*60 + start.Minute()
+	endMin := end.Hour()*60 + end.Minute()
+	nowMin := t.Hour()*60 + t.Minute()
+
+	if startMin < endMin {
+		return w.startsOn(t.Weekday()) && nowMin >= startMin && nowMin < endMin
+	}
+	// the window spans midnight: it is open from the start time on the
+	// window days until the end time of the following days
+	if nowMin >= startMin && w.startsOn(t.Weekday()) {
+		return true
+	}
+	return nowMin < endMin && w.startsOn((t.Weekday()+6)%7)
+}
+
+func (w MaintenanceWindow) startsOn(day time.Weekday) bool {
+	for _, d := range w.Days {
+		if wd, ok := maintenanceWindowDays[strings.ToLower(d)]; ok && wd == day {
+			return true
+		}
+	}
+	return false
+}
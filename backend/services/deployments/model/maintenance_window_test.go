@@ -0,0 +1,187 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		window MaintenanceWindow
+		err    string
+	}{
+		"ok, group": {
+			window: MaintenanceWindow{
+				Name:     "oslo",
+				Group:    "oslo",
+				Days:     []string{"Mon", "tue"},
+				Start:    "01:00",
+				End:      "04:00",
+				Timezone: "Europe/Oslo",
+			},
+		},
+		"ok, tag": {
+			window: MaintenanceWindow{
+				Name:  "lab",
+				Tag:   &MaintenanceWindowTag{Name: "site", Value: "lab"},
+				Days:  []string{"sat"},
+				Start: "22:00",
+				End:   "02:00",
+			},
+		},
+		"error, group and tag": {
+			window: MaintenanceWindow{
+				Name:  "lab",
+				Group: "lab",
+				Tag:   &MaintenanceWindowTag{Name: "site", Value: "lab"},
+				Days:  []string{"sat"},
+				Start: "22:00",
+				End:   "02:00",
+			},
+			err: ErrMaintenanceWindowTarget.Error(),
+		},
+		"error, invalid time": {
+			window: MaintenanceWindow{
+				Name:  "lab",
+				Group: "lab",
+				Days:  []string{"sat"},
+				Start: "25:00",
+				End:   "02:00",
+			},
+			err: `start: invalid time "25:00": must be in HH:MM format.`,
+		},
+		"error, invalid name": {
+			window: MaintenanceWindow{
+				Name:  "Lab",
+				Group: "lab",
+				Days:  []string{"sat"},
+				Start: "22:00",
+				End:   "02:00",
+			},
+			err: "name: " + ErrMaintenanceWindowNameInvalid.Error() + ".",
+		},
+		"error, no days": {
+			window: MaintenanceWindow{
+				Name:  "lab",
+				Group: "lab",
+				Start: "22:00",
+				End:   "02:00",
+			},
+			err: "days: cannot be blank.",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.window.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowIsOpen(t *testing.T) {
+	t.Parallel()
+
+	oslo, _ := time.LoadLocation("Europe/Oslo")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	weekdays := MaintenanceWindow{
+		Days:     []string{"mon", "tue", "wed", "thu", "fri"},
+		Start:    "01:00",
+		End:      "04:00",
+		Timezone: "Europe/Oslo",
+	}
+	overnight := MaintenanceWindow{
+		Days:  []string{"sat"},
+		Start: "22:00",
+		End:   "02:00",
+	}
+
+	testCases := map[string]struct {
+		window         MaintenanceWindow
+		deviceTimezone string
+		at             time.Time
+		open           bool
+	}{
+		"open, window time zone": {
+			window: weekdays,
+			// Monday 02:30 in Oslo
+			at:   time.Date(2026, 10, 19, 2, 30, 0, 0, oslo),
+			open: true,
+		},
+		"closed, window time zone": {
+			window: weekdays,
+			// Monday 02:30 UTC is 04:30 in Oslo
+			at: time.Date(2026, 10, 19, 2, 30, 0, 0, time.UTC),
+		},
+		"closed, weekend": {
+			window: weekdays,
+			at:     time.Date(2026, 10, 18, 2, 30, 0, 0, oslo),
+		},
+		"open, device time zone": {
+			window:         weekdays,
+			deviceTimezone: "America/New_York",
+			at:             time.Date(2026, 10, 19, 1, 30, 0, 0, newYork),
+			open:           true,
+		},
+		"open, overnight before midnight": {
+			window: overnight,
+			at:     time.Date(2026, 10, 24, 23, 0, 0, 0, time.UTC),
+			open:   true,
+		},
+		"open, overnight after midnight": {
+			window: overnight,
+			at:     time.Date(2026, 10, 25, 1, 59, 0, 0, time.UTC),
+			open:   true,
+		},
+		"closed, overnight end": {
+			window: overnight,
+			at:     time.Date(2026, 10, 25, 2, 0, 0, 0, time.UTC),
+		},
+		"closed, overnight previous day": {
+			window: overnight,
+			at:     time.Date(2026, 10, 24, 1, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			loc := tc.window.Location(tc.deviceTimezone)
+			assert.Equal(t, tc.open, tc.window.IsOpen(tc.at, loc))
+		})
+	}
+}
+
+func TestMaintenanceWindowAppliesTo(t *testing.T) {
+	t.Parallel()
+
+	group := MaintenanceWindow{Group: "oslo"}
+	tag := MaintenanceWindow{Tag: &MaintenanceWindowTag{Name: "site", Value: "lab"}}
+
+	assert.True(t, group.AppliesTo([]string{"oslo"}, nil))
+	assert.False(t, group.AppliesTo([]string{"bergen"}, map[string]string{"site": "lab"}))
+	assert.True(t, group.AppliesTo([]string{"oslo/east"}, nil))
+	assert.False(t, group.AppliesTo([]string{"oslo-east"}, nil))
+	assert.True(t, tag.AppliesTo(nil, map[string]string{"site": "lab"}))
+	assert.False(t, tag.AppliesTo([]string{"lab"}, map[string]string{"site": "office"}))
+}
@@ -186,6 +186,32 @@ type Release struct {
 	ArtifactsCount int        `json:"artifacts_count" bson:"artifacts_count"`
 	Tags           Tags       `json:"tags" bson:"tags,omitempty"`
 	Notes          Notes      `json:"notes" bson:"notes,omitempty"`
+
+	// Channel is the release channel the release was promoted to.
+	Channel string `json:"channel,omitempty" bson:"channel,omitempty"`
+	// Promotion is the pending promotion awaiting approvals.
+	Promotion *ReleasePromotion `json:"promotion,omitempty" bson:"promotion,omitempty"`
+}
+
+// Uploaders returns the users who uploaded the artifacts of the release.
+func (r Release) Uploaders() []string {
+	var uploaders []string
+	for _, artifact := range r.Artifacts {
+		if artifact.UploadedBy == "" {
+			continue
+		}
+		found := false
+		for _, uploader := range uploaders {
+			if uploader == artifact.UploadedBy {
+				found = true
+				break
+			}
+		}
+		if !found {
+			uploaders = append(uploaders, artifact.UploadedBy)
+		}
+	}
+	return uploaders
 }
 
 type ReleaseV1 struct {
@@ -200,7 +226,14 @@ type ReleaseV1 struct {
 func ConvertReleasesToV1(releases []Release) []ReleaseV1 {
 	realesesV1 := make([]ReleaseV1, len(releases))
 	for i, release := range releases {
-		realesesV1[i] = ReleaseV1(release)
+		realesesV1[i] = ReleaseV1{
+			Name:           release.Name,
+			Modified:       release.Modified,
+			Artifacts:      release.Artifacts,
+			ArtifactsCount: release.ArtifactsCount,
+			Tags:           release.Tags,
+			Notes:          release.Notes,
+		}
 	}
 	return realesesV1
 }
@@ -219,6 +252,7 @@ type ReleaseOrImageFilter struct {
 	DeviceType  string   `json:"device_type"`
 	Tags        []string `json:"tags"`
 	UpdateType  string   `json:"update_type"`
+	Channel     string   `json:"channel"`
 	Page        int      `json:"page"`
 	PerPage     int      `json:"per_page"`
 	Sort        string   `json:"sort"`
@@ -0,0 +1,144 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ReleaseChannelMaxApprovals is the maximum number of approvals a
+	// channel can require for promoting a release.
+	ReleaseChannelMaxApprovals = 10
+	// ReleaseChannelMaxGroups is the maximum number of device groups
+	// restricted to a channel.
+	ReleaseChannelMaxGroups = 100
+	// GroupSeparator separates the names of nested device groups.
+	GroupSeparator = "/"
+)
+
+var (
+	validChannelName = regexp.MustCompile("^[a-z0-9][a-z0-9_.-]*$")
+	validGroupName   = regexp.MustCompile("^[A-Za-z0-9_-]+(/[A-Za-z0-9_-]+)*$")
+
+	ErrChannelNameInvalid = errors.New(
+		"must start with a lower case letter or a digit and contain " +
+			"only lower case letters, digits, '-', '_' and '.'",
+	)
+	ErrGroupNameInvalid = errors.New(
+		"must contain only letters, digits, '-' and '_', " +
+			"and '/' separating the names of nested groups",
+	)
+)
+
+// ReleaseChannel is a stage of the release lifecycle (for example dev,
+// staging or production). Releases are promoted from the channels with
+// a lower rank to the channels with a higher rank.
+type ReleaseChannel struct {
+	Name string `json:"name" bson:"_id"`
+	// Rank orders the channels: a release can be deployed to the groups
+	// restricted to a channel if it was promoted to that channel or to
+	// a channel with a higher rank.
+	Rank int `json:"rank" bson:"rank"`
+	// RequiredApprovals is the number of users, other than the uploaders
+	// of the release, who must approve the promotion to this channel.
+	RequiredApprovals int `json:"required_approvals" bson:"required_approvals"`
+	// Groups only accept releases promoted to this channel.
+	Groups []string `json:"groups" bson:"groups"`
+
+	Created  *time.Time `json:"created,omitempty" bson:"created,omitempty"`
+	Modified *time.Time `json:"modified,omitempty" bson:"modified,omitempty"`
+}
+
+func (c ReleaseChannel) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 64),
+			validation.Match(validChannelName).ErrorObject(
+				validation.NewError("validation_channel_name", ErrChannelNameInvalid.Error()),
+			),
+		),
+		validation.Field(&c.Rank, validation.Min(0)),
+		validation.Field(&c.RequiredApprovals,
+			validation.Min(0), validation.Max(ReleaseChannelMaxApprovals),
+		),
+		validation.Field(&c.Groups,
+			validation.Length(0, ReleaseChannelMaxGroups),
+			validation.Each(validation.Required, validation.Length(1, 1024),
+				validation.Match(validGroupName).ErrorObject(
+					validation.NewError("validation_group_name", ErrGroupNameInvalid.Error()),
+				),
+			),
+		),
+	)
+}
+
+// HasGroup returns true if the group, or one of the groups it is
+// nested in, is restricted to the channel.
+func (c ReleaseChannel) HasGroup(group string) bool {
+	for _, g := range c.Groups {
+		if InGroup(group, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// InGroup returns true if the group is the parent group or is nested in it,
+// e.g. "europe/norway" is in "europe".
+func InGroup(group, parent string) bool {
+	return group == parent ||
+		strings.HasPrefix(group, parent+GroupSeparator)
+}
+
+// ReleasePromotionRequest is the request to promote a release to a channel.
+type ReleasePromotionRequest struct {
+	Channel string `json:"channel"`
+}
+
+func (r ReleasePromotionRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Channel, validation.Required, validation.Length(1, 64)),
+	)
+}
+
+// ReleasePromotion is a pending promotion of a release to a channel
+// requiring approvals.
+type ReleasePromotion struct {
+	Channel     string              `json:"channel" bson:"channel"`
+	RequestedBy string              `json:"requested_by,omitempty" bson:"requested_by,omitempty"`
+	Requested   time.Time           `json:"requested" bson:"requested"`
+	Approvals   []PromotionApproval `json:"approvals" bson:"approvals"`
+}
+
+// IsApprovedBy returns true if the user already approved the promotion.
+func (p ReleasePromotion) IsApprovedBy(userID string) bool {
+	for _, approval := range p.Approvals {
+		if approval.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// PromotionApproval is the approval of a release promotion by a user.
+type PromotionApproval struct {
+	UserID   string    `json:"user_id" bson:"user_id"`
+	Approved time.Time `json:"approved" bson:"approved"`
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseChannelValidate(t *testing.T) {
+	t.Parallel()
+
+	tooManyGroups := make([]string, ReleaseChannelMaxGroups+1)
+	for i := range tooManyGroups {
+		tooManyGroups[i] = "group"
+	}
+
+	testCases := map[string]struct {
+		channel ReleaseChannel
+		err     string
+	}{
+		"ok": {
+			channel: ReleaseChannel{
+				Name:              "production",
+				Rank:              2,
+				RequiredApprovals: 2,
+				Groups:            []string{"production", "field_test-1"},
+			},
+		},
+		"error, no name": {
+			channel: ReleaseChannel{},
+			err:     "name: cannot be blank.",
+		},
+		"error, invalid name": {
+			channel: ReleaseChannel{Name: "Production"},
+			err:     "name: " + ErrChannelNameInvalid.Error() + ".",
+		},
+		"error, name too long": {
+			channel: ReleaseChannel{Name: strings.Repeat("a", 65)},
+			err:     "name: the length must be between 1 and 64.",
+		},
+		"error, negative rank": {
+			channel: ReleaseChannel{Name: "dev", Rank: -1},
+			err:     "rank: must be no less than 0.",
+		},
+		"error, too many approvals": {
+			channel: ReleaseChannel{
+				Name:              "dev",
+				RequiredApprovals: ReleaseChannelMaxApprovals + 1,
+			},
+			err: "required_approvals: must be no greater than 10.",
+		},
+		"error, invalid group": {
+			channel: ReleaseChannel{Name: "dev", Groups: []string{"dev group"}},
+			err:     "groups: (0: " + ErrGroupNameInvalid.Error() + ".).",
+		},
+		"error, invalid nested group": {
+			channel: ReleaseChannel{Name: "dev", Groups: []string{"europe//norway"}},
+			err:     "groups: (0: " + ErrGroupNameInvalid.Error() + ".).",
+		},
+		"error, too many groups": {
+			channel: ReleaseChannel{Name: "dev", Groups: tooManyGroups},
+			err:     "groups: the length must be no more than 100.",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.channel.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInGroup(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, InGroup("europe", "europe"))
+	assert.True(t, InGroup("europe/norway/oslo", "europe"))
+	assert.True(t, InGroup("europe/norway", "europe/norway"))
+	assert.False(t, InGroup("europe", "europe/norway"))
+	assert.False(t, InGroup("europe-west", "europe"))
+
+	channel := ReleaseChannel{Name: "production", Groups: []string{"europe"}}
+	assert.True(t, channel.HasGroup("europe/norway"))
+	assert.False(t, channel.HasGroup("asia"))
+}
+
+func TestReleasePromotionIsApprovedBy(t *testing.T) {
+	t.Parallel()
+
+	promotion := ReleasePromotion{
+		Channel:   "production",
+		Approvals: []PromotionApproval{{UserID: "user-1"}},
+	}
+	assert.True(t, promotion.IsApprovedBy("user-1"))
+	assert.False(t, promotion.IsApprovedBy("user-2"))
+}
+
+func TestReleaseUploaders(t *testing.T) {
+	t.Parallel()
+
+	release := Release{
+		Artifacts: []Image{
+			{UploadedBy: "user-1"},
+			{},
+			{UploadedBy: "user-2"},
+			{UploadedBy: "user-1"},
+		},
+	}
+	assert.Equal(t, []string{"user-1", "user-2"}, release.Uploaders())
+	assert.Nil(t, Release{}.Uploaders())
+}
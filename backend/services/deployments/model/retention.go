@@ -0,0 +1,92 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// RetentionMaxKeepReleases is the maximum number of releases per device
+	// type the retention policy can keep.
+	RetentionMaxKeepReleases = 1000
+	// RetentionMaxUnusedDays is the maximum number of days the retention
+	// policy can wait before deleting unused artifacts.
+	RetentionMaxUnusedDays = 3650
+)
+
+// RetentionPolicy are the tenant rules for deleting artifacts automatically.
+// An artifact which is one of the KeepReleases most recent releases of any
+// of its device types is always kept; the remaining artifacts are deleted
+// once they have not been used by any deployment for UnusedDays. Artifacts
+// installed on devices or used by active deployments are never deleted.
+type RetentionPolicy struct {
+	// KeepReleases is the number of most recent releases to keep per
+	// device type; 0 disables the rule.
+	KeepReleases int `json:"keep_releases" bson:"keep_releases"`
+	// UnusedDays is the number of days after which artifacts not used by
+	// any deployment are deleted; 0 disables the rule.
+	UnusedDays int `json:"unused_days" bson:"unused_days"`
+}
+
+func (p RetentionPolicy) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.KeepReleases,
+			validation.Min(0), validation.Max(RetentionMaxKeepReleases),
+		),
+		validation.Field(&p.UnusedDays,
+			validation.Min(0), validation.Max(RetentionMaxUnusedDays),
+		),
+	)
+}
+
+// Enabled returns true if the policy deletes any artifact.
+func (p RetentionPolicy) Enabled() bool {
+	return p.KeepReleases > 0 || p.UnusedDays > 0
+}
+
+const (
+	// RetentionReasonReleaseLimit marks artifacts exceeding the number of
+	// releases to keep per device type.
+	RetentionReasonReleaseLimit = "release_limit"
+	// RetentionReasonUnused marks artifacts not used by any deployment
+	// for longer than the policy allows.
+	RetentionReasonUnused = "unused"
+)
+
+// RetentionCandidate is an artifact selected for deletion by the retention
+// policy.
+type RetentionCandidate struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	DeviceTypes []string   `json:"device_types_compatible"`
+	Size        int64      `json:"size"`
+	Modified    *time.Time `json:"modified,omitempty"`
+	// LastUsed is the creation time of the last deployment of the artifact.
+	LastUsed *time.Time `json:"last_used,omitempty"`
+	Reason   string     `json:"reason"`
+}
+
+// RetentionReport lists the artifacts deleted, or to be deleted in a dry
+// run, by the retention policy.
+type RetentionReport struct {
+	Policy    RetentionPolicy      `json:"policy"`
+	DryRun    bool                 `json:"dry_run"`
+	Artifacts []RetentionCandidate `json:"artifacts"`
+	// TotalSize is the total size in bytes of the artifacts.
+	TotalSize int64 `json:"total_size"`
+}
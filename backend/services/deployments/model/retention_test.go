@@ -0,0 +1,59 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy  RetentionPolicy
+		enabled bool
+		err     string
+	}{
+		"ok, disabled": {},
+		"ok": {
+			policy:  RetentionPolicy{KeepReleases: 5, UnusedDays: 30},
+			enabled: true,
+		},
+		"error, negative keep releases": {
+			policy: RetentionPolicy{KeepReleases: -1},
+			err:    "keep_releases: must be no less than 0.",
+		},
+		"error, too many unused days": {
+			policy:  RetentionPolicy{UnusedDays: RetentionMaxUnusedDays + 1},
+			enabled: true,
+			err:     "unused_days: must be no greater than 3650.",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.policy.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.enabled, tc.policy.Enabled())
+		})
+	}
+}
@@ -85,6 +85,23 @@ func TestStorageSettingsDeserialize(t *testing.T) {
 			Key:    "AccountName",
 			Secret: "AccountKey",
 		},
+	}, {
+		Name: "ok/gcs_interop",
+
+		Raw: `
+{
+  "type": "gcs_interop",
+  "key": "GOOG1EXAMPLEHMACACCESSIDEXAMPLEHMACACCESSIDEXAMPLEHMACACCESS",
+  "secret": "super_secret",
+  "bucket": "bucketMcBucketFace"
+}
+`,
+		Expected: StorageSettings{
+			Type:   StorageTypeGCSInterop,
+			Bucket: "bucketMcBucketFace",
+			Key:    "GOOG1EXAMPLEHMACACCESSIDEXAMPLEHMACACCESSIDEXAMPLEHMACACCESS",
+			Secret: "super_secret",
+		},
 	}, {
 		Name: "error/malformed data",
 
@@ -0,0 +1,167 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/mendersoftware/mender-artifact/artifact"
+	"github.com/pkg/errors"
+)
+
+const (
+	SigningKeyTypeRSA     = "rsa"
+	SigningKeyTypeECDSA   = "ecdsa"
+	SigningKeyTypeEd25519 = "ed25519"
+
+	// ImageSignatureStatusUnsigned marks artifacts without a signature.
+	ImageSignatureStatusUnsigned = "unsigned"
+	// ImageSignatureStatusVerified marks artifacts signed with one of
+	// the tenant's signing keys.
+	ImageSignatureStatusVerified = "verified"
+	// ImageSignatureStatusUnverified marks signed artifacts whose signature
+	// does not match any of the tenant's signing keys, or whose payload was
+	// not checked against the signed manifest.
+	ImageSignatureStatusUnverified = "unverified"
+)
+
+var (
+	ErrSigningKeyInvalidPEM = errors.New("public key must be a PEM encoded PKIX public key")
+	ErrSigningKeyType       = errors.New("public key must be an RSA, ECDSA or Ed25519 key")
+	ErrSigningKeyCurve      = errors.New("ECDSA public key must use the P-256 curve")
+)
+
+// NewSigningKey is the request registering a public key used for
+// verifying artifact signatures.
+type NewSigningKey struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"`
+}
+
+func (k NewSigningKey) Validate() error {
+	return validation.ValidateStruct(&k,
+		validation.Field(&k.Name, validation.Required, lengthIn1To256),
+		validation.Field(&k.PublicKey, validation.Required, lengthIn1To4096,
+			validation.By(func(interface{}) error {
+				_, _, _, err := parsePublicKey(k.PublicKey)
+				return err
+			}),
+		),
+	)
+}
+
+// SigningKey is a public key registered by the tenant for verifying
+// artifact signatures.
+type SigningKey struct {
+	ID          string    `json:"id" bson:"_id"`
+	Name        string    `json:"name" bson:"name"`
+	Type        string    `json:"type" bson:"type"`
+	Fingerprint string    `json:"fingerprint" bson:"fingerprint"`
+	PublicKey   string    `json:"public_key" bson:"public_key"`
+	Created     time.Time `json:"created" bson:"created"`
+}
+
+// NewSigningKeyFromRequest parses the public key from the request and
+// returns the signing key to store.
+func NewSigningKeyFromRequest(id string, req *NewSigningKey) (*SigningKey, error) {
+	_, keyType, fingerprint, err := parsePublicKey(req.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{
+		ID:          id,
+		Name:        req.Name,
+		Type:        keyType,
+		Fingerprint: fingerprint,
+		PublicKey:   req.PublicKey,
+		Created:     time.Now().UTC(),
+	}, nil
+}
+
+// Verify checks the base64 encoded artifact signature of the message
+// (the artifact manifest) against the public key.
+func (k *SigningKey) Verify(message, signature []byte) error {
+	key, keyType, _, err := parsePublicKey(k.PublicKey)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(string(signature))
+	if err != nil {
+		return errors.Wrap(err, "error decoding signature")
+	}
+	switch keyType {
+	case SigningKeyTypeRSA:
+		return new(artifact.RSA).Verify(message, sig, key)
+	case SigningKeyTypeECDSA:
+		return new(artifact.ECDSA256).Verify(message, sig, key)
+	default:
+		if !ed25519.Verify(key.(ed25519.PublicKey), message, sig) {
+			return errors.New("failed to verify Ed25519 signature")
+		}
+		return nil
+	}
+}
+
+func parsePublicKey(keyPEM string) (key crypto.PublicKey, keyType, fingerprint string, err error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, "", "", ErrSigningKeyInvalidPEM
+	}
+	key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, "", "", ErrSigningKeyInvalidPEM
+	}
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		keyType = SigningKeyTypeRSA
+	case *ecdsa.PublicKey:
+		// mender-artifact signs with ECDSA over P-256 only
+		if k.Curve != elliptic.P256() {
+			return nil, "", "", ErrSigningKeyCurve
+		}
+		keyType = SigningKeyTypeECDSA
+	case ed25519.PublicKey:
+		keyType = SigningKeyTypeEd25519
+	default:
+		return nil, "", "", ErrSigningKeyType
+	}
+	sum := sha256.Sum256(block.Bytes)
+	return key, keyType, hex.EncodeToString(sum[:]), nil
+}
+
+// SignatureSettings are the tenant settings for artifact signatures.
+type SignatureSettings struct {
+	// RequireSignature rejects artifacts which are not signed with
+	// one of the tenant's signing keys.
+	RequireSignature bool `json:"require_signed_artifacts" bson:"require_signed_artifacts"`
+}
+
+// ImageSignature is the result of the signature verification of an artifact.
+type ImageSignature struct {
+	Status string `json:"status" bson:"status"`
+	// KeyID is the ID of the signing key which verified the signature.
+	KeyID string `json:"key_id,omitempty" bson:"key_id,omitempty"`
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/mendersoftware/mender-artifact/artifact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePublicKey(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestSigningKeyVerify(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("manifest")
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+	})
+	rsaSigner, err := artifact.NewPKISigner(rsaPEM)
+	require.NoError(t, err)
+	rsaSig, err := rsaSigner.Sign(message)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	ecSigner, err := artifact.NewPKISigner(
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+	)
+	require.NoError(t, err)
+	ecSig, err := ecSigner.Sign(message)
+	require.NoError(t, err)
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edSig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(edKey, message)))
+
+	testCases := map[string]struct {
+		publicKey crypto.PublicKey
+		keyType   string
+		signature []byte
+		message   []byte
+
+		verified bool
+	}{
+		"rsa": {
+			publicKey: &rsaKey.PublicKey,
+			keyType:   SigningKeyTypeRSA,
+			signature: rsaSig,
+			verified:  true,
+		},
+		"ecdsa": {
+			publicKey: &ecKey.PublicKey,
+			keyType:   SigningKeyTypeECDSA,
+			signature: ecSig,
+			verified:  true,
+		},
+		"ed25519": {
+			publicKey: edPub,
+			keyType:   SigningKeyTypeEd25519,
+			signature: edSig,
+			verified:  true,
+		},
+		"ed25519, tampered message": {
+			publicKey: edPub,
+			keyType:   SigningKeyTypeEd25519,
+			signature: edSig,
+			message:   []byte("tampered"),
+		},
+		"rsa, wrong key": {
+			publicKey: &rsaKey.PublicKey,
+			keyType:   SigningKeyTypeRSA,
+			signature: ecSig,
+		},
+		"ecdsa, not base64": {
+			publicKey: &ecKey.PublicKey,
+			keyType:   SigningKeyTypeECDSA,
+			signature: []byte("!!"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := &NewSigningKey{
+				Name:      name,
+				PublicKey: encodePublicKey(t, tc.publicKey),
+			}
+			require.NoError(t, req.Validate())
+			key, err := NewSigningKeyFromRequest("id", req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.keyType, key.Type)
+			assert.Len(t, key.Fingerprint, 64)
+
+			msg := message
+			if tc.message != nil {
+				msg = tc.message
+			}
+			err = key.Verify(msg, tc.signature)
+			if tc.verified {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewSigningKeyValidate(t *testing.T) {
+	t.Parallel()
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		key NewSigningKey
+		err string
+	}{
+		"ok": {
+			key: NewSigningKey{Name: "release", PublicKey: encodePublicKey(t, edPub)},
+		},
+		"error, missing name": {
+			key: NewSigningKey{PublicKey: encodePublicKey(t, edPub)},
+			err: "name: cannot be blank.",
+		},
+		"error, not PEM": {
+			key: NewSigningKey{Name: "release", PublicKey: "ssh-ed25519 AAAA"},
+			err: "public_key: " + ErrSigningKeyInvalidPEM.Error() + ".",
+		},
+		"error, private key": {
+			key: NewSigningKey{Name: "release", PublicKey: string(pem.EncodeToMemory(
+				&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")},
+			))},
+			err: "public_key: " + ErrSigningKeyInvalidPEM.Error() + ".",
+		},
+		"error, ECDSA key not on P-256": {
+			key: NewSigningKey{
+				Name:      "release",
+				PublicKey: encodePublicKey(t, &p384Key.PublicKey),
+			},
+			err: "public_key: " + ErrSigningKeyCurve.Error() + ".",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.key.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
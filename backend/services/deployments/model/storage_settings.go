@@ -28,10 +28,12 @@ type StorageType uint32
 const (
 	StorageTypeS3 StorageType = iota
 	StorageTypeAzure
+	StorageTypeGCSInterop
 	storageTypeMax
 
-	storageTypeStrS3    = "s3"
-	storageTypeStrAzure = "azure"
+	storageTypeStrS3         = "s3"
+	storageTypeStrAzure      = "azure"
+	storageTypeStrGCSInterop = "gcs_interop"
 )
 
 func (typ *StorageType) UnmarshalText(b []byte) error {
@@ -41,6 +43,9 @@ func (typ *StorageType) UnmarshalText(b []byte) error {
 
 	case bytes.Equal(b, []byte(storageTypeStrAzure)):
 		*typ = StorageTypeAzure
+
+	case bytes.Equal(b, []byte(storageTypeStrGCSInterop)):
+		*typ = StorageTypeGCSInterop
 	default:
 		return errors.New("storage type invalid")
 	}
@@ -53,13 +58,15 @@ func (typ StorageType) MarshalText() ([]byte, error) {
 		return []byte(storageTypeStrS3), nil
 	case StorageTypeAzure:
 		return []byte(storageTypeStrAzure), nil
+	case StorageTypeGCSInterop:
+		return []byte(storageTypeStrGCSInterop), nil
 	default:
 		return nil, errors.New("storage type invalid")
 	}
 }
 
 type StorageSettings struct {
-	// Type is the provider type (azblob/s3) for the given settings
+	// Type is the provider type (azblob/s3/gcs_interop) for the given settings
 	Type StorageType `json:"type" bson:"type"`
 	// Region sets the s3 bucket region (required when StorageType == StorageTypeAWS)
 	Region string `json:"region" bson:"region"`
@@ -136,6 +143,11 @@ var (
 
 // Validate checks structure according to valid tags
 func (s StorageSettings) Validate() error {
+	ruleKeyLen := ruleLen5_50
+	if s.Type == StorageTypeGCSInterop {
+		// GCS HMAC access IDs are longer than the AWS access key IDs.
+		ruleKeyLen = ruleLen5_100
+	}
 	return validation.ValidateStruct(&s,
 		validation.Field(&s.Type, ruleStorageType),
 		validation.Field(&s.Region, validation.When(s.Type == StorageTypeS3,
@@ -144,7 +156,7 @@ func (s StorageSettings) Validate() error {
 		validation.Field(&s.Bucket, validation.Required, ruleLen5_100),
 		validation.Field(&s.Key, validation.When(
 			s.Type == StorageTypeS3 || s.ConnectionString == nil,
-			validation.Required, ruleLen5_50,
+			validation.Required, ruleKeyLen,
 		)),
 		validation.Field(&s.Secret, validation.When(
 			s.Type == StorageTypeS3 || s.ConnectionString == nil,
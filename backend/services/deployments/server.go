@@ -36,6 +36,8 @@ import (
 	dconfig "github.com/mendersoftware/mender-server/services/deployments/config"
 	"github.com/mendersoftware/mender-server/services/deployments/storage"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/azblob"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/filesystem"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/gcsinterop"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/manager"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/s3"
 	mstore "github.com/mendersoftware/mender-server/services/deployments/store/mongo"
@@ -132,6 +134,66 @@ func SetupBlobStorage(
 	return azblob.New(ctx, c.GetString(dconfig.SettingStorageBucket), options)
 }
 
+func SetupGCSInterop(ctx context.Context, defaultOptions *s3.Options) (storage.ObjectStorage, error) {
+	c := config.Config
+
+	options := gcsinterop.NewOptions(defaultOptions).
+		SetStaticCredentials(
+			c.GetString(dconfig.SettingGCSInteropAuthKeyId),
+			c.GetString(dconfig.SettingGCSInteropAuthSecret),
+			"",
+		)
+	if c.IsSet(dconfig.SettingGCSInteropURI) {
+		options.SetURI(c.GetString(dconfig.SettingGCSInteropURI))
+	}
+	if c.IsSet(dconfig.SettingGCSInteropExternalURI) {
+		options.SetExternalURI(c.GetString(dconfig.SettingGCSInteropExternalURI))
+	}
+	if c.IsSet(dconfig.SettingStorageProxyURI) {
+		rawURL := c.GetString(dconfig.SettingStorageProxyURI)
+		proxyURL, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid setting `storage.proxy_uri`")
+		}
+		options.SetProxyURI(proxyURL)
+	}
+	return gcsinterop.New(ctx, c.GetString(dconfig.SettingStorageBucket), options)
+}
+
+func SetupFilesystem(ctx context.Context) (storage.ObjectStorage, error) {
+	c := config.Config
+
+	secret, err := presignSecret()
+	if err != nil {
+		return nil, errors.WithMessagef(err,
+			"invalid setting `%s`", dconfig.SettingPresignSecret)
+	}
+	objectsURL, err := url.Parse(api.FMTStorageObjectsURL(
+		c.GetString(dconfig.SettingPresignScheme),
+		c.GetString(dconfig.SettingPresignHost),
+	))
+	if err != nil {
+		return nil, errors.WithMessagef(err,
+			"invalid setting `%s`", dconfig.SettingPresignHost)
+	}
+	options := filesystem.NewOptions().
+		SetRootDir(c.GetString(dconfig.SettingStorageFilesystemRootDir)).
+		SetSecret(secret).
+		SetURL(objectsURL)
+	if c.IsSet(dconfig.SettingStorageFilesystemInternalURI) {
+		internalURL, err := url.Parse(
+			strings.TrimSuffix(c.GetString(dconfig.SettingStorageFilesystemInternalURI), "/") +
+				api.ApiUrlDevices + api.ApiUrlDevicesStorageObjects,
+		)
+		if err != nil {
+			return nil, errors.WithMessagef(err,
+				"invalid setting `%s`", dconfig.SettingStorageFilesystemInternalURI)
+		}
+		options.SetInternalURL(internalURL)
+	}
+	return filesystem.New(ctx, options)
+}
+
 func SetupObjectStorage(ctx context.Context) (objManager storage.ObjectStorage, err error) {
 	c := config.Config
 
@@ -147,6 +209,7 @@ func SetupObjectStorage(ctx context.Context) (objManager storage.ObjectStorage,
 				SetBufferSize(int(bufferSize))
 		azOptions = azblob.NewOptions().
 				SetContentType(app.ArtifactContentType)
+		gcsInteropOptions = gcsinterop.NewOptions(s3Options)
 	)
 	var defaultStorage storage.ObjectStorage
 	switch defType := c.GetString(dconfig.SettingDefaultStorage); defType {
@@ -154,16 +217,32 @@ func SetupObjectStorage(ctx context.Context) (objManager storage.ObjectStorage,
 		defaultStorage, err = SetupS3(ctx, s3Options)
 	case dconfig.StorageTypeAzure:
 		defaultStorage, err = SetupBlobStorage(ctx, azOptions)
+	case dconfig.StorageTypeGCSInterop:
+		defaultStorage, err = SetupGCSInterop(ctx, gcsInteropOptions)
+	case dconfig.StorageTypeFilesystem:
+		defaultStorage, err = SetupFilesystem(ctx)
 	default:
 		err = errors.Errorf(
-			`storage type must be one of %q or %q, received value %q`,
-			dconfig.StorageTypeAWS, dconfig.StorageTypeAzure, defType,
+			`storage type must be one of %q, %q, %q or %q, received value %q`,
+			dconfig.StorageTypeAWS, dconfig.StorageTypeAzure,
+			dconfig.StorageTypeGCSInterop, dconfig.StorageTypeFilesystem, defType,
 		)
 	}
 	if err != nil {
 		return nil, err
 	}
-	return manager.New(ctx, defaultStorage, s3Options, azOptions)
+	return manager.New(ctx, defaultStorage, s3Options, azOptions, gcsInteropOptions)
+}
+
+// presignSecret decodes the base64 (std or URL encoding ignoring padding)
+// secret used for signing URLs.
+func presignSecret() ([]byte, error) {
+	base64Repl := strings.NewReplacer("-", "+", "_", "/", "=", "")
+	return base64.RawStdEncoding.DecodeString(
+		base64Repl.Replace(
+			config.Config.GetString(dconfig.SettingPresignSecret),
+		),
+	)
 }
 
 func RunServer(ctx context.Context) error {
@@ -185,14 +264,13 @@ func RunServer(ctx context.Context) error {
 		return errors.WithMessage(err, "main: failed to setup storage client")
 	}
 
-	app := app.NewDeployments(ds, objStore, 0, false)
+	app := app.NewDeployments(ds, objStore, 0, c.GetBool(dconfig.SettingEnableAudit))
 	if addr := c.GetString(dconfig.SettingReportingAddr); addr != "" {
 		c := reporting.NewClient(addr)
 		app = app.WithReporting(c)
 	}
 
 	// Setup API Router configuration
-	base64Repl := strings.NewReplacer("-", "+", "_", "/", "=", "")
 	expire := c.GetDuration(dconfig.SettingPresignExpireSeconds)
 	apiConf := api.NewConfig().
 		SetPresignExpire(time.Second * expire).
@@ -204,13 +282,14 @@ func RunServer(ctx context.Context) error {
 		SetEnableDirectUploadSkipVerify(c.GetBool(dconfig.SettingStorageDirectUploadSkipVerify)).
 		SetDisableNewReleasesFeature(c.GetBool(dconfig.SettingDisableNewReleasesFeature)).
 		SetMaxRequestSize(c.GetInt64(dconfig.SettingMaxRequestSize))
-	if key, err := base64.RawStdEncoding.DecodeString(
-		base64Repl.Replace(
-			c.GetString(dconfig.SettingPresignSecret),
-		),
-	); err == nil {
+	if key, err := presignSecret(); err == nil {
 		apiConf.SetPresignSecret(key)
 	}
+	if c.GetString(dconfig.SettingDefaultStorage) == dconfig.StorageTypeFilesystem {
+		// Objects on the filesystem are served through the default
+		// storage of the manager.
+		apiConf.SetLocalStorage(objStore)
+	}
 	handler := api.NewRouter(ctx, app, ds, apiConf)
 
 	listen := c.GetString(dconfig.SettingListen)
@@ -0,0 +1,267 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+// Package filesystem implements an object storage on the local filesystem.
+// Signed requests generated by the storage are served by the deployments
+// service itself and signed using the model.RequestSignature scheme.
+package filesystem
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+)
+
+const (
+	// ParamFilename is the query parameter setting the file name of
+	// the downloaded object.
+	ParamFilename = "filename"
+
+	fileSuffixTmp = ".tmp"
+)
+
+var (
+	ErrInvalidPath = errors.New("filesystem: invalid object path")
+)
+
+type client struct {
+	rootDir       string
+	url           *url.URL
+	internalURL   *url.URL
+	secret        []byte
+	defaultExpire time.Duration
+}
+
+func New(ctx context.Context, opts ...*Options) (storage.ObjectStorage, error) {
+	opt := NewOptions(opts...)
+	if err := opt.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "filesystem: invalid options")
+	}
+	rootDir, err := filepath.Abs(*opt.RootDir)
+	if err != nil {
+		return nil, errors.WithMessage(err, "filesystem: invalid root directory")
+	}
+	err = os.MkdirAll(rootDir, 0700)
+	if err != nil {
+		return nil, errors.WithMessage(err, "filesystem: failed to create root directory")
+	}
+	c := &client{
+		rootDir:       rootDir,
+		url:           opt.URL,
+		internalURL:   opt.URL,
+		secret:        opt.Secret,
+		defaultExpire: DefaultExpire,
+	}
+	if opt.InternalURL != nil {
+		c.internalURL = opt.InternalURL
+	}
+	if opt.DefaultExpire != nil {
+		c.defaultExpire = *opt.DefaultExpire
+	}
+	return c, nil
+}
+
+// filePath returns the path of the object on the filesystem making sure
+// the object path does not escape the root directory.
+func (c *client) filePath(objectPath string) (string, error) {
+	objectPath = filepath.FromSlash(objectPath)
+	if !filepath.IsLocal(objectPath) {
+		return "", ErrInvalidPath
+	}
+	return filepath.Join(c.rootDir, objectPath), nil
+}
+
+func (c *client) HealthCheck(ctx context.Context) error {
+	info, err := os.Stat(c.rootDir)
+	if err != nil {
+		return errors.WithMessage(err, "filesystem: failed to check root directory")
+	} else if !info.IsDir() {
+		return errors.Errorf("filesystem: %s is not a directory", c.rootDir)
+	}
+	return nil
+}
+
+type objectReader struct {
+	*os.File
+	length int64
+}
+
+func (r objectReader) Length() int64 {
+	return r.length
+}
+
+func (c *client) GetObject(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	filePath, err := c.filePath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, storage.ErrObjectNotFound
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "filesystem: failed to open object")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, errors.WithMessage(err, "filesystem: failed to open object")
+	}
+	return objectReader{File: f, length: info.Size()}, nil
+}
+
+// PutObject writes the object to a temporary file which replaces the
+// object once fully written, so that partial objects are never served.
+func (c *client) PutObject(ctx context.Context, objectPath string, src io.Reader) error {
+	filePath, err := c.filePath(objectPath)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return errors.WithMessage(err, "filesystem: failed to create object directory")
+	}
+	f, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+"-*"+fileSuffixTmp)
+	if err != nil {
+		return errors.WithMessage(err, "filesystem: failed to create object")
+	}
+	defer os.Remove(f.Name())
+	_, err = io.Copy(f, src)
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return errors.WithMessage(err, "filesystem: failed to write object")
+	}
+	if err = os.Rename(f.Name(), filePath); err != nil {
+		return errors.WithMessage(err, "filesystem: failed to write object")
+	}
+	return nil
+}
+
+func (c *client) DeleteObject(ctx context.Context, objectPath string) error {
+	filePath, err := c.filePath(objectPath)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return storage.ErrObjectNotFound
+	} else if err != nil {
+		return errors.WithMessage(err, "filesystem: failed to delete object")
+	}
+	return nil
+}
+
+func (c *client) StatObject(
+	ctx context.Context,
+	objectPath string,
+) (*storage.ObjectInfo, error) {
+	filePath, err := c.filePath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, storage.ErrObjectNotFound
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "filesystem: failed to stat object")
+	}
+	size := info.Size()
+	modTime := info.ModTime()
+	return &storage.ObjectInfo{
+		Path:         objectPath,
+		Size:         &size,
+		LastModified: &modTime,
+	}, nil
+}
+
+func (c *client) signedLink(
+	method string,
+	objectPath string,
+	filename string,
+	expireAfter time.Duration,
+	public bool,
+) (*model.Link, error) {
+	if _, err := c.filePath(objectPath); err != nil {
+		return nil, err
+	}
+	if expireAfter <= 0 {
+		expireAfter = c.defaultExpire
+	}
+	baseURL := c.internalURL
+	if public {
+		baseURL = c.url
+	}
+	objectURL := *baseURL
+	objectURL.Path = path.Join(baseURL.Path, objectPath)
+	objectURL.RawPath = ""
+	objectURL.RawQuery = ""
+	if filename != "" {
+		q := objectURL.Query()
+		q.Set(ParamFilename, filename)
+		objectURL.RawQuery = q.Encode()
+	}
+	req := &http.Request{
+		Method: method,
+		URL:    &objectURL,
+	}
+	expire := time.Now().Add(expireAfter).Truncate(time.Second)
+	sig := model.NewRequestSignature(req, c.secret)
+	sig.SetExpire(expire)
+	return &model.Link{
+		Uri:    sig.PresignURL(),
+		Expire: expire,
+		Method: method,
+	}, nil
+}
+
+func (c *client) GetRequest(
+	ctx context.Context,
+	objectPath string,
+	filename string,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	if _, err := c.StatObject(ctx, objectPath); err != nil {
+		return nil, err
+	}
+	return c.signedLink(http.MethodGet, objectPath, filename, duration, public)
+}
+
+func (c *client) DeleteRequest(
+	ctx context.Context,
+	objectPath string,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	return c.signedLink(http.MethodDelete, objectPath, "", duration, public)
+}
+
+func (c *client) PutRequest(
+	ctx context.Context,
+	objectPath string,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	return c.signedLink(http.MethodPut, objectPath, "", duration, public)
+}
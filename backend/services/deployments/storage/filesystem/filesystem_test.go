@@ -0,0 +1,160 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+)
+
+var testSecret = []byte("secret")
+
+func newTestStorage(t *testing.T) storage.ObjectStorage {
+	u, _ := url.Parse("https://mender.io/api/devices/v1/deployments/storage/objects")
+	internal, _ := url.Parse("http://deployments:8080/api/devices/v1/deployments/storage/objects")
+	objStore, err := New(context.Background(), NewOptions().
+		SetRootDir(t.TempDir()).
+		SetURL(u).
+		SetInternalURL(internal).
+		SetSecret(testSecret),
+	)
+	require.NoError(t, err)
+	return objStore
+}
+
+func TestNewInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(context.Background(), NewOptions().SetRootDir(t.TempDir()))
+	assert.ErrorContains(t, err, "filesystem: invalid options")
+}
+
+func TestObjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	objStore := newTestStorage(t)
+	require.NoError(t, objStore.HealthCheck(ctx))
+
+	const objectPath = "tenant/artifact"
+	_, err := objStore.GetObject(ctx, objectPath)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = objStore.StatObject(ctx, objectPath)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	err = objStore.PutObject(ctx, objectPath, strings.NewReader("artifact"))
+	require.NoError(t, err)
+
+	info, err := objStore.StatObject(ctx, objectPath)
+	require.NoError(t, err)
+	assert.Equal(t, objectPath, info.Path)
+	if assert.NotNil(t, info.Size) {
+		assert.Equal(t, int64(len("artifact")), *info.Size)
+	}
+	assert.NotNil(t, info.LastModified)
+
+	obj, err := objStore.GetObject(ctx, objectPath)
+	require.NoError(t, err)
+	if assert.Implements(t, (*storage.ObjectReader)(nil), obj) {
+		assert.Equal(t, int64(len("artifact")), obj.(storage.ObjectReader).Length())
+	}
+	b, err := io.ReadAll(obj)
+	obj.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "artifact", string(b))
+
+	// overwrite the object
+	err = objStore.PutObject(ctx, objectPath, strings.NewReader("new"))
+	require.NoError(t, err)
+	obj, err = objStore.GetObject(ctx, objectPath)
+	require.NoError(t, err)
+	b, _ = io.ReadAll(obj)
+	obj.Close()
+	assert.Equal(t, "new", string(b))
+
+	require.NoError(t, objStore.DeleteObject(ctx, objectPath))
+	assert.ErrorIs(t, objStore.DeleteObject(ctx, objectPath), storage.ErrObjectNotFound)
+}
+
+func TestInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	objStore := newTestStorage(t)
+	for _, objectPath := range []string{"../artifact", "/etc/passwd", "tenant/../../artifact", ""} {
+		err := objStore.PutObject(ctx, objectPath, strings.NewReader("artifact"))
+		assert.ErrorIs(t, err, ErrInvalidPath, objectPath)
+		_, err = objStore.GetObject(ctx, objectPath)
+		assert.ErrorIs(t, err, ErrInvalidPath, objectPath)
+		_, err = objStore.PutRequest(ctx, objectPath, time.Minute, true)
+		assert.ErrorIs(t, err, ErrInvalidPath, objectPath)
+	}
+}
+
+func TestSignedRequests(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	objStore := newTestStorage(t)
+	const objectPath = "tenant/artifact"
+
+	_, err := objStore.GetRequest(ctx, objectPath, "artifact.mender", time.Minute, true)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	require.NoError(t, objStore.PutObject(ctx, objectPath, strings.NewReader("artifact")))
+
+	verify := func(link *model.Link, method, host string) *url.URL {
+		assert.Equal(t, method, link.Method)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), link.Expire, 2*time.Second)
+		u, err := url.Parse(link.Uri)
+		require.NoError(t, err)
+		assert.Equal(t, host, u.Host)
+		assert.Equal(t,
+			"/api/devices/v1/deployments/storage/objects/"+objectPath,
+			u.Path,
+		)
+		sig := model.NewRequestSignature(&http.Request{Method: method, URL: u}, testSecret)
+		assert.NoError(t, sig.Validate())
+		assert.True(t, sig.VerifyHMAC256())
+
+		// the signature is bound to the method
+		sig = model.NewRequestSignature(&http.Request{Method: http.MethodPost, URL: u}, testSecret)
+		assert.False(t, sig.VerifyHMAC256())
+		return u
+	}
+
+	link, err := objStore.GetRequest(ctx, objectPath, "artifact.mender", time.Minute, true)
+	require.NoError(t, err)
+	u := verify(link, http.MethodGet, "mender.io")
+	assert.Equal(t, "artifact.mender", u.Query().Get(ParamFilename))
+
+	link, err = objStore.PutRequest(ctx, objectPath, time.Minute, false)
+	require.NoError(t, err)
+	verify(link, http.MethodPut, "deployments:8080")
+
+	link, err = objStore.DeleteRequest(ctx, objectPath, time.Minute, true)
+	require.NoError(t, err)
+	verify(link, http.MethodDelete, "mender.io")
+}
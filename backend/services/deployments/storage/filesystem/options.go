@@ -0,0 +1,100 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package filesystem
+
+import (
+	"net/url"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	DefaultExpire = 15 * time.Minute
+)
+
+type Options struct {
+	// RootDir is the directory storing the objects.
+	RootDir *string
+	// URL is the (public) URL serving the objects, the object path is
+	// appended to the URL path.
+	URL *url.URL
+	// InternalURL is the URL serving the objects used for links which
+	// are not public (defaults to URL).
+	InternalURL *url.URL
+	// Secret is the secret used for signing the URLs.
+	Secret []byte
+	// DefaultExpire is the fallback presign expire duration
+	// (defaults to 15min).
+	DefaultExpire *time.Duration
+}
+
+func NewOptions(opts ...*Options) *Options {
+	ret := new(Options)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.RootDir != nil {
+			ret.RootDir = opt.RootDir
+		}
+		if opt.URL != nil {
+			ret.URL = opt.URL
+		}
+		if opt.InternalURL != nil {
+			ret.InternalURL = opt.InternalURL
+		}
+		if opt.Secret != nil {
+			ret.Secret = opt.Secret
+		}
+		if opt.DefaultExpire != nil {
+			ret.DefaultExpire = opt.DefaultExpire
+		}
+	}
+	return ret
+}
+
+func (opts Options) Validate() error {
+	return validation.ValidateStruct(&opts,
+		validation.Field(&opts.RootDir, validation.Required),
+		validation.Field(&opts.URL, validation.Required),
+		validation.Field(&opts.Secret, validation.Required),
+	)
+}
+
+func (opts *Options) SetRootDir(rootDir string) *Options {
+	opts.RootDir = &rootDir
+	return opts
+}
+
+func (opts *Options) SetURL(u *url.URL) *Options {
+	opts.URL = u
+	return opts
+}
+
+func (opts *Options) SetInternalURL(u *url.URL) *Options {
+	opts.InternalURL = u
+	return opts
+}
+
+func (opts *Options) SetSecret(secret []byte) *Options {
+	opts.Secret = secret
+	return opts
+}
+
+func (opts *Options) SetDefaultExpire(defaultExpire time.Duration) *Options {
+	opts.DefaultExpire = &defaultExpire
+	return opts
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+// Package gcsinterop implements a Google Cloud Storage backend through the
+// S3 interoperability of the XML API: it is the s3 client with the GCS
+// endpoint, and requires HMAC keys (interoperability credentials) as the
+// service accounts and OAuth credentials of the native GCS API are not
+// supported. Signed URLs use the AWS V4 signing process, which GCS
+// accepts with HMAC keys.
+package gcsinterop
+
+import (
+	"context"
+
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/s3"
+)
+
+const (
+	DefaultURI    = "https://storage.googleapis.com"
+	DefaultRegion = "auto"
+)
+
+// NewOptions returns the s3 options with the defaults for Google Cloud
+// Storage applied before the given options.
+func NewOptions(opts ...*s3.Options) *s3.Options {
+	defaults := s3.NewOptions().
+		SetURI(DefaultURI).
+		SetRegion(DefaultRegion).
+		// Google Cloud Storage does not tolerate signing the
+		// Accept-Encoding header.
+		SetUnsignedHeaders([]string{"Accept-Encoding"})
+	return s3.NewOptions(append([]*s3.Options{defaults}, opts...)...)
+}
+
+// NewEmpty initializes a client without a default bucket, the bucket and
+// credentials are provided by the storage settings in the context.
+func NewEmpty(ctx context.Context, opts ...*s3.Options) (storage.ObjectStorage, error) {
+	return s3.NewEmpty(ctx, NewOptions(opts...))
+}
+
+func New(ctx context.Context, bucket string, opts ...*s3.Options) (storage.ObjectStorage, error) {
+	return s3.New(ctx, NewOptions(opts...).SetBucketName(bucket))
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package gcsinterop
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/s3"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSignedRequest(t *testing.T) {
+	t.Parallel()
+
+	const (
+		bucket = "artifacts"
+		keyID  = "GOOG1EXAMPLE"
+		secret = "secret"
+	)
+	// The transport is never reached: presigning happens offline.
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, http.ErrNotSupported
+	})
+	objStore, err := NewEmpty(context.Background(), s3.NewOptions().
+		SetTransport(transport).
+		SetStaticCredentials(keyID, secret, ""),
+	)
+	require.NoError(t, err)
+
+	ctx := storage.SettingsWithContext(context.Background(), &model.StorageSettings{
+		Type:   model.StorageTypeGCSInterop,
+		Bucket: bucket,
+		Key:    keyID,
+		Secret: secret,
+	})
+	link, err := objStore.PutRequest(ctx, "tenant/artifact", time.Minute, true)
+	require.NoError(t, err)
+
+	u, err := url.Parse(link.Uri)
+	require.NoError(t, err)
+	assert.Equal(t, bucket+".storage.googleapis.com", u.Host)
+	assert.Equal(t, "/tenant/artifact", u.Path)
+	q := u.Query()
+	assert.Equal(t, "AWS4-HMAC-SHA256", q.Get("X-Amz-Algorithm"))
+	assert.True(t,
+		strings.HasPrefix(q.Get("X-Amz-Credential"), keyID+"/"),
+		"unexpected credential: %s", q.Get("X-Amz-Credential"),
+	)
+	assert.Contains(t, q.Get("X-Amz-Credential"), "/"+DefaultRegion+"/s3/aws4_request")
+	assert.Equal(t, http.MethodPut, link.Method)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/mendersoftware/mender-server/services/deployments/model"
 	"github.com/mendersoftware/mender-server/services/deployments/storage"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/azblob"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/gcsinterop"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/s3"
 )
 
@@ -40,9 +41,10 @@ func New(
 	defaultStore storage.ObjectStorage,
 	s3Options *s3.Options,
 	azOptions *azblob.Options,
+	gcsInteropOptions *s3.Options,
 ) (storage.ObjectStorage, error) {
 	var err error
-	providerMap := make(map[model.StorageType]storage.ObjectStorage, 2)
+	providerMap := make(map[model.StorageType]storage.ObjectStorage, 3)
 	providerMap[model.StorageTypeAzure], err = azblob.NewEmpty(ctx, azOptions)
 	if err != nil {
 		return nil, err
@@ -51,6 +53,10 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	providerMap[model.StorageTypeGCSInterop], err = gcsinterop.NewEmpty(ctx, gcsInteropOptions)
+	if err != nil {
+		return nil, err
+	}
 
 	return &client{
 		defaultStorage: defaultStore,
@@ -49,6 +49,7 @@ type DataStore interface {
 
 	//tenants
 	ProvisionTenant(ctx context.Context, tenantId string) error
+	DeleteTenant(ctx context.Context, tenantId string) error
 
 	// images
 	Exists(ctx context.Context, id string) (bool, error)
@@ -61,12 +62,13 @@ type DataStore interface {
 	ListImages(ctx context.Context, filt *model.ReleaseOrImageFilter) ([]*model.Image, int, error)
 	ListImagesV2(ctx context.Context, filt *model.ImageFilter) ([]*model.Image, error)
 	DeleteImagesByNames(ctx context.Context, names []string) error
+	ListImageIDs(ctx context.Context) ([]string, error)
 
 	//artifact getter
 	ImagesByName(ctx context.Context,
 		artifactName string) ([]*model.Image, error)
 	ImageByIdsAndDeviceType(ctx context.Context,
-		ids []string, deviceType string) (*model.Image, error)
+		ids []string, deviceType, artifactName string) (*model.Image, error)
 	ImageByNameAndDeviceType(ctx context.Context,
 		name, deviceType string) (*model.Image, error)
 
@@ -79,6 +81,12 @@ type DataStore interface {
 	SaveDeviceDeploymentLog(ctx context.Context, log model.DeploymentLog) error
 	GetDeviceDeploymentLog(ctx context.Context,
 		deviceID, deploymentID string) (*model.DeploymentLog, error)
+	ReplaceDeploymentLogEntries(ctx context.Context,
+		deviceID, deploymentID string, entries []model.DeploymentLogEntry) error
+	SearchDeploymentLogEntries(ctx context.Context,
+		query DeploymentLogQuery) ([]model.DeploymentLogEntry, int, error)
+	ClusterDeploymentLogEntries(ctx context.Context,
+		query DeploymentLogQuery) (*model.DeploymentLogClusters, error)
 
 	// device deployments
 	InsertDeviceDeployment(ctx context.Context, deviceDeployment *model.DeviceDeployment,
@@ -118,6 +126,8 @@ type DataStore interface {
 		query ListQueryDeviceDeployments) ([]model.DeviceDeployment, int, error)
 	HasDeploymentForDevice(ctx context.Context,
 		deploymentID string, deviceID string) (bool, error)
+	GetLastSuccessfulDeviceDeployments(ctx context.Context,
+		deviceIDs []string, before time.Time) ([]model.DeviceDeployment, error)
 	AbortDeviceDeployments(ctx context.Context, deploymentID string) error
 	DeleteDeviceDeploymentsHistory(ctx context.Context, deviceId string) error
 	DecommissionDeviceDeployments(ctx context.Context, deviceId string) error
@@ -205,6 +215,46 @@ type DataStore interface {
 	SaveUpdateTypes(ctx context.Context, updateTypes []string) error
 	GetUpdateTypes(ctx context.Context) ([]string, error)
 	DeleteReleasesByNames(ctx context.Context, names []string) error
+
+	// artifact signatures
+	InsertSigningKey(ctx context.Context, key *model.SigningKey) error
+	GetSigningKeys(ctx context.Context) ([]model.SigningKey, error)
+	DeleteSigningKey(ctx context.Context, id string) error
+	GetSignatureSettings(ctx context.Context) (*model.SignatureSettings, error)
+	SetSignatureSettings(ctx context.Context, settings *model.SignatureSettings) error
+
+	// release channels
+	InsertReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error
+	GetReleaseChannels(ctx context.Context) ([]model.ReleaseChannel, error)
+	GetReleaseChannel(ctx context.Context, name string) (*model.ReleaseChannel, error)
+	UpdateReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error
+	DeleteReleaseChannel(ctx context.Context, name string) error
+	CountReleasesInChannel(ctx context.Context, channel string) (int64, error)
+	SetReleaseChannel(ctx context.Context, releaseName string, channel string) error
+	SetReleasePromotion(
+		ctx context.Context,
+		releaseName string,
+		promotion *model.ReleasePromotion,
+	) error
+	AddReleasePromotionApproval(
+		ctx context.Context,
+		releaseName string,
+		channel string,
+		approval model.PromotionApproval,
+	) (*model.ReleasePromotion, error)
+
+	// artifact retention
+	GetRetentionPolicy(ctx context.Context) (*model.RetentionPolicy, error)
+	SetRetentionPolicy(ctx context.Context, policy *model.RetentionPolicy) error
+	GetInstalledArtifactIDs(ctx context.Context) ([]string, error)
+	GetArtifactsLastUsed(ctx context.Context) (map[string]time.Time, error)
+
+	// maintenance windows
+	InsertMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error
+	GetMaintenanceWindows(ctx context.Context) ([]model.MaintenanceWindow, error)
+	GetMaintenanceWindow(ctx context.Context, name string) (*model.MaintenanceWindow, error)
+	UpdateMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error
+	DeleteMaintenanceWindow(ctx context.Context, name string) error
 }
 
 var ErrNotFound = errors.New("document not found")
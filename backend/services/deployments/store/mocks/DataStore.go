@@ -50,6 +50,36 @@ func (_m *DataStore) AbortDeviceDeployments(ctx context.Context, deploymentID st
 	return r0
 }
 
+// AddReleasePromotionApproval provides a mock function with given fields: ctx, releaseName, channel, approval
+func (_m *DataStore) AddReleasePromotionApproval(ctx context.Context, releaseName string, channel string, approval model.PromotionApproval) (*model.ReleasePromotion, error) {
+	ret := _m.Called(ctx, releaseName, channel, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReleasePromotionApproval")
+	}
+
+	var r0 *model.ReleasePromotion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.PromotionApproval) (*model.ReleasePromotion, error)); ok {
+		return rf(ctx, releaseName, channel, approval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.PromotionApproval) *model.ReleasePromotion); ok {
+		r0 = rf(ctx, releaseName, channel, approval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReleasePromotion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, model.PromotionApproval) error); ok {
+		r1 = rf(ctx, releaseName, channel, approval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AggregateDeviceDeploymentByStatus provides a mock function with given fields: ctx, id
 func (_m *DataStore) AggregateDeviceDeploymentByStatus(ctx context.Context, id string) (model.Stats, error) {
 	ret := _m.Called(ctx, id)
@@ -98,6 +128,64 @@ func (_m *DataStore) AssignArtifact(ctx context.Context, deviceID string, deploy
 	return r0
 }
 
+// ClusterDeploymentLogEntries provides a mock function with given fields: ctx, query
+func (_m *DataStore) ClusterDeploymentLogEntries(ctx context.Context, query store.DeploymentLogQuery) (*model.DeploymentLogClusters, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClusterDeploymentLogEntries")
+	}
+
+	var r0 *model.DeploymentLogClusters
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) (*model.DeploymentLogClusters, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) *model.DeploymentLogClusters); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeploymentLogClusters)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeploymentLogQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountReleasesInChannel provides a mock function with given fields: ctx, channel
+func (_m *DataStore) CountReleasesInChannel(ctx context.Context, channel string) (int64, error) {
+	ret := _m.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReleasesInChannel")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, channel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, channel)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, channel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DecommissionDeviceDeployments provides a mock function with given fields: ctx, deviceId
 func (_m *DataStore) DecommissionDeviceDeployments(ctx context.Context, deviceId string) error {
 	ret := _m.Called(ctx, deviceId)
@@ -188,6 +276,42 @@ func (_m *DataStore) DeleteImagesByNames(ctx context.Context, names []string) er
 	return r0
 }
 
+// DeleteMaintenanceWindow provides a mock function with given fields: ctx, name
+func (_m *DataStore) DeleteMaintenanceWindow(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMaintenanceWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReleaseChannel provides a mock function with given fields: ctx, name
+func (_m *DataStore) DeleteReleaseChannel(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReleaseChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteReleasesByNames provides a mock function with given fields: ctx, names
 func (_m *DataStore) DeleteReleasesByNames(ctx context.Context, names []string) error {
 	ret := _m.Called(ctx, names)
@@ -206,6 +330,42 @@ func (_m *DataStore) DeleteReleasesByNames(ctx context.Context, names []string)
 	return r0
 }
 
+// DeleteSigningKey provides a mock function with given fields: ctx, id
+func (_m *DataStore) DeleteSigningKey(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSigningKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTenant provides a mock function with given fields: ctx, tenantId
+func (_m *DataStore) DeleteTenant(ctx context.Context, tenantId string) error {
+	ret := _m.Called(ctx, tenantId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTenant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceCountByDeployment provides a mock function with given fields: ctx, id
 func (_m *DataStore) DeviceCountByDeployment(ctx context.Context, id string) (int, error) {
 	ret := _m.Called(ctx, id)
@@ -660,6 +820,36 @@ func (_m *DataStore) FindUploadLinks(ctx context.Context, expired time.Time) (st
 	return r0, r1
 }
 
+// GetArtifactsLastUsed provides a mock function with given fields: ctx
+func (_m *DataStore) GetArtifactsLastUsed(ctx context.Context) (map[string]time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsLastUsed")
+	}
+
+	var r0 map[string]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]time.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeploymentIDsByArtifactNames provides a mock function with given fields: ctx, artifactNames
 func (_m *DataStore) GetDeploymentIDsByArtifactNames(ctx context.Context, artifactNames []string) ([]string, error) {
 	ret := _m.Called(ctx, artifactNames)
@@ -884,6 +1074,36 @@ func (_m *DataStore) GetDevicesListForDeployment(ctx context.Context, query stor
 	return r0, r1, r2
 }
 
+// GetInstalledArtifactIDs provides a mock function with given fields: ctx
+func (_m *DataStore) GetInstalledArtifactIDs(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInstalledArtifactIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastDeviceDeploymentStatus provides a mock function with given fields: ctx, devicesIds
 func (_m *DataStore) GetLastDeviceDeploymentStatus(ctx context.Context, devicesIds []string) ([]model.DeviceDeploymentLastStatus, error) {
 	ret := _m.Called(ctx, devicesIds)
@@ -914,6 +1134,36 @@ func (_m *DataStore) GetLastDeviceDeploymentStatus(ctx context.Context, devicesI
 	return r0, r1
 }
 
+// GetLastSuccessfulDeviceDeployments provides a mock function with given fields: ctx, deviceIDs, before
+func (_m *DataStore) GetLastSuccessfulDeviceDeployments(ctx context.Context, deviceIDs []string, before time.Time) ([]model.DeviceDeployment, error) {
+	ret := _m.Called(ctx, deviceIDs, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSuccessfulDeviceDeployments")
+	}
+
+	var r0 []model.DeviceDeployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time) ([]model.DeviceDeployment, error)); ok {
+		return rf(ctx, deviceIDs, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time) []model.DeviceDeployment); ok {
+		r0 = rf(ctx, deviceIDs, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DeviceDeployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Time) error); ok {
+		r1 = rf(ctx, deviceIDs, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLimit provides a mock function with given fields: ctx, name
 func (_m *DataStore) GetLimit(ctx context.Context, name string) (*model.Limit, error) {
 	ret := _m.Called(ctx, name)
@@ -944,29 +1194,29 @@ func (_m *DataStore) GetLimit(ctx context.Context, name string) (*model.Limit, e
 	return r0, r1
 }
 
-// GetRelease provides a mock function with given fields: ctx, releaseName
-func (_m *DataStore) GetRelease(ctx context.Context, releaseName string) (*model.Release, error) {
-	ret := _m.Called(ctx, releaseName)
+// GetMaintenanceWindow provides a mock function with given fields: ctx, name
+func (_m *DataStore) GetMaintenanceWindow(ctx context.Context, name string) (*model.MaintenanceWindow, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRelease")
+		panic("no return value specified for GetMaintenanceWindow")
 	}
 
-	var r0 *model.Release
+	var r0 *model.MaintenanceWindow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Release, error)); ok {
-		return rf(ctx, releaseName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.MaintenanceWindow, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Release); ok {
-		r0 = rf(ctx, releaseName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.MaintenanceWindow); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Release)
+			r0 = ret.Get(0).(*model.MaintenanceWindow)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, releaseName)
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -974,66 +1224,276 @@ func (_m *DataStore) GetRelease(ctx context.Context, releaseName string) (*model
 	return r0, r1
 }
 
-// GetReleases provides a mock function with given fields: ctx, filt
-func (_m *DataStore) GetReleases(ctx context.Context, filt *model.ReleaseOrImageFilter) ([]model.Release, int, error) {
-	ret := _m.Called(ctx, filt)
+// GetMaintenanceWindows provides a mock function with given fields: ctx
+func (_m *DataStore) GetMaintenanceWindows(ctx context.Context) ([]model.MaintenanceWindow, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReleases")
+		panic("no return value specified for GetMaintenanceWindows")
 	}
 
-	var r0 []model.Release
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseOrImageFilter) ([]model.Release, int, error)); ok {
-		return rf(ctx, filt)
+	var r0 []model.MaintenanceWindow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.MaintenanceWindow, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseOrImageFilter) []model.Release); ok {
-		r0 = rf(ctx, filt)
+	if rf, ok := ret.Get(0).(func(context.Context) []model.MaintenanceWindow); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Release)
+			r0 = ret.Get(0).([]model.MaintenanceWindow)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *model.ReleaseOrImageFilter) int); ok {
-		r1 = rf(ctx, filt)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, *model.ReleaseOrImageFilter) error); ok {
-		r2 = rf(ctx, filt)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetStorageSettings provides a mock function with given fields: ctx
-func (_m *DataStore) GetStorageSettings(ctx context.Context) (*model.StorageSettings, error) {
-	ret := _m.Called(ctx)
+// GetRelease provides a mock function with given fields: ctx, releaseName
+func (_m *DataStore) GetRelease(ctx context.Context, releaseName string) (*model.Release, error) {
+	ret := _m.Called(ctx, releaseName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStorageSettings")
+		panic("no return value specified for GetRelease")
 	}
 
-	var r0 *model.StorageSettings
+	var r0 *model.Release
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*model.StorageSettings, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Release, error)); ok {
+		return rf(ctx, releaseName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *model.StorageSettings); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Release); ok {
+		r0 = rf(ctx, releaseName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.StorageSettings)
+			r0 = ret.Get(0).(*model.Release)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, releaseName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReleaseChannel provides a mock function with given fields: ctx, name
+func (_m *DataStore) GetReleaseChannel(ctx context.Context, name string) (*model.ReleaseChannel, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReleaseChannel")
+	}
+
+	var r0 *model.ReleaseChannel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.ReleaseChannel, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ReleaseChannel); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReleaseChannel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReleaseChannels provides a mock function with given fields: ctx
+func (_m *DataStore) GetReleaseChannels(ctx context.Context) ([]model.ReleaseChannel, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReleaseChannels")
+	}
+
+	var r0 []model.ReleaseChannel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.ReleaseChannel, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.ReleaseChannel); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReleaseChannel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReleases provides a mock function with given fields: ctx, filt
+func (_m *DataStore) GetReleases(ctx context.Context, filt *model.ReleaseOrImageFilter) ([]model.Release, int, error) {
+	ret := _m.Called(ctx, filt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReleases")
+	}
+
+	var r0 []model.Release
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseOrImageFilter) ([]model.Release, int, error)); ok {
+		return rf(ctx, filt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseOrImageFilter) []model.Release); ok {
+		r0 = rf(ctx, filt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ReleaseOrImageFilter) int); ok {
+		r1 = rf(ctx, filt)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ReleaseOrImageFilter) error); ok {
+		r2 = rf(ctx, filt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRetentionPolicy provides a mock function with given fields: ctx
+func (_m *DataStore) GetRetentionPolicy(ctx context.Context) (*model.RetentionPolicy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRetentionPolicy")
+	}
+
+	var r0 *model.RetentionPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.RetentionPolicy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.RetentionPolicy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RetentionPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSignatureSettings provides a mock function with given fields: ctx
+func (_m *DataStore) GetSignatureSettings(ctx context.Context) (*model.SignatureSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSignatureSettings")
+	}
+
+	var r0 *model.SignatureSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.SignatureSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.SignatureSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SignatureSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSigningKeys provides a mock function with given fields: ctx
+func (_m *DataStore) GetSigningKeys(ctx context.Context) ([]model.SigningKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSigningKeys")
+	}
+
+	var r0 []model.SigningKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.SigningKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.SigningKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SigningKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageSettings provides a mock function with given fields: ctx
+func (_m *DataStore) GetStorageSettings(ctx context.Context) (*model.StorageSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageSettings")
+	}
+
+	var r0 *model.StorageSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.StorageSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.StorageSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StorageSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1129,9 +1589,9 @@ func (_m *DataStore) HasDeploymentForDevice(ctx context.Context, deploymentID st
 	return r0, r1
 }
 
-// ImageByIdsAndDeviceType provides a mock function with given fields: ctx, ids, deviceType
-func (_m *DataStore) ImageByIdsAndDeviceType(ctx context.Context, ids []string, deviceType string) (*model.Image, error) {
-	ret := _m.Called(ctx, ids, deviceType)
+// ImageByIdsAndDeviceType provides a mock function with given fields: ctx, ids, deviceType, artifactName
+func (_m *DataStore) ImageByIdsAndDeviceType(ctx context.Context, ids []string, deviceType string, artifactName string) (*model.Image, error) {
+	ret := _m.Called(ctx, ids, deviceType, artifactName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ImageByIdsAndDeviceType")
@@ -1139,19 +1599,19 @@ func (_m *DataStore) ImageByIdsAndDeviceType(ctx context.Context, ids []string,
 
 	var r0 *model.Image
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string, string) (*model.Image, error)); ok {
-		return rf(ctx, ids, deviceType)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, string) (*model.Image, error)); ok {
+		return rf(ctx, ids, deviceType, artifactName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string, string) *model.Image); ok {
-		r0 = rf(ctx, ids, deviceType)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, string) *model.Image); ok {
+		r0 = rf(ctx, ids, deviceType, artifactName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Image)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
-		r1 = rf(ctx, ids, deviceType)
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string, string) error); ok {
+		r1 = rf(ctx, ids, deviceType, artifactName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1309,6 +1769,24 @@ func (_m *DataStore) InsertImage(ctx context.Context, image *model.Image) error
 	return r0
 }
 
+// InsertMaintenanceWindow provides a mock function with given fields: ctx, window
+func (_m *DataStore) InsertMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error {
+	ret := _m.Called(ctx, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertMaintenanceWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MaintenanceWindow) error); ok {
+		r0 = rf(ctx, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertMany provides a mock function with given fields: ctx, deployment
 func (_m *DataStore) InsertMany(ctx context.Context, deployment ...*model.DeviceDeployment) error {
 	_va := make([]interface{}, len(deployment))
@@ -1334,6 +1812,42 @@ func (_m *DataStore) InsertMany(ctx context.Context, deployment ...*model.Device
 	return r0
 }
 
+// InsertReleaseChannel provides a mock function with given fields: ctx, channel
+func (_m *DataStore) InsertReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error {
+	ret := _m.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertReleaseChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseChannel) error); ok {
+		r0 = rf(ctx, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertSigningKey provides a mock function with given fields: ctx, key
+func (_m *DataStore) InsertSigningKey(ctx context.Context, key *model.SigningKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSigningKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SigningKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertUploadIntent provides a mock function with given fields: ctx, link
 func (_m *DataStore) InsertUploadIntent(ctx context.Context, link *model.UploadLink) error {
 	ret := _m.Called(ctx, link)
@@ -1380,6 +1894,36 @@ func (_m *DataStore) IsArtifactUnique(ctx context.Context, artifactName string,
 	return r0, r1
 }
 
+// ListImageIDs provides a mock function with given fields: ctx
+func (_m *DataStore) ListImageIDs(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListImageIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListImages provides a mock function with given fields: ctx, filt
 func (_m *DataStore) ListImages(ctx context.Context, filt *model.ReleaseOrImageFilter) ([]*model.Image, int, error) {
 	ret := _m.Called(ctx, filt)
@@ -1513,6 +2057,24 @@ func (_m *DataStore) ProvisionTenant(ctx context.Context, tenantId string) error
 	return r0
 }
 
+// ReplaceDeploymentLogEntries provides a mock function with given fields: ctx, deviceID, deploymentID, entries
+func (_m *DataStore) ReplaceDeploymentLogEntries(ctx context.Context, deviceID string, deploymentID string, entries []model.DeploymentLogEntry) error {
+	ret := _m.Called(ctx, deviceID, deploymentID, entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceDeploymentLogEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []model.DeploymentLogEntry) error); ok {
+		r0 = rf(ctx, deviceID, deploymentID, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReplaceReleaseTags provides a mock function with given fields: ctx, releaseName, tags
 func (_m *DataStore) ReplaceReleaseTags(ctx context.Context, releaseName string, tags model.Tags) error {
 	ret := _m.Called(ctx, releaseName, tags)
@@ -1603,6 +2165,43 @@ func (_m *DataStore) SaveUpdateTypes(ctx context.Context, updateTypes []string)
 	return r0
 }
 
+// SearchDeploymentLogEntries provides a mock function with given fields: ctx, query
+func (_m *DataStore) SearchDeploymentLogEntries(ctx context.Context, query store.DeploymentLogQuery) ([]model.DeploymentLogEntry, int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchDeploymentLogEntries")
+	}
+
+	var r0 []model.DeploymentLogEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) ([]model.DeploymentLogEntry, int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.DeploymentLogQuery) []model.DeploymentLogEntry); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DeploymentLogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.DeploymentLogQuery) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, store.DeploymentLogQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SetDeploymentDeviceCount provides a mock function with given fields: ctx, deploymentID, count
 func (_m *DataStore) SetDeploymentDeviceCount(ctx context.Context, deploymentID string, count int) error {
 	ret := _m.Called(ctx, deploymentID, count)
@@ -1639,6 +2238,78 @@ func (_m *DataStore) SetDeploymentStatus(ctx context.Context, id string, status
 	return r0
 }
 
+// SetReleaseChannel provides a mock function with given fields: ctx, releaseName, channel
+func (_m *DataStore) SetReleaseChannel(ctx context.Context, releaseName string, channel string) error {
+	ret := _m.Called(ctx, releaseName, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReleaseChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, releaseName, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetReleasePromotion provides a mock function with given fields: ctx, releaseName, promotion
+func (_m *DataStore) SetReleasePromotion(ctx context.Context, releaseName string, promotion *model.ReleasePromotion) error {
+	ret := _m.Called(ctx, releaseName, promotion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReleasePromotion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.ReleasePromotion) error); ok {
+		r0 = rf(ctx, releaseName, promotion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetRetentionPolicy provides a mock function with given fields: ctx, policy
+func (_m *DataStore) SetRetentionPolicy(ctx context.Context, policy *model.RetentionPolicy) error {
+	ret := _m.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRetentionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RetentionPolicy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSignatureSettings provides a mock function with given fields: ctx, settings
+func (_m *DataStore) SetSignatureSettings(ctx context.Context, settings *model.SignatureSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSignatureSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SignatureSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetStorageSettings provides a mock function with given fields: ctx, storageSettings
 func (_m *DataStore) SetStorageSettings(ctx context.Context, storageSettings *model.StorageSettings) error {
 	ret := _m.Called(ctx, storageSettings)
@@ -1749,6 +2420,24 @@ func (_m *DataStore) UpdateDeviceDeploymentStatus(ctx context.Context, deviceID
 	return r0, r1
 }
 
+// UpdateMaintenanceWindow provides a mock function with given fields: ctx, window
+func (_m *DataStore) UpdateMaintenanceWindow(ctx context.Context, window *model.MaintenanceWindow) error {
+	ret := _m.Called(ctx, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMaintenanceWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MaintenanceWindow) error); ok {
+		r0 = rf(ctx, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRelease provides a mock function with given fields: ctx, releaseName, release
 func (_m *DataStore) UpdateRelease(ctx context.Context, releaseName string, release model.ReleasePatch) error {
 	ret := _m.Called(ctx, releaseName, release)
@@ -1803,6 +2492,24 @@ func (_m *DataStore) UpdateReleaseArtifacts(ctx context.Context, artifactToAdd *
 	return r0
 }
 
+// UpdateReleaseChannel provides a mock function with given fields: ctx, channel
+func (_m *DataStore) UpdateReleaseChannel(ctx context.Context, channel *model.ReleaseChannel) error {
+	ret := _m.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReleaseChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReleaseChannel) error); ok {
+		r0 = rf(ctx, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateStats provides a mock function with given fields: ctx, id, stats
 func (_m *DataStore) UpdateStats(ctx context.Context, id string, stats model.Stats) error {
 	ret := _m.Called(ctx, id, stats)
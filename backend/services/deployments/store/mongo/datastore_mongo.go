@@ -370,6 +370,8 @@ const (
 	StorageKeyImageDescription = "meta.description"
 	StorageKeyImageModified    = "modified"
 
+	StorageKeyImageDependsArtifactName = "meta_artifact.depends.artifact_name"
+
 	// releases
 	StorageKeyReleaseName                      = "_id"
 	StorageKeyReleaseModified                  = "modified"
@@ -412,6 +414,7 @@ const (
 	StorageKeyDeviceDeploymentArtifact       = "image"
 	StorageKeyDeviceDeploymentRequest        = "request"
 	StorageKeyDeviceDeploymentDeleted        = "deleted"
+	StorageKeyDeviceDeploymentStatusHistory  = "status_history"
 
 	StorageKeyDeploymentName                = "deploymentconstructor.name"
 	StorageKeyDeploymentArtifactName        = "deploymentconstructor.artifactname"
@@ -745,6 +748,9 @@ func (db *DataStoreMongo) getReleases_1_2_15(
 		if filt.UpdateType != "" {
 			filter[StorageKeyReleaseArtifactsUpdateTypes] = filt.UpdateType
 		}
+		if filt.Channel != "" {
+			filter[StorageKeyReleaseChannel] = filt.Channel
+		}
 	}
 	releases := []model.Release{}
 	cursor, err := collReleases.Find(ctx, filter, opts)
@@ -793,6 +799,18 @@ func (db *DataStoreMongo) ProvisionTenant(ctx context.Context, tenantId string)
 	return MigrateSingle(ctx, dbname, DbVersion, db.client, true)
 }
 
+// DeleteTenant drops the database of the tenant.
+func (db *DataStoreMongo) DeleteTenant(ctx context.Context, tenantId string) error {
+	if tenantId == "" {
+		return errors.New("tenant ID is required")
+	}
+	dbname := mstore.DbNameForTenant(tenantId, DbName)
+	if err := db.client.Database(dbname).Drop(ctx); err != nil {
+		return errors.Wrapf(err, "failed to drop database %s", dbname)
+	}
+	return nil
+}
+
 //images
 
 // Exists checks if object with ID exists
@@ -884,8 +902,11 @@ func (db *DataStoreMongo) ImageByNameAndDeviceType(ctx context.Context,
 }
 
 // ImageByIdsAndDeviceType finds image with id from ids and target device type
+// which can be installed on top of the currently installed artifactName:
+// images depending on a different artifact name (e.g. delta artifacts
+// generated for another source) are skipped.
 func (db *DataStoreMongo) ImageByIdsAndDeviceType(ctx context.Context,
-	ids []string, deviceType string) (*model.Image, error) {
+	ids []string, deviceType, artifactName string) (*model.Image, error) {
 
 	if len(deviceType) == 0 {
 		return nil, ErrImagesStorageInvalidDeviceType
@@ -898,12 +919,19 @@ func (db *DataStoreMongo) ImageByIdsAndDeviceType(ctx context.Context,
 	query := bson.D{
 		{Key: StorageKeyId, Value: bson.M{"$in": ids}},
 		{Key: StorageKeyImageDeviceTypes, Value: deviceType},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: StorageKeyImageDependsArtifactName, Value: bson.D{
+				{Key: "$exists", Value: false},
+			}}},
+			bson.D{{Key: StorageKeyImageDependsArtifactName, Value: artifactName}},
+		}},
 	}
 
 	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
 	collImg := database.Collection(CollectionImages)
 
-	// If multiple entries matches, pick the smallest one
+	// If multiple entries matches, pick the smallest one: a delta
+	// artifact matching the installed artifact wins over the full one
 	findOpts := mopts.FindOne()
 	findOpts.SetSort(bson.D{{Key: StorageKeyImageSize, Value: 1}})
 
@@ -1424,6 +1452,23 @@ func (db *DataStoreMongo) DeleteImagesByNames(ctx context.Context, names []strin
 	return err
 }
 
+// ListImageIDs returns the IDs of all the images
+func (db *DataStoreMongo) ListImageIDs(ctx context.Context) ([]string, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collImages := database.Collection(CollectionImages)
+	res, err := collImages.Distinct(ctx, "_id", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(res))
+	for _, id := range res {
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
+		}
+	}
+	return ids, nil
+}
+
 // device deployment log
 func (db *DataStoreMongo) SaveDeviceDeploymentLog(ctx context.Context,
 	log model.DeploymentLog) error {
@@ -1689,14 +1734,22 @@ func (db *DataStoreMongo) UpdateDeviceDeploymentStatus(
 		set[StorageKeyDeviceDeploymentSubState] = ddState.SubState
 	}
 
+	now := time.Now().UTC()
 	if currentStatus == model.DeviceDeploymentStatusPending &&
 		ddState.Status != currentStatus {
-		startedTime := time.Now().UTC()
-		set[StorageKeyDeviceDeploymentStarted] = startedTime
+		set[StorageKeyDeviceDeploymentStarted] = now
 	}
 
+	// record the status change for the device deployment timeline
 	update := bson.D{
 		{Key: "$set", Value: set},
+		{Key: "$push", Value: bson.M{
+			StorageKeyDeviceDeploymentStatusHistory: model.DeviceDeploymentStatusChange{
+				Status:    ddState.Status,
+				SubState:  ddState.SubState,
+				Timestamp: now,
+			},
+		}},
 	}
 
 	var old model.DeviceDeployment
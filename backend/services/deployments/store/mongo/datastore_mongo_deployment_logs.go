@@ -0,0 +1,297 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+const (
+	CollectionDeviceDeploymentLogEntries = "devices.logs.entries"
+
+	StorageKeyDeploymentLogEntryTimestamp = "timestamp"
+	StorageKeyDeploymentLogEntryLevel     = "level"
+	StorageKeyDeploymentLogEntryMessage   = "message"
+
+	// maxDeploymentLogClusterDevices is the maximum number of devices
+	// listed in a cluster of log entries
+	maxDeploymentLogClusterDevices = 10
+)
+
+var (
+	// 1.2.20
+	IndexNameDeploymentLogEntries = "deployment_device"
+	IndexDeploymentLogEntries     = mongo.IndexModel{
+		Keys: bson.D{
+			{Key: StorageKeyDeviceDeploymentDeploymentID, Value: 1},
+			{Key: StorageKeyDeviceDeploymentDeviceId, Value: 1},
+			{Key: StorageKeyDeploymentLogEntryTimestamp, Value: -1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameDeploymentLogEntries),
+	}
+	IndexNameDeploymentLogEntriesLevel = "level_timestamp"
+	IndexDeploymentLogEntriesLevel     = mongo.IndexModel{
+		Keys: bson.D{
+			{Key: StorageKeyDeploymentLogEntryLevel, Value: 1},
+			{Key: StorageKeyDeploymentLogEntryTimestamp, Value: -1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameDeploymentLogEntriesLevel),
+	}
+)
+
+// ReplaceDeploymentLogEntries replaces the indexed log entries of the device
+// deployment.
+func (db *DataStoreMongo) ReplaceDeploymentLogEntries(
+	ctx context.Context,
+	deviceID, deploymentID string,
+	entries []model.DeploymentLogEntry,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDeviceDeploymentLogEntries)
+
+	_, err := collection.DeleteMany(ctx, bson.D{
+		{Key: StorageKeyDeviceDeploymentDeviceId, Value: deviceID},
+		{Key: StorageKeyDeviceDeploymentDeploymentID, Value: deploymentID},
+	})
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	docs := make([]interface{}, len(entries))
+	for i := range entries {
+		entries[i].DeviceID = deviceID
+		entries[i].DeploymentID = deploymentID
+		docs[i] = entries[i]
+	}
+	_, err = collection.InsertMany(ctx, docs, mopts.InsertMany().SetOrdered(false))
+	return err
+}
+
+func deploymentLogQueryFilter(query store.DeploymentLogQuery) bson.D {
+	filter := bson.D{}
+	if query.DeploymentID != "" {
+		filter = append(filter, bson.E{
+			Key: StorageKeyDeviceDeploymentDeploymentID, Value: query.DeploymentID,
+		})
+	}
+	if query.DeviceID != "" {
+		filter = append(filter, bson.E{
+			Key: StorageKeyDeviceDeploymentDeviceId, Value: query.DeviceID,
+		})
+	}
+	if len(query.Levels) > 0 {
+		filter = append(filter, bson.E{
+			Key: StorageKeyDeploymentLogEntryLevel, Value: bson.D{
+				{Key: "$in", Value: query.Levels},
+			},
+		})
+	}
+	if query.Text != "" {
+		filter = append(filter, bson.E{
+			Key: StorageKeyDeploymentLogEntryMessage, Value: bson.D{
+				{Key: "$regex", Value: regexp.QuoteMeta(query.Text)},
+				{Key: "$options", Value: "i"},
+			},
+		})
+	}
+	return filter
+}
+
+// SearchDeploymentLogEntries returns the log entries matching the query,
+// latest first, and the total number of matching entries.
+func (db *DataStoreMongo) SearchDeploymentLogEntries(
+	ctx context.Context,
+	query store.DeploymentLogQuery,
+) ([]model.DeploymentLogEntry, int, error) {
+	if err := query.Validate(); err != nil {
+		return nil, 0, err
+	}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDeviceDeploymentLogEntries)
+
+	filter := deploymentLogQueryFilter(query)
+	findOpts := mopts.Find().
+		SetSort(bson.D{
+			{Key: StorageKeyDeploymentLogEntryTimestamp, Value: -1},
+			{Key: StorageKeyId, Value: 1},
+		}).
+		SetProjection(bson.D{{Key: StorageKeyId, Value: 0}}).
+		SetSkip(int64(query.Skip)).
+		SetLimit(int64(query.Limit))
+	cursor, err := collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries := []model.DeploymentLogEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, int(count), nil
+}
+
+// ClusterDeploymentLogEntries groups the log entries matching the query by
+// level and message, and returns the clusters logged by the most devices
+// first.
+func (db *DataStoreMongo) ClusterDeploymentLogEntries(
+	ctx context.Context,
+	query store.DeploymentLogQuery,
+) (*model.DeploymentLogClusters, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDeviceDeploymentLogEntries)
+	filter := deploymentLogQueryFilter(query)
+	aggregateOpts := mopts.Aggregate().SetAllowDiskUse(true)
+
+	// Group by message and device first, so that counting the devices
+	// of a cluster does not collect the devices of the whole fleet.
+	pipeline := []bson.D{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "level", Value: "$" + StorageKeyDeploymentLogEntryLevel},
+				{Key: "message", Value: "$" + StorageKeyDeploymentLogEntryMessage},
+				{Key: "device", Value: "$" + StorageKeyDeviceDeploymentDeviceId},
+			}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "first_seen", Value: bson.D{
+				{Key: "$min", Value: "$" + StorageKeyDeploymentLogEntryTimestamp},
+			}},
+			{Key: "last_seen", Value: bson.D{
+				{Key: "$max", Value: "$" + StorageKeyDeploymentLogEntryTimestamp},
+			}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "level", Value: "$_id.level"},
+				{Key: "message", Value: "$_id.message"},
+			}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: "$count"}}},
+			{Key: "device_count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "first_seen", Value: bson.D{{Key: "$min", Value: "$first_seen"}}},
+			{Key: "last_seen", Value: bson.D{{Key: "$max", Value: "$last_seen"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "device_count", Value: -1},
+			{Key: "count", Value: -1},
+			{Key: "_id.message", Value: 1},
+		}}},
+		{{Key: "$skip", Value: int64(query.Skip)}},
+		{{Key: "$limit", Value: int64(query.Limit)}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "level", Value: "$_id.level"},
+			{Key: "message", Value: "$_id.message"},
+			{Key: "count", Value: 1},
+			{Key: "device_count", Value: 1},
+			{Key: "first_seen", Value: 1},
+			{Key: "last_seen", Value: 1},
+		}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline, aggregateOpts)
+	if err != nil {
+		return nil, err
+	}
+	clusters := &model.DeploymentLogClusters{
+		Clusters: []model.DeploymentLogCluster{},
+	}
+	if err := cursor.All(ctx, &clusters.Clusters); err != nil {
+		return nil, err
+	}
+	for i := range clusters.Clusters {
+		cluster := &clusters.Clusters[i]
+		cluster.Devices, err = deploymentLogClusterDevices(
+			ctx, collection, filter, cluster,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cursor, err = collection.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + StorageKeyDeviceDeploymentDeviceId},
+		}}},
+		{{Key: "$count", Value: "count"}},
+	}, aggregateOpts)
+	if err != nil {
+		return nil, err
+	}
+	var devices []struct {
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, err
+	}
+	if len(devices) > 0 {
+		clusters.DeviceCount = devices[0].Count
+	}
+	return clusters, nil
+}
+
+// deploymentLogClusterDevices returns a sample of the devices which logged
+// the message of the cluster.
+func deploymentLogClusterDevices(
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.D,
+	cluster *model.DeploymentLogCluster,
+) ([]string, error) {
+	// the query may already filter on the level and the message
+	clusterFilter := bson.D{{Key: "$and", Value: bson.A{filter, bson.D{
+		{Key: StorageKeyDeploymentLogEntryLevel, Value: cluster.Level},
+		{Key: StorageKeyDeploymentLogEntryMessage, Value: cluster.Message},
+	}}}}
+	cursor, err := collection.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: clusterFilter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + StorageKeyDeviceDeploymentDeviceId},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: maxDeploymentLogClusterDevices}},
+	}, mopts.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	devices := make([]string, len(results))
+	for i, result := range results {
+		devices[i] = result.ID
+	}
+	return devices, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+func TestDeploymentLogEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestDeploymentLogEntries in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	entry := func(ts time.Time, level, message string) model.DeploymentLogEntry {
+		return model.DeploymentLogEntry{
+			Timestamp: ts,
+			Level:     level,
+			Message:   message,
+		}
+	}
+	err := ds.ReplaceDeploymentLogEntries(ctx, "device-1", "deployment-1",
+		[]model.DeploymentLogEntry{
+			entry(now.Add(-time.Minute), model.LogLevelInfo, "installing"),
+			entry(now, model.LogLevelError, "No space left on device"),
+		})
+	require.NoError(t, err)
+	err = ds.ReplaceDeploymentLogEntries(ctx, "device-2", "deployment-1",
+		[]model.DeploymentLogEntry{
+			entry(now.Add(-time.Hour), model.LogLevelError, "stale"),
+		})
+	require.NoError(t, err)
+	// the new upload replaces the previous entries of the device
+	err = ds.ReplaceDeploymentLogEntries(ctx, "device-2", "deployment-1",
+		[]model.DeploymentLogEntry{
+			entry(now.Add(-time.Second), model.LogLevelError, "No space left on device"),
+			entry(now.Add(-2*time.Second), model.LogLevelError, "No space left on device"),
+			entry(now.Add(-3*time.Second), model.LogLevelFatal, "rollback failed"),
+		})
+	require.NoError(t, err)
+	err = ds.ReplaceDeploymentLogEntries(ctx, "device-1", "deployment-2",
+		[]model.DeploymentLogEntry{
+			entry(now, model.LogLevelError, "No space left on device"),
+		})
+	require.NoError(t, err)
+
+	entries, count, err := ds.SearchDeploymentLogEntries(ctx, store.DeploymentLogQuery{
+		DeploymentID: "deployment-1",
+		Levels:       []string{model.LogLevelError},
+		Text:         "no SPACE",
+		Limit:        1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []model.DeploymentLogEntry{{
+		DeviceID:     "device-1",
+		DeploymentID: "deployment-1",
+		Timestamp:    now,
+		Level:        model.LogLevelError,
+		Message:      "No space left on device",
+	}}, entries)
+
+	entries, count, err = ds.SearchDeploymentLogEntries(ctx, store.DeploymentLogQuery{
+		DeploymentID: "deployment-1",
+		Text:         "stale",
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, entries)
+
+	clusters, err := ds.ClusterDeploymentLogEntries(ctx, store.DeploymentLogQuery{
+		DeploymentID: "deployment-1",
+		Levels:       model.LogErrorLevels,
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, clusters.DeviceCount)
+	if assert.Len(t, clusters.Clusters, 2) {
+		cluster := clusters.Clusters[0]
+		assert.Equal(t, "No space left on device", cluster.Message)
+		assert.Equal(t, model.LogLevelError, cluster.Level)
+		assert.Equal(t, 3, cluster.Count)
+		assert.Equal(t, 2, cluster.DeviceCount)
+		assert.ElementsMatch(t, []string{"device-1", "device-2"}, cluster.Devices)
+		assert.Equal(t, now.Add(-2*time.Second), cluster.FirstSeen)
+		assert.Equal(t, now, cluster.LastSeen)
+
+		assert.Equal(t, "rollback failed", clusters.Clusters[1].Message)
+		assert.Equal(t, 1, clusters.Clusters[1].DeviceCount)
+	}
+
+	// the clusters list a sample of the devices
+	for i := 0; i < maxDeploymentLogClusterDevices+2; i++ {
+		err = ds.ReplaceDeploymentLogEntries(ctx, fmt.Sprintf("device-%02d", i),
+			"deployment-4", []model.DeploymentLogEntry{
+				entry(now, model.LogLevelError, "No space left on device"),
+			})
+		require.NoError(t, err)
+	}
+	clusters, err = ds.ClusterDeploymentLogEntries(ctx, store.DeploymentLogQuery{
+		DeploymentID: "deployment-4",
+		Levels:       model.LogErrorLevels,
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, maxDeploymentLogClusterDevices+2, clusters.DeviceCount)
+	if assert.Len(t, clusters.Clusters, 1) {
+		assert.Equal(t, maxDeploymentLogClusterDevices+2, clusters.Clusters[0].DeviceCount)
+		assert.Len(t, clusters.Clusters[0].Devices, maxDeploymentLogClusterDevices)
+		assert.Equal(t, "device-00", clusters.Clusters[0].Devices[0])
+	}
+
+	clusters, err = ds.ClusterDeploymentLogEntries(ctx, store.DeploymentLogQuery{
+		DeploymentID: "deployment-3",
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &model.DeploymentLogClusters{
+		Clusters: []model.DeploymentLogCluster{},
+	}, clusters)
+}
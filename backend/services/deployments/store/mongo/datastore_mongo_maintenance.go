@@ -0,0 +1,129 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+const (
+	CollectionMaintenanceWindows = "maintenance_windows"
+)
+
+var (
+	ErrMaintenanceWindowConflict = errors.New(
+		"a maintenance window with the same name already exists",
+	)
+)
+
+// InsertMaintenanceWindow stores a new maintenance window.
+func (db *DataStoreMongo) InsertMaintenanceWindow(
+	ctx context.Context,
+	window *model.MaintenanceWindow,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionMaintenanceWindows)
+
+	if _, err := collection.InsertOne(ctx, window); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrMaintenanceWindowConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// GetMaintenanceWindows returns all the maintenance windows ordered by name.
+func (db *DataStoreMongo) GetMaintenanceWindows(
+	ctx context.Context,
+) ([]model.MaintenanceWindow, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionMaintenanceWindows)
+
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: StorageKeyId, Value: 1}})
+	cursor, err := collection.Find(ctx, bson.D{}, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	windows := []model.MaintenanceWindow{}
+	if err := cursor.All(ctx, &windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+// GetMaintenanceWindow returns the maintenance window with the given name.
+func (db *DataStoreMongo) GetMaintenanceWindow(
+	ctx context.Context,
+	name string,
+) (*model.MaintenanceWindow, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionMaintenanceWindows)
+
+	window := new(model.MaintenanceWindow)
+	err := collection.FindOne(ctx, bson.D{{Key: StorageKeyId, Value: name}}).
+		Decode(window)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// UpdateMaintenanceWindow replaces the maintenance window with the same name.
+func (db *DataStoreMongo) UpdateMaintenanceWindow(
+	ctx context.Context,
+	window *model.MaintenanceWindow,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionMaintenanceWindows)
+
+	res, err := collection.ReplaceOne(ctx,
+		bson.D{{Key: StorageKeyId, Value: window.Name}},
+		window,
+	)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteMaintenanceWindow removes the maintenance window with the given name.
+func (db *DataStoreMongo) DeleteMaintenanceWindow(ctx context.Context, name string) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionMaintenanceWindows)
+
+	res, err := collection.DeleteOne(ctx, bson.D{{Key: StorageKeyId, Value: name}})
+	if err != nil {
+		return err
+	} else if res.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestMaintenanceWindows in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant",
+	})
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	oslo := &model.MaintenanceWindow{
+		Name:     "oslo",
+		Group:    "oslo",
+		Days:     []string{"mon", "tue", "wed", "thu", "fri"},
+		Start:    "01:00",
+		End:      "04:00",
+		Timezone: "Europe/Oslo",
+		Created:  &now,
+	}
+	lab := &model.MaintenanceWindow{
+		Name:       "lab",
+		Tag:        &model.MaintenanceWindowTag{Name: "site", Value: "lab"},
+		Days:       []string{"sat"},
+		Start:      "22:00",
+		End:        "02:00",
+		GateReboot: true,
+		Created:    &now,
+	}
+	require.NoError(t, ds.InsertMaintenanceWindow(ctx, oslo))
+	require.NoError(t, ds.InsertMaintenanceWindow(ctx, lab))
+	assert.ErrorIs(t, ds.InsertMaintenanceWindow(ctx, lab), ErrMaintenanceWindowConflict)
+
+	windows, err := ds.GetMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.MaintenanceWindow{*lab, *oslo}, windows)
+
+	// windows are not visible to other tenants
+	windows, err = ds.GetMaintenanceWindows(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, windows)
+
+	oslo.End = "05:00"
+	require.NoError(t, ds.UpdateMaintenanceWindow(ctx, oslo))
+	window, err := ds.GetMaintenanceWindow(ctx, oslo.Name)
+	require.NoError(t, err)
+	assert.Equal(t, oslo, window)
+	assert.ErrorIs(t,
+		ds.UpdateMaintenanceWindow(ctx, &model.MaintenanceWindow{Name: "bergen"}),
+		store.ErrNotFound,
+	)
+
+	require.NoError(t, ds.DeleteMaintenanceWindow(ctx, lab.Name))
+	assert.ErrorIs(t, ds.DeleteMaintenanceWindow(ctx, lab.Name), store.ErrNotFound)
+	_, err = ds.GetMaintenanceWindow(ctx, lab.Name)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+const (
+	CollectionReleaseChannels = "release_channels"
+
+	StorageKeyReleaseChannelRank = "rank"
+
+	StorageKeyReleaseChannel            = "channel"
+	StorageKeyReleasePromotion          = "promotion"
+	StorageKeyReleasePromotionChannel   = StorageKeyReleasePromotion + ".channel"
+	StorageKeyReleasePromotionApprovals = StorageKeyReleasePromotion + ".approvals"
+	StorageKeyReleasePromotionApprover  = StorageKeyReleasePromotionApprovals + ".user_id"
+)
+
+var (
+	ErrReleaseChannelConflict = errors.New("a release channel with the same name already exists")
+)
+
+var (
+	// 1.2.19
+	IndexNameReleaseChannel = "release_channel"
+	IndexReleaseChannel     = mongo.IndexModel{
+		Keys: bson.D{
+			{Key: StorageKeyReleaseChannel, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameReleaseChannel).
+			SetSparse(true),
+	}
+)
+
+// InsertReleaseChannel stores a new release channel.
+func (db *DataStoreMongo) InsertReleaseChannel(
+	ctx context.Context,
+	channel *model.ReleaseChannel,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionReleaseChannels)
+
+	if _, err := collection.InsertOne(ctx, channel); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrReleaseChannelConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// GetReleaseChannels returns all the release channels ordered by rank.
+func (db *DataStoreMongo) GetReleaseChannels(
+	ctx context.Context,
+) ([]model.ReleaseChannel, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionReleaseChannels)
+
+	findOpts := mopts.Find().
+		SetSort(bson.D{
+			{Key: StorageKeyReleaseChannelRank, Value: 1},
+			{Key: StorageKeyId, Value: 1},
+		})
+	cursor, err := collection.Find(ctx, bson.D{}, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	channels := []model.ReleaseChannel{}
+	if err := cursor.All(ctx, &channels); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// GetReleaseChannel returns the release channel with the given name.
+func (db *DataStoreMongo) GetReleaseChannel(
+	ctx context.Context,
+	name string,
+) (*model.ReleaseChannel, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionReleaseChannels)
+
+	channel := new(model.ReleaseChannel)
+	err := collection.FindOne(ctx, bson.D{{Key: StorageKeyId, Value: name}}).
+		Decode(channel)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// UpdateReleaseChannel replaces the release channel with the same name.
+func (db *DataStoreMongo) UpdateReleaseChannel(
+	ctx context.Context,
+	channel *model.ReleaseChannel,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionReleaseChannels)
+
+	res, err := collection.ReplaceOne(ctx,
+		bson.D{{Key: StorageKeyId, Value: channel.Name}},
+		channel,
+	)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteReleaseChannel removes the release channel with the given name.
+func (db *DataStoreMongo) DeleteReleaseChannel(ctx context.Context, name string) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionReleaseChannels)
+
+	res, err := collection.DeleteOne(ctx, bson.D{{Key: StorageKeyId, Value: name}})
+	if err != nil {
+		return err
+	} else if res.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// CountReleasesInChannel returns the number of releases promoted to, or
+// pending promotion to, the given channel.
+func (db *DataStoreMongo) CountReleasesInChannel(
+	ctx context.Context,
+	channel string,
+) (int64, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collReleases := database.Collection(CollectionReleases)
+
+	return collReleases.CountDocuments(ctx, bson.D{{
+		Key: "$or", Value: bson.A{
+			bson.D{{Key: StorageKeyReleaseChannel, Value: channel}},
+			bson.D{{Key: StorageKeyReleasePromotionChannel, Value: channel}},
+		},
+	}})
+}
+
+// SetReleaseChannel moves the release to the given channel and discards
+// the pending promotion.
+func (db *DataStoreMongo) SetReleaseChannel(
+	ctx context.Context,
+	releaseName string,
+	channel string,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collReleases := database.Collection(CollectionReleases)
+
+	res, err := collReleases.UpdateOne(ctx,
+		bson.D{{Key: StorageKeyReleaseName, Value: releaseName}},
+		bson.D{
+			{Key: mongoOpSet, Value: bson.D{
+				{Key: StorageKeyReleaseChannel, Value: channel},
+				{Key: StorageKeyReleaseModified, Value: time.Now()},
+			}},
+			{Key: "$unset", Value: bson.D{
+				{Key: StorageKeyReleasePromotion, Value: ""},
+			}},
+		},
+	)
+	if err != nil {
+		return errors.WithMessage(err, "mongo: failed to update release channel")
+	} else if res.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// SetReleasePromotion sets the pending promotion of the release, or
+// discards it if promotion is nil.
+func (db *DataStoreMongo) SetReleasePromotion(
+	ctx context.Context,
+	releaseName string,
+	promotion *model.ReleasePromotion,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collReleases := database.Collection(CollectionReleases)
+
+	var update bson.D
+	if promotion != nil {
+		update = bson.D{{Key: mongoOpSet, Value: bson.D{
+			{Key: StorageKeyReleasePromotion, Value: promotion},
+		}}}
+	} else {
+		update = bson.D{{Key: "$unset", Value: bson.D{
+			{Key: StorageKeyReleasePromotion, Value: ""},
+		}}}
+	}
+	res, err := collReleases.UpdateOne(ctx,
+		bson.D{{Key: StorageKeyReleaseName, Value: releaseName}},
+		update,
+	)
+	if err != nil {
+		return errors.WithMessage(err, "mongo: failed to update release promotion")
+	} else if res.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// AddReleasePromotionApproval records the approval of the pending
+// promotion of the release to the given channel and returns the updated
+// promotion. It returns store.ErrNotFound if there is no such pending
+// promotion or if the user already approved it.
+func (db *DataStoreMongo) AddReleasePromotionApproval(
+	ctx context.Context,
+	releaseName string,
+	channel string,
+	approval model.PromotionApproval,
+) (*model.ReleasePromotion, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collReleases := database.Collection(CollectionReleases)
+
+	release := new(model.Release)
+	err := collReleases.FindOneAndUpdate(ctx,
+		bson.D{
+			{Key: StorageKeyReleaseName, Value: releaseName},
+			{Key: StorageKeyReleasePromotionChannel, Value: channel},
+			{Key: StorageKeyReleasePromotionApprover, Value: bson.D{
+				{Key: "$ne", Value: approval.UserID},
+			}},
+		},
+		bson.D{{Key: "$push", Value: bson.D{
+			{Key: StorageKeyReleasePromotionApprovals, Value: approval},
+		}}},
+		mopts.FindOneAndUpdate().
+			SetReturnDocument(mopts.After).
+			SetProjection(bson.D{{Key: StorageKeyReleasePromotion, Value: 1}}),
+	).Decode(release)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrNotFound
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "mongo: failed to approve release promotion")
+	}
+	return release.Promotion, nil
+}
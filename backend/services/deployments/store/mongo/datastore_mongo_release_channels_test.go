@@ -0,0 +1,212 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+func TestReleaseChannels(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestReleaseChannels in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant",
+	})
+	err := MigrateSingle(ctx, DbName+"-tenant", DbVersion, db.Client(), true)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	production := &model.ReleaseChannel{
+		Name:              "production",
+		Rank:              2,
+		RequiredApprovals: 2,
+		Groups:            []string{"fleet"},
+		Created:           &now,
+	}
+	dev := &model.ReleaseChannel{
+		Name:    "dev",
+		Rank:    0,
+		Groups:  []string{},
+		Created: &now,
+	}
+	require.NoError(t, ds.InsertReleaseChannel(ctx, production))
+	require.NoError(t, ds.InsertReleaseChannel(ctx, dev))
+	assert.ErrorIs(t, ds.InsertReleaseChannel(ctx, dev), ErrReleaseChannelConflict)
+
+	channels, err := ds.GetReleaseChannels(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ReleaseChannel{*dev, *production}, channels)
+
+	// channels are not visible to other tenants
+	channels, err = ds.GetReleaseChannels(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+
+	production.RequiredApprovals = 1
+	require.NoError(t, ds.UpdateReleaseChannel(ctx, production))
+	channel, err := ds.GetReleaseChannel(ctx, production.Name)
+	require.NoError(t, err)
+	assert.Equal(t, production, channel)
+	assert.ErrorIs(t,
+		ds.UpdateReleaseChannel(ctx, &model.ReleaseChannel{Name: "staging"}),
+		store.ErrNotFound,
+	)
+
+	require.NoError(t, ds.DeleteReleaseChannel(ctx, dev.Name))
+	assert.ErrorIs(t, ds.DeleteReleaseChannel(ctx, dev.Name), store.ErrNotFound)
+	_, err = ds.GetReleaseChannel(ctx, dev.Name)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestReleasePromotion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestReleasePromotion in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := context.Background()
+
+	img := &model.Image{
+		Id:        "6d4f6e27-c3bb-438c-ad9c-d9de30e59d80",
+		ImageMeta: &model.ImageMeta{},
+		ArtifactMeta: &model.ArtifactMeta{
+			Name:                  "App1 v1.0",
+			DeviceTypesCompatible: []string{"foo"},
+			Updates:               []model.Update{},
+		},
+		UploadedBy: "uploader",
+	}
+	require.NoError(t, ds.UpdateReleaseArtifacts(ctx, img, nil, img.ArtifactMeta.Name))
+
+	assert.ErrorIs(t,
+		ds.SetReleaseChannel(ctx, "App2 v1.0", "dev"),
+		store.ErrNotFound,
+	)
+	require.NoError(t, ds.SetReleaseChannel(ctx, img.ArtifactMeta.Name, "dev"))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	promotion := &model.ReleasePromotion{
+		Channel:     "production",
+		RequestedBy: "uploader",
+		Requested:   now,
+		Approvals:   []model.PromotionApproval{},
+	}
+	require.NoError(t, ds.SetReleasePromotion(ctx, img.ArtifactMeta.Name, promotion))
+
+	count, err := ds.CountReleasesInChannel(ctx, "production")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	approval := model.PromotionApproval{UserID: "approver", Approved: now}
+	updated, err := ds.AddReleasePromotionApproval(
+		ctx, img.ArtifactMeta.Name, "production", approval,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []model.PromotionApproval{approval}, updated.Approvals)
+
+	// the same user cannot approve twice
+	_, err = ds.AddReleasePromotionApproval(
+		ctx, img.ArtifactMeta.Name, "production", approval,
+	)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	// the promotion must target the same channel
+	_, err = ds.AddReleasePromotionApproval(
+		ctx, img.ArtifactMeta.Name, "staging",
+		model.PromotionApproval{UserID: "other", Approved: now},
+	)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	require.NoError(t, ds.SetReleaseChannel(ctx, img.ArtifactMeta.Name, "production"))
+	release, err := ds.GetRelease(ctx, img.ArtifactMeta.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "production", release.Channel)
+	assert.Nil(t, release.Promotion)
+	assert.Equal(t, []string{"uploader"}, release.Uploaders())
+
+	releases, _, err := ds.GetReleases(ctx, &model.ReleaseOrImageFilter{
+		Channel: "dev",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, releases)
+	releases, _, err = ds.GetReleases(ctx, &model.ReleaseOrImageFilter{
+		Channel: "production",
+	})
+	require.NoError(t, err)
+	assert.Len(t, releases, 1)
+}
+
+func TestReleaseChannelResetOnNewArtifact(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestReleaseChannelResetOnNewArtifact in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := context.Background()
+
+	image := func(id, deviceType string) *model.Image {
+		return &model.Image{
+			Id:        id,
+			ImageMeta: &model.ImageMeta{},
+			ArtifactMeta: &model.ArtifactMeta{
+				Name:                  "App1 v1.0",
+				DeviceTypesCompatible: []string{deviceType},
+				Updates:               []model.Update{},
+			},
+		}
+	}
+	first := image("6d4f6e27-c3bb-438c-ad9c-d9de30e59d80", "foo")
+	second := image("7e5a7f38-d4cc-449d-be0d-e0ef41f6ae91", "bar")
+
+	require.NoError(t, ds.UpdateReleaseArtifacts(ctx, first, nil, "App1 v1.0"))
+	require.NoError(t, ds.SetReleaseChannel(ctx, "App1 v1.0", "production"))
+	require.NoError(t, ds.SetReleasePromotion(ctx, "App1 v1.0",
+		&model.ReleasePromotion{Channel: "staging", Approvals: []model.PromotionApproval{}},
+	))
+
+	// removing an artifact keeps the channel
+	require.NoError(t, ds.UpdateReleaseArtifacts(ctx, second, nil, "App1 v1.0"))
+	require.NoError(t, ds.SetReleaseChannel(ctx, "App1 v1.0", "production"))
+	require.NoError(t, ds.UpdateReleaseArtifacts(ctx, nil, second, "App1 v1.0"))
+	release, err := ds.GetRelease(ctx, "App1 v1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "production", release.Channel)
+
+	// uploading a new artifact resets the channel and the promotion
+	require.NoError(t, ds.SetReleasePromotion(ctx, "App1 v1.0",
+		&model.ReleasePromotion{Channel: "lts", Approvals: []model.PromotionApproval{}},
+	))
+	require.NoError(t, ds.UpdateReleaseArtifacts(ctx, second, nil, "App1 v1.0"))
+	release, err = ds.GetRelease(ctx, "App1 v1.0")
+	require.NoError(t, err)
+	assert.Empty(t, release.Channel)
+	assert.Nil(t, release.Promotion)
+	assert.Equal(t, 2, release.ArtifactsCount)
+}
@@ -90,6 +90,12 @@ func (db *DataStoreMongo) UpdateReleaseArtifacts(
 		update["$inc"] = bson.M{
 			StorageKeyReleaseArtifactsCount: 1,
 		}
+		// the new artifact was not promoted with the release: the release
+		// goes back to no channel and has to be promoted again
+		update["$unset"] = bson.M{
+			StorageKeyReleaseChannel:   "",
+			StorageKeyReleasePromotion: "",
+		}
 	}
 	_, err := collReleases.UpdateOne(
 		ctx,
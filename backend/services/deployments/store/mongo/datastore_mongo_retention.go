@@ -0,0 +1,166 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+const (
+	StorageKeyRetentionPolicyID = "retention"
+)
+
+// GetRetentionPolicy returns the artifact retention policy,
+// or nil if it was never set.
+func (db *DataStoreMongo) GetRetentionPolicy(
+	ctx context.Context,
+) (*model.RetentionPolicy, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	policy := new(model.RetentionPolicy)
+	query := bson.D{{Key: StorageKeyId, Value: StorageKeyRetentionPolicyID}}
+	if err := collection.FindOne(ctx, query).Decode(policy); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SetRetentionPolicy stores the artifact retention policy.
+func (db *DataStoreMongo) SetRetentionPolicy(
+	ctx context.Context,
+	policy *model.RetentionPolicy,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	filter := bson.D{{Key: StorageKeyId, Value: StorageKeyRetentionPolicyID}}
+	_, err := collection.ReplaceOne(ctx, filter, policy,
+		mopts.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// GetInstalledArtifactIDs returns the IDs of the artifacts installed on the
+// devices: the artifact of the last successful deployment of each device.
+// Failed or skipped deployments leave the previous artifact installed.
+func (db *DataStoreMongo) GetInstalledArtifactIDs(ctx context.Context) ([]string, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDevicesLastStatus)
+
+	tenantID := ""
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: StorageKeyTenantId, Value: tenantID},
+		}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: CollectionDevices},
+			{Key: "let", Value: bson.D{{Key: "device_id", Value: "$" + StorageKeyId}}},
+			{Key: "pipeline", Value: mongo.Pipeline{
+				{{Key: "$match", Value: bson.D{
+					{Key: "$expr", Value: bson.D{
+						{Key: "$eq", Value: bson.A{
+							"$" + StorageKeyDeviceDeploymentDeviceId, "$$device_id",
+						}},
+					}},
+					{Key: StorageKeyDeviceDeploymentStatus,
+						Value: model.DeviceDeploymentStatusSuccess},
+				}}},
+				{{Key: "$sort", Value: bson.D{
+					{Key: StorageKeyDeviceDeploymentCreated, Value: -1},
+				}}},
+				{{Key: "$limit", Value: 1}},
+				{{Key: "$project", Value: bson.D{
+					{Key: StorageKeyDeviceDeploymentAssignedImageId, Value: 1},
+				}}},
+			}},
+			{Key: "as", Value: "device_deployment"},
+		}}},
+		{{Key: "$unwind", Value: "$device_deployment"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: StorageKeyId, Value: "$device_deployment." +
+				StorageKeyDeviceDeploymentAssignedImageId},
+		}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: StorageKeyId, Value: bson.D{{Key: "$ne", Value: nil}}},
+		}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	return ids, nil
+}
+
+// GetArtifactsLastUsed returns the creation time of the last deployment
+// of each artifact by artifact ID.
+func (db *DataStoreMongo) GetArtifactsLastUsed(
+	ctx context.Context,
+) (map[string]time.Time, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDeployments)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$" + StorageKeyDeploymentArtifacts}},
+		{{Key: "$group", Value: bson.D{
+			{Key: StorageKeyId, Value: "$" + StorageKeyDeploymentArtifacts},
+			{Key: "last_used", Value: bson.D{
+				{Key: "$max", Value: "$" + StorageKeyDeploymentCreated},
+			}},
+		}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		ID       string    `bson:"_id"`
+		LastUsed time.Time `bson:"last_used"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	lastUsed := make(map[string]time.Time, len(results))
+	for _, result := range results {
+		lastUsed[result.ID] = result.LastUsed
+	}
+	return lastUsed, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestRetentionPolicy in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant",
+	})
+
+	policy, err := ds.GetRetentionPolicy(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	expected := &model.RetentionPolicy{KeepReleases: 5, UnusedDays: 30}
+	require.NoError(t, ds.SetRetentionPolicy(ctx, expected))
+	policy, err = ds.GetRetentionPolicy(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expected, policy)
+
+	// the policy is not visible to other tenants
+	policy, err = ds.GetRetentionPolicy(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestRetentionArtifactUsage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestRetentionArtifactUsage in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant",
+	})
+	err := MigrateSingle(ctx, DbName+"-tenant", DbVersion, db.Client(), true)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	created := []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Hour)}
+	for _, c := range created {
+		deployment, err := model.NewDeploymentFromConstructor(&model.DeploymentConstructor{
+			Name:         "deployment",
+			ArtifactName: "artifact",
+			Devices:      []string{"device-1"},
+		})
+		require.NoError(t, err)
+		deployment.Created = &c
+		deployment.Artifacts = []string{"artifact-1", "artifact-2"}
+		require.NoError(t, ds.InsertDeployment(ctx, deployment))
+	}
+	lastUsed, err := ds.GetArtifactsLastUsed(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{
+		"artifact-1": created[1],
+		"artifact-2": created[1],
+	}, lastUsed)
+
+	// the deployments of each device, from the oldest to the last one
+	deviceDeployments := map[string][]struct {
+		artifactID string
+		status     model.DeviceDeploymentStatus
+	}{
+		// the failed update leaves artifact-1 installed
+		"device-1": {
+			{"artifact-1", model.DeviceDeploymentStatusSuccess},
+			{"artifact-2", model.DeviceDeploymentStatusFailure},
+		},
+		// no artifact was compatible: artifact-3 stays installed
+		"device-2": {
+			{"artifact-3", model.DeviceDeploymentStatusSuccess},
+			{"", model.DeviceDeploymentStatusNoArtifact},
+		},
+		// the device never installed an artifact through a deployment
+		"device-3": {
+			{"artifact-4", model.DeviceDeploymentStatusFailure},
+		},
+		"device-4": {
+			{"artifact-5", model.DeviceDeploymentStatusPending},
+		},
+	}
+	for device, deployments := range deviceDeployments {
+		for i, d := range deployments {
+			deviceDeployment := model.NewDeviceDeployment(device, "deployment")
+			c := now.Add(time.Duration(i-len(deployments)) * time.Hour)
+			deviceDeployment.Created = &c
+			deviceDeployment.Status = d.status
+			deviceDeployment.Active = d.status.Active()
+			if d.artifactID != "" {
+				deviceDeployment.Image = &model.Image{Id: d.artifactID}
+			}
+			require.NoError(t, ds.InsertDeviceDeployment(ctx, deviceDeployment, false))
+			require.NoError(t, ds.SaveLastDeviceDeploymentStatus(ctx, *deviceDeployment))
+		}
+	}
+	installed, err := ds.GetInstalledArtifactIDs(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"artifact-1", "artifact-3"}, installed)
+
+	installed, err = ds.GetInstalledArtifactIDs(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, installed)
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+// GetLastSuccessfulDeviceDeployments returns, for each of the devices, the
+// latest device deployment created before the given time which finished
+// successfully.
+func (db *DataStoreMongo) GetLastSuccessfulDeviceDeployments(
+	ctx context.Context,
+	deviceIDs []string,
+	before time.Time,
+) ([]model.DeviceDeployment, error) {
+	if len(deviceIDs) == 0 {
+		return []model.DeviceDeployment{}, nil
+	}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDevs := database.Collection(CollectionDevices)
+
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.D{
+			{Key: StorageKeyDeviceDeploymentDeviceId, Value: bson.D{
+				{Key: "$in", Value: deviceIDs},
+			}},
+			{Key: StorageKeyDeviceDeploymentStatus,
+				Value: model.DeviceDeploymentStatusSuccess},
+			{Key: StorageKeyDeviceDeploymentCreated, Value: bson.D{
+				{Key: "$lt", Value: before},
+			}},
+			{Key: StorageKeyDeviceDeploymentDeleted, Value: bson.D{
+				{Key: "$exists", Value: false},
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: StorageKeyDeviceDeploymentCreated, Value: -1},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + StorageKeyDeviceDeploymentDeviceId},
+			{Key: "last", Value: bson.D{{Key: "$first", Value: "$$ROOT"}}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$last"}}}},
+	}
+	cursor, err := collDevs.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	deviceDeployments := []model.DeviceDeployment{}
+	if err := cursor.All(ctx, &deviceDeployments); err != nil {
+		return nil, err
+	}
+	return deviceDeployments, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func TestGetLastSuccessfulDeviceDeployments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestGetLastSuccessfulDeviceDeployments in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	deviceDeployment := func(
+		device string,
+		status model.DeviceDeploymentStatus,
+		created time.Time,
+		artifact string,
+	) *model.DeviceDeployment {
+		dd := model.NewDeviceDeployment(device, "a0a0a0a0-0000-4000-8000-000000000000")
+		dd.Status = status
+		dd.Active = status.Active()
+		dd.Created = &created
+		dd.Image = &model.Image{
+			Id:           "img-" + artifact,
+			ArtifactMeta: &model.ArtifactMeta{Name: artifact},
+		}
+		return dd
+	}
+	err := ds.InsertMany(ctx,
+		deviceDeployment("device-1", model.DeviceDeploymentStatusSuccess,
+			now.Add(-3*time.Hour), "v1"),
+		deviceDeployment("device-1", model.DeviceDeploymentStatusSuccess,
+			now.Add(-2*time.Hour), "v2"),
+		deviceDeployment("device-1", model.DeviceDeploymentStatusFailure,
+			now.Add(-time.Hour), "v3"),
+		deviceDeployment("device-1", model.DeviceDeploymentStatusSuccess,
+			now.Add(time.Hour), "v4"),
+		deviceDeployment("device-2", model.DeviceDeploymentStatusFailure,
+			now.Add(-time.Hour), "v1"),
+		deviceDeployment("device-3", model.DeviceDeploymentStatusSuccess,
+			now.Add(-time.Hour), "v1"),
+	)
+	require.NoError(t, err)
+
+	deviceDeployments, err := ds.GetLastSuccessfulDeviceDeployments(ctx,
+		[]string{"device-1", "device-2"}, now)
+	require.NoError(t, err)
+	if assert.Len(t, deviceDeployments, 1) {
+		assert.Equal(t, "device-1", deviceDeployments[0].DeviceId)
+		assert.Equal(t, "v2", deviceDeployments[0].Image.ArtifactMeta.Name)
+	}
+
+	deviceDeployments, err = ds.GetLastSuccessfulDeviceDeployments(ctx, nil, now)
+	require.NoError(t, err)
+	assert.Empty(t, deviceDeployments)
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+const (
+	CollectionSigningKeys = "signing_keys"
+
+	StorageKeySigningKeyFingerprint = "fingerprint"
+	StorageKeySigningKeyCreated     = "created"
+
+	StorageKeySignatureSettingsID = "signatures"
+)
+
+var (
+	ErrSigningKeyConflict = errors.New("a signing key with the same fingerprint already exists")
+)
+
+var (
+	// 1.2.18
+	IndexNameSigningKeyFingerprint = "signing_key_fingerprint"
+	IndexSigningKeyFingerprint     = mongo.IndexModel{
+		Keys: bson.D{
+			{Key: StorageKeySigningKeyFingerprint, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameSigningKeyFingerprint).
+			SetUnique(true),
+	}
+)
+
+// InsertSigningKey stores a new signing key.
+func (db *DataStoreMongo) InsertSigningKey(ctx context.Context, key *model.SigningKey) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionSigningKeys)
+
+	if _, err := collection.InsertOne(ctx, key); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrSigningKeyConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// GetSigningKeys returns all the signing keys, oldest first.
+func (db *DataStoreMongo) GetSigningKeys(ctx context.Context) ([]model.SigningKey, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionSigningKeys)
+
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: StorageKeySigningKeyCreated, Value: 1}})
+	cursor, err := collection.Find(ctx, bson.D{}, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	keys := []model.SigningKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// DeleteSigningKey removes the signing key with the given ID.
+func (db *DataStoreMongo) DeleteSigningKey(ctx context.Context, id string) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionSigningKeys)
+
+	res, err := collection.DeleteOne(ctx, bson.D{{Key: StorageKeyId, Value: id}})
+	if err != nil {
+		return err
+	} else if res.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// GetSignatureSettings returns the artifact signature settings,
+// or nil if they were never set.
+func (db *DataStoreMongo) GetSignatureSettings(
+	ctx context.Context,
+) (*model.SignatureSettings, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	settings := new(model.SignatureSettings)
+	query := bson.D{{Key: StorageKeyId, Value: StorageKeySignatureSettingsID}}
+	if err := collection.FindOne(ctx, query).Decode(settings); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetSignatureSettings stores the artifact signature settings.
+func (db *DataStoreMongo) SetSignatureSettings(
+	ctx context.Context,
+	settings *model.SignatureSettings,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	filter := bson.D{{Key: StorageKeyId, Value: StorageKeySignatureSettingsID}}
+	_, err := collection.ReplaceOne(ctx, filter, settings,
+		mopts.Replace().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+func TestSigningKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestSigningKeys in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "tenant",
+	})
+	err := MigrateSingle(ctx, DbName+"-tenant", DbVersion, db.Client(), true)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	first := &model.SigningKey{
+		ID:          "ef2a2ca8-57fd-4c1b-9b87-9fed5d32ba63",
+		Name:        "first",
+		Type:        model.SigningKeyTypeEd25519,
+		Fingerprint: "aaaa",
+		PublicKey:   "PEM",
+		Created:     now.Add(-time.Minute),
+	}
+	second := &model.SigningKey{
+		ID:          "0e5a8a7f-3fe2-4e1e-a1f1-6e0cbd8aa1a4",
+		Name:        "second",
+		Type:        model.SigningKeyTypeRSA,
+		Fingerprint: "bbbb",
+		PublicKey:   "PEM",
+		Created:     now,
+	}
+	require.NoError(t, ds.InsertSigningKey(ctx, second))
+	require.NoError(t, ds.InsertSigningKey(ctx, first))
+
+	duplicate := *first
+	duplicate.ID = "4d8c3e0c-0b0e-4bd4-8bd4-7c6c4b6c2e2f"
+	assert.ErrorIs(t, ds.InsertSigningKey(ctx, &duplicate), ErrSigningKeyConflict)
+
+	keys, err := ds.GetSigningKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SigningKey{*first, *second}, keys)
+
+	// keys are not visible to other tenants
+	keys, err = ds.GetSigningKeys(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	require.NoError(t, ds.DeleteSigningKey(ctx, first.ID))
+	assert.ErrorIs(t, ds.DeleteSigningKey(ctx, first.ID), store.ErrNotFound)
+	keys, err = ds.GetSigningKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SigningKey{*second}, keys)
+}
+
+func TestSignatureSettings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestSignatureSettings in short mode.")
+	}
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	ctx := context.Background()
+
+	settings, err := ds.GetSignatureSettings(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+
+	// signature settings do not interfere with storage settings
+	require.NoError(t, ds.SetStorageSettings(ctx, &model.StorageSettings{
+		Bucket: "bucket",
+	}))
+
+	expected := &model.SignatureSettings{RequireSignature: true}
+	require.NoError(t, ds.SetSignatureSettings(ctx, expected))
+	settings, err = ds.GetSignatureSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expected, settings)
+
+	storageSettings, err := ds.GetStorageSettings(ctx)
+	require.NoError(t, err)
+	if assert.NotNil(t, storageSettings) {
+		assert.Equal(t, "bucket", storageSettings.Bucket)
+	}
+}
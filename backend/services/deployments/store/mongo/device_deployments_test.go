@@ -345,6 +345,14 @@ func TestUpdateDeviceDeploymentStatus(t *testing.T) {
 					if testCase.InputSubState != "" {
 						assert.Equal(t, testCase.InputSubState, deployment.SubState)
 					}
+
+					// the status change is recorded in the history
+					if assert.NotEmpty(t, deployment.StatusHistory) {
+						change := deployment.StatusHistory[len(deployment.StatusHistory)-1]
+						assert.Equal(t, testCase.InputStatus, change.Status)
+						assert.Equal(t, testCase.InputSubState, change.SubState)
+						assert.WithinDuration(t, time.Now(), change.Timestamp, time.Minute)
+					}
 				}
 			}
 		})
@@ -186,6 +186,131 @@ func TestImagesStorageImageByNameAndDeviceType(t *testing.T) {
 	}
 }
 
+func TestImagesStorageImageByIdsAndDeviceType(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestImagesStorageImageByIdsAndDeviceType in short mode.")
+	}
+	newID := func() string {
+		val, _ := uuid.NewRandom()
+		return val.String()
+	}
+
+	//image dataset - full artifact and deltas from two different sources
+	inputImgs := []*model.Image{
+		{
+			Id:        newID(),
+			ImageMeta: &model.ImageMeta{},
+			ArtifactMeta: &model.ArtifactMeta{
+				Name:                  "rootfs v2",
+				DeviceTypesCompatible: []string{"foo"},
+				Updates:               []model.Update{},
+			},
+			Size: 1000,
+		},
+		{
+			Id:        newID(),
+			ImageMeta: &model.ImageMeta{},
+			ArtifactMeta: &model.ArtifactMeta{
+				Name:                  "rootfs v2",
+				DeviceTypesCompatible: []string{"foo"},
+				Updates:               []model.Update{},
+				Depends: map[string]interface{}{
+					model.ArtifactDependsArtifactName: []string{"rootfs v1"},
+				},
+			},
+			Size: 10,
+		},
+		{
+			Id:        newID(),
+			ImageMeta: &model.ImageMeta{},
+			ArtifactMeta: &model.ArtifactMeta{
+				Name:                  "rootfs v2",
+				DeviceTypesCompatible: []string{"foo"},
+				Updates:               []model.Update{},
+				Depends: map[string]interface{}{
+					model.ArtifactDependsArtifactName: []string{"rootfs v0"},
+				},
+			},
+			Size: 1,
+		},
+	}
+	ids := make([]string, len(inputImgs))
+
+	ctx := context.Background()
+	db.Wipe()
+	store := NewDataStoreMongoWithClient(db.Client())
+	for i, image := range inputImgs {
+		err := store.InsertImage(ctx, image)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		ids[i] = image.Id
+	}
+
+	testCases := map[string]struct {
+		IDs          []string
+		DeviceType   string
+		ArtifactName string
+
+		OutputImageID string
+		OutputError   error
+	}{
+		"delta matching the installed artifact": {
+			IDs:          ids,
+			DeviceType:   "foo",
+			ArtifactName: "rootfs v1",
+
+			OutputImageID: inputImgs[1].Id,
+		},
+		"full artifact when no delta matches": {
+			IDs:          ids,
+			DeviceType:   "foo",
+			ArtifactName: "rootfs v3",
+
+			OutputImageID: inputImgs[0].Id,
+		},
+		"no full artifact in the deployment": {
+			IDs:          ids[1:],
+			DeviceType:   "foo",
+			ArtifactName: "rootfs v3",
+		},
+		"dev type incompatible": {
+			IDs:          ids,
+			DeviceType:   "bar",
+			ArtifactName: "rootfs v1",
+		},
+		"dev type validation error": {
+			IDs:          ids,
+			ArtifactName: "rootfs v1",
+
+			OutputError: ErrImagesStorageInvalidDeviceType,
+		},
+		"ids validation error": {
+			DeviceType:   "foo",
+			ArtifactName: "rootfs v1",
+
+			OutputError: ErrImagesStorageInvalidID,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			img, err := store.ImageByIdsAndDeviceType(ctx,
+				tc.IDs, tc.DeviceType, tc.ArtifactName)
+
+			if tc.OutputError != nil {
+				assert.EqualError(t, err, tc.OutputError.Error())
+			} else if assert.NoError(t, err) {
+				if tc.OutputImageID == "" {
+					assert.Nil(t, img)
+				} else if assert.NotNil(t, img) {
+					assert.Equal(t, tc.OutputImageID, img.Id)
+				}
+			}
+		})
+	}
+}
+
 func TestIsArtifactUnique(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping TestIsArtifactUnique in short mode.")
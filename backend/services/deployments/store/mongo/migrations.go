@@ -25,7 +25,7 @@ import (
 )
 
 const (
-	DbVersion        = "1.2.17"
+	DbVersion        = "1.2.20"
 	DbMinimumVersion = "1.2.17"
 	DbName           = "deployment_service"
 )
@@ -143,6 +143,18 @@ func MigrateSingle(ctx context.Context,
 			client: client,
 			db:     db,
 		},
+		&migration_1_2_18{
+			client: client,
+			db:     db,
+		},
+		&migration_1_2_19{
+			client: client,
+			db:     db,
+		},
+		&migration_1_2_20{
+			client: client,
+			db:     db,
+		},
 	}
 
 	err = m.Apply(ctx, *ver, migrations)
@@ -0,0 +1,49 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package store
+
+import (
+	"errors"
+)
+
+// MaxDeploymentLogQueryTextLength is the maximum length of the text
+// searched in the deployment logs.
+const MaxDeploymentLogQueryTextLength = 1024
+
+// DeploymentLogQuery selects the deployment log entries of a deployment,
+// or of all the deployments if DeploymentID is not set.
+type DeploymentLogQuery struct {
+	Skip         int
+	Limit        int
+	DeploymentID string
+	DeviceID     string
+	// Levels of the log entries, any level if not set
+	Levels []string
+	// Text is searched in the log messages, ignoring the case
+	Text string
+}
+
+func (q DeploymentLogQuery) Validate() error {
+	if q.Limit <= 0 {
+		return errors.New("limit: must be a positive integer")
+	}
+	if q.Skip < 0 {
+		return errors.New("skip: must be a non-negative integer")
+	}
+	if len(q.Text) > MaxDeploymentLogQueryTextLength {
+		return errors.New("q: the length must be no more than 1024")
+	}
+	return nil
+}
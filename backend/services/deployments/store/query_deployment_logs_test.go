@@ -0,0 +1,59 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentLogQueryValidate(t *testing.T) {
+	testCases := map[string]struct {
+		query DeploymentLogQuery
+		err   error
+	}{
+		"ok": {
+			query: DeploymentLogQuery{Limit: 20, Text: "no space left"},
+		},
+		"limit": {
+			query: DeploymentLogQuery{},
+			err:   errors.New("limit: must be a positive integer"),
+		},
+		"skip": {
+			query: DeploymentLogQuery{Limit: 1, Skip: -1},
+			err:   errors.New("skip: must be a non-negative integer"),
+		},
+		"text": {
+			query: DeploymentLogQuery{
+				Limit: 1,
+				Text:  strings.Repeat("a", MaxDeploymentLogQueryTextLength+1),
+			},
+			err: errors.New("q: the length must be no more than 1024"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.query.Validate()
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -562,6 +562,40 @@ func (i *DevAuthApiHandlers) GetLimitHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, LimitValue{lim.Value})
 }
 
+func (i *DevAuthApiHandlers) GetOfflineSettingsHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	settings, err := i.app.GetOfflineSettings(ctx)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (i *DevAuthApiHandlers) PutOfflineSettingsHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var settings model.OfflineSettings
+	err := c.ShouldBindJSON(&settings)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode offline settings request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = i.app.SetOfflineSettings(ctx, settings)
+	switch {
+	case err == nil:
+		c.Status(http.StatusNoContent)
+	case devauth.IsErrDevAuthBadRequest(err):
+		rest.RenderError(c, http.StatusBadRequest, errors.Cause(err))
+	default:
+		rest.RenderInternalError(c, err)
+	}
+}
+
 func (i *DevAuthApiHandlers) DeleteTokensHandler(c *gin.Context) {
 
 	ctx := c.Request.Context()
@@ -611,6 +645,19 @@ func (i *DevAuthApiHandlers) ProvisionTenantHandler(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+// DeleteTenantHandler removes all the devices, authentication sets and
+// tokens of the tenant.
+func (i *DevAuthApiHandlers) DeleteTenantHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	tid := c.Param("tid")
+	if err := i.app.DeleteTenant(ctx, tid); err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 func (i *DevAuthApiHandlers) GetTenantDeviceStatus(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -1573,6 +1573,143 @@ func TestApiV2DevAuthGetLimit(t *testing.T) {
 	}
 }
 
+func TestApiV2DevAuthGetOfflineSettings(t *testing.T) {
+	t.Parallel()
+
+	tcases := []struct {
+		daSettings *model.OfflineSettings
+		daErr      error
+
+		code int
+		body string
+	}{
+		{
+			daSettings: &model.OfflineSettings{
+				Threshold:          3600,
+				GroupThresholds:    map[string]uint64{"critical": 300},
+				NotificationEmails: []string{"user@acme.io"},
+			},
+
+			code: http.StatusOK,
+			body: string(asJSON(
+				model.OfflineSettings{
+					Threshold:          3600,
+					GroupThresholds:    map[string]uint64{"critical": 300},
+					NotificationEmails: []string{"user@acme.io"},
+				},
+			)),
+		},
+		{
+			daErr: errors.New("generic error"),
+
+			code: http.StatusInternalServerError,
+			body: RestError("internal error"),
+		},
+	}
+
+	for i := range tcases {
+		tc := tcases[i]
+		t.Run(fmt.Sprintf("tc %d", i), func(t *testing.T) {
+			t.Parallel()
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path:   "http://localhost/api/management/v2/devauth/settings/offline",
+				Auth:   true,
+			})
+
+			da := &mocks.App{}
+			da.On("GetOfflineSettings",
+				mtest.ContextMatcher()).
+				Return(tc.daSettings, tc.daErr)
+
+			apih := makeMockApiHandler(t, da, nil)
+			runTestRequest(t, apih, req, tc.code, tc.body)
+		})
+	}
+}
+
+func TestApiV2DevAuthPutOfflineSettings(t *testing.T) {
+	t.Parallel()
+
+	tcases := []struct {
+		body interface{}
+
+		settings model.OfflineSettings
+		daErr    error
+
+		code    int
+		rspBody string
+	}{
+		{
+			body: map[string]interface{}{
+				"threshold":           3600,
+				"notification_emails": []string{"user@acme.io"},
+			},
+			settings: model.OfflineSettings{
+				Threshold:          3600,
+				NotificationEmails: []string{"user@acme.io"},
+			},
+
+			code: http.StatusNoContent,
+		},
+		{
+			body: []string{"garbage"},
+
+			code: http.StatusBadRequest,
+			rspBody: RestError("failed to decode offline settings request: " +
+				"json: cannot unmarshal array into Go value of type model.OfflineSettings"),
+		},
+		{
+			body: map[string]interface{}{
+				"threshold": 10,
+			},
+			settings: model.OfflineSettings{
+				Threshold: 10,
+			},
+			daErr: devauth.MakeErrDevAuthBadRequest(
+				errors.New("threshold: must be zero or at least 60 seconds."),
+			),
+
+			code:    http.StatusBadRequest,
+			rspBody: RestError("threshold: must be zero or at least 60 seconds."),
+		},
+		{
+			body: map[string]interface{}{
+				"threshold": 3600,
+			},
+			settings: model.OfflineSettings{
+				Threshold: 3600,
+			},
+			daErr: errors.New("generic error"),
+
+			code:    http.StatusInternalServerError,
+			rspBody: RestError("internal error"),
+		},
+	}
+
+	for i := range tcases {
+		tc := tcases[i]
+		t.Run(fmt.Sprintf("tc %d", i), func(t *testing.T) {
+			t.Parallel()
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "PUT",
+				Path:   "http://localhost/api/management/v2/devauth/settings/offline",
+				Body:   tc.body,
+				Auth:   true,
+			})
+
+			da := &mocks.App{}
+			da.On("SetOfflineSettings",
+				mtest.ContextMatcher(),
+				tc.settings).
+				Return(tc.daErr)
+
+			apih := makeMockApiHandler(t, da, nil)
+			runTestRequest(t, apih, req, tc.code, tc.rspBody)
+		})
+	}
+}
+
 func TestApiDevAuthGetTenantLimit(t *testing.T) {
 	t.Parallel()
 
@@ -1944,6 +2081,53 @@ func TestApiDeleteTokens(t *testing.T) {
 	}
 }
 
+func TestApiDeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	tcases := map[string]struct {
+		devAuthErr error
+
+		checker mt.ResponseChecker
+	}{
+		"ok": {
+			checker: mt.NewJSONResponse(
+				http.StatusNoContent,
+				nil,
+				nil),
+		},
+		"error, devauth": {
+			devAuthErr: errors.New("generic error"),
+			checker: mt.NewJSONResponse(
+				http.StatusInternalServerError,
+				nil,
+				restError("internal error")),
+		},
+	}
+
+	for n := range tcases {
+		tc := tcases[n]
+		t.Run(fmt.Sprintf("tc %s", n), func(t *testing.T) {
+			t.Parallel()
+
+			da := &mocks.App{}
+			defer da.AssertExpectations(t)
+			da.On("DeleteTenant",
+				mtest.ContextMatcher(),
+				"foo").
+				Return(tc.devAuthErr)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "DELETE",
+				Path:   "http://localhost/api/internal/v1/devauth/tenants/foo",
+			})
+
+			apih := makeMockApiHandler(t, da, nil)
+			recorded := runSimpleTestRequest(t, apih, req)
+			mt.CheckHTTPResponse(t, tc.checker, recorded)
+		})
+	}
+}
+
 func TestApiDevAuthGetTenantDeviceStatus(t *testing.T) {
 	t.Parallel()
 
@@ -42,6 +42,7 @@ const (
 	uriTenantLimit        = "/tenant/:id/limits/:name"
 	uriTokens             = "/tokens"
 	uriTenants            = "/tenants"
+	uriTenant             = "/tenants/:tid"
 	uriTenantDevice       = "/tenants/:tid/devices/:did"
 	uriTenantDeviceStatus = "/tenants/:tid/devices/:did/status"
 	uriTenantDevices      = "/tenants/:tid/devices"
@@ -57,6 +58,7 @@ const (
 	v2uriDeviceAuthSetStatus = "/devices/:id/auth/:aid/status"
 	v2uriToken               = "/tokens/:id"
 	v2uriDevicesLimit        = "/limits/:name"
+	v2uriOfflineSettings     = "/settings/offline"
 
 	HdrAuthReqSign = "X-MEN-Signature"
 )
@@ -157,13 +159,15 @@ func NewRouter(app devauth.App, db store.DataStore, options ...Option) http.Hand
 	mgmtAPIV2.GET(v2uriDevice, d.GetDeviceV2Handler)
 	mgmtAPIV2.GET(v2uriDeviceAuthSetStatus, d.GetAuthSetStatusHandler)
 	mgmtAPIV2.GET(v2uriDevicesLimit, d.GetLimitHandler)
+	mgmtAPIV2.GET(v2uriOfflineSettings, d.GetOfflineSettingsHandler)
 	mgmtAPIV2.DELETE(v2uriDevice, d.DecommissionDeviceHandler)
 	mgmtAPIV2.DELETE(v2uriDeviceAuthSet, d.DeleteDeviceAuthSetHandler)
 	mgmtAPIV2.DELETE(v2uriToken, d.DeleteTokenHandler)
 	mgmtAPIV2.Group(".").Use(contenttype.CheckJSON()).
 		POST(v2uriDevices, d.PostDevicesV2Handler).
 		PUT(v2uriDeviceAuthSetStatus, d.UpdateDeviceStatusHandler).
-		POST(v2uriDevicesSearch, d.SearchDevicesV2Handler)
+		POST(v2uriDevicesSearch, d.SearchDevicesV2Handler).
+		PUT(v2uriOfflineSettings, d.PutOfflineSettingsHandler)
 
 	// automatically add Option routes for public endpoints
 	AutogenOptionsRoutes(router, AllowHeaderOptionsGenerator)
@@ -182,6 +186,7 @@ func NewRouter(app devauth.App, db store.DataStore, options ...Option) http.Hand
 	intrnlAPIV1.GET(uriTenantLimit, d.GetTenantLimitHandler)
 	intrnlAPIV1.DELETE(uriTenantLimit, d.DeleteTenantLimitHandler)
 	intrnlAPIV1.POST(uriTenants, d.ProvisionTenantHandler)
+	intrnlAPIV1.DELETE(uriTenant, d.DeleteTenantHandler)
 	intrnlAPIV1.GET(uriTenantDeviceStatus, d.GetTenantDeviceStatus)
 	intrnlAPIV1.GET(uriTenantDevices, d.GetTenantDevicesHandler)
 	intrnlAPIV1.GET(uriTenantDevicesCount, d.GetTenantDevicesCountHandler)
@@ -38,7 +38,8 @@ const (
 	urlUpdateDeviceStatus = "/api/internal/v1/inventory/tenants/#tid/devices/status/"
 	urlSetDeviceAttribute = "/api/internal/v1/inventory/tenants/#tid/device/" +
 		"#did/attribute/scope/#scope"
-	defaultTimeout = 10 * time.Second
+	urlDeviceGroups = "/api/internal/v1/inventory/tenants/#tid/devices/#did/groups"
+	defaultTimeout  = 10 * time.Second
 )
 
 var ErrPreconditionsFailed = errors.New("preconditions failed")
@@ -65,6 +66,7 @@ type Client interface {
 		idData map[string]interface{},
 		unmodifiedSince time.Time,
 	) error
+	GetDeviceGroups(ctx context.Context, tenantId, deviceId string) ([]string, error)
 }
 
 type client struct {
@@ -285,3 +287,46 @@ func (c *client) SetDeviceIdentityIfUnmodifiedSince(
 ) error {
 	return c.setDeviceIdentityIfUnmodifiedSince(ctx, tenantID, deviceID, idData, &unmodifiedSince)
 }
+
+// GetDeviceGroups returns the groups the device belongs to; devices unknown
+// to the inventory do not belong to any group.
+func (c *client) GetDeviceGroups(
+	ctx context.Context,
+	tenantID,
+	deviceID string,
+) ([]string, error) {
+	url := utils.JoinURL(c.urlBase, urlDeviceGroups)
+	url = strings.Replace(url, "#tid", tenantID, 1)
+	url = strings.Replace(url, "#did", deviceID, 1)
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create request")
+	}
+	req.Header.Set("X-MEN-Source", "deviceauth")
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to submit %s %s", req.Method, req.URL)
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+		var res struct {
+			Groups []string `json:"groups"`
+		}
+		if err := json.NewDecoder(rsp.Body).Decode(&res); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the device groups")
+		}
+		return res.Groups, nil
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, errors.Errorf(
+			"%s %s request failed with status %v", req.Method, req.URL, rsp.Status)
+	}
+}
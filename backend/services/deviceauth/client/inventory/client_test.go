@@ -412,3 +412,67 @@ func TestClientSetDeviceIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestClientGetDeviceGroups(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		code int
+		body string
+
+		groups []string
+		err    string
+	}{
+		"ok": {
+			code:   http.StatusOK,
+			body:   `{"groups":["prod"]}`,
+			groups: []string{"prod"},
+		},
+		"ok, no groups": {
+			code: http.StatusOK,
+			body: `{}`,
+		},
+		"ok, device not found": {
+			code: http.StatusNotFound,
+		},
+		"error: malformed response": {
+			code: http.StatusOK,
+			body: `rawr`,
+			err:  "failed to parse the device groups",
+		},
+		"error: inventory": {
+			code: http.StatusInternalServerError,
+			err:  "request failed with status 500",
+		},
+	}
+
+	for name := range cases {
+		tc := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					url := strings.NewReplacer(
+						"#tid", "tenant",
+						"#did", "device",
+					).Replace(urlDeviceGroups)
+					assert.Equal(t, http.MethodGet, r.Method)
+					assert.Equal(t, url, r.URL.Path)
+					w.WriteHeader(tc.code)
+					_, _ = w.Write([]byte(tc.body))
+				}))
+			defer s.Close()
+
+			c := NewClient(s.URL, true)
+			groups, err := c.GetDeviceGroups(context.Background(), "tenant", "device")
+			if tc.err != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.groups, groups)
+			}
+		})
+	}
+}
@@ -49,6 +49,36 @@ func (_m *Client) CheckHealth(ctx context.Context) error {
 	return r0
 }
 
+// GetDeviceGroups provides a mock function with given fields: ctx, tenantId, deviceId
+func (_m *Client) GetDeviceGroups(ctx context.Context, tenantId string, deviceId string) ([]string, error) {
+	ret := _m.Called(ctx, tenantId, deviceId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceGroups")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, tenantId, deviceId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, tenantId, deviceId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantId, deviceId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetDeviceIdentity provides a mock function with given fields: ctx, tenantId, deviceId, idData
 func (_m *Client) SetDeviceIdentity(ctx context.Context, tenantId string, deviceId string, idData map[string]interface{}) error {
 	ret := _m.Called(ctx, tenantId, deviceId, idData)
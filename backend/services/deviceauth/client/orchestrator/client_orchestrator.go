@@ -42,6 +42,9 @@ const (
 	DeviceLimitWarningURI                = "/api/v1/workflow/device_limit_email"
 	ReindexReportingURI                  = "/api/v1/workflow/reindex_reporting"
 	ReindexReportingBatchURI             = "/api/v1/workflow/reindex_reporting/batch"
+	AuditlogsURI                         = "/api/v1/workflow/emit_auditlog"
+	UpdateDeviceConnectivityURI          = "/api/v1/workflow/update_device_connectivity"
+	OfflineDevicesNotificationURI        = "/api/v1/workflow/notify_offline_devices"
 	// default request timeout, 10s?
 	defaultReqTimeout = time.Duration(10) * time.Second
 )
@@ -66,6 +69,9 @@ type ClientRunner interface {
 	SubmitUpdateDeviceInventoryJob(ctx context.Context, req UpdateDeviceInventoryReq) error
 	SubmitReindexReporting(c context.Context, device string) error
 	SubmitReindexReportingBatch(c context.Context, devices []string) error
+	SubmitAuditLog(ctx context.Context, log AuditLog) error
+	SubmitUpdateDeviceConnectivityJob(ctx context.Context, req UpdateDeviceConnectivityReq) error
+	SubmitOfflineDevicesNotification(ctx context.Context, req OfflineDevicesNotification) error
 }
 
 // Client is an opaque implementation of orchestrator client. Implements
@@ -457,3 +463,136 @@ func (co *Client) SubmitReindexReportingBatch(ctx context.Context, devices []str
 		rsp.Status,
 	)
 }
+
+func (co *Client) SubmitAuditLog(ctx context.Context, log AuditLog) error {
+	ctx, cancel := context.WithTimeout(ctx, co.conf.Timeout)
+	defer cancel()
+
+	if log.EventTS.IsZero() {
+		log.EventTS = time.Now()
+	}
+	if err := log.Validate(); err != nil {
+		return errors.Wrap(err, "workflows: invalid AuditLog entry")
+	}
+	tenantID := ""
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	wflow := AuditWorkflow{
+		RequestID: requestid.FromContext(ctx),
+		TenantID:  tenantID,
+		AuditLog:  log,
+	}
+	payload, _ := json.Marshal(wflow)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		utils.JoinURL(co.conf.OrchestratorAddr, AuditlogsURI),
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err, "workflows: error preparing HTTP request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := co.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to submit auditlog")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 300 {
+		return nil
+	} else if rsp.StatusCode == http.StatusNotFound {
+		return errors.New(`workflows: workflow "emit_auditlog" not defined`)
+	}
+
+	return errors.Errorf(
+		"workflows: unexpected HTTP status from workflows service: %s",
+		rsp.Status,
+	)
+}
+
+func (co *Client) SubmitUpdateDeviceConnectivityJob(
+	ctx context.Context,
+	connReq UpdateDeviceConnectivityReq,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, co.conf.Timeout)
+	defer cancel()
+
+	payload, _ := json.Marshal(connReq)
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		utils.JoinURL(co.conf.OrchestratorAddr, UpdateDeviceConnectivityURI),
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err, "workflows: error preparing HTTP request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := co.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to submit device connectivity job")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 300 {
+		return nil
+	} else if rsp.StatusCode == http.StatusNotFound {
+		return errors.New(`workflows: workflow "update_device_connectivity" not defined`)
+	}
+	return errors.Errorf(
+		"workflows: unexpected HTTP status from workflows service: %s",
+		rsp.Status,
+	)
+}
+
+func (co *Client) SubmitOfflineDevicesNotification(
+	ctx context.Context,
+	notification OfflineDevicesNotification,
+) error {
+	if err := notification.Validate(); err != nil {
+		return errors.Wrap(err,
+			"workflows: [internal] invalid request argument",
+		)
+	}
+	ctx, cancel := context.WithTimeout(ctx, co.conf.Timeout)
+	defer cancel()
+
+	payload, _ := json.Marshal(notification)
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		utils.JoinURL(co.conf.OrchestratorAddr, OfflineDevicesNotificationURI),
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err,
+			"workflows: error preparing offline devices notification request",
+		)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := co.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err,
+			"workflows: error sending offline devices notification request",
+		)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode >= 400 {
+		var (
+			apiErr    = new(rest.Error)
+			jsDecoder = json.NewDecoder(rsp.Body)
+		)
+		err := jsDecoder.Decode(apiErr)
+		if err != nil {
+			return errors.Errorf(
+				"workflows: unexpected HTTP response: %s",
+				rsp.Status,
+			)
+		}
+		return apiErr
+	}
+	return nil
+}
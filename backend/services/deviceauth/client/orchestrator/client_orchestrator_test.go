@@ -612,3 +612,228 @@ func TestReindexReportingBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestSubmitAuditLog(t *testing.T) {
+	t.Parallel()
+
+	validLog := AuditLog{
+		Action: ActionAcceptDevice,
+		Actor: Actor{
+			ID:   "user-id",
+			Type: ActorUser,
+		},
+		Object: Object{
+			ID:   "device-id",
+			Type: ObjectDevice,
+		},
+	}
+	testCases := []struct {
+		name string
+
+		log  AuditLog
+		code int
+
+		err error
+	}{
+		{
+			name: "ok",
+			log:  validLog,
+			code: http.StatusCreated,
+		},
+		{
+			name: "error, invalid log",
+			log: AuditLog{
+				Action: "login",
+				Actor:  validLog.Actor,
+				Object: validLog.Object,
+			},
+			err: errors.New("workflows: invalid AuditLog entry: action: must be a valid value."),
+		},
+		{
+			name: "error, 404",
+			log:  validLog,
+			code: http.StatusNotFound,
+			err:  errors.New(`workflows: workflow "emit_auditlog" not defined`),
+		},
+		{
+			name: "error, 500",
+			log:  validLog,
+			code: http.StatusInternalServerError,
+			err:  errors.New(`workflows: unexpected HTTP status from workflows service: 500 Internal Server Error`),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, AuditlogsURI, r.URL.Path)
+				var wflow AuditWorkflow
+				err := json.NewDecoder(r.Body).Decode(&wflow)
+				assert.NoError(t, err)
+				assert.Equal(t, "tenant", wflow.TenantID)
+				assert.Equal(t, "reqid", wflow.RequestID)
+				assert.Equal(t, tc.log.Object, wflow.AuditLog.Object)
+				assert.False(t, wflow.AuditLog.EventTS.IsZero())
+				w.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			ctx := requestid.WithContext(context.Background(), "reqid")
+			ctx = identity.WithContext(ctx, &identity.Identity{
+				Tenant: "tenant",
+			})
+			client := NewClient(Config{
+				OrchestratorAddr: srv.URL,
+			})
+
+			err := client.SubmitAuditLog(ctx, tc.log)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubmitUpdateDeviceConnectivityJob(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		code int
+
+		err error
+	}{
+		{
+			name: "ok",
+			code: http.StatusCreated,
+		},
+		{
+			name: "error, 404",
+			code: http.StatusNotFound,
+			err:  errors.New(`workflows: workflow "update_device_connectivity" not defined`),
+		},
+		{
+			name: "error, 500",
+			code: http.StatusInternalServerError,
+			err: errors.New(`workflows: unexpected HTTP status from workflows service: ` +
+				`500 Internal Server Error`),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := UpdateDeviceConnectivityReq{
+				RequestId:    "reqid",
+				TenantId:     "tenant",
+				DeviceId:     "device",
+				Attributes:   `[{"name":"offline","value":"true"}]`,
+				Connectivity: `{"offline":true}`,
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, UpdateDeviceConnectivityURI, r.URL.Path)
+				var body UpdateDeviceConnectivityReq
+				err := json.NewDecoder(r.Body).Decode(&body)
+				assert.NoError(t, err)
+				assert.Equal(t, req, body)
+				w.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			client := NewClient(Config{
+				OrchestratorAddr: srv.URL,
+				Timeout:          time.Second,
+			})
+
+			err := client.SubmitUpdateDeviceConnectivityJob(context.Background(), req)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubmitOfflineDevicesNotification(t *testing.T) {
+	t.Parallel()
+
+	validNotification := OfflineDevicesNotification{
+		RequestID:   "reqid",
+		TenantID:    "tenant",
+		Recipients:  "user@acme.io,admin@acme.io",
+		DeviceCount: 1,
+		Devices:     "device (last check-in: 2026-01-01T00:00:00Z)",
+	}
+	testCases := []struct {
+		name string
+
+		notification OfflineDevicesNotification
+		code         int
+		body         string
+
+		err error
+	}{
+		{
+			name:         "ok",
+			notification: validNotification,
+			code:         http.StatusCreated,
+		},
+		{
+			name: "error, invalid notification",
+			notification: OfflineDevicesNotification{
+				Recipients:  "user@acme.io",
+				DeviceCount: 1,
+			},
+			err: errors.New(`workflows: [internal] invalid request argument: ` +
+				`devices: cannot be blank.`),
+		},
+		{
+			name:         "error, API error returned from workflow",
+			notification: validNotification,
+			code:         http.StatusInternalServerError,
+			body:         `{"error":"internal error"}`,
+			err:          errors.New("internal error"),
+		},
+		{
+			name:         "error, unexpected response",
+			notification: validNotification,
+			code:         http.StatusBadGateway,
+			err:          errors.New(`workflows: unexpected HTTP response: 502 Bad Gateway`),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, OfflineDevicesNotificationURI, r.URL.Path)
+				var body OfflineDevicesNotification
+				err := json.NewDecoder(r.Body).Decode(&body)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.notification, body)
+				w.WriteHeader(tc.code)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			client := NewClient(Config{
+				OrchestratorAddr: srv.URL,
+				Timeout:          time.Second,
+			})
+
+			err := client.SubmitOfflineDevicesNotification(
+				context.Background(), tc.notification,
+			)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
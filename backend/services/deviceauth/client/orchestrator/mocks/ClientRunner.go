@@ -46,6 +46,24 @@ func (_m *ClientRunner) CheckHealth(ctx context.Context) error {
 	return r0
 }
 
+// SubmitAuditLog provides a mock function with given fields: ctx, log
+func (_m *ClientRunner) SubmitAuditLog(ctx context.Context, log orchestrator.AuditLog) error {
+	ret := _m.Called(ctx, log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitAuditLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, orchestrator.AuditLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubmitDeviceDecommisioningJob provides a mock function with given fields: ctx, req
 func (_m *ClientRunner) SubmitDeviceDecommisioningJob(ctx context.Context, req orchestrator.DecommissioningReq) error {
 	ret := _m.Called(ctx, req)
@@ -82,6 +100,24 @@ func (_m *ClientRunner) SubmitDeviceLimitWarning(ctx context.Context, devWarn or
 	return r0
 }
 
+// SubmitOfflineDevicesNotification provides a mock function with given fields: ctx, req
+func (_m *ClientRunner) SubmitOfflineDevicesNotification(ctx context.Context, req orchestrator.OfflineDevicesNotification) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitOfflineDevicesNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, orchestrator.OfflineDevicesNotification) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubmitProvisionDeviceJob provides a mock function with given fields: ctx, req
 func (_m *ClientRunner) SubmitProvisionDeviceJob(ctx context.Context, req orchestrator.ProvisionDeviceReq) error {
 	ret := _m.Called(ctx, req)
@@ -136,6 +172,24 @@ func (_m *ClientRunner) SubmitReindexReportingBatch(c context.Context, devices [
 	return r0
 }
 
+// SubmitUpdateDeviceConnectivityJob provides a mock function with given fields: ctx, req
+func (_m *ClientRunner) SubmitUpdateDeviceConnectivityJob(ctx context.Context, req orchestrator.UpdateDeviceConnectivityReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitUpdateDeviceConnectivityJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, orchestrator.UpdateDeviceConnectivityReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubmitUpdateDeviceInventoryJob provides a mock function with given fields: ctx, req
 func (_m *ClientRunner) SubmitUpdateDeviceInventoryJob(ctx context.Context, req orchestrator.UpdateDeviceInventoryReq) error {
 	ret := _m.Called(ctx, req)
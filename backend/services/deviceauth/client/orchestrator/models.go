@@ -15,9 +15,12 @@
 package orchestrator
 
 import (
-	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
 )
 
 const (
@@ -106,9 +109,120 @@ type UpdateDeviceInventoryReq struct {
 	Attributes string `json:"attributes"`
 }
 
+// UpdateDeviceConnectivityReq contains request data of request to start
+// the update device connectivity workflow
+type UpdateDeviceConnectivityReq struct {
+	// Request ID
+	RequestId string `json:"request_id"`
+	// Tenant ID
+	TenantId string `json:"tenant_id"`
+	// Device ID
+	DeviceId string `json:"device_id"`
+	// Device inventory attributes in the monitor scope
+	Attributes string `json:"attributes"`
+	// Connectivity change notified to the iot-manager
+	Connectivity string `json:"connectivity"`
+}
+
+// OfflineDevicesNotification contains request data of request to send the
+// digest of the devices which went offline
+type OfflineDevicesNotification struct {
+	RequestID string `json:"request_id"`
+	TenantID  string `json:"tenant_id"`
+
+	// Recipients is the comma-separated list of recipients
+	Recipients  string `json:"to"`
+	DeviceCount int    `json:"device_count"`
+	// Devices is the human readable list of devices which went offline
+	Devices string `json:"devices"`
+}
+
+func (n OfflineDevicesNotification) Validate() error {
+	return validation.ValidateStruct(&n,
+		validation.Field(&n.Recipients, validation.Required),
+		validation.Field(&n.DeviceCount, validation.Required),
+		validation.Field(&n.Devices, validation.Required),
+	)
+}
+
 type ReindexReportingWorkflow struct {
 	RequestID string `json:"request_id"`
 	TenantID  string `json:"tenant_id"`
 	DeviceID  string `json:"device_id"`
 	Service   string `json:"service"`
 }
+
+type AuditWorkflow struct {
+	RequestID string   `json:"request_id"`
+	TenantID  string   `json:"tenant_id"`
+	AuditLog  AuditLog `json:"auditlog"`
+}
+
+type Action string
+
+const (
+	ActionAcceptDevice Action = "accept_device"
+	ActionRejectDevice Action = "reject_device"
+)
+
+type ActorType string
+
+const (
+	ActorUser ActorType = "user"
+)
+
+type Actor struct {
+	ID    string    `json:"id"`
+	Type  ActorType `json:"type"`
+	Email string    `json:"email,omitempty"`
+}
+
+func (a Actor) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ID, validation.Required),
+		validation.Field(&a.Type,
+			validation.In(ActorUser),
+			validation.Required,
+		),
+	)
+}
+
+type ObjectType string
+
+const ObjectDevice ObjectType = "device"
+
+type Object struct {
+	ID   string     `json:"id"`
+	Type ObjectType `json:"type"`
+}
+
+func (o Object) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.ID, validation.Required),
+		validation.Field(&o.Type,
+			validation.Required,
+			validation.In(ObjectDevice),
+		),
+	)
+}
+
+type AuditLog struct {
+	Action   Action              `json:"action"`
+	Actor    Actor               `json:"actor"`
+	Object   Object              `json:"object"`
+	Change   string              `json:"change,omitempty"`
+	MetaData map[string][]string `json:"meta,omitempty"`
+	EventTS  time.Time           `json:"time,omitempty"`
+}
+
+func (l AuditLog) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Actor, validation.Required),
+		validation.Field(&l.Action, validation.In(
+			ActionAcceptDevice,
+			ActionRejectDevice,
+		), validation.Required),
+		validation.Field(&l.Object, validation.Required),
+		validation.Field(&l.EventTS, validation.Required),
+	)
+}
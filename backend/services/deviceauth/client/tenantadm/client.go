@@ -0,0 +1,149 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package tenantadm
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/utils"
+)
+
+const (
+	urlHealth      = "/api/internal/v1/tenantadm/health"
+	urlVerifyToken = "/api/internal/v1/tenantadm/tenants/verify"
+	defaultTimeout = 10 * time.Second
+
+	TenantStatusActive    = "active"
+	TenantStatusSuspended = "suspended"
+)
+
+var ErrTokenVerificationFailed = errors.New("tenant token verification failed")
+
+// Tenant is the tenant a tenant token belongs to.
+type Tenant struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+//go:generate ../../../../utils/mockgen.sh
+type Client interface {
+	CheckHealth(ctx context.Context) error
+	VerifyToken(ctx context.Context, token string) (*Tenant, error)
+}
+
+type client struct {
+	client  *http.Client
+	urlBase string
+}
+
+func NewClient(urlBase string, skipVerify bool) *client {
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: skipVerify},
+	}
+
+	return &client{
+		client: &http.Client{
+			Transport: tr,
+		},
+		urlBase: urlBase,
+	}
+}
+
+func (c *client) CheckHealth(ctx context.Context) error {
+	var apiErr rest.Error
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, "GET",
+		utils.JoinURL(c.urlBase, urlHealth), nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create healthcheck request: %w", err)
+	}
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode >= http.StatusOK && rsp.StatusCode < 300 {
+		return nil
+	}
+	decoder := json.NewDecoder(rsp.Body)
+	err = decoder.Decode(&apiErr)
+	if err != nil {
+		return errors.Errorf("health check HTTP error: %s", rsp.Status)
+	}
+	return &apiErr
+}
+
+// VerifyToken resolves the tenant token to the tenant it belongs to;
+// ErrTokenVerificationFailed is returned if the token is not valid.
+func (c *client) VerifyToken(ctx context.Context, token string) (*Tenant, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost,
+		utils.JoinURL(c.urlBase, urlVerifyToken), nil,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(requestid.RequestIdHeader, requestid.FromContext(ctx))
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to submit tenant token verification request")
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrTokenVerificationFailed
+	default:
+		return nil, errors.Errorf(
+			"tenant token verification request failed with unexpected status %s",
+			rsp.Status,
+		)
+	}
+	tenant := new(Tenant)
+	if err := json.NewDecoder(rsp.Body).Decode(tenant); err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant token verification response")
+	}
+	return tenant, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package tenantadm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+)
+
+func TestCheckHealth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		ResponseCode int
+		ResponseBody interface{}
+
+		Error string
+	}{{
+		Name: "ok",
+
+		ResponseCode: http.StatusNoContent,
+	}, {
+		Name: "error, tenantadm unhealthy",
+
+		ResponseCode: http.StatusInternalServerError,
+		ResponseBody: rest.Error{Err: "internal error"},
+
+		Error: "internal error",
+	}, {
+		Name: "error, bad response",
+
+		ResponseCode: http.StatusServiceUnavailable,
+		ResponseBody: "potato",
+
+		Error: "health check HTTP error: 503 Service Unavailable",
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, urlHealth, r.URL.Path)
+					w.WriteHeader(tc.ResponseCode)
+					if tc.ResponseBody != nil {
+						_ = json.NewEncoder(w).Encode(tc.ResponseBody)
+					}
+				}))
+			defer srv.Close()
+
+			err := NewClient(srv.URL, false).CheckHealth(context.Background())
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		ResponseCode int
+		ResponseBody interface{}
+
+		Tenant *Tenant
+		Error  string
+	}{{
+		Name: "ok",
+
+		ResponseCode: http.StatusOK,
+		ResponseBody: Tenant{ID: "tenant", Name: "acme", Status: TenantStatusActive},
+
+		Tenant: &Tenant{ID: "tenant", Name: "acme", Status: TenantStatusActive},
+	}, {
+		Name: "error, invalid token",
+
+		ResponseCode: http.StatusUnauthorized,
+		ResponseBody: rest.Error{Err: "invalid tenant token"},
+
+		Error: ErrTokenVerificationFailed.Error(),
+	}, {
+		Name: "error, unexpected status",
+
+		ResponseCode: http.StatusInternalServerError,
+		ResponseBody: rest.Error{Err: "internal error"},
+
+		Error: "tenant token verification request failed with unexpected " +
+			"status 500 Internal Server Error",
+	}, {
+		Name: "error, malformed response",
+
+		ResponseCode: http.StatusOK,
+		ResponseBody: "potato",
+
+		Error: "failed to parse tenant token verification response",
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPost, r.Method)
+					assert.Equal(t, urlVerifyToken, r.URL.Path)
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					w.WriteHeader(tc.ResponseCode)
+					_ = json.NewEncoder(w).Encode(tc.ResponseBody)
+				}))
+			defer srv.Close()
+
+			tenant, err := NewClient(srv.URL, false).
+				VerifyToken(context.Background(), "token")
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				assert.Nil(t, tenant)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Tenant, tenant)
+			}
+		})
+	}
+
+	t.Run("error, tenantadm unreachable", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewClient("http://localhost:0", false).
+			VerifyToken(context.Background(), "token")
+		assert.ErrorContains(t, err,
+			"failed to submit tenant token verification request")
+	})
+}
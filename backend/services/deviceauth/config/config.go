@@ -43,9 +43,20 @@ const (
 	SettingOrchestratorAddr        = "orchestrator_addr"
 	SettingOrchestratorAddrDefault = "http://mender-workflows-server:8080/"
 
+	// SettingTenantAdmAddr enables the verification of the tenant tokens
+	// against the tenant administration service when set.
+	SettingTenantAdmAddr        = "tenantadm_addr"
+	SettingTenantAdmAddrDefault = ""
+
+	SettingDefaultTenantToken        = "default_tenant_token"
+	SettingDefaultTenantTokenDefault = ""
+
 	SettingEnableReporting        = "enable_reporting"
 	SettingEnableReportingDefault = false
 
+	SettingEnableAudit        = "enable_audit"
+	SettingEnableAuditDefault = false
+
 	SettingServerPrivKeyPath        = "server_priv_key_path"
 	SettingServerPrivKeyPathDefault = "/etc/deviceauth/rsa/private.pem"
 
@@ -82,7 +93,10 @@ var (
 		{Key: SettingDb, Value: SettingDbDefault},
 		{Key: SettingInventoryAddr, Value: SettingInventoryAddrDefault},
 		{Key: SettingOrchestratorAddr, Value: SettingOrchestratorAddrDefault},
+		{Key: SettingTenantAdmAddr, Value: SettingTenantAdmAddrDefault},
+		{Key: SettingDefaultTenantToken, Value: SettingDefaultTenantTokenDefault},
 		{Key: SettingEnableReporting, Value: SettingEnableReportingDefault},
+		{Key: SettingEnableAudit, Value: SettingEnableAuditDefault},
 		{Key: SettingServerPrivKeyPath, Value: SettingServerPrivKeyPathDefault},
 		{Key: SettingServerFallbackPrivKeyPath, Value: SettingServerFallbackPrivKeyPathDefault},
 		{Key: SettingJWTIssuer, Value: SettingJWTIssuerDefault},
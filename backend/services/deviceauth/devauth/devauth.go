@@ -34,6 +34,7 @@ import (
 	"github.com/mendersoftware/mender-server/services/deviceauth/cache"
 	"github.com/mendersoftware/mender-server/services/deviceauth/client/inventory"
 	"github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator"
+	"github.com/mendersoftware/mender-server/services/deviceauth/client/tenantadm"
 	"github.com/mendersoftware/mender-server/services/deviceauth/jwt"
 	"github.com/mendersoftware/mender-server/services/deviceauth/model"
 	"github.com/mendersoftware/mender-server/services/deviceauth/store"
@@ -57,6 +58,11 @@ var (
 
 	ErrInvalidDeviceID  = errors.New("invalid device ID type")
 	ErrInvalidAuthSetID = errors.New("auth set id is not a valid ID")
+
+	ErrInvalidTenantToken = MakeErrDevAuthUnauthorized(
+		errors.New("invalid tenant token"))
+	ErrTenantSuspended = MakeErrDevAuthUnauthorized(
+		errors.New("tenant is suspended"))
 )
 
 func IsErrDevAuthUnauthorized(e error) bool {
@@ -100,6 +106,7 @@ type App interface {
 	RevokeToken(ctx context.Context, tokenID string) error
 	VerifyToken(ctx context.Context, token string) error
 	DeleteTokens(ctx context.Context, tenantID, deviceID string) error
+	DeleteTenant(ctx context.Context, tenantID string) error
 
 	SetTenantLimit(ctx context.Context, tenant_id string, limit model.Limit) error
 	DeleteTenantLimit(ctx context.Context, tenant_id string, limit string) error
@@ -110,12 +117,17 @@ type App interface {
 	GetDevCountByStatus(ctx context.Context, status string) (int, error)
 
 	GetTenantDeviceStatus(ctx context.Context, tenantId, deviceId string) (*model.Status, error)
+
+	GetOfflineSettings(ctx context.Context) (*model.OfflineSettings, error)
+	SetOfflineSettings(ctx context.Context, settings model.OfflineSettings) error
+	CheckOfflineDevices(ctx context.Context, dryRun bool) error
 }
 
 type DevAuth struct {
 	db          store.DataStore
 	invClient   inventory.Client
 	cOrch       orchestrator.ClientRunner
+	cTenant     tenantadm.Client
 	jwt         jwt.Handler
 	jwtFallback jwt.Handler
 	config      Config
@@ -136,6 +148,7 @@ type Config struct {
 
 	EnableReporting bool
 	HaveAddons      bool
+	HaveAuditLogs   bool
 }
 
 func NewDevAuth(d store.DataStore, co orchestrator.ClientRunner,
@@ -171,6 +184,12 @@ func (d *DevAuth) HealthCheck(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "Workflows service unhealthy")
 	}
+	if d.cTenant != nil {
+		err = d.cTenant.CheckHealth(ctx)
+		if err != nil {
+			return errors.Wrap(err, "Tenantadm service unhealthy")
+		}
+	}
 	return nil
 }
 
@@ -277,6 +296,16 @@ func (d *DevAuth) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (stri
 	var err error
 
 	ctx = identity.WithContext(ctx, nil)
+	tenantID := ""
+	if d.cTenant != nil {
+		tenantID, err = d.verifyTenantToken(ctx, r.TenantToken)
+		if err != nil {
+			return "", err
+		}
+		ctx = identity.WithContext(ctx, &identity.Identity{
+			Tenant: tenantID,
+		})
+	}
 
 	// first, try to handle preauthorization
 	authSet, err := d.processPreAuthRequest(ctx, r)
@@ -312,6 +341,7 @@ func (d *DevAuth) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (stri
 					time.Duration(d.config.ExpirationTime)),
 			},
 			IssuedAt: jwt.Time{Time: now},
+			Tenant:   tenantID,
 			Device:   true,
 		}}
 
@@ -338,6 +368,27 @@ func (d *DevAuth) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (stri
 	return "", ErrDevAuthUnauthorized
 }
 
+// verifyTenantToken resolves the tenant token submitted by the device, or
+// the default tenant token if none, to the ID of an active tenant.
+func (d *DevAuth) verifyTenantToken(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		token = d.config.DefaultTenantToken
+	}
+	if token == "" {
+		return "", ErrInvalidTenantToken
+	}
+	tenant, err := d.cTenant.VerifyToken(ctx, token)
+	if errors.Is(err, tenantadm.ErrTokenVerificationFailed) {
+		return "", ErrInvalidTenantToken
+	} else if err != nil {
+		return "", errors.Wrap(err, "failed to verify the tenant token")
+	}
+	if tenant.Status == tenantadm.TenantStatusSuspended {
+		return "", ErrTenantSuspended
+	}
+	return tenant.ID, nil
+}
+
 func (d *DevAuth) handlePreAuthDevice(
 	ctx context.Context,
 	aset *model.AuthSet,
@@ -846,6 +897,7 @@ func (d *DevAuth) AcceptDeviceAuth(ctx context.Context, device_id string, auth_i
 	if err := d.setAuthSetStatus(ctx, device_id, auth_id, model.DevStatusAccepted); err != nil {
 		return err
 	}
+	d.submitAuditLog(ctx, orchestrator.ActionAcceptDevice, device_id)
 
 	if dev.Status != model.DevStatusPending {
 		// Device already exist in all services
@@ -952,7 +1004,42 @@ func (d *DevAuth) RejectDeviceAuth(ctx context.Context, device_id string, auth_i
 		return errors.Wrapf(err, "failed to delete token for %s from cache", device_id)
 	}
 
-	return d.setAuthSetStatus(ctx, device_id, auth_id, model.DevStatusRejected)
+	err = d.setAuthSetStatus(ctx, device_id, auth_id, model.DevStatusRejected)
+	if err != nil {
+		return err
+	}
+	d.submitAuditLog(ctx, orchestrator.ActionRejectDevice, device_id)
+	return nil
+}
+
+// submitAuditLog records a user decision on a device in the audit trail.
+// Failures are only logged since the status change has already been applied.
+func (d *DevAuth) submitAuditLog(
+	ctx context.Context,
+	action orchestrator.Action,
+	deviceID string,
+) {
+	id := identity.FromContext(ctx)
+	if !d.config.HaveAuditLogs || id == nil || !id.IsUser {
+		return
+	}
+	err := d.cOrch.SubmitAuditLog(ctx, orchestrator.AuditLog{
+		Action: action,
+		Actor: orchestrator.Actor{
+			ID:   id.Subject,
+			Type: orchestrator.ActorUser,
+		},
+		Object: orchestrator.Object{
+			ID:   deviceID,
+			Type: orchestrator.ObjectDevice,
+		},
+		EventTS: time.Now(),
+	})
+	if err != nil {
+		log.FromContext(ctx).
+			Errorf("failed to submit audit log for device %s: %s",
+				deviceID, err.Error())
+	}
 }
 
 func (d *DevAuth) ResetDeviceAuth(ctx context.Context, device_id string, auth_id string) error {
@@ -1359,6 +1446,13 @@ func (d *DevAuth) WithJWTFallbackHandler(handler jwt.Handler) *DevAuth {
 	return d
 }
 
+// WithTenantAdm enables the verification of the tenant tokens submitted
+// by the devices against the tenant administration service.
+func (d *DevAuth) WithTenantAdm(c tenantadm.Client) *DevAuth {
+	d.cTenant = c
+	return d
+}
+
 func (d *DevAuth) WithCache(c cache.Cache) *DevAuth {
 	d.cache = c
 	return d
@@ -1484,6 +1578,18 @@ func (d *DevAuth) DeleteTokens(
 	return nil
 }
 
+// DeleteTenant removes all the devices, authentication sets and tokens of
+// the tenant.
+func (d *DevAuth) DeleteTenant(ctx context.Context, tenantID string) error {
+	if err := d.cacheFlush(ctx, tenantID); err != nil {
+		return errors.Wrapf(err, "failed to flush cache for tenant %v", tenantID)
+	}
+	if err := d.db.DeleteTenant(ctx, tenantID); err != nil {
+		return errors.Wrapf(err, "failed to delete tenant %v", tenantID)
+	}
+	return nil
+}
+
 func (d *DevAuth) cacheFlush(ctx context.Context, tenantID string) error {
 	if d.cache == nil {
 		return nil
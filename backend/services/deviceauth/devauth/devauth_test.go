@@ -36,6 +36,8 @@ import (
 	minv "github.com/mendersoftware/mender-server/services/deviceauth/client/inventory/mocks"
 	"github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator"
 	morchestrator "github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/client/tenantadm"
+	mtenantadm "github.com/mendersoftware/mender-server/services/deviceauth/client/tenantadm/mocks"
 	"github.com/mendersoftware/mender-server/services/deviceauth/jwt"
 	mjwt "github.com/mendersoftware/mender-server/services/deviceauth/jwt/mocks"
 	"github.com/mendersoftware/mender-server/services/deviceauth/model"
@@ -57,6 +59,7 @@ func TestHealthCheck(t *testing.T) {
 		DataStoreError error
 		InventoryError error
 		WorkflowsError error
+		TenantadmError error
 	}{{
 		Name: "ok",
 	}, {
@@ -71,6 +74,10 @@ func TestHealthCheck(t *testing.T) {
 	}, {
 		Name:           "error, workflows",
 		WorkflowsError: errors.New("connection error"),
+	}, {
+		Name:           "error, tenantadm",
+		MultiTenant:    true,
+		TenantadmError: errors.New("connection error"),
 	}}
 
 	for _, tc := range testCases {
@@ -81,10 +88,18 @@ func TestHealthCheck(t *testing.T) {
 			db := &mstore.DataStore{}
 			wf := &morchestrator.ClientRunner{}
 			inv := &minv.Client{}
+			tadm := &mtenantadm.Client{}
 			devauth := NewDevAuth(db, wf, nil, Config{})
 			devauth.invClient = inv
+			if tc.MultiTenant {
+				devauth = devauth.WithTenantAdm(tadm)
+			}
 			switch {
 			default:
+				if tc.MultiTenant {
+					tadm.On("CheckHealth", ctx).
+						Return(tc.TenantadmError)
+				}
 				fallthrough
 			case tc.WorkflowsError != nil:
 				wf.On("CheckHealth", ctx).
@@ -116,12 +131,18 @@ func TestHealthCheck(t *testing.T) {
 					"Workflows service unhealthy: "+
 						tc.WorkflowsError.Error(),
 				)
+			case tc.TenantadmError != nil:
+				assert.EqualError(t, err,
+					"Tenantadm service unhealthy: "+
+						tc.TenantadmError.Error(),
+				)
 			default:
 				assert.NoError(t, err)
 			}
 			db.AssertExpectations(t)
 			inv.AssertExpectations(t)
 			wf.AssertExpectations(t)
+			tadm.AssertExpectations(t)
 		})
 	}
 }
@@ -565,6 +586,174 @@ func TestDevAuthSubmitAuthRequest(t *testing.T) {
 	}
 }
 
+func TestDevAuthSubmitAuthRequestTenantToken(t *testing.T) {
+	t.Parallel()
+
+	pubKey := "dummy_pubkey"
+	idData := "{\"mac\":\"00:00:00:01\"}"
+	devId := oid.NewUUIDv4().String()
+	authId := oid.NewUUIDv4().String()
+	_, idDataHash, err := parseIdData(idData)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+
+		tenantToken        string
+		defaultTenantToken string
+
+		verifiedToken string
+		tenant        *tenantadm.Tenant
+		verifyErr     error
+
+		err error
+	}{
+		{
+			desc: "ok",
+
+			tenantToken:   "token",
+			verifiedToken: "token",
+			tenant: &tenantadm.Tenant{
+				ID:     "tenant",
+				Status: tenantadm.TenantStatusActive,
+			},
+		},
+		{
+			desc: "ok, default tenant token",
+
+			defaultTenantToken: "default",
+			verifiedToken:      "default",
+			tenant: &tenantadm.Tenant{
+				ID:     "tenant",
+				Status: tenantadm.TenantStatusActive,
+			},
+		},
+		{
+			desc: "error, no tenant token",
+
+			err: ErrInvalidTenantToken,
+		},
+		{
+			desc: "error, invalid tenant token",
+
+			tenantToken:   "token",
+			verifiedToken: "token",
+			verifyErr:     tenantadm.ErrTokenVerificationFailed,
+
+			err: ErrInvalidTenantToken,
+		},
+		{
+			desc: "error, tenant suspended",
+
+			tenantToken:   "token",
+			verifiedToken: "token",
+			tenant: &tenantadm.Tenant{
+				ID:     "tenant",
+				Status: tenantadm.TenantStatusSuspended,
+			},
+
+			err: ErrTenantSuspended,
+		},
+		{
+			desc: "error, tenantadm unreachable",
+
+			tenantToken:   "token",
+			verifiedToken: "token",
+			verifyErr:     errors.New("connection refused"),
+
+			err: errors.New("failed to verify the tenant token: connection refused"),
+		},
+	}
+
+	for tcidx := range testCases {
+		tc := testCases[tcidx]
+		t.Run(fmt.Sprintf("tc: %s", tc.desc), func(t *testing.T) {
+			t.Parallel()
+
+			ctxMatcher := mock.MatchedBy(func(ctx context.Context) bool {
+				id := identity.FromContext(ctx)
+				return assert.NotNil(t, id) &&
+					assert.Equal(t, "tenant", id.Tenant)
+			})
+
+			tadm := &mtenantadm.Client{}
+			defer tadm.AssertExpectations(t)
+			if tc.verifiedToken != "" {
+				tadm.On("VerifyToken",
+					mtesting.ContextMatcher(),
+					tc.verifiedToken,
+				).Return(tc.tenant, tc.verifyErr)
+			}
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			if tc.err == nil {
+				db.On("GetAuthSetByIdDataHashKeyByStatus",
+					ctxMatcher, idDataHash, pubKey, model.DevStatusPreauth,
+				).Return(nil, store.ErrAuthSetNotFound)
+				db.On("AddDevice", ctxMatcher,
+					mock.AnythingOfType("model.Device"),
+				).Return(store.ErrObjectExists)
+				db.On("GetDeviceByIdentityDataHash", ctxMatcher, idDataHash).
+					Return(&model.Device{
+						Id:     devId,
+						Status: model.DevStatusAccepted,
+					}, nil)
+				db.On("AddAuthSet", ctxMatcher,
+					mock.AnythingOfType("model.AuthSet"),
+				).Return(store.ErrObjectExists)
+				db.On("GetDeviceStatus", ctxMatcher, devId).
+					Return(model.DevStatusAccepted, nil)
+				db.On("GetAuthSetByIdDataHashKey",
+					ctxMatcher, idDataHash, pubKey,
+				).Return(&model.AuthSet{
+					Id:       authId,
+					DeviceId: devId,
+					Status:   model.DevStatusAccepted,
+				}, nil)
+				db.On("AddToken", ctxMatcher,
+					mock.MatchedBy(func(token *jwt.Token) bool {
+						return assert.Equal(t, "tenant", token.Claims.Tenant)
+					}),
+				).Return(nil)
+				db.On("UpdateDevice", ctxMatcher, devId,
+					mock.AnythingOfType("model.DeviceUpdate"),
+				).Return(nil)
+			}
+
+			co := &morchestrator.ClientRunner{}
+			defer co.AssertExpectations(t)
+			if tc.err == nil {
+				co.On("SubmitUpdateDeviceInventoryJob", mock.Anything,
+					mock.MatchedBy(func(req orchestrator.UpdateDeviceInventoryReq) bool {
+						return assert.Equal(t, "tenant", req.TenantId)
+					}),
+				).Return(nil)
+			}
+
+			jwth := &mjwt.Handler{}
+			jwth.On("ToJWT", mock.AnythingOfType("*jwt.Token")).
+				Return("dummytoken", nil)
+
+			devauth := NewDevAuth(db, co, jwth, Config{
+				DefaultTenantToken: tc.defaultTenantToken,
+			}).WithTenantAdm(tadm)
+
+			res, err := devauth.SubmitAuthRequest(context.Background(), &model.AuthReq{
+				IdData:      idData,
+				TenantToken: tc.tenantToken,
+				PubKey:      pubKey,
+			})
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "dummytoken", res)
+			}
+		})
+	}
+}
+
 // still a Submit... test, but focuses on preauth
 func TestDevAuthSubmitAuthRequestPreauth(t *testing.T) {
 	idData := "{\"mac\":\"00:00:00:01\"}"
@@ -1496,6 +1685,87 @@ func TestDevAuthRejectDevice(t *testing.T) {
 	}
 }
 
+func TestDevAuthRejectDeviceAuditLog(t *testing.T) {
+	t.Parallel()
+
+	dummyAuthID := oid.NewUUIDv5("dummy_aid").String()
+	dummyDevUUID := oid.NewUUIDv5("dummy_devid")
+	dummyDevID := dummyDevUUID.String()
+
+	testCases := map[string]struct {
+		identity      *identity.Identity
+		haveAuditLogs bool
+		submitErr     error
+		submit        bool
+	}{
+		"ok": {
+			identity:      &identity.Identity{Subject: "user-id", IsUser: true},
+			haveAuditLogs: true,
+			submit:        true,
+		},
+		"ok, submit error is only logged": {
+			identity:      &identity.Identity{Subject: "user-id", IsUser: true},
+			haveAuditLogs: true,
+			submit:        true,
+			submitErr:     errors.New("workflows down"),
+		},
+		"ok, audit logs disabled": {
+			identity: &identity.Identity{Subject: "user-id", IsUser: true},
+		},
+		"ok, not a user": {
+			identity:      &identity.Identity{Subject: "device-id", IsDevice: true},
+			haveAuditLogs: true,
+		},
+	}
+
+	for name := range testCases {
+		tc := testCases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), tc.identity)
+			aset := &model.AuthSet{
+				Id:       dummyAuthID,
+				DeviceId: dummyDevID,
+				Status:   model.DevStatusPending,
+			}
+
+			db := mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetAuthSetById", ctx, dummyAuthID).Return(aset, nil)
+			db.On("UpdateAuthSetById", ctx, dummyAuthID,
+				model.AuthSetUpdate{Status: model.DevStatusRejected}).Return(nil)
+			db.On("GetDeviceStatus", ctx, dummyDevID).
+				Return(model.DevStatusRejected, nil)
+			db.On("UpdateDevice", ctx, dummyDevID,
+				mock.AnythingOfType("model.DeviceUpdate")).Return(nil)
+			db.On("GetDeviceById", ctx, dummyDevID).
+				Return(&model.Device{Id: dummyDevID}, nil)
+
+			co := morchestrator.ClientRunner{}
+			defer co.AssertExpectations(t)
+			co.On("SubmitUpdateDeviceStatusJob", ctx,
+				mock.AnythingOfType("orchestrator.UpdateDeviceStatusReq")).
+				Return(nil)
+			if tc.submit {
+				co.On("SubmitAuditLog", ctx, mock.MatchedBy(
+					func(log orchestrator.AuditLog) bool {
+						return log.Action == orchestrator.ActionRejectDevice &&
+							log.Actor.ID == tc.identity.Subject &&
+							log.Object.ID == dummyDevID &&
+							log.Object.Type == orchestrator.ObjectDevice
+					})).Return(tc.submitErr)
+			}
+
+			devauth := NewDevAuth(&db, &co, nil, Config{
+				HaveAuditLogs: tc.haveAuditLogs,
+			})
+			err := devauth.RejectDeviceAuth(ctx, dummyDevID, dummyAuthID)
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestDevAuthRevokeToken(t *testing.T) {
 	t.Parallel()
 
@@ -3128,6 +3398,55 @@ func TestDeleteTokens(t *testing.T) {
 	}
 }
 
+func TestDevAuthDeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cacheErr error
+		dbErr    error
+
+		outErr error
+	}{
+		"ok": {},
+		"error, cache": {
+			cacheErr: errors.New("redis error"),
+			outErr:   errors.New("failed to flush cache for tenant foo: redis error"),
+		},
+		"error, db": {
+			dbErr:  errors.New("db error"),
+			outErr: errors.New("failed to delete tenant foo: db error"),
+		},
+	}
+
+	for n := range testCases {
+		tc := testCases[n]
+		t.Run(fmt.Sprintf("tc %s", n), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			ctxMatcher := mtesting.ContextMatcher()
+
+			c := &mcache.Cache{}
+			defer c.AssertExpectations(t)
+			c.On("SuspendTenant", ctxMatcher, "foo").Return(tc.cacheErr)
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			if tc.cacheErr == nil {
+				db.On("DeleteTenant", ctxMatcher, "foo").Return(tc.dbErr)
+			}
+
+			devauth := NewDevAuth(db, nil, nil, Config{}).WithCache(c)
+			err := devauth.DeleteTenant(ctx, "foo")
+			if tc.outErr != nil {
+				assert.EqualError(t, err, tc.outErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGetTenantDeviceStatus(t *testing.T) {
 	t.Parallel()
 
@@ -47,6 +47,24 @@ func (_m *App) AcceptDeviceAuth(ctx context.Context, dev_id string, auth_id stri
 	return r0
 }
 
+// CheckOfflineDevices provides a mock function with given fields: ctx, dryRun
+func (_m *App) CheckOfflineDevices(ctx context.Context, dryRun bool) error {
+	ret := _m.Called(ctx, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckOfflineDevices")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) error); ok {
+		r0 = rf(ctx, dryRun)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DecommissionDevice provides a mock function with given fields: ctx, dev_id
 func (_m *App) DecommissionDevice(ctx context.Context, dev_id string) error {
 	ret := _m.Called(ctx, dev_id)
@@ -101,6 +119,24 @@ func (_m *App) DeleteDevice(ctx context.Context, dev_id string) error {
 	return r0
 }
 
+// DeleteTenant provides a mock function with given fields: ctx, tenantID
+func (_m *App) DeleteTenant(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTenant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTenantLimit provides a mock function with given fields: ctx, tenant_id, limit
 func (_m *App) DeleteTenantLimit(ctx context.Context, tenant_id string, limit string) error {
 	ret := _m.Called(ctx, tenant_id, limit)
@@ -255,6 +291,36 @@ func (_m *App) GetLimit(ctx context.Context, name string) (*model.Limit, error)
 	return r0, r1
 }
 
+// GetOfflineSettings provides a mock function with given fields: ctx
+func (_m *App) GetOfflineSettings(ctx context.Context) (*model.OfflineSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOfflineSettings")
+	}
+
+	var r0 *model.OfflineSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.OfflineSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.OfflineSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OfflineSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTenantDeviceStatus provides a mock function with given fields: ctx, tenantId, deviceId
 func (_m *App) GetTenantDeviceStatus(ctx context.Context, tenantId string, deviceId string) (*model.Status, error) {
 	ret := _m.Called(ctx, tenantId, deviceId)
@@ -417,6 +483,24 @@ func (_m *App) RevokeToken(ctx context.Context, tokenID string) error {
 	return r0
 }
 
+// SetOfflineSettings provides a mock function with given fields: ctx, settings
+func (_m *App) SetOfflineSettings(ctx context.Context, settings model.OfflineSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOfflineSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OfflineSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetTenantLimit provides a mock function with given fields: ctx, tenant_id, limit
 func (_m *App) SetTenantLimit(ctx context.Context, tenant_id string, limit model.Limit) error {
 	ret := _m.Called(ctx, tenant_id, limit)
@@ -0,0 +1,333 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package devauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+const (
+	InventoryScopeMonitor = "monitor"
+
+	offlineDevicesBatchSize = 100
+)
+
+// GetOfflineSettings returns the tenant's offline detection settings; the
+// detection is disabled unless configured.
+func (d *DevAuth) GetOfflineSettings(ctx context.Context) (*model.OfflineSettings, error) {
+	settings, err := d.db.GetOfflineSettings(ctx)
+	if errors.Is(err, store.ErrOfflineSettingsNotFound) {
+		return &model.OfflineSettings{}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get offline settings")
+	}
+	return settings, nil
+}
+
+// SetOfflineSettings replaces the tenant's offline detection settings.
+func (d *DevAuth) SetOfflineSettings(
+	ctx context.Context,
+	settings model.OfflineSettings,
+) error {
+	if err := settings.Validate(); err != nil {
+		return MakeErrDevAuthBadRequest(err)
+	}
+	if id := identity.FromContext(ctx); id != nil {
+		settings.TenantID = id.Tenant
+	}
+	if err := d.db.PutOfflineSettings(ctx, settings); err != nil {
+		return errors.Wrap(err, "failed to save offline settings")
+	}
+	return nil
+}
+
+// offlineDevice describes a device which went offline during a check.
+type offlineDevice struct {
+	ID          string
+	CheckInTime time.Time
+}
+
+// CheckOfflineDevices flags the accepted devices which have not checked in
+// within the configured threshold as offline and clears the flag of the
+// devices which checked in again. Every transition is published to the
+// inventory (monitor scope) and to the iot-manager (webhooks); the
+// recipients configured by the tenant receive a digest of the devices which
+// went offline. With dryRun set, the transitions are only logged.
+func (d *DevAuth) CheckOfflineDevices(ctx context.Context, dryRun bool) error {
+	l := log.FromContext(ctx)
+	settingsList, err := d.db.ListOfflineSettings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list offline settings")
+	}
+	for _, settings := range settingsList {
+		tenantCtx := ctx
+		if settings.TenantID != "" {
+			tenantCtx = identity.WithContext(ctx, &identity.Identity{
+				Tenant: settings.TenantID,
+			})
+		}
+		err := d.checkOfflineDevices(tenantCtx, settings, dryRun)
+		if err != nil {
+			l.Errorf("failed to check offline devices for tenant %q: %s",
+				settings.TenantID, err.Error())
+		}
+	}
+	return nil
+}
+
+func (d *DevAuth) checkOfflineDevices(
+	ctx context.Context,
+	settings model.OfflineSettings,
+	dryRun bool,
+) error {
+	now := d.clock.Now().UTC()
+	if err := d.checkOnlineDevices(ctx, settings, dryRun); err != nil {
+		return err
+	}
+	minThreshold := settings.MinThreshold()
+	if minThreshold == 0 {
+		return nil
+	}
+
+	var (
+		l       = log.FromContext(ctx)
+		offline []offlineDevice
+		skip    uint
+	)
+	for {
+		devs, err := d.db.GetDevicesCheckedInBefore(
+			ctx, now.Add(-minThreshold), skip, offlineDevicesBatchSize,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to list devices")
+		}
+		d.refreshCheckInTimes(ctx, settings.TenantID, devs)
+		transitioned := 0
+		for _, dev := range devs {
+			if dev.CheckInTime == nil {
+				continue
+			}
+			threshold := settings.Threshold
+			if len(settings.GroupThresholds) > 0 {
+				groups, err := d.invClient.GetDeviceGroups(
+					ctx, settings.TenantID, dev.Id,
+				)
+				if err != nil {
+					l.Errorf("failed to get groups of device %s: %s",
+						dev.Id, err.Error())
+					continue
+				}
+				threshold = uint64(settings.ThresholdFor(groups) / time.Second)
+			}
+			if threshold == 0 ||
+				now.Sub(*dev.CheckInTime) <= time.Duration(threshold)*time.Second {
+				continue
+			}
+			if dryRun {
+				l.Infof("device %s is offline since %s (dry run)",
+					dev.Id, dev.CheckInTime.Format(time.RFC3339))
+				continue
+			}
+			err := d.setDeviceConnectivity(ctx, settings.TenantID, dev, dev.CheckInTime)
+			if err != nil {
+				l.Errorf("failed to flag device %s as offline: %s",
+					dev.Id, err.Error())
+				continue
+			}
+			transitioned++
+			offline = append(offline, offlineDevice{
+				ID:          dev.Id,
+				CheckInTime: *dev.CheckInTime,
+			})
+		}
+		if len(devs) < offlineDevicesBatchSize {
+			break
+		}
+		// flagged devices no longer match the query
+		skip += uint(len(devs) - transitioned)
+	}
+	if len(offline) > 0 && len(settings.NotificationEmails) > 0 {
+		return d.notifyOfflineDevices(ctx, settings, offline)
+	}
+	return nil
+}
+
+// checkOnlineDevices clears the offline flag of the devices which checked
+// in since they went offline.
+func (d *DevAuth) checkOnlineDevices(
+	ctx context.Context,
+	settings model.OfflineSettings,
+	dryRun bool,
+) error {
+	var (
+		l    = log.FromContext(ctx)
+		skip uint
+	)
+	for {
+		devs, err := d.db.GetOfflineDevices(ctx, skip, offlineDevicesBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to list offline devices")
+		}
+		d.refreshCheckInTimes(ctx, settings.TenantID, devs)
+		transitioned := 0
+		for _, dev := range devs {
+			if dev.CheckInTime == nil || dev.OfflineSince == nil ||
+				!dev.CheckInTime.After(*dev.OfflineSince) {
+				continue
+			}
+			if dryRun {
+				l.Infof("device %s is back online since %s (dry run)",
+					dev.Id, dev.CheckInTime.Format(time.RFC3339))
+				continue
+			}
+			err := d.setDeviceConnectivity(ctx, settings.TenantID, dev, nil)
+			if err != nil {
+				l.Errorf("failed to clear offline flag of device %s: %s",
+					dev.Id, err.Error())
+				continue
+			}
+			transitioned++
+		}
+		if len(devs) < offlineDevicesBatchSize {
+			break
+		}
+		skip += uint(len(devs) - transitioned)
+	}
+	return nil
+}
+
+// refreshCheckInTimes updates the check-in times of the devices with the
+// cached values: the database is only updated once a day when the cache
+// is enabled.
+func (d *DevAuth) refreshCheckInTimes(
+	ctx context.Context,
+	tenantID string,
+	devs []model.Device,
+) {
+	if d.cache == nil || len(devs) == 0 {
+		return
+	}
+	ids := make([]string, len(devs))
+	for i := range devs {
+		ids[i] = devs[i].Id
+	}
+	checkInTimes, err := d.cache.GetCheckInTimes(ctx, tenantID, ids)
+	if err != nil {
+		log.FromContext(ctx).Errorf(
+			"failed to get check-in times for devices: %s", err.Error(),
+		)
+		return
+	}
+	for i := range devs {
+		if i < len(checkInTimes) && checkInTimes[i] != nil {
+			devs[i].CheckInTime = checkInTimes[i]
+		}
+	}
+}
+
+// setDeviceConnectivity flags the device as offline since the given time,
+// or clears the flag if offlineSince is nil, and publishes the change.
+func (d *DevAuth) setDeviceConnectivity(
+	ctx context.Context,
+	tenantID string,
+	dev model.Device,
+	offlineSince *time.Time,
+) error {
+	if err := d.db.SetDeviceOfflineSince(ctx, dev.Id, offlineSince); err != nil {
+		return errors.Wrap(err, "failed to update device")
+	}
+	var (
+		offline = offlineSince != nil
+		// an empty value clears the attribute in inventory
+		offlineSinceAttr = ""
+	)
+	if offline {
+		offlineSinceAttr = offlineSince.UTC().Format(time.RFC3339)
+	}
+	attributes := []model.DeviceAttribute{
+		{
+			Name:  "offline",
+			Value: fmt.Sprintf("%t", offline),
+			Scope: InventoryScopeMonitor,
+		},
+		{
+			Name:  "offline_since",
+			Value: offlineSinceAttr,
+			Scope: InventoryScopeMonitor,
+		},
+	}
+	attrJson, err := json.Marshal(attributes)
+	if err != nil {
+		return errors.New("internal error: cannot marshal attributes into json")
+	}
+	connJson, err := json.Marshal(model.DeviceConnectivity{
+		Offline:      offline,
+		OfflineSince: offlineSince,
+		CheckInTime:  dev.CheckInTime,
+	})
+	if err != nil {
+		return errors.New("internal error: cannot marshal connectivity into json")
+	}
+	err = d.cOrch.SubmitUpdateDeviceConnectivityJob(ctx,
+		orchestrator.UpdateDeviceConnectivityReq{
+			RequestId:    requestid.FromContext(ctx),
+			TenantId:     tenantID,
+			DeviceId:     dev.Id,
+			Attributes:   string(attrJson),
+			Connectivity: string(connJson),
+		})
+	if err != nil {
+		return errors.Wrap(err, "failed to start device connectivity job")
+	}
+	return nil
+}
+
+func (d *DevAuth) notifyOfflineDevices(
+	ctx context.Context,
+	settings model.OfflineSettings,
+	devices []offlineDevice,
+) error {
+	lines := make([]string, len(devices))
+	for i, dev := range devices {
+		lines[i] = fmt.Sprintf("%s (last check-in: %s)",
+			dev.ID, dev.CheckInTime.UTC().Format(time.RFC3339))
+	}
+	err := d.cOrch.SubmitOfflineDevicesNotification(ctx,
+		orchestrator.OfflineDevicesNotification{
+			RequestID:   requestid.FromContext(ctx),
+			TenantID:    settings.TenantID,
+			Recipients:  strings.Join(settings.NotificationEmails, ","),
+			DeviceCount: len(devices),
+			Devices:     strings.Join(lines, "\n"),
+		})
+	if err != nil {
+		return errors.Wrap(err, "failed to submit offline devices notification")
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package devauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	mcache "github.com/mendersoftware/mender-server/services/deviceauth/cache/mocks"
+	minv "github.com/mendersoftware/mender-server/services/deviceauth/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator"
+	morchestrator "github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceauth/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/utils"
+)
+
+func TestGetOfflineSettings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		Settings *model.OfflineSettings
+		DBError  error
+
+		Result *model.OfflineSettings
+		Error  error
+	}{{
+		Name: "ok",
+
+		Settings: &model.OfflineSettings{Threshold: 3600},
+		Result:   &model.OfflineSettings{Threshold: 3600},
+	}, {
+		Name: "ok, not configured",
+
+		DBError: store.ErrOfflineSettingsNotFound,
+		Result:  &model.OfflineSettings{},
+	}, {
+		Name: "error, datastore",
+
+		DBError: errors.New("connection error"),
+		Error:   errors.New("failed to get offline settings: connection error"),
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetOfflineSettings", ctx).Return(tc.Settings, tc.DBError)
+
+			devauth := NewDevAuth(db, nil, nil, Config{})
+			settings, err := devauth.GetOfflineSettings(ctx)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Result, settings)
+			}
+		})
+	}
+}
+
+func TestSetOfflineSettings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		Settings model.OfflineSettings
+		DBError  error
+
+		Error error
+	}{{
+		Name: "ok",
+
+		Settings: model.OfflineSettings{
+			Threshold:          3600,
+			GroupThresholds:    map[string]uint64{"critical": 300},
+			NotificationEmails: []string{"user@acme.io"},
+		},
+	}, {
+		Name: "error, invalid settings",
+
+		Settings: model.OfflineSettings{Threshold: 10},
+		Error: errors.New(
+			"dev auth: bad request: threshold: must be zero or at least 60 seconds.",
+		),
+	}, {
+		Name: "error, datastore",
+
+		Settings: model.OfflineSettings{Threshold: 3600},
+		DBError:  errors.New("connection error"),
+		Error:    errors.New("failed to save offline settings: connection error"),
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: "tenant",
+			})
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			if tc.Error == nil || tc.DBError != nil {
+				expected := tc.Settings
+				expected.TenantID = "tenant"
+				db.On("PutOfflineSettings", ctx, expected).Return(tc.DBError)
+			}
+
+			devauth := NewDevAuth(db, nil, nil, Config{})
+			err := devauth.SetOfflineSettings(ctx, tc.Settings)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckOfflineDevices(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	hourAgo := now.Add(-time.Hour)
+	dayAgo := now.Add(-24 * time.Hour)
+
+	matchConnectivity := func(deviceID string, offline bool) interface{} {
+		return mock.MatchedBy(func(req orchestrator.UpdateDeviceConnectivityReq) bool {
+			var (
+				conn  model.DeviceConnectivity
+				attrs []model.DeviceAttribute
+			)
+			if err := json.Unmarshal([]byte(req.Connectivity), &conn); err != nil {
+				return false
+			}
+			if err := json.Unmarshal([]byte(req.Attributes), &attrs); err != nil {
+				return false
+			}
+			// coming back online clears the offline_since attribute
+			offlineSince := ""
+			if offline {
+				offlineSince = conn.OfflineSince.Format(time.RFC3339)
+			}
+			return req.TenantId == "tenant" &&
+				req.DeviceId == deviceID &&
+				conn.Offline == offline &&
+				len(attrs) == 2 &&
+				attrs[0].Value == fmt.Sprintf("%t", offline) &&
+				attrs[1].Name == "offline_since" &&
+				attrs[1].Value == offlineSince
+		})
+	}
+
+	testCases := []struct {
+		Name string
+
+		DryRun   bool
+		Settings model.OfflineSettings
+		Cache    bool
+
+		Setup func(*mstore.DataStore, *morchestrator.ClientRunner, *minv.Client, *mcache.Cache)
+
+		Error error
+	}{{
+		Name: "ok, device goes offline",
+
+		Settings: model.OfflineSettings{
+			Threshold:          3600,
+			NotificationEmails: []string{"user@acme.io", "admin@acme.io"},
+		},
+		Setup: func(
+			db *mstore.DataStore,
+			wf *morchestrator.ClientRunner,
+			_ *minv.Client,
+			_ *mcache.Cache,
+		) {
+			db.On("GetOfflineDevices", mock.Anything, uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{}, nil)
+			db.On("GetDevicesCheckedInBefore", mock.Anything,
+				now.Add(-time.Hour), uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{
+					{Id: "dev1", CheckInTime: &dayAgo},
+					{Id: "dev2", CheckInTime: &hourAgo},
+				}, nil)
+			db.On("SetDeviceOfflineSince", mock.Anything, "dev1", &dayAgo).
+				Return(nil)
+			wf.On("SubmitUpdateDeviceConnectivityJob", mock.Anything,
+				matchConnectivity("dev1", true)).
+				Return(nil)
+			wf.On("SubmitOfflineDevicesNotification", mock.Anything,
+				mock.MatchedBy(func(n orchestrator.OfflineDevicesNotification) bool {
+					return n.TenantID == "tenant" &&
+						n.Recipients == "user@acme.io,admin@acme.io" &&
+						n.DeviceCount == 1 &&
+						n.Devices == "dev1 (last check-in: 2025-12-31T12:00:00Z)"
+				})).
+				Return(nil)
+		},
+	}, {
+		Name: "ok, group threshold disables detection",
+
+		Settings: model.OfflineSettings{
+			Threshold:       3600,
+			GroupThresholds: map[string]uint64{"lab": 0, "critical": 600},
+		},
+		Setup: func(
+			db *mstore.DataStore,
+			_ *morchestrator.ClientRunner,
+			inv *minv.Client,
+			_ *mcache.Cache,
+		) {
+			db.On("GetOfflineDevices", mock.Anything, uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{}, nil)
+			db.On("GetDevicesCheckedInBefore", mock.Anything,
+				now.Add(-10*time.Minute), uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{
+					{Id: "dev1", CheckInTime: &dayAgo},
+				}, nil)
+			inv.On("GetDeviceGroups", mock.Anything, "tenant", "dev1").
+				Return([]string{"lab"}, nil)
+		},
+	}, {
+		Name: "ok, device back online",
+
+		Settings: model.OfflineSettings{Threshold: 7200},
+		Cache:    true,
+		Setup: func(
+			db *mstore.DataStore,
+			wf *morchestrator.ClientRunner,
+			_ *minv.Client,
+			c *mcache.Cache,
+		) {
+			db.On("GetOfflineDevices", mock.Anything, uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{
+					{Id: "dev1", CheckInTime: &dayAgo, OfflineSince: &dayAgo},
+				}, nil)
+			c.On("GetCheckInTimes", mock.Anything, "tenant", []string{"dev1"}).
+				Return([]*time.Time{&hourAgo}, nil)
+			db.On("SetDeviceOfflineSince", mock.Anything, "dev1", (*time.Time)(nil)).
+				Return(nil)
+			wf.On("SubmitUpdateDeviceConnectivityJob", mock.Anything,
+				matchConnectivity("dev1", false)).
+				Return(nil)
+			db.On("GetDevicesCheckedInBefore", mock.Anything,
+				now.Add(-2*time.Hour), uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{}, nil)
+		},
+	}, {
+		Name: "ok, dry run",
+
+		DryRun: true,
+		Settings: model.OfflineSettings{
+			Threshold:          3600,
+			NotificationEmails: []string{"user@acme.io"},
+		},
+		Setup: func(
+			db *mstore.DataStore,
+			_ *morchestrator.ClientRunner,
+			_ *minv.Client,
+			_ *mcache.Cache,
+		) {
+			db.On("GetOfflineDevices", mock.Anything, uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{
+					{Id: "dev2", CheckInTime: &hourAgo, OfflineSince: &dayAgo},
+				}, nil)
+			db.On("GetDevicesCheckedInBefore", mock.Anything,
+				now.Add(-time.Hour), uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{
+					{Id: "dev1", CheckInTime: &dayAgo},
+				}, nil)
+		},
+	}, {
+		Name: "ok, detection disabled",
+
+		Settings: model.OfflineSettings{},
+		Setup: func(
+			db *mstore.DataStore,
+			_ *morchestrator.ClientRunner,
+			_ *minv.Client,
+			_ *mcache.Cache,
+		) {
+			db.On("GetOfflineDevices", mock.Anything, uint(0), uint(offlineDevicesBatchSize)).
+				Return([]model.Device{}, nil)
+		},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := &mstore.DataStore{}
+			wf := &morchestrator.ClientRunner{}
+			inv := &minv.Client{}
+			c := &mcache.Cache{}
+			defer db.AssertExpectations(t)
+			defer wf.AssertExpectations(t)
+			defer inv.AssertExpectations(t)
+			defer c.AssertExpectations(t)
+
+			settings := tc.Settings
+			settings.TenantID = "tenant"
+			db.On("ListOfflineSettings", ctx).
+				Return([]model.OfflineSettings{settings}, nil)
+			tc.Setup(db, wf, inv, c)
+
+			devauth := NewDevAuth(db, wf, nil, Config{}).
+				WithClock(utils.NewMockClock(now.Unix()))
+			devauth.invClient = inv
+			if tc.Cache {
+				devauth = devauth.WithCache(c)
+			}
+			err := devauth.CheckOfflineDevices(ctx, tc.DryRun)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("error, datastore", func(t *testing.T) {
+		ctx := context.Background()
+		db := &mstore.DataStore{}
+		defer db.AssertExpectations(t)
+		db.On("ListOfflineSettings", ctx).
+			Return(nil, errors.New("connection error"))
+
+		devauth := NewDevAuth(db, nil, nil, Config{})
+		err := devauth.CheckOfflineDevices(ctx, false)
+		assert.EqualError(t, err, "failed to list offline settings: connection error")
+	})
+}
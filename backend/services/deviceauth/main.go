@@ -33,6 +33,7 @@ import (
 	"github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator"
 	"github.com/mendersoftware/mender-server/services/deviceauth/cmd"
 	dconfig "github.com/mendersoftware/mender-server/services/deviceauth/config"
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
 	"github.com/mendersoftware/mender-server/services/deviceauth/store/mongo"
 )
 
@@ -156,6 +157,20 @@ func doMain(args []string) {
 
 			Action: cmdPropagateReporting,
 		},
+		{
+			Name: "check-offline-devices",
+			Usage: "Flag the devices which stopped checking in as offline " +
+				"and notify the integrations",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name: "dry-run",
+					Usage: "Do not perform any modifications," +
+						" only log the devices which changed connectivity",
+				},
+			},
+
+			Action: cmdCheckOfflineDevices,
+		},
 		{
 			Name:  "maintenance",
 			Usage: "Run maintenance operations and exit",
@@ -390,6 +405,42 @@ func cmdPropagateReporting(args *cli.Context) error {
 	return nil
 }
 
+func cmdCheckOfflineDevices(args *cli.Context) error {
+	db, err := mongo.NewDataStoreMongo(makeDataStoreConfig())
+	if err != nil {
+		return err
+	}
+
+	wflows := orchestrator.NewClient(orchestrator.Config{
+		OrchestratorAddr: config.Config.GetString(
+			dconfig.SettingOrchestratorAddr,
+		),
+		Timeout: time.Duration(30) * time.Second,
+	})
+	app := devauth.NewDevAuth(db, wflows, nil, devauth.Config{
+		InventoryAddr: config.Config.GetString(dconfig.SettingInventoryAddr),
+	})
+
+	cacheConnStr := config.Config.GetString(dconfig.SettingRedisConnectionString)
+	if cacheConnStr == "" {
+		cacheConnStr = config.Config.GetString(dconfig.SettingRedisAddr)
+	}
+	if cacheConnStr != "" {
+		// the cache holds the most recent check-in times
+		srvCache, _, err := setupRedis(config.Config, cacheConnStr)
+		if err != nil {
+			return err
+		}
+		app = app.WithCache(srvCache)
+	}
+
+	err = app.CheckOfflineDevices(context.Background(), args.Bool("dry-run"))
+	if err != nil {
+		return cli.NewExitError(err, 7)
+	}
+	return nil
+}
+
 func makeDataStoreConfig() mongo.DataStoreMongoConfig {
 	return mongo.DataStoreMongoConfig{
 		ConnectionString: config.Config.GetString(dconfig.SettingDb),
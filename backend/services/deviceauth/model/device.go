@@ -36,6 +36,8 @@ const (
 	DevKeyIdDataStruct = "id_data_struct"
 	DevKeyIdDataSha256 = "id_data_sha256"
 	DevKeyStatus       = "status"
+	DevKeyCheckInTime  = "check_in_time"
+	DevKeyOfflineSince = "offline_since"
 )
 
 var (
@@ -61,6 +63,7 @@ type Device struct {
 	UpdatedTs       time.Time              `json:"updated_ts" bson:"updated_ts,omitempty"`
 	AuthSets        []AuthSet              `json:"auth_sets" bson:"-"`
 	CheckInTime     *time.Time             `json:"check_in_time,omitempty" bson:"check_in_time,omitempty"` // nolint:lll
+	OfflineSince    *time.Time             `json:"offline_since,omitempty" bson:"offline_since,omitempty"` // nolint:lll
 	//ApiLimits override tenant-wide quota/burst config
 	ApiLimits ratelimits.ApiLimits `json:"-" bson:"api_limits"`
 
@@ -0,0 +1,120 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinOfflineThreshold is the smallest (non-zero) offline threshold
+	// in seconds
+	MinOfflineThreshold = 60
+)
+
+var validGroupNameRegex = regexp.MustCompile("^[A-Za-z0-9_-]+$")
+
+// OfflineSettings configures the detection of the devices which stopped
+// checking in with the server.
+type OfflineSettings struct {
+	// Threshold is the time in seconds since the last check-in after which
+	// a device is considered offline unless a group threshold applies;
+	// zero disables the detection for devices without a group threshold.
+	Threshold uint64 `json:"threshold" bson:"threshold"`
+	// GroupThresholds overrides the threshold for the devices in the
+	// given groups; a zero value disables the detection for the group.
+	GroupThresholds map[string]uint64 `json:"group_thresholds,omitempty" bson:"group_thresholds,omitempty"` // nolint:lll
+	// NotificationEmails receive a digest of the devices which went offline.
+	NotificationEmails []string `json:"notification_emails,omitempty" bson:"notification_emails,omitempty"` // nolint:lll
+
+	TenantID string `json:"-" bson:"tenant_id"`
+}
+
+func validateThreshold(value interface{}) error {
+	threshold, _ := value.(uint64)
+	if threshold > 0 && threshold < MinOfflineThreshold {
+		return errors.Errorf("must be zero or at least %d seconds", MinOfflineThreshold)
+	}
+	return nil
+}
+
+func (s OfflineSettings) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Threshold, validation.By(validateThreshold)),
+		validation.Field(&s.GroupThresholds,
+			validation.Each(validation.By(validateThreshold)),
+			validation.By(func(value interface{}) error {
+				for group := range s.GroupThresholds {
+					if len(group) > 1024 || !validGroupNameRegex.MatchString(group) {
+						return errors.Errorf("invalid group name %q", group)
+					}
+				}
+				return nil
+			}),
+		),
+		validation.Field(&s.NotificationEmails,
+			validation.Length(0, 100),
+			validation.Each(validation.Required, is.EmailFormat),
+		),
+	)
+}
+
+// ThresholdFor returns the offline threshold for a device in the given
+// groups: the smallest non-zero threshold among the groups with a
+// threshold, or the default threshold if none of the groups has one.
+// A zero group threshold disables the detection for the group.
+func (s OfflineSettings) ThresholdFor(groups []string) time.Duration {
+	var (
+		threshold uint64
+		found     bool
+	)
+	for _, group := range groups {
+		if t, ok := s.GroupThresholds[group]; ok {
+			found = true
+			if t > 0 && (threshold == 0 || t < threshold) {
+				threshold = t
+			}
+		}
+	}
+	if !found {
+		threshold = s.Threshold
+	}
+	return time.Duration(threshold) * time.Second
+}
+
+// MinThreshold returns the smallest configured offline threshold, or zero
+// if the detection is disabled.
+func (s OfflineSettings) MinThreshold() time.Duration {
+	threshold := s.Threshold
+	for _, t := range s.GroupThresholds {
+		if t > 0 && (threshold == 0 || t < threshold) {
+			threshold = t
+		}
+	}
+	return time.Duration(threshold) * time.Second
+}
+
+// DeviceConnectivity describes a change of the device connectivity
+// published to the integrations.
+type DeviceConnectivity struct {
+	Offline      bool       `json:"offline"`
+	OfflineSince *time.Time `json:"offline_since,omitempty"`
+	CheckInTime  *time.Time `json:"check_in_time,omitempty"`
+}
@@ -34,6 +34,7 @@ import (
 	api_http "github.com/mendersoftware/mender-server/services/deviceauth/api/http"
 	"github.com/mendersoftware/mender-server/services/deviceauth/cache"
 	"github.com/mendersoftware/mender-server/services/deviceauth/client/orchestrator"
+	"github.com/mendersoftware/mender-server/services/deviceauth/client/tenantadm"
 	dconfig "github.com/mendersoftware/mender-server/services/deviceauth/config"
 	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
 	"github.com/mendersoftware/mender-server/services/deviceauth/jwt"
@@ -84,12 +85,18 @@ func RunServer(c config.Reader) error {
 			ExpirationTime: int64(c.GetInt(dconfig.SettingJWTExpirationTimeout)),
 			InventoryAddr:  config.Config.GetString(dconfig.SettingInventoryAddr),
 
+			DefaultTenantToken: c.GetString(dconfig.SettingDefaultTenantToken),
+
 			EnableReporting: config.Config.GetBool(dconfig.SettingEnableReporting),
+			HaveAuditLogs:   config.Config.GetBool(dconfig.SettingEnableAudit),
 		})
 
 	if jwtFallbackHandler != nil {
 		devauth = devauth.WithJWTFallbackHandler(jwtFallbackHandler)
 	}
+	if tadmAddr := c.GetString(dconfig.SettingTenantAdmAddr); tadmAddr != "" {
+		devauth = devauth.WithTenantAdm(tenantadm.NewClient(tadmAddr, false))
+	}
 
 	var apiOptions []api_http.Option
 
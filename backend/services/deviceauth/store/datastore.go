@@ -17,6 +17,7 @@ package store
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
 	"github.com/mendersoftware/mender-server/pkg/mongo/oid"
@@ -38,6 +39,8 @@ var (
 	ErrObjectExists = errors.New("object exists")
 	// device status unknown
 	ErrDevStatusBroken = errors.New("cannot qualify device status")
+	// offline detection settings not found
+	ErrOfflineSettingsNotFound = errors.New("offline settings not found")
 )
 
 const (
@@ -142,6 +145,32 @@ type DataStore interface {
 	// fetch limit information from data store
 	GetLimit(ctx context.Context, name string) (*model.Limit, error)
 
+	// list accepted devices which last checked in before the given time
+	// and are not flagged as offline
+	GetDevicesCheckedInBefore(
+		ctx context.Context,
+		before time.Time,
+		skip,
+		limit uint,
+	) ([]model.Device, error)
+
+	// list devices flagged as offline
+	GetOfflineDevices(ctx context.Context, skip, limit uint) ([]model.Device, error)
+
+	// flags the device as offline since the given time, or clears the
+	// flag if since is nil
+	SetDeviceOfflineSince(ctx context.Context, deviceID string, since *time.Time) error
+
+	// fetch the tenant's offline detection settings
+	// returns ErrOfflineSettingsNotFound if not configured
+	GetOfflineSettings(ctx context.Context) (*model.OfflineSettings, error)
+
+	// put the tenant's offline detection settings into data store
+	PutOfflineSettings(ctx context.Context, settings model.OfflineSettings) error
+
+	// list the offline detection settings of all the tenants
+	ListOfflineSettings(ctx context.Context) ([]model.OfflineSettings, error)
+
 	// get the number of devices with a given admission status
 	// computed based on aggregated auth set statuses
 	GetDevCountByStatus(ctx context.Context, status string) (int, error)
@@ -157,4 +186,8 @@ type DataStore interface {
 	ListTenantsIds(
 		ctx context.Context,
 	) ([]string, error)
+
+	// removes all the devices, auth sets, tokens, limits and settings of
+	// the tenant
+	DeleteTenant(ctx context.Context, tenantID string) error
 }
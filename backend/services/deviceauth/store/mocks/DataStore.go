@@ -29,6 +29,8 @@ import (
 	oid "github.com/mendersoftware/mender-server/pkg/mongo/oid"
 
 	store "github.com/mendersoftware/mender-server/services/deviceauth/store"
+
+	time "time"
 )
 
 // DataStore is an autogenerated mock type for the DataStore type
@@ -162,6 +164,24 @@ func (_m *DataStore) DeleteLimit(ctx context.Context, lim string) error {
 	return r0
 }
 
+// DeleteTenant provides a mock function with given fields: ctx, tenantID
+func (_m *DataStore) DeleteTenant(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTenant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteToken provides a mock function with given fields: ctx, jti
 func (_m *DataStore) DeleteToken(ctx context.Context, jti oid.ObjectID) error {
 	ret := _m.Called(ctx, jti)
@@ -500,6 +520,36 @@ func (_m *DataStore) GetDevices(ctx context.Context, skip uint, limit uint, filt
 	return r0, r1
 }
 
+// GetDevicesCheckedInBefore provides a mock function with given fields: ctx, before, skip, limit
+func (_m *DataStore) GetDevicesCheckedInBefore(ctx context.Context, before time.Time, skip uint, limit uint) ([]model.Device, error) {
+	ret := _m.Called(ctx, before, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDevicesCheckedInBefore")
+	}
+
+	var r0 []model.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, uint) ([]model.Device, error)); ok {
+		return rf(ctx, before, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, uint) []model.Device); ok {
+		r0 = rf(ctx, before, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uint, uint) error); ok {
+		r1 = rf(ctx, before, skip, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLimit provides a mock function with given fields: ctx, name
 func (_m *DataStore) GetLimit(ctx context.Context, name string) (*model.Limit, error) {
 	ret := _m.Called(ctx, name)
@@ -530,6 +580,66 @@ func (_m *DataStore) GetLimit(ctx context.Context, name string) (*model.Limit, e
 	return r0, r1
 }
 
+// GetOfflineDevices provides a mock function with given fields: ctx, skip, limit
+func (_m *DataStore) GetOfflineDevices(ctx context.Context, skip uint, limit uint) ([]model.Device, error) {
+	ret := _m.Called(ctx, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOfflineDevices")
+	}
+
+	var r0 []model.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) ([]model.Device, error)); ok {
+		return rf(ctx, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []model.Device); ok {
+		r0 = rf(ctx, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, skip, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOfflineSettings provides a mock function with given fields: ctx
+func (_m *DataStore) GetOfflineSettings(ctx context.Context) (*model.OfflineSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOfflineSettings")
+	}
+
+	var r0 *model.OfflineSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.OfflineSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.OfflineSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OfflineSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetToken provides a mock function with given fields: ctx, jti
 func (_m *DataStore) GetToken(ctx context.Context, jti oid.ObjectID) (*jwt.Token, error) {
 	ret := _m.Called(ctx, jti)
@@ -560,6 +670,36 @@ func (_m *DataStore) GetToken(ctx context.Context, jti oid.ObjectID) (*jwt.Token
 	return r0, r1
 }
 
+// ListOfflineSettings provides a mock function with given fields: ctx
+func (_m *DataStore) ListOfflineSettings(ctx context.Context) ([]model.OfflineSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOfflineSettings")
+	}
+
+	var r0 []model.OfflineSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.OfflineSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.OfflineSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OfflineSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListTenantsIds provides a mock function with given fields: ctx
 func (_m *DataStore) ListTenantsIds(ctx context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
@@ -644,6 +784,24 @@ func (_m *DataStore) PutLimit(ctx context.Context, lim model.Limit) error {
 	return r0
 }
 
+// PutOfflineSettings provides a mock function with given fields: ctx, settings
+func (_m *DataStore) PutOfflineSettings(ctx context.Context, settings model.OfflineSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutOfflineSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OfflineSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RejectAuthSetsForDevice provides a mock function with given fields: ctx, deviceID
 func (_m *DataStore) RejectAuthSetsForDevice(ctx context.Context, deviceID string) error {
 	ret := _m.Called(ctx, deviceID)
@@ -662,6 +820,24 @@ func (_m *DataStore) RejectAuthSetsForDevice(ctx context.Context, deviceID strin
 	return r0
 }
 
+// SetDeviceOfflineSince provides a mock function with given fields: ctx, deviceID, since
+func (_m *DataStore) SetDeviceOfflineSince(ctx context.Context, deviceID string, since *time.Time) error {
+	ret := _m.Called(ctx, deviceID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceOfflineSince")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
+		r0 = rf(ctx, deviceID, since)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreMigrationVersion provides a mock function with given fields: ctx, version
 func (_m *DataStore) StoreMigrationVersion(ctx context.Context, version *migrate.Version) error {
 	ret := _m.Called(ctx, version)
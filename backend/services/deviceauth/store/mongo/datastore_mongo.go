@@ -40,12 +40,13 @@ import (
 )
 
 const (
-	DbVersion     = "2.0.0"
-	DbName        = "deviceauth"
-	DbDevicesColl = "devices"
-	DbAuthSetColl = "auth_sets"
-	DbTokensColl  = "tokens"
-	DbLimitsColl  = "limits"
+	DbVersion             = "2.1.0"
+	DbName                = "deviceauth"
+	DbDevicesColl         = "devices"
+	DbAuthSetColl         = "auth_sets"
+	DbTokensColl          = "tokens"
+	DbLimitsColl          = "limits"
+	DbOfflineSettingsColl = "offline_settings"
 
 	DbKeyDeviceRevision = "revision"
 	dbFieldID           = "_id"
@@ -58,6 +59,8 @@ const (
 	dbFieldTenantClaim  = "mender.tenant"
 	dbFieldName         = "name"
 	dbFieldSubject      = "sub"
+	dbFieldCheckInTime  = "check_in_time"
+	dbFieldOfflineSince = "offline_since"
 )
 
 var (
@@ -567,6 +570,10 @@ func (db *DataStoreMongo) MigrateTenant(ctx context.Context, database, version s
 			ds:  db,
 			ctx: ctx,
 		},
+		&migration_2_1_0{
+			ds:  db,
+			ctx: ctx,
+		},
 	}
 
 	ver, err := migrate.NewVersion(version)
@@ -941,6 +948,142 @@ func (db *DataStoreMongo) GetLimit(ctx context.Context, name string) (*model.Lim
 	return &lim, nil
 }
 
+func (db *DataStoreMongo) findDevices(
+	ctx context.Context,
+	filter bson.D,
+	skip,
+	limit uint,
+) ([]model.Device, error) {
+	const MaxInt64 = int64(^uint64(1 << 63))
+	res := []model.Device{}
+	collDevs := db.client.
+		Database(DbName).
+		Collection(DbDevicesColl)
+
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: dbFieldID, Value: 1}}).
+		SetSkip(int64(skip) & MaxInt64)
+	if limit > 0 {
+		findOpts.SetLimit(int64(limit))
+	}
+
+	cursor, err := collDevs.Find(ctx, ctxstore.WithTenantID(ctx, filter), findOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch device list")
+	}
+	if err := cursor.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *DataStoreMongo) GetDevicesCheckedInBefore(
+	ctx context.Context,
+	before time.Time,
+	skip,
+	limit uint,
+) ([]model.Device, error) {
+	return db.findDevices(ctx, bson.D{
+		{Key: dbFieldStatus, Value: model.DevStatusAccepted},
+		{Key: dbFieldCheckInTime, Value: bson.D{{Key: "$lt", Value: before}}},
+		{Key: dbFieldOfflineSince, Value: bson.D{{Key: "$exists", Value: false}}},
+	}, skip, limit)
+}
+
+func (db *DataStoreMongo) GetOfflineDevices(
+	ctx context.Context,
+	skip,
+	limit uint,
+) ([]model.Device, error) {
+	return db.findDevices(ctx, bson.D{
+		{Key: dbFieldOfflineSince, Value: bson.D{{Key: "$exists", Value: true}}},
+	}, skip, limit)
+}
+
+func (db *DataStoreMongo) SetDeviceOfflineSince(
+	ctx context.Context,
+	deviceID string,
+	since *time.Time,
+) error {
+	c := db.client.Database(DbName).Collection(DbDevicesColl)
+
+	var update bson.D
+	if since != nil {
+		update = bson.D{{Key: "$set", Value: bson.D{
+			{Key: dbFieldOfflineSince, Value: *since},
+		}}}
+	} else {
+		update = bson.D{{Key: "$unset", Value: bson.D{
+			{Key: dbFieldOfflineSince, Value: ""},
+		}}}
+	}
+	res, err := c.UpdateOne(ctx,
+		ctxstore.WithTenantID(ctx, bson.D{{Key: dbFieldID, Value: deviceID}}),
+		update,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to update device")
+	} else if res.MatchedCount < 1 {
+		return store.ErrDevNotFound
+	}
+	return nil
+}
+
+func (db *DataStoreMongo) GetOfflineSettings(
+	ctx context.Context,
+) (*model.OfflineSettings, error) {
+	c := db.client.Database(DbName).Collection(DbOfflineSettingsColl)
+
+	var settings model.OfflineSettings
+	err := c.FindOne(ctx, ctxstore.WithTenantID(ctx, bson.D{})).Decode(&settings)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, store.ErrOfflineSettingsNotFound
+		}
+		return nil, errors.Wrap(err, "failed to fetch offline settings")
+	}
+	return &settings, nil
+}
+
+func (db *DataStoreMongo) PutOfflineSettings(
+	ctx context.Context,
+	settings model.OfflineSettings,
+) error {
+	c := db.client.Database(DbName).Collection(DbOfflineSettingsColl)
+	if id := identity.FromContext(ctx); id != nil {
+		settings.TenantID = id.Tenant
+	} else {
+		settings.TenantID = ""
+	}
+
+	_, err := c.ReplaceOne(ctx,
+		bson.D{{Key: dbFieldTenantID, Value: settings.TenantID}},
+		settings,
+		mopts.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set offline settings")
+	}
+	return nil
+}
+
+func (db *DataStoreMongo) ListOfflineSettings(
+	ctx context.Context,
+) ([]model.OfflineSettings, error) {
+	c := db.client.Database(DbName).Collection(DbOfflineSettingsColl)
+
+	res := []model.OfflineSettings{}
+	cursor, err := c.Find(ctx, bson.D{},
+		mopts.Find().SetSort(bson.D{{Key: dbFieldTenantID, Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch offline settings")
+	}
+	if err := cursor.All(ctx, &res); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch offline settings")
+	}
+	return res, nil
+}
+
 func (db *DataStoreMongo) GetDevCountByStatus(ctx context.Context, status string) (int, error) {
 	var (
 		fltr     = bson.D{}
@@ -1076,6 +1219,25 @@ func (db *DataStoreMongo) ListTenantsIds(
 	return ids, nil
 }
 
+func (db *DataStoreMongo) DeleteTenant(ctx context.Context, tenantID string) error {
+	database := db.client.Database(DbName)
+	for _, collName := range []string{
+		DbDevicesColl, DbAuthSetColl, DbLimitsColl, DbOfflineSettingsColl,
+	} {
+		_, err := database.Collection(collName).
+			DeleteMany(ctx, bson.M{dbFieldTenantID: tenantID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove the tenant %s", collName)
+		}
+	}
+	_, err := database.Collection(DbTokensColl).
+		DeleteMany(ctx, bson.M{dbFieldTenantClaim: tenantID})
+	if err != nil {
+		return errors.Wrap(err, "failed to remove the tenant tokens")
+	}
+	return nil
+}
+
 func (db *DataStoreMongo) ListAllDevices(
 	ctx context.Context,
 	fields ...string,
@@ -782,6 +782,169 @@ func TestStoreDeleteTokens(t *testing.T) {
 	}
 }
 
+func TestStoreDeleteTenant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestStoreDeleteTenant in short mode.")
+	}
+
+	ctx := context.Background()
+	d := getDb(ctx)
+	database := d.client.Database(DbName)
+	collNames := []string{DbDevicesColl, DbAuthSetColl, DbLimitsColl, DbOfflineSettingsColl}
+	for _, tenantID := range []string{"foo", "bar"} {
+		for _, collName := range collNames {
+			_, err := database.Collection(collName).InsertOne(ctx, bson.M{
+				dbFieldID:       tenantID + "-" + collName,
+				dbFieldTenantID: tenantID,
+			})
+			assert.NoError(t, err)
+		}
+		_, err := database.Collection(DbTokensColl).InsertOne(ctx, bson.M{
+			dbFieldID:          tenantID + "-token",
+			dbFieldTenantClaim: tenantID,
+		})
+		assert.NoError(t, err)
+	}
+
+	err := d.DeleteTenant(ctx, "foo")
+	assert.NoError(t, err)
+
+	for _, collName := range collNames {
+		c := database.Collection(collName)
+		n, err := c.CountDocuments(ctx, bson.M{dbFieldTenantID: "foo"})
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+		n, err = c.CountDocuments(ctx, bson.M{dbFieldTenantID: "bar"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	}
+	c := database.Collection(DbTokensColl)
+	n, err := c.CountDocuments(ctx, bson.M{dbFieldTenantClaim: "foo"})
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	n, err = c.CountDocuments(ctx, bson.M{dbFieldTenantClaim: "bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestStoreOfflineDevices(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestStoreOfflineDevices in short mode.")
+	}
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "foo",
+	})
+	d := getDb(ctx)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	old := now.Add(-2 * time.Hour)
+	devices := []model.Device{{
+		Id:          "1",
+		IdData:      "1",
+		Status:      model.DevStatusAccepted,
+		CheckInTime: &old,
+	}, {
+		Id:          "2",
+		IdData:      "2",
+		Status:      model.DevStatusAccepted,
+		CheckInTime: &now,
+	}, {
+		Id:          "3",
+		IdData:      "3",
+		Status:      model.DevStatusRejected,
+		CheckInTime: &old,
+	}, {
+		Id:     "4",
+		IdData: "4",
+		Status: model.DevStatusAccepted,
+	}}
+	for _, dev := range devices {
+		dev.IdDataSha256 = []byte(dev.Id)
+		err := d.AddDevice(ctx, dev)
+		assert.NoError(t, err)
+	}
+	// device in another tenant
+	err := d.AddDevice(context.Background(), model.Device{
+		Id:           "5",
+		IdData:       "5",
+		IdDataSha256: []byte("5"),
+		Status:       model.DevStatusAccepted,
+		CheckInTime:  &old,
+	})
+	assert.NoError(t, err)
+
+	res, err := d.GetDevicesCheckedInBefore(ctx, now.Add(-time.Hour), 0, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "1", res[0].Id)
+	}
+
+	err = d.SetDeviceOfflineSince(ctx, "1", &old)
+	assert.NoError(t, err)
+	err = d.SetDeviceOfflineSince(ctx, "missing", &old)
+	assert.ErrorIs(t, err, store.ErrDevNotFound)
+
+	res, err = d.GetDevicesCheckedInBefore(ctx, now.Add(-time.Hour), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, res, 0)
+
+	res, err = d.GetOfflineDevices(ctx, 0, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "1", res[0].Id)
+		if assert.NotNil(t, res[0].OfflineSince) {
+			assert.WithinDuration(t, old, *res[0].OfflineSince, 0)
+		}
+	}
+
+	err = d.SetDeviceOfflineSince(ctx, "1", nil)
+	assert.NoError(t, err)
+	res, err = d.GetOfflineDevices(ctx, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, res, 0)
+}
+
+func TestStoreOfflineSettings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestStoreOfflineSettings in short mode.")
+	}
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "foo",
+	})
+	d := getDb(ctx)
+
+	_, err := d.GetOfflineSettings(ctx)
+	assert.ErrorIs(t, err, store.ErrOfflineSettingsNotFound)
+
+	settings := model.OfflineSettings{
+		Threshold:          3600,
+		GroupThresholds:    map[string]uint64{"prod": 600},
+		NotificationEmails: []string{"ops@example.com"},
+	}
+	err = d.PutOfflineSettings(ctx, settings)
+	assert.NoError(t, err)
+	settings.Threshold = 7200
+	err = d.PutOfflineSettings(ctx, settings)
+	assert.NoError(t, err)
+	err = d.PutOfflineSettings(context.Background(), model.OfflineSettings{
+		Threshold: 600,
+	})
+	assert.NoError(t, err)
+
+	res, err := d.GetOfflineSettings(ctx)
+	assert.NoError(t, err)
+	settings.TenantID = "foo"
+	assert.Equal(t, &settings, res)
+
+	all, err := d.ListOfflineSettings(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, all, 2) {
+		assert.Equal(t, "", all[0].TenantID)
+		assert.Equal(t, "foo", all[1].TenantID)
+	}
+}
+
 func TestStoreDeleteTokenByDevId(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping TestDeleteTokenByDevId in short mode.")
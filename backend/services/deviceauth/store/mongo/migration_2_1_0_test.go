@@ -0,0 +1,57 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration_2_1_0(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestMigration_2_1_0 in short mode.")
+	}
+	ctx := context.Background()
+
+	db.Wipe()
+	ds := NewDataStoreMongoWithClient(db.Client())
+	m := &migration_2_1_0{
+		ds:  ds,
+		ctx: ctx,
+	}
+	err := m.Up(m.Version())
+	assert.NoError(t, err)
+
+	indexNames := func(collName string) []string {
+		cur, err := ds.client.Database(DbName).
+			Collection(collName).
+			Indexes().
+			List(ctx)
+		assert.NoError(t, err)
+		var indexes []bson.M
+		assert.NoError(t, cur.All(ctx, &indexes))
+		names := make([]string, len(indexes))
+		for i, idx := range indexes {
+			names[i], _ = idx["name"].(string)
+		}
+		return names
+	}
+	assert.Contains(t, indexNames(DbDevicesColl), "tenant_id_status_check_in_time")
+	assert.Contains(t, indexNames(DbDevicesColl), "tenant_id_offline_since")
+	assert.Contains(t, indexNames(DbOfflineSettingsColl), "tenant_id")
+}
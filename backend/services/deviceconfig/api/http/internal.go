@@ -140,6 +140,85 @@ func (api *InternalAPI) ProvisionDevice(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+// PUT /tenants/:tenant_id/configurations/device/:device_id
+func (api *InternalAPI) SetConfiguration(c *gin.Context) {
+	deviceID := c.Param("device_id")
+	tenantID := c.Param("tenant_id")
+	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
+		Subject: deviceID,
+		Tenant:  tenantID,
+	})
+
+	var attrs model.Attributes
+	if err := c.ShouldBindJSON(&attrs); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request parameters"),
+		)
+		return
+	} else if err = attrs.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request parameters"),
+		)
+		return
+	}
+
+	err := api.App.SetConfiguration(ctx, deviceID, attrs)
+	if renderValidationError(c, err) {
+		return
+	} else if err != nil {
+		_ = c.Error(err)
+		rest.RenderError(c,
+			http.StatusInternalServerError,
+			errors.New(http.StatusText(http.StatusInternalServerError)),
+		)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// PUT /tenants/:tenant_id/configurations/device/:device_id/reported
+func (api *InternalAPI) SetReportedConfiguration(c *gin.Context) {
+	deviceID := c.Param("device_id")
+	tenantID := c.Param("tenant_id")
+	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
+		Subject: deviceID,
+		Tenant:  tenantID,
+	})
+
+	var attrs model.Attributes
+	if err := c.ShouldBindJSON(&attrs); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request parameters"),
+		)
+		return
+	} else if err = attrs.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request parameters"),
+		)
+		return
+	}
+
+	err := api.App.SetReportedConfiguration(ctx, deviceID, attrs)
+	if err != nil {
+		switch cause := errors.Cause(err); cause {
+		case store.ErrDeviceNoExist:
+			rest.RenderError(c, http.StatusNotFound, cause)
+		default:
+			_ = c.Error(err)
+			rest.RenderError(c,
+				http.StatusInternalServerError,
+				errors.New(http.StatusText(http.StatusInternalServerError)),
+			)
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 // PATCH /tenants/:tenant_id/configurations/device/:device_id
 func (api *InternalAPI) UpdateConfiguration(c *gin.Context) {
 	deviceID := c.Param("device_id")
@@ -165,7 +244,9 @@ func (api *InternalAPI) UpdateConfiguration(c *gin.Context) {
 	}
 
 	err := api.App.UpdateConfiguration(ctx, deviceID, attrs)
-	if err != nil {
+	if renderValidationError(c, err) {
+		return
+	} else if err != nil {
 		_ = c.Error(err)
 		rest.RenderError(c,
 			http.StatusInternalServerError,
@@ -639,6 +639,272 @@ func TestUpdateConfiguration(t *testing.T) {
 	}
 }
 
+func TestInternalSetConfiguration(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		DeviceID string
+		TenantID string
+		Body     interface{}
+		App      func(t *testing.T, self *testCase) *mapp.App
+
+		Code  int
+		Error error
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body: model.Attributes{{
+			Key:   "key",
+			Value: "value",
+		}},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetConfiguration",
+				matchCTXIdentity(self.TenantID),
+				self.DeviceID,
+				self.Body.(model.Attributes),
+			).Return(nil).
+				Once()
+			return app
+		},
+		Code: http.StatusNoContent,
+	}, {
+		Name: "error/internal",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body: model.Attributes{{
+			Key:   "key",
+			Value: "value",
+		}},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetConfiguration",
+				matchCTXIdentity(self.TenantID),
+				self.DeviceID,
+				self.Body.(model.Attributes),
+			).Return(errors.New("internal error"))
+			return app
+		},
+		Code:  http.StatusInternalServerError,
+		Error: errors.New(http.StatusText(http.StatusInternalServerError)),
+	}, {
+		Name: "error/invalid attribute",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body: map[string]interface{}{
+			"key": 1.0,
+		},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			return new(mapp.App)
+		},
+		Code:  http.StatusBadRequest,
+		Error: errors.New("invalid request parameters"),
+	}, {
+		Name: "error/malformed body",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body:     []byte("key:value"),
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			return new(mapp.App)
+		},
+		Code:  http.StatusBadRequest,
+		Error: errors.New("malformed request parameters"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			l := log.NewEmpty()
+			l.Logger.Out = io.Discard
+			ctx := log.WithContext(context.Background(), l)
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			path := strings.NewReplacer(
+				":tenant_id", tc.TenantID,
+				":device_id", tc.DeviceID,
+			).Replace(URIInternal + URITenant + URIConfiguration)
+
+			var body io.Reader
+			switch typ := tc.Body.(type) {
+			case []byte:
+				body = bytes.NewReader(typ)
+			default:
+				b, _ := json.Marshal(typ)
+				body = bytes.NewReader(b)
+			}
+			req, _ := http.NewRequestWithContext(
+				ctx,
+				http.MethodPut,
+				"http://localhost:8080"+path,
+				body,
+			)
+
+			w := httptest.NewRecorder()
+			api := NewRouter(app)
+			api.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.Code, w.Code)
+			if tc.Error != nil {
+				var err rest.Error
+				_ = json.Unmarshal(w.Body.Bytes(), &err)
+				assert.Regexp(t, tc.Error.Error(), err.Error())
+			} else {
+				assert.Empty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestInternalSetReportedConfiguration(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		DeviceID string
+		TenantID string
+		Body     interface{}
+		App      func(t *testing.T, self *testCase) *mapp.App
+
+		Code  int
+		Error error
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body: model.Attributes{{
+			Key:   "key",
+			Value: "value",
+		}},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetReportedConfiguration",
+				matchCTXIdentity(self.TenantID),
+				self.DeviceID,
+				self.Body.(model.Attributes),
+			).Return(nil).
+				Once()
+			return app
+		},
+		Code: http.StatusNoContent,
+	}, {
+		Name: "error/device not found",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body: model.Attributes{{
+			Key:   "key",
+			Value: "value",
+		}},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetReportedConfiguration",
+				matchCTXIdentity(self.TenantID),
+				self.DeviceID,
+				self.Body.(model.Attributes),
+			).Return(store.ErrDeviceNoExist)
+			return app
+		},
+		Code:  http.StatusNotFound,
+		Error: store.ErrDeviceNoExist,
+	}, {
+		Name: "error/internal",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body: model.Attributes{{
+			Key:   "key",
+			Value: "value",
+		}},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetReportedConfiguration",
+				matchCTXIdentity(self.TenantID),
+				self.DeviceID,
+				self.Body.(model.Attributes),
+			).Return(errors.New("internal error"))
+			return app
+		},
+		Code:  http.StatusInternalServerError,
+		Error: errors.New(http.StatusText(http.StatusInternalServerError)),
+	}, {
+		Name: "error/invalid attribute",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body: map[string]interface{}{
+			"key": 1.0,
+		},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			return new(mapp.App)
+		},
+		Code:  http.StatusBadRequest,
+		Error: errors.New("invalid request parameters"),
+	}, {
+		Name: "error/malformed body",
+
+		DeviceID: "5526343c-69e4-48a2-9f44-d4542044294b",
+		TenantID: "123456789012345678901234",
+		Body:     []byte("key:value"),
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			return new(mapp.App)
+		},
+		Code:  http.StatusBadRequest,
+		Error: errors.New("malformed request parameters"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			l := log.NewEmpty()
+			l.Logger.Out = io.Discard
+			ctx := log.WithContext(context.Background(), l)
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			path := strings.NewReplacer(
+				":tenant_id", tc.TenantID,
+				":device_id", tc.DeviceID,
+			).Replace(URIInternal + URITenant + URIReportedConfig)
+
+			var body io.Reader
+			switch typ := tc.Body.(type) {
+			case []byte:
+				body = bytes.NewReader(typ)
+			default:
+				b, _ := json.Marshal(typ)
+				body = bytes.NewReader(b)
+			}
+			req, _ := http.NewRequestWithContext(
+				ctx,
+				http.MethodPut,
+				"http://localhost:8080"+path,
+				body,
+			)
+
+			w := httptest.NewRecorder()
+			api := NewRouter(app)
+			api.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.Code, w.Code)
+			if tc.Error != nil {
+				var err rest.Error
+				_ = json.Unmarshal(w.Body.Bytes(), &err)
+				assert.Regexp(t, tc.Error.Error(), err.Error())
+			} else {
+				assert.Empty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
 func TestDecommissionDevice(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
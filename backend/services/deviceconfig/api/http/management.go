@@ -63,7 +63,9 @@ func (api *ManagementAPI) SetConfiguration(c *gin.Context) {
 	}
 
 	err = api.App.SetConfiguration(ctx, devID, configuration)
-	if err != nil {
+	if renderValidationError(c, err) {
+		return
+	} else if err != nil {
 		c.Error(err) //nolint:errcheck
 		rest.RenderError(c,
 			http.StatusInternalServerError,
@@ -149,7 +151,9 @@ func (api *ManagementAPI) DeployConfiguration(c *gin.Context) {
 	}
 
 	response, err := api.App.DeployConfiguration(ctx, device, request)
-	if err != nil {
+	if renderValidationError(c, err) {
+		return
+	} else if err != nil {
 		rest.RenderError(c,
 			http.StatusInternalServerError,
 			errors.Wrap(err, "configuration deployment failed"),
@@ -0,0 +1,228 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/plan"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/app"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+)
+
+var errInvalidProfileID = errors.New("invalid profile ID")
+
+// renderProfileError renders the errors common to the profile endpoints.
+func renderProfileError(c *gin.Context, err error) {
+	switch cause := errors.Cause(err); cause {
+	case store.ErrProfileNoExist, store.ErrDeviceNoExist:
+		rest.RenderError(c, http.StatusNotFound, cause)
+	case store.ErrProfileAlreadyExists:
+		rest.RenderError(c, http.StatusConflict, cause)
+	case app.ErrProfileNotAssigned:
+		rest.RenderError(c, http.StatusBadRequest, cause)
+	default:
+		c.Error(err) //nolint:errcheck
+		rest.RenderError(c,
+			http.StatusInternalServerError,
+			errors.New(http.StatusText(http.StatusInternalServerError)),
+		)
+	}
+}
+
+func bindProfile(c *gin.Context) (model.Profile, bool) {
+	var profile model.Profile
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return profile, false
+	}
+	if err := profile.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request body"),
+		)
+		return profile, false
+	}
+	return profile, true
+}
+
+func profileIDFromPath(c *gin.Context) (uuid.UUID, bool) {
+	profileID, err := uuid.Parse(c.Param(pathParamProfileID))
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, errInvalidProfileID)
+		return uuid.Nil, false
+	}
+	return profileID, true
+}
+
+func (api *ManagementAPI) CreateProfile(c *gin.Context) {
+	profile, ok := bindProfile(c)
+	if !ok {
+		return
+	}
+	profileID, err := api.App.CreateProfile(c.Request.Context(), profile)
+	if err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	location := URIManagement + strings.Replace(
+		URIProfile, ":"+pathParamProfileID, profileID.String(), 1,
+	)
+	c.Header("Location", location)
+	c.Status(http.StatusCreated)
+}
+
+func (api *ManagementAPI) GetProfiles(c *gin.Context) {
+	profiles, err := api.App.GetProfiles(c.Request.Context())
+	if err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, profiles)
+}
+
+func (api *ManagementAPI) GetProfile(c *gin.Context) {
+	profileID, ok := profileIDFromPath(c)
+	if !ok {
+		return
+	}
+	profile, err := api.App.GetProfile(c.Request.Context(), profileID)
+	if err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, profile)
+}
+
+func (api *ManagementAPI) UpdateProfile(c *gin.Context) {
+	profileID, ok := profileIDFromPath(c)
+	if !ok {
+		return
+	}
+	profile, ok := bindProfile(c)
+	if !ok {
+		return
+	}
+	profile.ID = profileID
+	if err := api.App.UpdateProfile(c.Request.Context(), profile); err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (api *ManagementAPI) DeleteProfile(c *gin.Context) {
+	profileID, ok := profileIDFromPath(c)
+	if !ok {
+		return
+	}
+	if err := api.App.DeleteProfile(c.Request.Context(), profileID); err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (api *ManagementAPI) DeployProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	profileID, ok := profileIDFromPath(c)
+	if !ok {
+		return
+	}
+
+	request := model.DeployConfigurationRequest{}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+
+	identity := identity.FromContext(ctx)
+	if identity == nil {
+		rest.RenderError(c, http.StatusForbidden, errInvalidIdentity)
+		return
+	}
+	// udpate control map is available only for Enterprise customers
+	if len(request.UpdateControlMap) > 0 &&
+		!plan.IsHigherOrEqual(identity.Plan, plan.PlanEnterprise) {
+		rest.RenderError(c, http.StatusForbidden, errUpdateContrloMapForbidden)
+		return
+	}
+
+	response, err := api.App.DeployProfile(ctx, profileID, request)
+	if err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (api *ManagementAPI) GetDefaults(c *gin.Context) {
+	defaults, err := api.App.GetDefaults(c.Request.Context())
+	if err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, defaults)
+}
+
+func (api *ManagementAPI) SetDefaults(c *gin.Context) {
+	var defaults model.Attributes
+	if err := c.ShouldBindJSON(&defaults); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	if err := defaults.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request body"),
+		)
+		return
+	}
+	if err := api.App.SetDefaults(c.Request.Context(), defaults); err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (api *ManagementAPI) GetEffectiveConfiguration(c *gin.Context) {
+	effective, err := api.App.GetEffectiveConfiguration(
+		c.Request.Context(), c.Param(pathParamDeviceID),
+	)
+	if err != nil {
+		renderProfileError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, effective)
+}
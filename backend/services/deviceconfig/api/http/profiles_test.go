@@ -0,0 +1,333 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/app"
+	mapp "github.com/mendersoftware/mender-server/services/deviceconfig/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+)
+
+func newProfileRequest(method, uri string, body interface{}) *http.Request {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method,
+		"http://localhost"+URIManagement+uri,
+		bytes.NewReader(b),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", enterpriseToken)
+	return req
+}
+
+func profileURI(uri string, profileID uuid.UUID) string {
+	return strings.Replace(uri, ":"+pathParamProfileID, profileID.String(), 1)
+}
+
+func TestProfiles(t *testing.T) {
+	t.Parallel()
+
+	profileID := uuid.New()
+	profile := model.Profile{
+		ID:            profileID,
+		Name:          "office",
+		Group:         "office",
+		Configuration: model.Attributes{{Key: "ntp", Value: "ntp.example.com"}},
+	}
+	testCases := []struct {
+		Name string
+
+		Request *http.Request
+		App     func(t *testing.T) *mapp.App
+
+		Status   int
+		Location string
+		Body     interface{}
+	}{{
+		Name: "ok, create",
+
+		Request: newProfileRequest("POST", URIProfiles, map[string]interface{}{
+			"name":          "office",
+			"group":         "office",
+			"configuration": map[string]string{"ntp": "ntp.example.com"},
+		}),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("CreateProfile", contextMatcher,
+				mock.MatchedBy(func(p model.Profile) bool {
+					return assert.Equal(t, "office", p.Name) &&
+						assert.Equal(t, profile.Configuration, p.Configuration)
+				})).Return(profileID, nil)
+			return a
+		},
+		Status:   http.StatusCreated,
+		Location: URIManagement + profileURI(URIProfile, profileID),
+	}, {
+		Name: "error, create, invalid profile",
+
+		Request: newProfileRequest("POST", URIProfiles, map[string]interface{}{
+			"group": "office",
+		}),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "error, create, duplicate name",
+
+		Request: newProfileRequest("POST", URIProfiles, map[string]interface{}{
+			"name": "office",
+		}),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("CreateProfile", contextMatcher, mock.Anything).
+				Return(uuid.Nil, store.ErrProfileAlreadyExists)
+			return a
+		},
+		Status: http.StatusConflict,
+	}, {
+		Name: "ok, list",
+
+		Request: newProfileRequest("GET", URIProfiles, nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetProfiles", contextMatcher).
+				Return([]model.Profile{profile}, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   []model.Profile{profile},
+	}, {
+		Name: "ok, get",
+
+		Request: newProfileRequest("GET", profileURI(URIProfile, profileID), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetProfile", contextMatcher, profileID).Return(profile, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   profile,
+	}, {
+		Name: "error, get, invalid ID",
+
+		Request: newProfileRequest("GET",
+			strings.Replace(URIProfile, ":profile_id", "foo", 1), nil),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "error, get, not found",
+
+		Request: newProfileRequest("GET", profileURI(URIProfile, profileID), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetProfile", contextMatcher, profileID).
+				Return(model.Profile{}, store.ErrProfileNoExist)
+			return a
+		},
+		Status: http.StatusNotFound,
+	}, {
+		Name: "ok, update",
+
+		Request: newProfileRequest("PUT", profileURI(URIProfile, profileID), profile),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("UpdateProfile", contextMatcher,
+				mock.MatchedBy(func(p model.Profile) bool {
+					return p.ID == profileID
+				})).Return(nil)
+			return a
+		},
+		Status: http.StatusNoContent,
+	}, {
+		Name: "ok, delete",
+
+		Request: newProfileRequest("DELETE", profileURI(URIProfile, profileID), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("DeleteProfile", contextMatcher, profileID).Return(nil)
+			return a
+		},
+		Status: http.StatusNoContent,
+	}, {
+		Name: "error, delete, internal error",
+
+		Request: newProfileRequest("DELETE", profileURI(URIProfile, profileID), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("DeleteProfile", contextMatcher, profileID).
+				Return(errors.New("internal error"))
+			return a
+		},
+		Status: http.StatusInternalServerError,
+	}, {
+		Name: "ok, deploy",
+
+		Request: newProfileRequest("POST", profileURI(URIDeployProfile, profileID),
+			model.DeployConfigurationRequest{Retries: 1}),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("DeployProfile", contextMatcher, profileID,
+				model.DeployConfigurationRequest{Retries: 1}).
+				Return(model.DeployProfileResponse{
+					Deployments: []model.DeviceDeployment{{
+						DeviceID:     "device",
+						DeploymentID: profileID,
+					}},
+				}, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body: model.DeployProfileResponse{
+			Deployments: []model.DeviceDeployment{{
+				DeviceID:     "device",
+				DeploymentID: profileID,
+			}},
+		},
+	}, {
+		Name: "error, deploy, update control map not allowed",
+
+		Request: func() *http.Request {
+			req := newProfileRequest("POST", profileURI(URIDeployProfile, profileID),
+				model.DeployConfigurationRequest{
+					UpdateControlMap: map[string]interface{}{"priority": 1},
+				})
+			req.Header.Set("Authorization", professionalToken)
+			return req
+		}(),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusForbidden,
+	}, {
+		Name: "error, deploy, not assigned",
+
+		Request: newProfileRequest("POST", profileURI(URIDeployProfile, profileID),
+			model.DeployConfigurationRequest{}),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("DeployProfile", contextMatcher, profileID, mock.Anything).
+				Return(model.DeployProfileResponse{}, app.ErrProfileNotAssigned)
+			return a
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "ok, get defaults",
+
+		Request: newProfileRequest("GET", URIDefaults, nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetDefaults", contextMatcher).
+				Return(model.Attributes{{Key: "ntp", Value: "pool.ntp.org"}}, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   map[string]string{"ntp": "pool.ntp.org"},
+	}, {
+		Name: "ok, set defaults",
+
+		Request: newProfileRequest("PUT", URIDefaults,
+			map[string]string{"ntp": "pool.ntp.org"}),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("SetDefaults", contextMatcher,
+				model.Attributes{{Key: "ntp", Value: "pool.ntp.org"}}).
+				Return(nil)
+			return a
+		},
+		Status: http.StatusNoContent,
+	}, {
+		Name: "error, set defaults, invalid value",
+
+		Request: newProfileRequest("PUT", URIDefaults,
+			map[string]int{"ntp": 1}),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "ok, effective configuration",
+
+		Request: newProfileRequest("GET",
+			strings.Replace(URIEffectiveConfig, ":device_id", "device", 1), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetEffectiveConfiguration", contextMatcher, "device").
+				Return(model.EffectiveConfiguration{
+					DeviceID:      "device",
+					Configuration: profile.Configuration,
+					Profiles:      []uuid.UUID{profileID},
+				}, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body: model.EffectiveConfiguration{
+			DeviceID:      "device",
+			Configuration: profile.Configuration,
+			Profiles:      []uuid.UUID{profileID},
+		},
+	}, {
+		Name: "error, effective configuration, device not found",
+
+		Request: newProfileRequest("GET",
+			strings.Replace(URIEffectiveConfig, ":device_id", "device", 1), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetEffectiveConfiguration", contextMatcher, "device").
+				Return(model.EffectiveConfiguration{}, store.ErrDeviceNoExist)
+			return a
+		},
+		Status: http.StatusNotFound,
+	}}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			a := tc.App(t)
+			defer a.AssertExpectations(t)
+
+			router := NewRouter(a)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.Request)
+
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.Location != "" {
+				assert.Equal(t, tc.Location, w.Header().Get("Location"))
+			}
+			if tc.Body != nil {
+				b, _ := json.Marshal(tc.Body)
+				assert.JSONEq(t, string(b), w.Body.String())
+			}
+		})
+	}
+}
@@ -27,8 +27,12 @@ import (
 
 // API URL used by the HTTP router
 const (
-	pathParamDeviceID = "device_id"
-	pathParamTenantID = "tenant_id"
+	pathParamDeviceID   = "device_id"
+	pathParamTenantID   = "tenant_id"
+	pathParamProfileID  = "profile_id"
+	pathParamVersion    = "version"
+	pathParamTargetType = "target_type"
+	pathParamTarget     = "target"
 
 	URIDevices    = "/api/devices/v1/deviceconfig"
 	URIInternal   = "/api/internal/v1/deviceconfig"
@@ -40,8 +44,22 @@ const (
 	URITenantDevice  = "/tenants/:tenant_id/devices/:device_id"
 
 	URIConfiguration       = "/configurations/device/:device_id"
+	URIReportedConfig      = "/configurations/device/:device_id/reported"
 	URIDeployConfiguration = "/configurations/device/:device_id/deploy"
 	URIDeviceConfiguration = "/configuration"
+	URIEffectiveConfig     = "/configurations/device/:device_id/effective"
+	URIDefaults            = "/configurations/defaults"
+	URIProfiles            = "/configurations/profiles"
+	URIProfile             = "/configurations/profiles/:profile_id"
+	URIDeployProfile       = "/configurations/profiles/:profile_id/deploy"
+	URIVersions            = "/configurations/device/:device_id/versions"
+	URIVersion             = "/configurations/device/:device_id/versions/:version"
+	URIVersionDiff         = "/configurations/device/:device_id/versions/:version/diff"
+	URIVersionRollback     = "/configurations/device/:device_id/versions/:version/rollback"
+	URIDrift               = "/configurations/drift"
+	URIDeviceSchema        = "/configurations/device/:device_id/schema"
+	URISchemas             = "/configurations/schemas"
+	URISchema              = "/configurations/schemas/:target_type/:target"
 
 	URIAlive  = "/alive"
 	URIHealth = "/health"
@@ -100,7 +118,9 @@ func NewRouter(app app.App, options ...Option) http.Handler {
 	intrnlGrp.POST(URITenantDevices, intrnlAPI.ProvisionDevice)
 	intrnlGrp.DELETE(URITenantDevice, intrnlAPI.DecommissionDevice)
 
+	intrnlGrp.PUT(URITenant+URIConfiguration, intrnlAPI.SetConfiguration)
 	intrnlGrp.PATCH(URITenant+URIConfiguration, intrnlAPI.UpdateConfiguration)
+	intrnlGrp.PUT(URITenant+URIReportedConfig, intrnlAPI.SetReportedConfiguration)
 	intrnlGrp.POST(URITenant+URIDeployConfiguration, intrnlAPI.DeployConfiguration)
 
 	mgmtAPI := (*ManagementAPI)(apiHandler)
@@ -111,6 +131,25 @@ func NewRouter(app app.App, options ...Option) http.Handler {
 	mgmtGrp.GET(URIConfiguration, mgmtAPI.GetConfiguration)
 	mgmtGrp.PUT(URIConfiguration, mgmtAPI.SetConfiguration)
 	mgmtGrp.POST(URIDeployConfiguration, mgmtAPI.DeployConfiguration)
+	mgmtGrp.GET(URIEffectiveConfig, mgmtAPI.GetEffectiveConfiguration)
+	mgmtGrp.GET(URIDefaults, mgmtAPI.GetDefaults)
+	mgmtGrp.PUT(URIDefaults, mgmtAPI.SetDefaults)
+	mgmtGrp.GET(URIProfiles, mgmtAPI.GetProfiles)
+	mgmtGrp.POST(URIProfiles, mgmtAPI.CreateProfile)
+	mgmtGrp.GET(URIProfile, mgmtAPI.GetProfile)
+	mgmtGrp.PUT(URIProfile, mgmtAPI.UpdateProfile)
+	mgmtGrp.DELETE(URIProfile, mgmtAPI.DeleteProfile)
+	mgmtGrp.POST(URIDeployProfile, mgmtAPI.DeployProfile)
+	mgmtGrp.GET(URIVersions, mgmtAPI.GetConfigurationVersions)
+	mgmtGrp.GET(URIVersion, mgmtAPI.GetConfigurationVersion)
+	mgmtGrp.GET(URIVersionDiff, mgmtAPI.DiffConfigurationVersions)
+	mgmtGrp.POST(URIVersionRollback, mgmtAPI.RollbackConfiguration)
+	mgmtGrp.GET(URIDrift, mgmtAPI.GetDriftedDevices)
+	mgmtGrp.GET(URIDeviceSchema, mgmtAPI.GetDeviceSchema)
+	mgmtGrp.GET(URISchemas, mgmtAPI.GetSchemas)
+	mgmtGrp.GET(URISchema, mgmtAPI.GetSchema)
+	mgmtGrp.PUT(URISchema, mgmtAPI.SetSchema)
+	mgmtGrp.DELETE(URISchema, mgmtAPI.DeleteSchema)
 
 	devAPI := (*DevicesAPI)(apiHandler)
 	devGrp := router.Group(URIDevices)
@@ -0,0 +1,162 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+)
+
+// validationErrorResponse extends the standard error response with the
+// location of each schema violation.
+type validationErrorResponse struct {
+	rest.Error
+	Details []model.SchemaError `json:"details"`
+}
+
+// renderValidationError renders a 400 response listing the schema
+// violations if err is a *model.ValidationError; it returns false for any
+// other error.
+func renderValidationError(c *gin.Context, err error) bool {
+	var verr *model.ValidationError
+	if !errors.As(err, &verr) {
+		return false
+	}
+	_ = c.Error(err)
+	c.JSON(http.StatusBadRequest, validationErrorResponse{
+		Error: rest.Error{
+			Err:       verr.Error(),
+			RequestID: requestid.FromContext(c.Request.Context()),
+		},
+		Details: verr.Errors,
+	})
+	return true
+}
+
+func renderSchemaError(c *gin.Context, err error) {
+	switch cause := errors.Cause(err); cause {
+	case store.ErrSchemaNoExist:
+		rest.RenderError(c, http.StatusNotFound, cause)
+	default:
+		c.Error(err) //nolint:errcheck
+		rest.RenderError(c,
+			http.StatusInternalServerError,
+			errors.New(http.StatusText(http.StatusInternalServerError)),
+		)
+	}
+}
+
+func schemaTargetFromPath(c *gin.Context) (model.SchemaTargetType, string, bool) {
+	targetType := model.SchemaTargetType(c.Param(pathParamTargetType))
+	if err := targetType.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid schema target type"),
+		)
+		return "", "", false
+	}
+	return targetType, c.Param(pathParamTarget), true
+}
+
+// GetSchemas responds to GET /configurations/schemas
+func (api *ManagementAPI) GetSchemas(c *gin.Context) {
+	schemas, err := api.App.GetSchemas(c.Request.Context())
+	if err != nil {
+		renderSchemaError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schemas)
+}
+
+// GetSchema responds to GET /configurations/schemas/:target_type/:target
+func (api *ManagementAPI) GetSchema(c *gin.Context) {
+	targetType, target, ok := schemaTargetFromPath(c)
+	if !ok {
+		return
+	}
+	schema, err := api.App.GetSchema(c.Request.Context(), targetType, target)
+	if err != nil {
+		renderSchemaError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// SetSchema responds to PUT /configurations/schemas/:target_type/:target
+//
+// The request body is the JSON Schema document.
+func (api *ManagementAPI) SetSchema(c *gin.Context) {
+	targetType, target, ok := schemaTargetFromPath(c)
+	if !ok {
+		return
+	}
+	b, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	schema, err := model.ParseSchema(b)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = api.App.SetSchema(c.Request.Context(), model.ConfigurationSchema{
+		TargetType: targetType,
+		Target:     target,
+		Schema:     schema,
+	})
+	if err != nil {
+		renderSchemaError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteSchema responds to DELETE /configurations/schemas/:target_type/:target
+func (api *ManagementAPI) DeleteSchema(c *gin.Context) {
+	targetType, target, ok := schemaTargetFromPath(c)
+	if !ok {
+		return
+	}
+	err := api.App.DeleteSchema(c.Request.Context(), targetType, target)
+	if err != nil {
+		renderSchemaError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeviceSchema responds to GET /configurations/device/:device_id/schema
+func (api *ManagementAPI) GetDeviceSchema(c *gin.Context) {
+	schema, err := api.App.GetDeviceSchema(c.Request.Context(),
+		c.Param(pathParamDeviceID))
+	if err != nil {
+		renderSchemaError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+
+	mapp "github.com/mendersoftware/mender-server/services/deviceconfig/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+)
+
+const testRequestID = "eed14d55-d996-42cd-8248-e806663810a8"
+
+func schemaURI(targetType, target string) string {
+	uri := strings.Replace(URISchema, ":"+pathParamTargetType, targetType, 1)
+	return strings.Replace(uri, ":"+pathParamTarget, target, 1)
+}
+
+func newRawRequest(method, uri, body string) *http.Request {
+	req, _ := http.NewRequest(method,
+		"http://localhost"+URIManagement+uri,
+		bytes.NewReader([]byte(body)),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", enterpriseToken)
+	req.Header.Set(requestid.RequestIdHeader, testRequestID)
+	return req
+}
+
+func TestSchemas(t *testing.T) {
+	t.Parallel()
+
+	const schemaDoc = `{"type":"object","properties":{"hostname":{"type":"string"}}}`
+	parsed, err := model.ParseSchema([]byte(schemaDoc))
+	require.NoError(t, err)
+	schema := model.ConfigurationSchema{
+		TargetType: model.SchemaTargetDeviceType,
+		Target:     "raspberrypi4",
+		Schema:     parsed,
+	}
+	verr := &model.ValidationError{Errors: []model.SchemaError{{
+		Path:    "/hostnmae",
+		Message: "property is not allowed by the schema",
+	}}}
+
+	testCases := []struct {
+		Name string
+
+		Request *http.Request
+		App     func(t *testing.T) *mapp.App
+
+		Status int
+		Body   interface{}
+	}{{
+		Name: "ok, set",
+
+		Request: newRawRequest("PUT", schemaURI("device_type", "raspberrypi4"), schemaDoc),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("SetSchema", contextMatcher,
+				mock.MatchedBy(func(s model.ConfigurationSchema) bool {
+					return assert.Equal(t, schema.TargetType, s.TargetType) &&
+						assert.Equal(t, schema.Target, s.Target) &&
+						assert.Equal(t, parsed, s.Schema)
+				})).Return(nil)
+			return a
+		},
+		Status: http.StatusNoContent,
+	}, {
+		Name: "error, set, invalid target type",
+
+		Request: newRawRequest("PUT", schemaURI("firmware", "foo"), schemaDoc),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "error, set, invalid schema",
+
+		Request: newRawRequest("PUT", schemaURI("group", "office"),
+			`{"type":"object","oneOf":[]}`),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+		Body: map[string]string{
+			"error":      `invalid schema: #: unsupported keyword "oneOf"`,
+			"request_id": testRequestID,
+		},
+	}, {
+		Name: "ok, get",
+
+		Request: newRawRequest("GET", schemaURI("device_type", "raspberrypi4"), ""),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetSchema", contextMatcher,
+				model.SchemaTargetDeviceType, "raspberrypi4").
+				Return(schema, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   schema,
+	}, {
+		Name: "ok, list",
+
+		Request: newRawRequest("GET", URISchemas, ""),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetSchemas", contextMatcher).
+				Return([]model.ConfigurationSchema{schema}, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   []model.ConfigurationSchema{schema},
+	}, {
+		Name: "error, delete, not found",
+
+		Request: newRawRequest("DELETE", schemaURI("group", "office"), ""),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("DeleteSchema", contextMatcher,
+				model.SchemaTargetGroup, "office").
+				Return(store.ErrSchemaNoExist)
+			return a
+		},
+		Status: http.StatusNotFound,
+	}, {
+		Name: "ok, device schema",
+
+		Request: newRawRequest("GET",
+			strings.Replace(URIDeviceSchema, ":"+pathParamDeviceID, "device", 1), ""),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetDeviceSchema", contextMatcher, "device").
+				Return(schema, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   schema,
+	}, {
+		Name: "error, set configuration violates the schema",
+
+		Request: newRawRequest("PUT",
+			strings.Replace(URIConfiguration, ":"+pathParamDeviceID, "device", 1),
+			`{"hostnmae":"foo"}`),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("SetConfiguration", contextMatcher, "device",
+				mock.AnythingOfType("model.Attributes")).
+				Return(verr)
+			return a
+		},
+		Status: http.StatusBadRequest,
+		Body: map[string]interface{}{
+			"error":      verr.Error(),
+			"details":    verr.Errors,
+			"request_id": testRequestID,
+		},
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			a := tc.App(t)
+			defer a.AssertExpectations(t)
+
+			router := NewRouter(a)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.Request)
+
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.Body != nil {
+				b, _ := json.Marshal(tc.Body)
+				assert.JSONEq(t, string(b), w.Body.String())
+			}
+		})
+	}
+}
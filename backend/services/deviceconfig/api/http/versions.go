@@ -0,0 +1,192 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+)
+
+const (
+	hdrTotalCount = "X-Total-Count"
+
+	paramTo  = "to"
+	paramKey = "key"
+)
+
+var errInvalidVersion = errors.New("invalid configuration version")
+
+// renderVersionError renders the errors common to the version endpoints.
+func renderVersionError(c *gin.Context, err error) {
+	switch cause := errors.Cause(err); cause {
+	case store.ErrVersionNoExist, store.ErrDeviceNoExist:
+		rest.RenderError(c, http.StatusNotFound, cause)
+	default:
+		c.Error(err) //nolint:errcheck
+		rest.RenderError(c,
+			http.StatusInternalServerError,
+			errors.New(http.StatusText(http.StatusInternalServerError)),
+		)
+	}
+}
+
+func parseVersion(value string) (int, error) {
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, errInvalidVersion
+	}
+	return version, nil
+}
+
+func versionFromPath(c *gin.Context) (int, bool) {
+	version, err := parseVersion(c.Param(pathParamVersion))
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return 0, false
+	}
+	return version, true
+}
+
+func renderPage(c *gin.Context, page, perPage, count int64, body interface{}) {
+	hints := rest.NewPagingHints().
+		SetPage(page).
+		SetPerPage(perPage).
+		SetTotalCount(count)
+	links, err := rest.MakePagingHeaders(c.Request, hints)
+	if err == nil {
+		for _, link := range links {
+			c.Writer.Header().Add("Link", link)
+		}
+	}
+	c.Writer.Header().Set(hdrTotalCount, strconv.FormatInt(count, 10))
+	c.JSON(http.StatusOK, body)
+}
+
+// GetConfigurationVersions responds to
+// GET /configurations/device/:device_id/versions
+func (api *ManagementAPI) GetConfigurationVersions(c *gin.Context) {
+	ctx := c.Request.Context()
+	devID := c.Param(pathParamDeviceID)
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	versions, count, err := api.App.GetConfigurationVersions(ctx, devID, page, perPage)
+	if err != nil {
+		renderVersionError(c, err)
+		return
+	}
+	renderPage(c, page, perPage, count, versions)
+}
+
+// GetConfigurationVersion responds to
+// GET /configurations/device/:device_id/versions/:version
+func (api *ManagementAPI) GetConfigurationVersion(c *gin.Context) {
+	ctx := c.Request.Context()
+	devID := c.Param(pathParamDeviceID)
+	version, ok := versionFromPath(c)
+	if !ok {
+		return
+	}
+
+	result, err := api.App.GetConfigurationVersion(ctx, devID, version)
+	if err != nil {
+		renderVersionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DiffConfigurationVersions responds to
+// GET /configurations/device/:device_id/versions/:version/diff
+//
+// The version is compared with the version given by the "to" query
+// parameter, or with the latest version if omitted.
+func (api *ManagementAPI) DiffConfigurationVersions(c *gin.Context) {
+	ctx := c.Request.Context()
+	devID := c.Param(pathParamDeviceID)
+	from, ok := versionFromPath(c)
+	if !ok {
+		return
+	}
+	var to int
+	if value, ok := c.GetQuery(paramTo); ok {
+		var err error
+		to, err = parseVersion(value)
+		if err != nil {
+			rest.RenderError(c, http.StatusBadRequest,
+				errors.Wrap(err, "invalid query parameter \"to\""))
+			return
+		}
+	}
+
+	diff, err := api.App.DiffConfigurationVersions(ctx, devID, from, to)
+	if err != nil {
+		renderVersionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// RollbackConfiguration responds to
+// POST /configurations/device/:device_id/versions/:version/rollback
+func (api *ManagementAPI) RollbackConfiguration(c *gin.Context) {
+	ctx := c.Request.Context()
+	devID := c.Param(pathParamDeviceID)
+	version, ok := versionFromPath(c)
+	if !ok {
+		return
+	}
+
+	err := api.App.RollbackConfiguration(ctx, devID, version)
+	if renderValidationError(c, err) {
+		return
+	} else if err != nil {
+		renderVersionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetDriftedDevices responds to GET /configurations/drift
+func (api *ManagementAPI) GetDriftedDevices(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	devices, count, err := api.App.FindDriftedDevices(ctx, model.DriftFilter{
+		Key:     c.Query(paramKey),
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		renderVersionError(c, err)
+		return
+	}
+	renderPage(c, page, perPage, count, devices)
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mapp "github.com/mendersoftware/mender-server/services/deviceconfig/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+)
+
+func versionURI(uri, devID, version string) string {
+	uri = strings.Replace(uri, ":"+pathParamDeviceID, devID, 1)
+	return strings.Replace(uri, ":"+pathParamVersion, version, 1)
+}
+
+func TestConfigurationVersions(t *testing.T) {
+	t.Parallel()
+
+	const devID = "device"
+	versions := []model.ConfigurationVersion{{
+		DeviceID:      devID,
+		Version:       2,
+		Configuration: model.Attributes{{Key: "hostname", Value: "bar"}},
+		Author:        model.Author{Type: model.AuthorSystem},
+	}, {
+		DeviceID:      devID,
+		Version:       1,
+		Configuration: model.Attributes{{Key: "hostname", Value: "foo"}},
+		Author:        model.Author{ID: "user", Type: model.AuthorUser},
+	}}
+	diff := model.VersionDiff{
+		From: 1,
+		To:   2,
+		Changes: model.DiffAttributes(
+			versions[1].Configuration, versions[0].Configuration,
+		),
+	}
+	testCases := []struct {
+		Name string
+
+		Request *http.Request
+		App     func(t *testing.T) *mapp.App
+
+		Status     int
+		TotalCount string
+		Body       interface{}
+	}{{
+		Name: "ok, list",
+
+		Request: newProfileRequest("GET",
+			versionURI(URIVersions, devID, "")+"?page=1&per_page=2", nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetConfigurationVersions", contextMatcher, devID,
+				int64(1), int64(2)).
+				Return(versions, int64(5), nil)
+			return a
+		},
+		Status:     http.StatusOK,
+		TotalCount: "5",
+		Body:       versions,
+	}, {
+		Name: "error, list, invalid paging",
+
+		Request: newProfileRequest("GET",
+			versionURI(URIVersions, devID, "")+"?per_page=foo", nil),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "ok, get",
+
+		Request: newProfileRequest("GET", versionURI(URIVersion, devID, "1"), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetConfigurationVersion", contextMatcher, devID, 1).
+				Return(versions[1], nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   versions[1],
+	}, {
+		Name: "error, get, invalid version",
+
+		Request: newProfileRequest("GET", versionURI(URIVersion, devID, "0"), nil),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "error, get, not found",
+
+		Request: newProfileRequest("GET", versionURI(URIVersion, devID, "9"), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("GetConfigurationVersion", contextMatcher, devID, 9).
+				Return(model.ConfigurationVersion{}, store.ErrVersionNoExist)
+			return a
+		},
+		Status: http.StatusNotFound,
+	}, {
+		Name: "ok, diff with latest",
+
+		Request: newProfileRequest("GET", versionURI(URIVersionDiff, devID, "1"), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("DiffConfigurationVersions", contextMatcher, devID, 1, 0).
+				Return(diff, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   diff,
+	}, {
+		Name: "ok, diff",
+
+		Request: newProfileRequest("GET",
+			versionURI(URIVersionDiff, devID, "1")+"?to=2", nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("DiffConfigurationVersions", contextMatcher, devID, 1, 2).
+				Return(diff, nil)
+			return a
+		},
+		Status: http.StatusOK,
+		Body:   diff,
+	}, {
+		Name: "error, diff, invalid to",
+
+		Request: newProfileRequest("GET",
+			versionURI(URIVersionDiff, devID, "1")+"?to=latest", nil),
+		App: func(t *testing.T) *mapp.App {
+			return new(mapp.App)
+		},
+		Status: http.StatusBadRequest,
+	}, {
+		Name: "ok, rollback",
+
+		Request: newProfileRequest("POST",
+			versionURI(URIVersionRollback, devID, "1"), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("RollbackConfiguration", contextMatcher, devID, 1).
+				Return(nil)
+			return a
+		},
+		Status: http.StatusNoContent,
+	}, {
+		Name: "error, rollback, internal",
+
+		Request: newProfileRequest("POST",
+			versionURI(URIVersionRollback, devID, "1"), nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("RollbackConfiguration", contextMatcher, devID, 1).
+				Return(errors.New("internal error"))
+			return a
+		},
+		Status: http.StatusInternalServerError,
+	}, {
+		Name: "ok, drift",
+
+		Request: newProfileRequest("GET", URIDrift+"?key=hostname", nil),
+		App: func(t *testing.T) *mapp.App {
+			a := new(mapp.App)
+			a.On("FindDriftedDevices", contextMatcher,
+				mock.MatchedBy(func(filter model.DriftFilter) bool {
+					return assert.Equal(t, model.DriftFilter{
+						Key:     "hostname",
+						Page:    1,
+						PerPage: 20,
+					}, filter)
+				})).
+				Return([]model.Device{{ID: devID}}, int64(1), nil)
+			return a
+		},
+		Status:     http.StatusOK,
+		TotalCount: "1",
+		Body:       []model.Device{{ID: devID}},
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			a := tc.App(t)
+			defer a.AssertExpectations(t)
+
+			router := NewRouter(a)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.Request)
+
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.TotalCount != "" {
+				assert.Equal(t, tc.TotalCount, w.Header().Get(hdrTotalCount))
+			}
+			if tc.Body != nil {
+				b, _ := json.Marshal(tc.Body)
+				assert.JSONEq(t, string(b), w.Body.String())
+			}
+		})
+	}
+}
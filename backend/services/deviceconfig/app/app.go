@@ -23,6 +23,7 @@ import (
 
 	"github.com/mendersoftware/mender-server/pkg/identity"
 
+	"github.com/mendersoftware/mender-server/services/deviceconfig/client/inventory"
 	"github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows"
 	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
 	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
@@ -32,6 +33,7 @@ import (
 var (
 	ErrDeviceNotFound     = errors.New("device not found")
 	ErrDeviceNotConnected = errors.New("device not connected")
+	ErrProfileNotAssigned = errors.New("profile is not assigned to a group or filter")
 )
 
 // App interface describes app objects
@@ -52,30 +54,71 @@ type App interface {
 	SetReportedConfiguration(ctx context.Context, devID string, configuration model.Attributes) error
 	GetDevice(ctx context.Context, devID string) (model.Device, error)
 	DeployConfiguration(ctx context.Context, device model.Device, request model.DeployConfigurationRequest) (model.DeployConfigurationResponse, error)
+
+	CreateProfile(ctx context.Context, profile model.Profile) (uuid.UUID, error)
+	UpdateProfile(ctx context.Context, profile model.Profile) error
+	GetProfile(ctx context.Context, profileID uuid.UUID) (model.Profile, error)
+	GetProfiles(ctx context.Context) ([]model.Profile, error)
+	DeleteProfile(ctx context.Context, profileID uuid.UUID) error
+	DeployProfile(ctx context.Context, profileID uuid.UUID, request model.DeployConfigurationRequest) (model.DeployProfileResponse, error)
+	GetDefaults(ctx context.Context) (model.Attributes, error)
+	SetDefaults(ctx context.Context, attrs model.Attributes) error
+	GetEffectiveConfiguration(ctx context.Context, devID string) (model.EffectiveConfiguration, error)
+
+	GetConfigurationVersions(ctx context.Context, devID string, page, perPage int64) ([]model.ConfigurationVersion, int64, error)
+	GetConfigurationVersion(ctx context.Context, devID string, version int) (model.ConfigurationVersion, error)
+	DiffConfigurationVersions(ctx context.Context, devID string, from, to int) (model.VersionDiff, error)
+	RollbackConfiguration(ctx context.Context, devID string, version int) error
+	FindDriftedDevices(ctx context.Context, filter model.DriftFilter) ([]model.Device, int64, error)
+
+	SetSchema(ctx context.Context, schema model.ConfigurationSchema) error
+	GetSchema(ctx context.Context, targetType model.SchemaTargetType, target string) (model.ConfigurationSchema, error)
+	GetSchemas(ctx context.Context) ([]model.ConfigurationSchema, error)
+	DeleteSchema(ctx context.Context, targetType model.SchemaTargetType, target string) error
+	GetDeviceSchema(ctx context.Context, devID string) (model.ConfigurationSchema, error)
 }
 
 // app is an app object
 type app struct {
 	store     store.DataStore
 	workflows workflows.Client
+	inventory inventory.Client
 	Config
 }
 
 type Config struct {
 	HaveAuditLogs bool
+	// ReportDrift enables pushing the configuration drift status of the
+	// devices to the inventory.
+	ReportDrift bool
+	// SyncIntegrations enables pushing the configured attributes of the
+	// devices to the IoT integrations through iot-manager.
+	SyncIntegrations bool
 }
 
 // NewApp initialize a new deviceconfig App
-func New(ds store.DataStore, wf workflows.Client, config ...Config) App {
+func New(
+	ds store.DataStore,
+	wf workflows.Client,
+	inv inventory.Client,
+	config ...Config,
+) App {
 	conf := Config{}
 	for _, cfgIn := range config {
 		if cfgIn.HaveAuditLogs {
 			conf.HaveAuditLogs = true
 		}
+		if cfgIn.ReportDrift {
+			conf.ReportDrift = true
+		}
+		if cfgIn.SyncIntegrations {
+			conf.SyncIntegrations = true
+		}
 	}
 	return &app{
 		store:     ds,
 		workflows: wf,
+		inventory: inv,
 		Config:    conf,
 	}
 }
@@ -114,8 +157,20 @@ func (a *app) DecommissionDevice(ctx context.Context, devID string) error {
 func (a *app) SetConfiguration(ctx context.Context,
 	devID string,
 	configuration model.Attributes) error {
+	schema, err := a.deviceSchema(ctx, devID)
+	if err != nil {
+		return err
+	} else if schema != nil {
+		err = a.validateConfiguration(ctx, schema, model.Device{
+			ID:                   devID,
+			ConfiguredAttributes: configuration,
+		})
+		if err != nil {
+			return err
+		}
+	}
 	now := time.Now()
-	err := a.store.ReplaceConfiguration(ctx, model.Device{
+	err = a.store.ReplaceConfiguration(ctx, model.Device{
 		ID:                   devID,
 		ConfiguredAttributes: configuration,
 		UpdatedTS:            &now,
@@ -123,6 +178,12 @@ func (a *app) SetConfiguration(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	if err := a.addVersion(ctx, devID, configuration); err != nil {
+		return err
+	}
+	if err := a.syncIntegrations(ctx, devID, configuration); err != nil {
+		return err
+	}
 	if identity := identity.FromContext(ctx); identity != nil &&
 		identity.IsUser && a.HaveAuditLogs {
 		userID := identity.Subject
@@ -157,9 +218,36 @@ func (a *app) UpdateConfiguration(
 	devID string,
 	attrs model.Attributes,
 ) error {
-	err := a.store.UpdateConfiguration(ctx, devID, attrs)
+	schema, err := a.deviceSchema(ctx, devID)
 	if err != nil {
 		return err
+	} else if schema != nil {
+		current, err := a.store.GetDevice(ctx, devID)
+		if errors.Cause(err) == store.ErrDeviceNoExist {
+			current = model.Device{ID: devID}
+		} else if err != nil {
+			return err
+		}
+		current.ConfiguredAttributes = model.MergeAttributes(
+			current.ConfiguredAttributes, attrs,
+		)
+		if err := a.validateConfiguration(ctx, schema, current); err != nil {
+			return err
+		}
+	}
+	err = a.store.UpdateConfiguration(ctx, devID, attrs)
+	if err != nil {
+		return err
+	}
+	device, err := a.store.GetDevice(ctx, devID)
+	if err != nil {
+		return err
+	}
+	if err := a.addVersion(ctx, devID, device.ConfiguredAttributes); err != nil {
+		return err
+	}
+	if err := a.syncIntegrations(ctx, devID, device.ConfiguredAttributes); err != nil {
+		return err
 	}
 	if identity := identity.FromContext(ctx); identity != nil &&
 		identity.IsUser && a.HaveAuditLogs {
@@ -189,15 +277,37 @@ func (a *app) UpdateConfiguration(
 	return nil
 }
 
+// syncIntegrations submits the configured attributes to iot-manager which
+// mirrors them to the desired state of the device in the IoT platforms.
+func (a *app) syncIntegrations(
+	ctx context.Context,
+	devID string,
+	configuration model.Attributes,
+) error {
+	if !a.SyncIntegrations {
+		return nil
+	}
+	b, err := configuration.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	err = a.workflows.SyncDeviceConfiguration(ctx, tenantFromContext(ctx), devID, b)
+	return errors.Wrap(err, "failed to synchronize the configuration with the integrations")
+}
+
 func (a *app) SetReportedConfiguration(ctx context.Context,
 	devID string,
 	configuration model.Attributes) error {
 	now := time.Now()
-	return a.store.ReplaceReportedConfiguration(ctx, model.Device{
+	err := a.store.ReplaceReportedConfiguration(ctx, model.Device{
 		ID:                 devID,
 		ReportedAttributes: configuration,
 		ReportTS:           &now,
 	})
+	if err != nil {
+		return err
+	}
+	return a.checkDrift(ctx, devID, configuration)
 }
 
 func (a *app) GetDevice(ctx context.Context, devID string) (model.Device, error) {
@@ -207,16 +317,29 @@ func (a *app) GetDevice(ctx context.Context, devID string) (model.Device, error)
 func (a *app) DeployConfiguration(ctx context.Context, device model.Device,
 	request model.DeployConfigurationRequest) (model.DeployConfigurationResponse, error) {
 	response := model.DeployConfigurationResponse{}
-	configuration, err := device.ConfiguredAttributes.MarshalJSON()
-	if err != nil {
-		return response, err
-	}
 	identity := identity.FromContext(ctx)
 	if identity == nil {
 		return response, errors.New("identity missing from the context")
 	}
+	effective, err := a.effectiveConfiguration(ctx, device)
+	if err != nil {
+		return response, err
+	}
+	schema, err := a.deviceSchema(ctx, device.ID)
+	if err != nil {
+		return response, err
+	} else if schema != nil {
+		err = schema.Schema.ValidateConfiguration(effective.Configuration)
+		if err != nil {
+			return response, err
+		}
+	}
+	configuration, err := effective.Configuration.MarshalJSON()
+	if err != nil {
+		return response, err
+	}
 	deploymentID := uuid.New()
-	err = a.store.SetDeploymentID(ctx, device.ID, deploymentID)
+	err = a.store.SetDeploymentID(ctx, device.ID, deploymentID, effective.Configuration)
 	if err != nil {
 		return response, nil
 	}
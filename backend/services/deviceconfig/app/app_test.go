@@ -46,7 +46,7 @@ func TestHealthCheck(t *testing.T) {
 		}),
 	).Return(err)
 
-	app := New(store, nil, Config{})
+	app := New(store, nil, nil, Config{})
 
 	ctx := context.Background()
 	res := app.HealthCheck(ctx)
@@ -76,7 +76,7 @@ func TestProvisionTenant(t *testing.T) {
 
 	defer ds.AssertExpectations(t)
 
-	app := New(ds, nil, Config{})
+	app := New(ds, nil, nil, Config{})
 	err := app.ProvisionTenant(ctx, tenant)
 	assert.NoError(t, err)
 }
@@ -115,7 +115,7 @@ func TestDeleteTenant(t *testing.T) {
 				}),
 				tc.tenantId,
 			).Return(tc.dbErr)
-			app := New(ds, nil, Config{})
+			app := New(ds, nil, nil, Config{})
 			err := app.DeleteTenant(ctx, tc.tenantId)
 
 			if tc.dbErr != nil {
@@ -144,7 +144,7 @@ func TestProvisionDevice(t *testing.T) {
 	defer ds.AssertExpectations(t)
 	ds.On("InsertDevice", ctx, deviceMatcher).Return(nil)
 
-	app := New(ds, nil, Config{})
+	app := New(ds, nil, nil, Config{})
 	err := app.ProvisionDevice(ctx, dev)
 	assert.NoError(t, err)
 }
@@ -170,7 +170,7 @@ func TestGetDevice(t *testing.T) {
 	ds.On("InsertDevice", ctx, deviceMatcher).Return(nil)
 	ds.On("GetDevice", ctx, dev.ID).Return(device, nil)
 
-	app := New(ds, nil, Config{})
+	app := New(ds, nil, nil, Config{})
 	err := app.ProvisionDevice(ctx, dev)
 	assert.NoError(t, err)
 
@@ -189,7 +189,7 @@ func TestDecommissionDevice(t *testing.T) {
 	defer ds.AssertExpectations(t)
 	ds.On("DeleteDevice", ctx, devID).Return(nil)
 
-	app := New(ds, nil, Config{})
+	app := New(ds, nil, nil, Config{})
 	err := app.DecommissionDevice(ctx, devID)
 	assert.NoError(t, err)
 }
@@ -225,10 +225,17 @@ func TestSetConfiguration(t *testing.T) {
 	ds := new(mstore.DataStore)
 	defer ds.AssertExpectations(t)
 	ds.On("InsertDevice", ctx, deviceMatcher).Return(nil)
+	ds.On("GetSchemas", ctx).Return([]model.ConfigurationSchema{}, nil)
 	ds.On("ReplaceConfiguration", ctx, deviceMatcher).Return(nil)
+	ds.On("AddConfigurationVersion", ctx,
+		mock.MatchedBy(func(v *model.ConfigurationVersion) bool {
+			return assert.Equal(t, dev.ID, v.DeviceID) &&
+				assert.Equal(t, model.AuthorSystem, v.Author.Type)
+		}),
+	).Return(nil).Times(3)
 	ds.On("GetDevice", ctx, dev.ID).Return(device, nil)
 
-	app := New(ds, nil, Config{})
+	app := New(ds, nil, nil, Config{})
 	err := app.ProvisionDevice(ctx, dev)
 	assert.NoError(t, err)
 
@@ -288,11 +295,25 @@ func TestUpdateConfiguration(t *testing.T) {
 
 		Store: func(t *testing.T, self *testCase) *mstore.DataStore {
 			store := new(mstore.DataStore)
+			store.On("GetSchemas", contextMatcher).
+				Return([]model.ConfigurationSchema{}, nil).Once()
 			store.On("UpdateConfiguration",
 				contextMatcher,
 				self.DeviceID,
 				self.Attrs,
 			).Return(nil).Once()
+			store.On("GetDevice", contextMatcher, self.DeviceID).
+				Return(model.Device{
+					ID:                   self.DeviceID,
+					ConfiguredAttributes: self.Attrs,
+				}, nil).Once()
+			store.On("AddConfigurationVersion",
+				contextMatcher,
+				mock.MatchedBy(func(v *model.ConfigurationVersion) bool {
+					return assert.Equal(t, self.DeviceID, v.DeviceID) &&
+						assert.Equal(t, self.Attrs, v.Configuration)
+				}),
+			).Return(nil).Once()
 			return store
 		},
 		Wf: func(t *testing.T, self *testCase) *mworkflows.Client {
@@ -313,11 +334,25 @@ func TestUpdateConfiguration(t *testing.T) {
 
 		Store: func(t *testing.T, self *testCase) *mstore.DataStore {
 			store := new(mstore.DataStore)
+			store.On("GetSchemas", contextMatcher).
+				Return([]model.ConfigurationSchema{}, nil).Once()
 			store.On("UpdateConfiguration",
 				contextMatcher,
 				self.DeviceID,
 				self.Attrs,
 			).Return(nil).Once()
+			store.On("GetDevice", contextMatcher, self.DeviceID).
+				Return(model.Device{
+					ID:                   self.DeviceID,
+					ConfiguredAttributes: self.Attrs,
+				}, nil).Once()
+			store.On("AddConfigurationVersion",
+				contextMatcher,
+				mock.MatchedBy(func(v *model.ConfigurationVersion) bool {
+					return assert.Equal(t, self.DeviceID, v.DeviceID) &&
+						assert.Equal(t, self.Attrs, v.Configuration)
+				}),
+			).Return(nil).Once()
 			return store
 		},
 		Wf: func(t *testing.T, self *testCase) *mworkflows.Client {
@@ -351,11 +386,25 @@ func TestUpdateConfiguration(t *testing.T) {
 
 		Store: func(t *testing.T, self *testCase) *mstore.DataStore {
 			store := new(mstore.DataStore)
+			store.On("GetSchemas", contextMatcher).
+				Return([]model.ConfigurationSchema{}, nil).Once()
 			store.On("UpdateConfiguration",
 				contextMatcher,
 				self.DeviceID,
 				self.Attrs,
 			).Return(nil).Once()
+			store.On("GetDevice", contextMatcher, self.DeviceID).
+				Return(model.Device{
+					ID:                   self.DeviceID,
+					ConfiguredAttributes: self.Attrs,
+				}, nil).Once()
+			store.On("AddConfigurationVersion",
+				contextMatcher,
+				mock.MatchedBy(func(v *model.ConfigurationVersion) bool {
+					return assert.Equal(t, self.DeviceID, v.DeviceID) &&
+						assert.Equal(t, self.Attrs, v.Configuration)
+				}),
+			).Return(nil).Once()
 			return store
 		},
 		Wf: func(t *testing.T, self *testCase) *mworkflows.Client {
@@ -389,6 +438,8 @@ func TestUpdateConfiguration(t *testing.T) {
 
 		Store: func(t *testing.T, self *testCase) *mstore.DataStore {
 			store := new(mstore.DataStore)
+			store.On("GetSchemas", contextMatcher).
+				Return([]model.ConfigurationSchema{}, nil).Once()
 			store.On("UpdateConfiguration",
 				contextMatcher,
 				self.DeviceID,
@@ -411,7 +462,7 @@ func TestUpdateConfiguration(t *testing.T) {
 			defer ds.AssertExpectations(t)
 			defer wf.AssertExpectations(t)
 
-			app := New(ds, wf, Config{HaveAuditLogs: true})
+			app := New(ds, wf, nil, Config{HaveAuditLogs: true})
 			err := app.UpdateConfiguration(tc.CTX, tc.DeviceID, tc.Attrs)
 			if tc.Error != nil {
 				if assert.Error(t, err) {
@@ -468,7 +519,16 @@ func TestSetConfigurationWithAuditLogs(t *testing.T) {
 			ds := new(mstore.DataStore)
 			defer ds.AssertExpectations(t)
 			ds.On("InsertDevice", ctx, deviceMatcher).Return(nil)
+			ds.On("GetSchemas", ctx).Return([]model.ConfigurationSchema{}, nil)
 			ds.On("ReplaceConfiguration", ctx, deviceMatcher).Return(nil)
+			ds.On("AddConfigurationVersion", ctx,
+				mock.MatchedBy(func(v *model.ConfigurationVersion) bool {
+					return assert.Equal(t, model.Author{
+						ID:   userID,
+						Type: model.AuthorUser,
+					}, v.Author)
+				}),
+			).Return(nil)
 
 			wflows := &mworkflows.Client{}
 			defer wflows.AssertExpectations(t)
@@ -493,7 +553,7 @@ func TestSetConfigurationWithAuditLogs(t *testing.T) {
 				}),
 			).Return(tc.err)
 
-			app := New(ds, wflows, Config{HaveAuditLogs: true})
+			app := New(ds, wflows, nil, Config{HaveAuditLogs: true})
 			err := app.ProvisionDevice(ctx, dev)
 			assert.NoError(t, err)
 
@@ -508,6 +568,60 @@ func TestSetConfigurationWithAuditLogs(t *testing.T) {
 	}
 }
 
+func TestSetConfigurationSyncIntegrations(t *testing.T) {
+	t.Parallel()
+	const tenantID = "tenant-id"
+	devID := uuid.NewSHA1(uuid.NameSpaceDNS, []byte("mender.io")).String()
+	configuration := model.Attributes{{
+		Key:   "hostname",
+		Value: "some0",
+	}}
+
+	testCases := map[string]struct {
+		err error
+	}{
+		"ok": {},
+		"error": {
+			err: errors.New("workflows error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+
+			ds := new(mstore.DataStore)
+			defer ds.AssertExpectations(t)
+			ds.On("GetSchemas", ctx).Return([]model.ConfigurationSchema{}, nil)
+			ds.On("ReplaceConfiguration", ctx, mock.AnythingOfType("model.Device")).
+				Return(nil)
+			ds.On("AddConfigurationVersion", ctx,
+				mock.AnythingOfType("*model.ConfigurationVersion")).
+				Return(nil)
+
+			wflows := &mworkflows.Client{}
+			defer wflows.AssertExpectations(t)
+			wflows.On("SyncDeviceConfiguration", ctx, tenantID, devID,
+				[]byte(`{"hostname":"some0"}`)).
+				Return(tc.err)
+
+			app := New(ds, wflows, nil, Config{SyncIntegrations: true})
+			err := app.SetConfiguration(ctx, devID, configuration)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err,
+					"failed to synchronize the configuration with the integrations: "+
+						tc.err.Error())
+			}
+		})
+	}
+}
+
 func TestSetReportedConfiguration(t *testing.T) {
 	t.Parallel()
 	ctx := context.TODO()
@@ -548,7 +662,7 @@ func TestSetReportedConfiguration(t *testing.T) {
 	ds.On("ReplaceReportedConfiguration", ctx, deviceMatcherReport).Return(nil)
 	ds.On("GetDevice", ctx, dev.ID).Return(device, nil)
 
-	app := New(ds, nil, Config{})
+	app := New(ds, nil, nil, Config{})
 	err := app.ProvisionDevice(ctx, dev)
 	assert.NoError(t, err)
 
@@ -617,12 +731,19 @@ func TestDeployConfiguration(t *testing.T) {
 			ds := new(mstore.DataStore)
 			defer ds.AssertExpectations(t)
 
+			ds.On("GetDefaults", mock.Anything).
+				Return(model.Attributes{}, nil)
+			ds.On("GetProfiles", mock.Anything).
+				Return([]model.Profile{}, nil)
+			ds.On("GetSchemas", mock.Anything).
+				Return([]model.ConfigurationSchema{}, nil)
 			ds.On("SetDeploymentID",
 				mock.MatchedBy(func(ctx context.Context) bool {
 					return true
 				}),
 				tc.device.ID,
 				mock.AnythingOfType("uuid.UUID"),
+				mock.AnythingOfType("model.Attributes"),
 			).Return(tc.dsErr)
 
 			wflows := &mworkflows.Client{}
@@ -666,7 +787,7 @@ func TestDeployConfiguration(t *testing.T) {
 				).Return(tc.wfErr)
 			}
 
-			app := New(ds, wflows, Config{HaveAuditLogs: true})
+			app := New(ds, wflows, nil, Config{HaveAuditLogs: true})
 			_, err := app.DeployConfiguration(ctx, tc.device, tc.request)
 			if tc.err != nil {
 				assert.Error(t, err, tc.err)
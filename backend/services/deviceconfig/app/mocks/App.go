@@ -21,6 +21,8 @@ import (
 
 	model "github.com/mendersoftware/mender-server/services/deviceconfig/model"
 	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
 )
 
 // App is an autogenerated mock type for the App type
@@ -28,6 +30,36 @@ type App struct {
 	mock.Mock
 }
 
+// CreateProfile provides a mock function with given fields: ctx, profile
+func (_m *App) CreateProfile(ctx context.Context, profile model.Profile) (uuid.UUID, error) {
+	ret := _m.Called(ctx, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProfile")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Profile) (uuid.UUID, error)); ok {
+		return rf(ctx, profile)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Profile) uuid.UUID); ok {
+		r0 = rf(ctx, profile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Profile) error); ok {
+		r1 = rf(ctx, profile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DecommissionDevice provides a mock function with given fields: ctx, devID
 func (_m *App) DecommissionDevice(ctx context.Context, devID string) error {
 	ret := _m.Called(ctx, devID)
@@ -46,6 +78,42 @@ func (_m *App) DecommissionDevice(ctx context.Context, devID string) error {
 	return r0
 }
 
+// DeleteProfile provides a mock function with given fields: ctx, profileID
+func (_m *App) DeleteProfile(ctx context.Context, profileID uuid.UUID) error {
+	ret := _m.Called(ctx, profileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, profileID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSchema provides a mock function with given fields: ctx, targetType, target
+func (_m *App) DeleteSchema(ctx context.Context, targetType model.SchemaTargetType, target string) error {
+	ret := _m.Called(ctx, targetType, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchema")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SchemaTargetType, string) error); ok {
+		r0 = rf(ctx, targetType, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTenant provides a mock function with given fields: ctx, tenant_id
 func (_m *App) DeleteTenant(ctx context.Context, tenant_id string) error {
 	ret := _m.Called(ctx, tenant_id)
@@ -92,6 +160,194 @@ func (_m *App) DeployConfiguration(ctx context.Context, device model.Device, req
 	return r0, r1
 }
 
+// DeployProfile provides a mock function with given fields: ctx, profileID, request
+func (_m *App) DeployProfile(ctx context.Context, profileID uuid.UUID, request model.DeployConfigurationRequest) (model.DeployProfileResponse, error) {
+	ret := _m.Called(ctx, profileID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeployProfile")
+	}
+
+	var r0 model.DeployProfileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.DeployConfigurationRequest) (model.DeployProfileResponse, error)); ok {
+		return rf(ctx, profileID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.DeployConfigurationRequest) model.DeployProfileResponse); ok {
+		r0 = rf(ctx, profileID, request)
+	} else {
+		r0 = ret.Get(0).(model.DeployProfileResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, model.DeployConfigurationRequest) error); ok {
+		r1 = rf(ctx, profileID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffConfigurationVersions provides a mock function with given fields: ctx, devID, from, to
+func (_m *App) DiffConfigurationVersions(ctx context.Context, devID string, from int, to int) (model.VersionDiff, error) {
+	ret := _m.Called(ctx, devID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffConfigurationVersions")
+	}
+
+	var r0 model.VersionDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (model.VersionDiff, error)); ok {
+		return rf(ctx, devID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) model.VersionDiff); ok {
+		r0 = rf(ctx, devID, from, to)
+	} else {
+		r0 = ret.Get(0).(model.VersionDiff)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, devID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDriftedDevices provides a mock function with given fields: ctx, filter
+func (_m *App) FindDriftedDevices(ctx context.Context, filter model.DriftFilter) ([]model.Device, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDriftedDevices")
+	}
+
+	var r0 []model.Device
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DriftFilter) ([]model.Device, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DriftFilter) []model.Device); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DriftFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.DriftFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetConfigurationVersion provides a mock function with given fields: ctx, devID, version
+func (_m *App) GetConfigurationVersion(ctx context.Context, devID string, version int) (model.ConfigurationVersion, error) {
+	ret := _m.Called(ctx, devID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigurationVersion")
+	}
+
+	var r0 model.ConfigurationVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (model.ConfigurationVersion, error)); ok {
+		return rf(ctx, devID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) model.ConfigurationVersion); ok {
+		r0 = rf(ctx, devID, version)
+	} else {
+		r0 = ret.Get(0).(model.ConfigurationVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, devID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfigurationVersions provides a mock function with given fields: ctx, devID, page, perPage
+func (_m *App) GetConfigurationVersions(ctx context.Context, devID string, page int64, perPage int64) ([]model.ConfigurationVersion, int64, error) {
+	ret := _m.Called(ctx, devID, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigurationVersions")
+	}
+
+	var r0 []model.ConfigurationVersion
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) ([]model.ConfigurationVersion, int64, error)); ok {
+		return rf(ctx, devID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) []model.ConfigurationVersion); ok {
+		r0 = rf(ctx, devID, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ConfigurationVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) int64); ok {
+		r1 = rf(ctx, devID, page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64, int64) error); ok {
+		r2 = rf(ctx, devID, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDefaults provides a mock function with given fields: ctx
+func (_m *App) GetDefaults(ctx context.Context) (model.Attributes, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefaults")
+	}
+
+	var r0 model.Attributes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (model.Attributes, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) model.Attributes); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Attributes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: ctx, devID
 func (_m *App) GetDevice(ctx context.Context, devID string) (model.Device, error) {
 	ret := _m.Called(ctx, devID)
@@ -120,6 +376,178 @@ func (_m *App) GetDevice(ctx context.Context, devID string) (model.Device, error
 	return r0, r1
 }
 
+// GetDeviceSchema provides a mock function with given fields: ctx, devID
+func (_m *App) GetDeviceSchema(ctx context.Context, devID string) (model.ConfigurationSchema, error) {
+	ret := _m.Called(ctx, devID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceSchema")
+	}
+
+	var r0 model.ConfigurationSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.ConfigurationSchema, error)); ok {
+		return rf(ctx, devID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.ConfigurationSchema); ok {
+		r0 = rf(ctx, devID)
+	} else {
+		r0 = ret.Get(0).(model.ConfigurationSchema)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, devID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEffectiveConfiguration provides a mock function with given fields: ctx, devID
+func (_m *App) GetEffectiveConfiguration(ctx context.Context, devID string) (model.EffectiveConfiguration, error) {
+	ret := _m.Called(ctx, devID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEffectiveConfiguration")
+	}
+
+	var r0 model.EffectiveConfiguration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.EffectiveConfiguration, error)); ok {
+		return rf(ctx, devID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.EffectiveConfiguration); ok {
+		r0 = rf(ctx, devID)
+	} else {
+		r0 = ret.Get(0).(model.EffectiveConfiguration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, devID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfile provides a mock function with given fields: ctx, profileID
+func (_m *App) GetProfile(ctx context.Context, profileID uuid.UUID) (model.Profile, error) {
+	ret := _m.Called(ctx, profileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 model.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (model.Profile, error)); ok {
+		return rf(ctx, profileID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) model.Profile); ok {
+		r0 = rf(ctx, profileID)
+	} else {
+		r0 = ret.Get(0).(model.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, profileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfiles provides a mock function with given fields: ctx
+func (_m *App) GetProfiles(ctx context.Context) ([]model.Profile, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfiles")
+	}
+
+	var r0 []model.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.Profile, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Profile); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Profile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchema provides a mock function with given fields: ctx, targetType, target
+func (_m *App) GetSchema(ctx context.Context, targetType model.SchemaTargetType, target string) (model.ConfigurationSchema, error) {
+	ret := _m.Called(ctx, targetType, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchema")
+	}
+
+	var r0 model.ConfigurationSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SchemaTargetType, string) (model.ConfigurationSchema, error)); ok {
+		return rf(ctx, targetType, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.SchemaTargetType, string) model.ConfigurationSchema); ok {
+		r0 = rf(ctx, targetType, target)
+	} else {
+		r0 = ret.Get(0).(model.ConfigurationSchema)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.SchemaTargetType, string) error); ok {
+		r1 = rf(ctx, targetType, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchemas provides a mock function with given fields: ctx
+func (_m *App) GetSchemas(ctx context.Context) ([]model.ConfigurationSchema, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchemas")
+	}
+
+	var r0 []model.ConfigurationSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.ConfigurationSchema, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.ConfigurationSchema); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ConfigurationSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HealthCheck provides a mock function with given fields: ctx
 func (_m *App) HealthCheck(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -174,6 +602,24 @@ func (_m *App) ProvisionTenant(ctx context.Context, tenant model.NewTenant) erro
 	return r0
 }
 
+// RollbackConfiguration provides a mock function with given fields: ctx, devID, version
+func (_m *App) RollbackConfiguration(ctx context.Context, devID string, version int) error {
+	ret := _m.Called(ctx, devID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, devID, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetConfiguration provides a mock function with given fields: ctx, devID, configuration
 func (_m *App) SetConfiguration(ctx context.Context, devID string, configuration model.Attributes) error {
 	ret := _m.Called(ctx, devID, configuration)
@@ -192,6 +638,24 @@ func (_m *App) SetConfiguration(ctx context.Context, devID string, configuration
 	return r0
 }
 
+// SetDefaults provides a mock function with given fields: ctx, attrs
+func (_m *App) SetDefaults(ctx context.Context, attrs model.Attributes) error {
+	ret := _m.Called(ctx, attrs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDefaults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Attributes) error); ok {
+		r0 = rf(ctx, attrs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetReportedConfiguration provides a mock function with given fields: ctx, devID, configuration
 func (_m *App) SetReportedConfiguration(ctx context.Context, devID string, configuration model.Attributes) error {
 	ret := _m.Called(ctx, devID, configuration)
@@ -210,6 +674,24 @@ func (_m *App) SetReportedConfiguration(ctx context.Context, devID string, confi
 	return r0
 }
 
+// SetSchema provides a mock function with given fields: ctx, schema
+func (_m *App) SetSchema(ctx context.Context, schema model.ConfigurationSchema) error {
+	ret := _m.Called(ctx, schema)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSchema")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ConfigurationSchema) error); ok {
+		r0 = rf(ctx, schema)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateConfiguration provides a mock function with given fields: ctx, devID, attrs
 func (_m *App) UpdateConfiguration(ctx context.Context, devID string, attrs model.Attributes) error {
 	ret := _m.Called(ctx, devID, attrs)
@@ -228,6 +710,24 @@ func (_m *App) UpdateConfiguration(ctx context.Context, devID string, attrs mode
 	return r0
 }
 
+// UpdateProfile provides a mock function with given fields: ctx, profile
+func (_m *App) UpdateProfile(ctx context.Context, profile model.Profile) error {
+	ret := _m.Called(ctx, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Profile) error); ok {
+		r0 = rf(ctx, profile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewApp creates a new instance of App. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApp(t interface {
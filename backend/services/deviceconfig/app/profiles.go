@@ -0,0 +1,351 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+)
+
+// inventorySearchPageSize is the page size used when resolving the devices
+// a profile applies to; it matches the maximum allowed by inventory.
+const inventorySearchPageSize = 500
+
+func (a *app) CreateProfile(ctx context.Context, profile model.Profile) (uuid.UUID, error) {
+	now := time.Now().UTC()
+	profile.ID = uuid.New()
+	profile.CreatedTS = now
+	profile.UpdatedTS = now
+	if profile.Configuration == nil {
+		profile.Configuration = model.Attributes{}
+	}
+	if err := a.store.InsertProfile(ctx, profile); err != nil {
+		return uuid.Nil, err
+	}
+	err := a.submitProfileAuditLog(ctx,
+		workflows.ActionSetConfiguration, profile.ID, profile.Configuration,
+	)
+	return profile.ID, err
+}
+
+func (a *app) UpdateProfile(ctx context.Context, profile model.Profile) error {
+	current, err := a.store.GetProfile(ctx, profile.ID)
+	if err != nil {
+		return err
+	}
+	profile.CreatedTS = current.CreatedTS
+	profile.UpdatedTS = time.Now().UTC()
+	if profile.Configuration == nil {
+		profile.Configuration = model.Attributes{}
+	}
+	if err := a.store.ReplaceProfile(ctx, profile); err != nil {
+		return err
+	}
+	return a.submitProfileAuditLog(ctx,
+		workflows.ActionSetConfiguration, profile.ID, profile.Configuration,
+	)
+}
+
+func (a *app) GetProfile(ctx context.Context, profileID uuid.UUID) (model.Profile, error) {
+	return a.store.GetProfile(ctx, profileID)
+}
+
+func (a *app) GetProfiles(ctx context.Context) ([]model.Profile, error) {
+	return a.store.GetProfiles(ctx)
+}
+
+func (a *app) DeleteProfile(ctx context.Context, profileID uuid.UUID) error {
+	return a.store.DeleteProfile(ctx, profileID)
+}
+
+func (a *app) GetDefaults(ctx context.Context) (model.Attributes, error) {
+	return a.store.GetDefaults(ctx)
+}
+
+func (a *app) SetDefaults(ctx context.Context, attrs model.Attributes) error {
+	if attrs == nil {
+		attrs = model.Attributes{}
+	}
+	return a.store.SetDefaults(ctx, attrs)
+}
+
+func (a *app) GetEffectiveConfiguration(
+	ctx context.Context,
+	devID string,
+) (model.EffectiveConfiguration, error) {
+	device, err := a.store.GetDevice(ctx, devID)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+	return a.effectiveConfiguration(ctx, device)
+}
+
+// effectiveConfiguration merges the tenant defaults, the profiles matching
+// the device and the device configuration, in this order.
+func (a *app) effectiveConfiguration(
+	ctx context.Context,
+	device model.Device,
+) (model.EffectiveConfiguration, error) {
+	defaults, err := a.store.GetDefaults(ctx)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+	profiles, err := a.store.GetProfiles(ctx)
+	if err != nil {
+		return model.EffectiveConfiguration{}, err
+	}
+	matching := make([]model.Profile, 0, len(profiles))
+	for _, profile := range profiles {
+		if !profile.IsAssigned() {
+			continue
+		}
+		devs, _, err := a.inventory.Search(ctx, tenantFromContext(ctx), model.SearchParams{
+			Page:      1,
+			PerPage:   1,
+			Filters:   profile.Terms(),
+			DeviceIDs: []string{device.ID},
+		})
+		if err != nil {
+			return model.EffectiveConfiguration{}, errors.Wrapf(err,
+				"failed to match profile %q", profile.Name,
+			)
+		}
+		if len(devs) > 0 {
+			matching = append(matching, profile)
+		}
+	}
+	return mergeConfiguration(device, defaults, matching), nil
+}
+
+func mergeConfiguration(
+	device model.Device,
+	defaults model.Attributes,
+	profiles []model.Profile,
+) model.EffectiveConfiguration {
+	layers := make([]model.Attributes, 0, len(profiles)+2)
+	layers = append(layers, defaults)
+	profileIDs := make([]uuid.UUID, len(profiles))
+	for i, profile := range profiles {
+		layers = append(layers, profile.Configuration)
+		profileIDs[i] = profile.ID
+	}
+	layers = append(layers, device.ConfiguredAttributes)
+	return model.EffectiveConfiguration{
+		DeviceID:      device.ID,
+		Configuration: model.MergeAttributes(layers...),
+		Profiles:      profileIDs,
+	}
+}
+
+// profileMembers returns the set of devices the profile applies to.
+func (a *app) profileMembers(
+	ctx context.Context,
+	profile model.Profile,
+) (map[string]struct{}, error) {
+	members := make(map[string]struct{})
+	tenantID := tenantFromContext(ctx)
+	for page := 1; ; page++ {
+		devs, total, err := a.inventory.Search(ctx, tenantID, model.SearchParams{
+			Page:    page,
+			PerPage: inventorySearchPageSize,
+			Filters: profile.Terms(),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err,
+				"failed to resolve devices for profile %q", profile.Name,
+			)
+		}
+		for _, dev := range devs {
+			members[dev.ID] = struct{}{}
+		}
+		if len(devs) < inventorySearchPageSize || len(members) >= total {
+			return members, nil
+		}
+	}
+}
+
+// DeployProfile deploys the effective configuration to every device the
+// profile applies to.
+func (a *app) DeployProfile(
+	ctx context.Context,
+	profileID uuid.UUID,
+	request model.DeployConfigurationRequest,
+) (model.DeployProfileResponse, error) {
+	response := model.DeployProfileResponse{
+		Deployments: []model.DeviceDeployment{},
+	}
+	id := identity.FromContext(ctx)
+	if id == nil {
+		return response, errors.New("identity missing from the context")
+	}
+	target, err := a.store.GetProfile(ctx, profileID)
+	if err != nil {
+		return response, err
+	} else if !target.IsAssigned() {
+		return response, ErrProfileNotAssigned
+	}
+	defaults, err := a.store.GetDefaults(ctx)
+	if err != nil {
+		return response, err
+	}
+	profiles, err := a.store.GetProfiles(ctx)
+	if err != nil {
+		return response, err
+	}
+	schemas, err := a.store.GetSchemas(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	// Resolve the members of each profile once, rather than matching
+	// every device against every profile.
+	type profileWithMembers struct {
+		model.Profile
+		members map[string]struct{}
+	}
+	resolved := make([]profileWithMembers, 0, len(profiles))
+	var targetMembers map[string]struct{}
+	for _, profile := range profiles {
+		if !profile.IsAssigned() {
+			continue
+		}
+		members, err := a.profileMembers(ctx, profile)
+		if err != nil {
+			return response, err
+		}
+		if profile.ID == target.ID {
+			targetMembers = members
+		}
+		resolved = append(resolved, profileWithMembers{
+			Profile: profile,
+			members: members,
+		})
+	}
+
+	l := log.FromContext(ctx)
+	for devID := range targetMembers {
+		device, err := a.store.GetDevice(ctx, devID)
+		if err != nil {
+			l.Warnf("profile %s: failed to get device %s: %s",
+				target.ID, devID, err.Error())
+			response.Failed = append(response.Failed, devID)
+			continue
+		}
+		matching := make([]model.Profile, 0, len(resolved))
+		for _, profile := range resolved {
+			if _, ok := profile.members[devID]; ok {
+				matching = append(matching, profile.Profile)
+			}
+		}
+		effective := mergeConfiguration(device, defaults, matching)
+		schema, err := a.schemaFor(ctx, schemas, devID)
+		if err == nil && schema != nil {
+			err = schema.Schema.ValidateConfiguration(effective.Configuration)
+		}
+		if err != nil {
+			l.Warnf("profile %s: invalid configuration for device %s: %s",
+				target.ID, devID, err.Error())
+			response.Failed = append(response.Failed, devID)
+			continue
+		}
+		deploymentID, err := a.deploy(ctx, id.Tenant, devID,
+			effective.Configuration, request)
+		if err != nil {
+			l.Warnf("profile %s: failed to deploy configuration to device %s: %s",
+				target.ID, devID, err.Error())
+			response.Failed = append(response.Failed, devID)
+			continue
+		}
+		response.Deployments = append(response.Deployments, model.DeviceDeployment{
+			DeviceID:     devID,
+			DeploymentID: deploymentID,
+		})
+	}
+
+	err = a.submitProfileAuditLog(ctx,
+		workflows.ActionDeployConfiguration, target.ID, target.Configuration,
+	)
+	return response, err
+}
+
+func (a *app) deploy(
+	ctx context.Context,
+	tenantID, devID string,
+	attrs model.Attributes,
+	request model.DeployConfigurationRequest,
+) (uuid.UUID, error) {
+	configuration, err := attrs.MarshalJSON()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	deploymentID := uuid.New()
+	err = a.store.SetDeploymentID(ctx, devID, deploymentID, attrs)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	err = a.workflows.DeployConfiguration(ctx, tenantID, devID,
+		deploymentID, configuration, request.Retries, request.UpdateControlMap)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return deploymentID, nil
+}
+
+func (a *app) submitProfileAuditLog(
+	ctx context.Context,
+	action workflows.Action,
+	profileID uuid.UUID,
+	attrs model.Attributes,
+) error {
+	id := identity.FromContext(ctx)
+	if id == nil || !id.IsUser || !a.HaveAuditLogs {
+		return nil
+	}
+	configuration, err := attrs.MarshalJSON()
+	if err == nil {
+		err = a.workflows.SubmitAuditLog(ctx, workflows.AuditLog{
+			Action: action,
+			Actor: workflows.Actor{
+				ID:   id.Subject,
+				Type: workflows.ActorUser,
+			},
+			Object: workflows.Object{
+				ID:   profileID.String(),
+				Type: workflows.ObjectProfile,
+			},
+			Change:  string(configuration),
+			EventTS: time.Now(),
+		})
+	}
+	return errors.Wrapf(err,
+		"failed to submit audit log for configuration profile %s", profileID,
+	)
+}
+
+func tenantFromContext(ctx context.Context) string {
+	if id := identity.FromContext(ctx); id != nil {
+		return id.Tenant
+	}
+	return ""
+}
@@ -0,0 +1,343 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	minventory "github.com/mendersoftware/mender-server/services/deviceconfig/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows"
+	mworkflows "github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceconfig/store/mocks"
+)
+
+func searchParamsFor(profile model.Profile, devIDs ...string) interface{} {
+	return mock.MatchedBy(func(params model.SearchParams) bool {
+		return assert.ObjectsAreEqual(profile.Terms(), params.Filters) &&
+			assert.ObjectsAreEqual(devIDs, params.DeviceIDs)
+	})
+}
+
+func TestGetEffectiveConfiguration(t *testing.T) {
+	t.Parallel()
+
+	const tenantID = "tenant"
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenantID,
+	})
+	device := model.Device{
+		ID: "device",
+		ConfiguredAttributes: model.Attributes{
+			{Key: "log_level", Value: "debug"},
+		},
+	}
+	defaults := model.Attributes{
+		{Key: "ntp", Value: "pool.ntp.org"},
+		{Key: "log_level", Value: "warning"},
+	}
+	office := model.Profile{
+		ID:            uuid.New(),
+		Name:          "office",
+		Group:         "office",
+		Configuration: model.Attributes{{Key: "ntp", Value: "ntp.office"}},
+	}
+	lab := model.Profile{
+		ID:            uuid.New(),
+		Name:          "lab",
+		Group:         "lab",
+		Priority:      10,
+		Configuration: model.Attributes{{Key: "ntp", Value: "ntp.lab"}},
+	}
+	draft := model.Profile{
+		ID:            uuid.New(),
+		Name:          "draft",
+		Configuration: model.Attributes{{Key: "ntp", Value: "ntp.draft"}},
+	}
+
+	testCases := []struct {
+		Name string
+
+		DeviceErr   error
+		ProfilesErr error
+		SearchErr   error
+		Matching    map[uuid.UUID]bool
+
+		Result model.EffectiveConfiguration
+		Error  string
+	}{{
+		Name: "ok, defaults and device",
+
+		Matching: map[uuid.UUID]bool{},
+		Result: model.EffectiveConfiguration{
+			DeviceID: device.ID,
+			Configuration: model.Attributes{
+				{Key: "ntp", Value: "pool.ntp.org"},
+				{Key: "log_level", Value: "debug"},
+			},
+			Profiles: []uuid.UUID{},
+		},
+	}, {
+		Name: "ok, profiles merged by priority",
+
+		Matching: map[uuid.UUID]bool{office.ID: true, lab.ID: true},
+		Result: model.EffectiveConfiguration{
+			DeviceID: device.ID,
+			Configuration: model.Attributes{
+				{Key: "ntp", Value: "ntp.lab"},
+				{Key: "log_level", Value: "debug"},
+			},
+			Profiles: []uuid.UUID{office.ID, lab.ID},
+		},
+	}, {
+		Name: "error, device not found",
+
+		DeviceErr: store.ErrDeviceNoExist,
+		Error:     store.ErrDeviceNoExist.Error(),
+	}, {
+		Name: "error, listing profiles",
+
+		ProfilesErr: errors.New("internal error"),
+		Error:       "internal error",
+	}, {
+		Name: "error, inventory",
+
+		SearchErr: errors.New("inventory down"),
+		Error:     `failed to match profile "office": inventory down`,
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := new(mstore.DataStore)
+			defer ds.AssertExpectations(t)
+			inv := new(minventory.Client)
+			defer inv.AssertExpectations(t)
+
+			ds.On("GetDevice", contextMatcher, device.ID).
+				Return(device, tc.DeviceErr)
+			if tc.DeviceErr == nil {
+				ds.On("GetDefaults", contextMatcher).Return(defaults, nil)
+				ds.On("GetProfiles", contextMatcher).
+					Return([]model.Profile{office, draft, lab}, tc.ProfilesErr)
+			}
+			if tc.SearchErr != nil {
+				inv.On("Search", contextMatcher, tenantID, searchParamsFor(office, device.ID)).
+					Return(nil, -1, tc.SearchErr)
+			} else if tc.Matching != nil {
+				for _, profile := range []model.Profile{office, lab} {
+					var devs []model.InvDevice
+					if tc.Matching[profile.ID] {
+						devs = []model.InvDevice{{ID: device.ID}}
+					}
+					inv.On("Search", contextMatcher, tenantID,
+						searchParamsFor(profile, device.ID)).
+						Return(devs, len(devs), nil)
+				}
+			}
+
+			app := New(ds, nil, inv)
+			res, err := app.GetEffectiveConfiguration(ctx, device.ID)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Result, res)
+			}
+		})
+	}
+}
+
+func TestDeployProfile(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tenantID = "tenant"
+		userID   = "user"
+	)
+	office := model.Profile{
+		ID:            uuid.New(),
+		Name:          "office",
+		Group:         "office",
+		Configuration: model.Attributes{{Key: "ntp", Value: "ntp.office"}},
+	}
+	lab := model.Profile{
+		ID:            uuid.New(),
+		Name:          "lab",
+		Group:         "lab",
+		Priority:      1,
+		Configuration: model.Attributes{{Key: "ntp", Value: "ntp.lab"}},
+	}
+	defaults := model.Attributes{{Key: "timezone", Value: "UTC"}}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		ctx := identity.WithContext(context.Background(), &identity.Identity{
+			Tenant:  tenantID,
+			Subject: userID,
+			IsUser:  true,
+		})
+		ds := new(mstore.DataStore)
+		defer ds.AssertExpectations(t)
+		inv := new(minventory.Client)
+		defer inv.AssertExpectations(t)
+		wf := new(mworkflows.Client)
+		defer wf.AssertExpectations(t)
+
+		ds.On("GetProfile", contextMatcher, office.ID).Return(office, nil)
+		ds.On("GetDefaults", contextMatcher).Return(defaults, nil)
+		ds.On("GetProfiles", contextMatcher).
+			Return([]model.Profile{office, lab}, nil)
+		ds.On("GetSchemas", contextMatcher).
+			Return([]model.ConfigurationSchema{}, nil)
+		inv.On("Search", contextMatcher, tenantID, searchParamsFor(office)).
+			Return([]model.InvDevice{{ID: "dev-1"}, {ID: "dev-2"}, {ID: "dev-3"}}, 3, nil)
+		inv.On("Search", contextMatcher, tenantID, searchParamsFor(lab)).
+			Return([]model.InvDevice{{ID: "dev-2"}}, 1, nil)
+
+		ds.On("GetDevice", contextMatcher, "dev-1").
+			Return(model.Device{ID: "dev-1"}, nil)
+		ds.On("GetDevice", contextMatcher, "dev-2").
+			Return(model.Device{ID: "dev-2"}, nil)
+		ds.On("GetDevice", contextMatcher, "dev-3").
+			Return(model.Device{}, store.ErrDeviceNoExist)
+		ds.On("SetDeploymentID", contextMatcher,
+			mock.AnythingOfType("string"), mock.AnythingOfType("uuid.UUID"),
+			mock.AnythingOfType("model.Attributes")).
+			Return(nil).Twice()
+
+		wf.On("DeployConfiguration", contextMatcher, tenantID, "dev-1",
+			mock.AnythingOfType("uuid.UUID"),
+			[]byte(`{"ntp":"ntp.office","timezone":"UTC"}`),
+			uint(3), map[string]interface{}(nil),
+		).Return(nil)
+		wf.On("DeployConfiguration", contextMatcher, tenantID, "dev-2",
+			mock.AnythingOfType("uuid.UUID"),
+			[]byte(`{"ntp":"ntp.lab","timezone":"UTC"}`),
+			uint(3), map[string]interface{}(nil),
+		).Return(nil)
+		wf.On("SubmitAuditLog", contextMatcher,
+			mock.MatchedBy(func(log workflows.AuditLog) bool {
+				return log.Action == workflows.ActionDeployConfiguration &&
+					log.Object.ID == office.ID.String() &&
+					log.Object.Type == workflows.ObjectProfile
+			}),
+		).Return(nil)
+
+		app := New(ds, wf, inv, Config{HaveAuditLogs: true})
+		res, err := app.DeployProfile(ctx, office.ID,
+			model.DeployConfigurationRequest{Retries: 3})
+		assert.NoError(t, err)
+		assert.Len(t, res.Deployments, 2)
+		assert.Equal(t, []string{"dev-3"}, res.Failed)
+	})
+
+	t.Run("error, profile not assigned", func(t *testing.T) {
+		t.Parallel()
+		ctx := identity.WithContext(context.Background(), &identity.Identity{
+			Tenant: tenantID,
+		})
+		ds := new(mstore.DataStore)
+		defer ds.AssertExpectations(t)
+
+		draft := model.Profile{ID: uuid.New(), Name: "draft"}
+		ds.On("GetProfile", contextMatcher, draft.ID).Return(draft, nil)
+
+		app := New(ds, nil, nil)
+		_, err := app.DeployProfile(ctx, draft.ID, model.DeployConfigurationRequest{})
+		assert.ErrorIs(t, err, ErrProfileNotAssigned)
+	})
+
+	t.Run("error, inventory", func(t *testing.T) {
+		t.Parallel()
+		ctx := identity.WithContext(context.Background(), &identity.Identity{
+			Tenant: tenantID,
+		})
+		ds := new(mstore.DataStore)
+		defer ds.AssertExpectations(t)
+		inv := new(minventory.Client)
+		defer inv.AssertExpectations(t)
+
+		ds.On("GetProfile", contextMatcher, office.ID).Return(office, nil)
+		ds.On("GetDefaults", contextMatcher).Return(defaults, nil)
+		ds.On("GetProfiles", contextMatcher).
+			Return([]model.Profile{office}, nil)
+		ds.On("GetSchemas", contextMatcher).
+			Return([]model.ConfigurationSchema{}, nil)
+		inv.On("Search", contextMatcher, tenantID, searchParamsFor(office)).
+			Return(nil, -1, errors.New("inventory down"))
+
+		app := New(ds, nil, inv)
+		_, err := app.DeployProfile(ctx, office.ID, model.DeployConfigurationRequest{})
+		assert.EqualError(t, err,
+			`failed to resolve devices for profile "office": inventory down`)
+	})
+
+	t.Run("error, missing identity", func(t *testing.T) {
+		t.Parallel()
+		app := New(nil, nil, nil)
+		_, err := app.DeployProfile(context.Background(), office.ID,
+			model.DeployConfigurationRequest{})
+		assert.EqualError(t, err, "identity missing from the context")
+	})
+}
+
+func TestCreateProfile(t *testing.T) {
+	t.Parallel()
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant:  "tenant",
+		Subject: "user",
+		IsUser:  true,
+	})
+	profile := model.Profile{
+		Name:  "office",
+		Group: "office",
+	}
+
+	ds := new(mstore.DataStore)
+	defer ds.AssertExpectations(t)
+	wf := new(mworkflows.Client)
+	defer wf.AssertExpectations(t)
+
+	ds.On("InsertProfile", contextMatcher,
+		mock.MatchedBy(func(p model.Profile) bool {
+			return p.ID != uuid.Nil &&
+				p.Name == profile.Name &&
+				!p.CreatedTS.IsZero() &&
+				p.Configuration != nil
+		})).Return(nil)
+	wf.On("SubmitAuditLog", contextMatcher,
+		mock.MatchedBy(func(log workflows.AuditLog) bool {
+			return log.Action == workflows.ActionSetConfiguration &&
+				log.Object.Type == workflows.ObjectProfile &&
+				log.Change == "{}"
+		})).Return(errors.New("workflows down"))
+
+	app := New(ds, wf, nil, Config{HaveAuditLogs: true})
+	id, err := app.CreateProfile(ctx, profile)
+	assert.NotEqual(t, uuid.Nil, id)
+	assert.EqualError(t, err, "failed to submit audit log for configuration profile "+
+		id.String()+": workflows down")
+}
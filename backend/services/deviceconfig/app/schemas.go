@@ -0,0 +1,122 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+)
+
+func (a *app) SetSchema(ctx context.Context, schema model.ConfigurationSchema) error {
+	schema.UpdatedTS = time.Now().UTC()
+	return a.store.SetSchema(ctx, schema)
+}
+
+func (a *app) GetSchema(
+	ctx context.Context,
+	targetType model.SchemaTargetType,
+	target string,
+) (model.ConfigurationSchema, error) {
+	return a.store.GetSchema(ctx, targetType, target)
+}
+
+func (a *app) GetSchemas(ctx context.Context) ([]model.ConfigurationSchema, error) {
+	return a.store.GetSchemas(ctx)
+}
+
+func (a *app) DeleteSchema(
+	ctx context.Context,
+	targetType model.SchemaTargetType,
+	target string,
+) error {
+	return a.store.DeleteSchema(ctx, targetType, target)
+}
+
+// GetDeviceSchema returns the configuration schema applicable to the
+// device, or store.ErrSchemaNoExist if there is none.
+func (a *app) GetDeviceSchema(ctx context.Context, devID string) (model.ConfigurationSchema, error) {
+	schema, err := a.deviceSchema(ctx, devID)
+	if err != nil {
+		return model.ConfigurationSchema{}, err
+	} else if schema == nil {
+		return model.ConfigurationSchema{}, store.ErrSchemaNoExist
+	}
+	return *schema, nil
+}
+
+func (a *app) deviceSchema(ctx context.Context, devID string) (*model.ConfigurationSchema, error) {
+	schemas, err := a.store.GetSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.schemaFor(ctx, schemas, devID)
+}
+
+// schemaFor selects the schema applicable to the device: the schema of
+// the device's group takes precedence over the schema of its device type.
+func (a *app) schemaFor(
+	ctx context.Context,
+	schemas []model.ConfigurationSchema,
+	devID string,
+) (*model.ConfigurationSchema, error) {
+	if len(schemas) == 0 {
+		return nil, nil
+	}
+	devs, _, err := a.inventory.Search(ctx, tenantFromContext(ctx), model.SearchParams{
+		Page:      1,
+		PerPage:   1,
+		DeviceIDs: []string{devID},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the device from the inventory")
+	} else if len(devs) == 0 {
+		return nil, nil
+	}
+	group := devs[0].Attribute(model.FilterScopeSystem, model.FilterAttrGroup)
+	deviceType := devs[0].Attribute(model.InvScopeInventory, model.InvAttrDeviceType)
+	var match *model.ConfigurationSchema
+	for i := range schemas {
+		switch schemas[i].TargetType {
+		case model.SchemaTargetGroup:
+			if group != "" && schemas[i].Target == group {
+				return &schemas[i], nil
+			}
+		case model.SchemaTargetDeviceType:
+			if deviceType != "" && schemas[i].Target == deviceType {
+				match = &schemas[i]
+			}
+		}
+	}
+	return match, nil
+}
+
+// validateConfiguration validates the effective configuration of the
+// device against the schema.
+func (a *app) validateConfiguration(
+	ctx context.Context,
+	schema *model.ConfigurationSchema,
+	device model.Device,
+) error {
+	effective, err := a.effectiveConfiguration(ctx, device)
+	if err != nil {
+		return err
+	}
+	return schema.Schema.ValidateConfiguration(effective.Configuration)
+}
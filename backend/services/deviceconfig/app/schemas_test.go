@@ -0,0 +1,190 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	minventory "github.com/mendersoftware/mender-server/services/deviceconfig/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceconfig/store/mocks"
+)
+
+func mustParseSchema(t *testing.T, doc string) *model.Schema {
+	schema, err := model.ParseSchema([]byte(doc))
+	require.NoError(t, err)
+	return schema
+}
+
+func TestGetDeviceSchema(t *testing.T) {
+	t.Parallel()
+
+	const tenantID = "tenant"
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenantID,
+	})
+	byType := model.ConfigurationSchema{
+		TargetType: model.SchemaTargetDeviceType,
+		Target:     "raspberrypi4",
+		Schema:     mustParseSchema(t, `{"type": "object"}`),
+	}
+	byGroup := model.ConfigurationSchema{
+		TargetType: model.SchemaTargetGroup,
+		Target:     "office",
+		Schema:     mustParseSchema(t, `{"type": "object", "required": ["ntp"]}`),
+	}
+	invDevice := func(group string) model.InvDevice {
+		dev := model.InvDevice{
+			ID: "device",
+			Attributes: []model.InvAttribute{{
+				Scope: model.InvScopeInventory,
+				Name:  model.InvAttrDeviceType,
+				Value: "raspberrypi4",
+			}},
+		}
+		if group != "" {
+			dev.Attributes = append(dev.Attributes, model.InvAttribute{
+				Scope: model.FilterScopeSystem,
+				Name:  model.FilterAttrGroup,
+				Value: group,
+			})
+		}
+		return dev
+	}
+
+	testCases := []struct {
+		Name string
+
+		Schemas   []model.ConfigurationSchema
+		InvDevice *model.InvDevice
+
+		Schema model.ConfigurationSchema
+		Error  error
+	}{{
+		Name: "ok, group takes precedence",
+
+		Schemas:   []model.ConfigurationSchema{byType, byGroup},
+		InvDevice: func() *model.InvDevice { d := invDevice("office"); return &d }(),
+
+		Schema: byGroup,
+	}, {
+		Name: "ok, device type",
+
+		Schemas:   []model.ConfigurationSchema{byType, byGroup},
+		InvDevice: func() *model.InvDevice { d := invDevice("lab"); return &d }(),
+
+		Schema: byType,
+	}, {
+		Name: "error, no schemas",
+
+		Error: store.ErrSchemaNoExist,
+	}, {
+		Name: "error, device not in inventory",
+
+		Schemas: []model.ConfigurationSchema{byType},
+
+		Error: store.ErrSchemaNoExist,
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := new(mstore.DataStore)
+			defer ds.AssertExpectations(t)
+			inv := new(minventory.Client)
+			defer inv.AssertExpectations(t)
+
+			ds.On("GetSchemas", ctx).Return(tc.Schemas, nil)
+			if len(tc.Schemas) > 0 {
+				devs := []model.InvDevice{}
+				if tc.InvDevice != nil {
+					devs = append(devs, *tc.InvDevice)
+				}
+				inv.On("Search", ctx, tenantID, model.SearchParams{
+					Page:      1,
+					PerPage:   1,
+					DeviceIDs: []string{"device"},
+				}).Return(devs, len(devs), nil)
+			}
+
+			app := New(ds, nil, inv)
+			schema, err := app.GetDeviceSchema(ctx, "device")
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tc.Schema, schema)
+			}
+		})
+	}
+}
+
+func TestSetConfigurationSchemaViolation(t *testing.T) {
+	t.Parallel()
+
+	const tenantID = "tenant"
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenantID,
+	})
+	schema := model.ConfigurationSchema{
+		TargetType: model.SchemaTargetDeviceType,
+		Target:     "raspberrypi4",
+		Schema: mustParseSchema(t, `{
+			"type": "object",
+			"properties": {"hostname": {"type": "string"}},
+			"additionalProperties": false
+		}`),
+	}
+
+	ds := new(mstore.DataStore)
+	defer ds.AssertExpectations(t)
+	inv := new(minventory.Client)
+	defer inv.AssertExpectations(t)
+
+	ds.On("GetSchemas", ctx).Return([]model.ConfigurationSchema{schema}, nil)
+	ds.On("GetDefaults", ctx).Return(model.Attributes{}, nil)
+	ds.On("GetProfiles", ctx).Return([]model.Profile{}, nil)
+	inv.On("Search", ctx, tenantID, model.SearchParams{
+		Page:      1,
+		PerPage:   1,
+		DeviceIDs: []string{"device"},
+	}).Return([]model.InvDevice{{
+		ID: "device",
+		Attributes: []model.InvAttribute{{
+			Scope: model.InvScopeInventory,
+			Name:  model.InvAttrDeviceType,
+			Value: "raspberrypi4",
+		}},
+	}}, 1, nil)
+
+	app := New(ds, nil, inv)
+	err := app.SetConfiguration(ctx, "device", model.Attributes{
+		{Key: "hostname", Value: "foo"},
+		{Key: "hostnmae", Value: "bar"},
+	})
+	var verr *model.ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, []model.SchemaError{{
+			Path:    "/hostnmae",
+			Message: "property is not allowed by the schema",
+		}}, verr.Errors)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+)
+
+const (
+	// inventory attribute holding the configuration drift status
+	inventoryAttrDrift  = "configuration_drift"
+	inventoryScopeDrift = "system"
+
+	driftStatusDrifted = "drifted"
+	driftStatusInSync  = "in_sync"
+)
+
+// addVersion stores the configuration as a new version.
+func (a *app) addVersion(ctx context.Context, devID string, attrs model.Attributes) error {
+	author := model.Author{Type: model.AuthorSystem}
+	if id := identity.FromContext(ctx); id != nil && id.IsUser {
+		author = model.Author{
+			ID:   id.Subject,
+			Type: model.AuthorUser,
+		}
+	}
+	if attrs == nil {
+		attrs = model.Attributes{}
+	}
+	err := a.store.AddConfigurationVersion(ctx, &model.ConfigurationVersion{
+		ID:            uuid.New(),
+		DeviceID:      devID,
+		Configuration: attrs,
+		Author:        author,
+		CreatedTS:     time.Now().UTC(),
+	})
+	return errors.Wrap(err, "failed to store the configuration version")
+}
+
+func (a *app) GetConfigurationVersions(
+	ctx context.Context,
+	devID string,
+	page, perPage int64,
+) ([]model.ConfigurationVersion, int64, error) {
+	return a.store.GetConfigurationVersions(ctx, devID, page, perPage)
+}
+
+func (a *app) GetConfigurationVersion(
+	ctx context.Context,
+	devID string,
+	version int,
+) (model.ConfigurationVersion, error) {
+	return a.store.GetConfigurationVersion(ctx, devID, version)
+}
+
+// DiffConfigurationVersions returns the changes between two versions of
+// the configuration; version 0 refers to the latest version.
+func (a *app) DiffConfigurationVersions(
+	ctx context.Context,
+	devID string,
+	from, to int,
+) (model.VersionDiff, error) {
+	fromVersion, err := a.store.GetConfigurationVersion(ctx, devID, from)
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+	toVersion, err := a.store.GetConfigurationVersion(ctx, devID, to)
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+	return model.VersionDiff{
+		From: fromVersion.Version,
+		To:   toVersion.Version,
+		Changes: model.DiffAttributes(
+			fromVersion.Configuration, toVersion.Configuration,
+		),
+	}, nil
+}
+
+// RollbackConfiguration restores the configuration of a previous version,
+// which is stored as a new version.
+func (a *app) RollbackConfiguration(ctx context.Context, devID string, version int) error {
+	previous, err := a.store.GetConfigurationVersion(ctx, devID, version)
+	if err != nil {
+		return err
+	}
+	return a.SetConfiguration(ctx, devID, previous.Configuration)
+}
+
+func (a *app) FindDriftedDevices(
+	ctx context.Context,
+	filter model.DriftFilter,
+) ([]model.Device, int64, error) {
+	return a.store.FindDriftedDevices(ctx, filter)
+}
+
+// checkDrift compares the configuration reported by the device with the
+// configuration of the latest deployment and records the differences.
+func (a *app) checkDrift(ctx context.Context, devID string, reported model.Attributes) error {
+	device, err := a.store.GetDevice(ctx, devID)
+	if err != nil {
+		return err
+	}
+	if device.DeployedTS == nil {
+		// nothing deployed yet, nothing to compare with
+		return nil
+	}
+	var drift *model.Drift
+	if changes := model.DiffAttributes(device.DeployedAttributes, reported); len(changes) > 0 {
+		drift = &model.Drift{
+			Changes:    changes,
+			DetectedTS: time.Now().UTC(),
+		}
+	}
+	if drift == nil && device.Drift == nil {
+		return nil
+	} else if drift != nil && device.Drift != nil &&
+		reflect.DeepEqual(drift.Changes, device.Drift.Changes) {
+		// keep the timestamp of the first detection
+		return nil
+	}
+	if err := a.store.SetDrift(ctx, devID, drift); err != nil {
+		return err
+	}
+	if !a.ReportDrift || (drift != nil) == (device.Drift != nil) {
+		return nil
+	}
+	status := driftStatusInSync
+	if drift != nil {
+		status = driftStatusDrifted
+	}
+	err = a.workflows.UpdateDeviceInventory(ctx,
+		tenantFromContext(ctx), devID, inventoryScopeDrift,
+		[]workflows.InventoryAttribute{{
+			Name:  inventoryAttrDrift,
+			Value: status,
+		}},
+	)
+	return errors.Wrap(err, "failed to report the configuration drift")
+}
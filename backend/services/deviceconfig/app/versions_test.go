@@ -0,0 +1,300 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows"
+	mworkflows "github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/model"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceconfig/store/mocks"
+)
+
+func TestDiffConfigurationVersions(t *testing.T) {
+	t.Parallel()
+
+	const devID = "device"
+	ctx := context.Background()
+
+	ds := new(mstore.DataStore)
+	defer ds.AssertExpectations(t)
+	ds.On("GetConfigurationVersion", ctx, devID, 1).
+		Return(model.ConfigurationVersion{
+			Version: 1,
+			Configuration: model.Attributes{
+				{Key: "hostname", Value: "foo"},
+				{Key: "ntp", Value: "pool.ntp.org"},
+			},
+		}, nil).Once()
+	ds.On("GetConfigurationVersion", ctx, devID, 0).
+		Return(model.ConfigurationVersion{
+			Version: 3,
+			Configuration: model.Attributes{
+				{Key: "hostname", Value: "bar"},
+				{Key: "log_level", Value: "debug"},
+			},
+		}, nil).Once()
+	ds.On("GetConfigurationVersion", ctx, devID, 7).
+		Return(model.ConfigurationVersion{}, store.ErrVersionNoExist).Once()
+
+	app := New(ds, nil, nil)
+	diff, err := app.DiffConfigurationVersions(ctx, devID, 1, 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, model.VersionDiff{
+			From: 1,
+			To:   3,
+			Changes: []model.AttributeChange{{
+				Key:    "hostname",
+				Change: model.ChangeModified,
+				From:   "foo",
+				To:     "bar",
+			}, {
+				Key:    "log_level",
+				Change: model.ChangeAdded,
+				To:     "debug",
+			}, {
+				Key:    "ntp",
+				Change: model.ChangeRemoved,
+				From:   "pool.ntp.org",
+			}},
+		}, diff)
+	}
+
+	_, err = app.DiffConfigurationVersions(ctx, devID, 7, 0)
+	assert.ErrorIs(t, err, store.ErrVersionNoExist)
+}
+
+func TestRollbackConfiguration(t *testing.T) {
+	t.Parallel()
+
+	const devID = "device"
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Subject: "user",
+		IsUser:  true,
+	})
+	previous := model.Attributes{{Key: "hostname", Value: "foo"}}
+
+	ds := new(mstore.DataStore)
+	defer ds.AssertExpectations(t)
+	ds.On("GetConfigurationVersion", ctx, devID, 2).
+		Return(model.ConfigurationVersion{
+			Version:       2,
+			Configuration: previous,
+		}, nil).Once()
+	ds.On("GetSchemas", ctx).Return([]model.ConfigurationSchema{}, nil).Once()
+	ds.On("ReplaceConfiguration", ctx,
+		mock.MatchedBy(func(d model.Device) bool {
+			return assert.Equal(t, previous, d.ConfiguredAttributes)
+		}),
+	).Return(nil).Once()
+	ds.On("AddConfigurationVersion", ctx,
+		mock.MatchedBy(func(v *model.ConfigurationVersion) bool {
+			return assert.Equal(t, previous, v.Configuration) &&
+				assert.Equal(t, model.Author{
+					ID:   "user",
+					Type: model.AuthorUser,
+				}, v.Author)
+		}),
+	).Return(nil).Once()
+	ds.On("GetConfigurationVersion", ctx, devID, 5).
+		Return(model.ConfigurationVersion{}, store.ErrVersionNoExist).Once()
+
+	app := New(ds, nil, nil)
+	err := app.RollbackConfiguration(ctx, devID, 2)
+	assert.NoError(t, err)
+
+	err = app.RollbackConfiguration(ctx, devID, 5)
+	assert.ErrorIs(t, err, store.ErrVersionNoExist)
+}
+
+func TestSetReportedConfigurationDrift(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tenantID = "tenant"
+		devID    = "device"
+	)
+	deployedTS := time.Now().Add(-time.Hour)
+	deployed := model.Attributes{{Key: "hostname", Value: "foo"}}
+	drifted := model.Attributes{{Key: "hostname", Value: "bar"}}
+	driftChanges := model.DiffAttributes(deployed, drifted)
+
+	type testCase struct {
+		Name string
+
+		Device      model.Device
+		Reported    model.Attributes
+		ReportDrift bool
+
+		// SetDrift is nil if the drift must not be updated
+		SetDrift *bool
+		// Status is the status reported to the inventory, if any
+		Status string
+		WfErr  error
+
+		Error error
+	}
+	yes, no := true, false
+	testCases := []testCase{{
+		Name: "ok/nothing deployed",
+
+		Device: model.Device{
+			ID: devID,
+		},
+		Reported:    drifted,
+		ReportDrift: true,
+	}, {
+		Name: "ok/in sync",
+
+		Device: model.Device{
+			ID:                 devID,
+			DeployedAttributes: deployed,
+			DeployedTS:         &deployedTS,
+		},
+		Reported:    deployed,
+		ReportDrift: true,
+	}, {
+		Name: "ok/drift detected",
+
+		Device: model.Device{
+			ID:                 devID,
+			DeployedAttributes: deployed,
+			DeployedTS:         &deployedTS,
+		},
+		Reported:    drifted,
+		ReportDrift: true,
+
+		SetDrift: &yes,
+		Status:   driftStatusDrifted,
+	}, {
+		Name: "ok/drift detected, reporting disabled",
+
+		Device: model.Device{
+			ID:                 devID,
+			DeployedAttributes: deployed,
+			DeployedTS:         &deployedTS,
+		},
+		Reported: drifted,
+
+		SetDrift: &yes,
+	}, {
+		Name: "ok/drift unchanged",
+
+		Device: model.Device{
+			ID:                 devID,
+			DeployedAttributes: deployed,
+			DeployedTS:         &deployedTS,
+			Drift: &model.Drift{
+				Changes:    driftChanges,
+				DetectedTS: deployedTS,
+			},
+		},
+		Reported:    drifted,
+		ReportDrift: true,
+	}, {
+		Name: "ok/drift resolved",
+
+		Device: model.Device{
+			ID:                 devID,
+			DeployedAttributes: deployed,
+			DeployedTS:         &deployedTS,
+			Drift: &model.Drift{
+				Changes:    driftChanges,
+				DetectedTS: deployedTS,
+			},
+		},
+		Reported:    deployed,
+		ReportDrift: true,
+
+		SetDrift: &no,
+		Status:   driftStatusInSync,
+	}, {
+		Name: "error/reporting drift",
+
+		Device: model.Device{
+			ID:                 devID,
+			DeployedAttributes: deployed,
+			DeployedTS:         &deployedTS,
+		},
+		Reported:    drifted,
+		ReportDrift: true,
+
+		SetDrift: &yes,
+		Status:   driftStatusDrifted,
+		WfErr:    errors.New("internal error"),
+
+		Error: errors.New("failed to report the configuration drift: internal error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Subject:  devID,
+				Tenant:   tenantID,
+				IsDevice: true,
+			})
+
+			ds := new(mstore.DataStore)
+			defer ds.AssertExpectations(t)
+			ds.On("ReplaceReportedConfiguration", ctx,
+				mock.AnythingOfType("model.Device")).
+				Return(nil).Once()
+			ds.On("GetDevice", ctx, devID).
+				Return(tc.Device, nil).Once()
+			if tc.SetDrift != nil {
+				ds.On("SetDrift", ctx, devID,
+					mock.MatchedBy(func(drift *model.Drift) bool {
+						if !*tc.SetDrift {
+							return drift == nil
+						}
+						return drift != nil &&
+							assert.Equal(t, driftChanges, drift.Changes)
+					}),
+				).Return(nil).Once()
+			}
+
+			wf := new(mworkflows.Client)
+			defer wf.AssertExpectations(t)
+			if tc.Status != "" {
+				wf.On("UpdateDeviceInventory", ctx,
+					tenantID, devID, inventoryScopeDrift,
+					[]workflows.InventoryAttribute{{
+						Name:  inventoryAttrDrift,
+						Value: tc.Status,
+					}},
+				).Return(tc.WfErr).Once()
+			}
+
+			app := New(ds, wf, nil, Config{ReportDrift: tc.ReportDrift})
+			err := app.SetReportedConfiguration(ctx, devID, tc.Reported)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -34,6 +34,8 @@ const (
 	HealthCheckURI              = "/api/v1/health"
 	AuditlogsURI                = "/api/v1/workflow/emit_auditlog"
 	DeployDeviceConfigurationRI = "/api/v1/workflow/deploy_device_configuration"
+	UpdateDeviceInventoryURI    = "/api/v1/workflow/update_device_inventory"
+	SyncDeviceConfigurationURI  = "/api/v1/workflow/sync_device_configuration"
 )
 
 const (
@@ -49,6 +51,10 @@ type Client interface {
 	DeployConfiguration(ctx context.Context, tenantID string, deviceID string,
 		deploymentID uuid.UUID, configuration []byte,
 		retries uint, updateControlMap map[string]interface{}) error
+	UpdateDeviceInventory(ctx context.Context, tenantID, deviceID, scope string,
+		attributes []InventoryAttribute) error
+	SyncDeviceConfiguration(ctx context.Context, tenantID, deviceID string,
+		configuration []byte) error
 }
 
 type ClientOptions struct {
@@ -213,3 +219,97 @@ func (c *client) DeployConfiguration(ctx context.Context, tenantID string, devic
 		rsp.Status,
 	)
 }
+
+func (c *client) UpdateDeviceInventory(ctx context.Context, tenantID, deviceID, scope string,
+	attributes []InventoryAttribute) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	attrs, _ := json.Marshal(attributes)
+	wflow := UpdateDeviceInventoryWorkflow{
+		RequestID:  requestid.FromContext(ctx),
+		TenantID:   tenantID,
+		DeviceID:   deviceID,
+		Scope:      scope,
+		Attributes: string(attrs),
+	}
+
+	payload, _ := json.Marshal(wflow)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		c.url+UpdateDeviceInventoryURI,
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err, "workflows: error preparing HTTP request")
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to update device inventory")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 300 {
+		return nil
+	}
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return errors.New(`workflows: workflow "update_device_inventory" not defined`)
+	}
+
+	return errors.Errorf(
+		"workflows: unexpected HTTP status from workflows service: %s",
+		rsp.Status,
+	)
+}
+
+func (c *client) SyncDeviceConfiguration(ctx context.Context, tenantID, deviceID string,
+	configuration []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	wflow := SyncDeviceConfigurationWorkflow{
+		RequestID:     requestid.FromContext(ctx),
+		TenantID:      tenantID,
+		DeviceID:      deviceID,
+		Configuration: json.RawMessage(configuration),
+	}
+
+	payload, _ := json.Marshal(wflow)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		c.url+SyncDeviceConfigurationURI,
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err, "workflows: error preparing HTTP request")
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to synchronize device configuration")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 300 {
+		return nil
+	}
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return errors.New(`workflows: workflow "sync_device_configuration" not defined`)
+	}
+
+	return errors.Errorf(
+		"workflows: unexpected HTTP status from workflows service: %s",
+		rsp.Status,
+	)
+}
@@ -613,3 +613,141 @@ func TestDeployConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateDeviceInventory(t *testing.T) {
+	t.Parallel()
+	attrs := []InventoryAttribute{{
+		Name:  "configuration_drift",
+		Value: "drifted",
+	}}
+	testCases := []struct {
+		Name string
+
+		Response *http.Response
+		Error    error
+	}{{
+		Name: "ok",
+
+		Response: &http.Response{
+			StatusCode: 201,
+		},
+	}, {
+		Name: "error, update_device_inventory does not exist",
+
+		Error: errors.New(`^workflows: workflow "update_device_inventory" not defined$`),
+		Response: &http.Response{
+			StatusCode: 404,
+		},
+	}, {
+		Name: "error, unexpected response",
+
+		Error: errors.Errorf(`^workflows: unexpected HTTP status from `+
+			`workflows service: %d`, http.StatusInternalServerError),
+		Response: &http.Response{
+			StatusCode: 500,
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rspChan := make(chan *http.Response, 1)
+			reqChan := make(chan *http.Request, 1)
+			srv := newTestServer(rspChan, reqChan)
+			defer srv.Close()
+			c := NewClient(srv.URL)
+			rspChan <- tc.Response
+
+			ctx := requestid.WithContext(context.Background(), "testing")
+			err := c.UpdateDeviceInventory(ctx, "tenantID", "deviceID", "system", attrs)
+
+			if tc.Error != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.Error.Error(), err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			req := <-reqChan
+			assert.Equal(t, UpdateDeviceInventoryURI, req.URL.Path)
+			var wflow UpdateDeviceInventoryWorkflow
+			err = json.NewDecoder(req.Body).Decode(&wflow)
+			if assert.NoError(t, err) {
+				assert.Equal(t, UpdateDeviceInventoryWorkflow{
+					RequestID:  "testing",
+					TenantID:   "tenantID",
+					DeviceID:   "deviceID",
+					Scope:      "system",
+					Attributes: `[{"name":"configuration_drift","value":"drifted"}]`,
+				}, wflow)
+			}
+		})
+	}
+}
+
+func TestSyncDeviceConfiguration(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name string
+
+		Response *http.Response
+		Error    error
+	}{{
+		Name: "ok",
+
+		Response: &http.Response{
+			StatusCode: 201,
+		},
+	}, {
+		Name: "error, sync_device_configuration does not exist",
+
+		Error: errors.New(`^workflows: workflow "sync_device_configuration" not defined$`),
+		Response: &http.Response{
+			StatusCode: 404,
+		},
+	}, {
+		Name: "error, unexpected response",
+
+		Error: errors.Errorf(`^workflows: unexpected HTTP status from `+
+			`workflows service: %d`, http.StatusInternalServerError),
+		Response: &http.Response{
+			StatusCode: 500,
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rspChan := make(chan *http.Response, 1)
+			reqChan := make(chan *http.Request, 1)
+			srv := newTestServer(rspChan, reqChan)
+			defer srv.Close()
+			c := NewClient(srv.URL)
+			rspChan <- tc.Response
+
+			ctx := requestid.WithContext(context.Background(), "testing")
+			err := c.SyncDeviceConfiguration(ctx, "tenantID", "deviceID",
+				[]byte(`{"hostname":"mender"}`))
+
+			if tc.Error != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.Error.Error(), err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			req := <-reqChan
+			assert.Equal(t, SyncDeviceConfigurationURI, req.URL.Path)
+			var wflow map[string]interface{}
+			err = json.NewDecoder(req.Body).Decode(&wflow)
+			if assert.NoError(t, err) {
+				assert.Equal(t, map[string]interface{}{
+					"request_id": "testing",
+					"tenant_id":  "tenantID",
+					"device_id":  "deviceID",
+					"configuration": map[string]interface{}{
+						"hostname": "mender",
+					},
+				}, wflow)
+			}
+		})
+	}
+}
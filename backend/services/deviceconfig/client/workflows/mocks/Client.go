@@ -84,6 +84,42 @@ func (_m *Client) SubmitAuditLog(ctx context.Context, log workflows.AuditLog) er
 	return r0
 }
 
+// SyncDeviceConfiguration provides a mock function with given fields: ctx, tenantID, deviceID, configuration
+func (_m *Client) SyncDeviceConfiguration(ctx context.Context, tenantID string, deviceID string, configuration []byte) error {
+	ret := _m.Called(ctx, tenantID, deviceID, configuration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncDeviceConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = rf(ctx, tenantID, deviceID, configuration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDeviceInventory provides a mock function with given fields: ctx, tenantID, deviceID, scope, attributes
+func (_m *Client) UpdateDeviceInventory(ctx context.Context, tenantID string, deviceID string, scope string, attributes []workflows.InventoryAttribute) error {
+	ret := _m.Called(ctx, tenantID, deviceID, scope, attributes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceInventory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []workflows.InventoryAttribute) error); ok {
+		r0 = rf(ctx, tenantID, deviceID, scope, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
@@ -15,6 +15,7 @@
 package workflows
 
 import (
+	"encoding/json"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -72,7 +73,10 @@ func (a Actor) Validate() error {
 
 type ObjectType string
 
-const ObjectDevice ObjectType = "device"
+const (
+	ObjectDevice  ObjectType = "device"
+	ObjectProfile ObjectType = "configuration_profile"
+)
 
 type Object struct {
 	ID   string     `json:"id"`
@@ -84,7 +88,7 @@ func (o Object) Validate() error {
 		validation.Field(&o.ID, validation.Required),
 		validation.Field(&o.Type,
 			validation.Required,
-			validation.In(ObjectDevice),
+			validation.In(ObjectDevice, ObjectProfile),
 		),
 	)
 	return err
@@ -120,3 +124,25 @@ type DeployConfigurationWorkflow struct {
 	Retries          uint                   `json:"retries"`
 	UpdateControlMap map[string]interface{} `json:"update_control_map,omitempty"`
 }
+
+type UpdateDeviceInventoryWorkflow struct {
+	RequestID string `json:"request_id"`
+	TenantID  string `json:"tenant_id"`
+	DeviceID  string `json:"device_id"`
+	Scope     string `json:"scope"`
+	// Attributes is the JSON encoded list of inventory attributes
+	Attributes string `json:"attributes"`
+}
+
+type SyncDeviceConfigurationWorkflow struct {
+	RequestID string `json:"request_id"`
+	TenantID  string `json:"tenant_id"`
+	DeviceID  string `json:"device_id"`
+	// Configuration is the JSON object with the configured attributes
+	Configuration json.RawMessage `json:"configuration"`
+}
+
+type InventoryAttribute struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
@@ -74,6 +74,16 @@ const (
 	SettingEnableAudit        = "enable_audit"
 	SettingEnableAuditDefault = false
 
+	// SettingReportDrift enables reporting the configuration drift status
+	// of the devices to the inventory.
+	SettingReportDrift        = "report_drift"
+	SettingReportDriftDefault = false
+
+	// SettingSyncIntegrations enables mirroring the device configuration
+	// to the IoT integrations managed by iot-manager.
+	SettingSyncIntegrations        = "sync_integrations"
+	SettingSyncIntegrationsDefault = false
+
 	// Max Request body size
 	SettingMaxRequestSize        = "request_size_limit"
 	SettingMaxRequestSizeDefault = 1024 * 1024 // 1 MiB
@@ -90,6 +100,8 @@ var (
 		{Key: SettingDebugLog, Value: SettingDebugLogDefault},
 		{Key: SettingWorkflowsURL, Value: SettingWorkflowsURLDefault},
 		{Key: SettingEnableAudit, Value: SettingEnableAuditDefault},
+		{Key: SettingReportDrift, Value: SettingReportDriftDefault},
+		{Key: SettingSyncIntegrations, Value: SettingSyncIntegrationsDefault},
 		{Key: SettingInventoryURL, Value: SettingInventoryURLDefault},
 		{Key: SettingInventoryTimeout, Value: SettingInventoryTimeoutDefault},
 		{Key: SettingMaxRequestSize, Value: SettingMaxRequestSizeDefault},
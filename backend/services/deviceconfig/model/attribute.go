@@ -44,6 +44,25 @@ func (a Attributes) Validate() error {
 	return validation.Validate([]Attribute(a), validateAttributesLength)
 }
 
+// MergeAttributes merges the layers of attributes in order: attributes
+// from later layers override those with the same key in earlier layers.
+// Keys keep the position of their first occurrence.
+func MergeAttributes(layers ...Attributes) Attributes {
+	index := make(map[string]int)
+	merged := Attributes{}
+	for _, layer := range layers {
+		for _, attr := range layer {
+			if i, ok := index[attr.Key]; ok {
+				merged[i].Value = attr.Value
+				continue
+			}
+			index[attr.Key] = len(merged)
+			merged = append(merged, attr)
+		}
+	}
+	return merged
+}
+
 func map2Attributes(configurationMap map[string]interface{}) Attributes {
 	attributes := make(Attributes, len(configurationMap))
 	i := 0
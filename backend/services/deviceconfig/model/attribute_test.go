@@ -51,3 +51,26 @@ func TestMap2Attributes(t *testing.T) {
 	configurationMap["hostname"] = "some0other"
 	assert.NotEqual(t, map2Attributes(configurationMap), attributes)
 }
+
+func TestMergeAttributes(t *testing.T) {
+	defaults := Attributes{
+		{Key: "ntp", Value: "pool.ntp.org"},
+		{Key: "timezone", Value: "UTC"},
+	}
+	profile := Attributes{
+		{Key: "ntp", Value: "ntp.example.com"},
+		{Key: "log_level", Value: "info"},
+	}
+	device := Attributes{
+		{Key: "log_level", Value: "debug"},
+	}
+	merged := MergeAttributes(defaults, profile, device)
+	assert.Equal(t, Attributes{
+		{Key: "ntp", Value: "ntp.example.com"},
+		{Key: "timezone", Value: "UTC"},
+		{Key: "log_level", Value: "debug"},
+	}, merged)
+	// the layers are not modified
+	assert.Equal(t, "pool.ntp.org", defaults[0].Value)
+	assert.Equal(t, Attributes{}, MergeAttributes())
+}
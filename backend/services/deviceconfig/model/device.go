@@ -32,6 +32,13 @@ type Device struct {
 	ReportedAttributes Attributes `bson:"reported,omitempty" json:"reported"`
 	// DeploymentID is the ID of the latest configuration deployment
 	DeploymentID *uuid.UUID `bson:"deployment_id,omitempty" json:"deployment_id,omitempty"`
+	// DeployedAttributes is the configuration sent with the latest deployment.
+	DeployedAttributes Attributes `bson:"deployed,omitempty" json:"deployed,omitempty"`
+	// DeployedTS holds the timestamp of the latest deployment.
+	DeployedTS *time.Time `bson:"deployed_ts,omitempty" json:"deployed_ts,omitempty"`
+	// Drift is set when the configuration reported after the latest
+	// deployment does not match the deployed configuration.
+	Drift *Drift `bson:"drift,omitempty" json:"drift,omitempty"`
 
 	// UpdatedTS holds the timestamp for when the desired state changed,
 	// including when the object was created.
@@ -14,6 +14,81 @@
 
 package model
 
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	FilterScopeSystem   = "system"
+	FilterScopeIdentity = "identity"
+
+	FilterAttrGroup = "group"
+
+	FilterTypeEqual = "$eq"
+	FilterTypeIn    = "$in"
+	FilterTypeNotIn = "$nin"
+)
+
+var (
+	validFilterScopes = []interface{}{
+		FilterScopeSystem, FilterScopeIdentity, "inventory", "monitor", "tags", "iot",
+	}
+	validFilterTypes = []interface{}{
+		FilterTypeEqual, FilterTypeIn, FilterTypeNotIn,
+	}
+)
+
+// FilterPredicate is a single inventory search term.
+type FilterPredicate struct {
+	Scope     string      `json:"scope" bson:"scope"`
+	Attribute string      `json:"attribute" bson:"attribute"`
+	Type      string      `json:"type" bson:"type"`
+	Value     interface{} `json:"value" bson:"value"`
+}
+
+func (f FilterPredicate) Validate() error {
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Scope, validation.Required, validation.In(validFilterScopes...)),
+		validation.Field(&f.Attribute, validation.Required),
+		validation.Field(&f.Type, validation.Required, validation.In(validFilterTypes...)),
+		validation.Field(&f.Value, validation.NotNil),
+	)
+}
+
+// SearchParams are the parameters of an inventory device search.
+type SearchParams struct {
+	Page      int               `json:"page"`
+	PerPage   int               `json:"per_page"`
+	Filters   []FilterPredicate `json:"filters"`
+	DeviceIDs []string          `json:"device_ids,omitempty"`
+}
+
+// InvDevice is a device returned by the inventory search.
+type InvDevice struct {
+	ID         string         `json:"id"`
+	Attributes []InvAttribute `json:"attributes,omitempty"`
+}
+
+// InvAttribute is an inventory attribute of a device.
+type InvAttribute struct {
+	Name  string      `json:"name"`
+	Scope string      `json:"scope"`
+	Value interface{} `json:"value"`
+}
+
+// Attribute returns the value of the attribute as a string, or an empty
+// string if the device does not have the attribute.
+func (d InvDevice) Attribute(scope, name string) string {
+	for _, attr := range d.Attributes {
+		if attr.Scope == scope && attr.Name == name {
+			if value, ok := attr.Value.(string); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
 type DeviceIds struct {
 	Devices []string `json:"devices,omitempty" valid:"required" bson:"-"`
 }
@@ -0,0 +1,110 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const ProfileNameMaxLength = 256
+
+var (
+	ErrProfileTargetAmbiguous = errors.New(
+		"a profile can be assigned either to a group or to a filter, not both",
+	)
+)
+
+// Profile is a named configuration which is applied to all the devices
+// belonging to an inventory group or matching an inventory filter.
+type Profile struct {
+	ID   uuid.UUID `bson:"_id" json:"id"`
+	Name string    `bson:"name" json:"name"`
+
+	// Configuration holds the attributes provided by the profile.
+	Configuration Attributes `bson:"configuration" json:"configuration"`
+
+	// Group assigns the profile to the devices in the inventory group.
+	Group string `bson:"group,omitempty" json:"group,omitempty"`
+	// Filter assigns the profile to the devices matching the terms.
+	Filter []FilterPredicate `bson:"filter,omitempty" json:"filter,omitempty"`
+
+	// Priority sets the order in which profiles matching the same device
+	// are merged: profiles with a higher priority take precedence.
+	Priority int `bson:"priority" json:"priority"`
+
+	CreatedTS time.Time `bson:"created_ts" json:"created_ts"`
+	UpdatedTS time.Time `bson:"updated_ts" json:"updated_ts"`
+}
+
+func (p Profile) Validate() error {
+	if p.Group != "" && len(p.Filter) > 0 {
+		return ErrProfileTargetAmbiguous
+	}
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Name,
+			validation.Required,
+			validation.Length(1, ProfileNameMaxLength),
+		),
+		validation.Field(&p.Configuration),
+		validation.Field(&p.Group, lengthLessThan4096),
+		validation.Field(&p.Filter),
+	)
+}
+
+// IsAssigned returns true if the profile targets a group or a filter.
+func (p Profile) IsAssigned() bool {
+	return p.Group != "" || len(p.Filter) > 0
+}
+
+// Terms returns the inventory search terms selecting the devices the
+// profile applies to.
+func (p Profile) Terms() []FilterPredicate {
+	if p.Group != "" {
+		return []FilterPredicate{{
+			Scope:     FilterScopeSystem,
+			Attribute: FilterAttrGroup,
+			Type:      FilterTypeEqual,
+			Value:     p.Group,
+		}}
+	}
+	return p.Filter
+}
+
+// EffectiveConfiguration is the configuration resulting from merging the
+// tenant defaults, the profiles and the device configuration.
+type EffectiveConfiguration struct {
+	DeviceID string `json:"device_id"`
+	// Configuration holds the merged attributes.
+	Configuration Attributes `json:"configuration"`
+	// Profiles lists the IDs of the profiles applied, in merge order.
+	Profiles []uuid.UUID `json:"profiles"`
+}
+
+// DeviceDeployment pairs a device with its configuration deployment.
+type DeviceDeployment struct {
+	DeviceID     string    `json:"device_id"`
+	DeploymentID uuid.UUID `json:"deployment_id"`
+}
+
+type DeployProfileResponse struct {
+	// Deployments lists the configuration deployments started.
+	Deployments []DeviceDeployment `json:"deployments"`
+	// Failed lists the devices for which the deployment failed.
+	Failed []string `json:"failed,omitempty"`
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileValidate(t *testing.T) {
+	testCases := []struct {
+		Name string
+
+		Profile Profile
+
+		Error string
+	}{{
+		Name: "ok, group",
+
+		Profile: Profile{
+			Name:          "office",
+			Configuration: Attributes{{Key: "ntp", Value: "ntp.example.com"}},
+			Group:         "office",
+		},
+	}, {
+		Name: "ok, filter",
+
+		Profile: Profile{
+			Name: "arm devices",
+			Filter: []FilterPredicate{{
+				Scope:     "inventory",
+				Attribute: "cpu_model",
+				Type:      FilterTypeEqual,
+				Value:     "ARMv7",
+			}},
+		},
+	}, {
+		Name: "ok, unassigned",
+
+		Profile: Profile{Name: "draft"},
+	}, {
+		Name: "error, missing name",
+
+		Profile: Profile{Group: "office"},
+		Error:   "name: cannot be blank.",
+	}, {
+		Name: "error, group and filter",
+
+		Profile: Profile{
+			Name:  "office",
+			Group: "office",
+			Filter: []FilterPredicate{{
+				Scope:     FilterScopeSystem,
+				Attribute: FilterAttrGroup,
+				Type:      FilterTypeEqual,
+				Value:     "office",
+			}},
+		},
+		Error: ErrProfileTargetAmbiguous.Error(),
+	}, {
+		Name: "error, invalid filter",
+
+		Profile: Profile{
+			Name: "office",
+			Filter: []FilterPredicate{{
+				Scope:     "bogus",
+				Attribute: FilterAttrGroup,
+				Type:      "$regex",
+				Value:     "office",
+			}},
+		},
+		Error: "filter: (0: (scope: must be a valid value; type: must be a valid value.).).",
+	}, {
+		Name: "error, invalid configuration",
+
+		Profile: Profile{
+			Name:          "office",
+			Configuration: Attributes{{Key: "ntp", Value: 123}},
+		},
+		Error: "configuration: (0: (value: invalid type: int.).).",
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Profile.Validate()
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProfileTerms(t *testing.T) {
+	p := Profile{Name: "office", Group: "office"}
+	assert.True(t, p.IsAssigned())
+	assert.Equal(t, []FilterPredicate{{
+		Scope:     FilterScopeSystem,
+		Attribute: FilterAttrGroup,
+		Type:      FilterTypeEqual,
+		Value:     "office",
+	}}, p.Terms())
+
+	terms := []FilterPredicate{{
+		Scope:     FilterScopeIdentity,
+		Attribute: "mac",
+		Type:      FilterTypeIn,
+		Value:     []string{"00:11:22:33:44:55"},
+	}}
+	p = Profile{Name: "macs", Filter: terms}
+	assert.True(t, p.IsAssigned())
+	assert.Equal(t, terms, p.Terms())
+
+	p = Profile{Name: "draft"}
+	assert.False(t, p.IsAssigned())
+	assert.Empty(t, p.Terms())
+}
@@ -0,0 +1,380 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+type SchemaTargetType string
+
+const (
+	SchemaTargetDeviceType SchemaTargetType = "device_type"
+	SchemaTargetGroup      SchemaTargetType = "group"
+)
+
+// Inventory attributes used to select the schema of a device.
+const (
+	InvAttrDeviceType = "device_type"
+	InvScopeInventory = "inventory"
+)
+
+// schemaAnnotations are the keywords accepted in a schema which do not
+// affect validation.
+var schemaAnnotations = map[string]struct{}{
+	"$schema": {}, "$id": {}, "$comment": {},
+	"title": {}, "description": {}, "default": {}, "examples": {},
+	"format": {}, "readOnly": {}, "writeOnly": {}, "deprecated": {},
+}
+
+var (
+	ErrSchemaNotObject = errors.New(`the schema must describe an object ("type": "object")`)
+)
+
+func (t SchemaTargetType) Validate() error {
+	return validation.Validate(string(t), validation.Required,
+		validation.In(string(SchemaTargetDeviceType), string(SchemaTargetGroup)),
+	)
+}
+
+// ConfigurationSchema is a JSON Schema the configuration of the devices of
+// a device type, or belonging to a group, must comply with.
+type ConfigurationSchema struct {
+	TargetType SchemaTargetType `bson:"target_type" json:"target_type"`
+	Target     string           `bson:"target" json:"target"`
+	Schema     *Schema          `bson:"schema" json:"schema"`
+	UpdatedTS  time.Time        `bson:"updated_ts" json:"updated_ts"`
+}
+
+func (s ConfigurationSchema) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.TargetType),
+		validation.Field(&s.Target, validation.Required, lengthLessThan4096),
+		validation.Field(&s.Schema, validation.Required),
+	)
+}
+
+// Schema is the subset of JSON Schema applicable to device configurations:
+// an object whose properties are strings, optionally holding a number, an
+// integer or a boolean. The original document is preserved so it can be
+// served back to clients rendering configuration forms.
+type Schema struct {
+	raw  json.RawMessage
+	root schemaNode
+}
+
+type schemaNode struct {
+	Type                 string
+	Properties           map[string]*schemaNode
+	Required             []string
+	AdditionalProperties *bool
+	Enum                 []interface{}
+	Pattern              *regexp.Regexp
+	MinLength            *int
+	MaxLength            *int
+	Minimum              *float64
+	Maximum              *float64
+}
+
+// SchemaError is a single validation failure, located with a JSON Pointer.
+type SchemaError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a configuration does not comply with
+// the schema.
+type ValidationError struct {
+	Errors []SchemaError `json:"details"`
+}
+
+func (err *ValidationError) Error() string {
+	msgs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		msgs[i] = e.Path + ": " + e.Message
+	}
+	return "configuration does not match the schema: " + strings.Join(msgs, "; ")
+}
+
+// ParseSchema parses a JSON Schema document.
+func ParseSchema(b []byte) (*Schema, error) {
+	s := new(Schema)
+	if err := s.UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return errors.Wrap(err, "invalid schema")
+	}
+	root, err := parseSchemaNode(doc, "")
+	if err != nil {
+		return errors.Wrap(err, "invalid schema")
+	}
+	if root.Type != "object" {
+		return ErrSchemaNotObject
+	}
+	for key, prop := range root.Properties {
+		if prop.Type == "object" || prop.Type == "array" {
+			return errors.Errorf(
+				"invalid schema: #/properties/%s: unsupported type %q",
+				key, prop.Type,
+			)
+		}
+	}
+	s.raw = append(json.RawMessage{}, b...)
+	s.root = *root
+	return nil
+}
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	if s.raw == nil {
+		return []byte("null"), nil
+	}
+	return s.raw, nil
+}
+
+// MarshalBSONValue stores the schema as a JSON string: JSON Schema
+// keywords such as "$schema" are not valid document keys.
+func (s Schema) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(string(s.raw))
+}
+
+func (s *Schema) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	var doc string
+	if err := bson.UnmarshalValue(t, b, &doc); err != nil {
+		return err
+	}
+	return s.UnmarshalJSON([]byte(doc))
+}
+
+func parseSchemaNode(doc map[string]interface{}, path string) (*schemaNode, error) {
+	node := &schemaNode{}
+	var err error
+	for key, value := range doc {
+		switch key {
+		case "type":
+			t, ok := value.(string)
+			if !ok {
+				return nil, errors.Errorf("#%s/type: must be a string", path)
+			}
+			switch t {
+			case "object", "string", "number", "integer", "boolean", "array", "null":
+				node.Type = t
+			default:
+				return nil, errors.Errorf("#%s/type: unknown type %q", path, t)
+			}
+		case "properties":
+			props, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("#%s/properties: must be an object", path)
+			}
+			node.Properties = make(map[string]*schemaNode, len(props))
+			for name, prop := range props {
+				propPath := path + "/properties/" + name
+				propDoc, ok := prop.(map[string]interface{})
+				if !ok {
+					return nil, errors.Errorf("#%s: must be an object", propPath)
+				}
+				node.Properties[name], err = parseSchemaNode(propDoc, propPath)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case "required":
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.Errorf("#%s/required: must be an array", path)
+			}
+			for _, item := range items {
+				name, ok := item.(string)
+				if !ok {
+					return nil, errors.Errorf("#%s/required: must contain strings", path)
+				}
+				node.Required = append(node.Required, name)
+			}
+		case "additionalProperties":
+			allowed, ok := value.(bool)
+			if !ok {
+				return nil, errors.Errorf(
+					"#%s/additionalProperties: only boolean values are supported", path,
+				)
+			}
+			node.AdditionalProperties = &allowed
+		case "enum":
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.Errorf("#%s/enum: must be an array", path)
+			}
+			node.Enum = items
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				return nil, errors.Errorf("#%s/pattern: must be a string", path)
+			}
+			node.Pattern, err = regexp.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "#%s/pattern", path)
+			}
+		case "minLength", "maxLength":
+			n, ok := value.(float64)
+			if !ok || n < 0 || n != float64(int(n)) {
+				return nil, errors.Errorf("#%s/%s: must be a non-negative integer", path, key)
+			}
+			length := int(n)
+			if key == "minLength" {
+				node.MinLength = &length
+			} else {
+				node.MaxLength = &length
+			}
+		case "minimum", "maximum":
+			n, ok := value.(float64)
+			if !ok {
+				return nil, errors.Errorf("#%s/%s: must be a number", path, key)
+			}
+			if key == "minimum" {
+				node.Minimum = &n
+			} else {
+				node.Maximum = &n
+			}
+		default:
+			if _, ok := schemaAnnotations[key]; !ok {
+				return nil, errors.Errorf("#%s: unsupported keyword %q", path, key)
+			}
+		}
+	}
+	if node.Type == "" && node.Properties != nil {
+		node.Type = "object"
+	}
+	return node, nil
+}
+
+// ValidateConfiguration checks the configuration against the schema; the returned
+// error is a *ValidationError listing every failure.
+func (s *Schema) ValidateConfiguration(attrs Attributes) error {
+	var errs []SchemaError
+	present := make(map[string]struct{}, len(attrs))
+	for _, attr := range attrs {
+		present[attr.Key] = struct{}{}
+		path := "/" + escapeJSONPointer(attr.Key)
+		prop, ok := s.root.Properties[attr.Key]
+		if !ok {
+			if s.root.AdditionalProperties != nil && !*s.root.AdditionalProperties {
+				errs = append(errs, SchemaError{
+					Path:    path,
+					Message: "property is not allowed by the schema",
+				})
+			}
+			continue
+		}
+		if msg := prop.validateValue(attr.Value); msg != "" {
+			errs = append(errs, SchemaError{Path: path, Message: msg})
+		}
+	}
+	for _, name := range s.root.Required {
+		if _, ok := present[name]; !ok {
+			errs = append(errs, SchemaError{
+				Path:    "/" + escapeJSONPointer(name),
+				Message: "property is required",
+			})
+		}
+	}
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].Path < errs[j].Path
+		})
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// validateValue validates a configuration value; since values are always
+// transmitted as strings, numeric and boolean types are checked on the
+// string representation.
+func (node *schemaNode) validateValue(value interface{}) string {
+	str := fmt.Sprint(value)
+	var number float64
+	switch node.Type {
+	case "number":
+		n, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return "must be a number"
+		}
+		number = n
+	case "integer":
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return "must be an integer"
+		}
+		number = float64(n)
+	case "boolean":
+		if str != "true" && str != "false" {
+			return `must be "true" or "false"`
+		}
+	case "null":
+		if str != "" {
+			return "must be empty"
+		}
+	}
+	if len(node.Enum) > 0 {
+		var found bool
+		for _, item := range node.Enum {
+			if fmt.Sprint(item) == str {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("must be one of %v", node.Enum)
+		}
+	}
+	if node.Pattern != nil && !node.Pattern.MatchString(str) {
+		return fmt.Sprintf("must match the pattern %q", node.Pattern.String())
+	}
+	length := len([]rune(str))
+	if node.MinLength != nil && length < *node.MinLength {
+		return fmt.Sprintf("must be at least %d characters long", *node.MinLength)
+	}
+	if node.MaxLength != nil && length > *node.MaxLength {
+		return fmt.Sprintf("must be at most %d characters long", *node.MaxLength)
+	}
+	if node.Type == "number" || node.Type == "integer" {
+		if node.Minimum != nil && number < *node.Minimum {
+			return fmt.Sprintf("must be greater than or equal to %v", *node.Minimum)
+		}
+		if node.Maximum != nil && number > *node.Maximum {
+			return fmt.Sprintf("must be less than or equal to %v", *node.Maximum)
+		}
+	}
+	return ""
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
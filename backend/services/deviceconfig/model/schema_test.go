@@ -0,0 +1,197 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Raspberry Pi",
+	"type": "object",
+	"properties": {
+		"hostname": {"type": "string", "pattern": "^[a-z0-9-]+$", "maxLength": 16},
+		"log_level": {"enum": ["debug", "info", "warning"]},
+		"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+		"ratio": {"type": "number", "maximum": 1},
+		"ssh/enabled": {"type": "boolean"}
+	},
+	"required": ["hostname"],
+	"additionalProperties": false
+}`
+
+func TestParseSchema(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		Schema string
+		Error  string
+	}{{
+		Name:   "ok",
+		Schema: testSchema,
+	}, {
+		Name:   "ok, properties without type",
+		Schema: `{"properties": {"hostname": {}}}`,
+	}, {
+		Name:   "error, not an object",
+		Schema: `{"type": "string"}`,
+		Error:  ErrSchemaNotObject.Error(),
+	}, {
+		Name:   "error, malformed",
+		Schema: `{"type": `,
+		Error:  "invalid schema: unexpected end of JSON input",
+	}, {
+		Name:   "error, unsupported keyword",
+		Schema: `{"type": "object", "oneOf": []}`,
+		Error:  `invalid schema: #: unsupported keyword "oneOf"`,
+	}, {
+		Name:   "error, nested object",
+		Schema: `{"type": "object", "properties": {"a": {"type": "object"}}}`,
+		Error:  `invalid schema: #/properties/a: unsupported type "object"`,
+	}, {
+		Name:   "error, bad pattern",
+		Schema: `{"type": "object", "properties": {"a": {"pattern": "("}}}`,
+		Error: "invalid schema: #/properties/a/pattern: error parsing regexp: " +
+			"missing closing ): `(`",
+	}, {
+		Name:   "error, bad length",
+		Schema: `{"type": "object", "properties": {"a": {"minLength": -1}}}`,
+		Error:  "invalid schema: #/properties/a/minLength: must be a non-negative integer",
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			schema, err := ParseSchema([]byte(tc.Schema))
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+				return
+			}
+			require.NoError(t, err)
+			b, err := json.Marshal(schema)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.Schema, string(b))
+		})
+	}
+}
+
+func TestSchemaBSON(t *testing.T) {
+	t.Parallel()
+
+	schema, err := ParseSchema([]byte(testSchema))
+	require.NoError(t, err)
+	b, err := bson.Marshal(ConfigurationSchema{
+		TargetType: SchemaTargetGroup,
+		Target:     "office",
+		Schema:     schema,
+	})
+	require.NoError(t, err)
+
+	var decoded ConfigurationSchema
+	err = bson.Unmarshal(b, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, schema, decoded.Schema)
+}
+
+func TestSchemaValidateConfiguration(t *testing.T) {
+	t.Parallel()
+
+	schema, err := ParseSchema([]byte(testSchema))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name string
+
+		Configuration Attributes
+		Errors        []SchemaError
+	}{{
+		Name: "ok",
+
+		Configuration: Attributes{
+			{Key: "hostname", Value: "raspberrypi"},
+			{Key: "log_level", Value: "debug"},
+			{Key: "port", Value: "22"},
+			{Key: "ratio", Value: "0.5"},
+			{Key: "ssh/enabled", Value: "true"},
+		},
+	}, {
+		Name: "error, every keyword",
+
+		Configuration: Attributes{
+			{Key: "hostname", Value: "Raspberry Pi"},
+			{Key: "log_level", Value: "trace"},
+			{Key: "port", Value: "0"},
+			{Key: "ratio", Value: "half"},
+			{Key: "ssh/enabled", Value: "yes"},
+			{Key: "hostnmae", Value: "typo"},
+		},
+		Errors: []SchemaError{{
+			Path:    "/hostname",
+			Message: `must match the pattern "^[a-z0-9-]+$"`,
+		}, {
+			Path:    "/hostnmae",
+			Message: "property is not allowed by the schema",
+		}, {
+			Path:    "/log_level",
+			Message: "must be one of [debug info warning]",
+		}, {
+			Path:    "/port",
+			Message: "must be greater than or equal to 1",
+		}, {
+			Path:    "/ratio",
+			Message: "must be a number",
+		}, {
+			Path:    "/ssh~1enabled",
+			Message: `must be "true" or "false"`,
+		}},
+	}, {
+		Name: "error, required and length",
+
+		Configuration: Attributes{
+			{Key: "port", Value: "8080"},
+			{Key: "ratio", Value: "1.5"},
+		},
+		Errors: []SchemaError{{
+			Path:    "/hostname",
+			Message: "property is required",
+		}, {
+			Path:    "/ratio",
+			Message: "must be less than or equal to 1",
+		}},
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			err := schema.ValidateConfiguration(tc.Configuration)
+			if tc.Errors == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			if assert.ErrorAs(t, err, &verr) {
+				assert.Equal(t, tc.Errors, verr.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuthorType string
+
+const (
+	AuthorUser   AuthorType = "user"
+	AuthorSystem AuthorType = "system"
+)
+
+// Author identifies who changed the configuration of a device.
+type Author struct {
+	ID   string     `bson:"id,omitempty" json:"id,omitempty"`
+	Type AuthorType `bson:"type" json:"type"`
+}
+
+// ConfigurationVersion is a snapshot of the configuration of a device.
+// A new version is stored every time the configuration changes.
+type ConfigurationVersion struct {
+	ID       uuid.UUID `bson:"_id" json:"id"`
+	DeviceID string    `bson:"device_id" json:"device_id"`
+	// Version is a sequence number, starting from 1, unique per device.
+	Version       int        `bson:"version" json:"version"`
+	Configuration Attributes `bson:"configuration" json:"configuration"`
+	Author        Author     `bson:"author" json:"author"`
+	CreatedTS     time.Time  `bson:"created_ts" json:"created_ts"`
+}
+
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// AttributeChange describes the difference of a single attribute between
+// two configurations.
+type AttributeChange struct {
+	Key    string      `bson:"key" json:"key"`
+	Change ChangeType  `bson:"change" json:"change"`
+	From   interface{} `bson:"from,omitempty" json:"from,omitempty"`
+	To     interface{} `bson:"to,omitempty" json:"to,omitempty"`
+}
+
+// DiffAttributes returns the changes from one configuration to another,
+// sorted by key.
+func DiffAttributes(from, to Attributes) []AttributeChange {
+	fromMap := make(map[string]interface{}, len(from))
+	for _, attr := range from {
+		fromMap[attr.Key] = attr.Value
+	}
+	changes := []AttributeChange{}
+	seen := make(map[string]struct{}, len(to))
+	for _, attr := range to {
+		seen[attr.Key] = struct{}{}
+		value, ok := fromMap[attr.Key]
+		if !ok {
+			changes = append(changes, AttributeChange{
+				Key:    attr.Key,
+				Change: ChangeAdded,
+				To:     attr.Value,
+			})
+		} else if !reflect.DeepEqual(value, attr.Value) {
+			changes = append(changes, AttributeChange{
+				Key:    attr.Key,
+				Change: ChangeModified,
+				From:   value,
+				To:     attr.Value,
+			})
+		}
+	}
+	for _, attr := range from {
+		if _, ok := seen[attr.Key]; !ok {
+			changes = append(changes, AttributeChange{
+				Key:    attr.Key,
+				Change: ChangeRemoved,
+				From:   attr.Value,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// VersionDiff is the difference between two configuration versions.
+type VersionDiff struct {
+	From    int               `json:"from"`
+	To      int               `json:"to"`
+	Changes []AttributeChange `json:"changes"`
+}
+
+// Drift records the differences between the configuration deployed to a
+// device and the configuration the device reported afterwards.
+type Drift struct {
+	Changes    []AttributeChange `bson:"changes" json:"changes"`
+	DetectedTS time.Time         `bson:"detected_ts" json:"detected_ts"`
+}
+
+// DriftFilter selects the devices listed in the drift report.
+type DriftFilter struct {
+	// Key restricts the report to devices drifting on the attribute.
+	Key     string
+	Page    int64
+	PerPage int64
+}
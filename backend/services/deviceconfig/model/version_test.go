@@ -0,0 +1,53 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffAttributes(t *testing.T) {
+	t.Parallel()
+
+	from := Attributes{
+		{Key: "hostname", Value: "foo"},
+		{Key: "ntp", Value: "pool.ntp.org"},
+		{Key: "timezone", Value: "UTC"},
+	}
+	to := Attributes{
+		{Key: "timezone", Value: "UTC"},
+		{Key: "hostname", Value: "bar"},
+		{Key: "log_level", Value: "debug"},
+	}
+	assert.Equal(t, []AttributeChange{{
+		Key:    "hostname",
+		Change: ChangeModified,
+		From:   "foo",
+		To:     "bar",
+	}, {
+		Key:    "log_level",
+		Change: ChangeAdded,
+		To:     "debug",
+	}, {
+		Key:    "ntp",
+		Change: ChangeRemoved,
+		From:   "pool.ntp.org",
+	}}, DiffAttributes(from, to))
+
+	assert.Empty(t, DiffAttributes(from, from))
+	assert.Empty(t, DiffAttributes(nil, nil))
+}
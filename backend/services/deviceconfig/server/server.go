@@ -28,6 +28,7 @@ import (
 
 	api "github.com/mendersoftware/mender-server/services/deviceconfig/api/http"
 	"github.com/mendersoftware/mender-server/services/deviceconfig/app"
+	"github.com/mendersoftware/mender-server/services/deviceconfig/client/inventory"
 	"github.com/mendersoftware/mender-server/services/deviceconfig/client/workflows"
 	. "github.com/mendersoftware/mender-server/services/deviceconfig/config"
 	"github.com/mendersoftware/mender-server/services/deviceconfig/store"
@@ -41,9 +42,21 @@ func InitAndRun(dataStore store.DataStore) error {
 	wflows := workflows.NewClient(
 		config.Config.GetString(SettingWorkflowsURL),
 	)
+	inv := inventory.NewClient(
+		config.Config.GetString(SettingInventoryURL),
+		inventory.ClientOptions{
+			Client: &http.Client{
+				Timeout: time.Duration(
+					config.Config.GetInt(SettingInventoryTimeout),
+				) * time.Second,
+			},
+		},
+	)
 	appl := app.New(
-		dataStore, wflows, app.Config{
-			HaveAuditLogs: config.Config.GetBool(SettingEnableAudit),
+		dataStore, wflows, inv, app.Config{
+			HaveAuditLogs:    config.Config.GetBool(SettingEnableAudit),
+			ReportDrift:      config.Config.GetBool(SettingReportDrift),
+			SyncIntegrations: config.Config.GetBool(SettingSyncIntegrations),
 		},
 	)
 
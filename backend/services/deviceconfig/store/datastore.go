@@ -26,6 +26,13 @@ import (
 var (
 	ErrDeviceNoExist       = errors.New("device does not exist")
 	ErrDeviceAlreadyExists = errors.New("device already exists")
+
+	ErrVersionNoExist = errors.New("configuration version does not exist")
+
+	ErrProfileNoExist       = errors.New("profile does not exist")
+	ErrProfileAlreadyExists = errors.New("a profile with the same name already exists")
+
+	ErrSchemaNoExist = errors.New("configuration schema does not exist")
 )
 
 // DataStore interface for DataStore services
@@ -62,12 +69,87 @@ type DataStore interface {
 	// to the existing set of (desired) attributes).
 	UpdateConfiguration(ctx context.Context, deviceID string, attrs model.Attributes) error
 
-	// SetDeploymentID updates the deployment ID of the device
-	SetDeploymentID(ctx context.Context, devID string, deploymentID uuid.UUID) error
-
-	// DeleteDevice removes the device object with the given ID from the database.
+	// SetDeploymentID updates the deployment ID of the device along with
+	// the deployed configuration, and clears the configuration drift.
+	SetDeploymentID(
+		ctx context.Context,
+		devID string,
+		deploymentID uuid.UUID,
+		deployed model.Attributes,
+	) error
+
+	// SetDrift sets the configuration drift of the device, or clears it
+	// if drift is nil.
+	SetDrift(ctx context.Context, devID string, drift *model.Drift) error
+
+	// FindDriftedDevices returns the devices with a configuration drift
+	// and their total count.
+	FindDriftedDevices(ctx context.Context, filter model.DriftFilter) ([]model.Device, int64, error)
+
+	// AddConfigurationVersion stores a new configuration version, assigning
+	// it the next version number for the device.
+	AddConfigurationVersion(ctx context.Context, version *model.ConfigurationVersion) error
+
+	// GetConfigurationVersions returns the configuration versions of a
+	// device, latest first, and their total count.
+	GetConfigurationVersions(
+		ctx context.Context,
+		devID string,
+		page, perPage int64,
+	) ([]model.ConfigurationVersion, int64, error)
+
+	// GetConfigurationVersion returns a configuration version of a device.
+	// Version 0 refers to the latest version.
+	GetConfigurationVersion(
+		ctx context.Context,
+		devID string,
+		version int,
+	) (model.ConfigurationVersion, error)
+
+	// DeleteDevice removes the device object with the given ID, and its
+	// configuration versions, from the database.
 	DeleteDevice(ctx context.Context, devID string) error
 
 	// GetDevice returns a device
 	GetDevice(ctx context.Context, devID string) (model.Device, error)
+
+	// InsertProfile inserts a new configuration profile
+	InsertProfile(ctx context.Context, profile model.Profile) error
+
+	// ReplaceProfile replaces an existing configuration profile
+	ReplaceProfile(ctx context.Context, profile model.Profile) error
+
+	// GetProfile returns the configuration profile with the given ID
+	GetProfile(ctx context.Context, profileID uuid.UUID) (model.Profile, error)
+
+	// GetProfiles returns all the configuration profiles sorted by
+	// priority and name
+	GetProfiles(ctx context.Context) ([]model.Profile, error)
+
+	// DeleteProfile removes the configuration profile with the given ID
+	DeleteProfile(ctx context.Context, profileID uuid.UUID) error
+
+	// GetDefaults returns the tenant default configuration
+	GetDefaults(ctx context.Context) (model.Attributes, error)
+
+	// SetDefaults replaces the tenant default configuration
+	SetDefaults(ctx context.Context, attrs model.Attributes) error
+
+	// SetSchema inserts or replaces the configuration schema of a
+	// device type or group
+	SetSchema(ctx context.Context, schema model.ConfigurationSchema) error
+
+	// GetSchema returns the configuration schema of a device type or group
+	GetSchema(
+		ctx context.Context,
+		targetType model.SchemaTargetType,
+		target string,
+	) (model.ConfigurationSchema, error)
+
+	// GetSchemas returns all the configuration schemas of the tenant
+	GetSchemas(ctx context.Context) ([]model.ConfigurationSchema, error)
+
+	// DeleteSchema removes the configuration schema of a device type or
+	// group
+	DeleteSchema(ctx context.Context, targetType model.SchemaTargetType, target string) error
 }
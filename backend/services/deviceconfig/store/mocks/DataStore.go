@@ -30,6 +30,24 @@ type DataStore struct {
 	mock.Mock
 }
 
+// AddConfigurationVersion provides a mock function with given fields: ctx, version
+func (_m *DataStore) AddConfigurationVersion(ctx context.Context, version *model.ConfigurationVersion) error {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddConfigurationVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ConfigurationVersion) error); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields: ctx
 func (_m *DataStore) Close(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -66,6 +84,42 @@ func (_m *DataStore) DeleteDevice(ctx context.Context, devID string) error {
 	return r0
 }
 
+// DeleteProfile provides a mock function with given fields: ctx, profileID
+func (_m *DataStore) DeleteProfile(ctx context.Context, profileID uuid.UUID) error {
+	ret := _m.Called(ctx, profileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, profileID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSchema provides a mock function with given fields: ctx, targetType, target
+func (_m *DataStore) DeleteSchema(ctx context.Context, targetType model.SchemaTargetType, target string) error {
+	ret := _m.Called(ctx, targetType, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchema")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SchemaTargetType, string) error); ok {
+		r0 = rf(ctx, targetType, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTenant provides a mock function with given fields: ctx, tenant_id
 func (_m *DataStore) DeleteTenant(ctx context.Context, tenant_id string) error {
 	ret := _m.Called(ctx, tenant_id)
@@ -102,6 +156,138 @@ func (_m *DataStore) DropDatabase(ctx context.Context) error {
 	return r0
 }
 
+// FindDriftedDevices provides a mock function with given fields: ctx, filter
+func (_m *DataStore) FindDriftedDevices(ctx context.Context, filter model.DriftFilter) ([]model.Device, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDriftedDevices")
+	}
+
+	var r0 []model.Device
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DriftFilter) ([]model.Device, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DriftFilter) []model.Device); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DriftFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.DriftFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetConfigurationVersion provides a mock function with given fields: ctx, devID, version
+func (_m *DataStore) GetConfigurationVersion(ctx context.Context, devID string, version int) (model.ConfigurationVersion, error) {
+	ret := _m.Called(ctx, devID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigurationVersion")
+	}
+
+	var r0 model.ConfigurationVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (model.ConfigurationVersion, error)); ok {
+		return rf(ctx, devID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) model.ConfigurationVersion); ok {
+		r0 = rf(ctx, devID, version)
+	} else {
+		r0 = ret.Get(0).(model.ConfigurationVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, devID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfigurationVersions provides a mock function with given fields: ctx, devID, page, perPage
+func (_m *DataStore) GetConfigurationVersions(ctx context.Context, devID string, page int64, perPage int64) ([]model.ConfigurationVersion, int64, error) {
+	ret := _m.Called(ctx, devID, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigurationVersions")
+	}
+
+	var r0 []model.ConfigurationVersion
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) ([]model.ConfigurationVersion, int64, error)); ok {
+		return rf(ctx, devID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) []model.ConfigurationVersion); ok {
+		r0 = rf(ctx, devID, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ConfigurationVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) int64); ok {
+		r1 = rf(ctx, devID, page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64, int64) error); ok {
+		r2 = rf(ctx, devID, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDefaults provides a mock function with given fields: ctx
+func (_m *DataStore) GetDefaults(ctx context.Context) (model.Attributes, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefaults")
+	}
+
+	var r0 model.Attributes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (model.Attributes, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) model.Attributes); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Attributes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: ctx, devID
 func (_m *DataStore) GetDevice(ctx context.Context, devID string) (model.Device, error) {
 	ret := _m.Called(ctx, devID)
@@ -130,6 +316,122 @@ func (_m *DataStore) GetDevice(ctx context.Context, devID string) (model.Device,
 	return r0, r1
 }
 
+// GetProfile provides a mock function with given fields: ctx, profileID
+func (_m *DataStore) GetProfile(ctx context.Context, profileID uuid.UUID) (model.Profile, error) {
+	ret := _m.Called(ctx, profileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 model.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (model.Profile, error)); ok {
+		return rf(ctx, profileID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) model.Profile); ok {
+		r0 = rf(ctx, profileID)
+	} else {
+		r0 = ret.Get(0).(model.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, profileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfiles provides a mock function with given fields: ctx
+func (_m *DataStore) GetProfiles(ctx context.Context) ([]model.Profile, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfiles")
+	}
+
+	var r0 []model.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.Profile, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Profile); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Profile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchema provides a mock function with given fields: ctx, targetType, target
+func (_m *DataStore) GetSchema(ctx context.Context, targetType model.SchemaTargetType, target string) (model.ConfigurationSchema, error) {
+	ret := _m.Called(ctx, targetType, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchema")
+	}
+
+	var r0 model.ConfigurationSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SchemaTargetType, string) (model.ConfigurationSchema, error)); ok {
+		return rf(ctx, targetType, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.SchemaTargetType, string) model.ConfigurationSchema); ok {
+		r0 = rf(ctx, targetType, target)
+	} else {
+		r0 = ret.Get(0).(model.ConfigurationSchema)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.SchemaTargetType, string) error); ok {
+		r1 = rf(ctx, targetType, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchemas provides a mock function with given fields: ctx
+func (_m *DataStore) GetSchemas(ctx context.Context) ([]model.ConfigurationSchema, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchemas")
+	}
+
+	var r0 []model.ConfigurationSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.ConfigurationSchema, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.ConfigurationSchema); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ConfigurationSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertDevice provides a mock function with given fields: ctx, dev
 func (_m *DataStore) InsertDevice(ctx context.Context, dev model.Device) error {
 	ret := _m.Called(ctx, dev)
@@ -148,6 +450,24 @@ func (_m *DataStore) InsertDevice(ctx context.Context, dev model.Device) error {
 	return r0
 }
 
+// InsertProfile provides a mock function with given fields: ctx, profile
+func (_m *DataStore) InsertProfile(ctx context.Context, profile model.Profile) error {
+	ret := _m.Called(ctx, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Profile) error); ok {
+		r0 = rf(ctx, profile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Migrate provides a mock function with given fields: ctx, version, automigrate
 func (_m *DataStore) Migrate(ctx context.Context, version string, automigrate bool) error {
 	ret := _m.Called(ctx, version, automigrate)
@@ -220,6 +540,24 @@ func (_m *DataStore) ReplaceConfiguration(ctx context.Context, dev model.Device)
 	return r0
 }
 
+// ReplaceProfile provides a mock function with given fields: ctx, profile
+func (_m *DataStore) ReplaceProfile(ctx context.Context, profile model.Profile) error {
+	ret := _m.Called(ctx, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Profile) error); ok {
+		r0 = rf(ctx, profile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReplaceReportedConfiguration provides a mock function with given fields: ctx, dev
 func (_m *DataStore) ReplaceReportedConfiguration(ctx context.Context, dev model.Device) error {
 	ret := _m.Called(ctx, dev)
@@ -238,17 +576,71 @@ func (_m *DataStore) ReplaceReportedConfiguration(ctx context.Context, dev model
 	return r0
 }
 
-// SetDeploymentID provides a mock function with given fields: ctx, devID, deploymentID
-func (_m *DataStore) SetDeploymentID(ctx context.Context, devID string, deploymentID uuid.UUID) error {
-	ret := _m.Called(ctx, devID, deploymentID)
+// SetDefaults provides a mock function with given fields: ctx, attrs
+func (_m *DataStore) SetDefaults(ctx context.Context, attrs model.Attributes) error {
+	ret := _m.Called(ctx, attrs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDefaults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Attributes) error); ok {
+		r0 = rf(ctx, attrs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDeploymentID provides a mock function with given fields: ctx, devID, deploymentID, deployed
+func (_m *DataStore) SetDeploymentID(ctx context.Context, devID string, deploymentID uuid.UUID, deployed model.Attributes) error {
+	ret := _m.Called(ctx, devID, deploymentID, deployed)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetDeploymentID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
-		r0 = rf(ctx, devID, deploymentID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, model.Attributes) error); ok {
+		r0 = rf(ctx, devID, deploymentID, deployed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDrift provides a mock function with given fields: ctx, devID, drift
+func (_m *DataStore) SetDrift(ctx context.Context, devID string, drift *model.Drift) error {
+	ret := _m.Called(ctx, devID, drift)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDrift")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.Drift) error); ok {
+		r0 = rf(ctx, devID, drift)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSchema provides a mock function with given fields: ctx, schema
+func (_m *DataStore) SetSchema(ctx context.Context, schema model.ConfigurationSchema) error {
+	ret := _m.Called(ctx, schema)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSchema")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ConfigurationSchema) error); ok {
+		r0 = rf(ctx, schema)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -36,13 +36,34 @@ import (
 const (
 	// CollDevices refers to the collection name for device configurations
 	CollDevices = "devices"
+	// CollProfiles refers to the collection name for configuration profiles
+	CollProfiles = "profiles"
+	// CollDefaults refers to the collection name for tenant defaults
+	CollDefaults = "defaults"
+	// CollVersions refers to the collection name for configuration versions
+	CollVersions = "versions"
+	// CollSchemas refers to the collection name for configuration schemas
+	CollSchemas = "schemas"
 	// fields
-	fieldID           = "_id"
-	fieldConfigured   = "configured"
-	fieldReported     = "reported"
-	fieldUpdatedTs    = "updated_ts"
-	fieldReportedTs   = "reported_ts"
-	fieldDeploymentID = "deployment_id"
+	fieldID            = "_id"
+	fieldConfigured    = "configured"
+	fieldReported      = "reported"
+	fieldUpdatedTs     = "updated_ts"
+	fieldReportedTs    = "reported_ts"
+	fieldDeploymentID  = "deployment_id"
+	fieldName          = "name"
+	fieldPriority      = "priority"
+	fieldConfiguration = "configuration"
+	fieldDeployed      = "deployed"
+	fieldDeployedTs    = "deployed_ts"
+	fieldDrift         = "drift"
+	fieldDriftKey      = "drift.changes.key"
+	fieldDriftTs       = "drift.detected_ts"
+	fieldConfigVersion = "config_version"
+	fieldDeviceID      = "device_id"
+	fieldVersion       = "version"
+	fieldTargetType    = "target_type"
+	fieldTarget        = "target"
 
 	KeyTenantID = "tenant_id"
 )
@@ -304,7 +325,7 @@ func (db *MongoStore) UpdateConfiguration(
 }
 
 func (db *MongoStore) SetDeploymentID(ctx context.Context, devID string,
-	deploymentID uuid.UUID) error {
+	deploymentID uuid.UUID, deployed model.Attributes) error {
 	collDevs := db.Database(ctx).Collection(CollDevices)
 
 	fltr := bson.D{{
@@ -318,7 +339,19 @@ func (db *MongoStore) SetDeploymentID(ctx context.Context, devID string,
 				Key:   fieldDeploymentID,
 				Value: deploymentID,
 			},
+			{
+				Key:   fieldDeployed,
+				Value: deployed,
+			},
+			{
+				Key:   fieldDeployedTs,
+				Value: time.Now().UTC(),
+			},
 		},
+		"$unset": bson.D{{
+			Key:   fieldDrift,
+			Value: "",
+		}},
 	}
 
 	res, err := collDevs.UpdateOne(ctx, mstore.WithTenantID(ctx, fltr), update, mopts.Update())
@@ -330,6 +363,172 @@ func (db *MongoStore) SetDeploymentID(ctx context.Context, devID string,
 	return nil
 }
 
+func (db *MongoStore) SetDrift(ctx context.Context, devID string, drift *model.Drift) error {
+	collDevs := db.Database(ctx).Collection(CollDevices)
+
+	fltr := bson.D{{
+		Key:   fieldID,
+		Value: devID,
+	}}
+	var update bson.D
+	if drift != nil {
+		update = bson.D{{
+			Key:   "$set",
+			Value: bson.D{{Key: fieldDrift, Value: drift}},
+		}}
+	} else {
+		update = bson.D{{
+			Key:   "$unset",
+			Value: bson.D{{Key: fieldDrift, Value: ""}},
+		}}
+	}
+	res, err := collDevs.UpdateOne(ctx, mstore.WithTenantID(ctx, fltr), update)
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to set the configuration drift")
+	} else if res.MatchedCount == 0 {
+		return errors.Wrap(store.ErrDeviceNoExist, "mongo")
+	}
+	return nil
+}
+
+func (db *MongoStore) FindDriftedDevices(
+	ctx context.Context,
+	filter model.DriftFilter,
+) ([]model.Device, int64, error) {
+	collDevs := db.Database(ctx).Collection(CollDevices)
+
+	fltr := bson.D{{
+		Key:   fieldDrift,
+		Value: bson.D{{Key: "$exists", Value: true}},
+	}}
+	if filter.Key != "" {
+		fltr = append(fltr, bson.E{Key: fieldDriftKey, Value: filter.Key})
+	}
+	fltr = mstore.WithTenantID(ctx, fltr)
+
+	count, err := collDevs.CountDocuments(ctx, fltr)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to count drifted devices")
+	}
+	opts := mopts.Find().
+		SetSort(bson.D{
+			{Key: fieldDriftTs, Value: -1},
+			{Key: fieldID, Value: 1},
+		})
+	if filter.PerPage > 0 {
+		opts.SetLimit(filter.PerPage)
+		if filter.Page > 1 {
+			opts.SetSkip((filter.Page - 1) * filter.PerPage)
+		}
+	}
+	cur, err := collDevs.Find(ctx, fltr, opts)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to find drifted devices")
+	}
+	devices := []model.Device{}
+	if err := cur.All(ctx, &devices); err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to decode drifted devices")
+	}
+	return devices, count, nil
+}
+
+func (db *MongoStore) AddConfigurationVersion(
+	ctx context.Context,
+	version *model.ConfigurationVersion,
+) error {
+	database := db.Database(ctx)
+
+	// The version number is a counter kept in the device document, so
+	// that concurrent changes are assigned distinct versions.
+	fltr := bson.D{{
+		Key:   fieldID,
+		Value: version.DeviceID,
+	}}
+	var counter struct {
+		Version int `bson:"config_version"`
+	}
+	err := database.Collection(CollDevices).FindOneAndUpdate(ctx,
+		mstore.WithTenantID(ctx, fltr),
+		bson.D{{
+			Key:   "$inc",
+			Value: bson.D{{Key: fieldConfigVersion, Value: 1}},
+		}},
+		mopts.FindOneAndUpdate().
+			SetReturnDocument(mopts.After).
+			SetProjection(bson.D{{Key: fieldConfigVersion, Value: 1}}),
+	).Decode(&counter)
+	if err == mongo.ErrNoDocuments {
+		return errors.Wrap(store.ErrDeviceNoExist, "mongo")
+	} else if err != nil {
+		return errors.Wrap(err, "mongo: failed to increment the configuration version")
+	}
+	version.Version = counter.Version
+
+	_, err = database.Collection(CollVersions).
+		InsertOne(ctx, mstore.WithTenantID(ctx, version))
+	return errors.Wrap(err, "mongo: failed to store configuration version")
+}
+
+func (db *MongoStore) GetConfigurationVersions(
+	ctx context.Context,
+	devID string,
+	page, perPage int64,
+) ([]model.ConfigurationVersion, int64, error) {
+	collVersions := db.Database(ctx).Collection(CollVersions)
+
+	fltr := mstore.WithTenantID(ctx, bson.D{{
+		Key:   fieldDeviceID,
+		Value: devID,
+	}})
+	count, err := collVersions.CountDocuments(ctx, fltr)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to count configuration versions")
+	}
+	opts := mopts.Find().
+		SetSort(bson.D{{Key: fieldVersion, Value: -1}})
+	if perPage > 0 {
+		opts.SetLimit(perPage)
+		if page > 1 {
+			opts.SetSkip((page - 1) * perPage)
+		}
+	}
+	cur, err := collVersions.Find(ctx, fltr, opts)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to list configuration versions")
+	}
+	versions := []model.ConfigurationVersion{}
+	if err := cur.All(ctx, &versions); err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to decode configuration versions")
+	}
+	return versions, count, nil
+}
+
+func (db *MongoStore) GetConfigurationVersion(
+	ctx context.Context,
+	devID string,
+	version int,
+) (model.ConfigurationVersion, error) {
+	collVersions := db.Database(ctx).Collection(CollVersions)
+
+	fltr := bson.D{{
+		Key:   fieldDeviceID,
+		Value: devID,
+	}}
+	opts := mopts.FindOne()
+	if version > 0 {
+		fltr = append(fltr, bson.E{Key: fieldVersion, Value: version})
+	} else {
+		opts.SetSort(bson.D{{Key: fieldVersion, Value: -1}})
+	}
+	var result model.ConfigurationVersion
+	err := collVersions.FindOne(ctx, mstore.WithTenantID(ctx, fltr), opts).
+		Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return result, errors.Wrap(store.ErrVersionNoExist, "mongo")
+	}
+	return result, errors.Wrap(err, "mongo: failed to get configuration version")
+}
+
 func (db *MongoStore) DeleteDevice(ctx context.Context, devID string) error {
 	collDevs := db.Database(ctx).Collection(CollDevices)
 
@@ -341,8 +540,16 @@ func (db *MongoStore) DeleteDevice(ctx context.Context, devID string) error {
 
 	if res != nil && res.DeletedCount == 0 {
 		return errors.Wrap(store.ErrDeviceNoExist, "mongo")
+	} else if err != nil {
+		return errors.Wrap(err, "mongo: failed to delete device configuration")
 	}
-	return errors.Wrap(err, "mongo: failed to delete device configuration")
+
+	_, err = db.Database(ctx).Collection(CollVersions).
+		DeleteMany(ctx, mstore.WithTenantID(ctx, bson.D{{
+			Key:   fieldDeviceID,
+			Value: devID,
+		}}))
+	return errors.Wrap(err, "mongo: failed to delete configuration versions")
 }
 
 func (db *MongoStore) GetDevice(ctx context.Context, devID string) (model.Device, error) {
@@ -364,6 +571,238 @@ func (db *MongoStore) GetDevice(ctx context.Context, devID string) (model.Device
 	return device, nil
 }
 
+func (db *MongoStore) InsertProfile(ctx context.Context, profile model.Profile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	collProfiles := db.Database(ctx).Collection(CollProfiles)
+
+	_, err := collProfiles.InsertOne(ctx, mstore.WithTenantID(ctx, profile))
+	if IsDuplicateKeyErr(err) {
+		return store.ErrProfileAlreadyExists
+	}
+	return errors.Wrap(err, "mongo: failed to store profile")
+}
+
+func (db *MongoStore) ReplaceProfile(ctx context.Context, profile model.Profile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	collProfiles := db.Database(ctx).Collection(CollProfiles)
+
+	fltr := bson.D{{
+		Key:   fieldID,
+		Value: profile.ID,
+	}}
+	res, err := collProfiles.ReplaceOne(ctx,
+		mstore.WithTenantID(ctx, fltr),
+		mstore.WithTenantID(ctx, profile),
+	)
+	if IsDuplicateKeyErr(err) {
+		return store.ErrProfileAlreadyExists
+	} else if err != nil {
+		return errors.Wrap(err, "mongo: failed to replace profile")
+	} else if res.MatchedCount == 0 {
+		return errors.Wrap(store.ErrProfileNoExist, "mongo")
+	}
+	return nil
+}
+
+func (db *MongoStore) GetProfile(
+	ctx context.Context,
+	profileID uuid.UUID,
+) (model.Profile, error) {
+	collProfiles := db.Database(ctx).Collection(CollProfiles)
+
+	fltr := bson.D{{
+		Key:   fieldID,
+		Value: profileID,
+	}}
+	var profile model.Profile
+	err := collProfiles.FindOne(ctx, mstore.WithTenantID(ctx, fltr)).
+		Decode(&profile)
+	if err == mongo.ErrNoDocuments {
+		return profile, errors.Wrap(store.ErrProfileNoExist, "mongo")
+	}
+	return profile, errors.Wrap(err, "mongo: failed to get profile")
+}
+
+func (db *MongoStore) GetProfiles(ctx context.Context) ([]model.Profile, error) {
+	collProfiles := db.Database(ctx).Collection(CollProfiles)
+
+	cur, err := collProfiles.Find(ctx,
+		mstore.WithTenantID(ctx, bson.D{}),
+		mopts.Find().SetSort(bson.D{
+			{Key: fieldPriority, Value: 1},
+			{Key: fieldName, Value: 1},
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to list profiles")
+	}
+	profiles := []model.Profile{}
+	if err := cur.All(ctx, &profiles); err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to decode profiles")
+	}
+	return profiles, nil
+}
+
+func (db *MongoStore) DeleteProfile(ctx context.Context, profileID uuid.UUID) error {
+	collProfiles := db.Database(ctx).Collection(CollProfiles)
+
+	fltr := bson.D{{
+		Key:   fieldID,
+		Value: profileID,
+	}}
+	res, err := collProfiles.DeleteOne(ctx, mstore.WithTenantID(ctx, fltr))
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to delete profile")
+	} else if res.DeletedCount == 0 {
+		return errors.Wrap(store.ErrProfileNoExist, "mongo")
+	}
+	return nil
+}
+
+// defaultsID returns the ID of the tenant defaults document, which is
+// unique per tenant.
+func defaultsID(ctx context.Context) string {
+	if id := identity.FromContext(ctx); id != nil {
+		return id.Tenant
+	}
+	return ""
+}
+
+func (db *MongoStore) GetDefaults(ctx context.Context) (model.Attributes, error) {
+	collDefaults := db.Database(ctx).Collection(CollDefaults)
+
+	fltr := bson.D{{
+		Key:   fieldID,
+		Value: defaultsID(ctx),
+	}}
+	var doc struct {
+		Configuration model.Attributes `bson:"configuration"`
+	}
+	err := collDefaults.FindOne(ctx, mstore.WithTenantID(ctx, fltr)).
+		Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return model.Attributes{}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to get default configuration")
+	}
+	if doc.Configuration == nil {
+		doc.Configuration = model.Attributes{}
+	}
+	return doc.Configuration, nil
+}
+
+func (db *MongoStore) SetDefaults(ctx context.Context, attrs model.Attributes) error {
+	if err := attrs.Validate(); err != nil {
+		return err
+	}
+	collDefaults := db.Database(ctx).Collection(CollDefaults)
+
+	fltr := bson.D{{
+		Key:   fieldID,
+		Value: defaultsID(ctx),
+	}}
+	update := bson.M{
+		"$set": bson.D{
+			{
+				Key:   fieldConfiguration,
+				Value: attrs,
+			},
+			{
+				Key:   fieldUpdatedTs,
+				Value: time.Now().UTC(),
+			},
+		},
+	}
+	_, err := collDefaults.UpdateOne(ctx,
+		mstore.WithTenantID(ctx, fltr),
+		update,
+		mopts.Update().SetUpsert(true))
+	return errors.Wrap(err, "mongo: failed to store default configuration")
+}
+
+func schemaFilter(targetType model.SchemaTargetType, target string) bson.D {
+	return bson.D{{
+		Key:   fieldTargetType,
+		Value: targetType,
+	}, {
+		Key:   fieldTarget,
+		Value: target,
+	}}
+}
+
+func (db *MongoStore) SetSchema(ctx context.Context, schema model.ConfigurationSchema) error {
+	if err := schema.Validate(); err != nil {
+		return err
+	}
+	collSchemas := db.Database(ctx).Collection(CollSchemas)
+
+	_, err := collSchemas.ReplaceOne(ctx,
+		mstore.WithTenantID(ctx, schemaFilter(schema.TargetType, schema.Target)),
+		mstore.WithTenantID(ctx, schema),
+		mopts.Replace().SetUpsert(true),
+	)
+	return errors.Wrap(err, "mongo: failed to store configuration schema")
+}
+
+func (db *MongoStore) GetSchema(
+	ctx context.Context,
+	targetType model.SchemaTargetType,
+	target string,
+) (model.ConfigurationSchema, error) {
+	collSchemas := db.Database(ctx).Collection(CollSchemas)
+
+	var schema model.ConfigurationSchema
+	err := collSchemas.FindOne(ctx,
+		mstore.WithTenantID(ctx, schemaFilter(targetType, target)),
+	).Decode(&schema)
+	if err == mongo.ErrNoDocuments {
+		return schema, errors.Wrap(store.ErrSchemaNoExist, "mongo")
+	}
+	return schema, errors.Wrap(err, "mongo: failed to get configuration schema")
+}
+
+func (db *MongoStore) GetSchemas(ctx context.Context) ([]model.ConfigurationSchema, error) {
+	collSchemas := db.Database(ctx).Collection(CollSchemas)
+
+	cur, err := collSchemas.Find(ctx,
+		mstore.WithTenantID(ctx, bson.D{}),
+		mopts.Find().SetSort(bson.D{
+			{Key: fieldTargetType, Value: 1},
+			{Key: fieldTarget, Value: 1},
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to list configuration schemas")
+	}
+	schemas := []model.ConfigurationSchema{}
+	if err := cur.All(ctx, &schemas); err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to decode configuration schemas")
+	}
+	return schemas, nil
+}
+
+func (db *MongoStore) DeleteSchema(
+	ctx context.Context,
+	targetType model.SchemaTargetType,
+	target string,
+) error {
+	collSchemas := db.Database(ctx).Collection(CollSchemas)
+
+	res, err := collSchemas.DeleteOne(ctx,
+		mstore.WithTenantID(ctx, schemaFilter(targetType, target)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to delete configuration schema")
+	} else if res.DeletedCount == 0 {
+		return errors.Wrap(store.ErrSchemaNoExist, "mongo")
+	}
+	return nil
+}
+
 func (db *MongoStore) DeleteTenant(ctx context.Context, tenant_id string) error {
 	database := db.Database(ctx)
 	collectionNames, err := database.ListCollectionNames(ctx, mopts.ListCollectionsOptions{})
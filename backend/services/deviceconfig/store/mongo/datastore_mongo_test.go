@@ -883,7 +883,10 @@ func TestSetDeploymentID(t *testing.T) {
 			err := ds.InsertDevice(ctx, testDevice)
 			require.NoError(t, err)
 
-			err = ds.SetDeploymentID(tc.CTX, tc.ID, tc.DeploymentID)
+			err = ds.SetDeploymentID(tc.CTX, tc.ID, tc.DeploymentID, model.Attributes{{
+				Key:   "hostname",
+				Value: "foo",
+			}})
 			if tc.Error != nil {
 				if assert.Error(t, err) {
 					assert.Regexp(t, tc.Error.Error(), err.Error())
@@ -999,3 +1002,332 @@ func TestDeleteTenant(t *testing.T) {
 	_, err = d.GetDevice(ctx, testDevice.ID)
 	assert.Error(t, err, store.ErrDeviceNoExist.Error())
 }
+
+func TestProfiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestProfiles in short mode.")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	ctx = identity.WithContext(ctx, &identity.Identity{
+		Tenant: "123456789012345678901234",
+	})
+	ds := GetTestDataStore(t)
+	defer ds.DropDatabase(ctx)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	office := model.Profile{
+		ID:   uuid.New(),
+		Name: "office",
+		Configuration: model.Attributes{
+			{Key: "ntp", Value: "ntp.example.com"},
+		},
+		Group:     "office",
+		Priority:  10,
+		CreatedTS: now,
+		UpdatedTS: now,
+	}
+	arm := model.Profile{
+		ID:   uuid.New(),
+		Name: "arm",
+		Filter: []model.FilterPredicate{{
+			Scope:     "inventory",
+			Attribute: "cpu_model",
+			Type:      model.FilterTypeEqual,
+			Value:     "ARMv7",
+		}},
+		CreatedTS: now,
+		UpdatedTS: now,
+	}
+
+	err := ds.InsertProfile(ctx, office)
+	require.NoError(t, err)
+	err = ds.InsertProfile(ctx, arm)
+	require.NoError(t, err)
+
+	err = ds.InsertProfile(ctx, model.Profile{ID: uuid.New()})
+	assert.EqualError(t, err, "name: cannot be blank.")
+
+	profile, err := ds.GetProfile(ctx, office.ID)
+	require.NoError(t, err)
+	assert.Equal(t, office, profile)
+
+	// profiles are not visible to other tenants
+	_, err = ds.GetProfile(context.Background(), office.ID)
+	assert.ErrorIs(t, err, store.ErrProfileNoExist)
+
+	profiles, err := ds.GetProfiles(ctx)
+	require.NoError(t, err)
+	if assert.Len(t, profiles, 2) {
+		assert.Equal(t, arm.ID, profiles[0].ID)
+		assert.Equal(t, office.ID, profiles[1].ID)
+	}
+
+	office.Priority = -1
+	office.Configuration = model.Attributes{}
+	err = ds.ReplaceProfile(ctx, office)
+	require.NoError(t, err)
+	profiles, err = ds.GetProfiles(ctx)
+	require.NoError(t, err)
+	if assert.Len(t, profiles, 2) {
+		assert.Equal(t, office.ID, profiles[0].ID)
+	}
+
+	err = ds.ReplaceProfile(ctx, model.Profile{ID: uuid.New(), Name: "missing"})
+	assert.ErrorIs(t, err, store.ErrProfileNoExist)
+
+	err = ds.DeleteProfile(ctx, office.ID)
+	require.NoError(t, err)
+	err = ds.DeleteProfile(ctx, office.ID)
+	assert.ErrorIs(t, err, store.ErrProfileNoExist)
+	_, err = ds.GetProfile(ctx, office.ID)
+	assert.ErrorIs(t, err, store.ErrProfileNoExist)
+}
+
+func TestDefaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestDefaults in short mode.")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	tenantCtx := identity.WithContext(ctx, &identity.Identity{
+		Tenant: "123456789012345678901234",
+	})
+	ds := GetTestDataStore(t)
+	defer ds.DropDatabase(ctx)
+
+	attrs, err := ds.GetDefaults(tenantCtx)
+	require.NoError(t, err)
+	assert.Empty(t, attrs)
+
+	defaults := model.Attributes{{Key: "ntp", Value: "pool.ntp.org"}}
+	err = ds.SetDefaults(tenantCtx, defaults)
+	require.NoError(t, err)
+	err = ds.SetDefaults(ctx, model.Attributes{{Key: "ntp", Value: "local"}})
+	require.NoError(t, err)
+
+	attrs, err = ds.GetDefaults(tenantCtx)
+	require.NoError(t, err)
+	assert.Equal(t, defaults, attrs)
+
+	attrs, err = ds.GetDefaults(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, model.Attributes{{Key: "ntp", Value: "local"}}, attrs)
+
+	err = ds.SetDefaults(ctx, model.Attributes{{Key: "ntp", Value: 1}})
+	assert.Error(t, err)
+}
+
+func TestConfigurationVersions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestConfigurationVersions in short mode.")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	ctx = identity.WithContext(ctx, &identity.Identity{
+		Tenant: "123456789012345678901234",
+	})
+	ds := GetTestDataStore(t)
+	defer ds.DropDatabase(ctx)
+
+	const devID = "device"
+	err := ds.InsertDevice(ctx, model.Device{ID: devID, UpdatedTS: ptrNow()})
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for i, value := range []string{"foo", "bar", "baz"} {
+		version := &model.ConfigurationVersion{
+			ID:            uuid.New(),
+			DeviceID:      devID,
+			Configuration: model.Attributes{{Key: "hostname", Value: value}},
+			Author:        model.Author{Type: model.AuthorSystem},
+			CreatedTS:     now,
+		}
+		err = ds.AddConfigurationVersion(ctx, version)
+		require.NoError(t, err)
+		assert.Equal(t, i+1, version.Version)
+	}
+
+	err = ds.AddConfigurationVersion(ctx, &model.ConfigurationVersion{
+		ID:       uuid.New(),
+		DeviceID: "missing",
+	})
+	assert.ErrorIs(t, err, store.ErrDeviceNoExist)
+
+	versions, count, err := ds.GetConfigurationVersions(ctx, devID, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	if assert.Len(t, versions, 2) {
+		assert.Equal(t, 3, versions[0].Version)
+		assert.Equal(t, 2, versions[1].Version)
+	}
+
+	version, err := ds.GetConfigurationVersion(ctx, devID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.Attributes{{Key: "hostname", Value: "foo"}},
+		version.Configuration)
+
+	version, err = ds.GetConfigurationVersion(ctx, devID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version.Version)
+
+	_, err = ds.GetConfigurationVersion(ctx, devID, 4)
+	assert.ErrorIs(t, err, store.ErrVersionNoExist)
+
+	// versions are not visible to other tenants
+	_, err = ds.GetConfigurationVersion(context.Background(), devID, 1)
+	assert.ErrorIs(t, err, store.ErrVersionNoExist)
+
+	err = ds.DeleteDevice(ctx, devID)
+	require.NoError(t, err)
+	_, count, err = ds.GetConfigurationVersions(ctx, devID, 1, 20)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestDrift(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestDrift in short mode.")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	ctx = identity.WithContext(ctx, &identity.Identity{
+		Tenant: "123456789012345678901234",
+	})
+	ds := GetTestDataStore(t)
+	defer ds.DropDatabase(ctx)
+
+	deployed := model.Attributes{
+		{Key: "hostname", Value: "foo"},
+		{Key: "ntp", Value: "pool.ntp.org"},
+	}
+	for _, devID := range []string{"dev1", "dev2", "dev3"} {
+		err := ds.InsertDevice(ctx, model.Device{ID: devID, UpdatedTS: ptrNow()})
+		require.NoError(t, err)
+		err = ds.SetDeploymentID(ctx, devID, uuid.New(), deployed)
+		require.NoError(t, err)
+	}
+	dev, err := ds.GetDevice(ctx, "dev1")
+	require.NoError(t, err)
+	assert.Equal(t, deployed, dev.DeployedAttributes)
+	assert.NotNil(t, dev.DeployedTS)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	err = ds.SetDrift(ctx, "dev1", &model.Drift{
+		Changes: []model.AttributeChange{{
+			Key:    "hostname",
+			Change: model.ChangeModified,
+			From:   "foo",
+			To:     "bar",
+		}},
+		DetectedTS: now.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	err = ds.SetDrift(ctx, "dev2", &model.Drift{
+		Changes: []model.AttributeChange{{
+			Key:    "ntp",
+			Change: model.ChangeRemoved,
+			From:   "pool.ntp.org",
+		}},
+		DetectedTS: now,
+	})
+	require.NoError(t, err)
+	err = ds.SetDrift(ctx, "missing", nil)
+	assert.ErrorIs(t, err, store.ErrDeviceNoExist)
+
+	devices, count, err := ds.FindDriftedDevices(ctx, model.DriftFilter{
+		Page:    1,
+		PerPage: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	if assert.Len(t, devices, 2) {
+		assert.Equal(t, "dev2", devices[0].ID)
+		assert.Equal(t, "dev1", devices[1].ID)
+	}
+
+	devices, count, err = ds.FindDriftedDevices(ctx, model.DriftFilter{
+		Key:     "hostname",
+		Page:    1,
+		PerPage: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	if assert.Len(t, devices, 1) {
+		assert.Equal(t, "dev1", devices[0].ID)
+	}
+
+	// a new deployment resets the drift
+	err = ds.SetDeploymentID(ctx, "dev1", uuid.New(), deployed)
+	require.NoError(t, err)
+	err = ds.SetDrift(ctx, "dev2", nil)
+	require.NoError(t, err)
+	_, count, err = ds.FindDriftedDevices(ctx, model.DriftFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestSchemas(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestSchemas in short mode.")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	ctx = identity.WithContext(ctx, &identity.Identity{
+		Tenant: "123456789012345678901234",
+	})
+	ds := GetTestDataStore(t)
+	defer ds.DropDatabase(ctx)
+
+	parse := func(doc string) *model.Schema {
+		schema, err := model.ParseSchema([]byte(doc))
+		require.NoError(t, err)
+		return schema
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	byType := model.ConfigurationSchema{
+		TargetType: model.SchemaTargetDeviceType,
+		Target:     "raspberrypi4",
+		Schema:     parse(`{"$schema": "https://json-schema.org/draft/2020-12/schema"}`),
+		UpdatedTS:  now,
+	}
+	byGroup := model.ConfigurationSchema{
+		TargetType: model.SchemaTargetGroup,
+		Target:     "office",
+		Schema:     parse(`{"type": "object", "required": ["ntp"]}`),
+		UpdatedTS:  now,
+	}
+	require.NoError(t, ds.SetSchema(ctx, byType))
+	require.NoError(t, ds.SetSchema(ctx, byGroup))
+
+	err := ds.SetSchema(ctx, model.ConfigurationSchema{
+		TargetType: "firmware",
+		Target:     "foo",
+		Schema:     byType.Schema,
+	})
+	assert.Error(t, err)
+
+	schema, err := ds.GetSchema(ctx, model.SchemaTargetGroup, "office")
+	require.NoError(t, err)
+	assert.Equal(t, byGroup, schema)
+
+	// replace the existing schema
+	byGroup.Schema = parse(`{"type": "object"}`)
+	require.NoError(t, ds.SetSchema(ctx, byGroup))
+
+	schemas, err := ds.GetSchemas(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ConfigurationSchema{byType, byGroup}, schemas)
+
+	// schemas are not visible to other tenants
+	schemas, err = ds.GetSchemas(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, schemas)
+
+	err = ds.DeleteSchema(ctx, model.SchemaTargetGroup, "office")
+	require.NoError(t, err)
+	err = ds.DeleteSchema(ctx, model.SchemaTargetGroup, "office")
+	assert.ErrorIs(t, err, store.ErrSchemaNoExist)
+	_, err = ds.GetSchema(ctx, model.SchemaTargetGroup, "office")
+	assert.ErrorIs(t, err, store.ErrSchemaNoExist)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+)
+
+// migration_1_0_2 creates the indexes for the configuration profiles.
+type migration_1_0_2 struct {
+	client *mongo.Client
+	db     string
+}
+
+func (m *migration_1_0_2) Up(from migrate.Version) error {
+	if m.db != DbName {
+		// profiles are only stored in the shared database
+		return nil
+	}
+	ctx := context.Background()
+	_, err := m.client.Database(m.db).
+		Collection(CollProfiles).
+		Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: mstore.FieldTenantID, Value: 1},
+				{Key: fieldName, Value: 1},
+			},
+			Options: mopts.Index().
+				SetName(mstore.FieldTenantID + "_" + fieldName).
+				SetUnique(true),
+		})
+	return err
+}
+
+func (m *migration_1_0_2) Version() migrate.Version {
+	return migrate.MakeVersion(1, 0, 2)
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+)
+
+const (
+	IndexNameVersions = mstore.FieldTenantID + "_" + fieldDeviceID + "_" + fieldVersion
+	IndexNameDrift    = mstore.FieldTenantID + "_" + fieldDriftTs
+)
+
+// migration_1_0_3 creates the indexes for the configuration versions and
+// the drift report.
+type migration_1_0_3 struct {
+	client *mongo.Client
+	db     string
+}
+
+func (m *migration_1_0_3) Up(from migrate.Version) error {
+	if m.db != DbName {
+		return nil
+	}
+	ctx := context.Background()
+	database := m.client.Database(m.db)
+	_, err := database.Collection(CollVersions).
+		Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: mstore.FieldTenantID, Value: 1},
+				{Key: fieldDeviceID, Value: 1},
+				{Key: fieldVersion, Value: -1},
+			},
+			Options: mopts.Index().
+				SetName(IndexNameVersions).
+				SetUnique(true),
+		})
+	if err != nil {
+		return err
+	}
+	_, err = database.Collection(CollDevices).
+		Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: mstore.FieldTenantID, Value: 1},
+				{Key: fieldDriftTs, Value: -1},
+			},
+			Options: mopts.Index().
+				SetName(IndexNameDrift).
+				SetPartialFilterExpression(bson.D{{
+					Key:   fieldDrift,
+					Value: bson.D{{Key: "$exists", Value: true}},
+				}}),
+		})
+	return err
+}
+
+func (m *migration_1_0_3) Version() migrate.Version {
+	return migrate.MakeVersion(1, 0, 3)
+}
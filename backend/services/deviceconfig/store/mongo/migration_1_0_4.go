@@ -0,0 +1,58 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+)
+
+const IndexNameSchemas = mstore.FieldTenantID + "_" + fieldTargetType + "_" + fieldTarget
+
+// migration_1_0_4 creates a unique index for the configuration schemas.
+type migration_1_0_4 struct {
+	client *mongo.Client
+	db     string
+}
+
+func (m *migration_1_0_4) Up(from migrate.Version) error {
+	if m.db != DbName {
+		return nil
+	}
+	_, err := m.client.Database(m.db).
+		Collection(CollSchemas).
+		Indexes().
+		CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				{Key: mstore.FieldTenantID, Value: 1},
+				{Key: fieldTargetType, Value: 1},
+				{Key: fieldTarget, Value: 1},
+			},
+			Options: mopts.Index().
+				SetName(IndexNameSchemas).
+				SetUnique(true),
+		})
+	return err
+}
+
+func (m *migration_1_0_4) Version() migrate.Version {
+	return migrate.MakeVersion(1, 0, 4)
+}
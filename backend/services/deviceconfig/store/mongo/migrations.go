@@ -26,7 +26,7 @@ import (
 
 const (
 	// DbVersion is the current schema version
-	DbVersion = "1.0.1"
+	DbVersion = "1.0.4"
 
 	// DbName is the database name
 	DbName = "deviceconfig"
@@ -70,6 +70,18 @@ func (db *MongoStore) Migrate(ctx context.Context, version string, automigrate b
 				client: db.client,
 				db:     DBName,
 			},
+			&migration_1_0_2{
+				client: db.client,
+				db:     DBName,
+			},
+			&migration_1_0_3{
+				client: db.client,
+				db:     DBName,
+			},
+			&migration_1_0_4{
+				client: db.client,
+				db:     DBName,
+			},
 		}
 		err = m.Apply(ctx, *ver, migrations)
 		if err != nil {
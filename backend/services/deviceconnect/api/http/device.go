@@ -55,8 +55,9 @@ var (
 
 // DeviceController container for end-points
 type DeviceController struct {
-	app  app.App
-	nats nats.Client
+	app        app.App
+	nats       nats.Client
+	filePusher *filePusher
 }
 
 // NewDeviceController returns a new DeviceController
@@ -297,6 +298,18 @@ func (h DeviceController) ConnectServeWS(
 		l.Error(err)
 		return
 	}
+	// dispatch the commands queued while the device was offline
+	errDispatch := dispatchPendingCommands(ctx, h.app, h.nats, id.Tenant, id.Subject)
+	if errDispatch != nil {
+		l.Warnf("failed to dispatch pending commands: %s", errDispatch.Error())
+	}
+	// push the files queued while the device was offline
+	if h.filePusher != nil {
+		errPush := h.filePusher.pushPending(ctx, id.Tenant, id.Subject)
+		if errPush != nil {
+			l.Warnf("failed to push pending files: %s", errPush.Error())
+		}
+	}
 	defer func() {
 		for sessionID, session := range sessMap {
 			// TODO: notify the session NATS topic about the session
@@ -342,6 +355,14 @@ func (h DeviceController) ConnectServeWS(
 			return err
 		}
 
+		if m.Header.Proto == ws.ProtoTypeCommand {
+			errCmd := h.app.HandleCommandMessage(ctx, id.Subject, m)
+			if errCmd != nil {
+				l.Warnf("failed to process command message: %s", errCmd.Error())
+			}
+			continue
+		}
+
 		sessMap[m.Header.SessionID] = &model.ActiveSession{}
 		switch m.Header.Proto {
 		case ws.ProtoTypeShell:
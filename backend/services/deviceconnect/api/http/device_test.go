@@ -30,6 +30,7 @@ import (
 
 	"github.com/mendersoftware/mender-server/pkg/identity"
 	"github.com/mendersoftware/mender-server/pkg/ws"
+	"github.com/mendersoftware/mender-server/pkg/ws/command"
 	"github.com/mendersoftware/mender-server/pkg/ws/shell"
 	app_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/app/mocks"
 	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
@@ -68,6 +69,18 @@ func TestDeviceConnect(t *testing.T) {
 		Identity.Tenant,
 		Identity.Subject,
 	).Return(int64(1), nil).Once()
+	app.On("GetPendingCommands",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		Identity.Subject,
+	).Return([]model.CommandResult{}, nil)
+	app.On("GetPendingFilePushes",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		Identity.Subject,
+	).Return([]model.FilePushResult{}, nil)
 
 	app.On("SetDeviceDisconnected",
 		mock.MatchedBy(func(_ context.Context) bool {
@@ -307,6 +320,125 @@ func TestDeviceConnect(t *testing.T) {
 	app.AssertExpectations(t)
 }
 
+func TestDeviceConnectCommands(t *testing.T) {
+	Identity := identity.Identity{
+		Subject:  "00000000-0000-0000-0000-000000000000",
+		Tenant:   "000000000000000000000000",
+		IsDevice: true,
+	}
+	pending := []model.CommandResult{{
+		ID:       "result-1",
+		JobID:    "job-1",
+		DeviceID: Identity.Subject,
+		Status:   model.CommandStatusPending,
+		Command:  "df -h",
+		Timeout:  30,
+	}}
+
+	app := &app_mocks.App{}
+	defer app.AssertExpectations(t)
+	app.On("RegisterShutdownCancel",
+		mock.AnythingOfType("context.CancelFunc"),
+	).Return(uint32(1))
+	app.On("UnregisterShutdownCancel",
+		mock.AnythingOfType("uint32"),
+	).Return().Maybe()
+	app.On("SetDeviceConnected",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		Identity.Tenant,
+		Identity.Subject,
+	).Return(int64(1), nil)
+	app.On("SetDeviceDisconnected",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		Identity.Tenant,
+		Identity.Subject,
+		int64(1),
+	).Return(nil).Maybe()
+	app.On("GetPendingCommands",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		Identity.Subject,
+	).Return(pending, nil)
+	app.On("GetPendingFilePushes",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		Identity.Subject,
+	).Return([]model.FilePushResult{}, nil)
+	app.On("SetCommandsDispatched",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		[]string{"result-1"},
+	).Return(nil)
+	handled := make(chan *ws.ProtoMsg, 1)
+	app.On("HandleCommandMessage",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		Identity.Subject,
+		mock.AnythingOfType("*ws.ProtoMsg"),
+	).Run(func(args mock.Arguments) {
+		handled <- args.Get(2).(*ws.ProtoMsg)
+	}).Return(nil)
+
+	natsClient := NewNATSTestClient(t)
+	router, _ := NewRouter(app, natsClient, nil)
+	s := httptest.NewServer(router)
+	defer s.Close()
+
+	url := "ws" + strings.TrimPrefix(s.URL, "http")
+	headers := http.Header{}
+	headers.Set(
+		headerAuthorization,
+		"Bearer "+GenerateJWT(Identity),
+	)
+	conn, _, err := websocket.DefaultDialer.Dial(url+APIURLDevicesConnect, headers)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer conn.Close()
+
+	// the pending command is dispatched on connect
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var msg ws.ProtoMsg
+	_ = msgpack.Unmarshal(data, &msg)
+	assert.Equal(t, ws.ProtoTypeCommand, msg.Header.Proto)
+	assert.Equal(t, command.MessageTypeRun, msg.Header.MsgType)
+	assert.Equal(t, "result-1", msg.Header.SessionID)
+	var run command.RunCommand
+	_ = msgpack.Unmarshal(msg.Body, &run)
+	assert.Equal(t, command.RunCommand{Command: "df -h", Timeout: 30}, run)
+
+	// the output is handed over to the app
+	b, _ := msgpack.Marshal(ws.ProtoMsg{
+		Header: ws.ProtoHdr{
+			Proto:     ws.ProtoTypeCommand,
+			MsgType:   command.MessageTypeStdout,
+			SessionID: "result-1",
+		},
+		Body: []byte("Filesystem"),
+	})
+	err = conn.WriteMessage(websocket.BinaryMessage, b)
+	assert.NoError(t, err)
+	select {
+	case m := <-handled:
+		assert.Equal(t, command.MessageTypeStdout, m.Header.MsgType)
+		assert.Equal(t, []byte("Filesystem"), m.Body)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "timeout waiting for the command output")
+	}
+}
+
 func TestDeviceConnectFailures(t *testing.T) {
 	JWT := GenerateJWT(identity.Identity{
 		Subject:  "00000000-0000-0000-0000-000000000000",
@@ -0,0 +1,302 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	natsio "github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/ws"
+	wsft "github.com/mendersoftware/mender-server/pkg/ws/filetransfer"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/app"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/client/nats"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+const (
+	defaultFilePushConcurrency = 10
+
+	// filePushRetryBackoff is the delay before retrying a failed transfer
+	// to a connected device, doubled on every attempt up to
+	// filePushRetryMaxBackoff.
+	filePushRetryBackoff    = 30 * time.Second
+	filePushRetryMaxBackoff = 10 * time.Minute
+)
+
+// filePusher runs the transfers of the file push jobs in the background
+// using the same file transfer protocol as the ManagementController. The
+// number of concurrent transfers is bounded per instance of the service.
+type filePusher struct {
+	ManagementController
+	slots        chan struct{}
+	retryBackoff time.Duration
+}
+
+func newFilePusher(app app.App, natsClient nats.Client, concurrency int) *filePusher {
+	if concurrency <= 0 {
+		concurrency = defaultFilePushConcurrency
+	}
+	return &filePusher{
+		ManagementController: ManagementController{
+			app:  app,
+			nats: natsClient,
+		},
+		slots:        make(chan struct{}, concurrency),
+		retryBackoff: filePushRetryBackoff,
+	}
+}
+
+// detachContext returns a context with the identity and the logger of ctx
+// which is not canceled when the request completes.
+func detachContext(ctx context.Context) context.Context {
+	detached := identity.WithContext(context.Background(), identity.FromContext(ctx))
+	return log.WithContext(detached, log.FromContext(ctx))
+}
+
+// pushJob starts the transfers to the target devices which are connected;
+// the other devices receive the file when they connect.
+func (p *filePusher) pushJob(
+	ctx context.Context,
+	tenantID string,
+	results []model.FilePushResult,
+) {
+	l := log.FromContext(ctx)
+	defer l.SimpleRecovery()
+	for _, result := range results {
+		device, err := p.app.GetDevice(ctx, tenantID, result.DeviceID)
+		if err == app.ErrDeviceNotFound {
+			continue
+		} else if err != nil {
+			l.Warnf("failed to get device %s: %s", result.DeviceID, err.Error())
+			continue
+		} else if device.Status != model.DeviceStatusConnected {
+			continue
+		}
+		p.slots <- struct{}{}
+		go func(resultID string) {
+			defer func() { <-p.slots }()
+			p.push(ctx, tenantID, resultID)
+		}(result.ID)
+	}
+}
+
+// pushPending starts the transfers of the files queued while the device
+// was offline. The files are pushed one at a time in the order the jobs
+// were created.
+func (p *filePusher) pushPending(ctx context.Context, tenantID, deviceID string) error {
+	results, err := p.app.GetPendingFilePushes(ctx, deviceID)
+	if err != nil || len(results) == 0 {
+		return err
+	}
+	ctx = detachContext(ctx)
+	go func() {
+		for _, result := range results {
+			p.slots <- struct{}{}
+			p.push(ctx, tenantID, result.ID)
+			<-p.slots
+		}
+	}()
+	return nil
+}
+
+func (p *filePusher) push(ctx context.Context, tenantID, resultID string) {
+	l := log.FromContext(ctx)
+	defer l.SimpleRecovery()
+
+	job, result, err := p.app.StartFilePush(ctx, resultID)
+	if err == app.ErrFilePushNotPending {
+		return
+	} else if err != nil {
+		l.Warnf("failed to start file push %s: %s", resultID, err.Error())
+		return
+	}
+	errPush := p.transfer(tenantID, result.DeviceID, job)
+	if errPush != nil {
+		l.Warnf("failed to push file %s to device %s: %s",
+			job.ID, result.DeviceID, errPush.Error())
+	}
+	err = p.app.FinishFilePush(ctx, result, errPush, isFilePushRetriable(errPush))
+	if err != nil && err != app.ErrFilePushNotPending {
+		l.Errorf("failed to update file push %s: %s", resultID, err.Error())
+	} else if err == nil && result.Status == model.FilePushStatusPending {
+		p.retryLater(ctx, tenantID, result)
+	}
+}
+
+// retryLater schedules the next attempt of a failed transfer with an
+// exponential backoff. The attempt is skipped if the device disconnected
+// in the meantime, as the file is pushed again when it reconnects.
+func (p *filePusher) retryLater(
+	ctx context.Context,
+	tenantID string,
+	result *model.FilePushResult,
+) {
+	delay := p.retryBackoff
+	for i := 1; i < result.Attempts && delay < filePushRetryMaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, filePushRetryMaxBackoff)
+	time.AfterFunc(delay, func() {
+		l := log.FromContext(ctx)
+		defer l.SimpleRecovery()
+		device, err := p.app.GetDevice(ctx, tenantID, result.DeviceID)
+		if err != nil {
+			if err != app.ErrDeviceNotFound {
+				l.Warnf("failed to get device %s: %s", result.DeviceID, err.Error())
+			}
+			return
+		} else if device.Status != model.DeviceStatusConnected {
+			return
+		}
+		p.slots <- struct{}{}
+		defer func() { <-p.slots }()
+		p.push(ctx, tenantID, result.ID)
+	})
+}
+
+// isFilePushRetriable returns false for the errors reported by the
+// device, which would occur again on the next attempt.
+func isFilePushRetriable(err error) bool {
+	var statusError *Error
+	if err == nil {
+		return false
+	} else if errors.As(err, &statusError) {
+		return statusError.statusCode == http.StatusRequestTimeout
+	}
+	return true
+}
+
+// transfer uploads the file of the job to the device
+func (p *filePusher) transfer(tenantID, deviceID string, job *model.FilePushJob) error {
+	sessionUUID, err := newFileTransferSessionID()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate session ID")
+	}
+	sessionID := sessionUUID.String()
+
+	deviceTopic := model.GetDeviceSubject(tenantID, deviceID)
+	sessionTopic := model.GetSessionSubject(tenantID, sessionID)
+	msgChan := make(chan *natsio.Msg, channelSize)
+	sub, err := p.nats.ChanSubscribe(sessionTopic, msgChan)
+	if err != nil {
+		return errors.Wrap(err, errFileTransferSubscribing.Error())
+	}
+	//nolint:errcheck
+	defer sub.Unsubscribe()
+
+	if err = p.filetransferHandshake(msgChan, sessionID, deviceTopic); err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer p.publishControlMessage(sessionID, deviceTopic, ws.MessageTypeClose, nil)
+
+	req := wsft.UploadRequest{
+		SrcPath: &job.Filename,
+		Path:    &job.Path,
+		UID:     job.UID,
+		GID:     job.GID,
+		Mode:    job.Mode,
+	}
+	if err := p.publishFileTransferProtoMessage(sessionID,
+		job.UserID, deviceTopic, wsft.MessageTypePut, req, 0); err != nil {
+		return err
+	}
+	ackOffset, err := p.waitFileTransferACK(msgChan, sessionID, job.UserID, deviceTopic)
+	if err != nil {
+		return err
+	}
+
+	var offset int64
+	size := int64(len(job.Content))
+	window := int64(fileTransferBufferSize * ackSlidingWindowRecv)
+	for {
+		end := min(offset+int64(fileTransferBufferSize), size)
+		// an empty chunk signals the end of the file
+		if err := p.publishFileTransferProtoMessage(sessionID,
+			job.UserID, deviceTopic, wsft.MessageTypeChunk,
+			job.Content[offset:end], offset); err != nil {
+			return err
+		}
+		if offset == size {
+			break
+		}
+		offset = end
+
+		// wait for acks, in case the ack sliding window is over
+		for offset > ackOffset+window {
+			ack, err := p.waitFileTransferACK(msgChan, sessionID, job.UserID, deviceTopic)
+			if err != nil {
+				return err
+			}
+			ackOffset = max(ackOffset, ack)
+		}
+	}
+	for offset > ackOffset {
+		ack, err := p.waitFileTransferACK(msgChan, sessionID, job.UserID, deviceTopic)
+		if err != nil {
+			return err
+		}
+		ackOffset = max(ackOffset, ack)
+	}
+	return nil
+}
+
+// waitFileTransferACK waits for the next ack from the device, answering
+// the pings in the meantime, and returns the acknowledged offset.
+func (p *filePusher) waitFileTransferACK(
+	msgChan <-chan *natsio.Msg,
+	sessionID, userID, deviceTopic string,
+) (int64, error) {
+	timeout := time.NewTimer(fileTransferTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case wsMessage := <-msgChan:
+			msg, msgBody, err := p.decodeFileTransferProtoMessage(wsMessage.Data)
+			if err != nil {
+				return -1, err
+			}
+			switch msg.Header.MsgType {
+			case wsft.MessageTypeError:
+				errorMsg := msgBody.(*ws.Error)
+				errCode := http.StatusBadRequest
+				if errorMsg.Code > 0 {
+					errCode = errorMsg.Code
+				}
+				return -1, NewError(errors.New(errorMsg.Error), errCode)
+
+			case wsft.MessageTypeACK:
+				offset, _ := msg.Header.Properties[PropertyOffset].(int64)
+				return offset, nil
+
+			case ws.MessageTypePing:
+				if err := p.publishFileTransferProtoMessage(
+					sessionID, userID, deviceTopic,
+					ws.MessageTypePong, nil, -1); err != nil {
+					return -1, err
+				}
+			}
+		case <-timeout.C:
+			return -1, errFileTransferTimeout
+		}
+	}
+}
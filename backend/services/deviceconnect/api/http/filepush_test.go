@@ -0,0 +1,308 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	natsio "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/mendersoftware/mender-server/pkg/ws"
+	wsft "github.com/mendersoftware/mender-server/pkg/ws/filetransfer"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/app"
+	app_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/app/mocks"
+	nats_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/client/nats/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+func TestFilePusherPush(t *testing.T) {
+	originalNewFileTransferSessionID := newFileTransferSessionID
+	originalFileTransferTimeout := fileTransferTimeout
+	defer func() {
+		newFileTransferSessionID = originalNewFileTransferSessionID
+		fileTransferTimeout = originalFileTransferTimeout
+	}()
+	fileTransferTimeout = 100 * time.Millisecond
+
+	sessionID, _ := uuid.NewRandom()
+	newFileTransferSessionID = func() (uuid.UUID, error) {
+		return sessionID, nil
+	}
+
+	const tenantID = "000000000000000000000000"
+	job := &model.FilePushJob{
+		ID:       "job-1",
+		UserID:   "user-1",
+		Path:     "/etc/ssl/certs/ca.pem",
+		Filename: "ca.pem",
+		Content:  []byte("certificate"),
+	}
+	result := &model.FilePushResult{
+		ID:       "r1",
+		JobID:    "job-1",
+		DeviceID: "device-1",
+		Status:   model.FilePushStatusRunning,
+		Attempts: 1,
+	}
+
+	accept, _ := msgpack.Marshal(ws.Accept{
+		Version:   ws.ProtocolVersion,
+		Protocols: []ws.ProtoType{ws.ProtoTypeFileTransfer},
+	})
+	acceptMsg, _ := msgpack.Marshal(ws.ProtoMsg{
+		Header: ws.ProtoHdr{
+			Proto:     ws.ProtoTypeControl,
+			MsgType:   ws.MessageTypeAccept,
+			SessionID: sessionID.String(),
+		},
+		Body: accept,
+	})
+	ackMsg := func(offset int64) []byte {
+		b, _ := msgpack.Marshal(ws.ProtoMsg{
+			Header: ws.ProtoHdr{
+				Proto:     ws.ProtoTypeFileTransfer,
+				MsgType:   wsft.MessageTypeACK,
+				SessionID: sessionID.String(),
+				Properties: map[string]interface{}{
+					PropertyOffset: offset,
+				},
+			},
+		})
+		return b
+	}
+	errBody, _ := msgpack.Marshal(ws.Error{Error: "permission denied"})
+	errMsg, _ := msgpack.Marshal(ws.ProtoMsg{
+		Header: ws.ProtoHdr{
+			Proto:     ws.ProtoTypeFileTransfer,
+			MsgType:   wsft.MessageTypeError,
+			SessionID: sessionID.String(),
+		},
+		Body: errBody,
+	})
+
+	testCases := []struct {
+		Name string
+
+		StartErr error
+		Messages [][]byte
+
+		PushErr error
+		Retry   bool
+	}{
+		{
+			Name: "ok",
+
+			Messages: [][]byte{acceptMsg, ackMsg(0), ackMsg(11)},
+		},
+		{
+			Name: "ok, already claimed",
+
+			StartErr: app.ErrFilePushNotPending,
+		},
+		{
+			Name: "ko, error from the device",
+
+			Messages: [][]byte{acceptMsg, errMsg},
+
+			PushErr: errors.New("permission denied"),
+			Retry:   false,
+		},
+		{
+			Name: "ko, missing ack from the device",
+
+			Messages: [][]byte{acceptMsg, ackMsg(0)},
+
+			PushErr: errFileTransferTimeout,
+			Retry:   true,
+		},
+		{
+			Name: "ko, device does not answer",
+
+			PushErr: errFileTransferTimeout,
+			Retry:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			deviceConnectApp := &app_mocks.App{}
+			defer deviceConnectApp.AssertExpectations(t)
+			natsClient := &nats_mocks.Client{}
+			defer natsClient.AssertExpectations(t)
+
+			if tc.StartErr != nil {
+				deviceConnectApp.On("StartFilePush", ctx, result.ID).
+					Return(nil, nil, tc.StartErr)
+			} else {
+				deviceConnectApp.On("StartFilePush", ctx, result.ID).
+					Return(job, result, nil)
+				deviceConnectApp.On("FinishFilePush", ctx, result,
+					mock.MatchedBy(func(err error) bool {
+						if tc.PushErr == nil {
+							return err == nil
+						}
+						return assert.EqualError(t, err, tc.PushErr.Error())
+					}),
+					tc.Retry,
+				).Return(nil)
+
+				natsClient.On("ChanSubscribe",
+					model.GetSessionSubject(tenantID, sessionID.String()),
+					mock.MatchedBy(func(msgChan chan *natsio.Msg) bool {
+						for _, data := range tc.Messages {
+							msgChan <- &natsio.Msg{Data: data}
+						}
+						return true
+					}),
+				).Return(&natsio.Subscription{}, nil)
+				natsClient.On("Publish",
+					model.GetDeviceSubject(tenantID, result.DeviceID),
+					mock.AnythingOfType("[]uint8"),
+				).Return(nil)
+			}
+
+			p := newFilePusher(deviceConnectApp, natsClient, 1)
+			p.push(ctx, tenantID, result.ID)
+		})
+	}
+}
+
+func TestFilePusherPushPending(t *testing.T) {
+	const tenantID = "000000000000000000000000"
+	ctx := context.Background()
+
+	deviceConnectApp := &app_mocks.App{}
+	defer deviceConnectApp.AssertExpectations(t)
+
+	deviceConnectApp.On("GetPendingFilePushes", ctx, "device-1").
+		Return(nil, errors.New("store error")).Once()
+	deviceConnectApp.On("GetPendingFilePushes", ctx, "device-1").
+		Return([]model.FilePushResult{{ID: "r1"}, {ID: "r2"}}, nil).Once()
+
+	started := make(chan string, 2)
+	deviceConnectApp.On("StartFilePush",
+		mock.MatchedBy(func(_ context.Context) bool {
+			return true
+		}),
+		mock.AnythingOfType("string"),
+	).Return(nil, nil, app.ErrFilePushNotPending).
+		Run(func(args mock.Arguments) {
+			started <- args.String(1)
+		})
+
+	p := newFilePusher(deviceConnectApp, nil, 1)
+	err := p.pushPending(ctx, tenantID, "device-1")
+	assert.EqualError(t, err, "store error")
+
+	err = p.pushPending(ctx, tenantID, "device-1")
+	assert.NoError(t, err)
+	for _, resultID := range []string{"r1", "r2"} {
+		select {
+		case id := <-started:
+			assert.Equal(t, resultID, id)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the file push to start")
+		}
+	}
+}
+
+func TestFilePusherRetry(t *testing.T) {
+	originalNewFileTransferSessionID := newFileTransferSessionID
+	originalFileTransferTimeout := fileTransferTimeout
+	defer func() {
+		newFileTransferSessionID = originalNewFileTransferSessionID
+		fileTransferTimeout = originalFileTransferTimeout
+	}()
+	fileTransferTimeout = 10 * time.Millisecond
+
+	sessionID, _ := uuid.NewRandom()
+	newFileTransferSessionID = func() (uuid.UUID, error) {
+		return sessionID, nil
+	}
+
+	const tenantID = "000000000000000000000000"
+	ctx := context.Background()
+	job := &model.FilePushJob{
+		ID:       "job-1",
+		UserID:   "user-1",
+		Path:     "/etc/ssl/certs/ca.pem",
+		Filename: "ca.pem",
+		Content:  []byte("certificate"),
+	}
+	result := &model.FilePushResult{
+		ID:       "r1",
+		JobID:    "job-1",
+		DeviceID: "device-1",
+		Status:   model.FilePushStatusRunning,
+		Attempts: 1,
+	}
+
+	deviceConnectApp := &app_mocks.App{}
+	defer deviceConnectApp.AssertExpectations(t)
+	natsClient := &nats_mocks.Client{}
+	defer natsClient.AssertExpectations(t)
+
+	deviceConnectApp.On("StartFilePush", ctx, result.ID).
+		Return(job, result, nil).Once()
+	deviceConnectApp.On("FinishFilePush", ctx, result,
+		mock.MatchedBy(func(err error) bool {
+			return err == errFileTransferTimeout
+		}),
+		true,
+	).Return(nil).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*model.FilePushResult).Status = model.FilePushStatusPending
+		}).Once()
+	deviceConnectApp.On("GetDevice", ctx, tenantID, result.DeviceID).
+		Return(&model.Device{
+			ID:     result.DeviceID,
+			Status: model.DeviceStatusConnected,
+		}, nil).Once()
+
+	retried := make(chan struct{})
+	deviceConnectApp.On("StartFilePush", ctx, result.ID).
+		Return(nil, nil, app.ErrFilePushNotPending).
+		Run(func(args mock.Arguments) {
+			close(retried)
+		}).Once()
+
+	natsClient.On("ChanSubscribe",
+		model.GetSessionSubject(tenantID, sessionID.String()),
+		mock.AnythingOfType("chan *nats.Msg"),
+	).Return(&natsio.Subscription{}, nil)
+	natsClient.On("Publish",
+		model.GetDeviceSubject(tenantID, result.DeviceID),
+		mock.AnythingOfType("[]uint8"),
+	).Return(nil)
+
+	p := newFilePusher(deviceConnectApp, natsClient, 1)
+	p.retryBackoff = time.Millisecond
+	p.push(ctx, tenantID, result.ID)
+
+	select {
+	case <-retried:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the file push to be retried")
+	}
+}
@@ -93,8 +93,9 @@ var wsUpgrader = websocket.Upgrader{
 
 // ManagementController container for end-points
 type ManagementController struct {
-	app  app.App
-	nats nats.Client
+	app        app.App
+	nats       nats.Client
+	filePusher *filePusher
 }
 
 // NewManagementController returns a new ManagementController
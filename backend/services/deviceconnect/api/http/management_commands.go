@@ -0,0 +1,234 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+	"github.com/mendersoftware/mender-server/pkg/ws"
+	"github.com/mendersoftware/mender-server/pkg/ws/command"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/app"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/client/nats"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+const (
+	hdrTotalCount = "X-Total-Count"
+
+	paramCommandStatus = "status"
+)
+
+// CreateCommandJob responds to POST /commands
+func (h ManagementController) CreateCommandJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	l := log.FromContext(ctx)
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	var newJob model.NewCommandJob
+	if err := c.ShouldBindJSON(&newJob); err != nil {
+		rest.RenderError(c, http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"))
+		return
+	}
+
+	job, results, err := h.app.CreateCommandJob(ctx, idata.Subject, newJob)
+	switch cause := errors.Cause(err); cause {
+	case nil:
+	case app.ErrNoDevicesFound, app.ErrTooManyDevices:
+		rest.RenderError(c, http.StatusBadRequest, cause)
+		return
+	default:
+		if _, ok := cause.(validation.Errors); ok {
+			rest.RenderError(c, http.StatusBadRequest, err)
+		} else {
+			rest.RenderInternalError(c, err)
+		}
+		return
+	}
+
+	// Devices that are not connected pick up the command on reconnect.
+	for _, result := range results {
+		if err := publishRunCommand(h.nats, idata.Tenant, result); err != nil {
+			l.Warnf("failed to dispatch command to device %s: %s",
+				result.DeviceID, err.Error())
+		}
+	}
+
+	c.Header("Location", "commands/"+job.ID)
+	c.JSON(http.StatusCreated, job)
+}
+
+// GetCommandJobs responds to GET /commands
+func (h ManagementController) GetCommandJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jobs, count, err := h.app.GetCommandJobs(ctx, (page-1)*perPage, perPage)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	writePagingHeaders(c, page, perPage, count)
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetCommandJob responds to GET /commands/:jobId
+func (h ManagementController) GetCommandJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	job, err := h.app.GetCommandJob(ctx, c.Param("jobId"))
+	if err == app.ErrCommandJobNotFound {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// GetCommandResults responds to GET /commands/:jobId/results
+func (h ManagementController) GetCommandResults(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	results, count, err := h.app.GetCommandResults(ctx, model.CommandResultsFilter{
+		JobID:  c.Param("jobId"),
+		Status: c.Query(paramCommandStatus),
+		Skip:   (page - 1) * perPage,
+		Limit:  perPage,
+	})
+	if err == app.ErrCommandJobNotFound {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	writePagingHeaders(c, page, perPage, count)
+	c.JSON(http.StatusOK, results)
+}
+
+func writePagingHeaders(c *gin.Context, page, perPage, count int64) {
+	hints := rest.NewPagingHints().
+		SetPage(page).
+		SetPerPage(perPage).
+		SetTotalCount(count)
+	links, err := rest.MakePagingHeaders(c.Request, hints)
+	if err == nil {
+		for _, link := range links {
+			c.Writer.Header().Add("Link", link)
+		}
+	}
+	c.Writer.Header().Set(hdrTotalCount, strconv.FormatInt(count, 10))
+}
+
+// publishRunCommand sends the command to the device. The result ID is used
+// as session ID, so the device can match the output to the request.
+func publishRunCommand(
+	natsClient nats.Client,
+	tenantID string,
+	result model.CommandResult,
+) error {
+	body, err := msgpack.Marshal(command.RunCommand{
+		Command: result.Command,
+		Timeout: result.Timeout,
+	})
+	if err != nil {
+		return err
+	}
+	msg := ws.ProtoMsg{
+		Header: ws.ProtoHdr{
+			Proto:     ws.ProtoTypeCommand,
+			MsgType:   command.MessageTypeRun,
+			SessionID: result.ID,
+		},
+		Body: body,
+	}
+	data, err := msgpack.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return natsClient.Publish(
+		model.GetDeviceSubject(tenantID, result.DeviceID),
+		data,
+	)
+}
+
+// dispatchPendingCommands sends the commands queued while the device was
+// offline.
+func dispatchPendingCommands(
+	ctx context.Context,
+	a app.App,
+	natsClient nats.Client,
+	tenantID, deviceID string,
+) error {
+	results, err := a.GetPendingCommands(ctx, deviceID)
+	if err != nil || len(results) == 0 {
+		return err
+	}
+	dispatched := make([]string, 0, len(results))
+	for _, result := range results {
+		if err := publishRunCommand(natsClient, tenantID, result); err != nil {
+			return err
+		}
+		dispatched = append(dispatched, result.ID)
+	}
+	return a.SetCommandsDispatched(ctx, dispatched)
+}
@@ -0,0 +1,365 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/app"
+	app_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/app/mocks"
+	nats_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/client/nats/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+func TestManagementCreateCommandJob(t *testing.T) {
+	userIdentity := &identity.Identity{
+		Subject: "00000000-0000-0000-0000-000000000000",
+		Tenant:  "000000000000000000000000",
+		IsUser:  true,
+	}
+	testCases := []struct {
+		Name     string
+		Identity *identity.Identity
+		Body     interface{}
+
+		Job        *model.CommandJob
+		Results    []model.CommandResult
+		CreateErr  error
+		PublishErr error
+
+		HTTPStatus int
+	}{
+		{
+			Name:     "ok",
+			Identity: userIdentity,
+			Body: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{DeviceIDs: []string{"1", "2"}},
+			},
+
+			Job: &model.CommandJob{ID: "job-1", Command: "df -h"},
+			Results: []model.CommandResult{
+				{ID: "r1", DeviceID: "1", Command: "df -h"},
+				{ID: "r2", DeviceID: "2", Command: "df -h"},
+			},
+
+			HTTPStatus: http.StatusCreated,
+		},
+		{
+			Name:     "ok, publish errors are ignored",
+			Identity: userIdentity,
+			Body: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{Group: "production"},
+			},
+
+			Job: &model.CommandJob{ID: "job-1", Command: "df -h"},
+			Results: []model.CommandResult{
+				{ID: "r1", DeviceID: "1", Command: "df -h"},
+			},
+			PublishErr: errors.New("nats down"),
+
+			HTTPStatus: http.StatusCreated,
+		},
+		{
+			Name: "ko, missing auth",
+			Body: model.NewCommandJob{},
+
+			HTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			Name:     "ko, malformed body",
+			Identity: userIdentity,
+			Body:     "foo",
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, validation error",
+			Identity: userIdentity,
+			Body:     model.NewCommandJob{},
+
+			CreateErr: errors.Wrap(validation.Errors{
+				"command": errors.New("cannot be blank"),
+			}, "app: invalid command job"),
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, no devices",
+			Identity: userIdentity,
+			Body: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{Group: "empty"},
+			},
+
+			CreateErr: app.ErrNoDevicesFound,
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, internal error",
+			Identity: userIdentity,
+			Body: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{Group: "production"},
+			},
+
+			CreateErr: errors.New("internal error"),
+
+			HTTPStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deviceConnectApp := &app_mocks.App{}
+			defer deviceConnectApp.AssertExpectations(t)
+			natsClient := &nats_mocks.Client{}
+			defer natsClient.AssertExpectations(t)
+
+			if newJob, ok := tc.Body.(model.NewCommandJob); ok && tc.Identity != nil {
+				deviceConnectApp.On("CreateCommandJob",
+					mock.MatchedBy(func(_ context.Context) bool {
+						return true
+					}),
+					tc.Identity.Subject,
+					newJob,
+				).Return(tc.Job, tc.Results, tc.CreateErr)
+			}
+			for _, result := range tc.Results {
+				natsClient.On("Publish",
+					model.GetDeviceSubject(tc.Identity.Tenant, result.DeviceID),
+					mock.AnythingOfType("[]uint8"),
+				).Return(tc.PublishErr)
+			}
+
+			router, _ := NewRouter(deviceConnectApp, natsClient, nil)
+
+			b, _ := json.Marshal(tc.Body)
+			req, _ := http.NewRequest(http.MethodPost,
+				"http://localhost"+APIURLManagementCommands,
+				bytes.NewReader(b),
+			)
+			if tc.Identity != nil {
+				req.Header.Set(headerAuthorization, "Bearer "+GenerateJWT(*tc.Identity))
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.HTTPStatus, w.Code)
+			if tc.HTTPStatus == http.StatusCreated {
+				var job model.CommandJob
+				_ = json.Unmarshal(w.Body.Bytes(), &job)
+				assert.Equal(t, *tc.Job, job)
+			}
+		})
+	}
+}
+
+func TestManagementGetCommandJob(t *testing.T) {
+	userIdentity := &identity.Identity{
+		Subject: "00000000-0000-0000-0000-000000000000",
+		Tenant:  "000000000000000000000000",
+		IsUser:  true,
+	}
+	testCases := []struct {
+		Name     string
+		Identity *identity.Identity
+
+		Job    *model.CommandJob
+		AppErr error
+
+		HTTPStatus int
+	}{
+		{
+			Name:     "ok",
+			Identity: userIdentity,
+
+			Job: &model.CommandJob{
+				ID:    "job-1",
+				Stats: map[string]int{model.CommandStatusSuccess: 2},
+			},
+
+			HTTPStatus: http.StatusOK,
+		},
+		{
+			Name: "ko, missing auth",
+
+			HTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			Name:     "ko, not found",
+			Identity: userIdentity,
+
+			AppErr: app.ErrCommandJobNotFound,
+
+			HTTPStatus: http.StatusNotFound,
+		},
+		{
+			Name:     "ko, internal error",
+			Identity: userIdentity,
+
+			AppErr: errors.New("internal error"),
+
+			HTTPStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deviceConnectApp := &app_mocks.App{}
+			defer deviceConnectApp.AssertExpectations(t)
+
+			if tc.Identity != nil {
+				deviceConnectApp.On("GetCommandJob",
+					mock.MatchedBy(func(_ context.Context) bool {
+						return true
+					}),
+					"job-1",
+				).Return(tc.Job, tc.AppErr)
+			}
+
+			router, _ := NewRouter(deviceConnectApp, nil, nil)
+
+			url := strings.Replace(APIURLManagementCommand, ":jobId", "job-1", 1)
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost"+url, nil)
+			if tc.Identity != nil {
+				req.Header.Set(headerAuthorization, "Bearer "+GenerateJWT(*tc.Identity))
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.HTTPStatus, w.Code)
+			if tc.HTTPStatus == http.StatusOK {
+				var job model.CommandJob
+				_ = json.Unmarshal(w.Body.Bytes(), &job)
+				assert.Equal(t, *tc.Job, job)
+			}
+		})
+	}
+}
+
+func TestManagementGetCommandResults(t *testing.T) {
+	userIdentity := &identity.Identity{
+		Subject: "00000000-0000-0000-0000-000000000000",
+		Tenant:  "000000000000000000000000",
+		IsUser:  true,
+	}
+	exitCode := 0
+	testCases := []struct {
+		Name     string
+		Identity *identity.Identity
+		Query    string
+
+		Filter  *model.CommandResultsFilter
+		Results []model.CommandResult
+		Count   int64
+		AppErr  error
+
+		HTTPStatus int
+	}{
+		{
+			Name:     "ok",
+			Identity: userIdentity,
+			Query:    "?status=success&page=2&per_page=1",
+
+			Filter: &model.CommandResultsFilter{
+				JobID:  "job-1",
+				Status: model.CommandStatusSuccess,
+				Skip:   1,
+				Limit:  1,
+			},
+			Results: []model.CommandResult{{
+				ID:       "r2",
+				JobID:    "job-1",
+				DeviceID: "2",
+				Status:   model.CommandStatusSuccess,
+				Stdout:   "Filesystem",
+				ExitCode: &exitCode,
+			}},
+			Count: 3,
+
+			HTTPStatus: http.StatusOK,
+		},
+		{
+			Name:     "ko, bad paging",
+			Identity: userIdentity,
+			Query:    "?page=0",
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, not found",
+			Identity: userIdentity,
+
+			Filter: &model.CommandResultsFilter{
+				JobID: "job-1",
+				Limit: 20,
+			},
+			AppErr: app.ErrCommandJobNotFound,
+
+			HTTPStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deviceConnectApp := &app_mocks.App{}
+			defer deviceConnectApp.AssertExpectations(t)
+
+			if tc.Filter != nil {
+				deviceConnectApp.On("GetCommandResults",
+					mock.MatchedBy(func(_ context.Context) bool {
+						return true
+					}),
+					*tc.Filter,
+				).Return(tc.Results, tc.Count, tc.AppErr)
+			}
+
+			router, _ := NewRouter(deviceConnectApp, nil, nil)
+
+			url := strings.Replace(APIURLManagementCommandResults, ":jobId", "job-1", 1)
+			req, _ := http.NewRequest(http.MethodGet,
+				"http://localhost"+url+tc.Query, nil)
+			if tc.Identity != nil {
+				req.Header.Set(headerAuthorization, "Bearer "+GenerateJWT(*tc.Identity))
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.HTTPStatus, w.Code)
+			if tc.HTTPStatus == http.StatusOK {
+				var results []model.CommandResult
+				_ = json.Unmarshal(w.Body.Bytes(), &results)
+				assert.Equal(t, tc.Results, results)
+				assert.Equal(t, "3", w.Header().Get(hdrTotalCount))
+			}
+		})
+	}
+}
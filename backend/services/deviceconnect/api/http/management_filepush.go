@@ -0,0 +1,230 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/app"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+const (
+	fieldFilePushTargets     = "targets"
+	fieldFilePushExpireAfter = "expire_after"
+
+	paramFilePushStatus = "status"
+)
+
+var errFilePushFileTooLarge = fmt.Errorf(
+	"file exceeds the maximum size of %d bytes", model.FilePushMaxFileSize)
+
+// CreateFilePushJob responds to POST /file-pushes
+func (h ManagementController) CreateFilePushJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	newJob, err := parseFilePushRequest(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"))
+		return
+	}
+
+	job, results, err := h.app.CreateFilePushJob(ctx, idata.Subject, *newJob)
+	switch cause := errors.Cause(err); cause {
+	case nil:
+	case app.ErrNoDevicesFound, app.ErrTooManyDevices:
+		rest.RenderError(c, http.StatusBadRequest, cause)
+		return
+	default:
+		if _, ok := cause.(validation.Errors); ok {
+			rest.RenderError(c, http.StatusBadRequest, err)
+		} else {
+			rest.RenderInternalError(c, err)
+		}
+		return
+	}
+
+	// Devices that are not connected receive the file on reconnect.
+	if h.filePusher != nil {
+		go h.filePusher.pushJob(detachContext(ctx), idata.Tenant, results)
+	}
+
+	c.Header("Location", "file-pushes/"+job.ID)
+	c.JSON(http.StatusCreated, job)
+}
+
+// GetFilePushJobs responds to GET /file-pushes
+func (h ManagementController) GetFilePushJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jobs, count, err := h.app.GetFilePushJobs(ctx, (page-1)*perPage, perPage)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	writePagingHeaders(c, page, perPage, count)
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetFilePushJob responds to GET /file-pushes/:jobId
+func (h ManagementController) GetFilePushJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	job, err := h.app.GetFilePushJob(ctx, c.Param("jobId"))
+	if err == app.ErrFilePushJobNotFound {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// GetFilePushResults responds to GET /file-pushes/:jobId/results
+func (h ManagementController) GetFilePushResults(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil || !idata.IsUser {
+		rest.RenderError(c, http.StatusUnauthorized, ErrMissingUserAuthentication)
+		return
+	}
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	results, count, err := h.app.GetFilePushResults(ctx, model.FilePushResultsFilter{
+		JobID:  c.Param("jobId"),
+		Status: c.Query(paramFilePushStatus),
+		Skip:   (page - 1) * perPage,
+		Limit:  perPage,
+	})
+	if err == app.ErrFilePushJobNotFound {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	writePagingHeaders(c, page, perPage, count)
+	c.JSON(http.StatusOK, results)
+}
+
+// parseFilePushRequest parses the multipart form of a file push job; the
+// fields are the same as the ones of the single device upload, plus the
+// JSON encoded targets and the expiration.
+func parseFilePushRequest(req *http.Request) (*model.NewFilePushJob, error) {
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	newJob := &model.NewFilePushJob{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(part, model.FilePushMaxFileSize+1))
+		part.Close()
+		if err != nil {
+			return nil, err
+		} else if len(data) > model.FilePushMaxFileSize {
+			return nil, errFilePushFileTooLarge
+		}
+		value := string(data)
+		switch part.FormName() {
+		case fieldUploadPath:
+			newJob.Path = &value
+		case fieldUploadUID:
+			v, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid uid")
+			}
+			uid := uint32(v)
+			newJob.UID = &uid
+		case fieldUploadGID:
+			v, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid gid")
+			}
+			gid := uint32(v)
+			newJob.GID = &gid
+		case fieldUploadMode:
+			v, err := strconv.ParseUint(value, 8, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid mode")
+			}
+			mode := uint32(v)
+			newJob.Mode = &mode
+		case fieldFilePushTargets:
+			if err := json.Unmarshal(data, &newJob.Targets); err != nil {
+				return nil, errors.Wrap(err, "invalid targets")
+			}
+		case fieldFilePushExpireAfter:
+			v, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid expire_after")
+			}
+			newJob.ExpireAfter = uint(v)
+		case fieldUploadFile:
+			newJob.Filename = part.FileName()
+			newJob.Content = data
+		}
+	}
+	return newJob, nil
+}
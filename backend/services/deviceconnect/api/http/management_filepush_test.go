@@ -0,0 +1,357 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/app"
+	app_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+func TestManagementCreateFilePushJob(t *testing.T) {
+	userIdentity := &identity.Identity{
+		Subject: "00000000-0000-0000-0000-000000000000",
+		Tenant:  "000000000000000000000000",
+		IsUser:  true,
+	}
+	testCases := []struct {
+		Name     string
+		Identity *identity.Identity
+		Fields   map[string]string
+		File     []byte
+
+		NewJob    *model.NewFilePushJob
+		Job       *model.FilePushJob
+		Results   []model.FilePushResult
+		CreateErr error
+
+		HTTPStatus int
+	}{
+		{
+			Name:     "ok",
+			Identity: userIdentity,
+			Fields: map[string]string{
+				fieldUploadPath:          "/etc/ssl/certs/ca.pem",
+				fieldUploadUID:           "0",
+				fieldUploadGID:           "0",
+				fieldUploadMode:          "0644",
+				fieldFilePushTargets:     `{"group":"production"}`,
+				fieldFilePushExpireAfter: "3600",
+			},
+			File: []byte("certificate"),
+
+			NewJob: &model.NewFilePushJob{
+				Path:        string2pointer("/etc/ssl/certs/ca.pem"),
+				UID:         uint322pointer(0),
+				GID:         uint322pointer(0),
+				Mode:        uint322pointer(0644),
+				Targets:     model.Targets{Group: "production"},
+				ExpireAfter: 3600,
+				Filename:    "ca.pem",
+				Content:     []byte("certificate"),
+			},
+			Job: &model.FilePushJob{
+				ID:       "job-1",
+				Path:     "/etc/ssl/certs/ca.pem",
+				Filename: "ca.pem",
+				Size:     11,
+			},
+			Results: []model.FilePushResult{
+				{ID: "r1", DeviceID: "1"},
+			},
+
+			HTTPStatus: http.StatusCreated,
+		},
+		{
+			Name: "ko, missing auth",
+
+			HTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			Name:     "ko, malformed targets",
+			Identity: userIdentity,
+			Fields: map[string]string{
+				fieldUploadPath:      "/etc/ssl/certs/ca.pem",
+				fieldFilePushTargets: `{"group":`,
+			},
+			File: []byte("certificate"),
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, malformed mode",
+			Identity: userIdentity,
+			Fields: map[string]string{
+				fieldUploadPath: "/etc/ssl/certs/ca.pem",
+				fieldUploadMode: "rw-r--r--",
+			},
+			File: []byte("certificate"),
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, file too large",
+			Identity: userIdentity,
+			Fields: map[string]string{
+				fieldUploadPath:      "/etc/ssl/certs/ca.pem",
+				fieldFilePushTargets: `{"group":"production"}`,
+			},
+			File: make([]byte, model.FilePushMaxFileSize+1),
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, validation error",
+			Identity: userIdentity,
+			Fields: map[string]string{
+				fieldUploadPath: "relative/path",
+			},
+			File: []byte("certificate"),
+
+			NewJob: &model.NewFilePushJob{
+				Path:     string2pointer("relative/path"),
+				Filename: "ca.pem",
+				Content:  []byte("certificate"),
+			},
+			CreateErr: errors.Wrap(validation.Errors{
+				"path": errors.New("must be absolute"),
+			}, "app: invalid file push job"),
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, too many devices",
+			Identity: userIdentity,
+			Fields: map[string]string{
+				fieldUploadPath:      "/etc/ssl/certs/ca.pem",
+				fieldFilePushTargets: `{"group":"production"}`,
+			},
+			File: []byte("certificate"),
+
+			NewJob: &model.NewFilePushJob{
+				Path:     string2pointer("/etc/ssl/certs/ca.pem"),
+				Targets:  model.Targets{Group: "production"},
+				Filename: "ca.pem",
+				Content:  []byte("certificate"),
+			},
+			CreateErr: app.ErrTooManyDevices,
+
+			HTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:     "ko, internal error",
+			Identity: userIdentity,
+			Fields: map[string]string{
+				fieldUploadPath:      "/etc/ssl/certs/ca.pem",
+				fieldFilePushTargets: `{"group":"production"}`,
+			},
+			File: []byte("certificate"),
+
+			NewJob: &model.NewFilePushJob{
+				Path:     string2pointer("/etc/ssl/certs/ca.pem"),
+				Targets:  model.Targets{Group: "production"},
+				Filename: "ca.pem",
+				Content:  []byte("certificate"),
+			},
+			CreateErr: errors.New("internal error"),
+
+			HTTPStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deviceConnectApp := &app_mocks.App{}
+			defer deviceConnectApp.AssertExpectations(t)
+
+			if tc.NewJob != nil {
+				deviceConnectApp.On("CreateFilePushJob",
+					mock.MatchedBy(func(_ context.Context) bool {
+						return true
+					}),
+					tc.Identity.Subject,
+					*tc.NewJob,
+				).Return(tc.Job, tc.Results, tc.CreateErr)
+			}
+			devicesLookedUp := make(chan struct{}, len(tc.Results))
+			for _, result := range tc.Results {
+				// the device is offline: the file is pushed on reconnect
+				deviceConnectApp.On("GetDevice",
+					mock.MatchedBy(func(_ context.Context) bool {
+						return true
+					}),
+					tc.Identity.Tenant,
+					result.DeviceID,
+				).Return(&model.Device{
+					ID:     result.DeviceID,
+					Status: model.DeviceStatusDisconnected,
+				}, nil).Run(func(mock.Arguments) {
+					devicesLookedUp <- struct{}{}
+				})
+			}
+
+			router, _ := NewRouter(deviceConnectApp, nil, nil)
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			for key, value := range tc.Fields {
+				_ = writer.WriteField(key, value)
+			}
+			if tc.File != nil {
+				part, _ := writer.CreateFormFile(fieldUploadFile, "ca.pem")
+				_, _ = part.Write(tc.File)
+			}
+			writer.Close()
+
+			req, _ := http.NewRequest(http.MethodPost,
+				"http://localhost"+APIURLManagementFilePushes,
+				body,
+			)
+			req.Header.Set(hdrContentType, writer.FormDataContentType())
+			if tc.Identity != nil {
+				req.Header.Set(headerAuthorization, "Bearer "+GenerateJWT(*tc.Identity))
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.HTTPStatus, w.Code)
+			if tc.HTTPStatus == http.StatusCreated {
+				var job model.FilePushJob
+				_ = json.Unmarshal(w.Body.Bytes(), &job)
+				assert.Equal(t, *tc.Job, job)
+				assert.Equal(t, "file-pushes/"+tc.Job.ID, w.Header().Get("Location"))
+			}
+			for range tc.Results {
+				select {
+				case <-devicesLookedUp:
+				case <-time.After(time.Second):
+					t.Fatal("timeout waiting for the device lookup")
+				}
+			}
+		})
+	}
+}
+
+func TestManagementGetFilePushResults(t *testing.T) {
+	userIdentity := &identity.Identity{
+		Subject: "00000000-0000-0000-0000-000000000000",
+		Tenant:  "000000000000000000000000",
+		IsUser:  true,
+	}
+	testCases := []struct {
+		Name     string
+		Identity *identity.Identity
+		Query    string
+
+		Filter  model.FilePushResultsFilter
+		Results []model.FilePushResult
+		Count   int64
+		AppErr  error
+
+		HTTPStatus int
+	}{
+		{
+			Name:     "ok",
+			Identity: userIdentity,
+			Query:    "?status=failure&page=2&per_page=10",
+
+			Filter: model.FilePushResultsFilter{
+				JobID:  "job-1",
+				Status: model.FilePushStatusFailure,
+				Skip:   10,
+				Limit:  10,
+			},
+			Results: []model.FilePushResult{{
+				ID:       "r1",
+				JobID:    "job-1",
+				DeviceID: "1",
+				Status:   model.FilePushStatusFailure,
+				Attempts: 1,
+				Error:    "permission denied",
+			}},
+			Count: 11,
+
+			HTTPStatus: http.StatusOK,
+		},
+		{
+			Name: "ko, missing auth",
+
+			HTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			Name:     "ko, not found",
+			Identity: userIdentity,
+
+			Filter: model.FilePushResultsFilter{
+				JobID: "job-1",
+				Limit: 20,
+			},
+			AppErr: app.ErrFilePushJobNotFound,
+
+			HTTPStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			deviceConnectApp := &app_mocks.App{}
+			defer deviceConnectApp.AssertExpectations(t)
+
+			if tc.Identity != nil {
+				deviceConnectApp.On("GetFilePushResults",
+					mock.MatchedBy(func(_ context.Context) bool {
+						return true
+					}),
+					tc.Filter,
+				).Return(tc.Results, tc.Count, tc.AppErr)
+			}
+
+			router, _ := NewRouter(deviceConnectApp, nil, nil)
+
+			url := strings.Replace(APIURLManagementFilePushResults, ":jobId", "job-1", 1)
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost"+url+tc.Query, nil)
+			if tc.Identity != nil {
+				req.Header.Set(headerAuthorization, "Bearer "+GenerateJWT(*tc.Identity))
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.HTTPStatus, w.Code)
+			if tc.HTTPStatus == http.StatusOK {
+				var results []model.FilePushResult
+				_ = json.Unmarshal(w.Body.Bytes(), &results)
+				assert.Equal(t, tc.Results, results)
+				assert.Equal(t, "11", w.Header().Get(hdrTotalCount))
+			}
+		})
+	}
+}
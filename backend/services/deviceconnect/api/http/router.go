@@ -54,6 +54,12 @@ const (
 	APIURLManagementDeviceSendInventory = APIURLManagement + "/devices/:deviceId/send-inventory"
 	APIURLManagementDeviceUpload        = APIURLManagement + "/devices/:deviceId/upload"
 	APIURLManagementPlayback            = APIURLManagement + "/sessions/:sessionId/playback"
+	APIURLManagementCommands            = APIURLManagement + "/commands"
+	APIURLManagementCommand             = APIURLManagement + "/commands/:jobId"
+	APIURLManagementCommandResults      = APIURLManagement + "/commands/:jobId/results"
+	APIURLManagementFilePushes          = APIURLManagement + "/file-pushes"
+	APIURLManagementFilePush            = APIURLManagement + "/file-pushes/:jobId"
+	APIURLManagementFilePushResults     = APIURLManagement + "/file-pushes/:jobId/results"
 
 	HdrKeyOrigin = "Origin"
 )
@@ -62,6 +68,7 @@ type RouterConfig struct {
 	GracefulShutdownTimeout time.Duration
 	MaxRequestSize          int64
 	MaxFileSize             int64
+	FilePushConcurrency     int
 }
 
 // NewRouter returns the gin router
@@ -98,12 +105,20 @@ func NewRouter(
 	router.POST(APIURLInternalDevicesIDCheckUpdate, internal.CheckUpdate)
 	router.POST(APIURLInternalDevicesIDSendInventory, internal.SendInventory)
 
+	filePushConcurrency := 0
+	if config != nil {
+		filePushConcurrency = config.FilePushConcurrency
+	}
+	filePusher := newFilePusher(app, natsClient, filePushConcurrency)
+
 	device := NewDeviceController(app, natsClient)
+	device.filePusher = filePusher
 	publicAPI.GET(APIURLDevicesConnect, device.Connect)
 	publicAPI.POST(APIURLInternalDevices, device.Provision)
 	publicAPI.DELETE(APIURLInternalDevicesID, device.Delete)
 
 	management := NewManagementController(app, natsClient)
+	management.filePusher = filePusher
 	publicAPI.GET(APIURLManagementDevice, management.GetDevice)
 	publicAPI.GET(APIURLManagementDeviceConnect, management.Connect)
 	publicAPI.GET(APIURLManagementDeviceDownload, management.DownloadFile)
@@ -112,6 +127,14 @@ func NewRouter(
 	publicAPI.POST(APIURLManagementDeviceSendInventory, management.SendInventory)
 	fileLimit.PUT(APIURLManagementDeviceUpload, management.UploadFile)
 	publicAPI.GET(APIURLManagementPlayback, management.Playback)
+	publicAPI.POST(APIURLManagementCommands, management.CreateCommandJob)
+	publicAPI.GET(APIURLManagementCommands, management.GetCommandJobs)
+	publicAPI.GET(APIURLManagementCommand, management.GetCommandJob)
+	publicAPI.GET(APIURLManagementCommandResults, management.GetCommandResults)
+	fileLimit.POST(APIURLManagementFilePushes, management.CreateFilePushJob)
+	publicAPI.GET(APIURLManagementFilePushes, management.GetFilePushJobs)
+	publicAPI.GET(APIURLManagementFilePush, management.GetFilePushJob)
+	publicAPI.GET(APIURLManagementFilePushResults, management.GetFilePushResults)
 
 	return router, nil
 }
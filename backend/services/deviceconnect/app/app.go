@@ -25,7 +25,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/ws"
 
+	"github.com/mendersoftware/mender-server/services/deviceconnect/client/inventory"
 	"github.com/mendersoftware/mender-server/services/deviceconnect/client/workflows"
 	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
 	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
@@ -42,6 +44,7 @@ var (
 //nolint:lll
 //go:generate ../../../utils/mockgen.sh
 type App interface {
+	WithInventory(client inventory.Client) App
 	HealthCheck(ctx context.Context) error
 	ProvisionDevice(ctx context.Context, tenantID string, device *model.Device) error
 	GetDevice(ctx context.Context, tenantID, deviceID string) (*model.Device, error)
@@ -58,6 +61,20 @@ type App interface {
 	DownloadFile(ctx context.Context, userID string, deviceID string, path string) error
 	UploadFile(ctx context.Context, userID string, deviceID string, path string) error
 	DeleteTenant(ctx context.Context, tenantID string) error
+	CreateCommandJob(ctx context.Context, userID string, job model.NewCommandJob) (*model.CommandJob, []model.CommandResult, error)
+	GetCommandJobs(ctx context.Context, skip, limit int64) ([]model.CommandJob, int64, error)
+	GetCommandJob(ctx context.Context, jobID string) (*model.CommandJob, error)
+	GetCommandResults(ctx context.Context, filter model.CommandResultsFilter) ([]model.CommandResult, int64, error)
+	GetPendingCommands(ctx context.Context, deviceID string) ([]model.CommandResult, error)
+	SetCommandsDispatched(ctx context.Context, resultIDs []string) error
+	HandleCommandMessage(ctx context.Context, deviceID string, msg *ws.ProtoMsg) error
+	CreateFilePushJob(ctx context.Context, userID string, job model.NewFilePushJob) (*model.FilePushJob, []model.FilePushResult, error)
+	GetFilePushJobs(ctx context.Context, skip, limit int64) ([]model.FilePushJob, int64, error)
+	GetFilePushJob(ctx context.Context, jobID string) (*model.FilePushJob, error)
+	GetFilePushResults(ctx context.Context, filter model.FilePushResultsFilter) ([]model.FilePushResult, int64, error)
+	GetPendingFilePushes(ctx context.Context, deviceID string) ([]model.FilePushResult, error)
+	StartFilePush(ctx context.Context, resultID string) (*model.FilePushJob, *model.FilePushResult, error)
+	FinishFilePush(ctx context.Context, result *model.FilePushResult, pushErr error, retry bool) error
 	Shutdown(timeout time.Duration)
 	ShutdownDone()
 	RegisterShutdownCancel(context.CancelFunc) uint32
@@ -68,6 +85,7 @@ type App interface {
 type app struct {
 	store            store.DataStore
 	workflows        workflows.Client
+	inventory        inventory.Client
 	shutdownCancels  map[uint32]context.CancelFunc
 	shutdownCancelsM *sync.Mutex
 	shutdownDone     chan struct{}
@@ -96,6 +114,12 @@ func New(ds store.DataStore, wf workflows.Client, config ...Config) App {
 	}
 }
 
+// WithInventory sets the inventory client
+func (a *app) WithInventory(client inventory.Client) App {
+	a.inventory = client
+	return a
+}
+
 // HealthCheck performs a health check and returns an error if it fails
 func (a *app) HealthCheck(ctx context.Context) error {
 	return a.store.Ping(ctx)
@@ -0,0 +1,267 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/ws"
+	"github.com/mendersoftware/mender-server/pkg/ws/command"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+)
+
+// Command job errors
+var (
+	ErrCommandJobNotFound    = errors.New("command job not found")
+	ErrNoDevicesFound        = errors.New("no devices match the given targets")
+	ErrTooManyDevices        = errors.New("the targets match too many devices")
+	ErrInventoryNotAvailable = errors.New("inventory client not configured")
+)
+
+const (
+	// commandTimeoutGracePeriod is added to the command timeout before a
+	// running command is considered timed out by the server
+	commandTimeoutGracePeriod = time.Minute
+
+	inventorySearchPerPage = 500
+)
+
+// CreateCommandJob resolves the targets and stores the command job with
+// a pending result for every targeted device.
+func (a *app) CreateCommandJob(
+	ctx context.Context,
+	userID string,
+	newJob model.NewCommandJob,
+) (*model.CommandJob, []model.CommandResult, error) {
+	if err := newJob.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "app: invalid command job")
+	}
+	deviceIDs, err := a.resolveTargets(ctx, newJob.Targets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if newJob.Timeout == 0 {
+		newJob.Timeout = model.CommandDefaultTimeout
+	}
+	if newJob.ExpireAfter == 0 {
+		newJob.ExpireAfter = model.CommandDefaultExpire
+	}
+	now := time.Now().UTC()
+	job := &model.CommandJob{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Command:   newJob.Command,
+		Targets:   newJob.Targets,
+		Timeout:   newJob.Timeout,
+		CreatedTs: now,
+		ExpireTs:  now.Add(time.Duration(newJob.ExpireAfter) * time.Second),
+	}
+	results := make([]model.CommandResult, len(deviceIDs))
+	for i, deviceID := range deviceIDs {
+		results[i] = model.CommandResult{
+			ID:        uuid.NewString(),
+			JobID:     job.ID,
+			DeviceID:  deviceID,
+			Status:    model.CommandStatusPending,
+			CreatedTs: now,
+			ExpireTs:  job.ExpireTs,
+			Command:   job.Command,
+			Timeout:   job.Timeout,
+		}
+	}
+	err = a.store.InsertCommandJob(ctx, job, results)
+	if err != nil {
+		return nil, nil, err
+	}
+	job.Stats = map[string]int{
+		model.CommandStatusPending: len(results),
+	}
+	return job, results, nil
+}
+
+func (a *app) resolveTargets(
+	ctx context.Context,
+	targets model.Targets,
+) ([]string, error) {
+	if len(targets.DeviceIDs) > 0 {
+		seen := make(map[string]struct{}, len(targets.DeviceIDs))
+		deviceIDs := make([]string, 0, len(targets.DeviceIDs))
+		for _, deviceID := range targets.DeviceIDs {
+			if _, ok := seen[deviceID]; !ok {
+				seen[deviceID] = struct{}{}
+				deviceIDs = append(deviceIDs, deviceID)
+			}
+		}
+		return deviceIDs, nil
+	}
+	if a.inventory == nil {
+		return nil, ErrInventoryNotAvailable
+	}
+	filters := targets.Filter
+	if targets.Group != "" {
+		filters = []model.FilterPredicate{{
+			Scope:     model.InventoryGroupScope,
+			Attribute: model.InventoryGroupAttributeName,
+			Type:      "$eq",
+			Value:     targets.Group,
+		}}
+	}
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	deviceIDs := []string{}
+	for page := 1; ; page++ {
+		devices, total, err := a.inventory.Search(ctx, tenantID, model.SearchParams{
+			Page:    page,
+			PerPage: inventorySearchPerPage,
+			Filters: filters,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "app: failed to resolve the target devices")
+		} else if total > model.CommandMaxDevices {
+			return nil, ErrTooManyDevices
+		}
+		for _, device := range devices {
+			deviceIDs = append(deviceIDs, device.ID)
+		}
+		if len(devices) < inventorySearchPerPage || len(deviceIDs) >= total {
+			break
+		}
+	}
+	if len(deviceIDs) == 0 {
+		return nil, ErrNoDevicesFound
+	}
+	return deviceIDs, nil
+}
+
+// GetCommandJobs returns the command jobs
+func (a *app) GetCommandJobs(
+	ctx context.Context,
+	skip, limit int64,
+) ([]model.CommandJob, int64, error) {
+	return a.store.GetCommandJobs(ctx, skip, limit)
+}
+
+// GetCommandJob returns the command job with the number of results by status
+func (a *app) GetCommandJob(ctx context.Context, jobID string) (*model.CommandJob, error) {
+	job, err := a.getCommandJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	job.Stats, err = a.store.GetCommandJobStats(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// getCommandJob fetches the job and updates the status of the results
+// which expired or timed out since the last lookup.
+func (a *app) getCommandJob(ctx context.Context, jobID string) (*model.CommandJob, error) {
+	job, err := a.store.GetCommandJob(ctx, jobID)
+	if err == store.ErrCommandJobNotFound {
+		return nil, ErrCommandJobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(job.Timeout)*time.Second + commandTimeoutGracePeriod
+	err = a.store.ExpireCommandResults(ctx, jobID, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetCommandResults returns the device results of a command job
+func (a *app) GetCommandResults(
+	ctx context.Context,
+	filter model.CommandResultsFilter,
+) ([]model.CommandResult, int64, error) {
+	_, err := a.getCommandJob(ctx, filter.JobID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return a.store.GetCommandResults(ctx, filter)
+}
+
+// GetPendingCommands returns the commands the device has not executed yet
+func (a *app) GetPendingCommands(
+	ctx context.Context,
+	deviceID string,
+) ([]model.CommandResult, error) {
+	return a.store.GetPendingCommandResults(ctx, deviceID)
+}
+
+// SetCommandsDispatched marks the results as sent to the device
+func (a *app) SetCommandsDispatched(ctx context.Context, resultIDs []string) error {
+	return a.store.SetCommandResultsDispatched(ctx, resultIDs)
+}
+
+// HandleCommandMessage records the output and the exit status sent by the
+// device for a command.
+func (a *app) HandleCommandMessage(
+	ctx context.Context,
+	deviceID string,
+	msg *ws.ProtoMsg,
+) error {
+	resultID := msg.Header.SessionID
+	if resultID == "" {
+		return errors.New("app: command message missing required session ID")
+	}
+	var err error
+	switch msg.Header.MsgType {
+	case command.MessageTypeStdout:
+		err = a.store.AppendCommandOutput(ctx, deviceID, resultID, string(msg.Body), "")
+	case command.MessageTypeStderr:
+		err = a.store.AppendCommandOutput(ctx, deviceID, resultID, "", string(msg.Body))
+	case command.MessageTypeExit:
+		var exit command.ExitStatus
+		if err := msgpack.Unmarshal(msg.Body, &exit); err != nil {
+			return errors.Wrap(err, "app: malformed command exit status")
+		}
+		status := model.CommandStatusSuccess
+		if exit.TimedOut {
+			status = model.CommandStatusTimeout
+		} else if exit.ExitCode != 0 {
+			status = model.CommandStatusFailure
+		}
+		err = a.store.FinishCommandResult(ctx, deviceID, resultID,
+			status, &exit.ExitCode, "")
+	case command.MessageTypeError:
+		var errMsg ws.Error
+		if err := msgpack.Unmarshal(msg.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "app: malformed command error")
+		}
+		err = a.store.FinishCommandResult(ctx, deviceID, resultID,
+			model.CommandStatusFailure, nil, errMsg.Error)
+	default:
+		return errors.Errorf("app: unexpected command message type %q",
+			msg.Header.MsgType)
+	}
+	if err == store.ErrCommandResultNotFound {
+		return ErrCommandJobNotFound
+	}
+	return err
+}
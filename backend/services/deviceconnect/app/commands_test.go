@@ -0,0 +1,345 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/ws"
+	"github.com/mendersoftware/mender-server/pkg/ws/command"
+
+	inv_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/client/inventory/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+	store_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/store/mocks"
+)
+
+func TestCreateCommandJob(t *testing.T) {
+	const tenantID = "tenant"
+	const userID = "user"
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant:  tenantID,
+		Subject: userID,
+		IsUser:  true,
+	})
+
+	testCases := []struct {
+		Name string
+
+		Job model.NewCommandJob
+
+		SearchDevices []model.InvDevice
+		SearchTotal   int
+		SearchErr     error
+
+		StoreErr error
+
+		DeviceIDs []string
+		Err       error
+	}{
+		{
+			Name: "ok, device IDs",
+
+			Job: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{DeviceIDs: []string{"1", "2", "1"}},
+			},
+
+			DeviceIDs: []string{"1", "2"},
+		},
+		{
+			Name: "ok, group",
+
+			Job: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{Group: "production"},
+				Timeout: 10,
+			},
+			SearchDevices: []model.InvDevice{{ID: "3"}, {ID: "4"}},
+			SearchTotal:   2,
+
+			DeviceIDs: []string{"3", "4"},
+		},
+		{
+			Name: "ko, invalid job",
+
+			Job: model.NewCommandJob{
+				Targets: model.Targets{DeviceIDs: []string{"1"}},
+			},
+
+			Err: validation.Errors{},
+		},
+		{
+			Name: "ko, no devices",
+
+			Job: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{Group: "empty"},
+			},
+			SearchDevices: []model.InvDevice{},
+
+			Err: ErrNoDevicesFound,
+		},
+		{
+			Name: "ko, too many devices",
+
+			Job: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{Group: "huge"},
+			},
+			SearchDevices: []model.InvDevice{{ID: "3"}},
+			SearchTotal:   model.CommandMaxDevices + 1,
+
+			Err: ErrTooManyDevices,
+		},
+		{
+			Name: "ko, inventory error",
+
+			Job: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{Group: "production"},
+			},
+			SearchErr: errors.New("inventory error"),
+
+			Err: errors.New("app: failed to resolve the target devices: inventory error"),
+		},
+		{
+			Name: "ko, store error",
+
+			Job: model.NewCommandJob{
+				Command: "df -h",
+				Targets: model.Targets{DeviceIDs: []string{"1"}},
+			},
+			StoreErr: errors.New("store error"),
+
+			Err: errors.New("store error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ds := &store_mocks.DataStore{}
+			defer ds.AssertExpectations(t)
+			inv := &inv_mocks.Client{}
+			defer inv.AssertExpectations(t)
+
+			if tc.SearchDevices != nil || tc.SearchErr != nil {
+				inv.On("Search", ctx, tenantID, model.SearchParams{
+					Page:    1,
+					PerPage: inventorySearchPerPage,
+					Filters: []model.FilterPredicate{{
+						Scope:     model.InventoryGroupScope,
+						Attribute: model.InventoryGroupAttributeName,
+						Type:      "$eq",
+						Value:     tc.Job.Targets.Group,
+					}},
+				}).Return(tc.SearchDevices, tc.SearchTotal, tc.SearchErr)
+			}
+			if tc.DeviceIDs != nil || tc.StoreErr != nil {
+				ds.On("InsertCommandJob", ctx,
+					mock.MatchedBy(func(job *model.CommandJob) bool {
+						return job.Command == tc.Job.Command &&
+							job.UserID == userID &&
+							job.Timeout > 0 &&
+							job.ExpireTs.After(job.CreatedTs)
+					}),
+					mock.AnythingOfType("[]model.CommandResult"),
+				).Return(tc.StoreErr)
+			}
+
+			app := New(ds, nil).WithInventory(inv)
+			job, results, err := app.CreateCommandJob(ctx, userID, tc.Job)
+			if tc.Err != nil {
+				if _, ok := tc.Err.(validation.Errors); ok {
+					assert.IsType(t, validation.Errors{}, pkgerrors.Cause(err))
+				} else {
+					assert.EqualError(t, err, tc.Err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Job.Command, job.Command)
+			deviceIDs := make([]string, len(results))
+			for i, result := range results {
+				deviceIDs[i] = result.DeviceID
+				assert.Equal(t, job.ID, result.JobID)
+				assert.Equal(t, model.CommandStatusPending, result.Status)
+				assert.Equal(t, job.ExpireTs, result.ExpireTs)
+			}
+			assert.Equal(t, tc.DeviceIDs, deviceIDs)
+		})
+	}
+}
+
+func TestGetCommandJob(t *testing.T) {
+	ctx := context.Background()
+	ds := &store_mocks.DataStore{}
+	defer ds.AssertExpectations(t)
+
+	ds.On("GetCommandJob", ctx, "missing").
+		Return(nil, store.ErrCommandJobNotFound).Once()
+	ds.On("GetCommandJob", ctx, "job-1").
+		Return(&model.CommandJob{ID: "job-1", Timeout: 30}, nil).Once()
+	ds.On("ExpireCommandResults", ctx, "job-1",
+		30*time.Second+commandTimeoutGracePeriod).
+		Return(nil).Once()
+	ds.On("GetCommandJobStats", ctx, "job-1").
+		Return(map[string]int{model.CommandStatusSuccess: 1}, nil).Once()
+
+	app := New(ds, nil)
+	_, err := app.GetCommandJob(ctx, "missing")
+	assert.Equal(t, ErrCommandJobNotFound, err)
+
+	job, err := app.GetCommandJob(ctx, "job-1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{model.CommandStatusSuccess: 1}, job.Stats)
+}
+
+func TestHandleCommandMessage(t *testing.T) {
+	const deviceID = "device"
+	ctx := context.Background()
+	exitBody := func(exit command.ExitStatus) []byte {
+		b, _ := msgpack.Marshal(exit)
+		return b
+	}
+	errorBody, _ := msgpack.Marshal(ws.Error{Error: "not supported"})
+	zero, one := 0, 1
+
+	testCases := []struct {
+		Name string
+		Msg  ws.ProtoMsg
+
+		StoreCall string
+		StoreArgs []interface{}
+		StoreErr  error
+
+		Err error
+	}{
+		{
+			Name: "ok, stdout",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeStdout, SessionID: "r1",
+			}, Body: []byte("out")},
+
+			StoreCall: "AppendCommandOutput",
+			StoreArgs: []interface{}{ctx, deviceID, "r1", "out", ""},
+		},
+		{
+			Name: "ok, stderr",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeStderr, SessionID: "r1",
+			}, Body: []byte("err")},
+
+			StoreCall: "AppendCommandOutput",
+			StoreArgs: []interface{}{ctx, deviceID, "r1", "", "err"},
+		},
+		{
+			Name: "ok, exit success",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeExit, SessionID: "r1",
+			}, Body: exitBody(command.ExitStatus{ExitCode: 0})},
+
+			StoreCall: "FinishCommandResult",
+			StoreArgs: []interface{}{ctx, deviceID, "r1",
+				model.CommandStatusSuccess, &zero, ""},
+		},
+		{
+			Name: "ok, exit failure",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeExit, SessionID: "r1",
+			}, Body: exitBody(command.ExitStatus{ExitCode: 1})},
+
+			StoreCall: "FinishCommandResult",
+			StoreArgs: []interface{}{ctx, deviceID, "r1",
+				model.CommandStatusFailure, &one, ""},
+		},
+		{
+			Name: "ok, exit timeout",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeExit, SessionID: "r1",
+			}, Body: exitBody(command.ExitStatus{ExitCode: 1, TimedOut: true})},
+
+			StoreCall: "FinishCommandResult",
+			StoreArgs: []interface{}{ctx, deviceID, "r1",
+				model.CommandStatusTimeout, &one, ""},
+		},
+		{
+			Name: "ok, error",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeError, SessionID: "r1",
+			}, Body: errorBody},
+
+			StoreCall: "FinishCommandResult",
+			StoreArgs: []interface{}{ctx, deviceID, "r1",
+				model.CommandStatusFailure, (*int)(nil), "not supported"},
+		},
+		{
+			Name: "ko, unknown result",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeStdout, SessionID: "r1",
+			}, Body: []byte("out")},
+
+			StoreCall: "AppendCommandOutput",
+			StoreArgs: []interface{}{ctx, deviceID, "r1", "out", ""},
+			StoreErr:  store.ErrCommandResultNotFound,
+
+			Err: ErrCommandJobNotFound,
+		},
+		{
+			Name: "ko, missing session ID",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: command.MessageTypeStdout,
+			}},
+
+			Err: errors.New("app: command message missing required session ID"),
+		},
+		{
+			Name: "ko, unknown message type",
+			Msg: ws.ProtoMsg{Header: ws.ProtoHdr{
+				MsgType: "foo", SessionID: "r1",
+			}},
+
+			Err: errors.New(`app: unexpected command message type "foo"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ds := &store_mocks.DataStore{}
+			defer ds.AssertExpectations(t)
+			if tc.StoreCall != "" {
+				ds.On(tc.StoreCall, tc.StoreArgs...).Return(tc.StoreErr)
+			}
+
+			app := New(ds, nil)
+			err := app.HandleCommandMessage(ctx, deviceID, &tc.Msg)
+			if tc.Err != nil {
+				assert.EqualError(t, err, tc.Err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
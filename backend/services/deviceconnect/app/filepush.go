@@ -0,0 +1,209 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+)
+
+// File push job errors
+var (
+	ErrFilePushJobNotFound = errors.New("file push job not found")
+	ErrFilePushNotPending  = errors.New("file push not pending")
+)
+
+// filePushStaleAfter is the time after which a running transfer which
+// did not finish is considered abandoned and rescheduled
+const filePushStaleAfter = 15 * time.Minute
+
+// CreateFilePushJob resolves the targets and stores the file push job
+// with a pending result for every targeted device.
+func (a *app) CreateFilePushJob(
+	ctx context.Context,
+	userID string,
+	newJob model.NewFilePushJob,
+) (*model.FilePushJob, []model.FilePushResult, error) {
+	if err := newJob.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "app: invalid file push job")
+	}
+	deviceIDs, err := a.resolveTargets(ctx, newJob.Targets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if newJob.ExpireAfter == 0 {
+		newJob.ExpireAfter = model.FilePushDefaultExpire
+	}
+	checksum := sha256.Sum256(newJob.Content)
+	now := time.Now().UTC()
+	job := &model.FilePushJob{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Path:      *newJob.Path,
+		UID:       newJob.UID,
+		GID:       newJob.GID,
+		Mode:      newJob.Mode,
+		Filename:  newJob.Filename,
+		Size:      int64(len(newJob.Content)),
+		Checksum:  hex.EncodeToString(checksum[:]),
+		Targets:   newJob.Targets,
+		CreatedTs: now,
+		ExpireTs:  now.Add(time.Duration(newJob.ExpireAfter) * time.Second),
+		Content:   newJob.Content,
+	}
+	results := make([]model.FilePushResult, len(deviceIDs))
+	for i, deviceID := range deviceIDs {
+		results[i] = model.FilePushResult{
+			ID:        uuid.NewString(),
+			JobID:     job.ID,
+			DeviceID:  deviceID,
+			Status:    model.FilePushStatusPending,
+			CreatedTs: now,
+			UpdatedTs: now,
+			ExpireTs:  job.ExpireTs,
+		}
+	}
+	err = a.store.InsertFilePushJob(ctx, job, results)
+	if err != nil {
+		return nil, nil, err
+	}
+	job.Stats = map[string]int{
+		model.FilePushStatusPending: len(results),
+	}
+	return job, results, nil
+}
+
+// GetFilePushJobs returns the file push jobs
+func (a *app) GetFilePushJobs(
+	ctx context.Context,
+	skip, limit int64,
+) ([]model.FilePushJob, int64, error) {
+	return a.store.GetFilePushJobs(ctx, skip, limit)
+}
+
+// GetFilePushJob returns the file push job with the number of results by
+// status
+func (a *app) GetFilePushJob(ctx context.Context, jobID string) (*model.FilePushJob, error) {
+	job, err := a.getFilePushJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.store.ExpireFilePushResults(ctx, jobID, filePushStaleAfter)
+	if err != nil {
+		return nil, err
+	}
+	job.Stats, err = a.store.GetFilePushJobStats(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (a *app) getFilePushJob(ctx context.Context, jobID string) (*model.FilePushJob, error) {
+	job, err := a.store.GetFilePushJob(ctx, jobID)
+	if err == store.ErrFilePushJobNotFound {
+		return nil, ErrFilePushJobNotFound
+	}
+	return job, err
+}
+
+// GetFilePushResults returns the device results of a file push job
+func (a *app) GetFilePushResults(
+	ctx context.Context,
+	filter model.FilePushResultsFilter,
+) ([]model.FilePushResult, int64, error) {
+	_, err := a.getFilePushJob(ctx, filter.JobID)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = a.store.ExpireFilePushResults(ctx, filter.JobID, filePushStaleAfter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return a.store.GetFilePushResults(ctx, filter)
+}
+
+// GetPendingFilePushes returns the files waiting to be pushed to the device
+func (a *app) GetPendingFilePushes(
+	ctx context.Context,
+	deviceID string,
+) ([]model.FilePushResult, error) {
+	return a.store.GetPendingFilePushResults(ctx, deviceID)
+}
+
+// StartFilePush claims the pending result for a new transfer attempt and
+// returns the job, including the file content, together with the result.
+// It returns ErrFilePushNotPending if the transfer was already started
+// elsewhere, or if the job expired.
+func (a *app) StartFilePush(
+	ctx context.Context,
+	resultID string,
+) (*model.FilePushJob, *model.FilePushResult, error) {
+	result, err := a.store.ClaimFilePushResult(ctx, resultID)
+	if err == store.ErrFilePushNotPending {
+		return nil, nil, ErrFilePushNotPending
+	} else if err != nil {
+		return nil, nil, err
+	}
+	job, err := a.getFilePushJob(ctx, result.JobID)
+	if err == nil {
+		err = a.UploadFile(ctx, job.UserID, result.DeviceID, job.Path)
+	}
+	if err != nil {
+		// release the result for the next attempt
+		_ = a.FinishFilePush(ctx, result, err, true)
+		return nil, nil, err
+	}
+	return job, result, nil
+}
+
+// FinishFilePush records the outcome of a transfer attempt. Failed
+// transfers which can be retried are rescheduled as long as the device
+// did not reach the maximum number of attempts; the status of result is
+// updated accordingly.
+func (a *app) FinishFilePush(
+	ctx context.Context,
+	result *model.FilePushResult,
+	pushErr error,
+	retry bool,
+) error {
+	status := model.FilePushStatusSuccess
+	var errMsg string
+	if pushErr != nil {
+		errMsg = pushErr.Error()
+		if retry && result.Attempts < model.FilePushMaxAttempts {
+			status = model.FilePushStatusPending
+		} else {
+			status = model.FilePushStatusFailure
+		}
+	}
+	err := a.store.FinishFilePushResult(ctx, result.ID, status, errMsg)
+	if err == store.ErrFilePushNotPending {
+		return ErrFilePushNotPending
+	} else if err != nil {
+		return err
+	}
+	result.Status = status
+	return nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/client/workflows"
+	wf_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+	store_mocks "github.com/mendersoftware/mender-server/services/deviceconnect/store/mocks"
+)
+
+func TestCreateFilePushJob(t *testing.T) {
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant:  "tenant",
+		Subject: "user",
+		IsUser:  true,
+	})
+	path := "/etc/ssl/certs/ca.pem"
+
+	ds := &store_mocks.DataStore{}
+	defer ds.AssertExpectations(t)
+	ds.On("InsertFilePushJob", ctx,
+		mock.MatchedBy(func(job *model.FilePushJob) bool {
+			return job.Path == path &&
+				job.UserID == "user" &&
+				job.Size == 4 &&
+				// sha256sum of "test"
+				job.Checksum == "9f86d081884c7d659a2feaa0c55ad015"+
+					"a3bf4f1b2b0b822cd15d6c15b0f00a08" &&
+				string(job.Content) == "test" &&
+				job.ExpireTs.After(job.CreatedTs)
+		}),
+		mock.MatchedBy(func(results []model.FilePushResult) bool {
+			return len(results) == 2 &&
+				results[0].DeviceID == "1" &&
+				results[0].Status == model.FilePushStatusPending &&
+				results[1].DeviceID == "2"
+		}),
+	).Return(nil).Once()
+
+	app := New(ds, nil)
+	job, results, err := app.CreateFilePushJob(ctx, "user", model.NewFilePushJob{
+		Path:     &path,
+		Targets:  model.Targets{DeviceIDs: []string{"1", "2", "2"}},
+		Filename: "ca.pem",
+		Content:  []byte("test"),
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, results, 2)
+		assert.Equal(t, map[string]int{model.FilePushStatusPending: 2}, job.Stats)
+	}
+
+	_, _, err = app.CreateFilePushJob(ctx, "user", model.NewFilePushJob{
+		Targets:  model.Targets{DeviceIDs: []string{"1"}},
+		Filename: "ca.pem",
+	})
+	assert.EqualError(t, err, "app: invalid file push job: path: cannot be blank.")
+}
+
+func TestStartFilePush(t *testing.T) {
+	ctx := context.Background()
+	result := &model.FilePushResult{
+		ID:       "r1",
+		JobID:    "job-1",
+		DeviceID: "device-1",
+		Attempts: 1,
+	}
+	job := &model.FilePushJob{
+		ID:     "job-1",
+		UserID: "user",
+		Path:   "/etc/ssl/certs/ca.pem",
+	}
+
+	testCases := []struct {
+		Name string
+
+		ClaimErr  error
+		JobErr    error
+		AuditErr  error
+		HaveAudit bool
+
+		Err error
+	}{
+		{
+			Name: "ok",
+		},
+		{
+			Name: "ok, with audit logs",
+
+			HaveAudit: true,
+		},
+		{
+			Name: "ko, not pending",
+
+			ClaimErr: store.ErrFilePushNotPending,
+
+			Err: ErrFilePushNotPending,
+		},
+		{
+			Name: "ko, job deleted",
+
+			JobErr: store.ErrFilePushJobNotFound,
+
+			Err: ErrFilePushJobNotFound,
+		},
+		{
+			Name: "ko, audit log error",
+
+			HaveAudit: true,
+			AuditErr:  errors.New("workflows down"),
+
+			Err: errors.New("failed to submit audit log for file transfer: workflows down"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ds := &store_mocks.DataStore{}
+			defer ds.AssertExpectations(t)
+			wf := &wf_mocks.Client{}
+			defer wf.AssertExpectations(t)
+
+			if tc.ClaimErr != nil {
+				ds.On("ClaimFilePushResult", ctx, result.ID).
+					Return(nil, tc.ClaimErr)
+			} else {
+				ds.On("ClaimFilePushResult", ctx, result.ID).
+					Return(result, nil)
+				ds.On("GetFilePushJob", ctx, result.JobID).
+					Return(job, tc.JobErr)
+			}
+			if tc.HaveAudit {
+				wf.On("SubmitAuditLog", ctx,
+					mock.MatchedBy(func(log workflows.AuditLog) bool {
+						return log.Action == workflows.ActionUploadFile &&
+							log.Object.ID == result.DeviceID
+					}),
+				).Return(tc.AuditErr)
+			}
+			if tc.JobErr != nil || tc.AuditErr != nil {
+				// the result is released for the next attempt
+				ds.On("FinishFilePushResult", ctx, result.ID,
+					model.FilePushStatusPending,
+					mock.AnythingOfType("string"),
+				).Return(nil)
+			}
+
+			app := New(ds, wf, Config{HaveAuditLogs: tc.HaveAudit})
+			j, r, err := app.StartFilePush(ctx, result.ID)
+			if tc.Err != nil {
+				assert.EqualError(t, err, tc.Err.Error())
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, job, j)
+				assert.Equal(t, result, r)
+			}
+		})
+	}
+}
+
+func TestFinishFilePush(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		Name string
+
+		Attempts int
+		PushErr  error
+		Retry    bool
+
+		Status   string
+		StoreErr error
+		Err      error
+	}{
+		{
+			Name: "ok, success",
+
+			Attempts: 1,
+			Status:   model.FilePushStatusSuccess,
+		},
+		{
+			Name: "ok, retry",
+
+			Attempts: 1,
+			PushErr:  errors.New("file transfer timed out"),
+			Retry:    true,
+			Status:   model.FilePushStatusPending,
+		},
+		{
+			Name: "ok, maximum attempts reached",
+
+			Attempts: model.FilePushMaxAttempts,
+			PushErr:  errors.New("file transfer timed out"),
+			Retry:    true,
+			Status:   model.FilePushStatusFailure,
+		},
+		{
+			Name: "ok, permanent failure",
+
+			Attempts: 1,
+			PushErr:  errors.New("permission denied"),
+			Status:   model.FilePushStatusFailure,
+		},
+		{
+			Name: "ko, rescheduled meanwhile",
+
+			Attempts: 1,
+			Status:   model.FilePushStatusSuccess,
+			StoreErr: store.ErrFilePushNotPending,
+			Err:      ErrFilePushNotPending,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ds := &store_mocks.DataStore{}
+			defer ds.AssertExpectations(t)
+
+			var errMsg string
+			if tc.PushErr != nil {
+				errMsg = tc.PushErr.Error()
+			}
+			ds.On("FinishFilePushResult", ctx, "r1", tc.Status, errMsg).
+				Return(tc.StoreErr)
+
+			app := New(ds, nil)
+			result := &model.FilePushResult{
+				ID:       "r1",
+				Status:   model.FilePushStatusRunning,
+				Attempts: tc.Attempts,
+			}
+			err := app.FinishFilePush(ctx, result, tc.PushErr, tc.Retry)
+			assert.Equal(t, tc.Err, err)
+			if tc.Err == nil {
+				assert.Equal(t, tc.Status, result.Status)
+			}
+		})
+	}
+}
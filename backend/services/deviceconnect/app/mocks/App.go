@@ -18,6 +18,11 @@ package mocks
 
 import (
 	context "context"
+
+	app "github.com/mendersoftware/mender-server/services/deviceconnect/app"
+
+	inventory "github.com/mendersoftware/mender-server/services/deviceconnect/client/inventory"
+
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
@@ -25,6 +30,8 @@ import (
 	model "github.com/mendersoftware/mender-server/services/deviceconnect/model"
 
 	time "time"
+
+	ws "github.com/mendersoftware/mender-server/pkg/ws"
 )
 
 // App is an autogenerated mock type for the App type
@@ -32,6 +39,84 @@ type App struct {
 	mock.Mock
 }
 
+// CreateCommandJob provides a mock function with given fields: ctx, userID, job
+func (_m *App) CreateCommandJob(ctx context.Context, userID string, job model.NewCommandJob) (*model.CommandJob, []model.CommandResult, error) {
+	ret := _m.Called(ctx, userID, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCommandJob")
+	}
+
+	var r0 *model.CommandJob
+	var r1 []model.CommandResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.NewCommandJob) (*model.CommandJob, []model.CommandResult, error)); ok {
+		return rf(ctx, userID, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.NewCommandJob) *model.CommandJob); ok {
+		r0 = rf(ctx, userID, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CommandJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.NewCommandJob) []model.CommandResult); ok {
+		r1 = rf(ctx, userID, job)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]model.CommandResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, model.NewCommandJob) error); ok {
+		r2 = rf(ctx, userID, job)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateFilePushJob provides a mock function with given fields: ctx, userID, job
+func (_m *App) CreateFilePushJob(ctx context.Context, userID string, job model.NewFilePushJob) (*model.FilePushJob, []model.FilePushResult, error) {
+	ret := _m.Called(ctx, userID, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFilePushJob")
+	}
+
+	var r0 *model.FilePushJob
+	var r1 []model.FilePushResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.NewFilePushJob) (*model.FilePushJob, []model.FilePushResult, error)); ok {
+		return rf(ctx, userID, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.NewFilePushJob) *model.FilePushJob); ok {
+		r0 = rf(ctx, userID, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FilePushJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.NewFilePushJob) []model.FilePushResult); ok {
+		r1 = rf(ctx, userID, job)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]model.FilePushResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, model.NewFilePushJob) error); ok {
+		r2 = rf(ctx, userID, job)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeleteDevice provides a mock function with given fields: ctx, tenantID, deviceID
 func (_m *App) DeleteDevice(ctx context.Context, tenantID string, deviceID string) error {
 	ret := _m.Called(ctx, tenantID, deviceID)
@@ -86,6 +171,24 @@ func (_m *App) DownloadFile(ctx context.Context, userID string, deviceID string,
 	return r0
 }
 
+// FinishFilePush provides a mock function with given fields: ctx, result, pushErr, retry
+func (_m *App) FinishFilePush(ctx context.Context, result *model.FilePushResult, pushErr error, retry bool) error {
+	ret := _m.Called(ctx, result, pushErr, retry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishFilePush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.FilePushResult, error, bool) error); ok {
+		r0 = rf(ctx, result, pushErr, retry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FreeUserSession provides a mock function with given fields: ctx, sessionID, sessionTypes
 func (_m *App) FreeUserSession(ctx context.Context, sessionID string, sessionTypes []string) error {
 	ret := _m.Called(ctx, sessionID, sessionTypes)
@@ -104,6 +207,110 @@ func (_m *App) FreeUserSession(ctx context.Context, sessionID string, sessionTyp
 	return r0
 }
 
+// GetCommandJob provides a mock function with given fields: ctx, jobID
+func (_m *App) GetCommandJob(ctx context.Context, jobID string) (*model.CommandJob, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommandJob")
+	}
+
+	var r0 *model.CommandJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.CommandJob, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.CommandJob); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CommandJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommandJobs provides a mock function with given fields: ctx, skip, limit
+func (_m *App) GetCommandJobs(ctx context.Context, skip int64, limit int64) ([]model.CommandJob, int64, error) {
+	ret := _m.Called(ctx, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommandJobs")
+	}
+
+	var r0 []model.CommandJob
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]model.CommandJob, int64, error)); ok {
+		return rf(ctx, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []model.CommandJob); ok {
+		r0 = rf(ctx, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CommandJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, skip, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, skip, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCommandResults provides a mock function with given fields: ctx, filter
+func (_m *App) GetCommandResults(ctx context.Context, filter model.CommandResultsFilter) ([]model.CommandResult, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommandResults")
+	}
+
+	var r0 []model.CommandResult
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.CommandResultsFilter) ([]model.CommandResult, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.CommandResultsFilter) []model.CommandResult); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CommandResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.CommandResultsFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.CommandResultsFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetControlRecorder provides a mock function with given fields: ctx, sessionID
 func (_m *App) GetControlRecorder(ctx context.Context, sessionID string) io.Writer {
 	ret := _m.Called(ctx, sessionID)
@@ -154,6 +361,170 @@ func (_m *App) GetDevice(ctx context.Context, tenantID string, deviceID string)
 	return r0, r1
 }
 
+// GetFilePushJob provides a mock function with given fields: ctx, jobID
+func (_m *App) GetFilePushJob(ctx context.Context, jobID string) (*model.FilePushJob, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePushJob")
+	}
+
+	var r0 *model.FilePushJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.FilePushJob, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.FilePushJob); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FilePushJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilePushJobs provides a mock function with given fields: ctx, skip, limit
+func (_m *App) GetFilePushJobs(ctx context.Context, skip int64, limit int64) ([]model.FilePushJob, int64, error) {
+	ret := _m.Called(ctx, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePushJobs")
+	}
+
+	var r0 []model.FilePushJob
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]model.FilePushJob, int64, error)); ok {
+		return rf(ctx, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []model.FilePushJob); ok {
+		r0 = rf(ctx, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FilePushJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, skip, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, skip, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetFilePushResults provides a mock function with given fields: ctx, filter
+func (_m *App) GetFilePushResults(ctx context.Context, filter model.FilePushResultsFilter) ([]model.FilePushResult, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePushResults")
+	}
+
+	var r0 []model.FilePushResult
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.FilePushResultsFilter) ([]model.FilePushResult, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.FilePushResultsFilter) []model.FilePushResult); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FilePushResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.FilePushResultsFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.FilePushResultsFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPendingCommands provides a mock function with given fields: ctx, deviceID
+func (_m *App) GetPendingCommands(ctx context.Context, deviceID string) ([]model.CommandResult, error) {
+	ret := _m.Called(ctx, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingCommands")
+	}
+
+	var r0 []model.CommandResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.CommandResult, error)); ok {
+		return rf(ctx, deviceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.CommandResult); ok {
+		r0 = rf(ctx, deviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CommandResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, deviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingFilePushes provides a mock function with given fields: ctx, deviceID
+func (_m *App) GetPendingFilePushes(ctx context.Context, deviceID string) ([]model.FilePushResult, error) {
+	ret := _m.Called(ctx, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingFilePushes")
+	}
+
+	var r0 []model.FilePushResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.FilePushResult, error)); ok {
+		return rf(ctx, deviceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.FilePushResult); ok {
+		r0 = rf(ctx, deviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FilePushResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, deviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecorder provides a mock function with given fields: ctx, sessionID
 func (_m *App) GetRecorder(ctx context.Context, sessionID string) io.Writer {
 	ret := _m.Called(ctx, sessionID)
@@ -192,6 +563,24 @@ func (_m *App) GetSessionRecording(ctx context.Context, id string, w io.Writer)
 	return r0
 }
 
+// HandleCommandMessage provides a mock function with given fields: ctx, deviceID, msg
+func (_m *App) HandleCommandMessage(ctx context.Context, deviceID string, msg *ws.ProtoMsg) error {
+	ret := _m.Called(ctx, deviceID, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleCommandMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *ws.ProtoMsg) error); ok {
+		r0 = rf(ctx, deviceID, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // HealthCheck provides a mock function with given fields: ctx
 func (_m *App) HealthCheck(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -300,6 +689,24 @@ func (_m *App) SaveSessionRecording(ctx context.Context, id string, sessionBytes
 	return r0
 }
 
+// SetCommandsDispatched provides a mock function with given fields: ctx, resultIDs
+func (_m *App) SetCommandsDispatched(ctx context.Context, resultIDs []string) error {
+	ret := _m.Called(ctx, resultIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCommandsDispatched")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, resultIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDeviceConnected provides a mock function with given fields: ctx, tenantID, deviceID
 func (_m *App) SetDeviceConnected(ctx context.Context, tenantID string, deviceID string) (int64, error) {
 	ret := _m.Called(ctx, tenantID, deviceID)
@@ -356,6 +763,45 @@ func (_m *App) ShutdownDone() {
 	_m.Called()
 }
 
+// StartFilePush provides a mock function with given fields: ctx, resultID
+func (_m *App) StartFilePush(ctx context.Context, resultID string) (*model.FilePushJob, *model.FilePushResult, error) {
+	ret := _m.Called(ctx, resultID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartFilePush")
+	}
+
+	var r0 *model.FilePushJob
+	var r1 *model.FilePushResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.FilePushJob, *model.FilePushResult, error)); ok {
+		return rf(ctx, resultID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.FilePushJob); ok {
+		r0 = rf(ctx, resultID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FilePushJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *model.FilePushResult); ok {
+		r1 = rf(ctx, resultID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.FilePushResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, resultID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UnregisterShutdownCancel provides a mock function with given fields: _a0
 func (_m *App) UnregisterShutdownCancel(_a0 uint32) {
 	_m.Called(_a0)
@@ -379,6 +825,26 @@ func (_m *App) UploadFile(ctx context.Context, userID string, deviceID string, p
 	return r0
 }
 
+// WithInventory provides a mock function with given fields: client
+func (_m *App) WithInventory(client inventory.Client) app.App {
+	ret := _m.Called(client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithInventory")
+	}
+
+	var r0 app.App
+	if rf, ok := ret.Get(0).(func(inventory.Client) app.App); ok {
+		r0 = rf(client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(app.App)
+		}
+	}
+
+	return r0
+}
+
 // NewApp creates a new instance of App. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApp(t interface {
@@ -0,0 +1,126 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+const (
+	URISearch = "/api/internal/v2/inventory/tenants/:tenantId/filters/search"
+
+	hdrTotalCount = "X-Total-Count"
+)
+
+const (
+	defaultTimeout = time.Duration(10) * time.Second
+)
+
+// Client is the inventory client
+//
+//go:generate ../../../../utils/mockgen.sh
+type Client interface {
+	Search(
+		ctx context.Context,
+		tenantID string,
+		searchParams model.SearchParams,
+	) ([]model.InvDevice, int, error)
+}
+
+type ClientOptions struct {
+	Client *http.Client
+}
+
+// NewClient returns a new inventory client
+func NewClient(url string, opts ...ClientOptions) Client {
+	// Initialize default options
+	var clientOpts = ClientOptions{
+		Client: &http.Client{},
+	}
+	// Merge options
+	for _, opt := range opts {
+		if opt.Client != nil {
+			clientOpts.Client = opt.Client
+		}
+	}
+
+	return &client{
+		url:    strings.TrimSuffix(url, "/"),
+		client: *clientOpts.Client,
+	}
+}
+
+type client struct {
+	url    string
+	client http.Client
+}
+
+func (c *client) Search(
+	ctx context.Context,
+	tenantID string,
+	searchParams model.SearchParams,
+) ([]model.InvDevice, int, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	payload, _ := json.Marshal(searchParams)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		c.url+strings.Replace(URISearch, ":tenantId", tenantID, 1),
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "inventory: error preparing HTTP request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "inventory: failed to search devices")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, -1, errors.Errorf(
+			"inventory: unexpected HTTP status from inventory service: %s",
+			rsp.Status,
+		)
+	}
+
+	devs := []model.InvDevice{}
+	if err := json.NewDecoder(rsp.Body).Decode(&devs); err != nil {
+		return nil, -1, errors.Wrap(err, "inventory: error parsing search response")
+	}
+
+	totalCount, err := strconv.Atoi(rsp.Header.Get(hdrTotalCount))
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "inventory: error parsing "+hdrTotalCount+" header")
+	}
+
+	return devs, totalCount, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		Status     int
+		Body       string
+		TotalCount string
+
+		Devices []model.InvDevice
+		Total   int
+		Err     string
+	}{
+		{
+			Name: "ok",
+
+			Status:     http.StatusOK,
+			Body:       `[{"id":"1"},{"id":"2"}]`,
+			TotalCount: "10",
+
+			Devices: []model.InvDevice{{ID: "1"}, {ID: "2"}},
+			Total:   10,
+		},
+		{
+			Name: "ko, unexpected status",
+
+			Status: http.StatusInternalServerError,
+
+			Err: "inventory: unexpected HTTP status from inventory service: " +
+				"500 Internal Server Error",
+		},
+		{
+			Name: "ko, malformed body",
+
+			Status: http.StatusOK,
+			Body:   `{`,
+
+			Err: "inventory: error parsing search response: unexpected EOF",
+		},
+		{
+			Name: "ko, missing total count",
+
+			Status: http.StatusOK,
+			Body:   `[]`,
+
+			Err: `inventory: error parsing X-Total-Count header: ` +
+				`strconv.Atoi: parsing "": invalid syntax`,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			params := model.SearchParams{Page: 1, PerPage: 20}
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPost, r.Method)
+					assert.Equal(t,
+						"/api/internal/v2/inventory/tenants/tenant/filters/search",
+						r.URL.Path,
+					)
+					var body model.SearchParams
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					assert.Equal(t, params, body)
+
+					if tc.TotalCount != "" {
+						w.Header().Set(hdrTotalCount, tc.TotalCount)
+					}
+					w.WriteHeader(tc.Status)
+					_, _ = w.Write([]byte(tc.Body))
+				},
+			))
+			defer srv.Close()
+
+			client := NewClient(srv.URL)
+			devs, total, err := client.Search(context.Background(), "tenant", params)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Devices, devs)
+			assert.Equal(t, tc.Total, total)
+		})
+	}
+}
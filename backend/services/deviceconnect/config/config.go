@@ -65,6 +65,11 @@ const (
 	// SettingWorkflowsURLDefault sets the default workflows URL.
 	SettingWorkflowsURLDefault = "http://mender-workflows-server:8080"
 
+	// SettingInventoryURL sets the base URL for the inventory service.
+	SettingInventoryURL = "inventory_url"
+	// SettingInventoryURLDefault sets the default inventory URL.
+	SettingInventoryURLDefault = "http://mender-inventory:8080"
+
 	// SettingEnableAuditLogs enables/disables audit logging.
 	SettingEnableAuditLogs = "enable_audit"
 	// SettingEnableAuditLogsDefault is disabled by default.
@@ -92,6 +97,11 @@ const (
 	// Max Upload size
 	SettingMaxFileUploadSize        = "file_upload_limit"
 	SettingMaxFileUploadSizeDefault = 1024 * 1024 * 1024 // 1 GiB
+
+	// SettingFilePushConcurrency is the maximum number of concurrent
+	// transfers run by the file push jobs
+	SettingFilePushConcurrency        = "file_push_concurrency"
+	SettingFilePushConcurrencyDefault = 10
 )
 
 var (
@@ -105,11 +115,13 @@ var (
 		{Key: SettingDbSSLSkipVerify, Value: SettingDbSSLSkipVerifyDefault},
 		{Key: SettingDebugLog, Value: SettingDebugLogDefault},
 		{Key: SettingWorkflowsURL, Value: SettingWorkflowsURLDefault},
+		{Key: SettingInventoryURL, Value: SettingInventoryURLDefault},
 		{Key: SettingEnableAuditLogs, Value: SettingEnableAuditLogsDefault},
 		{Key: SettingRecordingExpireSec, Value: SettingRecordingExpireDefault},
 		{Key: SettingWSAllowedOrigins, Value: SettingWSAllowedOriginsDefault},
 		{Key: SettingGracefulShutdownTimeout, Value: SettingGracefulShutdownTimeoutDefault},
 		{Key: SettingMaxRequestSize, Value: SettingMaxRequestSizeDefault},
 		{Key: SettingMaxFileUploadSize, Value: SettingMaxFileUploadSizeDefault},
+		{Key: SettingFilePushConcurrency, Value: SettingFilePushConcurrencyDefault},
 	}
 )
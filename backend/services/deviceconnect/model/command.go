@@ -0,0 +1,159 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Values for the command result status attribute
+const (
+	CommandStatusPending    = "pending"
+	CommandStatusDispatched = "dispatched"
+	CommandStatusRunning    = "running"
+	CommandStatusSuccess    = "success"
+	CommandStatusFailure    = "failure"
+	CommandStatusTimeout    = "timeout"
+	CommandStatusExpired    = "expired"
+)
+
+const (
+	// CommandMaxLength is the maximum length of the command line
+	CommandMaxLength = 4096
+	// CommandMaxDevices is the maximum number of devices a single command
+	// job can target
+	CommandMaxDevices = 10000
+	// CommandMaxOutputSize is the maximum number of characters stored for
+	// stdout and stderr respectively; the rest of the output is discarded
+	CommandMaxOutputSize = 64 * 1024
+
+	// CommandDefaultTimeout is the default execution timeout in seconds
+	CommandDefaultTimeout = 60
+	// CommandMaxTimeout is the maximum execution timeout in seconds
+	CommandMaxTimeout = 60 * 60
+	// CommandDefaultExpire is the default number of seconds a command job
+	// waits for offline devices to connect
+	CommandDefaultExpire = 24 * 60 * 60
+	// CommandMaxExpire is the maximum number of seconds a command job
+	// waits for offline devices to connect
+	CommandMaxExpire = 7 * 24 * 60 * 60
+)
+
+// Targets selects the devices a job applies to. Exactly one of the
+// selectors must be set.
+type Targets struct {
+	DeviceIDs []string          `json:"device_ids,omitempty" bson:"device_ids,omitempty"`
+	Group     string            `json:"group,omitempty" bson:"group,omitempty"`
+	Filter    []FilterPredicate `json:"filter,omitempty" bson:"filter,omitempty"`
+}
+
+func (t Targets) Validate() error {
+	set := 0
+	if len(t.DeviceIDs) > 0 {
+		set++
+	}
+	if t.Group != "" {
+		set++
+	}
+	if len(t.Filter) > 0 {
+		set++
+	}
+	if set != 1 {
+		return validation.NewError("targets_required",
+			"exactly one of device_ids, group or filter must be set")
+	}
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.DeviceIDs, validation.Length(0, CommandMaxDevices),
+			validation.Each(validation.Required)),
+	)
+}
+
+// NewCommandJob is the request to run a command on a set of devices
+type NewCommandJob struct {
+	Command string  `json:"command"`
+	Targets Targets `json:"targets"`
+	// Timeout is the execution timeout on the device in seconds
+	Timeout uint `json:"timeout,omitempty"`
+	// ExpireAfter is the number of seconds the job waits for offline
+	// devices to connect
+	ExpireAfter uint `json:"expire_after,omitempty"`
+}
+
+func (j NewCommandJob) Validate() error {
+	return validation.ValidateStruct(&j,
+		validation.Field(&j.Command, validation.Required,
+			validation.Length(1, CommandMaxLength)),
+		validation.Field(&j.Targets),
+		validation.Field(&j.Timeout, validation.Max(uint(CommandMaxTimeout))),
+		validation.Field(&j.ExpireAfter, validation.Max(uint(CommandMaxExpire))),
+	)
+}
+
+// CommandJob represents a command executed on a set of devices
+type CommandJob struct {
+	ID        string    `json:"id" bson:"_id"`
+	UserID    string    `json:"user_id" bson:"user_id"`
+	Command   string    `json:"command" bson:"command"`
+	Targets   Targets   `json:"targets" bson:"targets"`
+	Timeout   uint      `json:"timeout" bson:"timeout"`
+	CreatedTs time.Time `json:"created_ts" bson:"created_ts"`
+	ExpireTs  time.Time `json:"expire_ts" bson:"expire_ts"`
+
+	// Stats is computed on request and not stored in the database
+	Stats map[string]int `json:"stats,omitempty" bson:"-"`
+}
+
+// CommandResult holds the outcome of a command job on a single device.
+// The ID is used as the session ID of the messages exchanged with the
+// device.
+type CommandResult struct {
+	ID         string     `json:"id" bson:"_id"`
+	JobID      string     `json:"job_id" bson:"job_id"`
+	DeviceID   string     `json:"device_id" bson:"device_id"`
+	Status     string     `json:"status" bson:"status"`
+	Stdout     string     `json:"stdout" bson:"stdout"`
+	Stderr     string     `json:"stderr" bson:"stderr"`
+	ExitCode   *int       `json:"exit_code,omitempty" bson:"exit_code,omitempty"`
+	Error      string     `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedTs  time.Time  `json:"created_ts" bson:"created_ts"`
+	StartedTs  *time.Time `json:"started_ts,omitempty" bson:"started_ts,omitempty"`
+	FinishedTs *time.Time `json:"finished_ts,omitempty" bson:"finished_ts,omitempty"`
+	ExpireTs   time.Time  `json:"-" bson:"expire_ts"`
+
+	// Command and Timeout are copied from the job for dispatching the
+	// command to the device without looking up the job.
+	Command string `json:"-" bson:"command"`
+	Timeout uint   `json:"-" bson:"timeout"`
+}
+
+// IsFinished returns true if the result reached a final status
+func (r CommandResult) IsFinished() bool {
+	switch r.Status {
+	case CommandStatusSuccess, CommandStatusFailure,
+		CommandStatusTimeout, CommandStatusExpired:
+		return true
+	}
+	return false
+}
+
+// CommandResultsFilter filters the results of a command job
+type CommandResultsFilter struct {
+	JobID  string
+	Status string
+	Skip   int64
+	Limit  int64
+}
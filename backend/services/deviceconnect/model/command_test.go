@@ -0,0 +1,118 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommandJobValidation(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Request NewCommandJob
+		Error   error
+	}{
+		{
+			Name: "validation ok, device IDs",
+			Request: NewCommandJob{
+				Command: "uptime",
+				Targets: Targets{DeviceIDs: []string{"1", "2"}},
+			},
+		},
+		{
+			Name: "validation ok, filter",
+			Request: NewCommandJob{
+				Command: "uptime",
+				Targets: Targets{Filter: []FilterPredicate{{
+					Scope:     "inventory",
+					Attribute: "device_type",
+					Type:      "$eq",
+					Value:     "raspberrypi4",
+				}}},
+				Timeout:     CommandMaxTimeout,
+				ExpireAfter: CommandMaxExpire,
+			},
+		},
+		{
+			Name: "validation failed, missing command",
+			Request: NewCommandJob{
+				Targets: Targets{Group: "production"},
+			},
+			Error: errors.New("command: cannot be blank."),
+		},
+		{
+			Name: "validation failed, command too long",
+			Request: NewCommandJob{
+				Command: strings.Repeat("a", CommandMaxLength+1),
+				Targets: Targets{Group: "production"},
+			},
+			Error: errors.New("command: the length must be between 1 and 4096."),
+		},
+		{
+			Name: "validation failed, missing targets",
+			Request: NewCommandJob{
+				Command: "uptime",
+			},
+			Error: errors.New("targets: exactly one of device_ids, group or " +
+				"filter must be set."),
+		},
+		{
+			Name: "validation failed, multiple targets",
+			Request: NewCommandJob{
+				Command: "uptime",
+				Targets: Targets{
+					DeviceIDs: []string{"1"},
+					Group:     "production",
+				},
+			},
+			Error: errors.New("targets: exactly one of device_ids, group or " +
+				"filter must be set."),
+		},
+		{
+			Name: "validation failed, timeout too long",
+			Request: NewCommandJob{
+				Command: "uptime",
+				Targets: Targets{Group: "production"},
+				Timeout: CommandMaxTimeout + 1,
+			},
+			Error: errors.New("timeout: must be no greater than 3600."),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Request.Validate()
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommandResultIsFinished(t *testing.T) {
+	assert.False(t, CommandResult{Status: CommandStatusPending}.IsFinished())
+	assert.False(t, CommandResult{Status: CommandStatusDispatched}.IsFinished())
+	assert.False(t, CommandResult{Status: CommandStatusRunning}.IsFinished())
+	assert.True(t, CommandResult{Status: CommandStatusSuccess}.IsFinished())
+	assert.True(t, CommandResult{Status: CommandStatusFailure}.IsFinished())
+	assert.True(t, CommandResult{Status: CommandStatusTimeout}.IsFinished())
+	assert.True(t, CommandResult{Status: CommandStatusExpired}.IsFinished())
+}
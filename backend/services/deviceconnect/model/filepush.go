@@ -0,0 +1,128 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Values for the file push result status attribute
+const (
+	FilePushStatusPending = "pending"
+	FilePushStatusRunning = "running"
+	FilePushStatusSuccess = "success"
+	FilePushStatusFailure = "failure"
+	FilePushStatusExpired = "expired"
+)
+
+const (
+	// FilePushMaxFileSize is the maximum size of a file distributed with
+	// a file push job; larger files should be deployed with an artifact
+	FilePushMaxFileSize = 1024 * 1024
+	// FilePushMaxAttempts is the maximum number of transfers attempted
+	// on a device before giving up
+	FilePushMaxAttempts = 5
+
+	// FilePushDefaultExpire is the default number of seconds a file push
+	// job waits for offline devices to connect
+	FilePushDefaultExpire = 24 * 60 * 60
+	// FilePushMaxExpire is the maximum number of seconds a file push job
+	// waits for offline devices to connect
+	FilePushMaxExpire = 7 * 24 * 60 * 60
+)
+
+// NewFilePushJob is the request to push a file to a set of devices
+type NewFilePushJob struct {
+	// The destination path on the devices
+	Path *string `json:"path"`
+	// The file owner
+	UID *uint32 `json:"uid"`
+	// The file group
+	GID *uint32 `json:"gid"`
+	// Mode contains the file mode and permission bits.
+	Mode *uint32 `json:"mode"`
+	// Targets selects the devices receiving the file
+	Targets Targets `json:"targets"`
+	// ExpireAfter is the number of seconds the job waits for offline
+	// devices to connect
+	ExpireAfter uint `json:"expire_after"`
+	// Filename is the name of the uploaded file, appended to the path if
+	// it points to a directory on the device
+	Filename string `json:"filename"`
+	// Content is the content of the file
+	Content []byte `json:"file"`
+}
+
+// Validate validates the request
+func (j NewFilePushJob) Validate() error {
+	return validation.ValidateStruct(&j,
+		validation.Field(&j.Path, validation.Required,
+			validation.Match(absolutePathRegexp).Error("must be absolute")),
+		validation.Field(&j.Targets),
+		validation.Field(&j.ExpireAfter, validation.Max(uint(FilePushMaxExpire))),
+		validation.Field(&j.Filename, validation.Required),
+		validation.Field(&j.Content, validation.Length(0, FilePushMaxFileSize)),
+	)
+}
+
+// FilePushJob represents a file distributed to a set of devices
+type FilePushJob struct {
+	ID       string  `json:"id" bson:"_id"`
+	UserID   string  `json:"user_id" bson:"user_id"`
+	Path     string  `json:"path" bson:"path"`
+	UID      *uint32 `json:"uid,omitempty" bson:"uid,omitempty"`
+	GID      *uint32 `json:"gid,omitempty" bson:"gid,omitempty"`
+	Mode     *uint32 `json:"mode,omitempty" bson:"mode,omitempty"`
+	Filename string  `json:"filename" bson:"filename"`
+	Size     int64   `json:"size" bson:"size"`
+	// Checksum is the hex encoded SHA256 sum of the content
+	Checksum  string    `json:"checksum" bson:"checksum"`
+	Targets   Targets   `json:"targets" bson:"targets"`
+	CreatedTs time.Time `json:"created_ts" bson:"created_ts"`
+	ExpireTs  time.Time `json:"expire_ts" bson:"expire_ts"`
+
+	// Content is the content of the file, it is not returned when
+	// listing the jobs
+	Content []byte `json:"-" bson:"content,omitempty"`
+
+	// Stats is computed on request and not stored in the database
+	Stats map[string]int `json:"stats,omitempty" bson:"-"`
+}
+
+// FilePushResult holds the outcome of a file push job on a single device
+type FilePushResult struct {
+	ID       string `json:"id" bson:"_id"`
+	JobID    string `json:"job_id" bson:"job_id"`
+	DeviceID string `json:"device_id" bson:"device_id"`
+	Status   string `json:"status" bson:"status"`
+	// Attempts is the number of transfers started on the device
+	Attempts int `json:"attempts" bson:"attempts"`
+	// Error is the error of the last failed transfer
+	Error      string     `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedTs  time.Time  `json:"created_ts" bson:"created_ts"`
+	UpdatedTs  time.Time  `json:"updated_ts" bson:"updated_ts"`
+	FinishedTs *time.Time `json:"finished_ts,omitempty" bson:"finished_ts,omitempty"`
+	ExpireTs   time.Time  `json:"-" bson:"expire_ts"`
+}
+
+// FilePushResultsFilter filters the results of a file push job
+type FilePushResultsFilter struct {
+	JobID  string
+	Status string
+	Skip   int64
+	Limit  int64
+}
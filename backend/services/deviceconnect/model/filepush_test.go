@@ -0,0 +1,96 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFilePushJobValidation(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Request NewFilePushJob
+		Error   error
+	}{
+		{
+			Name: "validation ok",
+			Request: NewFilePushJob{
+				Path:     str2pointer("/etc/ssl/certs/ca.pem"),
+				Targets:  Targets{Group: "production"},
+				Filename: "ca.pem",
+				Content:  []byte("certificate"),
+			},
+		},
+		{
+			Name: "validation ok, empty file",
+			Request: NewFilePushJob{
+				Path:        str2pointer("/etc/ssl/certs/ca.pem"),
+				Targets:     Targets{DeviceIDs: []string{"1"}},
+				ExpireAfter: FilePushMaxExpire,
+				Filename:    "ca.pem",
+			},
+		},
+		{
+			Name: "validation failed, path is relative",
+			Request: NewFilePushJob{
+				Path:     str2pointer("etc/ssl/certs/ca.pem"),
+				Targets:  Targets{Group: "production"},
+				Filename: "ca.pem",
+			},
+			Error: errors.New("path: must be absolute."),
+		},
+		{
+			Name: "validation failed, missing file",
+			Request: NewFilePushJob{
+				Path:    str2pointer("/etc/ssl/certs/ca.pem"),
+				Targets: Targets{Group: "production"},
+			},
+			Error: errors.New("filename: cannot be blank."),
+		},
+		{
+			Name: "validation failed, file too large",
+			Request: NewFilePushJob{
+				Path:     str2pointer("/etc/ssl/certs/ca.pem"),
+				Targets:  Targets{Group: "production"},
+				Filename: "ca.pem",
+				Content:  make([]byte, FilePushMaxFileSize+1),
+			},
+			Error: errors.New("file: the length must be no more than 1048576."),
+		},
+		{
+			Name: "validation failed, missing targets",
+			Request: NewFilePushJob{
+				Path:     str2pointer("/etc/ssl/certs/ca.pem"),
+				Filename: "ca.pem",
+			},
+			Error: errors.New("targets: exactly one of device_ids, group or " +
+				"filter must be set."),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Request.Validate()
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
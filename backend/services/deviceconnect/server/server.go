@@ -28,6 +28,7 @@ import (
 
 	api "github.com/mendersoftware/mender-server/services/deviceconnect/api/http"
 	"github.com/mendersoftware/mender-server/services/deviceconnect/app"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/client/inventory"
 	"github.com/mendersoftware/mender-server/services/deviceconnect/client/nats"
 	"github.com/mendersoftware/mender-server/services/deviceconnect/client/workflows"
 	dconfig "github.com/mendersoftware/mender-server/services/deviceconnect/config"
@@ -55,17 +56,21 @@ func InitAndRun(conf config.Reader, dataStore store.DataStore) error {
 	wflows := workflows.NewClient(
 		config.Config.GetString(dconfig.SettingWorkflowsURL),
 	)
+	inv := inventory.NewClient(
+		config.Config.GetString(dconfig.SettingInventoryURL),
+	)
 	deviceConnectApp := app.New(
 		dataStore, wflows, app.Config{
 			HaveAuditLogs: conf.GetBool(dconfig.SettingEnableAuditLogs),
 		},
-	)
+	).WithInventory(inv)
 
 	gracefulShutdownTimeout := conf.GetDuration(dconfig.SettingGracefulShutdownTimeout)
 	router, err := api.NewRouter(deviceConnectApp, natsClient, &api.RouterConfig{
 		GracefulShutdownTimeout: gracefulShutdownTimeout,
 		MaxRequestSize:          config.Config.GetInt64(dconfig.SettingMaxRequestSize),
 		MaxFileSize:             config.Config.GetInt64(dconfig.SettingMaxFileUploadSize),
+		FilePushConcurrency:     config.Config.GetInt(dconfig.SettingFilePushConcurrency),
 	})
 	if err != nil {
 		l.Fatal(err)
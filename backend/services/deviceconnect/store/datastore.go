@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
 )
@@ -40,9 +41,32 @@ type DataStore interface {
 	InsertControlRecording(ctx context.Context, sessionID string, sessionBytes []byte) error
 	DeleteSession(ctx context.Context, sessionID string) (*model.Session, error)
 	DeleteTenant(ctx context.Context, tenantID string) error
+	InsertCommandJob(ctx context.Context, job *model.CommandJob, results []model.CommandResult) error
+	GetCommandJob(ctx context.Context, jobID string) (*model.CommandJob, error)
+	GetCommandJobs(ctx context.Context, skip, limit int64) ([]model.CommandJob, int64, error)
+	GetCommandJobStats(ctx context.Context, jobID string) (map[string]int, error)
+	GetCommandResults(ctx context.Context, filter model.CommandResultsFilter) ([]model.CommandResult, int64, error)
+	GetPendingCommandResults(ctx context.Context, deviceID string) ([]model.CommandResult, error)
+	SetCommandResultsDispatched(ctx context.Context, resultIDs []string) error
+	AppendCommandOutput(ctx context.Context, deviceID, resultID string, stdout, stderr string) error
+	FinishCommandResult(ctx context.Context, deviceID, resultID string, status string, exitCode *int, errMsg string) error
+	ExpireCommandResults(ctx context.Context, jobID string, timeout time.Duration) error
+	InsertFilePushJob(ctx context.Context, job *model.FilePushJob, results []model.FilePushResult) error
+	GetFilePushJob(ctx context.Context, jobID string) (*model.FilePushJob, error)
+	GetFilePushJobs(ctx context.Context, skip, limit int64) ([]model.FilePushJob, int64, error)
+	GetFilePushJobStats(ctx context.Context, jobID string) (map[string]int, error)
+	GetFilePushResults(ctx context.Context, filter model.FilePushResultsFilter) ([]model.FilePushResult, int64, error)
+	GetPendingFilePushResults(ctx context.Context, deviceID string) ([]model.FilePushResult, error)
+	ClaimFilePushResult(ctx context.Context, resultID string) (*model.FilePushResult, error)
+	FinishFilePushResult(ctx context.Context, resultID string, status string, errMsg string) error
+	ExpireFilePushResults(ctx context.Context, jobID string, staleAfter time.Duration) error
 	Close() error
 }
 
 var (
-	ErrSessionNotFound = errors.New("store: session not found")
+	ErrSessionNotFound       = errors.New("store: session not found")
+	ErrCommandJobNotFound    = errors.New("store: command job not found")
+	ErrCommandResultNotFound = errors.New("store: command result not found")
+	ErrFilePushJobNotFound   = errors.New("store: file push job not found")
+	ErrFilePushNotPending    = errors.New("store: file push result not pending")
 )
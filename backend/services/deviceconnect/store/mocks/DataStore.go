@@ -23,6 +23,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/mendersoftware/mender-server/services/deviceconnect/model"
+
+	time "time"
 )
 
 // DataStore is an autogenerated mock type for the DataStore type
@@ -48,6 +50,54 @@ func (_m *DataStore) AllocateSession(ctx context.Context, sess *model.Session) e
 	return r0
 }
 
+// AppendCommandOutput provides a mock function with given fields: ctx, deviceID, resultID, stdout, stderr
+func (_m *DataStore) AppendCommandOutput(ctx context.Context, deviceID string, resultID string, stdout string, stderr string) error {
+	ret := _m.Called(ctx, deviceID, resultID, stdout, stderr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendCommandOutput")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, deviceID, resultID, stdout, stderr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimFilePushResult provides a mock function with given fields: ctx, resultID
+func (_m *DataStore) ClaimFilePushResult(ctx context.Context, resultID string) (*model.FilePushResult, error) {
+	ret := _m.Called(ctx, resultID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimFilePushResult")
+	}
+
+	var r0 *model.FilePushResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.FilePushResult, error)); ok {
+		return rf(ctx, resultID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.FilePushResult); ok {
+		r0 = rf(ctx, resultID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FilePushResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, resultID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *DataStore) Close() error {
 	ret := _m.Called()
@@ -132,6 +182,212 @@ func (_m *DataStore) DeleteTenant(ctx context.Context, tenantID string) error {
 	return r0
 }
 
+// ExpireCommandResults provides a mock function with given fields: ctx, jobID, timeout
+func (_m *DataStore) ExpireCommandResults(ctx context.Context, jobID string, timeout time.Duration) error {
+	ret := _m.Called(ctx, jobID, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireCommandResults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, jobID, timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireFilePushResults provides a mock function with given fields: ctx, jobID, staleAfter
+func (_m *DataStore) ExpireFilePushResults(ctx context.Context, jobID string, staleAfter time.Duration) error {
+	ret := _m.Called(ctx, jobID, staleAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireFilePushResults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, jobID, staleAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishCommandResult provides a mock function with given fields: ctx, deviceID, resultID, status, exitCode, errMsg
+func (_m *DataStore) FinishCommandResult(ctx context.Context, deviceID string, resultID string, status string, exitCode *int, errMsg string) error {
+	ret := _m.Called(ctx, deviceID, resultID, status, exitCode, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishCommandResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *int, string) error); ok {
+		r0 = rf(ctx, deviceID, resultID, status, exitCode, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishFilePushResult provides a mock function with given fields: ctx, resultID, status, errMsg
+func (_m *DataStore) FinishFilePushResult(ctx context.Context, resultID string, status string, errMsg string) error {
+	ret := _m.Called(ctx, resultID, status, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishFilePushResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, resultID, status, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCommandJob provides a mock function with given fields: ctx, jobID
+func (_m *DataStore) GetCommandJob(ctx context.Context, jobID string) (*model.CommandJob, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommandJob")
+	}
+
+	var r0 *model.CommandJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.CommandJob, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.CommandJob); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CommandJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommandJobStats provides a mock function with given fields: ctx, jobID
+func (_m *DataStore) GetCommandJobStats(ctx context.Context, jobID string) (map[string]int, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommandJobStats")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]int, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]int); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommandJobs provides a mock function with given fields: ctx, skip, limit
+func (_m *DataStore) GetCommandJobs(ctx context.Context, skip int64, limit int64) ([]model.CommandJob, int64, error) {
+	ret := _m.Called(ctx, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommandJobs")
+	}
+
+	var r0 []model.CommandJob
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]model.CommandJob, int64, error)); ok {
+		return rf(ctx, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []model.CommandJob); ok {
+		r0 = rf(ctx, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CommandJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, skip, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, skip, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCommandResults provides a mock function with given fields: ctx, filter
+func (_m *DataStore) GetCommandResults(ctx context.Context, filter model.CommandResultsFilter) ([]model.CommandResult, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommandResults")
+	}
+
+	var r0 []model.CommandResult
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.CommandResultsFilter) ([]model.CommandResult, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.CommandResultsFilter) []model.CommandResult); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CommandResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.CommandResultsFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.CommandResultsFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetDevice provides a mock function with given fields: ctx, tenantID, deviceID
 func (_m *DataStore) GetDevice(ctx context.Context, tenantID string, deviceID string) (*model.Device, error) {
 	ret := _m.Called(ctx, tenantID, deviceID)
@@ -162,6 +418,200 @@ func (_m *DataStore) GetDevice(ctx context.Context, tenantID string, deviceID st
 	return r0, r1
 }
 
+// GetFilePushJob provides a mock function with given fields: ctx, jobID
+func (_m *DataStore) GetFilePushJob(ctx context.Context, jobID string) (*model.FilePushJob, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePushJob")
+	}
+
+	var r0 *model.FilePushJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.FilePushJob, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.FilePushJob); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FilePushJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilePushJobStats provides a mock function with given fields: ctx, jobID
+func (_m *DataStore) GetFilePushJobStats(ctx context.Context, jobID string) (map[string]int, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePushJobStats")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]int, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]int); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilePushJobs provides a mock function with given fields: ctx, skip, limit
+func (_m *DataStore) GetFilePushJobs(ctx context.Context, skip int64, limit int64) ([]model.FilePushJob, int64, error) {
+	ret := _m.Called(ctx, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePushJobs")
+	}
+
+	var r0 []model.FilePushJob
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]model.FilePushJob, int64, error)); ok {
+		return rf(ctx, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []model.FilePushJob); ok {
+		r0 = rf(ctx, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FilePushJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, skip, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, skip, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetFilePushResults provides a mock function with given fields: ctx, filter
+func (_m *DataStore) GetFilePushResults(ctx context.Context, filter model.FilePushResultsFilter) ([]model.FilePushResult, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePushResults")
+	}
+
+	var r0 []model.FilePushResult
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.FilePushResultsFilter) ([]model.FilePushResult, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.FilePushResultsFilter) []model.FilePushResult); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FilePushResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.FilePushResultsFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.FilePushResultsFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPendingCommandResults provides a mock function with given fields: ctx, deviceID
+func (_m *DataStore) GetPendingCommandResults(ctx context.Context, deviceID string) ([]model.CommandResult, error) {
+	ret := _m.Called(ctx, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingCommandResults")
+	}
+
+	var r0 []model.CommandResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.CommandResult, error)); ok {
+		return rf(ctx, deviceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.CommandResult); ok {
+		r0 = rf(ctx, deviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CommandResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, deviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingFilePushResults provides a mock function with given fields: ctx, deviceID
+func (_m *DataStore) GetPendingFilePushResults(ctx context.Context, deviceID string) ([]model.FilePushResult, error) {
+	ret := _m.Called(ctx, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingFilePushResults")
+	}
+
+	var r0 []model.FilePushResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.FilePushResult, error)); ok {
+		return rf(ctx, deviceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.FilePushResult); ok {
+		r0 = rf(ctx, deviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FilePushResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, deviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSession provides a mock function with given fields: ctx, sessionID
 func (_m *DataStore) GetSession(ctx context.Context, sessionID string) (*model.Session, error) {
 	ret := _m.Called(ctx, sessionID)
@@ -192,6 +642,24 @@ func (_m *DataStore) GetSession(ctx context.Context, sessionID string) (*model.S
 	return r0, r1
 }
 
+// InsertCommandJob provides a mock function with given fields: ctx, job, results
+func (_m *DataStore) InsertCommandJob(ctx context.Context, job *model.CommandJob, results []model.CommandResult) error {
+	ret := _m.Called(ctx, job, results)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertCommandJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CommandJob, []model.CommandResult) error); ok {
+		r0 = rf(ctx, job, results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertControlRecording provides a mock function with given fields: ctx, sessionID, sessionBytes
 func (_m *DataStore) InsertControlRecording(ctx context.Context, sessionID string, sessionBytes []byte) error {
 	ret := _m.Called(ctx, sessionID, sessionBytes)
@@ -210,6 +678,24 @@ func (_m *DataStore) InsertControlRecording(ctx context.Context, sessionID strin
 	return r0
 }
 
+// InsertFilePushJob provides a mock function with given fields: ctx, job, results
+func (_m *DataStore) InsertFilePushJob(ctx context.Context, job *model.FilePushJob, results []model.FilePushResult) error {
+	ret := _m.Called(ctx, job, results)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertFilePushJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.FilePushJob, []model.FilePushResult) error); ok {
+		r0 = rf(ctx, job, results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertSessionRecording provides a mock function with given fields: ctx, sessionID, sessionBytes
 func (_m *DataStore) InsertSessionRecording(ctx context.Context, sessionID string, sessionBytes []byte) error {
 	ret := _m.Called(ctx, sessionID, sessionBytes)
@@ -264,6 +750,24 @@ func (_m *DataStore) ProvisionDevice(ctx context.Context, tenantID string, devic
 	return r0
 }
 
+// SetCommandResultsDispatched provides a mock function with given fields: ctx, resultIDs
+func (_m *DataStore) SetCommandResultsDispatched(ctx context.Context, resultIDs []string) error {
+	ret := _m.Called(ctx, resultIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCommandResultsDispatched")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, resultIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDeviceConnected provides a mock function with given fields: ctx, tenantID, deviceID
 func (_m *DataStore) SetDeviceConnected(ctx context.Context, tenantID string, deviceID string) (int64, error) {
 	ret := _m.Called(ctx, tenantID, deviceID)
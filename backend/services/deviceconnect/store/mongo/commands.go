@@ -0,0 +1,376 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+)
+
+const (
+	// CommandsCollectionName refers to the name of the collection of
+	// command jobs
+	CommandsCollectionName = "commands"
+
+	// CommandResultsCollectionName refers to the name of the collection
+	// of per-device command results
+	CommandResultsCollectionName = "command_results"
+
+	dbFieldJobID      = "job_id"
+	dbFieldStdout     = "stdout"
+	dbFieldStderr     = "stderr"
+	dbFieldExitCode   = "exit_code"
+	dbFieldError      = "error"
+	dbFieldStartedTs  = "started_ts"
+	dbFieldFinishedTs = "finished_ts"
+	dbFieldExpireTs   = "expire_ts"
+)
+
+// InsertCommandJob stores a new command job together with the results
+// for the targeted devices.
+func (db *DataStoreMongo) InsertCommandJob(
+	ctx context.Context,
+	job *model.CommandJob,
+	results []model.CommandResult,
+) error {
+	database := db.client.Database(DbName)
+	_, err := database.Collection(CommandsCollectionName).
+		InsertOne(ctx, mstore.WithTenantID(ctx, job))
+	if err != nil {
+		return errors.Wrap(err, "store: failed to insert command job")
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(results))
+	for i := range results {
+		docs[i] = mstore.WithTenantID(ctx, results[i])
+	}
+	_, err = database.Collection(CommandResultsCollectionName).
+		InsertMany(ctx, docs)
+	if err != nil {
+		return errors.Wrap(err, "store: failed to insert command results")
+	}
+	return nil
+}
+
+// GetCommandJob returns a command job
+func (db *DataStoreMongo) GetCommandJob(
+	ctx context.Context,
+	jobID string,
+) (*model.CommandJob, error) {
+	coll := db.client.Database(DbName).Collection(CommandsCollectionName)
+
+	job := &model.CommandJob{}
+	err := coll.FindOne(ctx,
+		mstore.WithTenantID(ctx, bson.D{{Key: dbFieldID, Value: jobID}}),
+	).Decode(job)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrCommandJobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetCommandJobs returns the command jobs, the most recent first
+func (db *DataStoreMongo) GetCommandJobs(
+	ctx context.Context,
+	skip, limit int64,
+) ([]model.CommandJob, int64, error) {
+	coll := db.client.Database(DbName).Collection(CommandsCollectionName)
+
+	query := mstore.WithTenantID(ctx, bson.D{})
+	count, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: dbFieldCreatedTs, Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	cur, err := coll.Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs := []model.CommandJob{}
+	if err := cur.All(ctx, &jobs); err != nil {
+		return nil, 0, err
+	}
+	return jobs, count, nil
+}
+
+// GetCommandJobStats returns the number of device results grouped by status
+func (db *DataStoreMongo) GetCommandJobStats(
+	ctx context.Context,
+	jobID string,
+) (map[string]int, error) {
+	coll := db.client.Database(DbName).Collection(CommandResultsCollectionName)
+
+	cur, err := coll.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldJobID, Value: jobID},
+		})}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + dbFieldStatus},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var groups []struct {
+		Status string `bson:"_id"`
+		Count  int    `bson:"count"`
+	}
+	if err := cur.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]int, len(groups))
+	for _, group := range groups {
+		stats[group.Status] = group.Count
+	}
+	return stats, nil
+}
+
+// GetCommandResults returns the device results of a command job
+func (db *DataStoreMongo) GetCommandResults(
+	ctx context.Context,
+	filter model.CommandResultsFilter,
+) ([]model.CommandResult, int64, error) {
+	coll := db.client.Database(DbName).Collection(CommandResultsCollectionName)
+
+	query := bson.D{{Key: dbFieldJobID, Value: filter.JobID}}
+	if filter.Status != "" {
+		query = append(query, bson.E{Key: dbFieldStatus, Value: filter.Status})
+	}
+	query = mstore.WithTenantID(ctx, query)
+	count, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: dbFieldDeviceID, Value: 1}})
+	if filter.Skip > 0 {
+		findOpts.SetSkip(filter.Skip)
+	}
+	if filter.Limit > 0 {
+		findOpts.SetLimit(filter.Limit)
+	}
+	cur, err := coll.Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	results := []model.CommandResult{}
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+	return results, count, nil
+}
+
+// GetPendingCommandResults returns the results of the device which have
+// not been picked up by the device yet and did not expire.
+func (db *DataStoreMongo) GetPendingCommandResults(
+	ctx context.Context,
+	deviceID string,
+) ([]model.CommandResult, error) {
+	coll := db.client.Database(DbName).Collection(CommandResultsCollectionName)
+
+	now := clock.Now().UTC()
+	cur, err := coll.Find(ctx, mstore.WithTenantID(ctx, bson.D{
+		{Key: dbFieldDeviceID, Value: deviceID},
+		{Key: dbFieldStatus, Value: bson.D{{Key: "$in", Value: bson.A{
+			model.CommandStatusPending,
+			model.CommandStatusDispatched,
+		}}}},
+		{Key: dbFieldExpireTs, Value: bson.D{{Key: "$gt", Value: now}}},
+	}), mopts.Find().SetSort(bson.D{{Key: dbFieldCreatedTs, Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	results := []model.CommandResult{}
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// SetCommandResultsDispatched marks the pending results as dispatched
+func (db *DataStoreMongo) SetCommandResultsDispatched(
+	ctx context.Context,
+	resultIDs []string,
+) error {
+	if len(resultIDs) == 0 {
+		return nil
+	}
+	coll := db.client.Database(DbName).Collection(CommandResultsCollectionName)
+
+	_, err := coll.UpdateMany(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldID, Value: bson.D{{Key: "$in", Value: resultIDs}}},
+			{Key: dbFieldStatus, Value: model.CommandStatusPending},
+		}),
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: dbFieldStatus, Value: model.CommandStatusDispatched},
+		}}},
+	)
+	return err
+}
+
+// AppendCommandOutput appends the output chunks to the result and marks
+// it as running. The stored output is capped at model.CommandMaxOutputSize
+// characters per stream.
+func (db *DataStoreMongo) AppendCommandOutput(
+	ctx context.Context,
+	deviceID, resultID string,
+	stdout, stderr string,
+) error {
+	coll := db.client.Database(DbName).Collection(CommandResultsCollectionName)
+
+	now := clock.Now().UTC()
+	appendOutput := func(field, chunk string) bson.D {
+		return bson.D{{Key: "$substrCP", Value: bson.A{
+			bson.D{{Key: "$concat", Value: bson.A{
+				bson.D{{Key: "$ifNull", Value: bson.A{"$" + field, ""}}},
+				chunk,
+			}}},
+			0,
+			model.CommandMaxOutputSize,
+		}}}
+	}
+	res, err := coll.UpdateOne(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldID, Value: resultID},
+			{Key: dbFieldDeviceID, Value: deviceID},
+			{Key: dbFieldStatus, Value: bson.D{{Key: "$in", Value: bson.A{
+				model.CommandStatusPending,
+				model.CommandStatusDispatched,
+				model.CommandStatusRunning,
+			}}}},
+		}),
+		bson.A{
+			bson.D{{Key: "$set", Value: bson.D{
+				{Key: dbFieldStatus, Value: model.CommandStatusRunning},
+				{Key: dbFieldStartedTs, Value: bson.D{
+					{Key: "$ifNull", Value: bson.A{"$" + dbFieldStartedTs, now}},
+				}},
+				{Key: dbFieldStdout, Value: appendOutput(dbFieldStdout, stdout)},
+				{Key: dbFieldStderr, Value: appendOutput(dbFieldStderr, stderr)},
+			}}},
+		},
+	)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return store.ErrCommandResultNotFound
+	}
+	return nil
+}
+
+// FinishCommandResult sets the final status of the result
+func (db *DataStoreMongo) FinishCommandResult(
+	ctx context.Context,
+	deviceID, resultID string,
+	status string,
+	exitCode *int,
+	errMsg string,
+) error {
+	coll := db.client.Database(DbName).Collection(CommandResultsCollectionName)
+
+	now := clock.Now().UTC()
+	set := bson.D{
+		{Key: dbFieldStatus, Value: status},
+		{Key: dbFieldFinishedTs, Value: now},
+		{Key: dbFieldStartedTs, Value: bson.D{
+			{Key: "$ifNull", Value: bson.A{"$" + dbFieldStartedTs, now}},
+		}},
+	}
+	if exitCode != nil {
+		set = append(set, bson.E{Key: dbFieldExitCode, Value: *exitCode})
+	}
+	if errMsg != "" {
+		set = append(set, bson.E{Key: dbFieldError, Value: errMsg})
+	}
+	res, err := coll.UpdateOne(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldID, Value: resultID},
+			{Key: dbFieldDeviceID, Value: deviceID},
+			{Key: dbFieldStatus, Value: bson.D{{Key: "$in", Value: bson.A{
+				model.CommandStatusPending,
+				model.CommandStatusDispatched,
+				model.CommandStatusRunning,
+			}}}},
+		}),
+		bson.A{bson.D{{Key: "$set", Value: set}}},
+	)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return store.ErrCommandResultNotFound
+	}
+	return nil
+}
+
+// ExpireCommandResults updates the results of the job which were never
+// picked up before the job expired, and the results of the commands which
+// are running for longer than timeout.
+func (db *DataStoreMongo) ExpireCommandResults(
+	ctx context.Context,
+	jobID string,
+	timeout time.Duration,
+) error {
+	coll := db.client.Database(DbName).Collection(CommandResultsCollectionName)
+
+	now := clock.Now().UTC()
+	_, err := coll.UpdateMany(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldJobID, Value: jobID},
+			{Key: dbFieldStatus, Value: bson.D{{Key: "$in", Value: bson.A{
+				model.CommandStatusPending,
+				model.CommandStatusDispatched,
+			}}}},
+			{Key: dbFieldExpireTs, Value: bson.D{{Key: "$lte", Value: now}}},
+		}),
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: dbFieldStatus, Value: model.CommandStatusExpired},
+			{Key: dbFieldFinishedTs, Value: now},
+		}}},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = coll.UpdateMany(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldJobID, Value: jobID},
+			{Key: dbFieldStatus, Value: model.CommandStatusRunning},
+			{Key: dbFieldStartedTs, Value: bson.D{{Key: "$lte", Value: now.Add(-timeout)}}},
+		}),
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: dbFieldStatus, Value: model.CommandStatusTimeout},
+			{Key: dbFieldFinishedTs, Value: now},
+		}}},
+	)
+	return err
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+)
+
+func TestCommandJobLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestCommandJobLifecycle in short mode.")
+	}
+	const (
+		tenantID = "000000000000000000000000"
+		jobID    = "00000000-0000-0000-0000-000000000001"
+	)
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenantID,
+	})
+	previousClock := clock
+	defer func() {
+		clock = previousClock
+	}()
+	clock = mockClock{}
+
+	ds := DataStoreMongo{client: db.Client()}
+	defer ds.DropDatabase()
+
+	job := &model.CommandJob{
+		ID:        jobID,
+		UserID:    "user",
+		Command:   "uptime",
+		Targets:   model.Targets{DeviceIDs: []string{"dev-1", "dev-2", "dev-3"}},
+		Timeout:   60,
+		CreatedTs: mockTime,
+		ExpireTs:  mockTime.Add(time.Hour),
+	}
+	results := make([]model.CommandResult, len(job.Targets.DeviceIDs))
+	for i, deviceID := range job.Targets.DeviceIDs {
+		results[i] = model.CommandResult{
+			ID:        "result-" + deviceID,
+			JobID:     jobID,
+			DeviceID:  deviceID,
+			Status:    model.CommandStatusPending,
+			CreatedTs: mockTime,
+			ExpireTs:  job.ExpireTs,
+			Command:   job.Command,
+			Timeout:   job.Timeout,
+		}
+	}
+	err := ds.InsertCommandJob(ctx, job, results)
+	require.NoError(t, err)
+
+	jobs, count, err := ds.GetCommandJobs(ctx, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, jobID, jobs[0].ID)
+	}
+	_, err = ds.GetCommandJob(ctx, "missing")
+	assert.Equal(t, store.ErrCommandJobNotFound, err)
+
+	pending, err := ds.GetPendingCommandResults(ctx, "dev-1")
+	require.NoError(t, err)
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, "uptime", pending[0].Command)
+	}
+	err = ds.SetCommandResultsDispatched(ctx, []string{"result-dev-1"})
+	require.NoError(t, err)
+
+	err = ds.AppendCommandOutput(ctx, "dev-1", "result-dev-1", "up ", "")
+	require.NoError(t, err)
+	err = ds.AppendCommandOutput(ctx, "dev-1", "result-dev-1", "3 days", "warn")
+	require.NoError(t, err)
+	exitCode := 0
+	err = ds.FinishCommandResult(ctx, "dev-1", "result-dev-1",
+		model.CommandStatusSuccess, &exitCode, "")
+	require.NoError(t, err)
+	err = ds.AppendCommandOutput(ctx, "dev-1", "result-dev-1", "late", "")
+	assert.Equal(t, store.ErrCommandResultNotFound, err)
+	err = ds.AppendCommandOutput(ctx, "dev-2", "result-dev-1", "spoof", "")
+	assert.Equal(t, store.ErrCommandResultNotFound, err)
+
+	err = ds.AppendCommandOutput(ctx, "dev-2", "result-dev-2", "running", "")
+	require.NoError(t, err)
+
+	res, count, err := ds.GetCommandResults(ctx, model.CommandResultsFilter{
+		JobID:  jobID,
+		Status: model.CommandStatusSuccess,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "up 3 days", res[0].Stdout)
+		assert.Equal(t, "warn", res[0].Stderr)
+		assert.Equal(t, &exitCode, res[0].ExitCode)
+	}
+
+	stats, err := ds.GetCommandJobStats(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		model.CommandStatusSuccess: 1,
+		model.CommandStatusRunning: 1,
+		model.CommandStatusPending: 1,
+	}, stats)
+
+	// A zero timeout times out running commands right away; pending
+	// results only expire once the job does.
+	err = ds.ExpireCommandResults(ctx, jobID, 0)
+	require.NoError(t, err)
+	stats, err = ds.GetCommandJobStats(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		model.CommandStatusSuccess: 1,
+		model.CommandStatusTimeout: 1,
+		model.CommandStatusPending: 1,
+	}, stats)
+}
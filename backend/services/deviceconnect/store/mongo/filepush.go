@@ -0,0 +1,323 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+)
+
+const (
+	// FilePushesCollectionName refers to the name of the collection of
+	// file push jobs
+	FilePushesCollectionName = "file_pushes"
+
+	// FilePushResultsCollectionName refers to the name of the collection
+	// of per-device file push results
+	FilePushResultsCollectionName = "file_push_results"
+
+	dbFieldAttempts = "attempts"
+	dbFieldContent  = "content"
+)
+
+// InsertFilePushJob stores a new file push job together with the results
+// for the targeted devices.
+func (db *DataStoreMongo) InsertFilePushJob(
+	ctx context.Context,
+	job *model.FilePushJob,
+	results []model.FilePushResult,
+) error {
+	database := db.client.Database(DbName)
+	_, err := database.Collection(FilePushesCollectionName).
+		InsertOne(ctx, mstore.WithTenantID(ctx, job))
+	if err != nil {
+		return errors.Wrap(err, "store: failed to insert file push job")
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(results))
+	for i := range results {
+		docs[i] = mstore.WithTenantID(ctx, results[i])
+	}
+	_, err = database.Collection(FilePushResultsCollectionName).
+		InsertMany(ctx, docs)
+	if err != nil {
+		return errors.Wrap(err, "store: failed to insert file push results")
+	}
+	return nil
+}
+
+// GetFilePushJob returns a file push job including the file content
+func (db *DataStoreMongo) GetFilePushJob(
+	ctx context.Context,
+	jobID string,
+) (*model.FilePushJob, error) {
+	coll := db.client.Database(DbName).Collection(FilePushesCollectionName)
+
+	job := &model.FilePushJob{}
+	err := coll.FindOne(ctx,
+		mstore.WithTenantID(ctx, bson.D{{Key: dbFieldID, Value: jobID}}),
+	).Decode(job)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrFilePushJobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetFilePushJobs returns the file push jobs without the file content,
+// the most recent first
+func (db *DataStoreMongo) GetFilePushJobs(
+	ctx context.Context,
+	skip, limit int64,
+) ([]model.FilePushJob, int64, error) {
+	coll := db.client.Database(DbName).Collection(FilePushesCollectionName)
+
+	query := mstore.WithTenantID(ctx, bson.D{})
+	count, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	findOpts := mopts.Find().
+		SetProjection(bson.D{{Key: dbFieldContent, Value: 0}}).
+		SetSort(bson.D{{Key: dbFieldCreatedTs, Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	cur, err := coll.Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs := []model.FilePushJob{}
+	if err := cur.All(ctx, &jobs); err != nil {
+		return nil, 0, err
+	}
+	return jobs, count, nil
+}
+
+// GetFilePushJobStats returns the number of device results grouped by status
+func (db *DataStoreMongo) GetFilePushJobStats(
+	ctx context.Context,
+	jobID string,
+) (map[string]int, error) {
+	coll := db.client.Database(DbName).Collection(FilePushResultsCollectionName)
+
+	cur, err := coll.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldJobID, Value: jobID},
+		})}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + dbFieldStatus},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var groups []struct {
+		Status string `bson:"_id"`
+		Count  int    `bson:"count"`
+	}
+	if err := cur.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]int, len(groups))
+	for _, group := range groups {
+		stats[group.Status] = group.Count
+	}
+	return stats, nil
+}
+
+// GetFilePushResults returns the device results of a file push job
+func (db *DataStoreMongo) GetFilePushResults(
+	ctx context.Context,
+	filter model.FilePushResultsFilter,
+) ([]model.FilePushResult, int64, error) {
+	coll := db.client.Database(DbName).Collection(FilePushResultsCollectionName)
+
+	query := bson.D{{Key: dbFieldJobID, Value: filter.JobID}}
+	if filter.Status != "" {
+		query = append(query, bson.E{Key: dbFieldStatus, Value: filter.Status})
+	}
+	query = mstore.WithTenantID(ctx, query)
+	count, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: dbFieldDeviceID, Value: 1}})
+	if filter.Skip > 0 {
+		findOpts.SetSkip(filter.Skip)
+	}
+	if filter.Limit > 0 {
+		findOpts.SetLimit(filter.Limit)
+	}
+	cur, err := coll.Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	results := []model.FilePushResult{}
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+	return results, count, nil
+}
+
+// GetPendingFilePushResults returns the results of the device waiting for
+// a transfer which did not expire.
+func (db *DataStoreMongo) GetPendingFilePushResults(
+	ctx context.Context,
+	deviceID string,
+) ([]model.FilePushResult, error) {
+	coll := db.client.Database(DbName).Collection(FilePushResultsCollectionName)
+
+	now := clock.Now().UTC()
+	cur, err := coll.Find(ctx, mstore.WithTenantID(ctx, bson.D{
+		{Key: dbFieldDeviceID, Value: deviceID},
+		{Key: dbFieldStatus, Value: model.FilePushStatusPending},
+		{Key: dbFieldExpireTs, Value: bson.D{{Key: "$gt", Value: now}}},
+	}), mopts.Find().SetSort(bson.D{{Key: dbFieldCreatedTs, Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	results := []model.FilePushResult{}
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ClaimFilePushResult marks a pending result as running and increments
+// the number of attempts. It returns store.ErrFilePushNotPending if the
+// result is not pending anymore, e.g. because another instance of the
+// service claimed it first, or if the job expired.
+func (db *DataStoreMongo) ClaimFilePushResult(
+	ctx context.Context,
+	resultID string,
+) (*model.FilePushResult, error) {
+	coll := db.client.Database(DbName).Collection(FilePushResultsCollectionName)
+
+	now := clock.Now().UTC()
+	result := &model.FilePushResult{}
+	err := coll.FindOneAndUpdate(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldID, Value: resultID},
+			{Key: dbFieldStatus, Value: model.FilePushStatusPending},
+			{Key: dbFieldExpireTs, Value: bson.D{{Key: "$gt", Value: now}}},
+		}),
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: dbFieldStatus, Value: model.FilePushStatusRunning},
+				{Key: dbFieldUpdatedTs, Value: now},
+			}},
+			{Key: "$inc", Value: bson.D{{Key: dbFieldAttempts, Value: 1}}},
+		},
+		mopts.FindOneAndUpdate().SetReturnDocument(mopts.After),
+	).Decode(result)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrFilePushNotPending
+	} else if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FinishFilePushResult sets the status of a running result; setting the
+// status back to pending schedules another attempt.
+func (db *DataStoreMongo) FinishFilePushResult(
+	ctx context.Context,
+	resultID string,
+	status string,
+	errMsg string,
+) error {
+	coll := db.client.Database(DbName).Collection(FilePushResultsCollectionName)
+
+	now := clock.Now().UTC()
+	set := bson.D{
+		{Key: dbFieldStatus, Value: status},
+		{Key: dbFieldUpdatedTs, Value: now},
+		{Key: dbFieldError, Value: errMsg},
+	}
+	if status != model.FilePushStatusPending {
+		set = append(set, bson.E{Key: dbFieldFinishedTs, Value: now})
+	}
+	res, err := coll.UpdateOne(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldID, Value: resultID},
+			{Key: dbFieldStatus, Value: model.FilePushStatusRunning},
+		}),
+		bson.D{{Key: "$set", Value: set}},
+	)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return store.ErrFilePushNotPending
+	}
+	return nil
+}
+
+// ExpireFilePushResults updates the pending results of the job which were
+// not transferred before the job expired, and reschedules the transfers
+// which did not report any progress for longer than staleAfter, e.g.
+// because the instance of the service running them was terminated.
+func (db *DataStoreMongo) ExpireFilePushResults(
+	ctx context.Context,
+	jobID string,
+	staleAfter time.Duration,
+) error {
+	coll := db.client.Database(DbName).Collection(FilePushResultsCollectionName)
+
+	now := clock.Now().UTC()
+	_, err := coll.UpdateMany(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldJobID, Value: jobID},
+			{Key: dbFieldStatus, Value: model.FilePushStatusRunning},
+			{Key: dbFieldUpdatedTs, Value: bson.D{
+				{Key: "$lte", Value: now.Add(-staleAfter)},
+			}},
+		}),
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: dbFieldStatus, Value: model.FilePushStatusPending},
+			{Key: dbFieldUpdatedTs, Value: now},
+		}}},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = coll.UpdateMany(ctx,
+		mstore.WithTenantID(ctx, bson.D{
+			{Key: dbFieldJobID, Value: jobID},
+			{Key: dbFieldStatus, Value: model.FilePushStatusPending},
+			{Key: dbFieldExpireTs, Value: bson.D{{Key: "$lte", Value: now}}},
+		}),
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: dbFieldStatus, Value: model.FilePushStatusExpired},
+			{Key: dbFieldUpdatedTs, Value: now},
+			{Key: dbFieldFinishedTs, Value: now},
+		}}},
+	)
+	return err
+}
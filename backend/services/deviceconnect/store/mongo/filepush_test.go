@@ -0,0 +1,135 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/model"
+	"github.com/mendersoftware/mender-server/services/deviceconnect/store"
+)
+
+func TestFilePushJobLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestFilePushJobLifecycle in short mode.")
+	}
+	const (
+		tenantID = "000000000000000000000000"
+		jobID    = "00000000-0000-0000-0000-000000000001"
+	)
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenantID,
+	})
+	previousClock := clock
+	defer func() {
+		clock = previousClock
+	}()
+	clock = mockClock{}
+
+	ds := DataStoreMongo{client: db.Client()}
+	defer ds.DropDatabase()
+
+	job := &model.FilePushJob{
+		ID:        jobID,
+		UserID:    "user",
+		Path:      "/etc/ssl/certs/ca.pem",
+		Filename:  "ca.pem",
+		Size:      11,
+		Targets:   model.Targets{DeviceIDs: []string{"dev-1", "dev-2"}},
+		CreatedTs: mockTime,
+		ExpireTs:  mockTime.Add(time.Hour),
+		Content:   []byte("certificate"),
+	}
+	results := make([]model.FilePushResult, len(job.Targets.DeviceIDs))
+	for i, deviceID := range job.Targets.DeviceIDs {
+		results[i] = model.FilePushResult{
+			ID:        "result-" + deviceID,
+			JobID:     jobID,
+			DeviceID:  deviceID,
+			Status:    model.FilePushStatusPending,
+			CreatedTs: mockTime,
+			UpdatedTs: mockTime,
+			ExpireTs:  job.ExpireTs,
+		}
+	}
+	err := ds.InsertFilePushJob(ctx, job, results)
+	require.NoError(t, err)
+
+	jobs, count, err := ds.GetFilePushJobs(ctx, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	if assert.Len(t, jobs, 1) {
+		assert.Nil(t, jobs[0].Content)
+	}
+	dbJob, err := ds.GetFilePushJob(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, job.Content, dbJob.Content)
+	_, err = ds.GetFilePushJob(ctx, "missing")
+	assert.Equal(t, store.ErrFilePushJobNotFound, err)
+
+	pending, err := ds.GetPendingFilePushResults(ctx, "dev-1")
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	// only one transfer can claim the result
+	result, err := ds.ClaimFilePushResult(ctx, "result-dev-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.FilePushStatusRunning, result.Status)
+	assert.Equal(t, 1, result.Attempts)
+	_, err = ds.ClaimFilePushResult(ctx, "result-dev-1")
+	assert.Equal(t, store.ErrFilePushNotPending, err)
+
+	// reschedule and claim again
+	err = ds.FinishFilePushResult(ctx, "result-dev-1",
+		model.FilePushStatusPending, "file transfer timed out")
+	require.NoError(t, err)
+	result, err = ds.ClaimFilePushResult(ctx, "result-dev-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Attempts)
+	err = ds.FinishFilePushResult(ctx, "result-dev-1",
+		model.FilePushStatusSuccess, "")
+	require.NoError(t, err)
+	err = ds.FinishFilePushResult(ctx, "result-dev-1",
+		model.FilePushStatusFailure, "late")
+	assert.Equal(t, store.ErrFilePushNotPending, err)
+
+	// a stale transfer is rescheduled
+	_, err = ds.ClaimFilePushResult(ctx, "result-dev-2")
+	require.NoError(t, err)
+	err = ds.ExpireFilePushResults(ctx, jobID, 0)
+	require.NoError(t, err)
+	res, count, err := ds.GetFilePushResults(ctx, model.FilePushResultsFilter{
+		JobID:  jobID,
+		Status: model.FilePushStatusPending,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "dev-2", res[0].DeviceID)
+	}
+
+	stats, err := ds.GetFilePushJobStats(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		model.FilePushStatusSuccess: 1,
+		model.FilePushStatusPending: 1,
+	}, stats)
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	mstore "github.com/mendersoftware/mender-server/pkg/store/v2"
+)
+
+type migration_2_2_0 struct {
+	client *mongo.Client
+	db     string
+}
+
+// Up creates the indexes for the file push jobs
+func (m *migration_2_2_0) Up(from migrate.Version) error {
+	if m.db != DbName {
+		return nil
+	}
+	ctx := context.Background()
+	database := m.client.Database(m.db)
+
+	_, err := database.Collection(FilePushesCollectionName).Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: mstore.FieldTenantID, Value: 1},
+				{Key: dbFieldCreatedTs, Value: -1},
+			},
+			Options: mopts.Index().
+				SetName(mstore.FieldTenantID + "_" + dbFieldCreatedTs),
+		})
+	if err != nil {
+		return err
+	}
+
+	_, err = database.Collection(FilePushResultsCollectionName).Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					{Key: mstore.FieldTenantID, Value: 1},
+					{Key: dbFieldJobID, Value: 1},
+					{Key: dbFieldDeviceID, Value: 1},
+				},
+				Options: mopts.Index().
+					SetName(mstore.FieldTenantID + "_" + dbFieldJobID +
+						"_" + dbFieldDeviceID),
+			},
+			{
+				Keys: bson.D{
+					{Key: mstore.FieldTenantID, Value: 1},
+					{Key: dbFieldDeviceID, Value: 1},
+					{Key: dbFieldStatus, Value: 1},
+				},
+				Options: mopts.Index().
+					SetName(mstore.FieldTenantID + "_" + dbFieldDeviceID +
+						"_" + dbFieldStatus),
+			},
+		})
+	return err
+}
+
+func (m *migration_2_2_0) Version() migrate.Version {
+	return migrate.MakeVersion(2, 2, 0)
+}
@@ -27,7 +27,7 @@ import (
 
 const (
 	// DbVersion is the current schema version
-	DbVersion = "2.0.1"
+	DbVersion = "2.2.0"
 
 	// DbName is the database name
 	DbName = "deviceconnect"
@@ -73,6 +73,14 @@ func Migrate(ctx context.Context,
 				client: client,
 				db:     dbName,
 			},
+			&migration_2_1_0{
+				client: client,
+				db:     dbName,
+			},
+			&migration_2_2_0{
+				client: client,
+				db:     dbName,
+			},
 			// NOTE: Future migrations need only be applied to DbName
 		}
 		err = m.Apply(ctx, *ver, migrations)
@@ -44,6 +44,8 @@ const (
 	uriDeviceTags       = "/devices/:id/tags"
 	uriDeviceGroups     = "/devices/:id/group"
 	uriDeviceGroup      = "/devices/:id/group/:name"
+	uriDeviceGroupList  = "/devices/:id/groups"
+	uriDeviceHistory    = "/devices/:id/attributes/history"
 	uriGroups           = "/groups"
 	uriGroupsName       = "/groups/:name"
 	uriGroupsDevices    = "/groups/:name/devices"
@@ -54,6 +56,7 @@ const (
 	uriInternalAlive         = "/alive"
 	uriInternalHealth        = "/health"
 	uriInternalTenants       = "/tenants"
+	uriInternalTenant        = "/tenants/:tenant_id"
 	uriInternalDevices       = "/tenants/:tenant_id/devices"
 	urlInternalDevicesStatus = "/tenants/:tenant_id/devices/status/:status"
 	uriInternalDeviceDetails = "/tenants/:tenant_id/devices/:device_id"
@@ -74,6 +77,10 @@ const (
 	queryParamGroup          = "group"
 	queryParamSort           = "sort"
 	queryParamHasGroup       = "has_group"
+	queryParamScope          = "scope"
+	queryParamName           = "name"
+	queryParamFrom           = "from"
+	queryParamTo             = "to"
 	queryParamValueSeparator = ":"
 	queryParamScopeSeparator = "/"
 	sortOrderAsc             = "asc"
@@ -323,6 +330,76 @@ func (i *ManagementAPI) GetDeviceHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, dev)
 }
 
+func parseTimeQueryParam(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Errorf("invalid %s query: must be a RFC3339 timestamp", name)
+	}
+	return &t, nil
+}
+
+func (i *ManagementAPI) GetDeviceAttributeHistoryHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	deviceID := c.Param("id")
+
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	filter := model.AttributeHistoryFilter{
+		Scope:   c.Query(queryParamScope),
+		Name:    c.Query(queryParamName),
+		Page:    int(page),
+		PerPage: int(perPage),
+	}
+	if filter.From, err = parseTimeQueryParam(c, queryParamFrom); err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	if filter.To, err = parseTimeQueryParam(c, queryParamTo); err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := filter.Validate(); err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	changes, totalCount, err := i.App.GetDeviceAttributeHistory(
+		ctx, model.DeviceID(deviceID), filter,
+	)
+	if err == store.ErrDevNotFound {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	hints := rest.NewPagingHints().
+		SetPage(page).
+		SetPerPage(perPage).
+		SetHasNext(totalCount > int(page*perPage)).
+		SetTotalCount(int64(totalCount))
+
+	links, err := rest.MakePagingHeaders(c.Request, hints)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	for _, l := range links {
+		c.Writer.Header().Add("Link", l)
+	}
+	c.Writer.Header().Add(hdrTotalCount, strconv.Itoa(totalCount))
+	c.JSON(http.StatusOK, changes)
+}
+
 func (i *ManagementAPI) DeleteDeviceInventoryHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -529,20 +606,46 @@ func (i *InternalAPI) PatchDeviceAttributesInternalHandler(
 		}
 		notModifiedAfter = &parsed
 	}
+	upsertAttrs := make(model.DeviceAttributes, 0, len(attrs))
+	removeAttrs := model.DeviceAttributes{}
 	for i := range attrs {
 		attrs[i].Scope = c.Param("scope")
-		if attrs[i].Name == checkInTimeParamName && attrs[i].Scope == checkInTimeParamScope {
+		if attrs[i].Name == model.AttrNameOfflineSince &&
+			attrs[i].Scope == model.AttrScopeMonitor &&
+			attrs[i].Value == "" {
+			// the device is back online
+			removeAttrs = append(removeAttrs, attrs[i])
+			continue
+		}
+		if (attrs[i].Name == checkInTimeParamName && attrs[i].Scope == checkInTimeParamScope) ||
+			(attrs[i].Name == model.AttrNameOfflineSince && attrs[i].Scope == model.AttrScopeMonitor) {
 			t, err := time.Parse(time.RFC3339, fmt.Sprintf("%v", attrs[i].Value))
 			if err != nil {
 				rest.RenderError(c, http.StatusBadRequest, err)
 				return
 			}
 			attrs[i].Value = t
+		} else if attrs[i].Name == model.AttrNameOffline && attrs[i].Scope == model.AttrScopeMonitor {
+			// the offline flag is stored as boolean like the other
+			// monitor attributes
+			offline, err := strconv.ParseBool(fmt.Sprintf("%v", attrs[i].Value))
+			if err != nil {
+				rest.RenderError(c, http.StatusBadRequest, err)
+				return
+			}
+			attrs[i].Value = offline
 		}
+		upsertAttrs = append(upsertAttrs, attrs[i])
 	}
 
 	//upsert the attributes
-	err = i.App.UpsertAttributes(ctx, model.DeviceID(deviceId), attrs, notModifiedAfter)
+	if len(removeAttrs) > 0 {
+		err = i.App.UpsertRemoveAttributes(ctx, model.DeviceID(deviceId),
+			upsertAttrs, removeAttrs)
+	} else {
+		err = i.App.UpsertAttributes(ctx, model.DeviceID(deviceId),
+			upsertAttrs, notModifiedAfter)
+	}
 	cause := errors.Cause(err)
 	switch cause {
 	case store.ErrNoAttrName:
@@ -840,6 +943,60 @@ func (i *ManagementAPI) GetDeviceGroupHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, ret)
 }
 
+func (i *ManagementAPI) GetDeviceGroupsHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	deviceID := c.Param("id")
+
+	groups, err := i.App.GetDeviceGroups(ctx, model.DeviceID(deviceID))
+	if err == store.ErrDevNotFound {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	res := model.DeviceGroups{Groups: []string{}}
+	for _, group := range groups {
+		res.Groups = append(res.Groups, string(group))
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (i *ManagementAPI) SetDeviceGroupsHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	deviceID := c.Param("id")
+
+	var req model.DeviceGroups
+	if err := c.ShouldBindJSON(&req); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "failed to decode device groups data"))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	groups := make([]model.GroupName, len(req.Groups))
+	for i, group := range req.Groups {
+		groups[i] = model.GroupName(group)
+	}
+	err := i.App.SetDeviceGroups(ctx, model.DeviceID(deviceID), groups)
+	if err == store.ErrDevNotFound {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 type newTenantRequest struct {
 	TenantID string `json:"tenant_id" valid:"required"`
 }
@@ -875,6 +1032,18 @@ func (i *InternalAPI) CreateTenantHandler(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+func (i *InternalAPI) DeleteTenantHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := i.App.DeleteTenant(ctx, c.Param("tenant_id"))
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (i *ManagementAPI) FiltersAttributesHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -1045,7 +1214,7 @@ func (i *InternalAPI) GetDeviceGroupsInternalHandler(c *gin.Context) {
 	ctx = getTenantContext(ctx, tenantId)
 
 	deviceID := c.Param("device_id")
-	group, err := i.App.GetDeviceGroup(ctx, model.DeviceID(deviceID))
+	groups, err := i.App.GetDeviceGroups(ctx, model.DeviceID(deviceID))
 	if err != nil {
 		if err == store.ErrDevNotFound {
 			rest.RenderError(c,
@@ -1062,7 +1231,7 @@ func (i *InternalAPI) GetDeviceGroupsInternalHandler(c *gin.Context) {
 	}
 
 	res := model.DeviceGroups{}
-	if group != "" {
+	for _, group := range groups {
 		res.Groups = append(res.Groups, string(group))
 	}
 
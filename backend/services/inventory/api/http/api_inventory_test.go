@@ -1603,6 +1603,36 @@ func TestApiInventoryUpsertAttributesInternal(t *testing.T) {
 			},
 		},
 
+		"body formatted ok, monitor scope offline attributes": {
+			tenantId: "3456355",
+			deviceId: "sdfg435fgs-gs-dgsfgdfs-3456dgsf",
+			scope:    model.AttrScopeMonitor,
+
+			payload: []model.DeviceAttribute{
+				{
+					Name:  model.AttrNameOffline,
+					Value: "true",
+				},
+				{
+					Name:  model.AttrNameOfflineSince,
+					Value: "2026-10-01T12:00:00Z",
+				},
+			},
+			inventoryErr: nil,
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusOK,
+				OutputBodyObject: nil,
+			},
+			deviceAttributes: model.DeviceAttributes{
+				{Name: model.AttrNameOffline, Value: true, Scope: model.AttrScopeMonitor},
+				{
+					Name:  model.AttrNameOfflineSince,
+					Value: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+					Scope: model.AttrScopeMonitor,
+				},
+			},
+		},
+
 		"body formatted ok, attributes ok (all fields, arrays)": {
 			tenantId: "3456355",
 			deviceId: "sdfg435fgs-gs-dgsfgdfs-3456dgsf",
@@ -1745,6 +1775,42 @@ func TestApiInventoryUpsertAttributesInternal(t *testing.T) {
 	}
 }
 
+func TestApiInventoryUpsertAttributesInternalBackOnline(t *testing.T) {
+	t.Parallel()
+
+	req := rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: "PATCH",
+		Path: "http://localhost/api/internal/v1/inventory/tenants/tenant" +
+			"/device/device/attribute/scope/" + model.AttrScopeMonitor,
+		Body: []model.DeviceAttribute{
+			{Name: model.AttrNameOffline, Value: "false"},
+			{Name: model.AttrNameOfflineSince, Value: ""},
+		},
+	})
+
+	inv := &minventory.InventoryApp{}
+	defer inv.AssertExpectations(t)
+	inv.On("UpsertRemoveAttributes",
+		contextMatcher(),
+		model.DeviceID("device"),
+		model.DeviceAttributes{{
+			Name:  model.AttrNameOffline,
+			Value: false,
+			Scope: model.AttrScopeMonitor,
+		}},
+		model.DeviceAttributes{{
+			Name:  model.AttrNameOfflineSince,
+			Value: "",
+			Scope: model.AttrScopeMonitor,
+		}},
+	).Return(nil)
+
+	apih := makeMockApiHandler(t, inv)
+	runTestRequest(t, apih, req, JSONResponseParams{
+		OutputStatus: http.StatusOK,
+	})
+}
+
 func TestApiInventoryDeleteDeviceGroup(t *testing.T) {
 
 	tcases := map[string]struct {
@@ -1863,8 +1929,10 @@ func TestApiInventoryAddDeviceToGroup(t *testing.T) {
 				Body:   InventoryApiGroup{"__+X@#$  ;"},
 			}),
 			JSONResponseParams: JSONResponseParams{
-				OutputStatus:     http.StatusBadRequest,
-				OutputBodyObject: RestError("Group name can only contain: upper/lowercase alphanum, -(dash), _(underscore)"),
+				OutputStatus: http.StatusBadRequest,
+				OutputBodyObject: RestError("Group name can only contain: " +
+					"upper/lowercase alphanum, -(dash), _(underscore) " +
+					"and /(slash) separating the names of nested groups"),
 			},
 			inventoryErr: nil,
 		},
@@ -1876,8 +1944,10 @@ func TestApiInventoryAddDeviceToGroup(t *testing.T) {
 				Body:   InventoryApiGroup{"ęą"},
 			}),
 			JSONResponseParams: JSONResponseParams{
-				OutputStatus:     http.StatusBadRequest,
-				OutputBodyObject: RestError("Group name can only contain: upper/lowercase alphanum, -(dash), _(underscore)"),
+				OutputStatus: http.StatusBadRequest,
+				OutputBodyObject: RestError("Group name can only contain: " +
+					"upper/lowercase alphanum, -(dash), _(underscore) " +
+					"and /(slash) separating the names of nested groups"),
 			},
 			inventoryErr: nil,
 		},
@@ -2323,27 +2393,41 @@ func TestApiGetDeviceGroupInternal(t *testing.T) {
 
 		inReq *http.Request
 
-		inventoryGroup model.GroupName
-		inventoryErr   error
+		inventoryGroups []model.GroupName
+		inventoryErr    error
 	}{
 		"device with group": {
 			inReq: rtest.MakeTestRequest(&rtest.TestRequest{
 				Method: "GET",
 				Path:   "http://localhost/api/internal/v1/inventory/tenants/foo/devices/1/groups",
 			}),
-			inventoryGroup: model.GroupName("dev"),
-			inventoryErr:   nil,
+			inventoryGroups: []model.GroupName{"dev"},
+			inventoryErr:    nil,
 
 			JSONResponseParams: JSONResponseParams{
 				OutputStatus:     http.StatusOK,
 				OutputBodyObject: model.DeviceGroups{Groups: []string{"dev"}},
 			},
 		},
+		"device with multiple groups": {
+			inReq: rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path:   "http://localhost/api/internal/v1/inventory/tenants/foo/devices/1/groups",
+			}),
+			inventoryGroups: []model.GroupName{"europe/norway", "rev-b"},
+
+			JSONResponseParams: JSONResponseParams{
+				OutputStatus: http.StatusOK,
+				OutputBodyObject: model.DeviceGroups{
+					Groups: []string{"europe/norway", "rev-b"},
+				},
+			},
+		},
 		"device without group": {
 			inReq: rtest.MakeTestRequest(&rtest.TestRequest{
 				Method: "GET",
 				Path:   "http://localhost/api/internal/v1/inventory/tenants/foo/devices/1/groups",
-			}), inventoryGroup: model.GroupName(""),
+			}), inventoryGroups: []model.GroupName{},
 			inventoryErr: nil,
 
 			JSONResponseParams: JSONResponseParams{
@@ -2355,7 +2439,7 @@ func TestApiGetDeviceGroupInternal(t *testing.T) {
 			inReq: rtest.MakeTestRequest(&rtest.TestRequest{
 				Method: "GET",
 				Path:   "http://localhost/api/internal/v1/inventory/tenants/foo/devices/1/groups",
-			}), inventoryGroup: model.GroupName(""),
+			}),
 			inventoryErr: store.ErrDevNotFound,
 
 			JSONResponseParams: JSONResponseParams{
@@ -2367,7 +2451,7 @@ func TestApiGetDeviceGroupInternal(t *testing.T) {
 			inReq: rtest.MakeTestRequest(&rtest.TestRequest{
 				Method: "GET",
 				Path:   "http://localhost/api/internal/v1/inventory/tenants/foo/devices/1/groups",
-			}), inventoryGroup: model.GroupName(""),
+			}),
 			inventoryErr: errors.New("inventory: internal error"),
 
 			JSONResponseParams: JSONResponseParams{
@@ -2383,9 +2467,9 @@ func TestApiGetDeviceGroupInternal(t *testing.T) {
 
 		ctx := contextMatcher()
 
-		inv.On("GetDeviceGroup",
+		inv.On("GetDeviceGroups",
 			ctx,
-			mock.AnythingOfType("model.DeviceID")).Return(tc.inventoryGroup, tc.inventoryErr)
+			mock.AnythingOfType("model.DeviceID")).Return(tc.inventoryGroups, tc.inventoryErr)
 
 		apih := makeMockApiHandler(t, &inv)
 
@@ -2575,7 +2659,8 @@ func TestAPICDeleteGroup(t *testing.T) {
 			OutputStatus: http.StatusBadRequest,
 			OutputBodyObject: map[string]interface{}{
 				"error": "Group name can only contain: upper/lowercase " +
-					"alphanum, -(dash), _(underscore)",
+					"alphanum, -(dash), _(underscore) and /(slash) " +
+					"separating the names of nested groups",
 				"request_id": "test",
 			},
 		},
@@ -2699,7 +2784,8 @@ func TestAPIClearDevicesGroup(t *testing.T) {
 			OutputStatus: http.StatusBadRequest,
 			OutputBodyObject: map[string]interface{}{
 				"error": "Group name can only contain: upper/lowercase " +
-					"alphanum, -(dash), _(underscore)",
+					"alphanum, -(dash), _(underscore) and /(slash) " +
+					"separating the names of nested groups",
 				"request_id": "test",
 			},
 		},
@@ -2807,7 +2893,8 @@ func TestAPIPatchGroupDevices(t *testing.T) {
 			OutputBodyObject: map[string]interface{}{
 				"error": "Group name can only contain: " +
 					"upper/lowercase alphanum, " +
-					"-(dash), _(underscore)",
+					"-(dash), _(underscore) and /(slash) " +
+					"separating the names of nested groups",
 				"request_id": "test",
 			},
 		},
@@ -2939,6 +3026,47 @@ func TestUserAdmApiCreateTenant(t *testing.T) {
 	}
 }
 
+func TestApiDeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		appError error
+
+		res JSONResponseParams
+	}{
+		"ok": {
+			res: JSONResponseParams{
+				OutputStatus: http.StatusNoContent,
+			},
+		},
+		"error: internal": {
+			appError: errors.New("some internal error"),
+
+			res: JSONResponseParams{
+				OutputStatus:     http.StatusInternalServerError,
+				OutputBodyObject: RestError("internal error"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(fmt.Sprintf("tc %s", name), func(t *testing.T) {
+			inv := &minventory.InventoryApp{}
+			defer inv.AssertExpectations(t)
+			inv.On("DeleteTenant", contextMatcher(), "foobar").Return(tc.appError)
+
+			api := makeMockApiHandler(t, inv)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodDelete,
+				Path:   "http://localhost/api/internal/v1/inventory/tenants/foobar",
+			})
+
+			runTestRequest(t, api, req, tc.res)
+		})
+	}
+}
+
 func TestApiInventoryInternalDevicesStatus(t *testing.T) {
 	t.Parallel()
 
@@ -4189,3 +4317,260 @@ func TestApiInventoryInternalReindex(t *testing.T) {
 		})
 	}
 }
+
+func TestApiGetDeviceAttributeHistory(t *testing.T) {
+	t.Parallel()
+
+	changes := []model.AttributeChange{{
+		Scope:     model.AttrScopeInventory,
+		Name:      "artifact_name",
+		OldValue:  "release-1",
+		NewValue:  "release-2",
+		Timestamp: *timePtr("2026-01-02T10:00:00Z"),
+	}}
+	path := "http://localhost" + apiUrlManagementV1 + "/devices/foo/attributes/history"
+
+	testCases := map[string]struct {
+		query      string
+		filter     *model.AttributeHistoryFilter
+		history    []model.AttributeChange
+		count      int
+		historyErr error
+		resp       JSONResponseParams
+	}{
+		"ok": {
+			query: "?scope=inventory&name=artifact_name" +
+				"&from=2026-01-01T00:00:00Z&to=2026-01-03T00:00:00Z",
+			filter: &model.AttributeHistoryFilter{
+				Scope:   model.AttrScopeInventory,
+				Name:    "artifact_name",
+				From:    timePtr("2026-01-01T00:00:00Z"),
+				To:      timePtr("2026-01-03T00:00:00Z"),
+				Page:    1,
+				PerPage: 20,
+			},
+			history: changes,
+			count:   1,
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusOK,
+				OutputBodyObject: changes,
+				OutputHeaders: map[string][]string{
+					hdrTotalCount: {"1"},
+				},
+			},
+		},
+		"error, invalid from": {
+			query: "?from=yesterday",
+			resp: JSONResponseParams{
+				OutputStatus: http.StatusBadRequest,
+				OutputBodyObject: RestError(
+					"invalid from query: must be a RFC3339 timestamp",
+				),
+			},
+		},
+		"error, device not found": {
+			filter:     &model.AttributeHistoryFilter{Page: 1, PerPage: 20},
+			count:      -1,
+			historyErr: store.ErrDevNotFound,
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusNotFound,
+				OutputBodyObject: RestError(store.ErrDevNotFound.Error()),
+			},
+		},
+		"error, internal": {
+			filter:     &model.AttributeHistoryFilter{Page: 1, PerPage: 20},
+			count:      -1,
+			historyErr: errors.New("db connection failed"),
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusInternalServerError,
+				OutputBodyObject: RestError("internal error"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			inv := &minventory.InventoryApp{}
+			defer inv.AssertExpectations(t)
+			if tc.filter != nil {
+				inv.On("GetDeviceAttributeHistory",
+					contextMatcher(),
+					model.DeviceID("foo"),
+					*tc.filter,
+				).Return(tc.history, tc.count, tc.historyErr)
+			}
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path:   path + tc.query,
+				Auth:   true,
+			})
+			runTestRequest(t, makeMockApiHandler(t, inv), req, tc.resp)
+		})
+	}
+}
+
+func TestApiGetDeviceGroups(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		groups    []model.GroupName
+		groupsErr error
+		resp      JSONResponseParams
+	}{
+		"ok": {
+			groups: []model.GroupName{"europe/norway", "rev-b"},
+			resp: JSONResponseParams{
+				OutputStatus: http.StatusOK,
+				OutputBodyObject: model.DeviceGroups{
+					Groups: []string{"europe/norway", "rev-b"},
+				},
+			},
+		},
+		"ok, no groups": {
+			groups: []model.GroupName{},
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusOK,
+				OutputBodyObject: model.DeviceGroups{Groups: []string{}},
+			},
+		},
+		"error, device not found": {
+			groupsErr: store.ErrDevNotFound,
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusNotFound,
+				OutputBodyObject: RestError(store.ErrDevNotFound.Error()),
+			},
+		},
+		"error, internal": {
+			groupsErr: errors.New("db connection failed"),
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusInternalServerError,
+				OutputBodyObject: RestError("internal error"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			inv := &minventory.InventoryApp{}
+			defer inv.AssertExpectations(t)
+			inv.On("GetDeviceGroups",
+				contextMatcher(),
+				model.DeviceID("foo"),
+			).Return(tc.groups, tc.groupsErr)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path:   "http://localhost" + apiUrlManagementV1 + "/devices/foo/groups",
+				Auth:   true,
+			})
+			runTestRequest(t, makeMockApiHandler(t, inv), req, tc.resp)
+		})
+	}
+}
+
+func TestApiSetDeviceGroups(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		body      interface{}
+		groups    []model.GroupName
+		groupsErr error
+		resp      JSONResponseParams
+	}{
+		"ok": {
+			body:   model.DeviceGroups{Groups: []string{"europe/norway", "rev-b"}},
+			groups: []model.GroupName{"europe/norway", "rev-b"},
+			resp: JSONResponseParams{
+				OutputStatus: http.StatusNoContent,
+			},
+		},
+		"ok, remove from all groups": {
+			body:   model.DeviceGroups{Groups: []string{}},
+			groups: []model.GroupName{},
+			resp: JSONResponseParams{
+				OutputStatus: http.StatusNoContent,
+			},
+		},
+		"error, duplicate group": {
+			body: model.DeviceGroups{Groups: []string{"rev-b", "rev-b"}},
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusBadRequest,
+				OutputBodyObject: RestError("duplicate group: rev-b"),
+			},
+		},
+		"error, invalid group": {
+			body: model.DeviceGroups{Groups: []string{"europe//norway"}},
+			resp: JSONResponseParams{
+				OutputStatus: http.StatusBadRequest,
+				OutputBodyObject: RestError("groups: (0: Group name can only contain: " +
+					"upper/lowercase alphanum, -(dash), _(underscore) " +
+					"and /(slash) separating the names of nested groups.)."),
+			},
+		},
+		"error, device not found": {
+			body:      model.DeviceGroups{Groups: []string{"rev-b"}},
+			groups:    []model.GroupName{"rev-b"},
+			groupsErr: store.ErrDevNotFound,
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusNotFound,
+				OutputBodyObject: RestError(store.ErrDevNotFound.Error()),
+			},
+		},
+		"error, internal": {
+			body:      model.DeviceGroups{Groups: []string{"rev-b"}},
+			groups:    []model.GroupName{"rev-b"},
+			groupsErr: errors.New("db connection failed"),
+			resp: JSONResponseParams{
+				OutputStatus:     http.StatusInternalServerError,
+				OutputBodyObject: RestError("internal error"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			inv := &minventory.InventoryApp{}
+			defer inv.AssertExpectations(t)
+			if tc.groups != nil {
+				inv.On("SetDeviceGroups",
+					contextMatcher(),
+					model.DeviceID("foo"),
+					tc.groups,
+				).Return(tc.groupsErr)
+			}
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "PUT",
+				Path:   "http://localhost" + apiUrlManagementV1 + "/devices/foo/groups",
+				Auth:   true,
+				Body:   tc.body,
+			})
+			runTestRequest(t, makeMockApiHandler(t, inv), req, tc.resp)
+		})
+	}
+}
+
+func TestApiGetDevicesByNestedGroup(t *testing.T) {
+	t.Parallel()
+
+	inv := &minventory.InventoryApp{}
+	defer inv.AssertExpectations(t)
+	inv.On("ListDevicesByGroup",
+		contextMatcher(),
+		model.GroupName("europe/norway"),
+		0,
+		20,
+	).Return(mockListDeviceIDs(2), 2, nil)
+
+	req := rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: "GET",
+		Path: "http://localhost" + apiUrlManagementV1 + uriGroups +
+			"/europe%2Fnorway/devices",
+		Auth: true,
+	})
+	runTestRequest(t, makeMockApiHandler(t, inv), req, JSONResponseParams{
+		OutputStatus:     http.StatusOK,
+		OutputBodyObject: mockListDeviceIDs(2),
+	})
+}
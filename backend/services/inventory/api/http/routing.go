@@ -106,6 +106,9 @@ func NewRouter(app inv.InventoryApp, options ...Option) http.Handler {
 	}
 
 	router := routing.NewGinRouter()
+	// nested group names contain slashes, which must be escaped in the
+	// path parameters
+	router.UseRawPath = true
 	router.Use(requestsize.Middleware(config.MaxRequestSize))
 
 	mgmtHandler := NewManagementHandler(app)
@@ -123,6 +126,8 @@ func NewRouter(app inv.InventoryApp, options ...Option) http.Handler {
 	mgmtAPIV1.GET(uriDevices, mgmtHandler.GetDevicesHandler)
 	mgmtAPIV1.GET(uriDevice, mgmtHandler.GetDeviceHandler)
 	mgmtAPIV1.GET(uriDeviceGroups, mgmtHandler.GetDeviceGroupHandler)
+	mgmtAPIV1.GET(uriDeviceGroupList, mgmtHandler.GetDeviceGroupsHandler)
+	mgmtAPIV1.GET(uriDeviceHistory, mgmtHandler.GetDeviceAttributeHistoryHandler)
 	mgmtAPIV1.GET(uriGroups, mgmtHandler.GetGroupsHandler)
 	mgmtAPIV1.GET(uriGroupsDevices, mgmtHandler.GetDevicesByGroupHandler)
 	mgmtAPIV1.DELETE(uriDevice, mgmtHandler.DeleteDeviceInventoryHandler)
@@ -131,6 +136,7 @@ func NewRouter(app inv.InventoryApp, options ...Option) http.Handler {
 	mgmtAPIV1.DELETE(uriGroupsDevices, mgmtHandler.ClearDevicesGroupHandler)
 	mgmtAPIV1.Group(".").Use(contenttype.CheckJSON()).
 		PUT(uriDeviceGroups, mgmtHandler.AddDeviceToGroupHandler).
+		PUT(uriDeviceGroupList, mgmtHandler.SetDeviceGroupsHandler).
 		PATCH(uriGroupsDevices, mgmtHandler.AppendDevicesToGroup).
 		PUT(uriDeviceTags, mgmtHandler.UpdateDeviceTagsHandler).
 		PATCH(uriDeviceTags, mgmtHandler.UpdateDeviceTagsHandler)
@@ -143,6 +149,8 @@ func NewRouter(app inv.InventoryApp, options ...Option) http.Handler {
 	mgmtAPIV1Legacy.GET(uriDevices, mgmtHandler.GetDevicesHandler)
 	mgmtAPIV1Legacy.GET(uriDevice, mgmtHandler.GetDeviceHandler)
 	mgmtAPIV1Legacy.GET(uriDeviceGroups, mgmtHandler.GetDeviceGroupHandler)
+	mgmtAPIV1Legacy.GET(uriDeviceGroupList, mgmtHandler.GetDeviceGroupsHandler)
+	mgmtAPIV1Legacy.GET(uriDeviceHistory, mgmtHandler.GetDeviceAttributeHistoryHandler)
 	mgmtAPIV1Legacy.GET(uriGroups, mgmtHandler.GetGroupsHandler)
 	mgmtAPIV1Legacy.GET(uriGroupsDevices, mgmtHandler.GetDevicesByGroupHandler)
 	mgmtAPIV1Legacy.DELETE(uriDevice, mgmtHandler.DeleteDeviceInventoryHandler)
@@ -152,6 +160,7 @@ func NewRouter(app inv.InventoryApp, options ...Option) http.Handler {
 
 	mgmtAPIV1Legacy.Group(".").Use(contenttype.CheckJSON()).
 		PUT(uriDeviceGroups, mgmtHandler.AddDeviceToGroupHandler).
+		PUT(uriDeviceGroupList, mgmtHandler.SetDeviceGroupsHandler).
 		PATCH(uriGroupsDevices, mgmtHandler.AppendDevicesToGroup).
 		PUT(uriDeviceTags, mgmtHandler.UpdateDeviceTagsHandler).
 		PATCH(uriDeviceTags, mgmtHandler.UpdateDeviceTagsHandler)
@@ -177,6 +186,7 @@ func NewRouter(app inv.InventoryApp, options ...Option) http.Handler {
 	intrnlAPIV1.PATCH(urlInternalAttributes, intrnlHandler.PatchDeviceAttributesInternalHandler)
 	intrnlAPIV1.POST(urlInternalReindex, intrnlHandler.ReindexDeviceDataHandler)
 	intrnlAPIV1.POST(uriInternalTenants, intrnlHandler.CreateTenantHandler)
+	intrnlAPIV1.DELETE(uriInternalTenant, intrnlHandler.DeleteTenantHandler)
 
 	intrnlAPIV1.POST(uriInternalDevices, intrnlHandler.AddDeviceHandler)
 	intrnlAPIV1.DELETE(uriInternalDeviceDetails, intrnlHandler.DeleteDeviceHandler)
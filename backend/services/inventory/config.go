@@ -45,6 +45,17 @@ const (
 	SettingEnableReporting        = "enable_reporting"
 	SettingEnableReportingDefault = false
 
+	SettingAttributeHistoryEnabled        = "attribute_history_enabled"
+	SettingAttributeHistoryEnabledDefault = false
+
+	// Number of days the attribute changes are kept in the history
+	SettingAttributeHistoryRetention        = "attribute_history_retention"
+	SettingAttributeHistoryRetentionDefault = 30
+
+	// Maximum number of attributes tracked in the history per device
+	SettingAttributeHistoryLimit        = "attribute_history_limit"
+	SettingAttributeHistoryLimitDefault = 100
+
 	SettingOrchestratorAddr        = "orchestrator_addr"
 	SettingOrchestratorAddrDefault = "http://mender-workflows-server:8080"
 
@@ -63,6 +74,9 @@ var (
 		{Key: SettingLimitTags, Value: SettingLimitTagsDefault},
 		{Key: SettingDevicemonitorAddr, Value: SettingDevicemonitorAddrDefault},
 		{Key: SettingEnableReporting, Value: SettingEnableReportingDefault},
+		{Key: SettingAttributeHistoryEnabled, Value: SettingAttributeHistoryEnabledDefault},
+		{Key: SettingAttributeHistoryRetention, Value: SettingAttributeHistoryRetentionDefault},
+		{Key: SettingAttributeHistoryLimit, Value: SettingAttributeHistoryLimitDefault},
 		{Key: SettingOrchestratorAddr, Value: SettingOrchestratorAddrDefault},
 		{Key: SettingMaxRequestSize, Value: SettingMaxRequestSizeDefault},
 	}
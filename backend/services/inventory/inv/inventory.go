@@ -69,6 +69,12 @@ type InventoryApp interface {
 		scope string,
 		etag string,
 	) error
+	UpsertRemoveAttributes(
+		ctx context.Context,
+		id model.DeviceID,
+		upsertAttrs model.DeviceAttributes,
+		removeAttrs model.DeviceAttributes,
+	) error
 	GetFiltersAttributes(ctx context.Context) ([]model.FilterAttribute, error)
 	DeleteGroup(ctx context.Context, groupName model.GroupName) (*model.UpdateResult, error)
 	UnsetDeviceGroup(ctx context.Context, id model.DeviceID, groupName model.GroupName) error
@@ -91,16 +97,25 @@ type InventoryApp interface {
 		limit int,
 	) ([]model.DeviceID, int, error)
 	GetDeviceGroup(ctx context.Context, id model.DeviceID) (model.GroupName, error)
+	GetDeviceGroups(ctx context.Context, id model.DeviceID) ([]model.GroupName, error)
+	SetDeviceGroups(ctx context.Context, id model.DeviceID, groups []model.GroupName) error
 	DeleteDevice(ctx context.Context, id model.DeviceID) error
 	DeleteDevices(
 		ctx context.Context,
 		ids []model.DeviceID,
 	) (*model.UpdateResult, error)
 	CreateTenant(ctx context.Context, tenant model.NewTenant) error
+	DeleteTenant(ctx context.Context, tenantID string) error
 	SearchDevices(ctx context.Context, searchParams model.SearchParams) ([]model.Device, int, error)
 	CheckAlerts(ctx context.Context, deviceId string) (int, error)
 	WithLimits(attributes, tags int) InventoryApp
 	WithDevicemonitor(client devicemonitor.Client) InventoryApp
+	WithAttributeHistory(retention time.Duration, limit int) InventoryApp
+	GetDeviceAttributeHistory(
+		ctx context.Context,
+		id model.DeviceID,
+		filter model.AttributeHistoryFilter,
+	) ([]model.AttributeChange, int, error)
 }
 
 type inventory struct {
@@ -110,6 +125,10 @@ type inventory struct {
 	dmClient        devicemonitor.Client
 	enableReporting bool
 	wfClient        workflows.Client
+
+	enableHistory    bool
+	historyRetention time.Duration
+	historyLimit     int
 }
 
 func NewInventory(d store.DataStore) InventoryApp {
@@ -133,6 +152,16 @@ func (i *inventory) WithReporting(client workflows.Client) InventoryApp {
 	return i
 }
 
+// WithAttributeHistory enables recording the changes of the device
+// attributes; the changes are kept for the retention period and at most
+// limit attributes are tracked per device (0 means no limit).
+func (i *inventory) WithAttributeHistory(retention time.Duration, limit int) InventoryApp {
+	i.enableHistory = true
+	i.historyRetention = retention
+	i.historyLimit = limit
+	return i
+}
+
 func (i *inventory) HealthCheck(ctx context.Context) error {
 	err := i.db.Ping(ctx)
 	if err != nil {
@@ -193,6 +222,7 @@ func (i *inventory) DeleteDevices(
 	if err != nil {
 		return nil, err
 	}
+	i.deleteAttributeHistory(ctx, ids)
 
 	if i.enableReporting {
 		for _, d := range ids {
@@ -210,6 +240,7 @@ func (i *inventory) DeleteDevice(ctx context.Context, id model.DeviceID) error {
 	} else if res.DeletedCount < 1 {
 		return store.ErrDevNotFound
 	}
+	i.deleteAttributeHistory(ctx, []model.DeviceID{id})
 	i.maybeTriggerReindex(ctx, []model.DeviceID{id})
 
 	return nil
@@ -221,6 +252,15 @@ func (i *inventory) UpsertAttributes(
 	attrs model.DeviceAttributes,
 	notModifiedAfter *time.Time,
 ) error {
+	var device *model.Device
+	if i.tracksAttributes(attrs) {
+		var err error
+		device, err = i.db.GetDevice(ctx, id)
+		if err != nil && err != store.ErrDevNotFound {
+			return errors.Wrap(err, "failed to get the device")
+		}
+	}
+
 	res, err := i.db.UpsertDevicesAttributes(
 		ctx, []model.DeviceID{id}, attrs, notModifiedAfter,
 	)
@@ -228,6 +268,7 @@ func (i *inventory) UpsertAttributes(
 		return errors.Wrap(err, "failed to upsert attributes in db")
 	}
 	if res != nil && res.MatchedCount > 0 {
+		i.recordAttributeChanges(ctx, id, device, attrs, nil)
 		i.reindexTextField(ctx, res.Devices)
 		i.maybeTriggerReindex(ctx, []model.DeviceID{id})
 	}
@@ -371,6 +412,7 @@ func (i *inventory) UpsertAttributesWithUpdated(
 	}
 
 	if res != nil && res.MatchedCount > 0 {
+		i.recordAttributeChanges(ctx, id, device, attrs, nil)
 		i.reindexTextField(ctx, res.Devices)
 		i.maybeTriggerReindex(ctx, []model.DeviceID{id})
 	}
@@ -440,12 +482,147 @@ func (i *inventory) ReplaceAttributes(
 		}
 	}
 	if res != nil && res.MatchedCount > 0 {
+		i.recordAttributeChanges(ctx, id, device, upsertAttrs, removeAttrs)
 		i.reindexTextField(ctx, res.Devices)
 		i.maybeTriggerReindex(ctx, []model.DeviceID{id})
 	}
 	return nil
 }
 
+// UpsertRemoveAttributes upserts some attributes of the device and removes
+// others, e.g. the attributes cleared by the internal services.
+func (i *inventory) UpsertRemoveAttributes(
+	ctx context.Context,
+	id model.DeviceID,
+	upsertAttrs model.DeviceAttributes,
+	removeAttrs model.DeviceAttributes,
+) error {
+	var device *model.Device
+	if i.tracksAttributes(upsertAttrs) || i.tracksAttributes(removeAttrs) {
+		var err error
+		device, err = i.db.GetDevice(ctx, id)
+		if err != nil && err != store.ErrDevNotFound {
+			return errors.Wrap(err, "failed to get the device")
+		}
+	}
+
+	res, err := i.db.UpsertRemoveDeviceAttributes(ctx, id, upsertAttrs, removeAttrs, "", "")
+	if err != nil {
+		return errors.Wrap(err, "failed to upsert attributes in db")
+	}
+	if res != nil && res.MatchedCount > 0 {
+		i.recordAttributeChanges(ctx, id, device, upsertAttrs, removeAttrs)
+		i.reindexTextField(ctx, res.Devices)
+		i.maybeTriggerReindex(ctx, []model.DeviceID{id})
+	}
+	return nil
+}
+
+// attributeHistoryScopes are the scopes of the attributes recorded in the
+// attribute history; the system and monitor attributes change on every
+// check-in and are left out.
+var attributeHistoryScopes = map[string]bool{
+	model.AttrScopeInventory: true,
+	model.AttrScopeIdentity:  true,
+	model.AttrScopeTags:      true,
+}
+
+func (i *inventory) tracksAttributes(attrs model.DeviceAttributes) bool {
+	if !i.enableHistory {
+		return false
+	}
+	for _, attr := range attrs {
+		if attributeHistoryScopes[attr.Scope] {
+			return true
+		}
+	}
+	return false
+}
+
+// recordAttributeChanges saves the changes of the attributes of the device
+// in the attribute history; failing to do so does not fail the update of the
+// attributes.
+func (i *inventory) recordAttributeChanges(
+	ctx context.Context,
+	id model.DeviceID,
+	device *model.Device,
+	upsertAttrs model.DeviceAttributes,
+	removeAttrs model.DeviceAttributes,
+) {
+	if !i.enableHistory {
+		return
+	}
+	now := time.Now()
+	changes := []model.AttributeChange{}
+	for _, change := range model.DiffAttributes(id, device, upsertAttrs, removeAttrs, now) {
+		if attributeHistoryScopes[change.Scope] {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	l := log.FromContext(ctx)
+	if i.historyLimit > 0 {
+		keys, err := i.db.GetTrackedAttributes(ctx, id)
+		if err != nil {
+			l.Errorf("failed to get the tracked attributes of the device %s: %v", id, err)
+			return
+		}
+		tracked := make(map[model.AttributeKey]bool, len(keys))
+		for _, key := range keys {
+			tracked[key] = true
+		}
+		limited := []model.AttributeChange{}
+		for _, change := range changes {
+			if !tracked[change.Key()] {
+				if len(tracked) >= i.historyLimit {
+					continue
+				}
+				tracked[change.Key()] = true
+			}
+			limited = append(limited, change)
+		}
+		changes = limited
+	}
+	for j := range changes {
+		changes[j].ExpireTs = now.Add(i.historyRetention)
+	}
+
+	if err := i.db.InsertAttributeChanges(ctx, changes); err != nil {
+		l.Errorf("failed to save the attribute history of the device %s: %v", id, err)
+	}
+}
+
+func (i *inventory) deleteAttributeHistory(ctx context.Context, ids []model.DeviceID) {
+	if !i.enableHistory {
+		return
+	}
+	if err := i.db.DeleteAttributeHistory(ctx, ids); err != nil {
+		log.FromContext(ctx).
+			Errorf("failed to delete the attribute history of the devices: %v", err)
+	}
+}
+
+func (i *inventory) GetDeviceAttributeHistory(
+	ctx context.Context,
+	id model.DeviceID,
+	filter model.AttributeHistoryFilter,
+) ([]model.AttributeChange, int, error) {
+	dev, err := i.db.GetDevice(ctx, id)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "failed to fetch device")
+	} else if dev == nil {
+		return nil, -1, store.ErrDevNotFound
+	}
+	changes, count, err := i.db.GetAttributeHistory(ctx, id, filter)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "failed to fetch attribute history")
+	}
+	return changes, count, nil
+}
+
 func (i *inventory) GetFiltersAttributes(ctx context.Context) ([]model.FilterAttribute, error) {
 	attributes, err := i.db.GetFiltersAttributes(ctx)
 	if err != nil {
@@ -629,6 +806,40 @@ func (i *inventory) GetDeviceGroup(
 	return group, nil
 }
 
+// GetDeviceGroups returns all the groups the device is a member of,
+// starting with its primary group.
+func (i *inventory) GetDeviceGroups(
+	ctx context.Context,
+	id model.DeviceID,
+) ([]model.GroupName, error) {
+	dev, err := i.db.GetDevice(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get device's groups")
+	} else if dev == nil {
+		return nil, store.ErrDevNotFound
+	}
+	return dev.GetGroups(), nil
+}
+
+// SetDeviceGroups replaces the groups of the device; the first group
+// becomes the primary group of the device.
+func (i *inventory) SetDeviceGroups(
+	ctx context.Context,
+	id model.DeviceID,
+	groups []model.GroupName,
+) error {
+	result, err := i.db.SetDeviceGroups(ctx, id, groups)
+	if err != nil {
+		return errors.Wrap(err, "failed to set device's groups")
+	} else if result.MatchedCount <= 0 {
+		return store.ErrDevNotFound
+	}
+
+	i.maybeTriggerReindex(ctx, []model.DeviceID{id})
+
+	return nil
+}
+
 func (i *inventory) CreateTenant(ctx context.Context, tenant model.NewTenant) error {
 	if err := i.db.WithAutomigrate().
 		MigrateTenant(ctx, mongo.DbVersion, tenant.ID); err != nil {
@@ -637,6 +848,13 @@ func (i *inventory) CreateTenant(ctx context.Context, tenant model.NewTenant) er
 	return nil
 }
 
+func (i *inventory) DeleteTenant(ctx context.Context, tenantID string) error {
+	if err := i.db.DeleteTenant(ctx, tenantID); err != nil {
+		return errors.Wrapf(err, "failed to delete tenant %v", tenantID)
+	}
+	return nil
+}
+
 func (i *inventory) SearchDevices(
 	ctx context.Context,
 	searchParams model.SearchParams,
@@ -1187,6 +1187,71 @@ func TestReplaceAttributes(t *testing.T) {
 	}
 }
 
+func TestInventoryUpsertRemoveAttributes(t *testing.T) {
+	t.Parallel()
+
+	upsertAttrs := model.DeviceAttributes{{
+		Name:  model.AttrNameOffline,
+		Value: false,
+		Scope: model.AttrScopeMonitor,
+	}}
+	removeAttrs := model.DeviceAttributes{{
+		Name:  model.AttrNameOfflineSince,
+		Scope: model.AttrScopeMonitor,
+	}}
+
+	testCases := map[string]struct {
+		datastoreRes   *model.UpdateResult
+		datastoreError error
+		outError       error
+	}{
+		"ok": {
+			datastoreRes: &model.UpdateResult{
+				MatchedCount: 1,
+				Devices:      []*model.Device{{ID: "1"}},
+			},
+		},
+		"ok, device not found": {
+			datastoreRes: &model.UpdateResult{},
+		},
+		"error, datastore": {
+			datastoreError: errors.New("db connection failed"),
+			outError:       errors.New("failed to upsert attributes in db: db connection failed"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("UpsertRemoveDeviceAttributes",
+				ctx,
+				model.DeviceID("1"),
+				upsertAttrs,
+				removeAttrs,
+				"",
+				"",
+			).Return(tc.datastoreRes, tc.datastoreError)
+			if tc.datastoreRes != nil && tc.datastoreRes.MatchedCount > 0 {
+				db.On("UpdateDeviceText",
+					ctx,
+					model.DeviceID("1"),
+					utils.GetTextField(tc.datastoreRes.Devices[0]),
+				).Return(nil)
+			}
+
+			err := invForTest(db).UpsertRemoveAttributes(ctx, "1", upsertAttrs, removeAttrs)
+			if tc.outError != nil {
+				assert.EqualError(t, err, tc.outError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGetFiltersAttributes(t *testing.T) {
 	t.Parallel()
 
@@ -1867,6 +1932,39 @@ func TestUserAdmCreateTenant(t *testing.T) {
 	}
 }
 
+func TestInventoryDeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		dbErr error
+		err   error
+	}{
+		"ok": {},
+		"error": {
+			dbErr: errors.New("connection error"),
+			err:   errors.New("failed to delete tenant foobar: connection error"),
+		},
+	}
+
+	for name := range testCases {
+		tc := testCases[name]
+		t.Run(fmt.Sprintf("tc %s", name), func(t *testing.T) {
+			ctx := context.Background()
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("DeleteTenant", ctx, "foobar").Return(tc.dbErr)
+
+			err := NewInventory(db).DeleteTenant(ctx, "foobar")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestInventorySearchDevices(t *testing.T) {
 	t.Parallel()
 
@@ -2058,3 +2156,303 @@ func TestCheckAlerts(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceAttributesWithHistory(t *testing.T) {
+	t.Parallel()
+
+	const devID = model.DeviceID("devid")
+	const retention = 24 * time.Hour
+
+	device := &model.Device{
+		ID: devID,
+		Attributes: model.DeviceAttributes{
+			{Name: "a", Value: "1", Scope: model.AttrScopeInventory},
+			{Name: "b", Value: "2", Scope: model.AttrScopeInventory},
+		},
+	}
+	upsertAttrs := model.DeviceAttributes{
+		{Name: "a", Value: "2", Scope: model.AttrScopeInventory},
+		{Name: "c", Value: "3", Scope: model.AttrScopeInventory},
+	}
+
+	testCases := map[string]struct {
+		limit       int
+		tracked     []model.AttributeKey
+		trackedErr  error
+		insertErr   error
+		outChanges  []model.AttributeKey
+		skipsInsert bool
+	}{
+		"ok": {
+			outChanges: []model.AttributeKey{
+				{Scope: model.AttrScopeInventory, Name: "a"},
+				{Scope: model.AttrScopeInventory, Name: "c"},
+				{Scope: model.AttrScopeInventory, Name: "b"},
+			},
+		},
+		"ok, limit of tracked attributes": {
+			limit: 2,
+			tracked: []model.AttributeKey{
+				{Scope: model.AttrScopeInventory, Name: "b"},
+			},
+			outChanges: []model.AttributeKey{
+				{Scope: model.AttrScopeInventory, Name: "a"},
+				{Scope: model.AttrScopeInventory, Name: "b"},
+			},
+		},
+		"ok, insert error is swallowed": {
+			insertErr: errors.New("db connection failed"),
+			outChanges: []model.AttributeKey{
+				{Scope: model.AttrScopeInventory, Name: "a"},
+				{Scope: model.AttrScopeInventory, Name: "c"},
+				{Scope: model.AttrScopeInventory, Name: "b"},
+			},
+		},
+		"ok, tracked attributes error is swallowed": {
+			limit:       2,
+			trackedErr:  errors.New("db connection failed"),
+			skipsInsert: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetDevice", ctx, devID).Return(device, nil)
+			db.On("UpsertRemoveDeviceAttributes",
+				ctx,
+				devID,
+				upsertAttrs,
+				mock.AnythingOfType("model.DeviceAttributes"),
+				model.AttrScopeInventory,
+				"",
+			).Return(&model.UpdateResult{MatchedCount: 1}, nil)
+			if tc.limit > 0 {
+				db.On("GetTrackedAttributes", ctx, devID).
+					Return(tc.tracked, tc.trackedErr)
+			}
+			if !tc.skipsInsert {
+				db.On("InsertAttributeChanges",
+					ctx,
+					mock.MatchedBy(func(changes []model.AttributeChange) bool {
+						keys := make([]model.AttributeKey, len(changes))
+						for i, change := range changes {
+							if change.DeviceID != devID ||
+								change.ExpireTs.Sub(change.Timestamp) != retention {
+								return false
+							}
+							keys[i] = change.Key()
+						}
+						return assert.Equal(t, tc.outChanges, keys)
+					}),
+				).Return(tc.insertErr)
+			}
+
+			i := invForTest(db).WithAttributeHistory(retention, tc.limit)
+			err := i.ReplaceAttributes(ctx, devID, upsertAttrs, model.AttrScopeInventory, "")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpsertAttributesWithHistoryUntrackedScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const devID = model.DeviceID("devid")
+	attrs := model.DeviceAttributes{
+		{Name: "check_in_time", Value: "now", Scope: model.AttrScopeSystem},
+	}
+
+	db := &mstore.DataStore{}
+	defer db.AssertExpectations(t)
+	db.On("UpsertDevicesAttributes",
+		ctx,
+		[]model.DeviceID{devID},
+		attrs,
+		(*time.Time)(nil),
+	).Return(&model.UpdateResult{MatchedCount: 1}, nil)
+
+	i := invForTest(db).WithAttributeHistory(time.Hour, 0)
+	err := i.UpsertAttributes(ctx, devID, attrs, nil)
+	assert.NoError(t, err)
+}
+
+func TestGetDeviceAttributeHistory(t *testing.T) {
+	t.Parallel()
+
+	const devID = model.DeviceID("devid")
+	filter := model.AttributeHistoryFilter{Page: 1, PerPage: 20}
+	changes := []model.AttributeChange{{
+		DeviceID: devID,
+		Scope:    model.AttrScopeInventory,
+		Name:     "a",
+		OldValue: "1",
+		NewValue: "2",
+	}}
+
+	testCases := map[string]struct {
+		device     *model.Device
+		deviceErr  error
+		historyErr error
+
+		outChanges []model.AttributeChange
+		outCount   int
+		outErr     error
+	}{
+		"ok": {
+			device:     &model.Device{ID: devID},
+			outChanges: changes,
+			outCount:   1,
+		},
+		"device not found": {
+			outCount: -1,
+			outErr:   store.ErrDevNotFound,
+		},
+		"device error": {
+			deviceErr: errors.New("db connection failed"),
+			outCount:  -1,
+			outErr:    errors.New("failed to fetch device: db connection failed"),
+		},
+		"history error": {
+			device:     &model.Device{ID: devID},
+			historyErr: errors.New("db connection failed"),
+			outCount:   -1,
+			outErr:     errors.New("failed to fetch attribute history: db connection failed"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetDevice", ctx, devID).Return(tc.device, tc.deviceErr)
+			if tc.device != nil {
+				if tc.historyErr != nil {
+					db.On("GetAttributeHistory", ctx, devID, filter).
+						Return(nil, -1, tc.historyErr)
+				} else {
+					db.On("GetAttributeHistory", ctx, devID, filter).
+						Return(changes, 1, nil)
+				}
+			}
+
+			i := invForTest(db)
+			res, count, err := i.GetDeviceAttributeHistory(ctx, devID, filter)
+			if tc.outErr != nil {
+				assert.EqualError(t, err, tc.outErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.outChanges, res)
+			assert.Equal(t, tc.outCount, count)
+		})
+	}
+}
+
+func TestInventoryGetDeviceGroups(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		device    *model.Device
+		deviceErr error
+
+		outGroups []model.GroupName
+		outErr    error
+	}{
+		"ok": {
+			device: &model.Device{
+				Group:  "europe/norway",
+				Groups: []model.GroupName{"rev-b"},
+			},
+			outGroups: []model.GroupName{"europe/norway", "rev-b"},
+		},
+		"ok, no groups": {
+			device:    &model.Device{},
+			outGroups: []model.GroupName{},
+		},
+		"device not found": {
+			outErr: store.ErrDevNotFound,
+		},
+		"datastore error": {
+			deviceErr: errors.New("db connection failed"),
+			outErr:    errors.New("failed to get device's groups: db connection failed"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetDevice", ctx, model.DeviceID("foo")).
+				Return(tc.device, tc.deviceErr)
+
+			i := invForTest(db)
+			groups, err := i.GetDeviceGroups(ctx, "foo")
+			if tc.outErr != nil {
+				assert.EqualError(t, err, tc.outErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.outGroups, groups)
+		})
+	}
+}
+
+func TestInventorySetDeviceGroups(t *testing.T) {
+	t.Parallel()
+
+	groups := []model.GroupName{"europe/norway", "rev-b"}
+
+	testCases := map[string]struct {
+		result    *model.UpdateResult
+		resultErr error
+
+		outErr error
+	}{
+		"ok": {
+			result: &model.UpdateResult{MatchedCount: 1},
+		},
+		"device not found": {
+			result: &model.UpdateResult{MatchedCount: 0},
+			outErr: store.ErrDevNotFound,
+		},
+		"datastore error": {
+			resultErr: errors.New("db connection failed"),
+			outErr:    errors.New("failed to set device's groups: db connection failed"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("SetDeviceGroups", ctx, model.DeviceID("foo"), groups).
+				Return(tc.result, tc.resultErr)
+
+			workflows := &mworkflows.Client{}
+			defer workflows.AssertExpectations(t)
+			if tc.outErr == nil {
+				workflows.On("StartReindex", ctx, []model.DeviceID{"foo"}).
+					Return(nil)
+			}
+
+			i := invForTest(db).WithReporting(workflows)
+			err := i.SetDeviceGroups(ctx, "foo", groups)
+			if tc.outErr != nil {
+				assert.EqualError(t, err, tc.outErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
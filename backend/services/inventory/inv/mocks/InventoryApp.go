@@ -180,6 +180,24 @@ func (_m *InventoryApp) DeleteGroup(ctx context.Context, groupName model.GroupNa
 	return r0, r1
 }
 
+// DeleteTenant provides a mock function with given fields: ctx, tenantID
+func (_m *InventoryApp) DeleteTenant(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTenant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetDevice provides a mock function with given fields: ctx, id
 func (_m *InventoryApp) GetDevice(ctx context.Context, id model.DeviceID) (*model.Device, error) {
 	ret := _m.Called(ctx, id)
@@ -210,6 +228,43 @@ func (_m *InventoryApp) GetDevice(ctx context.Context, id model.DeviceID) (*mode
 	return r0, r1
 }
 
+// GetDeviceAttributeHistory provides a mock function with given fields: ctx, id, filter
+func (_m *InventoryApp) GetDeviceAttributeHistory(ctx context.Context, id model.DeviceID, filter model.AttributeHistoryFilter) ([]model.AttributeChange, int, error) {
+	ret := _m.Called(ctx, id, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceAttributeHistory")
+	}
+
+	var r0 []model.AttributeChange
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) ([]model.AttributeChange, int, error)); ok {
+		return rf(ctx, id, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) []model.AttributeChange); ok {
+		r0 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AttributeChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) int); ok {
+		r1 = rf(ctx, id, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) error); ok {
+		r2 = rf(ctx, id, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetDeviceGroup provides a mock function with given fields: ctx, id
 func (_m *InventoryApp) GetDeviceGroup(ctx context.Context, id model.DeviceID) (model.GroupName, error) {
 	ret := _m.Called(ctx, id)
@@ -238,6 +293,36 @@ func (_m *InventoryApp) GetDeviceGroup(ctx context.Context, id model.DeviceID) (
 	return r0, r1
 }
 
+// GetDeviceGroups provides a mock function with given fields: ctx, id
+func (_m *InventoryApp) GetDeviceGroups(ctx context.Context, id model.DeviceID) ([]model.GroupName, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceGroups")
+	}
+
+	var r0 []model.GroupName
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID) ([]model.GroupName, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID) []model.GroupName); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.GroupName)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DeviceID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFiltersAttributes provides a mock function with given fields: ctx
 func (_m *InventoryApp) GetFiltersAttributes(ctx context.Context) ([]model.FilterAttribute, error) {
 	ret := _m.Called(ctx)
@@ -445,6 +530,24 @@ func (_m *InventoryApp) SearchDevices(ctx context.Context, searchParams model.Se
 	return r0, r1, r2
 }
 
+// SetDeviceGroups provides a mock function with given fields: ctx, id, groups
+func (_m *InventoryApp) SetDeviceGroups(ctx context.Context, id model.DeviceID, groups []model.GroupName) error {
+	ret := _m.Called(ctx, id, groups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceGroups")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, []model.GroupName) error); ok {
+		r0 = rf(ctx, id, groups)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnsetDeviceGroup provides a mock function with given fields: ctx, id, groupName
 func (_m *InventoryApp) UnsetDeviceGroup(ctx context.Context, id model.DeviceID, groupName model.GroupName) error {
 	ret := _m.Called(ctx, id, groupName)
@@ -607,6 +710,44 @@ func (_m *InventoryApp) UpsertDevicesStatuses(ctx context.Context, devices []mod
 	return r0, r1
 }
 
+// UpsertRemoveAttributes provides a mock function with given fields: ctx, id, upsertAttrs, removeAttrs
+func (_m *InventoryApp) UpsertRemoveAttributes(ctx context.Context, id model.DeviceID, upsertAttrs model.DeviceAttributes, removeAttrs model.DeviceAttributes) error {
+	ret := _m.Called(ctx, id, upsertAttrs, removeAttrs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRemoveAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, model.DeviceAttributes, model.DeviceAttributes) error); ok {
+		r0 = rf(ctx, id, upsertAttrs, removeAttrs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithAttributeHistory provides a mock function with given fields: retention, limit
+func (_m *InventoryApp) WithAttributeHistory(retention time.Duration, limit int) inv.InventoryApp {
+	ret := _m.Called(retention, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithAttributeHistory")
+	}
+
+	var r0 inv.InventoryApp
+	if rf, ok := ret.Get(0).(func(time.Duration, int) inv.InventoryApp); ok {
+		r0 = rf(retention, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(inv.InventoryApp)
+		}
+	}
+
+	return r0
+}
+
 // WithDevicemonitor provides a mock function with given fields: client
 func (_m *InventoryApp) WithDevicemonitor(client devicemonitor.Client) inv.InventoryApp {
 	ret := _m.Called(client)
@@ -0,0 +1,128 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttributeChange records the change of the value of a device attribute;
+// OldValue is nil for new attributes and NewValue is nil for the removed
+// ones.
+type AttributeChange struct {
+	DeviceID  DeviceID    `json:"-" bson:"device_id"`
+	Scope     string      `json:"scope" bson:"scope"`
+	Name      string      `json:"name" bson:"name"`
+	OldValue  interface{} `json:"old_value" bson:"old_value"`
+	NewValue  interface{} `json:"new_value" bson:"new_value"`
+	Timestamp time.Time   `json:"timestamp" bson:"timestamp"`
+	// ExpireTs is the time the change is removed from the history.
+	ExpireTs time.Time `json:"-" bson:"expire_ts"`
+}
+
+// AttributeKey identifies an attribute of a device.
+type AttributeKey struct {
+	Scope string `bson:"scope"`
+	Name  string `bson:"name"`
+}
+
+func (c AttributeChange) Key() AttributeKey {
+	return AttributeKey{Scope: c.Scope, Name: c.Name}
+}
+
+// AttributeHistoryFilter selects the changes of the device attributes.
+type AttributeHistoryFilter struct {
+	Scope   string
+	Name    string
+	From    *time.Time
+	To      *time.Time
+	Page    int
+	PerPage int
+}
+
+func (f AttributeHistoryFilter) Validate() error {
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Scope, validation.Length(0, 1024)),
+		validation.Field(&f.Name, validation.Length(0, 1024)),
+		validation.Field(&f.Page, validation.Required, validation.Min(1)),
+		validation.Field(&f.PerPage, validation.Required, validation.Min(1), validation.Max(500)),
+	)
+}
+
+// AttributeValuesEqual compares the values of an attribute taking into
+// account that arrays are decoded from the database as primitive.A.
+func AttributeValuesEqual(a, b interface{}) bool {
+	if arr, ok := a.(primitive.A); ok {
+		a = []interface{}(arr)
+	}
+	if arr, ok := b.(primitive.A); ok {
+		b = []interface{}(arr)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// DiffAttributes returns the changes applying the upserted and removed
+// attributes to the device with the given ID; a nil device has no
+// attributes yet.
+func DiffAttributes(
+	id DeviceID,
+	device *Device,
+	upsertAttrs DeviceAttributes,
+	removeAttrs DeviceAttributes,
+	at time.Time,
+) []AttributeChange {
+	current := map[AttributeKey]interface{}{}
+	if device != nil {
+		for _, attr := range device.Attributes {
+			current[AttributeKey{Scope: attr.Scope, Name: attr.Name}] = attr.Value
+		}
+	}
+
+	changes := []AttributeChange{}
+	for _, attr := range upsertAttrs {
+		key := AttributeKey{Scope: attr.Scope, Name: attr.Name}
+		oldValue, ok := current[key]
+		if ok && AttributeValuesEqual(oldValue, attr.Value) {
+			continue
+		}
+		changes = append(changes, AttributeChange{
+			DeviceID:  id,
+			Scope:     attr.Scope,
+			Name:      attr.Name,
+			OldValue:  oldValue,
+			NewValue:  attr.Value,
+			Timestamp: at,
+		})
+	}
+	for _, attr := range removeAttrs {
+		key := AttributeKey{Scope: attr.Scope, Name: attr.Name}
+		oldValue, ok := current[key]
+		if !ok {
+			continue
+		}
+		changes = append(changes, AttributeChange{
+			DeviceID:  id,
+			Scope:     attr.Scope,
+			Name:      attr.Name,
+			OldValue:  oldValue,
+			Timestamp: at,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDiffAttributes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	device := &Device{
+		Attributes: DeviceAttributes{
+			{Scope: AttrScopeInventory, Name: "same", Value: primitive.A{"a", "b"}},
+			{Scope: AttrScopeInventory, Name: "changed", Value: "1"},
+			{Scope: AttrScopeInventory, Name: "removed", Value: "x"},
+		},
+	}
+
+	changes := DiffAttributes("dev", device,
+		DeviceAttributes{
+			{Scope: AttrScopeInventory, Name: "same", Value: []interface{}{"a", "b"}},
+			{Scope: AttrScopeInventory, Name: "changed", Value: "2"},
+			{Scope: AttrScopeInventory, Name: "added", Value: 1.0},
+		},
+		DeviceAttributes{
+			{Scope: AttrScopeInventory, Name: "removed"},
+			{Scope: AttrScopeInventory, Name: "missing"},
+		},
+		now,
+	)
+	assert.Equal(t, []AttributeChange{{
+		DeviceID:  "dev",
+		Scope:     AttrScopeInventory,
+		Name:      "changed",
+		OldValue:  "1",
+		NewValue:  "2",
+		Timestamp: now,
+	}, {
+		DeviceID:  "dev",
+		Scope:     AttrScopeInventory,
+		Name:      "added",
+		NewValue:  1.0,
+		Timestamp: now,
+	}, {
+		DeviceID:  "dev",
+		Scope:     AttrScopeInventory,
+		Name:      "removed",
+		OldValue:  "x",
+		Timestamp: now,
+	}}, changes)
+
+	changes = DiffAttributes("dev", nil,
+		DeviceAttributes{{Scope: AttrScopeTags, Name: "location", Value: "office"}},
+		nil, now,
+	)
+	assert.Equal(t, []AttributeChange{{
+		DeviceID:  "dev",
+		Scope:     AttrScopeTags,
+		Name:      "location",
+		NewValue:  "office",
+		Timestamp: now,
+	}}, changes)
+}
+
+func TestAttributeHistoryFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, AttributeHistoryFilter{Page: 1, PerPage: 500}.Validate())
+	assert.Error(t, AttributeHistoryFilter{Page: 0, PerPage: 20}.Validate())
+	assert.Error(t, AttributeHistoryFilter{Page: 1, PerPage: 501}.Validate())
+}
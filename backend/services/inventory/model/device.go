@@ -34,14 +34,18 @@ const (
 	AttrScopeSystem    = "system"
 	AttrScopeTags      = "tags"
 	AttrScopeMonitor   = "monitor"
+	AttrScopeIoT       = "iot"
 
 	AttrNameID             = "id"
 	AttrNameGroup          = "group"
+	AttrNameGroups         = "groups"
 	AttrNameUpdated        = "updated_ts"
 	AttrNameCreated        = "created_ts"
 	AttrNameTagsEtag       = "tags_etag"
 	AttrNameNumberOfAlerts = "alert_count"
 	AttrNameAlerts         = "alerts"
+	AttrNameOffline        = "offline"
+	AttrNameOfflineSince   = "offline_since"
 )
 
 const (
@@ -49,7 +53,17 @@ const (
 	runeDot    = '\uFF0E'
 )
 
-var validGroupNameRegex = regexp.MustCompile("^[A-Za-z0-9_-]*$")
+const (
+	// GroupSeparator separates the names of nested groups, e.g.
+	// europe/norway/oslo is nested in europe/norway and europe.
+	GroupSeparator = "/"
+
+	// MaxDeviceGroups is the maximum number of groups a device can be a
+	// member of.
+	MaxDeviceGroups = 20
+)
+
+var validGroupNameRegex = regexp.MustCompile("^[A-Za-z0-9_-]+(/[A-Za-z0-9_-]+)*$")
 
 type DeviceID string
 
@@ -61,6 +75,25 @@ type DeviceGroups struct {
 	Groups []string `json:"groups" bson:"-"`
 }
 
+func (g DeviceGroups) Validate() error {
+	seen := make(map[string]struct{}, len(g.Groups))
+	for _, group := range g.Groups {
+		if _, ok := seen[group]; ok {
+			return errors.Errorf("duplicate group: %s", group)
+		}
+		seen[group] = struct{}{}
+	}
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.Groups,
+			validation.NotNil,
+			validation.Length(0, MaxDeviceGroups),
+			validation.Each(validation.By(func(value interface{}) error {
+				return GroupName(value.(string)).Validate()
+			})),
+		),
+	)
+}
+
 type DeviceAttribute struct {
 	Name        string      `json:"name" bson:",omitempty"`
 	Description *string     `json:"description,omitempty" bson:",omitempty"`
@@ -145,6 +178,9 @@ type Device struct {
 	//device's group name
 	Group GroupName `json:"-" bson:"group,omitempty"`
 
+	//device's additional group names
+	Groups []GroupName `json:"-" bson:"-"`
+
 	CreatedTs time.Time `json:"-" bson:"created_ts,omitempty"`
 	//Timestamp of the last attribute update.
 	UpdatedTs *time.Time `json:"updated_ts,omitempty" bson:"updated_ts,omitempty"`
@@ -173,6 +209,14 @@ func (d *Device) UnmarshalBSON(b []byte) error {
 			case AttrNameGroup:
 				group := attr.Value.(string)
 				d.Group = GroupName(group)
+			case AttrNameGroups:
+				groups, _ := attr.Value.(primitive.A)
+				d.Groups = make([]GroupName, 0, len(groups))
+				for _, group := range groups {
+					if name, ok := group.(string); ok {
+						d.Groups = append(d.Groups, GroupName(name))
+					}
+				}
 			case AttrNameUpdated:
 				if attr.Value != nil {
 					dateTime := attr.Value.(primitive.DateTime).Time()
@@ -200,9 +244,31 @@ func (d Device) MarshalBSON() ([]byte, error) {
 			Value: d.Group,
 		})
 	}
+	if len(d.Groups) > 0 {
+		d.Attributes = append(d.Attributes, DeviceAttribute{
+			Scope: AttrScopeSystem,
+			Name:  AttrNameGroups,
+			Value: d.Groups,
+		})
+	}
 	return bson.Marshal(internalDevice(d))
 }
 
+// GetGroups returns all the groups the device is a member of, starting
+// with its primary group.
+func (d *Device) GetGroups() []GroupName {
+	groups := make([]GroupName, 0, len(d.Groups)+1)
+	if d.Group != "" {
+		groups = append(groups, d.Group)
+	}
+	for _, group := range d.Groups {
+		if group != d.Group {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
 func (d Device) Validate() error {
 	return validation.ValidateStruct(&d,
 		validation.Field(&d.ID, validation.Required, validation.Length(1, 1024)),
@@ -231,12 +297,19 @@ func (gn GroupName) Validate() error {
 	} else if !validGroupNameRegex.MatchString(string(gn)) {
 		return errors.New(
 			"Group name can only contain: upper/lowercase " +
-				"alphanum, -(dash), _(underscore)",
+				"alphanum, -(dash), _(underscore) and /(slash) " +
+				"separating the names of nested groups",
 		)
 	}
 	return nil
 }
 
+// Contains returns true if the group is the same as or nested in gn.
+func (gn GroupName) Contains(group GroupName) bool {
+	return group == gn ||
+		strings.HasPrefix(string(group), string(gn)+GroupSeparator)
+}
+
 // wrapper for device attributes names and values
 type DeviceAttributes []DeviceAttribute
 
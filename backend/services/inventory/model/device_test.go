@@ -272,9 +272,48 @@ func TestValidateGroupName(t *testing.T) {
 		"1024 characters")
 	group2 := GroupName("totally.legit")
 	assert.EqualError(t, group2.Validate(), "Group name can only contain: "+
-		"upper/lowercase alphanum, -(dash), _(underscore)")
+		"upper/lowercase alphanum, -(dash), _(underscore) and /(slash) "+
+		"separating the names of nested groups")
 	group3 := GroupName("")
 	assert.EqualError(t, group3.Validate(), "Group name cannot be blank")
 	group4 := GroupName("test")
 	assert.NoError(t, group4.Validate())
+	group5 := GroupName("europe/norway/oslo")
+	assert.NoError(t, group5.Validate())
+	for _, name := range []string{"/europe", "europe/", "europe//norway"} {
+		assert.Error(t, GroupName(name).Validate(), name)
+	}
+}
+
+func TestGroupNameContains(t *testing.T) {
+	t.Parallel()
+	europe := GroupName("europe")
+	assert.True(t, europe.Contains("europe"))
+	assert.True(t, europe.Contains("europe/norway/oslo"))
+	assert.False(t, europe.Contains("europe-north"))
+	assert.False(t, europe.Contains("asia/europe"))
+	assert.False(t, GroupName("europe/norway").Contains("europe"))
+}
+
+func TestDeviceGroupsValidate(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, DeviceGroups{Groups: []string{}}.Validate())
+	assert.NoError(t, DeviceGroups{
+		Groups: []string{"europe/norway", "rev-b"},
+	}.Validate())
+	assert.EqualError(t, DeviceGroups{
+		Groups: []string{"rev-b", "rev-b"},
+	}.Validate(), "duplicate group: rev-b")
+	assert.Error(t, DeviceGroups{}.Validate())
+	assert.Error(t, DeviceGroups{Groups: []string{"in$valid"}}.Validate())
+}
+
+func TestDeviceGetGroups(t *testing.T) {
+	t.Parallel()
+	dev := Device{
+		Group:  "europe/norway",
+		Groups: []GroupName{"rev-b", "europe/norway"},
+	}
+	assert.Equal(t, []GroupName{"europe/norway", "rev-b"}, dev.GetGroups())
+	assert.Equal(t, []GroupName{}, (&Device{}).GetGroups())
 }
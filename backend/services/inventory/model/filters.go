@@ -23,7 +23,7 @@ import (
 
 var validSelectors = []interface{}{"$eq", "$in", "$nin"}
 var validSortOrders = []interface{}{"asc", "desc"}
-var validScopes = []string{"system", "identity", "inventory", "monitor", "tags"}
+var validScopes = []string{"system", "identity", "inventory", "monitor", "tags", "iot"}
 
 type SearchParams struct {
 	Page       int               `json:"page"`
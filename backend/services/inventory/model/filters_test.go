@@ -121,7 +121,7 @@ func TestSearchParams(t *testing.T) {
 					},
 				},
 			},
-			err: errors.New("scope: must be one of system, identity, inventory, monitor, tags."),
+			err: errors.New("scope: must be one of system, identity, inventory, monitor, tags, iot."),
 		},
 		"ok, attributes": {
 			params: &SearchParams{
@@ -152,7 +152,7 @@ func TestSearchParams(t *testing.T) {
 					},
 				},
 			},
-			err: errors.New("scope: must be one of system, identity, inventory, monitor, tags."),
+			err: errors.New("scope: must be one of system, identity, inventory, monitor, tags, iot."),
 		},
 	}
 
@@ -221,7 +221,7 @@ func TestFilter(t *testing.T) {
 					},
 				},
 			},
-			err: errors.New("validation failed for term: scope: must be one of system, identity, inventory, monitor, tags."),
+			err: errors.New("validation failed for term: scope: must be one of system, identity, inventory, monitor, tags, iot."),
 		},
 	}
 
@@ -57,6 +57,11 @@ func RunServer(c config.Reader) error {
 		return err
 	}
 
+	if c.GetBool(SettingAttributeHistoryEnabled) {
+		retention := time.Duration(c.GetInt(SettingAttributeHistoryRetention)) * 24 * time.Hour
+		inv = inv.WithAttributeHistory(retention, c.GetInt(SettingAttributeHistoryLimit))
+	}
+
 	options := []api_http.Option{
 		api_http.SetMaxRequestSize(config.Config.GetInt64(SettingMaxRequestSize)),
 	}
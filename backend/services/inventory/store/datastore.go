@@ -136,6 +136,13 @@ type DataStore interface {
 		group model.GroupName,
 	) (*model.UpdateResult, error)
 
+	// SetDeviceGroups replaces the groups of the device; the first group
+	// is the primary group of the device.
+	SetDeviceGroups(ctx context.Context,
+		id model.DeviceID,
+		groups []model.GroupName,
+	) (*model.UpdateResult, error)
+
 	// ListGroups returns a list of all existing groups. Devices included
 	// in the evaluation can be filtered by the filters argument.
 	ListGroups(ctx context.Context, filters []model.FilterPredicate) ([]model.GroupName, error)
@@ -159,9 +166,30 @@ type DataStore interface {
 
 	MigrateTenant(ctx context.Context, version string, tenantId string) error
 
+	// DeleteTenant removes the database of the tenant.
+	DeleteTenant(ctx context.Context, tenantId string) error
+
 	Migrate(ctx context.Context, version string) error
 
 	WithAutomigrate() DataStore
 
 	Maintenance(ctx context.Context, version string, tenantIDs ...string) error
+
+	// InsertAttributeChanges appends the changes to the attribute history.
+	InsertAttributeChanges(ctx context.Context, changes []model.AttributeChange) error
+
+	// GetAttributeHistory returns the attribute changes of the device
+	// matching the filter, most recent first, and their total count.
+	GetAttributeHistory(
+		ctx context.Context,
+		id model.DeviceID,
+		filter model.AttributeHistoryFilter,
+	) ([]model.AttributeChange, int, error)
+
+	// GetTrackedAttributes returns the attributes of the device having
+	// changes in the attribute history.
+	GetTrackedAttributes(ctx context.Context, id model.DeviceID) ([]model.AttributeKey, error)
+
+	// DeleteAttributeHistory removes the attribute history of the devices.
+	DeleteAttributeHistory(ctx context.Context, ids []model.DeviceID) error
 }
@@ -50,6 +50,24 @@ func (_m *DataStore) AddDevice(ctx context.Context, dev *model.Device) error {
 	return r0
 }
 
+// DeleteAttributeHistory provides a mock function with given fields: ctx, ids
+func (_m *DataStore) DeleteAttributeHistory(ctx context.Context, ids []model.DeviceID) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttributeHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.DeviceID) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDevices provides a mock function with given fields: ctx, ids
 func (_m *DataStore) DeleteDevices(ctx context.Context, ids []model.DeviceID) (*model.UpdateResult, error) {
 	ret := _m.Called(ctx, ids)
@@ -110,6 +128,24 @@ func (_m *DataStore) DeleteGroup(ctx context.Context, group model.GroupName) (ch
 	return r0, r1
 }
 
+// DeleteTenant provides a mock function with given fields: ctx, tenantId
+func (_m *DataStore) DeleteTenant(ctx context.Context, tenantId string) error {
+	ret := _m.Called(ctx, tenantId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTenant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAllAttributeNames provides a mock function with given fields: ctx
 func (_m *DataStore) GetAllAttributeNames(ctx context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
@@ -140,6 +176,43 @@ func (_m *DataStore) GetAllAttributeNames(ctx context.Context) ([]string, error)
 	return r0, r1
 }
 
+// GetAttributeHistory provides a mock function with given fields: ctx, id, filter
+func (_m *DataStore) GetAttributeHistory(ctx context.Context, id model.DeviceID, filter model.AttributeHistoryFilter) ([]model.AttributeChange, int, error) {
+	ret := _m.Called(ctx, id, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttributeHistory")
+	}
+
+	var r0 []model.AttributeChange
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) ([]model.AttributeChange, int, error)); ok {
+		return rf(ctx, id, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) []model.AttributeChange); ok {
+		r0 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AttributeChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) int); ok {
+		r1 = rf(ctx, id, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.DeviceID, model.AttributeHistoryFilter) error); ok {
+		r2 = rf(ctx, id, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetDevice provides a mock function with given fields: ctx, id
 func (_m *DataStore) GetDevice(ctx context.Context, id model.DeviceID) (*model.Device, error) {
 	ret := _m.Called(ctx, id)
@@ -302,6 +375,54 @@ func (_m *DataStore) GetFiltersAttributes(ctx context.Context) ([]model.FilterAt
 	return r0, r1
 }
 
+// GetTrackedAttributes provides a mock function with given fields: ctx, id
+func (_m *DataStore) GetTrackedAttributes(ctx context.Context, id model.DeviceID) ([]model.AttributeKey, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrackedAttributes")
+	}
+
+	var r0 []model.AttributeKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID) ([]model.AttributeKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID) []model.AttributeKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AttributeKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DeviceID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertAttributeChanges provides a mock function with given fields: ctx, changes
+func (_m *DataStore) InsertAttributeChanges(ctx context.Context, changes []model.AttributeChange) error {
+	ret := _m.Called(ctx, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAttributeChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.AttributeChange) error); ok {
+		r0 = rf(ctx, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ListGroups provides a mock function with given fields: ctx, filters
 func (_m *DataStore) ListGroups(ctx context.Context, filters []model.FilterPredicate) ([]model.GroupName, error) {
 	ret := _m.Called(ctx, filters)
@@ -448,6 +569,36 @@ func (_m *DataStore) SearchDevices(ctx context.Context, searchParams model.Searc
 	return r0, r1, r2
 }
 
+// SetDeviceGroups provides a mock function with given fields: ctx, id, groups
+func (_m *DataStore) SetDeviceGroups(ctx context.Context, id model.DeviceID, groups []model.GroupName) (*model.UpdateResult, error) {
+	ret := _m.Called(ctx, id, groups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceGroups")
+	}
+
+	var r0 *model.UpdateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, []model.GroupName) (*model.UpdateResult, error)); ok {
+		return rf(ctx, id, groups)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeviceID, []model.GroupName) *model.UpdateResult); ok {
+		r0 = rf(ctx, id, groups)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UpdateResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DeviceID, []model.GroupName) error); ok {
+		r1 = rf(ctx, id, groups)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnsetDevicesGroup provides a mock function with given fields: ctx, deviceIDs, group
 func (_m *DataStore) UnsetDevicesGroup(ctx context.Context, deviceIDs []model.DeviceID, group model.GroupName) (*model.UpdateResult, error) {
 	ret := _m.Called(ctx, deviceIDs, group)
@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -40,7 +41,7 @@ import (
 )
 
 const (
-	DbVersion = "1.1.0"
+	DbVersion = "1.3.0"
 
 	DbName        = "inventory"
 	DbDevicesColl = "devices"
@@ -60,6 +61,10 @@ const (
 		model.AttrScopeSystem + "-" + model.AttrNameGroup
 	DbDevAttributesGroupValue = DbDevAttributesGroup + "." +
 		DbDevAttributesValue
+	DbDevAttributesGroups = DbDevAttributes + "." +
+		model.AttrScopeSystem + "-" + model.AttrNameGroups
+	DbDevAttributesGroupsValue = DbDevAttributesGroups + "." +
+		DbDevAttributesValue
 
 	DbScopeInventory = "inventory"
 
@@ -200,16 +205,10 @@ func (db *DataStoreMongo) GetDevices(
 		}
 	}
 	if q.GroupName != "" {
-		groupFilter := bson.M{DbDevAttributesGroupValue: q.GroupName}
-		queryFilters = append(queryFilters, groupFilter)
+		queryFilters = append(queryFilters, groupQuery(model.GroupName(q.GroupName)))
 	}
 	if q.HasGroup != nil {
-		groupExistenceFilter := bson.M{
-			DbDevAttributesGroup: bson.M{
-				"$exists": *q.HasGroup,
-			},
-		}
-		queryFilters = append(queryFilters, groupExistenceFilter)
+		queryFilters = append(queryFilters, hasGroupQuery(*q.HasGroup))
 	}
 
 	findQuery := bson.M{}
@@ -257,6 +256,75 @@ func (db *DataStoreMongo) GetDevices(
 	return devices, int(count), nil
 }
 
+// groupQuery matches the devices member of the group or of one of the
+// groups nested in it, either as their primary or additional group.
+func groupQuery(group model.GroupName) bson.M {
+	regex := primitive.Regex{
+		Pattern: "^" + regexp.QuoteMeta(string(group)) +
+			"(" + regexp.QuoteMeta(model.GroupSeparator) + "|$)",
+	}
+	return bson.M{"$or": []bson.M{
+		{DbDevAttributesGroupValue: regex},
+		{DbDevAttributesGroupsValue: regex},
+	}}
+}
+
+// groupsQuery matches the devices member of any of the groups.
+func groupsQuery(groups []model.GroupName) bson.M {
+	queries := make([]bson.M, len(groups))
+	for i, group := range groups {
+		queries[i] = groupQuery(group)
+	}
+	return bson.M{"$or": queries}
+}
+
+// hasGroupQuery matches the devices member of at least one group if
+// hasGroup is true, otherwise the devices not member of any group.
+func hasGroupQuery(hasGroup bool) bson.M {
+	queries := []bson.M{
+		{DbDevAttributesGroup: bson.M{"$exists": hasGroup}},
+		{DbDevAttributesGroupsValue + ".0": bson.M{"$exists": hasGroup}},
+	}
+	if hasGroup {
+		return bson.M{"$or": queries}
+	}
+	return bson.M{"$and": queries}
+}
+
+// groupPredicateQuery translates the predicates on the group attribute
+// matching the group names to group queries including the nested groups.
+func groupPredicateQuery(filter model.FilterPredicate) (bson.M, bool) {
+	if filter.Scope != model.AttrScopeSystem || filter.Attribute != model.AttrNameGroup {
+		return nil, false
+	}
+	switch filter.Type {
+	case "$eq":
+		if group, ok := filter.Value.(string); ok {
+			return groupQuery(model.GroupName(group)), true
+		}
+	case "$in":
+		var groups []model.GroupName
+		switch values := filter.Value.(type) {
+		case []string:
+			for _, group := range values {
+				groups = append(groups, model.GroupName(group))
+			}
+		case []interface{}:
+			for _, value := range values {
+				group, ok := value.(string)
+				if !ok {
+					return nil, false
+				}
+				groups = append(groups, model.GroupName(group))
+			}
+		}
+		if len(groups) > 0 {
+			return groupsQuery(groups), true
+		}
+	}
+	return nil, false
+}
+
 func (db *DataStoreMongo) GetDevice(
 	ctx context.Context,
 	id model.DeviceID,
@@ -706,6 +774,10 @@ func (db *DataStoreMongo) UpdateDevicesGroup(
 				Value: group,
 			},
 		},
+		// the primary group is not repeated in the additional groups
+		"$pull": bson.M{
+			DbDevAttributesGroupsValue: group,
+		},
 	}
 	res, err := collDevs.UpdateMany(ctx, filter, update)
 	if err != nil {
@@ -717,6 +789,53 @@ func (db *DataStoreMongo) UpdateDevicesGroup(
 	}, nil
 }
 
+// SetDeviceGroups replaces the groups of the device: the first group is the
+// primary group of the device, the other ones are its additional groups.
+func (db *DataStoreMongo) SetDeviceGroups(
+	ctx context.Context,
+	id model.DeviceID,
+	groups []model.GroupName,
+) (*model.UpdateResult, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DbName))
+	collDevs := database.Collection(DbDevicesColl)
+
+	set := bson.M{}
+	unset := bson.M{}
+	if len(groups) > 0 {
+		set[DbDevAttributesGroup] = model.DeviceAttribute{
+			Scope: model.AttrScopeSystem,
+			Name:  model.AttrNameGroup,
+			Value: groups[0],
+		}
+	} else {
+		unset[DbDevAttributesGroup] = ""
+	}
+	if len(groups) > 1 {
+		set[DbDevAttributesGroups] = model.DeviceAttribute{
+			Scope: model.AttrScopeSystem,
+			Name:  model.AttrNameGroups,
+			Value: groups[1:],
+		}
+	} else {
+		unset[DbDevAttributesGroups] = ""
+	}
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	res, err := collDevs.UpdateOne(ctx, bson.M{DbDevId: id}, update)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UpdateResult{
+		MatchedCount: res.MatchedCount,
+		UpdatedCount: res.ModifiedCount,
+	}, nil
+}
+
 // UpdateDeviceText updates the device text field
 func (db *DataStoreMongo) UpdateDeviceText(
 	ctx context.Context,
@@ -824,7 +943,10 @@ func (db *DataStoreMongo) DeleteGroup(
 	database := db.client.Database(mstore.DbFromContext(ctx, DbName))
 	collDevs := database.Collection(DbDevicesColl)
 
-	filter := bson.M{DbDevAttributesGroupValue: group}
+	filter := bson.M{"$or": []bson.M{
+		{DbDevAttributesGroupValue: group},
+		{DbDevAttributesGroupsValue: group},
+	}}
 
 	const batchMaxSize = 100
 	batchSize := int32(batchMaxSize)
@@ -842,7 +964,8 @@ func (db *DataStoreMongo) DeleteGroup(
 		batch := make([]model.DeviceID, batchMaxSize)
 		batchSize := 0
 
-		update := bson.M{"$unset": bson.M{DbDevAttributesGroup: 1}}
+		unsetGroup := bson.M{"$unset": bson.M{DbDevAttributesGroup: 1}}
+		pullGroup := bson.M{"$pull": bson.M{DbDevAttributesGroupsValue: group}}
 		device := &model.Device{}
 		defer close(deviceIDs)
 
@@ -864,7 +987,12 @@ func (db *DataStoreMongo) DeleteGroup(
 			}
 		}
 
-		_, _ = collDevs.UpdateMany(ctx, bson.M{DbDevId: bson.M{"$in": batch[:batchSize]}}, update)
+		batchIDs := bson.M{"$in": batch[:batchSize]}
+		_, _ = collDevs.UpdateMany(ctx, bson.M{
+			DbDevId:                   batchIDs,
+			DbDevAttributesGroupValue: group,
+		}, unsetGroup)
+		_, _ = collDevs.UpdateMany(ctx, bson.M{DbDevId: batchIDs}, pullGroup)
 		for _, item := range batch[:batchSize] {
 			deviceIDs <- item
 		}
@@ -883,34 +1011,47 @@ func (db *DataStoreMongo) UnsetDevicesGroup(
 	database := db.client.Database(mstore.DbFromContext(ctx, DbName))
 	collDevs := database.Collection(DbDevicesColl)
 
-	var filter bson.D
+	var idFilter bson.E
 	// Add filter on device id (either $in or direct indexing)
 	switch len(deviceIDs) {
 	case 0:
 		return &model.UpdateResult{}, nil
 	case 1:
-		filter = bson.D{{Key: DbDevId, Value: deviceIDs[0]}}
+		idFilter = bson.E{Key: DbDevId, Value: deviceIDs[0]}
 	default:
-		filter = bson.D{{Key: DbDevId, Value: bson.M{"$in": deviceIDs}}}
+		idFilter = bson.E{Key: DbDevId, Value: bson.M{"$in": deviceIDs}}
 	}
 	// Append filter on group
-	filter = append(
-		filter,
+	groupFilter := bson.D{
+		idFilter,
 		bson.E{Key: DbDevAttributesGroupValue, Value: group},
-	)
+	}
 	// Create unset operation on group attribute
 	update := bson.M{
 		"$unset": bson.M{
 			DbDevAttributesGroup: "",
 		},
 	}
-	res, err := collDevs.UpdateMany(ctx, filter, update)
+	res, err := collDevs.UpdateMany(ctx, groupFilter, update)
+	if err != nil {
+		return nil, err
+	}
+	// Remove the group from the additional groups
+	groupsFilter := bson.D{
+		idFilter,
+		bson.E{Key: DbDevAttributesGroupsValue, Value: group},
+	}
+	resGroups, err := collDevs.UpdateMany(ctx, groupsFilter, bson.M{
+		"$pull": bson.M{
+			DbDevAttributesGroupsValue: group,
+		},
+	})
 	if err != nil {
 		return nil, err
 	}
 	return &model.UpdateResult{
-		MatchedCount: res.MatchedCount,
-		UpdatedCount: res.ModifiedCount,
+		MatchedCount: res.MatchedCount + resGroups.MatchedCount,
+		UpdatedCount: res.ModifiedCount + resGroups.ModifiedCount,
 	}, nil
 }
 
@@ -937,30 +1078,35 @@ func (db *DataStoreMongo) ListGroups(
 		Database(mstore.DbFromContext(ctx, DbName)).
 		Collection(DbDevicesColl)
 
-	fltr := bson.D{{
-		Key: DbDevAttributesGroupValue, Value: bson.M{"$exists": true},
-	}}
-	if len(fltr) > 0 {
-		for _, p := range filters {
-			q, err := predicateToQuery(p)
-			if err != nil {
-				return nil, errors.Wrap(
-					err, "store: bad filter predicate",
-				)
-			}
-			fltr = append(fltr, q...)
+	fltr := bson.D{}
+	for _, p := range filters {
+		q, err := predicateToQuery(p)
+		if err != nil {
+			return nil, errors.Wrap(
+				err, "store: bad filter predicate",
+			)
 		}
-	}
-	results, err := c.Distinct(
-		ctx, DbDevAttributesGroupValue, fltr,
-	)
-	if err != nil {
-		return nil, err
+		fltr = append(fltr, q...)
 	}
 
-	groups := make([]model.GroupName, len(results))
-	for i, d := range results {
-		groups[i] = model.GroupName(d.(string))
+	groups := []model.GroupName{}
+	found := map[model.GroupName]struct{}{}
+	for _, field := range []string{DbDevAttributesGroupValue, DbDevAttributesGroupsValue} {
+		fieldFltr := append(
+			bson.D{{Key: field, Value: bson.M{"$exists": true}}},
+			fltr...,
+		)
+		results, err := c.Distinct(ctx, field, fieldFltr)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range results {
+			group := model.GroupName(d.(string))
+			if _, ok := found[group]; !ok {
+				found[group] = struct{}{}
+				groups = append(groups, group)
+			}
+		}
 	}
 	return groups, nil
 }
@@ -975,7 +1121,7 @@ func (db *DataStoreMongo) GetDevicesByGroup(
 		Database(mstore.DbFromContext(ctx, DbName)).
 		Collection(DbDevicesColl)
 
-	filter := bson.M{DbDevAttributesGroupValue: group}
+	filter := groupQuery(group)
 	result := c.FindOne(ctx, filter)
 	if result == nil {
 		return nil, -1, store.ErrGroupNotFound
@@ -1116,6 +1262,10 @@ func (db *DataStoreMongo) SearchDevices(
 
 	queryFilters := make([]bson.M, 0)
 	for _, filter := range searchParams.Filters {
+		if query, ok := groupPredicateQuery(filter); ok {
+			queryFilters = append(queryFilters, query)
+			continue
+		}
 		op := filter.Type
 		var field string
 		if filter.Scope == model.AttrScopeIdentity && filter.Attribute == model.AttrNameID {
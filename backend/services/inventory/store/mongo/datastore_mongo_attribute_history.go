@@ -0,0 +1,145 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/inventory/model"
+)
+
+const (
+	DbAttributeHistoryColl = "attribute_history"
+
+	DbAttrHistoryDeviceID  = "device_id"
+	DbAttrHistoryScope     = "scope"
+	DbAttrHistoryName      = "name"
+	DbAttrHistoryTimestamp = "timestamp"
+	DbAttrHistoryExpireTs  = "expire_ts"
+)
+
+func (db *DataStoreMongo) InsertAttributeChanges(
+	ctx context.Context,
+	changes []model.AttributeChange,
+) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	c := db.client.Database(mstore.DbFromContext(ctx, DbName)).
+		Collection(DbAttributeHistoryColl)
+
+	docs := make([]interface{}, len(changes))
+	for i := range changes {
+		docs[i] = changes[i]
+	}
+	_, err := c.InsertMany(ctx, docs)
+	return err
+}
+
+func (db *DataStoreMongo) GetAttributeHistory(
+	ctx context.Context,
+	id model.DeviceID,
+	filter model.AttributeHistoryFilter,
+) ([]model.AttributeChange, int, error) {
+	c := db.client.Database(mstore.DbFromContext(ctx, DbName)).
+		Collection(DbAttributeHistoryColl)
+
+	query := bson.D{{Key: DbAttrHistoryDeviceID, Value: id}}
+	if filter.Scope != "" {
+		query = append(query, bson.E{Key: DbAttrHistoryScope, Value: filter.Scope})
+	}
+	if filter.Name != "" {
+		query = append(query, bson.E{Key: DbAttrHistoryName, Value: filter.Name})
+	}
+	if filter.From != nil || filter.To != nil {
+		timeRange := bson.D{}
+		if filter.From != nil {
+			timeRange = append(timeRange, bson.E{Key: "$gte", Value: *filter.From})
+		}
+		if filter.To != nil {
+			timeRange = append(timeRange, bson.E{Key: "$lte", Value: *filter.To})
+		}
+		query = append(query, bson.E{Key: DbAttrHistoryTimestamp, Value: timeRange})
+	}
+
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: DbAttrHistoryTimestamp, Value: -1}})
+	if filter.PerPage > 0 {
+		findOpts.SetLimit(int64(filter.PerPage))
+		if filter.Page > 1 {
+			findOpts.SetSkip(int64((filter.Page - 1) * filter.PerPage))
+		}
+	}
+	cursor, err := c.Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, -1, err
+	}
+	changes := []model.AttributeChange{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, -1, err
+	}
+
+	count, err := c.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, -1, err
+	}
+	return changes, int(count), nil
+}
+
+func (db *DataStoreMongo) GetTrackedAttributes(
+	ctx context.Context,
+	id model.DeviceID,
+) ([]model.AttributeKey, error) {
+	c := db.client.Database(mstore.DbFromContext(ctx, DbName)).
+		Collection(DbAttributeHistoryColl)
+
+	cursor, err := c.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: DbAttrHistoryDeviceID, Value: id}}}},
+		{{Key: "$group", Value: bson.D{{Key: DbDevId, Value: bson.D{
+			{Key: DbAttrHistoryScope, Value: "$" + DbAttrHistoryScope},
+			{Key: DbAttrHistoryName, Value: "$" + DbAttrHistoryName},
+		}}}}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$" + DbDevId}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := []model.AttributeKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (db *DataStoreMongo) DeleteAttributeHistory(
+	ctx context.Context,
+	ids []model.DeviceID,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	c := db.client.Database(mstore.DbFromContext(ctx, DbName)).
+		Collection(DbAttributeHistoryColl)
+
+	_, err := c.DeleteMany(ctx, bson.D{{
+		Key: DbAttrHistoryDeviceID, Value: bson.D{{Key: "$in", Value: ids}},
+	}})
+	return err
+}
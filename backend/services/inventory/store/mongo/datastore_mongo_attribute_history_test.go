@@ -0,0 +1,117 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/services/inventory/model"
+)
+
+func TestMongoAttributeHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestMongoAttributeHistory in short mode.")
+	}
+
+	db.Wipe()
+	store := NewDataStoreMongoWithSession(db.Client())
+	ctx := db.CTX()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	changes := []model.AttributeChange{{
+		DeviceID:  "1",
+		Scope:     model.AttrScopeInventory,
+		Name:      "artifact_name",
+		OldValue:  "release-1",
+		NewValue:  "release-2",
+		Timestamp: now.Add(-2 * time.Hour),
+		ExpireTs:  now.Add(time.Hour),
+	}, {
+		DeviceID:  "1",
+		Scope:     model.AttrScopeInventory,
+		Name:      "artifact_name",
+		OldValue:  "release-2",
+		NewValue:  "release-3",
+		Timestamp: now.Add(-time.Hour),
+		ExpireTs:  now.Add(time.Hour),
+	}, {
+		DeviceID:  "1",
+		Scope:     model.AttrScopeTags,
+		Name:      "location",
+		NewValue:  "office",
+		Timestamp: now,
+		ExpireTs:  now.Add(time.Hour),
+	}, {
+		DeviceID:  "2",
+		Scope:     model.AttrScopeInventory,
+		Name:      "artifact_name",
+		NewValue:  "release-1",
+		Timestamp: now,
+		ExpireTs:  now.Add(time.Hour),
+	}}
+	err := store.InsertAttributeChanges(ctx, changes)
+	assert.NoError(t, err)
+
+	res, count, err := store.GetAttributeHistory(ctx, "1",
+		model.AttributeHistoryFilter{Page: 1, PerPage: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []model.AttributeChange{changes[2], changes[1], changes[0]}, res)
+
+	from := now.Add(-90 * time.Minute)
+	res, count, err = store.GetAttributeHistory(ctx, "1",
+		model.AttributeHistoryFilter{
+			Scope:   model.AttrScopeInventory,
+			Name:    "artifact_name",
+			From:    &from,
+			Page:    1,
+			PerPage: 20,
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []model.AttributeChange{changes[1]}, res)
+
+	res, count, err = store.GetAttributeHistory(ctx, "1",
+		model.AttributeHistoryFilter{Page: 2, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []model.AttributeChange{changes[0]}, res)
+
+	keys, err := store.GetTrackedAttributes(ctx, "1")
+	assert.NoError(t, err)
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	assert.Equal(t, []model.AttributeKey{
+		{Scope: model.AttrScopeInventory, Name: "artifact_name"},
+		{Scope: model.AttrScopeTags, Name: "location"},
+	}, keys)
+
+	err = store.DeleteAttributeHistory(ctx, []model.DeviceID{"1"})
+	assert.NoError(t, err)
+
+	res, count, err = store.GetAttributeHistory(ctx, "1",
+		model.AttributeHistoryFilter{Page: 1, PerPage: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, res)
+
+	_, count, err = store.GetAttributeHistory(ctx, "2",
+		model.AttributeHistoryFilter{Page: 1, PerPage: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
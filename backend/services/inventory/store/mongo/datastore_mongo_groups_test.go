@@ -0,0 +1,114 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/services/inventory/model"
+	"github.com/mendersoftware/mender-server/services/inventory/store"
+)
+
+func TestMongoNestedGroups(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestMongoNestedGroups in short mode.")
+	}
+
+	db.Wipe()
+	client := db.Client()
+	ds := NewDataStoreMongoWithSession(client)
+	ctx := db.CTX()
+
+	for _, dev := range []model.Device{
+		{ID: "1", Group: "europe/norway/oslo"},
+		{ID: "2", Group: "europe/norway"},
+		{ID: "3", Group: "europe-north"},
+		{ID: "4"},
+	} {
+		_, err := client.Database(DbName).
+			Collection(DbDevicesColl).
+			InsertOne(ctx, dev)
+		assert.NoError(t, err, "failed to setup input data")
+	}
+
+	res, err := ds.SetDeviceGroups(ctx, "4", []model.GroupName{"rev-b", "europe/sweden"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.MatchedCount)
+
+	dev, err := ds.GetDevice(ctx, "4")
+	assert.NoError(t, err)
+	assert.Equal(t, model.GroupName("rev-b"), dev.Group)
+	assert.Equal(t, []model.GroupName{"europe/sweden"}, dev.Groups)
+
+	ids, count, err := ds.GetDevicesByGroup(ctx, "europe", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.ElementsMatch(t, []model.DeviceID{"1", "2", "4"}, ids)
+
+	ids, _, err = ds.GetDevicesByGroup(ctx, "europe/norway", 0, 10)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []model.DeviceID{"1", "2"}, ids)
+
+	_, _, err = ds.GetDevicesByGroup(ctx, "asia", 0, 10)
+	assert.Equal(t, store.ErrGroupNotFound, err)
+
+	devs, count, err := ds.SearchDevices(ctx, model.SearchParams{
+		Filters: []model.FilterPredicate{{
+			Scope:     model.AttrScopeSystem,
+			Attribute: model.AttrNameGroup,
+			Type:      "$eq",
+			Value:     "europe/sweden",
+		}},
+		Page:    1,
+		PerPage: 10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	if assert.Len(t, devs, 1) {
+		assert.Equal(t, model.DeviceID("4"), devs[0].ID)
+	}
+
+	groups, err := ds.ListGroups(ctx, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []model.GroupName{
+		"europe/norway/oslo", "europe/norway", "europe-north", "rev-b", "europe/sweden",
+	}, groups)
+
+	// moving the device to its additional group makes it the primary one
+	_, err = ds.UpdateDevicesGroup(ctx, []model.DeviceID{"4"}, "europe/sweden")
+	assert.NoError(t, err)
+	dev, err = ds.GetDevice(ctx, "4")
+	assert.NoError(t, err)
+	assert.Equal(t, model.GroupName("europe/sweden"), dev.Group)
+	assert.Empty(t, dev.Groups)
+
+	_, err = ds.SetDeviceGroups(ctx, "4", []model.GroupName{"rev-b", "europe/sweden"})
+	assert.NoError(t, err)
+	res, err = ds.UnsetDevicesGroup(ctx, []model.DeviceID{"4"}, "europe/sweden")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.MatchedCount)
+	dev, err = ds.GetDevice(ctx, "4")
+	assert.NoError(t, err)
+	assert.Equal(t, model.GroupName("rev-b"), dev.Group)
+	assert.Empty(t, dev.Groups)
+
+	hasGroup := false
+	devs, count, err = ds.GetDevices(ctx, store.ListQuery{HasGroup: &hasGroup})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, devs)
+}
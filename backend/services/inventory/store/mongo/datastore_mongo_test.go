@@ -5082,3 +5082,30 @@ func TestMongoUpsertDevicesAttributesWithRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestMongoDeleteTenant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestMongoDeleteTenant in short mode.")
+	}
+
+	db.Wipe()
+	client := db.Client()
+	ctx := db.CTX()
+
+	for _, tenantID := range []string{"foo", "bar"} {
+		_, err := client.Database(mstore.DbNameForTenant(tenantID, DbName)).
+			Collection(DbDevicesColl).
+			InsertOne(ctx, model.Device{ID: model.DeviceID(tenantID)})
+		assert.NoError(t, err, "failed to setup input data")
+	}
+
+	store := NewDataStoreMongoWithSession(client)
+	assert.EqualError(t, store.DeleteTenant(ctx, ""), "tenant ID is required")
+	assert.NoError(t, store.DeleteTenant(ctx, "foo"))
+
+	names, err := client.ListDatabaseNames(ctx, bson.M{
+		"name": bson.M{"$regex": "^" + DbName + "-"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{mstore.DbNameForTenant("bar", DbName)}, names)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+)
+
+const (
+	IndexNameAttrHistoryDevice = "device_id_timestamp"
+	IndexNameAttrHistoryExpire = "expire_ts"
+)
+
+// migration_1_2_0 creates the indexes of the attribute history collection:
+// the changes are looked up by device and expire at their expire_ts.
+type migration_1_2_0 struct {
+	ms  *DataStoreMongo
+	ctx context.Context
+}
+
+func (m *migration_1_2_0) Up(from migrate.Version) error {
+	databaseName := mstore.DbFromContext(m.ctx, DbName)
+	coll := m.ms.client.Database(databaseName).Collection(DbAttributeHistoryColl)
+
+	_, err := coll.Indexes().CreateMany(m.ctx, []mongo.IndexModel{{
+		Keys: bson.D{
+			{Key: DbAttrHistoryDeviceID, Value: 1},
+			{Key: DbAttrHistoryTimestamp, Value: -1},
+		},
+		Options: mopts.Index().SetName(IndexNameAttrHistoryDevice),
+	}, {
+		Keys: bson.D{
+			{Key: DbAttrHistoryExpireTs, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameAttrHistoryExpire).
+			SetExpireAfterSeconds(0),
+	}})
+	return err
+}
+
+func (m *migration_1_2_0) Version() migrate.Version {
+	return migrate.MakeVersion(1, 2, 0)
+}
@@ -83,6 +83,14 @@ func (db *DataStoreMongo) MigrateTenant(
 			ms:  db,
 			ctx: ctx,
 		},
+		&migration_1_2_0{
+			ms:  db,
+			ctx: ctx,
+		},
+		&migration_1_3_0{
+			ms:  db,
+			ctx: ctx,
+		},
 	}
 
 	err = m.Apply(ctx, *ver, migrations)
@@ -167,6 +175,17 @@ func (db *DataStoreMongo) upgradeTenant(
 
 }
 
+func (db *DataStoreMongo) DeleteTenant(ctx context.Context, tenantId string) error {
+	if tenantId == "" {
+		return errors.New("tenant ID is required")
+	}
+	database := mstore.DbNameForTenant(tenantId, DbName)
+	if err := db.client.Database(database).Drop(ctx); err != nil {
+		return errors.Wrapf(err, "failed to drop database %s", database)
+	}
+	return nil
+}
+
 func (db *DataStoreMongo) Maintenance(
 	ctx context.Context,
 	version string,
@@ -128,6 +128,101 @@ func (h *InternalHandler) DecommissionDevice(c *gin.Context) {
 	}
 }
 
+// POST /tenants/:tenant_id/devices/:device_id/inventory/sync
+func (h *InternalHandler) SyncDeviceInventory(c *gin.Context) {
+	deviceID := c.Param(ParamDeviceID)
+	tenantID := c.Param(ParamTenantID)
+
+	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
+		Subject: deviceID,
+		Tenant:  tenantID,
+	})
+	err := h.app.SyncDeviceInventory(ctx, deviceID)
+	switch errors.Cause(err) {
+	case nil:
+		c.Status(http.StatusNoContent)
+	case app.ErrDeviceNotFound:
+		rest.RenderError(c, http.StatusNotFound, err)
+	default:
+		rest.RenderError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// PUT /tenants/:tenant_id/devices/:device_id/configuration
+func (h *InternalHandler) SetDeviceConfiguration(c *gin.Context) {
+	deviceID := c.Param(ParamDeviceID)
+	tenantID := c.Param(ParamTenantID)
+
+	var configuration model.DeviceConfiguration
+	if err := c.ShouldBindJSON(&configuration); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+
+	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
+		Subject: deviceID,
+		Tenant:  tenantID,
+	})
+	err := h.app.SetDeviceConfiguration(ctx, deviceID, configuration)
+	switch errors.Cause(err) {
+	case nil:
+		c.Status(http.StatusNoContent)
+	case app.ErrDeviceNotFound:
+		rest.RenderError(c, http.StatusNotFound, err)
+	case app.ErrDeviceStateConflict:
+		rest.RenderError(c, http.StatusConflict, err)
+	default:
+		rest.RenderError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// POST /tenants/:tenant_id/devices/:device_id/configuration/sync
+func (h *InternalHandler) SyncDeviceConfiguration(c *gin.Context) {
+	deviceID := c.Param(ParamDeviceID)
+	tenantID := c.Param(ParamTenantID)
+
+	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
+		Subject: deviceID,
+		Tenant:  tenantID,
+	})
+	err := h.app.SyncDeviceConfiguration(ctx, deviceID)
+	switch errors.Cause(err) {
+	case nil:
+		c.Status(http.StatusNoContent)
+	case app.ErrDeviceNotFound:
+		rest.RenderError(c, http.StatusNotFound, err)
+	case app.ErrDeviceStateConflict:
+		rest.RenderError(c, http.StatusConflict, err)
+	default:
+		rest.RenderError(c, http.StatusInternalServerError, err)
+	}
+}
+
+// PUT /tenants/:tenant_id/devices/:device_id/connectivity
+func (h *InternalHandler) SetDeviceConnectivity(c *gin.Context) {
+	deviceID := c.Param(ParamDeviceID)
+	tenantID := c.Param(ParamTenantID)
+
+	var connectivity model.DeviceConnectivity
+	if err := c.ShouldBindJSON(&connectivity); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+
+	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
+		Subject: deviceID,
+		Tenant:  tenantID,
+	})
+	_ = h.app.SetDeviceConnectivity(ctx, deviceID, connectivity)
+	c.Status(http.StatusAccepted)
+}
+
 const (
 	maxBulkItems = 100
 )
@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
@@ -319,6 +320,339 @@ func TestDecommissionDevice(t *testing.T) {
 	}
 }
 
+func TestSyncDeviceInventory(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		TenantID string
+		DeviceID string
+		App      func(*testing.T, *testCase) *mapp.App
+
+		StatusCode int
+		Error      error
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SyncDeviceInventory",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID).
+				Return(nil)
+			return mock
+		},
+
+		StatusCode: http.StatusNoContent,
+	}, {
+		Name: "error/not found",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SyncDeviceInventory",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID).
+				Return(app.ErrDeviceNotFound)
+			return mock
+		},
+
+		StatusCode: http.StatusNotFound,
+		Error:      app.ErrDeviceNotFound,
+	}, {
+		Name: "error/internal failure",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SyncDeviceInventory",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID).
+				Return(errors.New("internal error"))
+			return mock
+		},
+
+		StatusCode: http.StatusInternalServerError,
+		Error:      errors.New("internal error"),
+	}}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			w := httptest.NewRecorder()
+			handler := NewRouter(app)
+
+			repl := strings.NewReplacer(
+				":tenant_id", tc.TenantID,
+				":device_id", tc.DeviceID,
+			)
+
+			req, _ := http.NewRequest(http.MethodPost,
+				"http://localhost"+
+					APIURLInternal+
+					repl.Replace(APIURLTenantDeviceSync),
+				nil,
+			)
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.StatusCode, w.Code)
+
+			if tc.Error != nil {
+				var err rest.Error
+				json.Unmarshal(w.Body.Bytes(), &err)
+				assert.Regexp(t, tc.Error.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestSetDeviceConfiguration(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		TenantID string
+		DeviceID string
+		Body     interface{}
+		App      func(*testing.T, *testCase) *mapp.App
+
+		StatusCode int
+		Error      error
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+		Body:     map[string]string{"ssid": "mender"},
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SetDeviceConfiguration",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID,
+				model.DeviceConfiguration{"ssid": "mender"}).
+				Return(nil)
+			return mock
+		},
+
+		StatusCode: http.StatusNoContent,
+	}, {
+		Name: "error/malformed body",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+		Body:     map[string]int{"channel": 6},
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			return new(mapp.App)
+		},
+
+		StatusCode: http.StatusBadRequest,
+		Error:      errors.New("malformed request body"),
+	}, {
+		Name: "error/not found",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+		Body:     map[string]string{},
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SetDeviceConfiguration",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID,
+				model.DeviceConfiguration{}).
+				Return(app.ErrDeviceNotFound)
+			return mock
+		},
+
+		StatusCode: http.StatusNotFound,
+		Error:      app.ErrDeviceNotFound,
+	}, {
+		Name: "error/conflict",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+		Body:     map[string]string{},
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SetDeviceConfiguration",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID,
+				model.DeviceConfiguration{}).
+				Return(app.ErrDeviceStateConflict)
+			return mock
+		},
+
+		StatusCode: http.StatusConflict,
+		Error:      app.ErrDeviceStateConflict,
+	}, {
+		Name: "error/internal failure",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+		Body:     map[string]string{},
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SetDeviceConfiguration",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID,
+				model.DeviceConfiguration{}).
+				Return(errors.New("internal error"))
+			return mock
+		},
+
+		StatusCode: http.StatusInternalServerError,
+		Error:      errors.New("internal error"),
+	}}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			w := httptest.NewRecorder()
+			handler := NewRouter(app)
+
+			repl := strings.NewReplacer(
+				":tenant_id", tc.TenantID,
+				":device_id", tc.DeviceID,
+			)
+			b, _ := json.Marshal(tc.Body)
+			req, _ := http.NewRequest(http.MethodPut,
+				"http://localhost"+
+					APIURLInternal+
+					repl.Replace(APIURLTenantDeviceConf),
+				bytes.NewReader(b),
+			)
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.StatusCode, w.Code)
+
+			if tc.Error != nil {
+				var err rest.Error
+				json.Unmarshal(w.Body.Bytes(), &err)
+				assert.Regexp(t, tc.Error.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestSyncDeviceConfiguration(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		TenantID string
+		DeviceID string
+		App      func(*testing.T, *testCase) *mapp.App
+
+		StatusCode int
+		Error      error
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SyncDeviceConfiguration",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID).
+				Return(nil)
+			return mock
+		},
+
+		StatusCode: http.StatusNoContent,
+	}, {
+		Name: "error/not found",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SyncDeviceConfiguration",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID).
+				Return(app.ErrDeviceNotFound)
+			return mock
+		},
+
+		StatusCode: http.StatusNotFound,
+		Error:      app.ErrDeviceNotFound,
+	}, {
+		Name: "error/internal failure",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "a8d77d55-ebaa-4ace-b9d4-a2bb581d87f8",
+
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mock := new(mapp.App)
+			mock.On("SyncDeviceConfiguration",
+				validateTenantIDCtx(self.TenantID),
+				self.DeviceID).
+				Return(errors.New("internal error"))
+			return mock
+		},
+
+		StatusCode: http.StatusInternalServerError,
+		Error:      errors.New("internal error"),
+	}}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			w := httptest.NewRecorder()
+			handler := NewRouter(app)
+
+			repl := strings.NewReplacer(
+				":tenant_id", tc.TenantID,
+				":device_id", tc.DeviceID,
+			)
+
+			req, _ := http.NewRequest(http.MethodPost,
+				"http://localhost"+
+					APIURLInternal+
+					repl.Replace(APIURLTenantConfSync),
+				nil,
+			)
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.StatusCode, w.Code)
+
+			if tc.Error != nil {
+				var err rest.Error
+				json.Unmarshal(w.Body.Bytes(), &err)
+				assert.Regexp(t, tc.Error.Error(), err.Error())
+			}
+		})
+	}
+}
+
 func TestBulkSetDeviceStatus(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -493,6 +827,133 @@ func TestBulkSetDeviceStatus(t *testing.T) {
 	}
 }
 
+func TestSetDeviceConnectivity(t *testing.T) {
+	t.Parallel()
+	offlineSince := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	type testCase struct {
+		Name string
+
+		TenantID string
+		DeviceID string
+		ReqBody  interface{}
+		App      func(t *testing.T, self *testCase) *mapp.App
+
+		StatusCode int
+		Response   interface{}
+	}
+	testCases := []testCase{{
+		Name: "ok, offline",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "960700f7-d563-4a31-94e6-a075fe6566bc",
+		ReqBody: map[string]interface{}{
+			"offline":       true,
+			"offline_since": offlineSince,
+			"check_in_time": offlineSince,
+		},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mockApp := new(mapp.App)
+			mockApp.On("SetDeviceConnectivity",
+				mock.MatchedBy(func(ctx context.Context) bool {
+					id := identity.FromContext(ctx)
+					return assert.NotNil(t, id) &&
+						assert.Equal(t, self.TenantID, id.Tenant)
+				}),
+				self.DeviceID,
+				model.DeviceConnectivity{
+					Offline:      true,
+					OfflineSince: &offlineSince,
+					CheckInTime:  &offlineSince,
+				},
+			).Return(nil)
+			return mockApp
+		},
+		StatusCode: http.StatusAccepted,
+	}, {
+		Name: "ok, online",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "960700f7-d563-4a31-94e6-a075fe6566bc",
+		ReqBody: map[string]interface{}{
+			"offline": false,
+		},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			mockApp := new(mapp.App)
+			mockApp.On("SetDeviceConnectivity",
+				contextMatcher,
+				self.DeviceID,
+				model.DeviceConnectivity{},
+			).Return(nil)
+			return mockApp
+		},
+		StatusCode: http.StatusAccepted,
+	}, {
+		Name: "error: invalid request body",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "960700f7-d563-4a31-94e6-a075fe6566bc",
+		ReqBody:  []byte("rawr"),
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			return new(mapp.App)
+		},
+		StatusCode: http.StatusBadRequest,
+		Response:   regexp.MustCompile(`{"error":\s?"malformed request body.*",\s?"request_id":\s?"test"}`),
+	}, {
+		Name: "error: offline without timestamp",
+
+		TenantID: "123456789012345678901234",
+		DeviceID: "960700f7-d563-4a31-94e6-a075fe6566bc",
+		ReqBody: map[string]interface{}{
+			"offline": true,
+		},
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			return new(mapp.App)
+		},
+		StatusCode: http.StatusBadRequest,
+		Response:   regexp.MustCompile(`{"error":\s?"malformed request body: offline_since: cannot be blank.*",\s?"request_id":\s?"test"}`),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			w := httptest.NewRecorder()
+			handler := NewRouter(app)
+			repl := strings.NewReplacer(
+				":tenant_id", tc.TenantID,
+				":device_id", tc.DeviceID,
+			)
+			var b []byte
+			switch t := tc.ReqBody.(type) {
+			case []byte:
+				b = t
+			default:
+				b, _ = json.Marshal(tc.ReqBody)
+			}
+			req, _ := http.NewRequest(
+				http.MethodPut,
+				"http://localhost"+
+					APIURLInternal+
+					repl.Replace(APIURLTenantDeviceConn),
+				bytes.NewReader(b),
+			)
+			req.Header.Set("X-Men-Requestid", "test")
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.StatusCode, w.Code)
+			switch res := tc.Response.(type) {
+			case nil:
+				assert.Empty(t, w.Body.Bytes())
+			case *regexp.Regexp:
+				assert.Regexp(t, res, w.Body.String())
+			}
+		})
+	}
+}
+
 func TestPreauthorize(t *testing.T) {
 	t.Parallel()
 	type AuthRequest struct {
@@ -188,6 +188,103 @@ func (h *ManagementHandler) SetIntegrationCredentials(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// PUT /integrations/{id}/inventory-sync
+func (h *ManagementHandler) SetIntegrationInventorySync(c *gin.Context) {
+	ctx, _, err := getContextAndIdentity(c)
+	if err != nil {
+		return
+	}
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "integration ID must be a valid UUID"),
+		)
+		return
+	}
+
+	inventorySync := model.InventorySync{}
+	if err := c.ShouldBindJSON(&inventorySync); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+
+	err = h.app.SetIntegrationInventorySync(ctx, integrationID, &inventorySync)
+	if err != nil {
+		switch cause := errors.Cause(err); cause {
+		case app.ErrIntegrationNotFound:
+			rest.RenderError(c, http.StatusNotFound, ErrIntegrationNotFound)
+		case app.ErrInventorySyncNotSupported:
+			rest.RenderError(c, http.StatusBadRequest, cause)
+		default:
+			rest.RenderError(c,
+				http.StatusInternalServerError,
+				err,
+			)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PUT /integrations/{id}/config-sync
+func (h *ManagementHandler) SetIntegrationConfigSync(c *gin.Context) {
+	configSync := model.ConfigSync{}
+	if err := c.ShouldBindJSON(&configSync); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	h.setIntegrationConfigSync(c, &configSync)
+}
+
+// DELETE /integrations/{id}/config-sync
+func (h *ManagementHandler) RemoveIntegrationConfigSync(c *gin.Context) {
+	h.setIntegrationConfigSync(c, nil)
+}
+
+func (h *ManagementHandler) setIntegrationConfigSync(
+	c *gin.Context,
+	configSync *model.ConfigSync,
+) {
+	ctx, _, err := getContextAndIdentity(c)
+	if err != nil {
+		return
+	}
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "integration ID must be a valid UUID"),
+		)
+		return
+	}
+
+	err = h.app.SetIntegrationConfigSync(ctx, integrationID, configSync)
+	if err != nil {
+		switch cause := errors.Cause(err); cause {
+		case app.ErrIntegrationNotFound:
+			rest.RenderError(c, http.StatusNotFound, ErrIntegrationNotFound)
+		case app.ErrConfigSyncNotSupported:
+			rest.RenderError(c, http.StatusBadRequest, cause)
+		default:
+			rest.RenderError(c,
+				http.StatusInternalServerError,
+				err,
+			)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // DELETE /integrations/{id}
 func (h *ManagementHandler) RemoveIntegration(c *gin.Context) {
 	ctx, _, err := getContextAndIdentity(c)
@@ -781,6 +781,341 @@ func TestSetIntegrationCredentials(t *testing.T) {
 	}
 }
 
+func TestSetIntegrationInventorySync(t *testing.T) {
+	t.Parallel()
+	integrationID := uuid.NewSHA1(uuid.NameSpaceOID, []byte("integration"))
+	userAuth := http.Header{
+		"Authorization": []string{"Bearer " + GenerateJWT(identity.Identity{
+			Subject: uuid.NewSHA1(uuid.NameSpaceOID, []byte{'2'}).String(),
+			Tenant:  "123456789012345678901234",
+			IsUser:  true,
+		})},
+	}
+	requestBody := map[string]interface{}{
+		"attributes": []map[string]interface{}{{
+			"property":  "firmware.version",
+			"attribute": "firmware_version",
+		}},
+	}
+	inventorySync := &model.InventorySync{
+		Attributes: []model.InventorySyncAttribute{{
+			Property:  "firmware.version",
+			Attribute: "firmware_version",
+		}},
+	}
+
+	type testCase struct {
+		Name string
+
+		IntegrationID string
+		Header        http.Header
+		RequestBody   interface{}
+		App           func(t *testing.T, self *testCase) *mapp.App
+
+		Code  int
+		Error error
+	}
+
+	testCases := []testCase{{
+		Name: "ok",
+
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetIntegrationInventorySync",
+				contextMatcher, integrationID, inventorySync).
+				Return(nil)
+			return app
+		},
+
+		Code: http.StatusNoContent,
+	}, {
+		Name: "error, cannot parse path param",
+
+		IntegrationID: "invalid_uuid",
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App:           func(t *testing.T, self *testCase) *mapp.App { return new(mapp.App) },
+
+		Code:  http.StatusBadRequest,
+		Error: errors.New("integration ID must be a valid UUID"),
+	}, {
+		Name: "error, malformed request body",
+
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody: map[string]interface{}{
+			"attributes": []map[string]interface{}{{
+				"property": "firmware..version",
+			}},
+		},
+		App: func(t *testing.T, self *testCase) *mapp.App { return new(mapp.App) },
+
+		Code:  http.StatusBadRequest,
+		Error: errors.New("malformed request body: attributes: "),
+	}, {
+		Name: "error, provider not supported",
+
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			appie := new(mapp.App)
+			appie.On("SetIntegrationInventorySync",
+				contextMatcher, integrationID, inventorySync).
+				Return(app.ErrInventorySyncNotSupported)
+			return appie
+		},
+
+		Code:  http.StatusBadRequest,
+		Error: app.ErrInventorySyncNotSupported,
+	}, {
+		Name: "error, integration not found",
+
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			appie := new(mapp.App)
+			appie.On("SetIntegrationInventorySync",
+				contextMatcher, integrationID, inventorySync).
+				Return(app.ErrIntegrationNotFound)
+			return appie
+		},
+
+		Code:  http.StatusNotFound,
+		Error: ErrIntegrationNotFound,
+	}, {
+		Name: "error, internal server error",
+
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			appie := new(mapp.App)
+			appie.On("SetIntegrationInventorySync",
+				contextMatcher, integrationID, inventorySync).
+				Return(errors.New("internal error"))
+			return appie
+		},
+
+		Code:  http.StatusInternalServerError,
+		Error: errors.New("internal error"),
+	}}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			repl := strings.NewReplacer(":id", tc.IntegrationID)
+			b, _ := json.Marshal(tc.RequestBody)
+			req, _ := http.NewRequest(
+				http.MethodPut,
+				"http://localhost"+APIURLManagement+
+					repl.Replace(APIURLIntegrationInvSync),
+				bytes.NewReader(b),
+			)
+			for k, v := range tc.Header {
+				req.Header[k] = v
+			}
+
+			w := httptest.NewRecorder()
+			handler := NewRouter(app)
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.Code, w.Code, "invalid HTTP status code")
+
+			if tc.Error != nil {
+				var erro rest.Error
+				err := json.Unmarshal(w.Body.Bytes(), &erro)
+				require.NoError(t, err)
+				assert.Regexp(t, tc.Error.Error(), erro.Error())
+			} else {
+				assert.Empty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestSetIntegrationConfigSync(t *testing.T) {
+	t.Parallel()
+	integrationID := uuid.NewSHA1(uuid.NameSpaceOID, []byte("integration"))
+	userAuth := http.Header{
+		"Authorization": []string{"Bearer " + GenerateJWT(identity.Identity{
+			Subject: uuid.NewSHA1(uuid.NameSpaceOID, []byte{'2'}).String(),
+			Tenant:  "123456789012345678901234",
+			IsUser:  true,
+		})},
+	}
+	requestBody := map[string]interface{}{
+		"property": "mender",
+		"conflict": "cloud",
+	}
+	configSync := &model.ConfigSync{
+		Property: "mender",
+		Conflict: model.ConfigSyncConflictCloud,
+	}
+
+	type testCase struct {
+		Name string
+
+		Method        string
+		IntegrationID string
+		Header        http.Header
+		RequestBody   interface{}
+		App           func(t *testing.T, self *testCase) *mapp.App
+
+		Code  int
+		Error error
+	}
+
+	testCases := []testCase{{
+		Name: "ok",
+
+		Method:        http.MethodPut,
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetIntegrationConfigSync",
+				contextMatcher, integrationID, configSync).
+				Return(nil)
+			return app
+		},
+
+		Code: http.StatusNoContent,
+	}, {
+		Name: "ok, disable",
+
+		Method:        http.MethodDelete,
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("SetIntegrationConfigSync",
+				contextMatcher, integrationID, (*model.ConfigSync)(nil)).
+				Return(nil)
+			return app
+		},
+
+		Code: http.StatusNoContent,
+	}, {
+		Name: "error, cannot parse path param",
+
+		Method:        http.MethodDelete,
+		IntegrationID: "invalid_uuid",
+		Header:        userAuth,
+		App:           func(t *testing.T, self *testCase) *mapp.App { return new(mapp.App) },
+
+		Code:  http.StatusBadRequest,
+		Error: errors.New("integration ID must be a valid UUID"),
+	}, {
+		Name: "error, malformed request body",
+
+		Method:        http.MethodPut,
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody: map[string]interface{}{
+			"conflict": "device",
+		},
+		App: func(t *testing.T, self *testCase) *mapp.App { return new(mapp.App) },
+
+		Code:  http.StatusBadRequest,
+		Error: errors.New("malformed request body: conflict: "),
+	}, {
+		Name: "error, provider not supported",
+
+		Method:        http.MethodPut,
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			appie := new(mapp.App)
+			appie.On("SetIntegrationConfigSync",
+				contextMatcher, integrationID, configSync).
+				Return(app.ErrConfigSyncNotSupported)
+			return appie
+		},
+
+		Code:  http.StatusBadRequest,
+		Error: app.ErrConfigSyncNotSupported,
+	}, {
+		Name: "error, integration not found",
+
+		Method:        http.MethodPut,
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			appie := new(mapp.App)
+			appie.On("SetIntegrationConfigSync",
+				contextMatcher, integrationID, configSync).
+				Return(app.ErrIntegrationNotFound)
+			return appie
+		},
+
+		Code:  http.StatusNotFound,
+		Error: ErrIntegrationNotFound,
+	}, {
+		Name: "error, internal server error",
+
+		Method:        http.MethodPut,
+		IntegrationID: integrationID.String(),
+		Header:        userAuth,
+		RequestBody:   requestBody,
+		App: func(t *testing.T, self *testCase) *mapp.App {
+			appie := new(mapp.App)
+			appie.On("SetIntegrationConfigSync",
+				contextMatcher, integrationID, configSync).
+				Return(errors.New("internal error"))
+			return appie
+		},
+
+		Code:  http.StatusInternalServerError,
+		Error: errors.New("internal error"),
+	}}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t, &tc)
+			defer app.AssertExpectations(t)
+			repl := strings.NewReplacer(":id", tc.IntegrationID)
+			b, _ := json.Marshal(tc.RequestBody)
+			req, _ := http.NewRequest(
+				tc.Method,
+				"http://localhost"+APIURLManagement+
+					repl.Replace(APIURLIntegrationConfSync),
+				bytes.NewReader(b),
+			)
+			for k, v := range tc.Header {
+				req.Header[k] = v
+			}
+
+			w := httptest.NewRecorder()
+			handler := NewRouter(app)
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.Code, w.Code, "invalid HTTP status code")
+
+			if tc.Error != nil {
+				var erro rest.Error
+				err := json.Unmarshal(w.Body.Bytes(), &erro)
+				require.NoError(t, err)
+				assert.Regexp(t, tc.Error.Error(), erro.Error())
+			} else {
+				assert.Empty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
 func TestRemoveIntegration(t *testing.T) {
 	t.Parallel()
 	integrationID := uuid.NewSHA1(uuid.NameSpaceOID, []byte("integration"))
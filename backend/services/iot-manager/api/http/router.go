@@ -45,6 +45,10 @@ const (
 	APIURLTenantAuth        = APIURLTenant + "/auth"
 	APIURLTenantDevices     = APIURLTenant + "/devices"
 	APIURLTenantDevice      = APIURLTenantDevices + "/:device_id"
+	APIURLTenantDeviceSync  = APIURLTenantDevice + "/inventory/sync"
+	APIURLTenantDeviceConf  = APIURLTenantDevice + "/configuration"
+	APIURLTenantConfSync    = APIURLTenantDeviceConf + "/sync"
+	APIURLTenantDeviceConn  = APIURLTenantDevice + "/connectivity"
 	APIURLTenantBulkDevices = APIURLTenant + "/bulk/devices"
 	APIURLTenantBulkStatus  = APIURLTenantBulkDevices + "/status/:status"
 
@@ -53,6 +57,8 @@ const (
 	APIURLIntegrations           = "/integrations"
 	APIURLIntegration            = "/integrations/:id"
 	APIURLIntegrationCredentials = APIURLIntegration + "/credentials"
+	APIURLIntegrationInvSync     = APIURLIntegration + "/inventory-sync"
+	APIURLIntegrationConfSync    = APIURLIntegration + "/config-sync"
 
 	APIURLDevice                 = "/devices/:id"
 	APIURLDeviceState            = APIURLDevice + "/state"
@@ -119,6 +125,10 @@ func NewRouter(
 	internalAPI.DELETE(APIURLTenant, internal.DeleteTenant)
 	internalAPI.POST(APIURLTenantDevices, internal.ProvisionDevice)
 	internalAPI.DELETE(APIURLTenantDevice, internal.DecommissionDevice)
+	internalAPI.POST(APIURLTenantDeviceSync, internal.SyncDeviceInventory)
+	internalAPI.PUT(APIURLTenantDeviceConf, internal.SetDeviceConfiguration)
+	internalAPI.POST(APIURLTenantConfSync, internal.SyncDeviceConfiguration)
+	internalAPI.PUT(APIURLTenantDeviceConn, internal.SetDeviceConnectivity)
 	internalAPI.PUT(APIURLTenantBulkStatus, internal.BulkSetDeviceStatus)
 
 	internalAPI.POST(APIURLTenantAuth, internal.PreauthorizeHandler)
@@ -128,6 +138,9 @@ func NewRouter(
 	managementAPI.GET(APIURLIntegration, management.GetIntegrationById)
 	managementAPI.POST(APIURLIntegrations, management.CreateIntegration)
 	managementAPI.PUT(APIURLIntegrationCredentials, management.SetIntegrationCredentials)
+	managementAPI.PUT(APIURLIntegrationInvSync, management.SetIntegrationInventorySync)
+	managementAPI.PUT(APIURLIntegrationConfSync, management.SetIntegrationConfigSync)
+	managementAPI.DELETE(APIURLIntegrationConfSync, management.RemoveIntegrationConfigSync)
 	managementAPI.DELETE(APIURLIntegration, management.RemoveIntegration)
 
 	managementAPI.GET(APIURLDeviceState, management.GetDeviceState)
@@ -29,6 +29,7 @@ import (
 	"github.com/mendersoftware/mender-server/services/iot-manager/client/devauth"
 	"github.com/mendersoftware/mender-server/services/iot-manager/client/iotcore"
 	"github.com/mendersoftware/mender-server/services/iot-manager/client/iothub"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/mqtt"
 	"github.com/mendersoftware/mender-server/services/iot-manager/client/workflows"
 	"github.com/mendersoftware/mender-server/services/iot-manager/model"
 	"github.com/mendersoftware/mender-server/services/iot-manager/store"
@@ -62,6 +63,7 @@ const (
 type App interface {
 	WithIoTCore(client iotcore.Client) App
 	WithIoTHub(client iothub.Client) App
+	WithMQTT(client mqtt.Client) App
 	WithWebhooksTimeout(timeout uint) App
 	HealthCheck(context.Context) error
 	GetDeviceIntegrations(context.Context, string) ([]model.Integration, error)
@@ -69,7 +71,10 @@ type App interface {
 	GetIntegrationById(context.Context, uuid.UUID) (*model.Integration, error)
 	CreateIntegration(context.Context, model.Integration) (*model.Integration, error)
 	SetDeviceStatus(context.Context, string, model.Status) error
+	SetDeviceConnectivity(context.Context, string, model.DeviceConnectivity) error
 	SetIntegrationCredentials(context.Context, uuid.UUID, model.Credentials) error
+	SetIntegrationInventorySync(context.Context, uuid.UUID, *model.InventorySync) error
+	SetIntegrationConfigSync(context.Context, uuid.UUID, *model.ConfigSync) error
 	RemoveIntegration(context.Context, uuid.UUID) error
 	GetDevice(context.Context, string) (*model.Device, error)
 	GetDeviceStateIntegration(context.Context, string, uuid.UUID) (*model.DeviceState, error)
@@ -83,6 +88,12 @@ type App interface {
 	DecommissionDevice(context.Context, string) error
 
 	SyncDevices(context.Context, int, bool) error
+	SyncDeviceInventory(ctx context.Context, deviceID string) error
+	SyncInventory(ctx context.Context, failEarly bool) error
+	SetDeviceConfiguration(ctx context.Context, deviceID string, configuration model.DeviceConfiguration) error
+	SyncDeviceConfiguration(ctx context.Context, deviceID string) error
+	SyncConfiguration(ctx context.Context, failEarly bool) error
+	SubscribeDesiredState(ctx context.Context, refreshInterval time.Duration) error
 
 	GetEvents(ctx context.Context, filter model.EventsFilter) ([]model.Event, error)
 	VerifyDeviceTwin(ctx context.Context, req model.PreauthRequest) error
@@ -93,6 +104,7 @@ type app struct {
 	store           store.DataStore
 	iothubClient    iothub.Client
 	iotcoreClient   iotcore.Client
+	mqttClient      mqtt.Client
 	wf              workflows.Client
 	devauth         devauth.Client
 	httpClient      *http.Client
@@ -110,6 +122,7 @@ func New(ds store.DataStore, wf workflows.Client, da devauth.Client) App {
 		wf:           wf,
 		devauth:      da,
 		iothubClient: hubClient,
+		mqttClient:   mqtt.NewClient(),
 		httpClient:   c,
 	}
 }
@@ -126,6 +139,12 @@ func (a *app) WithIoTCore(client iotcore.Client) App {
 	return a
 }
 
+// WithMQTT sets the MQTT client
+func (a *app) WithMQTT(client mqtt.Client) App {
+	a.mqttClient = client
+	return a
+}
+
 // WithWebhooksTimeout sets the timeout for webhooks requests
 func (a *app) WithWebhooksTimeout(timeout uint) App {
 	a.webhooksTimeout = time.Duration(timeout * uint(time.Second))
@@ -288,6 +307,119 @@ func (a *app) setDeviceStatus(ctx context.Context, deviceID string, status model
 			}
 			err = a.setDeviceStatusIoTCore(ctx, deviceID, status, integration)
 
+		case model.ProviderMQTT:
+			ok, err = device.HasIntegration(ctx, integration.ID)
+			if err != nil {
+				break // switch
+			} else if !ok {
+				continue // loop
+			}
+			err = a.publishMQTTEvent(ctx, deviceID, integration, event.WebhookEvent)
+
+		case model.ProviderWebhook:
+			var (
+				req *http.Request
+				rsp *http.Response
+			)
+			req, err = client.NewWebhookRequest(ctx,
+				&integration.Credentials,
+				event.WebhookEvent)
+			if err != nil {
+				break // switch
+			}
+			rsp, err = a.httpClient.Do(req)
+			if err != nil {
+				break // switch
+			}
+			deliver.StatusCode = &rsp.StatusCode
+			if rsp.StatusCode >= 300 {
+				err = client.NewHTTPError(rsp.StatusCode)
+			}
+			_ = rsp.Body.Close()
+
+		default:
+			continue
+		}
+		if err != nil {
+			var httpError client.HTTPError
+			if errors.As(err, &httpError) {
+				errCode := httpError.Code()
+				deliver.StatusCode = &errCode
+			}
+			deliver.Success = false
+			deliver.Error = err.Error()
+		}
+		event.DeliveryStatus = append(event.DeliveryStatus, deliver)
+	}
+	if len(integrations) > 0 {
+		err = a.store.SaveEvent(ctx, event)
+	}
+	return err
+}
+
+// SetDeviceConnectivity notifies the webhook and MQTT integrations that the
+// device went offline or came back online.
+func (a *app) SetDeviceConnectivity(
+	ctx context.Context,
+	deviceID string,
+	conn model.DeviceConnectivity,
+) error {
+	go func() {
+		ctxWithTimeout, cancel := context.WithTimeout(context.Background(), a.webhooksTimeout)
+		ctxWithTimeout = identity.WithContext(ctxWithTimeout, identity.FromContext(ctx))
+		defer cancel()
+		runAndLogError(ctxWithTimeout, func() error {
+			return a.setDeviceConnectivity(ctxWithTimeout, deviceID, conn)
+		})
+	}()
+	return nil
+}
+
+func (a *app) setDeviceConnectivity(
+	ctx context.Context,
+	deviceID string,
+	conn model.DeviceConnectivity,
+) error {
+	integrations, err := a.store.GetIntegrations(ctx, model.IntegrationFilter{})
+	if err != nil {
+		if errors.Is(err, store.ErrObjectNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to retrieve integrations")
+	}
+	event := model.Event{
+		WebhookEvent: model.WebhookEvent{
+			ID:   uuid.New(),
+			Type: conn.EventType(),
+			Data: model.DeviceEvent{
+				ID:           deviceID,
+				CheckInTime:  conn.CheckInTime,
+				OfflineSince: conn.OfflineSince,
+			},
+			EventTS: time.Now(),
+		},
+		DeliveryStatus: make([]model.DeliveryStatus, 0, len(integrations)),
+	}
+
+	var (
+		ok     bool
+		device = newDevice(deviceID, a.store)
+	)
+	for _, integration := range integrations {
+		deliver := model.DeliveryStatus{
+			IntegrationID: integration.ID,
+			Success:       true,
+		}
+		switch integration.Provider {
+		case model.ProviderMQTT:
+			ok, err = device.HasIntegration(ctx, integration.ID)
+			if err != nil {
+				break // switch
+			} else if !ok {
+				continue // loop
+			}
+			err = a.publishMQTTEvent(ctx, deviceID, integration, event.WebhookEvent)
+
 		case model.ProviderWebhook:
 			var (
 				req *http.Request
@@ -385,6 +517,9 @@ func (a *app) provisionDevice(
 				Status: iotcore.StatusEnabled,
 			})
 			integrationIDs = append(integrationIDs, integration.ID)
+		case model.ProviderMQTT:
+			err = a.publishMQTTEvent(ctx, device.ID, integration, event.WebhookEvent)
+			integrationIDs = append(integrationIDs, integration.ID)
 		case model.ProviderWebhook:
 			var (
 				req *http.Request
@@ -509,6 +644,14 @@ func (a *app) syncBatch(
 				}
 				l.Error(err)
 			}
+		case model.ProviderMQTT:
+			err := a.syncMQTTDevices(ctx, deviceIDs, *integration, failEarly)
+			if err != nil {
+				if failEarly {
+					return err
+				}
+				l.Error(err)
+			}
 		default:
 		}
 	}
@@ -656,6 +799,14 @@ func (a *app) decommissionDevice(ctx context.Context, deviceID string) error {
 				continue // loop
 			}
 			err = a.decommissionIoTCoreDevice(ctx, deviceID, integration)
+		case model.ProviderMQTT:
+			ok, err = device.HasIntegration(ctx, integration.ID)
+			if err != nil {
+				break // switch
+			} else if !ok {
+				continue // loop
+			}
+			err = a.publishMQTTEvent(ctx, deviceID, integration, event.WebhookEvent)
 		case model.ProviderWebhook:
 			var (
 				req *http.Request
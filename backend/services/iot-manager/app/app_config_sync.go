@@ -0,0 +1,333 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/client"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/iothub"
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+	"github.com/mendersoftware/mender-server/services/iot-manager/store"
+)
+
+var ErrConfigSyncNotSupported = errors.New(
+	"configuration synchronization is not supported by the integration provider")
+
+func (a *app) SetIntegrationConfigSync(
+	ctx context.Context,
+	integrationID uuid.UUID,
+	configSync *model.ConfigSync,
+) error {
+	integration, err := a.store.GetIntegrationById(ctx, integrationID)
+	if integration == nil && (err == nil || err == store.ErrObjectNotFound) {
+		return ErrIntegrationNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to retrieve the integration")
+	}
+	if !integration.Provider.HasDeviceState() {
+		return ErrConfigSyncNotSupported
+	}
+	err = a.store.SetIntegrationConfigSync(ctx, integrationID, configSync)
+	if errors.Cause(err) == store.ErrObjectNotFound {
+		return ErrIntegrationNotFound
+	}
+	return err
+}
+
+func (a *app) configSyncIntegrations(
+	ctx context.Context,
+	device *model.Device,
+) ([]*model.Integration, error) {
+	integrations := make([]*model.Integration, 0, len(device.IntegrationIDs))
+	for _, integrationID := range device.IntegrationIDs {
+		integration, err := a.store.GetIntegrationById(ctx, integrationID)
+		if err == store.ErrObjectNotFound {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve the integration")
+		}
+		if integration.ConfigSync != nil {
+			integrations = append(integrations, integration)
+		}
+	}
+	return integrations, nil
+}
+
+// SetDeviceConfiguration mirrors the configuration set in deviceconfig into
+// the desired state of the device for all the integrations of the device
+// with configuration synchronization enabled. If the desired state changed
+// in the cloud and the conflict rule gives precedence to the cloud, the
+// desired state is applied to the device configuration instead.
+func (a *app) SetDeviceConfiguration(
+	ctx context.Context,
+	deviceID string,
+	configuration model.DeviceConfiguration,
+) error {
+	device, err := a.store.GetDevice(ctx, deviceID)
+	if err == store.ErrObjectNotFound {
+		return ErrDeviceNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to retrieve the device")
+	}
+	integrations, err := a.configSyncIntegrations(ctx, device)
+	if err != nil || len(integrations) == 0 {
+		return err
+	}
+	if configuration == nil {
+		configuration = model.DeviceConfiguration{}
+	}
+	base := model.DeviceConfigState{}
+	if device.ConfigState != nil {
+		base = *device.ConfigState
+	}
+	for _, integration := range integrations {
+		state, err := a.getDeviceState(ctx, deviceID, integration)
+		if err != nil {
+			return err
+		} else if state == nil {
+			continue
+		}
+		sync := integration.ConfigSync
+		desired := sync.Configuration(state.Desired, base.Desired)
+		if device.ConfigState != nil &&
+			sync.ConflictRule() == model.ConfigSyncConflictCloud &&
+			!desired.Equal(base.Desired) {
+			err = a.wf.UpdateDeviceConfiguration(ctx, deviceID, desired, nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to update the device configuration")
+			}
+			base.Desired = desired
+		} else {
+			if !desired.Equal(configuration) {
+				err = a.patchDesiredState(ctx, deviceID, integration,
+					sync.Desired(configuration, desired))
+				if err != nil {
+					return err
+				}
+			}
+			base.Desired = configuration
+		}
+	}
+	return a.setDeviceConfigState(ctx, device, base)
+}
+
+// SyncDeviceConfiguration reconciles the desired and reported state of the
+// device with the configuration last synchronized from deviceconfig.
+func (a *app) SyncDeviceConfiguration(ctx context.Context, deviceID string) error {
+	device, err := a.store.GetDevice(ctx, deviceID)
+	if err == store.ErrObjectNotFound {
+		return ErrDeviceNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to retrieve the device")
+	}
+	integrations, err := a.configSyncIntegrations(ctx, device)
+	if err != nil {
+		return err
+	}
+	return a.syncDeviceConfiguration(ctx, device, integrations)
+}
+
+func (a *app) syncDeviceConfiguration(
+	ctx context.Context,
+	device *model.Device,
+	integrations []*model.Integration,
+) error {
+	base := model.DeviceConfigState{}
+	if device.ConfigState != nil {
+		base = *device.ConfigState
+	}
+	for _, integration := range integrations {
+		state, err := a.getDeviceState(ctx, device.ID, integration)
+		if err != nil {
+			return err
+		} else if state == nil {
+			continue
+		}
+		var (
+			sync          = integration.ConfigSync
+			desired       = sync.Configuration(state.Desired, base.Desired)
+			reported      = sync.Configuration(state.Reported, base.Desired)
+			configuration model.DeviceConfiguration
+		)
+		if !desired.Equal(base.Desired) {
+			switch sync.ConflictRule() {
+			case model.ConfigSyncConflictCloud:
+				configuration = desired
+				base.Desired = desired
+			default:
+				// Nothing was ever set from Mender: leave the desired
+				// state alone.
+				if device.ConfigState == nil {
+					break
+				}
+				err = a.patchDesiredState(ctx, device.ID, integration,
+					sync.Desired(base.Desired, desired))
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if reported.Equal(base.Reported) {
+			reported = nil
+		} else {
+			base.Reported = reported
+		}
+		if configuration != nil || reported != nil {
+			err = a.wf.UpdateDeviceConfiguration(ctx, device.ID, configuration, reported)
+			if err != nil {
+				return errors.Wrap(err, "failed to update the device configuration")
+			}
+		}
+	}
+	return a.setDeviceConfigState(ctx, device, base)
+}
+
+func (a *app) setDeviceConfigState(
+	ctx context.Context,
+	device *model.Device,
+	state model.DeviceConfigState,
+) error {
+	if device.ConfigState != nil &&
+		device.ConfigState.Desired.Equal(state.Desired) &&
+		device.ConfigState.Reported.Equal(state.Reported) {
+		return nil
+	}
+	err := a.store.SetDeviceConfigState(ctx, device.ID, &state)
+	if err == store.ErrObjectNotFound {
+		return ErrDeviceNotFound
+	}
+	return errors.Wrap(err, "failed to save the device configuration state")
+}
+
+func (a *app) getDeviceState(
+	ctx context.Context,
+	deviceID string,
+	integration *model.Integration,
+) (*model.DeviceState, error) {
+	switch integration.Provider {
+	case model.ProviderIoTHub:
+		return a.GetDeviceStateIoTHub(ctx, deviceID, integration)
+	case model.ProviderIoTCore:
+		return a.GetDeviceStateIoTCore(ctx, deviceID, integration)
+	default:
+		return nil, nil
+	}
+}
+
+// patchDesiredState merges the patch into the desired state of the device;
+// properties set to nil are removed.
+func (a *app) patchDesiredState(
+	ctx context.Context,
+	deviceID string,
+	integration *model.Integration,
+	patch map[string]interface{},
+) error {
+	switch integration.Provider {
+	case model.ProviderIoTHub:
+		cs := integration.Credentials.ConnectionString
+		if cs == nil {
+			return ErrNoCredentials
+		}
+		twin, err := a.iothubClient.GetDeviceTwin(ctx, cs, deviceID)
+		if err == nil {
+			err = a.iothubClient.UpdateDeviceTwin(ctx, cs, deviceID,
+				&iothub.DeviceTwinUpdate{
+					Properties: iothub.UpdateProperties{
+						Desired: patch,
+					},
+					ETag: twin.ETag,
+				})
+		}
+		if errHTTP, ok := err.(client.HTTPError); ok &&
+			errHTTP.Code() == http.StatusPreconditionFailed {
+			return ErrDeviceStateConflict
+		} else if err != nil {
+			return errors.Wrap(err, "failed to update the device twin")
+		}
+	case model.ProviderIoTCore:
+		_, err := a.SetDeviceStateIoTCore(ctx, deviceID, integration,
+			&model.DeviceState{Desired: patch})
+		if err != nil {
+			return errors.Wrap(err, "failed to update the device shadow")
+		}
+	}
+	return nil
+}
+
+// SyncConfiguration reconciles the desired and reported state of ALL
+// devices belonging to integrations with configuration synchronization
+// enabled.
+func (a *app) SyncConfiguration(ctx context.Context, failEarly bool) error {
+	type DeviceWithTenantID struct {
+		model.Device `bson:",inline"`
+		TenantID     string `bson:"tenant_id"`
+	}
+	iter, err := a.store.GetAllDevices(ctx)
+	if err != nil {
+		return err
+	}
+	defer iter.Close(ctx)
+
+	var (
+		l          = log.FromContext(ctx)
+		tenantID   string
+		tCtx       context.Context
+		integCache map[uuid.UUID]*model.Integration
+	)
+	for iter.Next(ctx) {
+		dev := DeviceWithTenantID{}
+		err := iter.Decode(&dev)
+		if err != nil {
+			return err
+		}
+		if tCtx == nil || tenantID != dev.TenantID {
+			tenantID = dev.TenantID
+			tCtx = identity.WithContext(ctx, &identity.Identity{
+				Tenant: tenantID,
+			})
+			integCache, err = a.syncCacheIntegrations(tCtx)
+			if err != nil {
+				return err
+			}
+		}
+		integrations := make([]*model.Integration, 0, len(dev.IntegrationIDs))
+		for _, id := range dev.IntegrationIDs {
+			if integration := integCache[id]; integration != nil &&
+				integration.ConfigSync != nil {
+				integrations = append(integrations, integration)
+			}
+		}
+		if len(integrations) == 0 {
+			continue
+		}
+		err = a.syncDeviceConfiguration(tCtx, &dev.Device, integrations)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to sync configuration of device %s", dev.ID)
+			if failEarly {
+				return err
+			}
+			l.Error(err)
+		}
+	}
+	return nil
+}
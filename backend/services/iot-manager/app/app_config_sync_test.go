@@ -0,0 +1,643 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/client"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/iotcore"
+	coreMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/iotcore/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/iothub"
+	hubMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/iothub/mocks"
+	wfMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+	"github.com/mendersoftware/mender-server/services/iot-manager/store"
+	storeMocks "github.com/mendersoftware/mender-server/services/iot-manager/store/mocks"
+)
+
+func newConfigSyncIntegrations() (hub, core *model.Integration) {
+	hub, core = newInventorySyncIntegrations()
+	hub.InventorySync, core.InventorySync = nil, nil
+	hub.ConfigSync = &model.ConfigSync{
+		Property: "mender",
+	}
+	core.ConfigSync = &model.ConfigSync{
+		Conflict: model.ConfigSyncConflictCloud,
+	}
+	return hub, core
+}
+
+func TestSetIntegrationConfigSync(t *testing.T) {
+	t.Parallel()
+	integrationID := uuid.New()
+	configSync := &model.ConfigSync{
+		Property: "mender",
+	}
+	testCases := []struct {
+		Name string
+
+		Store func(t *testing.T) *storeMocks.DataStore
+		Error error
+	}{{
+		Name: "ok",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(&model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderIoTHub,
+				}, nil)
+			ds.On("SetIntegrationConfigSync",
+				contextMatcher, integrationID, configSync).
+				Return(nil)
+			return ds
+		},
+	}, {
+		Name: "error, integration not found",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(nil, store.ErrObjectNotFound)
+			return ds
+		},
+		Error: ErrIntegrationNotFound,
+	}, {
+		Name: "error, provider not supported",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(&model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderMQTT,
+				}, nil)
+			return ds
+		},
+		Error: ErrConfigSyncNotSupported,
+	}, {
+		Name: "error, integration removed",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(&model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderIoTCore,
+				}, nil)
+			ds.On("SetIntegrationConfigSync",
+				contextMatcher, integrationID, configSync).
+				Return(store.ErrObjectNotFound)
+			return ds
+		},
+		Error: ErrIntegrationNotFound,
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := tc.Store(t)
+			defer ds.AssertExpectations(t)
+
+			app := New(ds, nil, nil)
+			err := app.SetIntegrationConfigSync(
+				context.Background(), integrationID, configSync,
+			)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetDeviceConfiguration(t *testing.T) {
+	t.Parallel()
+	const deviceID = "0a1b2c3d-4e5f-4a6b-8c9d-0e1f2a3b4c5d"
+	hubIntegration, coreIntegration := newConfigSyncIntegrations()
+	configuration := model.DeviceConfiguration{"ssid": "mender"}
+	type testCase struct {
+		Name string
+
+		Store     func(t *testing.T) *storeMocks.DataStore
+		Hub       func(t *testing.T) *hubMocks.Client
+		Core      func(t *testing.T) *coreMocks.Client
+		Workflows func(t *testing.T) *wfMocks.Client
+
+		Error error
+	}
+	testCases := []testCase{{
+		Name: "ok, IoT Hub",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{hubIntegration.ID},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, hubIntegration.ID).
+				Return(hubIntegration, nil)
+			ds.On("SetDeviceConfigState", contextMatcher, deviceID,
+				&model.DeviceConfigState{Desired: configuration}).
+				Return(nil)
+			return ds
+		},
+		Hub: func(t *testing.T) *hubMocks.Client {
+			hub := new(hubMocks.Client)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, deviceID).
+				Return(&iothub.DeviceTwin{
+					ETag: "etag",
+					Properties: iothub.TwinProperties{
+						Desired: map[string]interface{}{
+							"mender": map[string]interface{}{
+								"channel": "6",
+							},
+						},
+					},
+				}, nil)
+			hub.On("UpdateDeviceTwin", contextMatcher, validConnString, deviceID,
+				&iothub.DeviceTwinUpdate{
+					Properties: iothub.UpdateProperties{
+						Desired: map[string]interface{}{
+							"mender": map[string]interface{}{
+								"ssid":    "mender",
+								"channel": nil,
+							},
+						},
+					},
+					ETag: "etag",
+				}).Return(nil)
+			return hub
+		},
+	}, {
+		Name: "ok, IoT Core desired state changed in the cloud",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{coreIntegration.ID},
+					ConfigState: &model.DeviceConfigState{
+						Desired: model.DeviceConfiguration{"ssid": "guest"},
+					},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, coreIntegration.ID).
+				Return(coreIntegration, nil)
+			ds.On("SetDeviceConfigState", contextMatcher, deviceID,
+				&model.DeviceConfigState{
+					Desired: model.DeviceConfiguration{"ssid": "cloud"},
+				}).
+				Return(nil)
+			return ds
+		},
+		Core: func(t *testing.T) *coreMocks.Client {
+			core := new(coreMocks.Client)
+			core.On("GetDeviceShadow", contextMatcher,
+				*coreIntegration.Credentials.AWSCredentials, deviceID).
+				Return(&iotcore.DeviceShadow{
+					Payload: model.DeviceState{
+						Desired: map[string]interface{}{
+							"ssid": "cloud",
+						},
+					},
+				}, nil)
+			return core
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceConfiguration", contextMatcher, deviceID,
+				map[string]string{"ssid": "cloud"},
+				map[string]string(nil)).
+				Return(nil)
+			return wf
+		},
+	}, {
+		Name: "ok, no integrations with config sync",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{uuid.New()},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, mock.AnythingOfType("uuid.UUID")).
+				Return(nil, store.ErrObjectNotFound)
+			return ds
+		},
+	}, {
+		Name: "error, device not found",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(nil, store.ErrObjectNotFound)
+			return ds
+		},
+		Error: ErrDeviceNotFound,
+	}, {
+		Name: "error, device twin changed",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{hubIntegration.ID},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, hubIntegration.ID).
+				Return(hubIntegration, nil)
+			return ds
+		},
+		Hub: func(t *testing.T) *hubMocks.Client {
+			hub := new(hubMocks.Client)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, deviceID).
+				Return(&iothub.DeviceTwin{}, nil)
+			hub.On("UpdateDeviceTwin", contextMatcher, validConnString, deviceID,
+				mock.AnythingOfType("*iothub.DeviceTwinUpdate")).
+				Return(client.NewHTTPError(http.StatusPreconditionFailed))
+			return hub
+		},
+		Error: ErrDeviceStateConflict,
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := tc.Store(t)
+			defer ds.AssertExpectations(t)
+			wf := new(wfMocks.Client)
+			if tc.Workflows != nil {
+				wf = tc.Workflows(t)
+			}
+			defer wf.AssertExpectations(t)
+			hub := new(hubMocks.Client)
+			if tc.Hub != nil {
+				hub = tc.Hub(t)
+			}
+			defer hub.AssertExpectations(t)
+			core := new(coreMocks.Client)
+			if tc.Core != nil {
+				core = tc.Core(t)
+			}
+			defer core.AssertExpectations(t)
+
+			app := New(ds, wf, nil).WithIoTHub(hub).WithIoTCore(core)
+			err := app.SetDeviceConfiguration(context.Background(), deviceID, configuration)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSyncDeviceConfiguration(t *testing.T) {
+	t.Parallel()
+	const deviceID = "0a1b2c3d-4e5f-4a6b-8c9d-0e1f2a3b4c5d"
+	hubIntegration, coreIntegration := newConfigSyncIntegrations()
+	base := &model.DeviceConfigState{
+		Desired:  model.DeviceConfiguration{"ssid": "mender"},
+		Reported: model.DeviceConfiguration{"ssid": "guest"},
+	}
+	type testCase struct {
+		Name string
+
+		Store     func(t *testing.T) *storeMocks.DataStore
+		Hub       func(t *testing.T) *hubMocks.Client
+		Core      func(t *testing.T) *coreMocks.Client
+		Workflows func(t *testing.T) *wfMocks.Client
+
+		Error error
+	}
+	testCases := []testCase{{
+		Name: "ok, restore desired state and mirror reported state",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{hubIntegration.ID},
+					ConfigState:    base,
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, hubIntegration.ID).
+				Return(hubIntegration, nil)
+			ds.On("SetDeviceConfigState", contextMatcher, deviceID,
+				&model.DeviceConfigState{
+					Desired:  base.Desired,
+					Reported: model.DeviceConfiguration{"ssid": "mender"},
+				}).
+				Return(nil)
+			return ds
+		},
+		Hub: func(t *testing.T) *hubMocks.Client {
+			hub := new(hubMocks.Client)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, deviceID).
+				Return(&iothub.DeviceTwin{
+					ETag: "etag",
+					Properties: iothub.TwinProperties{
+						Desired: map[string]interface{}{
+							"mender": map[string]interface{}{
+								"ssid": "cloud",
+							},
+						},
+						Reported: map[string]interface{}{
+							"mender": map[string]interface{}{
+								"ssid": "mender",
+							},
+						},
+					},
+				}, nil)
+			hub.On("UpdateDeviceTwin", contextMatcher, validConnString, deviceID,
+				&iothub.DeviceTwinUpdate{
+					Properties: iothub.UpdateProperties{
+						Desired: map[string]interface{}{
+							"mender": map[string]interface{}{
+								"ssid": "mender",
+							},
+						},
+					},
+					ETag: "etag",
+				}).Return(nil)
+			return hub
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceConfiguration", contextMatcher, deviceID,
+				map[string]string(nil),
+				map[string]string{"ssid": "mender"}).
+				Return(nil)
+			return wf
+		},
+	}, {
+		Name: "ok, apply desired state changed in the cloud",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{coreIntegration.ID},
+					ConfigState:    base,
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, coreIntegration.ID).
+				Return(coreIntegration, nil)
+			ds.On("SetDeviceConfigState", contextMatcher, deviceID,
+				&model.DeviceConfigState{
+					Desired:  model.DeviceConfiguration{"ssid": "cloud"},
+					Reported: base.Reported,
+				}).
+				Return(nil)
+			return ds
+		},
+		Core: func(t *testing.T) *coreMocks.Client {
+			core := new(coreMocks.Client)
+			core.On("GetDeviceShadow", contextMatcher,
+				*coreIntegration.Credentials.AWSCredentials, deviceID).
+				Return(&iotcore.DeviceShadow{
+					Payload: model.DeviceState{
+						Desired: map[string]interface{}{
+							"ssid": "cloud",
+						},
+						Reported: map[string]interface{}{
+							"ssid": "guest",
+						},
+					},
+				}, nil)
+			return core
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceConfiguration", contextMatcher, deviceID,
+				map[string]string{"ssid": "cloud"},
+				map[string]string(nil)).
+				Return(nil)
+			return wf
+		},
+	}, {
+		Name: "ok, in sync",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{coreIntegration.ID},
+					ConfigState:    base,
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, coreIntegration.ID).
+				Return(coreIntegration, nil)
+			return ds
+		},
+		Core: func(t *testing.T) *coreMocks.Client {
+			core := new(coreMocks.Client)
+			core.On("GetDeviceShadow", contextMatcher,
+				*coreIntegration.Credentials.AWSCredentials, deviceID).
+				Return(&iotcore.DeviceShadow{
+					Payload: model.DeviceState{
+						Desired: map[string]interface{}{
+							"ssid": "mender",
+						},
+						Reported: map[string]interface{}{
+							"ssid": "guest",
+						},
+					},
+				}, nil)
+			return core
+		},
+	}, {
+		Name: "error, device not found",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(nil, store.ErrObjectNotFound)
+			return ds
+		},
+		Error: ErrDeviceNotFound,
+	}, {
+		Name: "error, failed to update device configuration",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{coreIntegration.ID},
+					ConfigState:    base,
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, coreIntegration.ID).
+				Return(coreIntegration, nil)
+			return ds
+		},
+		Core: func(t *testing.T) *coreMocks.Client {
+			core := new(coreMocks.Client)
+			core.On("GetDeviceShadow", contextMatcher,
+				*coreIntegration.Credentials.AWSCredentials, deviceID).
+				Return(&iotcore.DeviceShadow{
+					Payload: model.DeviceState{
+						Desired: map[string]interface{}{
+							"ssid": "cloud",
+						},
+					},
+				}, nil)
+			return core
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceConfiguration", contextMatcher, deviceID,
+				mock.Anything, mock.Anything).
+				Return(errors.New("internal error"))
+			return wf
+		},
+		Error: errors.New("failed to update the device configuration: internal error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := tc.Store(t)
+			defer ds.AssertExpectations(t)
+			wf := new(wfMocks.Client)
+			if tc.Workflows != nil {
+				wf = tc.Workflows(t)
+			}
+			defer wf.AssertExpectations(t)
+			hub := new(hubMocks.Client)
+			if tc.Hub != nil {
+				hub = tc.Hub(t)
+			}
+			defer hub.AssertExpectations(t)
+			core := new(coreMocks.Client)
+			if tc.Core != nil {
+				core = tc.Core(t)
+			}
+			defer core.AssertExpectations(t)
+
+			app := New(ds, wf, nil).WithIoTHub(hub).WithIoTCore(core)
+			err := app.SyncDeviceConfiguration(context.Background(), deviceID)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSyncConfiguration(t *testing.T) {
+	t.Parallel()
+	hubIntegration, _ := newConfigSyncIntegrations()
+	const tenantID = "000000000000000000000001"
+	devices := func() store.Iterator {
+		var b strings.Builder
+		enc := json.NewEncoder(&b)
+		for _, dev := range []map[string]interface{}{{
+			"id":              "1",
+			"TenantID":        tenantID,
+			"integration_ids": []uuid.UUID{hubIntegration.ID},
+		}, {
+			"id":              "2",
+			"TenantID":        tenantID,
+			"integration_ids": []uuid.UUID{hubIntegration.ID},
+		}} {
+			_ = enc.Encode(dev)
+		}
+		return (*JSONIterator)(json.NewDecoder(strings.NewReader(b.String())))
+	}
+	tenantMatcher := mock.MatchedBy(func(ctx context.Context) bool {
+		id := identity.FromContext(ctx)
+		return id != nil && id.Tenant == tenantID
+	})
+	testCases := []struct {
+		Name string
+
+		FailEarly bool
+		Error     error
+	}{{
+		Name: "ok, errors are logged",
+	}, {
+		Name: "error, fail early",
+
+		FailEarly: true,
+		Error: errors.New("failed to sync configuration of device 1: " +
+			"failed to get the device twin: internal error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := new(storeMocks.DataStore)
+			defer ds.AssertExpectations(t)
+			ds.On("GetAllDevices", contextMatcher).Return(devices(), nil)
+			ds.On("GetIntegrations", tenantMatcher, mock.Anything).
+				Return([]model.Integration{*hubIntegration}, nil)
+
+			hub := new(hubMocks.Client)
+			defer hub.AssertExpectations(t)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, "1").
+				Return(nil, errors.New("internal error"))
+			wf := new(wfMocks.Client)
+			defer wf.AssertExpectations(t)
+			if !tc.FailEarly {
+				hub.On("GetDeviceTwin", contextMatcher, validConnString, "2").
+					Return(&iothub.DeviceTwin{
+						Properties: iothub.TwinProperties{
+							Reported: map[string]interface{}{
+								"mender": map[string]interface{}{
+									"ssid": "mender",
+								},
+							},
+						},
+					}, nil)
+				wf.On("UpdateDeviceConfiguration", tenantMatcher, "2",
+					map[string]string(nil),
+					map[string]string{"ssid": "mender"}).
+					Return(nil)
+				ds.On("SetDeviceConfigState", tenantMatcher, "2",
+					&model.DeviceConfigState{
+						Reported: model.DeviceConfiguration{"ssid": "mender"},
+					}).
+					Return(nil)
+			}
+
+			app := New(ds, wf, nil).WithIoTHub(hub)
+			err := app.SyncConfiguration(context.Background(), tc.FailEarly)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/workflows"
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+	"github.com/mendersoftware/mender-server/services/iot-manager/store"
+)
+
+var ErrInventorySyncNotSupported = errors.New(
+	"inventory synchronization is not supported by the integration provider")
+
+func (a *app) SetIntegrationInventorySync(
+	ctx context.Context,
+	integrationID uuid.UUID,
+	inventorySync *model.InventorySync,
+) error {
+	integration, err := a.store.GetIntegrationById(ctx, integrationID)
+	if integration == nil && (err == nil || err == store.ErrObjectNotFound) {
+		return ErrIntegrationNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to retrieve the integration")
+	}
+	if !integration.Provider.HasDeviceState() {
+		return ErrInventorySyncNotSupported
+	}
+	if inventorySync != nil && len(inventorySync.Attributes) == 0 {
+		inventorySync = nil
+	}
+	err = a.store.SetIntegrationInventorySync(ctx, integrationID, inventorySync)
+	if errors.Cause(err) == store.ErrObjectNotFound {
+		return ErrIntegrationNotFound
+	}
+	return err
+}
+
+// SyncDeviceInventory copies the mapped properties of the device's reported
+// state into the inventory for all the integrations of the device with
+// inventory synchronization enabled.
+func (a *app) SyncDeviceInventory(ctx context.Context, deviceID string) error {
+	device, err := a.store.GetDevice(ctx, deviceID)
+	if err == store.ErrObjectNotFound {
+		return ErrDeviceNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to retrieve the device")
+	}
+	integrations := make([]*model.Integration, 0, len(device.IntegrationIDs))
+	for _, integrationID := range device.IntegrationIDs {
+		integration, err := a.store.GetIntegrationById(ctx, integrationID)
+		if err == store.ErrObjectNotFound {
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "failed to retrieve the integration")
+		}
+		integrations = append(integrations, integration)
+	}
+	return a.syncDeviceInventory(ctx, deviceID, integrations)
+}
+
+func (a *app) syncDeviceInventory(
+	ctx context.Context,
+	deviceID string,
+	integrations []*model.Integration,
+) error {
+	attributes := make(map[string]interface{})
+	for _, integration := range integrations {
+		if integration == nil || integration.InventorySync == nil {
+			continue
+		}
+		state, err := a.getDeviceState(ctx, deviceID, integration)
+		if err != nil {
+			return err
+		} else if state == nil {
+			continue
+		}
+		for name, value := range integration.InventorySync.Map(state.Reported) {
+			attributes[name] = value
+		}
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	inventory := make([]workflows.InventoryAttribute, 0, len(attributes))
+	for name, value := range attributes {
+		inventory = append(inventory, workflows.InventoryAttribute{
+			Name:  name,
+			Value: value,
+		})
+	}
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Name < inventory[j].Name
+	})
+	err := a.wf.UpdateDeviceInventory(ctx, deviceID, model.InventoryScopeIoT, inventory)
+	return errors.Wrap(err, "failed to update the device inventory")
+}
+
+// SyncInventory copies the reported state of ALL devices belonging to
+// integrations with inventory synchronization enabled into the inventory.
+func (a *app) SyncInventory(ctx context.Context, failEarly bool) error {
+	type DeviceWithTenantID struct {
+		model.Device `bson:",inline"`
+		TenantID     string `bson:"tenant_id"`
+	}
+	iter, err := a.store.GetAllDevices(ctx)
+	if err != nil {
+		return err
+	}
+	defer iter.Close(ctx)
+
+	var (
+		l          = log.FromContext(ctx)
+		tenantID   string
+		tCtx       context.Context
+		integCache map[uuid.UUID]*model.Integration
+	)
+	for iter.Next(ctx) {
+		dev := DeviceWithTenantID{}
+		err := iter.Decode(&dev)
+		if err != nil {
+			return err
+		}
+		if tCtx == nil || tenantID != dev.TenantID {
+			tenantID = dev.TenantID
+			tCtx = identity.WithContext(ctx, &identity.Identity{
+				Tenant: tenantID,
+			})
+			integCache, err = a.syncCacheIntegrations(tCtx)
+			if err != nil {
+				return err
+			}
+		}
+		integrations := make([]*model.Integration, 0, len(dev.IntegrationIDs))
+		for _, id := range dev.IntegrationIDs {
+			if integration := integCache[id]; integration != nil &&
+				integration.InventorySync != nil {
+				integrations = append(integrations, integration)
+			}
+		}
+		if len(integrations) == 0 {
+			continue
+		}
+		err = a.syncDeviceInventory(tCtx, dev.ID, integrations)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to sync inventory of device %s", dev.ID)
+			if failEarly {
+				return err
+			}
+			l.Error(err)
+		}
+	}
+	return nil
+}
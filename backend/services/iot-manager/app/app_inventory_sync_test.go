@@ -0,0 +1,508 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/iotcore"
+	coreMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/iotcore/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/iothub"
+	hubMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/iothub/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/workflows"
+	wfMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/crypto"
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+	"github.com/mendersoftware/mender-server/services/iot-manager/store"
+	storeMocks "github.com/mendersoftware/mender-server/services/iot-manager/store/mocks"
+)
+
+func newInventorySyncIntegrations() (hub, core *model.Integration) {
+	hub = &model.Integration{
+		ID:       uuid.New(),
+		Provider: model.ProviderIoTHub,
+		Credentials: model.Credentials{
+			Type:             model.CredentialTypeSAS,
+			ConnectionString: validConnString,
+		},
+		InventorySync: &model.InventorySync{
+			Attributes: []model.InventorySyncAttribute{{
+				Property:  "firmware.version",
+				Attribute: "firmware_version",
+			}},
+		},
+	}
+	accessKeyID := "x"
+	region := "us-east-1"
+	policy := "policy"
+	secret := crypto.String("x")
+	core = &model.Integration{
+		ID:       uuid.New(),
+		Provider: model.ProviderIoTCore,
+		Credentials: model.Credentials{
+			Type: model.CredentialTypeAWS,
+			AWSCredentials: &model.AWSCredentials{
+				AccessKeyID:      &accessKeyID,
+				SecretAccessKey:  &secret,
+				Region:           &region,
+				DevicePolicyName: &policy,
+			},
+		},
+		InventorySync: &model.InventorySync{
+			Attributes: []model.InventorySyncAttribute{{
+				Property: "metrics.cpu",
+			}},
+		},
+	}
+	return hub, core
+}
+
+func TestSetIntegrationInventorySync(t *testing.T) {
+	t.Parallel()
+	integrationID := uuid.New()
+	inventorySync := &model.InventorySync{
+		Attributes: []model.InventorySyncAttribute{{
+			Property: "firmware.version",
+		}},
+	}
+	testCases := []struct {
+		Name string
+
+		InventorySync *model.InventorySync
+		Store         func(t *testing.T) *storeMocks.DataStore
+		Error         error
+	}{{
+		Name: "ok",
+
+		InventorySync: inventorySync,
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(&model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderIoTHub,
+				}, nil)
+			ds.On("SetIntegrationInventorySync",
+				contextMatcher, integrationID, inventorySync).
+				Return(nil)
+			return ds
+		},
+	}, {
+		Name: "ok, empty attributes disables sync",
+
+		InventorySync: &model.InventorySync{},
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(&model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderIoTCore,
+				}, nil)
+			ds.On("SetIntegrationInventorySync",
+				contextMatcher, integrationID, (*model.InventorySync)(nil)).
+				Return(nil)
+			return ds
+		},
+	}, {
+		Name: "error, integration not found",
+
+		InventorySync: inventorySync,
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(nil, store.ErrObjectNotFound)
+			return ds
+		},
+		Error: ErrIntegrationNotFound,
+	}, {
+		Name: "error, provider not supported",
+
+		InventorySync: inventorySync,
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(&model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderWebhook,
+				}, nil)
+			return ds
+		},
+		Error: ErrInventorySyncNotSupported,
+	}, {
+		Name: "error, integration removed",
+
+		InventorySync: inventorySync,
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(&model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderIoTHub,
+				}, nil)
+			ds.On("SetIntegrationInventorySync",
+				contextMatcher, integrationID, inventorySync).
+				Return(store.ErrObjectNotFound)
+			return ds
+		},
+		Error: ErrIntegrationNotFound,
+	}, {
+		Name: "error, store failure",
+
+		InventorySync: inventorySync,
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetIntegrationById", contextMatcher, integrationID).
+				Return(nil, errors.New("internal error"))
+			return ds
+		},
+		Error: errors.New("failed to retrieve the integration: internal error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := tc.Store(t)
+			defer ds.AssertExpectations(t)
+
+			app := New(ds, nil, nil)
+			err := app.SetIntegrationInventorySync(
+				context.Background(), integrationID, tc.InventorySync,
+			)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSyncDeviceInventory(t *testing.T) {
+	t.Parallel()
+	const deviceID = "0a1b2c3d-4e5f-4a6b-8c9d-0e1f2a3b4c5d"
+	hubIntegration, coreIntegration := newInventorySyncIntegrations()
+	webhookID := uuid.New()
+	type testCase struct {
+		Name string
+
+		Store     func(t *testing.T) *storeMocks.DataStore
+		Hub       func(t *testing.T) *hubMocks.Client
+		Core      func(t *testing.T) *coreMocks.Client
+		Workflows func(t *testing.T) *wfMocks.Client
+
+		Error error
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID: deviceID,
+					IntegrationIDs: []uuid.UUID{
+						hubIntegration.ID, coreIntegration.ID, webhookID,
+					},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, hubIntegration.ID).
+				Return(hubIntegration, nil)
+			ds.On("GetIntegrationById", contextMatcher, coreIntegration.ID).
+				Return(coreIntegration, nil)
+			ds.On("GetIntegrationById", contextMatcher, webhookID).
+				Return(&model.Integration{
+					ID:       webhookID,
+					Provider: model.ProviderWebhook,
+				}, nil)
+			return ds
+		},
+		Hub: func(t *testing.T) *hubMocks.Client {
+			hub := new(hubMocks.Client)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, deviceID).
+				Return(&iothub.DeviceTwin{
+					Properties: iothub.TwinProperties{
+						Reported: map[string]interface{}{
+							"firmware": map[string]interface{}{
+								"version": "1.2.3",
+							},
+							"$metadata": map[string]interface{}{},
+						},
+					},
+				}, nil)
+			return hub
+		},
+		Core: func(t *testing.T) *coreMocks.Client {
+			core := new(coreMocks.Client)
+			core.On("GetDeviceShadow", contextMatcher,
+				*coreIntegration.Credentials.AWSCredentials, deviceID).
+				Return(&iotcore.DeviceShadow{
+					Payload: model.DeviceState{
+						Reported: map[string]interface{}{
+							"metrics": map[string]interface{}{
+								"cpu": 0.5,
+							},
+						},
+					},
+				}, nil)
+			return core
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceInventory", contextMatcher,
+				deviceID, model.InventoryScopeIoT,
+				[]workflows.InventoryAttribute{{
+					Name:  "firmware_version",
+					Value: "1.2.3",
+				}, {
+					Name:  "metrics.cpu",
+					Value: 0.5,
+				}}).Return(nil)
+			return wf
+		},
+	}, {
+		Name: "ok, nothing to sync",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{coreIntegration.ID},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, coreIntegration.ID).
+				Return(coreIntegration, nil)
+			return ds
+		},
+		Core: func(t *testing.T) *coreMocks.Client {
+			core := new(coreMocks.Client)
+			core.On("GetDeviceShadow", contextMatcher,
+				*coreIntegration.Credentials.AWSCredentials, deviceID).
+				Return(nil, iotcore.ErrDeviceNotFound)
+			return core
+		},
+	}, {
+		Name: "error, device not found",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(nil, store.ErrObjectNotFound)
+			return ds
+		},
+		Error: ErrDeviceNotFound,
+	}, {
+		Name: "error, failed to get device twin",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{hubIntegration.ID},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, hubIntegration.ID).
+				Return(hubIntegration, nil)
+			return ds
+		},
+		Hub: func(t *testing.T) *hubMocks.Client {
+			hub := new(hubMocks.Client)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, deviceID).
+				Return(nil, errors.New("internal error"))
+			return hub
+		},
+		Error: errors.New("failed to get the device twin: internal error"),
+	}, {
+		Name: "error, failed to update inventory",
+
+		Store: func(t *testing.T) *storeMocks.DataStore {
+			ds := new(storeMocks.DataStore)
+			ds.On("GetDevice", contextMatcher, deviceID).
+				Return(&model.Device{
+					ID:             deviceID,
+					IntegrationIDs: []uuid.UUID{hubIntegration.ID},
+				}, nil)
+			ds.On("GetIntegrationById", contextMatcher, hubIntegration.ID).
+				Return(hubIntegration, nil)
+			return ds
+		},
+		Hub: func(t *testing.T) *hubMocks.Client {
+			hub := new(hubMocks.Client)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, deviceID).
+				Return(&iothub.DeviceTwin{
+					Properties: iothub.TwinProperties{
+						Reported: map[string]interface{}{
+							"firmware": map[string]interface{}{
+								"version": "1.2.3",
+							},
+						},
+					},
+				}, nil)
+			return hub
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceInventory", contextMatcher,
+				deviceID, model.InventoryScopeIoT, mock.Anything).
+				Return(errors.New("internal error"))
+			return wf
+		},
+		Error: errors.New("failed to update the device inventory: internal error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := tc.Store(t)
+			defer ds.AssertExpectations(t)
+			wf := new(wfMocks.Client)
+			if tc.Workflows != nil {
+				wf = tc.Workflows(t)
+			}
+			defer wf.AssertExpectations(t)
+			hub := new(hubMocks.Client)
+			if tc.Hub != nil {
+				hub = tc.Hub(t)
+			}
+			defer hub.AssertExpectations(t)
+			core := new(coreMocks.Client)
+			if tc.Core != nil {
+				core = tc.Core(t)
+			}
+			defer core.AssertExpectations(t)
+
+			app := New(ds, wf, nil).WithIoTHub(hub).WithIoTCore(core)
+			err := app.SyncDeviceInventory(context.Background(), deviceID)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSyncInventory(t *testing.T) {
+	t.Parallel()
+	hubIntegration, _ := newInventorySyncIntegrations()
+	plainIntegration := &model.Integration{
+		ID:       uuid.New(),
+		Provider: model.ProviderIoTHub,
+		Credentials: model.Credentials{
+			Type:             model.CredentialTypeSAS,
+			ConnectionString: validConnString,
+		},
+	}
+	const (
+		tenantSync  = "000000000000000000000001"
+		tenantPlain = "000000000000000000000002"
+	)
+	devices := func() store.Iterator {
+		var b strings.Builder
+		enc := json.NewEncoder(&b)
+		for _, dev := range []map[string]interface{}{{
+			"id":              "1",
+			"TenantID":        tenantSync,
+			"integration_ids": []uuid.UUID{hubIntegration.ID},
+		}, {
+			"id":              "2",
+			"TenantID":        tenantSync,
+			"integration_ids": []uuid.UUID{hubIntegration.ID},
+		}, {
+			"id":              "3",
+			"TenantID":        tenantPlain,
+			"integration_ids": []uuid.UUID{plainIntegration.ID},
+		}} {
+			_ = enc.Encode(dev)
+		}
+		return (*JSONIterator)(json.NewDecoder(strings.NewReader(b.String())))
+	}
+	tenantMatcher := func(tenantID string) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			id := identity.FromContext(ctx)
+			return id != nil && id.Tenant == tenantID
+		})
+	}
+	testCases := []struct {
+		Name string
+
+		FailEarly bool
+		Error     error
+	}{{
+		Name: "ok, errors are logged",
+	}, {
+		Name: "error, fail early",
+
+		FailEarly: true,
+		Error: errors.New("failed to sync inventory of device 1: " +
+			"failed to get the device twin: internal error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := new(storeMocks.DataStore)
+			defer ds.AssertExpectations(t)
+			ds.On("GetAllDevices", contextMatcher).Return(devices(), nil)
+			ds.On("GetIntegrations", tenantMatcher(tenantSync), mock.Anything).
+				Return([]model.Integration{*hubIntegration}, nil)
+			if !tc.FailEarly {
+				ds.On("GetIntegrations", tenantMatcher(tenantPlain), mock.Anything).
+					Return([]model.Integration{*plainIntegration}, nil)
+			}
+
+			hub := new(hubMocks.Client)
+			defer hub.AssertExpectations(t)
+			hub.On("GetDeviceTwin", contextMatcher, validConnString, "1").
+				Return(nil, errors.New("internal error"))
+			wf := new(wfMocks.Client)
+			defer wf.AssertExpectations(t)
+			if !tc.FailEarly {
+				hub.On("GetDeviceTwin", contextMatcher, validConnString, "2").
+					Return(&iothub.DeviceTwin{
+						Properties: iothub.TwinProperties{
+							Reported: map[string]interface{}{
+								"firmware": map[string]interface{}{
+									"version": "1.2.3",
+								},
+							},
+						},
+					}, nil)
+				wf.On("UpdateDeviceInventory", tenantMatcher(tenantSync),
+					"2", model.InventoryScopeIoT,
+					[]workflows.InventoryAttribute{{
+						Name:  "firmware_version",
+						Value: "1.2.3",
+					}}).Return(nil)
+			}
+
+			app := New(ds, wf, nil).WithIoTHub(hub)
+			err := app.SyncInventory(context.Background(), tc.FailEarly)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/mqtt"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/workflows"
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+	"github.com/mendersoftware/mender-server/services/iot-manager/store"
+)
+
+const (
+	// inventoryScopeTags is the inventory scope desired state attributes
+	// are submitted to.
+	inventoryScopeTags = "tags"
+	// desiredStateKey is the key wrapping the desired state when the
+	// message follows the model.DeviceState format.
+	desiredStateKey = "desired"
+)
+
+// mqttReconnectDelay is the time to wait before subscribing again after a
+// subscription failed.
+var mqttReconnectDelay = 5 * time.Second
+
+func assertMQTTIntegration(integration model.Integration) error {
+	if err := integration.Validate(); err != nil {
+		return ErrNoCredentials
+	} else if integration.Credentials.Type != model.CredentialTypeMQTT {
+		return ErrNoCredentials
+	}
+	return nil
+}
+
+func tenantIDFromContext(ctx context.Context) string {
+	if id := identity.FromContext(ctx); id != nil {
+		return id.Tenant
+	}
+	return ""
+}
+
+// publishMQTTEvent publishes the event to the topic configured for the
+// integration.
+func (a *app) publishMQTTEvent(
+	ctx context.Context,
+	deviceID string,
+	integration model.Integration,
+	event model.WebhookEvent,
+) error {
+	if err := assertMQTTIntegration(integration); err != nil {
+		return err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize event")
+	}
+	creds := *integration.Credentials.MQTT
+	topic := creds.EventTopic(tenantIDFromContext(ctx), deviceID, event.Type)
+	err = a.mqttClient.Publish(ctx, integration.ID, creds, topic, payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to publish event to the MQTT broker")
+	}
+	return nil
+}
+
+func (a *app) syncMQTTDevices(
+	ctx context.Context,
+	deviceIDs []string,
+	integration model.Integration,
+	failEarly bool,
+) error {
+	if err := assertMQTTIntegration(integration); err != nil {
+		return err
+	}
+	l := log.FromContext(ctx)
+
+	devAuths, err := a.devauth.GetDevices(ctx, deviceIDs)
+	if err != nil {
+		return errors.Wrap(err, "app: failed to lookup device authentication")
+	}
+	statuses := make(map[string]model.Status, len(devAuths))
+	for _, auth := range devAuths {
+		statuses[auth.ID] = auth.Status
+	}
+
+	for _, deviceID := range deviceIDs {
+		status, ok := statuses[deviceID]
+		if !ok {
+			l.Warnf("Device '%s' does not have an auth set: deleting device", deviceID)
+			err := a.decommissionDevice(ctx, deviceID)
+			if err != nil && !errors.Is(err, ErrDeviceNotFound) {
+				err = errors.Wrap(err, "app: failed to decommission device")
+				if failEarly {
+					return err
+				}
+				l.Error(err)
+			}
+			continue
+		}
+		// The broker does not keep any device state: republish the
+		// current status to let subscribers reconcile.
+		err := a.publishMQTTEvent(ctx, deviceID, integration, model.WebhookEvent{
+			ID:   uuid.New(),
+			Type: model.EventTypeDeviceStatusChanged,
+			Data: model.DeviceEvent{
+				ID:     deviceID,
+				Status: status,
+			},
+			EventTS: time.Now(),
+		})
+		if err != nil {
+			err = errors.Wrap(err, "failed to publish device status")
+			if failEarly {
+				return err
+			}
+			l.Warn(err)
+		}
+	}
+	return nil
+}
+
+// SubscribeDesiredState subscribes to the desired state topic of every
+// MQTT integration and applies the messages received to the configured
+// target until the context is canceled. The set of integrations is
+// reloaded every refreshInterval.
+func (a *app) SubscribeDesiredState(
+	ctx context.Context,
+	refreshInterval time.Duration,
+) error {
+	type subscription struct {
+		integration tenantIntegration
+		cancel      context.CancelFunc
+	}
+	l := log.FromContext(ctx)
+	subscriptions := make(map[uuid.UUID]subscription)
+	defer func() {
+		for _, sub := range subscriptions {
+			sub.cancel()
+		}
+	}()
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		integrations, err := a.getDesiredStateIntegrations(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			l.Errorf("failed to load MQTT integrations: %s", err)
+		} else {
+			for id, sub := range subscriptions {
+				integration, ok := integrations[id]
+				if !ok || !reflect.DeepEqual(integration, sub.integration) {
+					sub.cancel()
+					delete(subscriptions, id)
+				}
+			}
+			for id, integration := range integrations {
+				if _, ok := subscriptions[id]; ok {
+					continue
+				}
+				subCtx, cancel := context.WithCancel(ctx)
+				subscriptions[id] = subscription{
+					integration: integration,
+					cancel:      cancel,
+				}
+				go a.subscribeDesiredState(subCtx, integration)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type tenantIntegration struct {
+	model.Integration `bson:",inline"`
+	TenantID          string `bson:"tenant_id"`
+}
+
+func (a *app) getDesiredStateIntegrations(
+	ctx context.Context,
+) (map[uuid.UUID]tenantIntegration, error) {
+	iter, err := a.store.GetAllIntegrations(ctx, model.ProviderMQTT)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close(ctx)
+
+	integrations := make(map[uuid.UUID]tenantIntegration)
+	for iter.Next(ctx) {
+		var integration tenantIntegration
+		if err := iter.Decode(&integration); err != nil {
+			return nil, err
+		}
+		if assertMQTTIntegration(integration.Integration) != nil ||
+			integration.Credentials.MQTT.DesiredStateTopic == "" {
+			continue
+		}
+		integrations[integration.ID] = integration
+	}
+	return integrations, nil
+}
+
+// subscribeDesiredState keeps a subscription open for the integration
+// until the context is canceled.
+func (a *app) subscribeDesiredState(ctx context.Context, integration tenantIntegration) {
+	ctx = identity.WithContext(ctx, &identity.Identity{
+		Tenant: integration.TenantID,
+	})
+	l := log.FromContext(ctx).
+		WithField("tenant_id", integration.TenantID).
+		WithField("integration_id", integration.ID.String())
+	creds := *integration.Credentials.MQTT
+	topic := creds.DesiredStateSubscription(integration.TenantID)
+	handler := func(ctx context.Context, msg mqtt.Message) {
+		ctx = requestid.WithContext(ctx, uuid.NewString())
+		err := a.applyDesiredState(ctx, integration.Integration, msg)
+		if err != nil {
+			l.Errorf("failed to apply desired state from topic %q: %s", msg.Topic, err)
+		}
+	}
+	for {
+		err := a.mqttClient.Subscribe(ctx, integration.ID, creds, topic, handler)
+		if ctx.Err() != nil {
+			return
+		} else if err != nil {
+			l.Warnf("MQTT subscription to %q interrupted: %s", topic, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(mqttReconnectDelay):
+		}
+	}
+}
+
+// applyDesiredState maps a desired state message to the device
+// configuration or inventory depending on the integration settings.
+func (a *app) applyDesiredState(
+	ctx context.Context,
+	integration model.Integration,
+	msg mqtt.Message,
+) error {
+	creds := integration.Credentials.MQTT
+	deviceID, ok := creds.DeviceIDFromTopic(tenantIDFromContext(ctx), msg.Topic)
+	if !ok {
+		return errors.Errorf("topic does not match %q", creds.DesiredStateTopic)
+	}
+	device, err := a.store.GetDeviceByIntegrationID(ctx, deviceID, integration.ID)
+	if err == store.ErrObjectNotFound || (err == nil && device == nil) {
+		return ErrDeviceNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to retrieve the device")
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(msg.Payload, &state); err != nil {
+		return errors.Wrap(err, "invalid desired state")
+	}
+	if desired, ok := state[desiredStateKey].(map[string]interface{}); ok && len(state) == 1 {
+		state = desired
+	}
+	keys := make([]string, 0, len(state))
+	for key, value := range state {
+		if value != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	switch creds.DesiredStateTarget {
+	case model.MQTTDesiredStateDeviceConfig:
+		config := make(map[string]string, len(keys))
+		for _, key := range keys {
+			switch value := state[key].(type) {
+			case string:
+				config[key] = value
+			default:
+				b, _ := json.Marshal(value)
+				config[key] = string(b)
+			}
+		}
+		err = a.wf.UpdateDeviceConfiguration(ctx, deviceID, config, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to submit desired configuration")
+		}
+	case model.MQTTDesiredStateInventory:
+		attributes := make([]workflows.InventoryAttribute, 0, len(keys))
+		for _, key := range keys {
+			attributes = append(attributes, workflows.InventoryAttribute{
+				Name:  key,
+				Value: state[key],
+			})
+		}
+		err = a.wf.UpdateDeviceInventory(ctx, deviceID, inventoryScopeTags, attributes)
+		if err != nil {
+			return errors.Wrap(err, "failed to submit desired inventory attributes")
+		}
+	default:
+		return ErrUnknownIntegration
+	}
+	return nil
+}
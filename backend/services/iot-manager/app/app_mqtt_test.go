@@ -0,0 +1,580 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/devauth"
+	mdevauth "github.com/mendersoftware/mender-server/services/iot-manager/client/devauth/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/mqtt"
+	mqttMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/mqtt/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/client/workflows"
+	wfMocks "github.com/mendersoftware/mender-server/services/iot-manager/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+	"github.com/mendersoftware/mender-server/services/iot-manager/store"
+	storeMocks "github.com/mendersoftware/mender-server/services/iot-manager/store/mocks"
+)
+
+const mqttTenantID = "123456789012345678901234"
+
+func newMQTTIntegration(target model.MQTTDesiredStateTarget) model.Integration {
+	creds := &model.MQTTCredentials{
+		BrokerURL: "mqtt://localhost:1883",
+	}
+	if target != "" {
+		creds.DesiredStateTopic = "fleet/{tenant_id}/{device_id}/desired"
+		creds.DesiredStateTarget = target
+	}
+	return model.Integration{
+		ID:       uuid.NewSHA1(uuid.NameSpaceOID, []byte("mqtt")),
+		Provider: model.ProviderMQTT,
+		Credentials: model.Credentials{
+			Type: model.CredentialTypeMQTT,
+			MQTT: creds,
+		},
+	}
+}
+
+func mqttTenantContext() context.Context {
+	return identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: mqttTenantID,
+	})
+}
+
+// integrationIterator implements store.Iterator over a slice of
+// integrations.
+type integrationIterator struct {
+	integrations []tenantIntegration
+	i            int
+}
+
+func (iter *integrationIterator) Next(ctx context.Context) bool {
+	iter.i++
+	return iter.i <= len(iter.integrations)
+}
+
+func (iter *integrationIterator) Decode(v interface{}) error {
+	*v.(*tenantIntegration) = iter.integrations[iter.i-1]
+	return nil
+}
+
+func (iter *integrationIterator) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestPublishMQTTEvent(t *testing.T) {
+	t.Parallel()
+	const deviceID = "68ac6f41-c2e7-429f-a4bd-852fac9a5045"
+	event := model.WebhookEvent{
+		ID:   uuid.New(),
+		Type: model.EventTypeDeviceStatusChanged,
+		Data: model.DeviceEvent{
+			ID:     deviceID,
+			Status: model.StatusAccepted,
+		},
+		EventTS: time.Now().UTC().Truncate(time.Second),
+	}
+	testCases := []struct {
+		Name        string
+		Integration model.Integration
+		Client      func(t *testing.T) *mqttMocks.Client
+		Error       error
+	}{{
+		Name:        "ok",
+		Integration: newMQTTIntegration(""),
+		Client: func(t *testing.T) *mqttMocks.Client {
+			client := new(mqttMocks.Client)
+			client.On("Publish",
+				contextMatcher,
+				mock.AnythingOfType("uuid.UUID"),
+				mock.AnythingOfType("model.MQTTCredentials"),
+				"mender/"+mqttTenantID+"/devices/"+deviceID+"/device-status-changed",
+				mock.AnythingOfType("[]uint8")).
+				Run(func(args mock.Arguments) {
+					var actual model.WebhookEvent
+					err := json.Unmarshal(args.Get(4).([]byte), &actual)
+					if assert.NoError(t, err) {
+						assert.Equal(t, event.ID, actual.ID)
+						assert.Equal(t, event.Type, actual.Type)
+						assert.True(t, event.EventTS.Equal(actual.EventTS))
+					}
+				}).
+				Return(nil)
+			return client
+		},
+	}, {
+		Name: "error, no credentials",
+		Integration: model.Integration{
+			Provider: model.ProviderMQTT,
+			Credentials: model.Credentials{
+				Type: model.CredentialTypeMQTT,
+			},
+		},
+		Client: func(t *testing.T) *mqttMocks.Client {
+			return new(mqttMocks.Client)
+		},
+		Error: ErrNoCredentials,
+	}, {
+		Name:        "error, publish",
+		Integration: newMQTTIntegration(""),
+		Client: func(t *testing.T) *mqttMocks.Client {
+			client := new(mqttMocks.Client)
+			client.On("Publish",
+				contextMatcher,
+				mock.AnythingOfType("uuid.UUID"),
+				mock.AnythingOfType("model.MQTTCredentials"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("[]uint8")).
+				Return(errors.New("connection refused"))
+			return client
+		},
+		Error: errors.New("failed to publish event to the MQTT broker: connection refused"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			client := tc.Client(t)
+			defer client.AssertExpectations(t)
+			a := New(nil, nil, nil).WithMQTT(client)
+
+			err := a.(*app).publishMQTTEvent(mqttTenantContext(), deviceID, tc.Integration, event)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeviceLifecycleMQTT(t *testing.T) {
+	t.Parallel()
+	const deviceID = "68ac6f41-c2e7-429f-a4bd-852fac9a5045"
+	integration := newMQTTIntegration("")
+	topicPrefix := "mender/" + mqttTenantID + "/devices/" + deviceID + "/"
+
+	ds := new(storeMocks.DataStore)
+	defer ds.AssertExpectations(t)
+	client := new(mqttMocks.Client)
+	defer client.AssertExpectations(t)
+	a := New(ds, nil, nil).WithMQTT(client).(*app)
+
+	ds.On("GetIntegrations", contextMatcher, model.IntegrationFilter{}).
+		Return([]model.Integration{integration}, nil)
+	ds.On("GetDevice", contextMatcher, deviceID).
+		Return(&model.Device{
+			ID:             deviceID,
+			IntegrationIDs: []uuid.UUID{integration.ID},
+		}, nil)
+	ds.On("UpsertDeviceIntegrations",
+		contextMatcher, deviceID, []uuid.UUID{integration.ID}).
+		Return(new(model.Device), nil).
+		Once()
+	ds.On("DeleteDevice", contextMatcher, deviceID).
+		Return(nil).
+		Once()
+	ds.On("SaveEvent", contextMatcher, mock.AnythingOfType("model.Event")).
+		Run(func(args mock.Arguments) {
+			event := args.Get(1).(model.Event)
+			if assert.Len(t, event.DeliveryStatus, 1) {
+				assert.True(t, event.DeliveryStatus[0].Success)
+				assert.Equal(t, integration.ID, event.DeliveryStatus[0].IntegrationID)
+			}
+		}).
+		Return(nil).
+		Times(3)
+	for _, typ := range []model.EventType{
+		model.EventTypeDeviceProvisioned,
+		model.EventTypeDeviceStatusChanged,
+		model.EventTypeDeviceDecommissioned,
+	} {
+		client.On("Publish",
+			contextMatcher,
+			integration.ID,
+			*integration.Credentials.MQTT,
+			topicPrefix+string(typ),
+			mock.AnythingOfType("[]uint8")).
+			Return(nil).
+			Once()
+	}
+
+	ctx := mqttTenantContext()
+	err := a.provisionDevice(ctx, model.DeviceEvent{ID: deviceID})
+	assert.NoError(t, err)
+	err = a.setDeviceStatus(ctx, deviceID, model.StatusAccepted)
+	assert.NoError(t, err)
+	err = a.decommissionDevice(ctx, deviceID)
+	assert.NoError(t, err)
+}
+
+func TestSyncMQTTDevices(t *testing.T) {
+	t.Parallel()
+	noLogger := log.NewEmpty()
+	noLogger.Logger.Out = io.Discard
+	const (
+		acceptedDevice = "38e5ebfb-963d-4ac2-8f5e-d51b2df1fa6e"
+		removedDevice  = "a4a32db1-047d-4b4b-9f4a-b86a6c16ab90"
+	)
+	integration := newMQTTIntegration("")
+
+	type testCase struct {
+		Name        string
+		Integration model.Integration
+		FailEarly   bool
+
+		GetDevicesError error
+		PublishError    error
+
+		Error error
+	}
+	testCases := []testCase{{
+		Name:        "ok",
+		Integration: integration,
+	}, {
+		Name:         "ok, publish error is logged",
+		Integration:  integration,
+		PublishError: errors.New("connection refused"),
+	}, {
+		Name:         "error, fail early",
+		Integration:  integration,
+		FailEarly:    true,
+		PublishError: errors.New("connection refused"),
+		Error: errors.New("failed to publish device status: " +
+			"failed to publish event to the MQTT broker: connection refused"),
+	}, {
+		Name: "error, invalid credentials",
+		Integration: model.Integration{
+			Provider: model.ProviderMQTT,
+			Credentials: model.Credentials{
+				Type: model.CredentialTypeHTTP,
+				MQTT: integration.Credentials.MQTT,
+			},
+		},
+		Error: ErrNoCredentials,
+	}, {
+		Name:            "error, device auth",
+		Integration:     integration,
+		GetDevicesError: errors.New("internal error"),
+		Error:           errors.New("app: failed to lookup device authentication: internal error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ctx := log.WithContext(mqttTenantContext(), noLogger)
+			deviceIDs := []string{acceptedDevice, removedDevice}
+
+			ds := new(storeMocks.DataStore)
+			defer ds.AssertExpectations(t)
+			da := new(mdevauth.Client)
+			defer da.AssertExpectations(t)
+			client := new(mqttMocks.Client)
+			defer client.AssertExpectations(t)
+
+			if tc.Error != ErrNoCredentials {
+				da.On("GetDevices", contextMatcher, deviceIDs).
+					Return([]devauth.Device{{
+						ID:     acceptedDevice,
+						Status: model.StatusAccepted,
+					}}, tc.GetDevicesError)
+			}
+			if tc.GetDevicesError == nil && tc.Error != ErrNoCredentials {
+				if !tc.FailEarly {
+					// Device without auth set is decommissioned
+					ds.On("GetIntegrations", contextMatcher, model.IntegrationFilter{}).
+						Return([]model.Integration{}, nil).
+						Once()
+					ds.On("DeleteDevice", contextMatcher, removedDevice).
+						Return(nil).
+						Once()
+				}
+				client.On("Publish",
+					contextMatcher,
+					integration.ID,
+					*integration.Credentials.MQTT,
+					"mender/"+mqttTenantID+"/devices/"+acceptedDevice+
+						"/device-status-changed",
+					mock.AnythingOfType("[]uint8")).
+					Return(tc.PublishError).
+					Once()
+			}
+
+			a := New(ds, nil, da).WithMQTT(client)
+			err := a.(*app).syncMQTTDevices(ctx, deviceIDs, tc.Integration, tc.FailEarly)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApplyDesiredState(t *testing.T) {
+	t.Parallel()
+	const deviceID = "68ac6f41-c2e7-429f-a4bd-852fac9a5045"
+	topic := "fleet/" + mqttTenantID + "/" + deviceID + "/desired"
+
+	type testCase struct {
+		Name    string
+		Target  model.MQTTDesiredStateTarget
+		Message mqtt.Message
+
+		GetDeviceError error
+		NoDevice       bool
+
+		Workflows func(t *testing.T) *wfMocks.Client
+
+		Error error
+	}
+	testCases := []testCase{{
+		Name:   "ok, deviceconfig",
+		Target: model.MQTTDesiredStateDeviceConfig,
+		Message: mqtt.Message{
+			Topic:   topic,
+			Payload: []byte(`{"mode":"eco","interval":30,"debug":true,"unset":null}`),
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceConfiguration", contextMatcher, deviceID,
+				map[string]string{
+					"mode":     "eco",
+					"interval": "30",
+					"debug":    "true",
+				},
+				map[string]string(nil)).
+				Return(nil)
+			return wf
+		},
+	}, {
+		Name:   "ok, inventory with device state format",
+		Target: model.MQTTDesiredStateInventory,
+		Message: mqtt.Message{
+			Topic:   topic,
+			Payload: []byte(`{"desired":{"location":"oslo","floor":3}}`),
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceInventory", contextMatcher, deviceID, "tags",
+				[]workflows.InventoryAttribute{
+					{Name: "floor", Value: 3.0},
+					{Name: "location", Value: "oslo"},
+				}).
+				Return(nil)
+			return wf
+		},
+	}, {
+		Name:   "error, workflows",
+		Target: model.MQTTDesiredStateInventory,
+		Message: mqtt.Message{
+			Topic:   topic,
+			Payload: []byte(`{"location":"oslo"}`),
+		},
+		Workflows: func(t *testing.T) *wfMocks.Client {
+			wf := new(wfMocks.Client)
+			wf.On("UpdateDeviceInventory", contextMatcher, deviceID, "tags",
+				mock.AnythingOfType("[]workflows.InventoryAttribute")).
+				Return(errors.New("internal error"))
+			return wf
+		},
+		Error: errors.New("failed to submit desired inventory attributes: internal error"),
+	}, {
+		Name:   "error, topic mismatch",
+		Target: model.MQTTDesiredStateInventory,
+		Message: mqtt.Message{
+			Topic:   "fleet/other-tenant/" + deviceID + "/desired",
+			Payload: []byte(`{}`),
+		},
+		Error: errors.New(`topic does not match "fleet/{tenant_id}/{device_id}/desired"`),
+	}, {
+		Name:   "error, device not found",
+		Target: model.MQTTDesiredStateInventory,
+		Message: mqtt.Message{
+			Topic:   topic,
+			Payload: []byte(`{}`),
+		},
+		GetDeviceError: store.ErrObjectNotFound,
+		Error:          ErrDeviceNotFound,
+	}, {
+		Name:   "error, device not in integration",
+		Target: model.MQTTDesiredStateInventory,
+		Message: mqtt.Message{
+			Topic:   topic,
+			Payload: []byte(`{}`),
+		},
+		NoDevice: true,
+		Error:    ErrDeviceNotFound,
+	}, {
+		Name:   "error, get device",
+		Target: model.MQTTDesiredStateInventory,
+		Message: mqtt.Message{
+			Topic:   topic,
+			Payload: []byte(`{}`),
+		},
+		GetDeviceError: errors.New("internal error"),
+		Error:          errors.New("failed to retrieve the device: internal error"),
+	}, {
+		Name:   "error, invalid payload",
+		Target: model.MQTTDesiredStateDeviceConfig,
+		Message: mqtt.Message{
+			Topic:   topic,
+			Payload: []byte(`["not", "an", "object"]`),
+		},
+		Error: errors.New("invalid desired state: json: cannot unmarshal array " +
+			"into Go value of type map[string]interface {}"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			integration := newMQTTIntegration(tc.Target)
+
+			ds := new(storeMocks.DataStore)
+			defer ds.AssertExpectations(t)
+			if tc.Message.Topic == topic {
+				var device *model.Device
+				if !tc.NoDevice && tc.GetDeviceError == nil {
+					device = &model.Device{
+						ID:             deviceID,
+						IntegrationIDs: []uuid.UUID{integration.ID},
+					}
+				}
+				ds.On("GetDeviceByIntegrationID",
+					contextMatcher, deviceID, integration.ID).
+					Return(device, tc.GetDeviceError)
+			}
+			wf := new(wfMocks.Client)
+			if tc.Workflows != nil {
+				wf = tc.Workflows(t)
+			}
+			defer wf.AssertExpectations(t)
+
+			a := New(ds, wf, nil).(*app)
+			err := a.applyDesiredState(mqttTenantContext(), integration, tc.Message)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubscribeDesiredState(t *testing.T) {
+	t.Parallel()
+	const deviceID = "68ac6f41-c2e7-429f-a4bd-852fac9a5045"
+	noLogger := log.NewEmpty()
+	noLogger.Logger.Out = io.Discard
+	ctx, cancel := context.WithTimeout(
+		log.WithContext(context.Background(), noLogger),
+		10*time.Second,
+	)
+	defer cancel()
+
+	integration := tenantIntegration{
+		Integration: newMQTTIntegration(model.MQTTDesiredStateInventory),
+		TenantID:    mqttTenantID,
+	}
+	noDesiredState := tenantIntegration{
+		Integration: model.Integration{
+			ID:       uuid.New(),
+			Provider: model.ProviderMQTT,
+			Credentials: model.Credentials{
+				Type: model.CredentialTypeMQTT,
+				MQTT: &model.MQTTCredentials{
+					BrokerURL: "mqtt://localhost",
+				},
+			},
+		},
+		TenantID: mqttTenantID,
+	}
+
+	ds := new(storeMocks.DataStore)
+	defer ds.AssertExpectations(t)
+	ds.On("GetAllIntegrations", contextMatcher, model.ProviderMQTT).
+		Return(&integrationIterator{
+			integrations: []tenantIntegration{integration, noDesiredState},
+		}, nil).
+		Once()
+	// The integration is removed on the next refresh
+	ds.On("GetAllIntegrations", contextMatcher, model.ProviderMQTT).
+		Return(&integrationIterator{}, nil)
+	ds.On("GetDeviceByIntegrationID", contextMatcher, deviceID, integration.ID).
+		Return(&model.Device{ID: deviceID}, nil)
+
+	applied := make(chan struct{})
+	wf := new(wfMocks.Client)
+	defer wf.AssertExpectations(t)
+	wf.On("UpdateDeviceInventory", mock.MatchedBy(func(ctx context.Context) bool {
+		id := identity.FromContext(ctx)
+		return id != nil && id.Tenant == mqttTenantID
+	}), deviceID, "tags", []workflows.InventoryAttribute{{
+		Name: "location", Value: "oslo",
+	}}).
+		Run(func(mock.Arguments) { close(applied) }).
+		Return(nil).
+		Once()
+
+	unsubscribed := make(chan struct{})
+	client := new(mqttMocks.Client)
+	defer client.AssertExpectations(t)
+	client.On("Subscribe",
+		contextMatcher,
+		integration.ID,
+		*integration.Credentials.MQTT,
+		"fleet/"+mqttTenantID+"/+/desired",
+		mock.AnythingOfType("mqtt.MessageHandler")).
+		Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			handler := args.Get(4).(mqtt.MessageHandler)
+			handler(ctx, mqtt.Message{
+				Topic:   "fleet/" + mqttTenantID + "/" + deviceID + "/desired",
+				Payload: []byte(`{"location":"oslo"}`),
+			})
+			<-ctx.Done()
+			close(unsubscribed)
+		}).
+		Return(nil).
+		Once()
+
+	a := New(ds, wf, nil).WithMQTT(client)
+	done := make(chan error, 1)
+	go func() {
+		done <- a.SubscribeDesiredState(ctx, 100*time.Millisecond)
+	}()
+	for _, c := range []chan struct{}{applied, unsubscribed} {
+		select {
+		case <-c:
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for the desired state subscription")
+		}
+	}
+	cancel()
+	assert.NoError(t, <-done)
+}
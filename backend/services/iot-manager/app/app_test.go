@@ -1629,6 +1629,158 @@ func TestSetDeviceStatus(t *testing.T) {
 	}
 }
 
+func TestSetDeviceConnectivity(t *testing.T) {
+	t.Parallel()
+	webhookIntegration := model.Integration{
+		ID:       uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+		Provider: model.ProviderWebhook,
+		Credentials: model.Credentials{
+			Type: model.CredentialTypeHTTP,
+			HTTP: &model.HTTPCredentials{
+				URL: "http://localhost",
+				Secret: func() *model.HexSecret {
+					sec := model.HexSecret([]byte{'1', '2', '3'})
+					return &sec
+				}(),
+			},
+		},
+	}
+	hubIntegration := model.Integration{
+		ID:       uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Provider: model.ProviderIoTHub,
+		Credentials: model.Credentials{
+			Type:             model.CredentialTypeSAS,
+			ConnectionString: validConnString,
+		},
+	}
+	offlineSince := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		Name         string
+		DeviceID     string
+		Connectivity model.DeviceConnectivity
+
+		Store        func(t *testing.T, self *testCase) *storeMocks.DataStore
+		RoundTripper func(t *testing.T, req *http.Request) (*http.Response, error)
+	}
+	testCases := []testCase{{
+		Name:     "ok, device offline",
+		DeviceID: "68ac6f41-c2e7-429f-a4bd-852fac9a5045",
+		Connectivity: model.DeviceConnectivity{
+			Offline:      true,
+			OfflineSince: &offlineSince,
+			CheckInTime:  &offlineSince,
+		},
+
+		Store: func(t *testing.T, self *testCase) *storeMocks.DataStore {
+			mockedStore := new(storeMocks.DataStore)
+			mockedStore.On("GetIntegrations",
+				contextMatcher,
+				model.IntegrationFilter{}).
+				Return([]model.Integration{
+					webhookIntegration,
+					// IoT Hub does not receive connectivity events
+					hubIntegration,
+				}, nil).
+				Once().
+				On("SaveEvent", contextMatcher, mock.AnythingOfType("model.Event")).
+				Run(func(args mock.Arguments) {
+					event := args.Get(1).(model.Event)
+					assert.Equal(t, model.EventTypeDeviceOffline, event.Type)
+					if assert.IsType(t, model.DeviceEvent{}, event.Data) {
+						data := event.Data.(model.DeviceEvent)
+						assert.Equal(t, self.DeviceID, data.ID)
+						assert.Equal(t, self.Connectivity.OfflineSince, data.OfflineSince)
+					}
+					if assert.Len(t, event.DeliveryStatus, 1) {
+						assert.True(t, event.DeliveryStatus[0].Success)
+					}
+				}).
+				Return(nil).
+				Once()
+			return mockedStore
+		},
+		RoundTripper: func(t *testing.T, req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			var event model.WebhookEvent
+			err := json.Unmarshal(b, &event)
+			assert.NoError(t, err)
+			assert.Equal(t, model.EventTypeDeviceOffline, event.Type)
+			w := httptest.NewRecorder()
+			w.WriteHeader(http.StatusOK)
+			return w.Result(), nil
+		},
+	}, {
+		Name:     "error/webhook returns error code",
+		DeviceID: "68ac6f41-c2e7-429f-a4bd-852fac9a5045",
+
+		Store: func(t *testing.T, self *testCase) *storeMocks.DataStore {
+			mockedStore := new(storeMocks.DataStore)
+			mockedStore.On("GetIntegrations",
+				contextMatcher,
+				model.IntegrationFilter{}).
+				Return([]model.Integration{webhookIntegration}, nil).
+				Once().
+				On("SaveEvent", contextMatcher, mock.AnythingOfType("model.Event")).
+				Run(func(args mock.Arguments) {
+					event := args.Get(1).(model.Event)
+					assert.Equal(t, model.EventTypeDeviceOnline, event.Type)
+					if assert.Len(t, event.DeliveryStatus, 1) {
+						stat := event.DeliveryStatus[0]
+						assert.False(t, stat.Success)
+						if assert.NotNil(t, stat.StatusCode) {
+							assert.Equal(t, http.StatusInternalServerError, *stat.StatusCode)
+						}
+					}
+				}).
+				Return(nil).
+				Once()
+			return mockedStore
+		},
+		RoundTripper: func(t *testing.T, req *http.Request) (*http.Response, error) {
+			w := httptest.NewRecorder()
+			w.WriteHeader(http.StatusInternalServerError)
+			return w.Result(), nil
+		},
+	}, {
+		Name:     "ok: integration does not exist",
+		DeviceID: "68ac6f41-c2e7-429f-a4bd-852fac9a5045",
+
+		Store: func(t *testing.T, self *testCase) *storeMocks.DataStore {
+			mockedStore := new(storeMocks.DataStore)
+			mockedStore.On("GetIntegrations", contextMatcher, model.IntegrationFilter{}).
+				Return(nil, store.ErrObjectNotFound)
+			return mockedStore
+		},
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			ds := tc.Store(t, &tc)
+			defer ds.AssertExpectations(t)
+			client := &http.Client{
+				Transport: roundTripperFunc(
+					func(req *http.Request) (*http.Response, error) {
+						return tc.RoundTripper(t, req)
+					},
+				),
+			}
+			a := &app{
+				store:           ds,
+				httpClient:      client,
+				webhooksTimeout: 10 * time.Second,
+			}
+
+			err := a.SetDeviceConnectivity(context.Background(), tc.DeviceID, tc.Connectivity)
+			assert.NoError(t, err)
+
+			// wait for the completion of the async go routine
+			time.Sleep(500 * time.Millisecond)
+		})
+	}
+}
+
 func TestGetDevice(t *testing.T) {
 	testCases := []struct {
 		Name string
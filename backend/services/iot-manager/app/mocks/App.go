@@ -29,6 +29,10 @@ import (
 
 	model "github.com/mendersoftware/mender-server/services/iot-manager/model"
 
+	mqtt "github.com/mendersoftware/mender-server/services/iot-manager/client/mqtt"
+
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -397,6 +401,42 @@ func (_m *App) RemoveIntegration(_a0 context.Context, _a1 uuid.UUID) error {
 	return r0
 }
 
+// SetDeviceConfiguration provides a mock function with given fields: ctx, deviceID, configuration
+func (_m *App) SetDeviceConfiguration(ctx context.Context, deviceID string, configuration model.DeviceConfiguration) error {
+	ret := _m.Called(ctx, deviceID, configuration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeviceConfiguration) error); ok {
+		r0 = rf(ctx, deviceID, configuration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDeviceConnectivity provides a mock function with given fields: _a0, _a1, _a2
+func (_m *App) SetDeviceConnectivity(_a0 context.Context, _a1 string, _a2 model.DeviceConnectivity) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceConnectivity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeviceConnectivity) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDeviceStateIntegration provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *App) SetDeviceStateIntegration(_a0 context.Context, _a1 string, _a2 uuid.UUID, _a3 *model.DeviceState) (*model.DeviceState, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -505,6 +545,24 @@ func (_m *App) SetDeviceStatus(_a0 context.Context, _a1 string, _a2 model.Status
 	return r0
 }
 
+// SetIntegrationConfigSync provides a mock function with given fields: _a0, _a1, _a2
+func (_m *App) SetIntegrationConfigSync(_a0 context.Context, _a1 uuid.UUID, _a2 *model.ConfigSync) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIntegrationConfigSync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.ConfigSync) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetIntegrationCredentials provides a mock function with given fields: _a0, _a1, _a2
 func (_m *App) SetIntegrationCredentials(_a0 context.Context, _a1 uuid.UUID, _a2 model.Credentials) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -523,6 +581,96 @@ func (_m *App) SetIntegrationCredentials(_a0 context.Context, _a1 uuid.UUID, _a2
 	return r0
 }
 
+// SetIntegrationInventorySync provides a mock function with given fields: _a0, _a1, _a2
+func (_m *App) SetIntegrationInventorySync(_a0 context.Context, _a1 uuid.UUID, _a2 *model.InventorySync) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIntegrationInventorySync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.InventorySync) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribeDesiredState provides a mock function with given fields: ctx, refreshInterval
+func (_m *App) SubscribeDesiredState(ctx context.Context, refreshInterval time.Duration) error {
+	ret := _m.Called(ctx, refreshInterval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeDesiredState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) error); ok {
+		r0 = rf(ctx, refreshInterval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncConfiguration provides a mock function with given fields: ctx, failEarly
+func (_m *App) SyncConfiguration(ctx context.Context, failEarly bool) error {
+	ret := _m.Called(ctx, failEarly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) error); ok {
+		r0 = rf(ctx, failEarly)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncDeviceConfiguration provides a mock function with given fields: ctx, deviceID
+func (_m *App) SyncDeviceConfiguration(ctx context.Context, deviceID string) error {
+	ret := _m.Called(ctx, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncDeviceConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, deviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncDeviceInventory provides a mock function with given fields: ctx, deviceID
+func (_m *App) SyncDeviceInventory(ctx context.Context, deviceID string) error {
+	ret := _m.Called(ctx, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncDeviceInventory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, deviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SyncDevices provides a mock function with given fields: _a0, _a1, _a2
 func (_m *App) SyncDevices(_a0 context.Context, _a1 int, _a2 bool) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -541,6 +689,24 @@ func (_m *App) SyncDevices(_a0 context.Context, _a1 int, _a2 bool) error {
 	return r0
 }
 
+// SyncInventory provides a mock function with given fields: ctx, failEarly
+func (_m *App) SyncInventory(ctx context.Context, failEarly bool) error {
+	ret := _m.Called(ctx, failEarly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncInventory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) error); ok {
+		r0 = rf(ctx, failEarly)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VerifyDeviceTwin provides a mock function with given fields: ctx, req
 func (_m *App) VerifyDeviceTwin(ctx context.Context, req model.PreauthRequest) error {
 	ret := _m.Called(ctx, req)
@@ -599,6 +765,26 @@ func (_m *App) WithIoTHub(client iothub.Client) app.App {
 	return r0
 }
 
+// WithMQTT provides a mock function with given fields: client
+func (_m *App) WithMQTT(client mqtt.Client) app.App {
+	ret := _m.Called(client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithMQTT")
+	}
+
+	var r0 app.App
+	if rf, ok := ret.Get(0).(func(mqtt.Client) app.App); ok {
+		r0 = rf(client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(app.App)
+		}
+	}
+
+	return r0
+}
+
 // WithWebhooksTimeout provides a mock function with given fields: timeout
 func (_m *App) WithWebhooksTimeout(timeout uint) app.App {
 	ret := _m.Called(timeout)
@@ -0,0 +1,432 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// MQTT 3.1.1 control packet types.
+const (
+	packetConnect     byte = 1
+	packetConnack     byte = 2
+	packetPublish     byte = 3
+	packetPuback      byte = 4
+	packetPubrec      byte = 5
+	packetPubrel      byte = 6
+	packetPubcomp     byte = 7
+	packetSubscribe   byte = 8
+	packetSuback      byte = 9
+	packetUnsubscribe byte = 10
+	packetUnsuback    byte = 11
+	packetPingreq     byte = 12
+	packetPingresp    byte = 13
+	packetDisconnect  byte = 14
+	maxRemainingBytes      = 4
+
+	connectFlagUsername byte = 0x80
+	pubrelFlags         byte = 0x02
+)
+
+var errMalformedPacket = errors.New("mqtt: malformed packet")
+
+type packet struct {
+	typ   byte
+	flags byte
+	body  []byte
+}
+
+func (p packet) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, 0, len(p.body)+1+maxRemainingBytes)
+	buf = append(buf, p.typ<<4|p.flags&0x0f)
+	length := len(p.body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		buf = append(buf, digit)
+		if length == 0 {
+			break
+		}
+	}
+	buf = append(buf, p.body...)
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+func readPacket(r *bufio.Reader) (*packet, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	var length, multiplier int = 0, 1
+	for i := 0; ; i++ {
+		if i == maxRemainingBytes {
+			return nil, errMalformedPacket
+		}
+		digit, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		length += int(digit&0x7f) * multiplier
+		multiplier *= 128
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+	p := &packet{
+		typ:   header >> 4,
+		flags: header & 0x0f,
+		body:  make([]byte, length),
+	}
+	if _, err = io.ReadFull(r, p.body); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+func readString(b []byte) (string, []byte, error) {
+	if len(b) < 2 {
+		return "", nil, errMalformedPacket
+	}
+	n := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+n {
+		return "", nil, errMalformedPacket
+	}
+	return string(b[2 : 2+n]), b[2+n:], nil
+}
+
+func readUint16(b []byte) (uint16, []byte, error) {
+	if len(b) < 2 {
+		return 0, nil, errMalformedPacket
+	}
+	return binary.BigEndian.Uint16(b), b[2:], nil
+}
+
+func packetWithID(typ byte, packetID uint16) packet {
+	return packet{
+		typ:  typ,
+		body: binary.BigEndian.AppendUint16(nil, packetID),
+	}
+}
+
+type publish struct {
+	topic    string
+	packetID uint16
+	qos      byte
+	payload  []byte
+}
+
+func (p publish) packet() packet {
+	body := appendString(make([]byte, 0, len(p.topic)+4+len(p.payload)), p.topic)
+	if p.qos > 0 {
+		body = binary.BigEndian.AppendUint16(body, p.packetID)
+	}
+	body = append(body, p.payload...)
+	return packet{typ: packetPublish, flags: p.qos << 1, body: body}
+}
+
+func parsePublish(p *packet) (*publish, error) {
+	var (
+		err  error
+		body = p.body
+		pub  = &publish{qos: (p.flags >> 1) & 0x03}
+	)
+	pub.topic, body, err = readString(body)
+	if err != nil {
+		return nil, err
+	}
+	if pub.qos > 0 {
+		pub.packetID, body, err = readUint16(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pub.payload = body
+	return pub, nil
+}
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+
+	mu       sync.Mutex
+	packetID uint16
+}
+
+func (c *conn) write(p packet) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := p.WriteTo(c.Conn)
+	return err
+}
+
+func (c *conn) nextPacketID() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.packetID++
+	if c.packetID == 0 {
+		c.packetID = 1
+	}
+	return c.packetID
+}
+
+type subscription struct {
+	filter string
+	qos    byte
+}
+
+// testBroker is a minimal MQTT broker implementing the subset of the
+// protocol used by the client.
+type testBroker struct {
+	t        *testing.T
+	listener net.Listener
+
+	username string
+	password string
+
+	mu            sync.Mutex
+	connections   int
+	subscriptions map[*conn][]subscription
+	published     []publish
+	subscribed    chan string
+	// acknowledged receives the IDs of the messages acknowledged by the
+	// subscribers
+	acknowledged chan uint16
+}
+
+func newTestBroker(t *testing.T, config *tls.Config) *testBroker {
+	var (
+		l   net.Listener
+		err error
+	)
+	if config != nil {
+		l, err = tls.Listen("tcp", "127.0.0.1:0", config)
+	} else {
+		l, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &testBroker{
+		t:             t,
+		listener:      l,
+		subscriptions: make(map[*conn][]subscription),
+		subscribed:    make(chan string, 10),
+		acknowledged:  make(chan uint16, 10),
+	}
+	go b.serve()
+	t.Cleanup(func() { l.Close() })
+	return b
+}
+
+func (b *testBroker) Addr() string {
+	return b.listener.Addr().String()
+}
+
+func (b *testBroker) Published() []publish {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]publish{}, b.published...)
+}
+
+// Connections returns the number of sessions currently connected.
+func (b *testBroker) Connections() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.connections
+}
+
+// Subscriptions returns the topic filters subscribed by all the sessions.
+func (b *testBroker) Subscriptions() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var filters []string
+	for _, subs := range b.subscriptions {
+		for _, sub := range subs {
+			filters = append(filters, sub.filter)
+		}
+	}
+	return filters
+}
+
+func (b *testBroker) serve() {
+	for {
+		nc, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(&conn{Conn: nc, r: bufio.NewReader(nc)})
+	}
+}
+
+func topicMatch(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		} else if i >= len(topicLevels) {
+			return false
+		} else if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
+
+func (b *testBroker) connect(c *conn) bool {
+	p, err := readPacket(c.r)
+	if err != nil || p.typ != packetConnect {
+		return false
+	}
+	// skip protocol name and level
+	_, rest, _ := readString(p.body)
+	flags := rest[1]
+	// skip flags and keep alive
+	_, rest, _ = readString(rest[4:])
+	var username, password string
+	if flags&connectFlagUsername != 0 {
+		username, rest, _ = readString(rest)
+		password, _, _ = readString(rest)
+	}
+	if username != b.username || password != b.password {
+		_ = c.write(packet{typ: packetConnack, body: []byte{0, 4}})
+		return false
+	}
+	if err = c.write(packet{typ: packetConnack, body: []byte{0, 0}}); err != nil {
+		return false
+	}
+	b.mu.Lock()
+	b.connections++
+	b.mu.Unlock()
+	return true
+}
+
+func (b *testBroker) handle(c *conn) {
+	defer c.Close()
+	if !b.connect(c) {
+		return
+	}
+	defer func() {
+		b.mu.Lock()
+		b.connections--
+		delete(b.subscriptions, c)
+		b.mu.Unlock()
+	}()
+	for {
+		p, err := readPacket(c.r)
+		if err != nil {
+			return
+		}
+		switch p.typ {
+		case packetSubscribe:
+			packetID, rest, _ := readUint16(p.body)
+			filter, rest, _ := readString(rest)
+			granted := rest[0]
+			if strings.HasPrefix(filter, "forbidden/") {
+				granted = subackFailure
+			} else {
+				b.mu.Lock()
+				b.subscriptions[c] = append(b.subscriptions[c], subscription{
+					filter: filter,
+					qos:    granted,
+				})
+				b.mu.Unlock()
+			}
+			suback := packetWithID(packetSuback, packetID)
+			suback.body = append(suback.body, granted)
+			_ = c.write(suback)
+			b.subscribed <- filter
+
+		case packetUnsubscribe:
+			packetID, rest, _ := readUint16(p.body)
+			filter, _, _ := readString(rest)
+			b.mu.Lock()
+			subs := b.subscriptions[c][:0]
+			for _, sub := range b.subscriptions[c] {
+				if sub.filter != filter {
+					subs = append(subs, sub)
+				}
+			}
+			b.subscriptions[c] = subs
+			b.mu.Unlock()
+			_ = c.write(packetWithID(packetUnsuback, packetID))
+
+		case packetPublish:
+			pub, err := parsePublish(p)
+			if err != nil {
+				return
+			}
+			b.mu.Lock()
+			b.published = append(b.published, *pub)
+			for sub, filters := range b.subscriptions {
+				for _, filter := range filters {
+					if topicMatch(filter.filter, pub.topic) {
+						_ = sub.write(publish{
+							topic:    pub.topic,
+							qos:      min(filter.qos, pub.qos),
+							packetID: sub.nextPacketID(),
+							payload:  pub.payload,
+						}.packet())
+						break
+					}
+				}
+			}
+			b.mu.Unlock()
+			switch pub.qos {
+			case 1:
+				_ = c.write(packetWithID(packetPuback, pub.packetID))
+			case 2:
+				_ = c.write(packetWithID(packetPubrec, pub.packetID))
+			}
+
+		case packetPubrel:
+			packetID, _, _ := readUint16(p.body)
+			_ = c.write(packetWithID(packetPubcomp, packetID))
+
+		case packetPubrec:
+			packetID, _, _ := readUint16(p.body)
+			pubrel := packetWithID(packetPubrel, packetID)
+			pubrel.flags = pubrelFlags
+			_ = c.write(pubrel)
+
+		case packetPuback, packetPubcomp:
+			packetID, _, _ := readUint16(p.body)
+			b.acknowledged <- packetID
+
+		case packetPingreq:
+			_ = c.write(packet{typ: packetPingresp})
+
+		case packetDisconnect:
+			return
+		}
+	}
+}
@@ -0,0 +1,426 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultKeepAlive   = 60 * time.Second
+	defaultIdleTimeout = 10 * time.Minute
+	clientIDPrefix     = "mender-iot-manager-"
+
+	defaultPort    = "1883"
+	defaultTLSPort = "8883"
+
+	subackFailure byte = 0x80
+)
+
+var (
+	ErrSubscriptionRejected = errors.New("mqtt: subscription rejected by the broker")
+	ErrTimeout              = errors.New("mqtt: timeout waiting for the broker")
+)
+
+// Message is an application message received from the broker.
+type Message struct {
+	Topic   string
+	Payload []byte
+}
+
+// MessageHandler processes the messages received on a subscription.
+type MessageHandler func(ctx context.Context, msg Message)
+
+// Client is an MQTT client keeping a persistent session with the broker
+// of each integration.
+//
+//go:generate ../../../../utils/mockgen.sh
+type Client interface {
+	// Publish delivers the payload to the broker with the QoS level of
+	// the credentials.
+	Publish(
+		ctx context.Context,
+		integrationID uuid.UUID,
+		creds model.MQTTCredentials,
+		topic string,
+		payload []byte,
+	) error
+	// Subscribe subscribes to the topic filter and calls the handler for
+	// each message received; messages are acknowledged once the handler
+	// returns. It blocks until the context is canceled, the session
+	// reconnects automatically if the connection is lost.
+	Subscribe(
+		ctx context.Context,
+		integrationID uuid.UUID,
+		creds model.MQTTCredentials,
+		topic string,
+		handler MessageHandler,
+	) error
+}
+
+type Options struct {
+	Timeout     *time.Duration
+	KeepAlive   *time.Duration
+	IdleTimeout *time.Duration
+	RootCAs     *x509.CertPool
+}
+
+func NewOptions(opts ...*Options) *Options {
+	ret := new(Options)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Timeout != nil {
+			ret.Timeout = opt.Timeout
+		}
+		if opt.KeepAlive != nil {
+			ret.KeepAlive = opt.KeepAlive
+		}
+		if opt.IdleTimeout != nil {
+			ret.IdleTimeout = opt.IdleTimeout
+		}
+		if opt.RootCAs != nil {
+			ret.RootCAs = opt.RootCAs
+		}
+	}
+	return ret
+}
+
+// SetTimeout sets the timeout for connecting and delivering messages.
+func (opts *Options) SetTimeout(timeout time.Duration) *Options {
+	opts.Timeout = &timeout
+	return opts
+}
+
+// SetKeepAlive sets the keep alive interval of the sessions.
+func (opts *Options) SetKeepAlive(keepAlive time.Duration) *Options {
+	opts.KeepAlive = &keepAlive
+	return opts
+}
+
+// SetIdleTimeout sets the time after which a session without
+// subscriptions and publications is disconnected.
+func (opts *Options) SetIdleTimeout(idleTimeout time.Duration) *Options {
+	opts.IdleTimeout = &idleTimeout
+	return opts
+}
+
+// SetRootCAs sets the pool of certificate authorities used for verifying
+// the broker certificate (defaults to the system pool).
+func (opts *Options) SetRootCAs(pool *x509.CertPool) *Options {
+	opts.RootCAs = pool
+	return opts
+}
+
+type client struct {
+	timeout     time.Duration
+	keepAlive   time.Duration
+	idleTimeout time.Duration
+	rootCAs     *x509.CertPool
+
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*session
+}
+
+func NewClient(opts ...*Options) Client {
+	opt := NewOptions(opts...)
+	c := &client{
+		timeout:     defaultTimeout,
+		keepAlive:   defaultKeepAlive,
+		idleTimeout: defaultIdleTimeout,
+		rootCAs:     opt.RootCAs,
+		sessions:    make(map[uuid.UUID]*session),
+	}
+	if opt.Timeout != nil {
+		c.timeout = *opt.Timeout
+	}
+	if opt.KeepAlive != nil {
+		c.keepAlive = *opt.KeepAlive
+	}
+	if opt.IdleTimeout != nil {
+		c.idleTimeout = *opt.IdleTimeout
+	}
+	return c
+}
+
+// session is the connection of an integration to its broker.
+type session struct {
+	paho.Client
+	// fingerprint identifies the credentials the session was opened with
+	fingerprint [sha256.Size]byte
+	connected   paho.Token
+	// lastUsed and subscriptions are protected by the client mutex
+	lastUsed      time.Time
+	subscriptions int
+}
+
+func fingerprint(creds model.MQTTCredentials) [sha256.Size]byte {
+	// The topics and the desired state target do not affect the session.
+	b, _ := json.Marshal(struct {
+		BrokerURL         string
+		Username          *string
+		Password          *string
+		ClientCertificate *string
+		ClientKey         *string
+		CACertificate     *string
+	}{
+		BrokerURL:         creds.BrokerURL,
+		Username:          creds.Username,
+		Password:          (*string)(creds.Password),
+		ClientCertificate: creds.ClientCertificate,
+		ClientKey:         (*string)(creds.ClientKey),
+		CACertificate:     creds.CACertificate,
+	})
+	return sha256.Sum256(b)
+}
+
+func (c *client) tlsConfig(creds model.MQTTCredentials, hostname string) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: hostname,
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    c.rootCAs,
+	}
+	if creds.CACertificate != nil {
+		pool := c.rootCAs
+		if pool == nil {
+			var err error
+			pool, err = x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+		} else {
+			pool = pool.Clone()
+		}
+		if !pool.AppendCertsFromPEM([]byte(*creds.CACertificate)) {
+			return nil, errors.New("mqtt: invalid CA certificate")
+		}
+		config.RootCAs = pool
+	}
+	if creds.ClientCertificate != nil && creds.ClientKey != nil {
+		cert, err := tls.X509KeyPair(
+			[]byte(*creds.ClientCertificate),
+			[]byte(*creds.ClientKey),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "mqtt: invalid client certificate")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func (c *client) clientOptions(
+	integrationID uuid.UUID,
+	creds model.MQTTCredentials,
+) (*paho.ClientOptions, error) {
+	uu, err := url.Parse(creds.BrokerURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "mqtt: invalid broker URL")
+	}
+	scheme, port := "tcp", defaultPort
+	if creds.UseTLS() {
+		scheme, port = "tls", defaultTLSPort
+	}
+	if uu.Port() != "" {
+		port = uu.Port()
+	}
+	opts := paho.NewClientOptions().
+		AddBroker(scheme + "://" + net.JoinHostPort(uu.Hostname(), port)).
+		SetClientID(clientIDPrefix + uuid.NewString()).
+		SetProtocolVersion(4).
+		// Keep the subscriptions and the messages in flight across
+		// reconnections.
+		SetCleanSession(false).
+		SetResumeSubs(true).
+		SetAutoReconnect(true).
+		SetConnectRetry(false).
+		SetConnectTimeout(c.timeout).
+		SetWriteTimeout(c.timeout).
+		SetPingTimeout(c.timeout).
+		SetKeepAlive(c.keepAlive).
+		SetMaxReconnectInterval(time.Minute).
+		// Messages are handled in order and acknowledged by the
+		// subscription once the handler returns.
+		SetOrderMatters(true).
+		SetAutoAckDisabled(true).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			log.NewEmpty().
+				WithField("integration_id", integrationID.String()).
+				Warnf("mqtt: connection lost, reconnecting: %s", err)
+		})
+	if creds.Username != nil {
+		opts.SetUsername(*creds.Username)
+	}
+	if creds.Password != nil {
+		opts.SetPassword(string(*creds.Password))
+	}
+	if creds.UseTLS() {
+		config, err := c.tlsConfig(creds, uu.Hostname())
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(config)
+	}
+	return opts, nil
+}
+
+// session returns the session of the integration, connecting to the broker
+// if the integration has no session yet or if its credentials changed.
+// Sessions left idle for longer than the idle timeout are closed.
+func (c *client) session(
+	ctx context.Context,
+	integrationID uuid.UUID,
+	creds model.MQTTCredentials,
+) (*session, error) {
+	fp := fingerprint(creds)
+	now := time.Now()
+
+	c.mu.Lock()
+	for id, s := range c.sessions {
+		if id != integrationID && s.subscriptions == 0 &&
+			now.Sub(s.lastUsed) > c.idleTimeout {
+			delete(c.sessions, id)
+			go s.Disconnect(0)
+		}
+	}
+	s, ok := c.sessions[integrationID]
+	if ok && s.fingerprint != fp {
+		delete(c.sessions, integrationID)
+		go s.Disconnect(0)
+		ok = false
+	}
+	if !ok {
+		opts, err := c.clientOptions(integrationID, creds)
+		if err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		s = &session{
+			Client:      paho.NewClient(opts),
+			fingerprint: fp,
+		}
+		s.connected = s.Connect()
+		c.sessions[integrationID] = s
+	}
+	s.lastUsed = now
+	c.mu.Unlock()
+
+	if err := c.wait(ctx, s.connected); err != nil {
+		c.mu.Lock()
+		if c.sessions[integrationID] == s {
+			// let the next call retry
+			delete(c.sessions, integrationID)
+		}
+		c.mu.Unlock()
+		s.Disconnect(0)
+		return nil, errors.Wrap(err, "mqtt: failed to connect to the broker")
+	}
+	return s, nil
+}
+
+// wait waits for the completion of the token and returns its error.
+func (c *client) wait(ctx context.Context, token paho.Token) error {
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return ErrTimeout
+	}
+}
+
+func (c *client) Publish(
+	ctx context.Context,
+	integrationID uuid.UUID,
+	creds model.MQTTCredentials,
+	topic string,
+	payload []byte,
+) error {
+	s, err := c.session(ctx, integrationID, creds)
+	if err != nil {
+		return err
+	}
+	token := s.Client.Publish(topic, creds.QualityOfService(), false, payload)
+	if err := c.wait(ctx, token); err != nil {
+		return errors.Wrap(err, "mqtt: failed to publish message")
+	}
+	return nil
+}
+
+func (c *client) Subscribe(
+	ctx context.Context,
+	integrationID uuid.UUID,
+	creds model.MQTTCredentials,
+	topic string,
+	handler MessageHandler,
+) error {
+	s, err := c.session(ctx, integrationID, creds)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	s.subscriptions++
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		s.subscriptions--
+		s.lastUsed = time.Now()
+		c.mu.Unlock()
+	}()
+
+	token := s.Client.Subscribe(topic, creds.QualityOfService(),
+		func(_ paho.Client, msg paho.Message) {
+			handler(ctx, Message{
+				Topic:   msg.Topic(),
+				Payload: msg.Payload(),
+			})
+			msg.Ack()
+		})
+	if err := c.wait(ctx, token); err != nil {
+		return errors.Wrap(err, "mqtt: failed to subscribe")
+	}
+	if sub, ok := token.(*paho.SubscribeToken); ok &&
+		sub.Result()[topic] == subackFailure {
+		return ErrSubscriptionRejected
+	}
+
+	<-ctx.Done()
+	// The session outlives the subscription: unsubscribe explicitly.
+	unsubCtx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	_ = c.wait(unsubCtx, s.Unsubscribe(topic))
+	return nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/crypto"
+	"github.com/mendersoftware/mender-server/services/iot-manager/model"
+)
+
+func str2ptr(s string) *string {
+	return &s
+}
+
+func newCertificate(t *testing.T, ips ...net.IP) (tls.Certificate, string, string) {
+	t.Helper()
+	pkey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           ips,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, pkey.Public(), pkey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(pkey)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return cert, string(certPEM), string(keyPEM)
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	t.Parallel()
+	broker := newTestBroker(t, nil)
+	broker.username = "mender"
+	broker.password = "secret"
+	password := crypto.String("secret")
+	creds := model.MQTTCredentials{
+		BrokerURL: "mqtt://" + broker.Addr(),
+		Username:  str2ptr("mender"),
+		Password:  &password,
+	}
+	integrationID := uuid.New()
+	client := NewClient(NewOptions().
+		SetTimeout(5 * time.Second).
+		SetKeepAlive(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	subCtx, subCancel := context.WithCancel(ctx)
+	messages := make(chan Message, 1)
+	release := make(chan struct{})
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- client.Subscribe(subCtx, integrationID, creds, "fleet/+/desired",
+			func(_ context.Context, msg Message) {
+				messages <- msg
+				<-release
+			})
+	}()
+	select {
+	case filter := <-broker.subscribed:
+		assert.Equal(t, "fleet/+/desired", filter)
+	case err := <-errChan:
+		t.Fatalf("unexpected error subscribing: %s", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for subscription")
+	}
+
+	err := client.Publish(ctx, integrationID, creds,
+		"fleet/device/desired", []byte(`{"key":"value"}`))
+	require.NoError(t, err)
+	err = client.Publish(ctx, integrationID, creds, "fleet/device/reported", []byte(`{}`))
+	require.NoError(t, err)
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, Message{
+			Topic:   "fleet/device/desired",
+			Payload: []byte(`{"key":"value"}`),
+		}, msg)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for message")
+	}
+	// The message is acknowledged only once handled
+	select {
+	case <-broker.acknowledged:
+		t.Fatal("message acknowledged before the handler returned")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-broker.acknowledged:
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for message acknowledgement")
+	}
+	assert.Len(t, broker.Published(), 2)
+	// The subscription and the publications share the same session
+	assert.Equal(t, 1, broker.Connections())
+
+	// Let the keep alive kick in before closing the subscription
+	time.Sleep(time.Second)
+	subCancel()
+	select {
+	case err := <-errChan:
+		assert.NoError(t, err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for subscription to close")
+	}
+	assert.Empty(t, broker.Subscriptions())
+	assert.Equal(t, 1, broker.Connections())
+}
+
+func TestPublishQoS(t *testing.T) {
+	t.Parallel()
+	broker := newTestBroker(t, nil)
+	client := NewClient(NewOptions().SetTimeout(5 * time.Second))
+	integrationID := uuid.New()
+	for _, qos := range []byte{0, 1, 2} {
+		err := client.Publish(context.Background(), integrationID, model.MQTTCredentials{
+			BrokerURL: "mqtt://" + broker.Addr(),
+			QoS:       &qos,
+		}, "topic", []byte("hello"))
+		require.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool {
+		return len(broker.Published()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	for i, pub := range broker.Published() {
+		assert.Equal(t, byte(i), pub.qos)
+	}
+	assert.Equal(t, 1, broker.Connections())
+}
+
+func TestIdleSession(t *testing.T) {
+	t.Parallel()
+	broker := newTestBroker(t, nil)
+	client := NewClient(NewOptions().
+		SetTimeout(5 * time.Second).
+		SetIdleTimeout(100 * time.Millisecond))
+	creds := model.MQTTCredentials{
+		BrokerURL: "mqtt://" + broker.Addr(),
+	}
+
+	ctx := context.Background()
+	require.NoError(t, client.Publish(ctx, uuid.New(), creds, "topic", nil))
+	require.NoError(t, client.Publish(ctx, uuid.New(), creds, "topic", nil))
+	assert.Equal(t, 2, broker.Connections())
+
+	// Using a session closes the sessions left idle
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, client.Publish(ctx, uuid.New(), creds, "topic", nil))
+	assert.Eventually(t, func() bool {
+		return broker.Connections() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConnectRefused(t *testing.T) {
+	t.Parallel()
+	broker := newTestBroker(t, nil)
+	broker.username = "mender"
+	broker.password = "secret"
+
+	client := NewClient()
+	err := client.Publish(context.Background(), uuid.New(), model.MQTTCredentials{
+		BrokerURL: "mqtt://" + broker.Addr(),
+		Username:  str2ptr("mender"),
+	}, "topic", nil)
+	assert.ErrorIs(t, err, packets.ErrorRefusedBadUsernameOrPassword)
+
+	err = client.Subscribe(context.Background(), uuid.New(), model.MQTTCredentials{
+		BrokerURL: "tcp://" + broker.Addr(),
+	}, "topic", nil)
+	assert.ErrorIs(t, err, packets.ErrorRefusedBadUsernameOrPassword)
+
+	err = client.Publish(context.Background(), uuid.New(), model.MQTTCredentials{
+		BrokerURL: "mqtt://127.0.0.1:1",
+	}, "topic", nil)
+	assert.ErrorContains(t, err, "mqtt: failed to connect to the broker")
+}
+
+func TestSubscribeRejected(t *testing.T) {
+	t.Parallel()
+	broker := newTestBroker(t, nil)
+	client := NewClient()
+	err := client.Subscribe(context.Background(), uuid.New(), model.MQTTCredentials{
+		BrokerURL: "mqtt://" + broker.Addr(),
+	}, "forbidden/#", nil)
+	assert.ErrorIs(t, err, ErrSubscriptionRejected)
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	t.Parallel()
+	serverCert, serverPEM, _ := newCertificate(t, net.IPv4(127, 0, 0, 1))
+	_, clientPEM, clientKey := newCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(clientPEM))
+	broker := newTestBroker(t, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+
+	key := crypto.String(clientKey)
+	creds := model.MQTTCredentials{
+		BrokerURL:         "mqtts://" + broker.Addr(),
+		CACertificate:     &serverPEM,
+		ClientCertificate: &clientPEM,
+		ClientKey:         &key,
+	}
+	integrationID := uuid.New()
+	client := NewClient()
+	err := client.Publish(context.Background(), integrationID, creds, "topic", []byte("hello"))
+	require.NoError(t, err)
+	if assert.Len(t, broker.Published(), 1) {
+		assert.Equal(t, "topic", broker.Published()[0].topic)
+	}
+
+	// Changing the credentials replaces the session:
+	// unknown broker certificate
+	creds.CACertificate = nil
+	err = client.Publish(context.Background(), integrationID, creds, "topic", nil)
+	assert.ErrorContains(t, err, "certificate")
+
+	// Invalid CA certificate
+	creds.CACertificate = str2ptr("garbage")
+	err = client.Publish(context.Background(), integrationID, creds, "topic", nil)
+	assert.EqualError(t, err, "mqtt: invalid CA certificate")
+}
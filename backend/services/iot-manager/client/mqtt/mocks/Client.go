@@ -0,0 +1,83 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+// Code generated by mockery v2.45.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/mendersoftware/mender-server/services/iot-manager/model"
+	mock "github.com/stretchr/testify/mock"
+
+	mqtt "github.com/mendersoftware/mender-server/services/iot-manager/client/mqtt"
+
+	uuid "github.com/google/uuid"
+)
+
+// Client is an autogenerated mock type for the Client type
+type Client struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: ctx, integrationID, creds, topic, payload
+func (_m *Client) Publish(ctx context.Context, integrationID uuid.UUID, creds model.MQTTCredentials, topic string, payload []byte) error {
+	ret := _m.Called(ctx, integrationID, creds, topic, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.MQTTCredentials, string, []byte) error); ok {
+		r0 = rf(ctx, integrationID, creds, topic, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: ctx, integrationID, creds, topic, handler
+func (_m *Client) Subscribe(ctx context.Context, integrationID uuid.UUID, creds model.MQTTCredentials, topic string, handler mqtt.MessageHandler) error {
+	ret := _m.Called(ctx, integrationID, creds, topic, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.MQTTCredentials, string, mqtt.MessageHandler) error); ok {
+		r0 = rf(ctx, integrationID, creds, topic, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Client {
+	mock := &Client{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -34,6 +34,8 @@ import (
 const (
 	URICheckHealth     = "/api/v1/health"
 	URIProvisionDevice = "/api/v1/workflow/provision_external_device"
+	URIUpdateInventory = "/api/v1/workflow/update_device_inventory"
+	URIUpdateConfig    = "/api/v1/workflow/update_device_configuration"
 )
 
 const (
@@ -46,6 +48,26 @@ const (
 type Client interface {
 	CheckHealth(ctx context.Context) error
 	ProvisionExternalDevice(ctx context.Context, devID string, config map[string]string) error
+	UpdateDeviceInventory(
+		ctx context.Context,
+		devID string,
+		scope string,
+		attributes []InventoryAttribute,
+	) error
+	// UpdateDeviceConfiguration applies the configuration and the reported
+	// configuration to the device in deviceconfig; nil values are skipped.
+	UpdateDeviceConfiguration(
+		ctx context.Context,
+		devID string,
+		configuration map[string]string,
+		reported map[string]string,
+	) error
+}
+
+// InventoryAttribute is a device attribute submitted to the inventory.
+type InventoryAttribute struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
 }
 
 type Options struct {
@@ -171,3 +193,115 @@ func (c *client) ProvisionExternalDevice(
 	}
 	return nil
 }
+
+func (c *client) UpdateDeviceInventory(
+	ctx context.Context,
+	devID string,
+	scope string,
+	attributes []InventoryAttribute,
+) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	attrs, _ := json.Marshal(attributes)
+	var workflow = struct {
+		TenantID   string `json:"tenant_id"`
+		DeviceID   string `json:"device_id"`
+		RequestID  string `json:"request_id"`
+		Scope      string `json:"scope"`
+		Attributes string `json:"attributes"`
+	}{
+		DeviceID:   devID,
+		RequestID:  requestid.FromContext(ctx),
+		Scope:      scope,
+		Attributes: string(attrs),
+	}
+
+	if id := identity.FromContext(ctx); id != nil {
+		workflow.TenantID = id.Tenant
+	}
+
+	b, _ := json.Marshal(workflow)
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		c.url+URIUpdateInventory,
+		bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to prepare request")
+	}
+	rsp, err := c.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to execute request")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode >= 400 {
+		return common.NewHTTPError(rsp.StatusCode)
+	}
+	return nil
+}
+
+func (c *client) UpdateDeviceConfiguration(
+	ctx context.Context,
+	devID string,
+	configuration map[string]string,
+	reported map[string]string,
+) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	var workflow = struct {
+		TenantID      string      `json:"tenant_id"`
+		DeviceID      string      `json:"device_id"`
+		RequestID     string      `json:"request_id"`
+		Configuration interface{} `json:"configuration"`
+		Reported      interface{} `json:"reported"`
+	}{
+		DeviceID:      devID,
+		RequestID:     requestid.FromContext(ctx),
+		Configuration: "",
+		Reported:      "",
+	}
+	// The workflow skips the tasks with empty inputs.
+	if configuration != nil {
+		workflow.Configuration = configuration
+	}
+	if reported != nil {
+		workflow.Reported = reported
+	}
+
+	if id := identity.FromContext(ctx); id != nil {
+		workflow.TenantID = id.Tenant
+	}
+
+	b, _ := json.Marshal(workflow)
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		c.url+URIUpdateConfig,
+		bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to prepare request")
+	}
+	rsp, err := c.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to execute request")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode >= 400 {
+		return common.NewHTTPError(rsp.StatusCode)
+	}
+	return nil
+}
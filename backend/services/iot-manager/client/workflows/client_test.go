@@ -319,3 +319,208 @@ func TestProvisionExternalDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateDeviceInventory(t *testing.T) {
+	t.Parallel()
+	const deviceID = "60131e78-5c31-43bf-9fab-2aaa3b422d13"
+	attributes := []InventoryAttribute{{
+		Name:  "foo",
+		Value: "bar",
+	}, {
+		Name:  "answer",
+		Value: 42.0,
+	}}
+	testCases := []struct {
+		Name string
+
+		CTX            context.Context
+		RoundTripError error
+		URLNoise       string
+
+		ResponseCode int
+		Error        error
+	}{{
+		Name: "ok",
+
+		CTX: identity.WithContext(context.Background(), &identity.Identity{
+			Tenant: "123456789012345678901234",
+		}),
+		ResponseCode: http.StatusCreated,
+	}, {
+		Name: "error/bad status code",
+
+		CTX:          context.Background(),
+		ResponseCode: http.StatusBadRequest,
+		Error:        common.NewHTTPError(http.StatusBadRequest),
+	}, {
+		Name: "error/round trip error",
+
+		CTX:            context.Background(),
+		RoundTripError: errors.New("internal error"),
+
+		Error: errors.New("workflows: failed to execute request:.*internal error"),
+	}, {
+		Name:     "error/fail to prepare request",
+		URLNoise: "%%%",
+
+		Error: errors.New("workflows: failed to prepare request"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			htClient := &http.Client{Transport: roundTripperFunc(func(
+				r *http.Request,
+			) (*http.Response, error) {
+				defer r.Body.Close()
+				if tc.RoundTripError != nil {
+					return nil, tc.RoundTripError
+				}
+				assert.Equal(t, URIUpdateInventory, r.URL.Path)
+				var req struct {
+					TenantID   string `json:"tenant_id"`
+					DeviceID   string `json:"device_id"`
+					Scope      string `json:"scope"`
+					Attributes string `json:"attributes"`
+				}
+				err := json.NewDecoder(r.Body).Decode(&req)
+				if assert.NoError(t, err) {
+					var tenantID string
+					if id := identity.FromContext(r.Context()); id != nil {
+						tenantID = id.Tenant
+					}
+					assert.Equal(t, tenantID, req.TenantID)
+					assert.Equal(t, deviceID, req.DeviceID)
+					assert.Equal(t, "tags", req.Scope)
+					var attrs []InventoryAttribute
+					err = json.Unmarshal([]byte(req.Attributes), &attrs)
+					if assert.NoError(t, err) {
+						assert.Equal(t, attributes, attrs)
+					}
+				}
+				w.WriteHeader(tc.ResponseCode)
+				return w.Result(), nil
+			})}
+			client := NewClient("http://localhost:6969"+tc.URLNoise,
+				NewOptions().SetClient(htClient))
+
+			err := client.UpdateDeviceInventory(tc.CTX, deviceID, "tags", attributes)
+			if tc.Error != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.Error.Error(), err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateDeviceConfiguration(t *testing.T) {
+	t.Parallel()
+	const deviceID = "60131e78-5c31-43bf-9fab-2aaa3b422d13"
+	testCases := []struct {
+		Name string
+
+		CTX            context.Context
+		Configuration  map[string]string
+		Reported       map[string]string
+		RoundTripError error
+		URLNoise       string
+
+		ResponseCode int
+		Error        error
+	}{{
+		Name: "ok",
+
+		CTX: identity.WithContext(context.Background(), &identity.Identity{
+			Tenant: "123456789012345678901234",
+		}),
+		Configuration: map[string]string{"ssid": "mender"},
+		Reported:      map[string]string{"ssid": "guest"},
+		ResponseCode:  http.StatusCreated,
+	}, {
+		Name: "ok, reported only",
+
+		CTX:          context.Background(),
+		Reported:     map[string]string{},
+		ResponseCode: http.StatusCreated,
+	}, {
+		Name: "error/bad status code",
+
+		CTX:          context.Background(),
+		ResponseCode: http.StatusBadRequest,
+		Error:        common.NewHTTPError(http.StatusBadRequest),
+	}, {
+		Name: "error/round trip error",
+
+		CTX:            context.Background(),
+		RoundTripError: errors.New("internal error"),
+
+		Error: errors.New("workflows: failed to execute request:.*internal error"),
+	}, {
+		Name:     "error/fail to prepare request",
+		URLNoise: "%%%",
+
+		Error: errors.New("workflows: failed to prepare request"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			htClient := &http.Client{Transport: roundTripperFunc(func(
+				r *http.Request,
+			) (*http.Response, error) {
+				defer r.Body.Close()
+				if tc.RoundTripError != nil {
+					return nil, tc.RoundTripError
+				}
+				assert.Equal(t, URIUpdateConfig, r.URL.Path)
+				var req struct {
+					TenantID      string          `json:"tenant_id"`
+					DeviceID      string          `json:"device_id"`
+					Configuration json.RawMessage `json:"configuration"`
+					Reported      json.RawMessage `json:"reported"`
+				}
+				err := json.NewDecoder(r.Body).Decode(&req)
+				if assert.NoError(t, err) {
+					var tenantID string
+					if id := identity.FromContext(r.Context()); id != nil {
+						tenantID = id.Tenant
+					}
+					assert.Equal(t, tenantID, req.TenantID)
+					assert.Equal(t, deviceID, req.DeviceID)
+					assertInput := func(expected map[string]string, raw json.RawMessage) {
+						if expected == nil {
+							assert.Equal(t, `""`, string(raw))
+						} else {
+							b, _ := json.Marshal(expected)
+							assert.JSONEq(t, string(b), string(raw))
+						}
+					}
+					assertInput(tc.Configuration, req.Configuration)
+					assertInput(tc.Reported, req.Reported)
+				}
+				w.WriteHeader(tc.ResponseCode)
+				return w.Result(), nil
+			})}
+			client := NewClient("http://localhost:6969"+tc.URLNoise,
+				NewOptions().SetClient(htClient))
+
+			err := client.UpdateDeviceConfiguration(
+				tc.CTX, deviceID, tc.Configuration, tc.Reported,
+			)
+			if tc.Error != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.Error.Error(), err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
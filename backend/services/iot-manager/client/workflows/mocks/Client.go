@@ -19,6 +19,7 @@ package mocks
 import (
 	context "context"
 
+	workflows "github.com/mendersoftware/mender-server/services/iot-manager/client/workflows"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -63,6 +64,42 @@ func (_m *Client) ProvisionExternalDevice(ctx context.Context, devID string, con
 	return r0
 }
 
+// UpdateDeviceConfiguration provides a mock function with given fields: ctx, devID, configuration, reported
+func (_m *Client) UpdateDeviceConfiguration(ctx context.Context, devID string, configuration map[string]string, reported map[string]string) error {
+	ret := _m.Called(ctx, devID, configuration, reported)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, map[string]string) error); ok {
+		r0 = rf(ctx, devID, configuration, reported)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDeviceInventory provides a mock function with given fields: ctx, devID, scope, attributes
+func (_m *Client) UpdateDeviceInventory(ctx context.Context, devID string, scope string, attributes []workflows.InventoryAttribute) error {
+	ret := _m.Called(ctx, devID, scope, attributes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceInventory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []workflows.InventoryAttribute) error); ok {
+		r0 = rf(ctx, devID, scope, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/mendersoftware/mender-server/services/iot-manager/app"
 	"github.com/mendersoftware/mender-server/services/iot-manager/client/devauth"
@@ -36,6 +38,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
+	"golang.org/x/sys/unix"
 
 	"github.com/mendersoftware/mender-server/pkg/config"
 	"github.com/mendersoftware/mender-server/pkg/log"
@@ -105,6 +108,44 @@ func doMain(args []string) {
 					},
 				},
 			},
+			{
+				Name: "sync-inventory",
+				Usage: "Copy the reported state of the devices into the " +
+					"inventory for integrations with inventory sync enabled.",
+				Action: cmdSyncInventory,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "fail-early",
+						Usage: "Do not ignore non-fatal errors.",
+					},
+				},
+			},
+			{
+				Name: "sync-configuration",
+				Usage: "Reconcile the desired and reported state of the " +
+					"devices with the device configuration for integrations " +
+					"with configuration sync enabled.",
+				Action: cmdSyncConfiguration,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "fail-early",
+						Usage: "Do not ignore non-fatal errors.",
+					},
+				},
+			},
+			{
+				Name: "mqtt-subscribe",
+				Usage: "Subscribe to the desired state topics of the MQTT " +
+					"integrations and apply the messages received.",
+				Action: cmdMQTTSubscribe,
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "refresh-interval",
+						Usage: "Interval for reloading the MQTT integrations.",
+						Value: time.Minute,
+					},
+				},
+			},
 			{
 				Name:  "version",
 				Usage: "Show version information",
@@ -245,3 +286,67 @@ func cmdSync(args *cli.Context) error {
 	app = app.WithWebhooksTimeout(config.Config.GetUint(dconfig.SettingWebhooksTimeoutSeconds))
 	return app.SyncDevices(ctx, args.Int("batch-size"), args.Bool("fail-early"))
 }
+
+func cmdSyncInventory(args *cli.Context) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), unix.SIGINT, unix.SIGTERM)
+	defer cancel()
+
+	httpClient := new(http.Client)
+	wf := workflows.NewClient(
+		config.Config.GetString(dconfig.SettingWorkflowsURL),
+		workflows.NewOptions().SetClient(httpClient),
+	)
+	hub := iothub.NewClient(iothub.NewOptions().SetClient(httpClient))
+	core := iotcore.NewClient()
+	ds, err := store.SetupDataStore(store.NewConfig())
+	if err != nil {
+		return err
+	}
+	defer ds.Close()
+	app := app.New(ds, wf, nil).WithIoTHub(hub).WithIoTCore(core)
+	return app.SyncInventory(ctx, args.Bool("fail-early"))
+}
+
+func cmdSyncConfiguration(args *cli.Context) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), unix.SIGINT, unix.SIGTERM)
+	defer cancel()
+
+	httpClient := new(http.Client)
+	wf := workflows.NewClient(
+		config.Config.GetString(dconfig.SettingWorkflowsURL),
+		workflows.NewOptions().SetClient(httpClient),
+	)
+	hub := iothub.NewClient(iothub.NewOptions().SetClient(httpClient))
+	core := iotcore.NewClient()
+	ds, err := store.SetupDataStore(store.NewConfig())
+	if err != nil {
+		return err
+	}
+	defer ds.Close()
+	app := app.New(ds, wf, nil).WithIoTHub(hub).WithIoTCore(core)
+	return app.SyncConfiguration(ctx, args.Bool("fail-early"))
+}
+
+func cmdMQTTSubscribe(args *cli.Context) error {
+	interval := args.Duration("refresh-interval")
+	if interval <= 0 {
+		return cli.NewExitError(
+			"invalid flag 'refresh-interval': must be a positive duration", 1,
+		)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), unix.SIGINT, unix.SIGTERM)
+	defer cancel()
+
+	httpClient := new(http.Client)
+	wf := workflows.NewClient(
+		config.Config.GetString(dconfig.SettingWorkflowsURL),
+		workflows.NewOptions().SetClient(httpClient),
+	)
+	ds, err := store.SetupDataStore(store.NewConfig())
+	if err != nil {
+		return err
+	}
+	defer ds.Close()
+	app := app.New(ds, wf, nil)
+	return app.SubscribeDesiredState(ctx, interval)
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// ConfigSyncConflict is the rule deciding which configuration wins when the
+// desired state was changed in the cloud since the last synchronization.
+type ConfigSyncConflict string
+
+const (
+	// ConfigSyncConflictMender restores the configuration set from Mender
+	// into the desired state.
+	ConfigSyncConflictMender ConfigSyncConflict = "mender"
+	// ConfigSyncConflictCloud applies the desired state set in the cloud
+	// to the configuration of the device in Mender.
+	ConfigSyncConflictCloud ConfigSyncConflict = "cloud"
+)
+
+func (c ConfigSyncConflict) Validate() error {
+	return validation.In(
+		ConfigSyncConflictMender,
+		ConfigSyncConflictCloud,
+	).Validate(c)
+}
+
+var errConfigProperty = errors.New("must not contain '.' or start with '$'")
+
+// ConfigSync configures mirroring the device configuration managed by
+// deviceconfig to the desired state of the device twin or shadow, and the
+// reported state back to deviceconfig.
+type ConfigSync struct {
+	// Property is the name of the desired and reported property holding
+	// the configuration. If empty, the configuration attributes map to
+	// top-level properties and only the attributes set from Mender are
+	// synchronized.
+	Property string `json:"property,omitempty" bson:"property,omitempty"`
+	// Conflict is the rule applied when the desired state changed in the
+	// cloud, defaults to ConfigSyncConflictMender.
+	Conflict ConfigSyncConflict `json:"conflict,omitempty" bson:"conflict,omitempty"`
+}
+
+func validateConfigProperty(value interface{}) error {
+	property, _ := value.(string)
+	if strings.Contains(property, ".") || strings.HasPrefix(property, "$") {
+		return errConfigProperty
+	}
+	return nil
+}
+
+func (sync ConfigSync) Validate() error {
+	return validation.ValidateStruct(&sync,
+		validation.Field(&sync.Property,
+			lenLessThan1024,
+			validation.By(validateConfigProperty)),
+		validation.Field(&sync.Conflict),
+	)
+}
+
+// ConflictRule returns the conflict rule applying the default.
+func (sync ConfigSync) ConflictRule() ConfigSyncConflict {
+	if sync.Conflict == "" {
+		return ConfigSyncConflictMender
+	}
+	return sync.Conflict
+}
+
+// Configuration extracts the device configuration from the desired or
+// reported state. Without a property, only the attributes present in
+// the managed configuration are extracted. Values other than strings are
+// serialized to JSON.
+func (sync ConfigSync) Configuration(
+	state map[string]interface{},
+	managed DeviceConfiguration,
+) DeviceConfiguration {
+	var values map[string]interface{}
+	if sync.Property != "" {
+		values, _ = state[sync.Property].(map[string]interface{})
+	} else {
+		values = make(map[string]interface{}, len(managed))
+		for key := range managed {
+			if value, ok := state[key]; ok {
+				values[key] = value
+			}
+		}
+	}
+	config := make(DeviceConfiguration, len(values))
+	for key, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			config[key] = v
+		default:
+			b, _ := json.Marshal(v)
+			config[key] = string(b)
+		}
+	}
+	return config
+}
+
+// Desired returns the patch replacing the previous configuration with the
+// given configuration in the desired state. Attributes removed from the
+// configuration are set to null which deletes them from the twin or shadow.
+func (sync ConfigSync) Desired(
+	configuration, previous DeviceConfiguration,
+) map[string]interface{} {
+	values := make(map[string]interface{}, len(configuration)+len(previous))
+	for key := range previous {
+		values[key] = nil
+	}
+	for key, value := range configuration {
+		values[key] = value
+	}
+	if sync.Property != "" {
+		return map[string]interface{}{
+			sync.Property: values,
+		}
+	}
+	return values
+}
+
+// DeviceConfiguration holds the configuration attributes of a device.
+type DeviceConfiguration map[string]string
+
+// Equal returns true if both configurations hold the same attributes.
+func (c DeviceConfiguration) Equal(other DeviceConfiguration) bool {
+	if len(c) != len(other) {
+		return false
+	}
+	for key, value := range c {
+		if v, ok := other[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+type configAttribute struct {
+	Key   string `bson:"key"`
+	Value string `bson:"value"`
+}
+
+// MarshalBSONValue stores the configuration as a list of key/value pairs
+// since attribute names may contain dots.
+func (c DeviceConfiguration) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	attrs := make([]configAttribute, 0, len(c))
+	for key, value := range c {
+		attrs = append(attrs, configAttribute{Key: key, Value: value})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+	return bson.MarshalValue(attrs)
+}
+
+func (c *DeviceConfiguration) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	var attrs []configAttribute
+	err := bson.RawValue{Type: t, Value: b}.Unmarshal(&attrs)
+	if err != nil {
+		return err
+	}
+	*c = make(DeviceConfiguration, len(attrs))
+	for _, attr := range attrs {
+		(*c)[attr.Key] = attr.Value
+	}
+	return nil
+}
+
+// DeviceConfigState is the device configuration as of the last
+// synchronization with the integrations.
+type DeviceConfigState struct {
+	// Desired is the configuration last written to or read from the
+	// desired state.
+	Desired DeviceConfiguration `json:"desired" bson:"desired"`
+	// Reported is the configuration last mirrored from the reported
+	// state.
+	Reported DeviceConfiguration `json:"reported" bson:"reported"`
+}
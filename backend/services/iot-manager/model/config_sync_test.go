@@ -0,0 +1,150 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestConfigSyncValidate(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		sync ConfigSync
+		err  string
+	}{
+		"ok": {
+			sync: ConfigSync{
+				Property: "mender",
+				Conflict: ConfigSyncConflictCloud,
+			},
+		},
+		"ok, empty": {},
+		"ko, nested property": {
+			sync: ConfigSync{
+				Property: "mender.config",
+			},
+			err: "property: " + errConfigProperty.Error() + ".",
+		},
+		"ko, reserved property": {
+			sync: ConfigSync{
+				Property: "$metadata",
+			},
+			err: "property: " + errConfigProperty.Error() + ".",
+		},
+		"ko, invalid conflict rule": {
+			sync: ConfigSync{
+				Conflict: "device",
+			},
+			err: "conflict: must be a valid value.",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.sync.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigSyncConfiguration(t *testing.T) {
+	t.Parallel()
+	var state map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"mender": {"ssid": "mender", "channel": 6, "removed": null},
+		"ssid": "guest",
+		"port": 8080,
+		"other": "value"
+	}`), &state)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sync := ConfigSync{Property: "mender"}
+	assert.Equal(t, DeviceConfiguration{
+		"ssid":    "mender",
+		"channel": "6",
+	}, sync.Configuration(state, nil))
+
+	sync = ConfigSync{}
+	assert.Equal(t, DeviceConfiguration{
+		"ssid": "guest",
+		"port": "8080",
+	}, sync.Configuration(state, DeviceConfiguration{
+		"ssid":    "",
+		"port":    "",
+		"missing": "",
+	}))
+	assert.Empty(t, sync.Configuration(nil, nil))
+}
+
+func TestConfigSyncDesired(t *testing.T) {
+	t.Parallel()
+	configuration := DeviceConfiguration{"ssid": "mender"}
+	previous := DeviceConfiguration{"ssid": "guest", "port": "8080"}
+
+	sync := ConfigSync{}
+	assert.Equal(t, map[string]interface{}{
+		"ssid": "mender",
+		"port": nil,
+	}, sync.Desired(configuration, previous))
+	assert.Equal(t, ConfigSyncConflictMender, sync.ConflictRule())
+
+	sync = ConfigSync{Property: "mender", Conflict: ConfigSyncConflictCloud}
+	assert.Equal(t, map[string]interface{}{
+		"mender": map[string]interface{}{
+			"ssid": "mender",
+		},
+	}, sync.Desired(configuration, nil))
+	assert.Equal(t, ConfigSyncConflictCloud, sync.ConflictRule())
+}
+
+func TestDeviceConfigurationEqual(t *testing.T) {
+	t.Parallel()
+	assert.True(t, DeviceConfiguration(nil).Equal(DeviceConfiguration{}))
+	assert.True(t, DeviceConfiguration{"a": "1"}.Equal(DeviceConfiguration{"a": "1"}))
+	assert.False(t, DeviceConfiguration{"a": "1"}.Equal(DeviceConfiguration{"a": "2"}))
+	assert.False(t, DeviceConfiguration{"a": "1"}.Equal(DeviceConfiguration{"b": "1"}))
+	assert.False(t, DeviceConfiguration{"a": "1"}.Equal(nil))
+}
+
+func TestDeviceConfigStateBSON(t *testing.T) {
+	t.Parallel()
+	state := DeviceConfigState{
+		Desired: DeviceConfiguration{
+			"network.ssid": "mender",
+			"$port":        "8080",
+		},
+		Reported: DeviceConfiguration{},
+	}
+	b, err := bson.Marshal(state)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var actual DeviceConfigState
+	err = bson.Unmarshal(b, &actual)
+	if assert.NoError(t, err) {
+		assert.Equal(t, state, actual)
+	}
+}
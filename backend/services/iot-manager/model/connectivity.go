@@ -0,0 +1,47 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// DeviceConnectivity describes a change in the device connectivity as
+// detected by the device monitor.
+type DeviceConnectivity struct {
+	// Offline is true if the device stopped checking in with the server.
+	Offline bool `json:"offline"`
+	// OfflineSince is the time since when the device is considered offline.
+	OfflineSince *time.Time `json:"offline_since,omitempty"`
+	// CheckInTime is the last time the device checked in with the server.
+	CheckInTime *time.Time `json:"check_in_time,omitempty"`
+}
+
+func (conn DeviceConnectivity) Validate() error {
+	return validation.ValidateStruct(&conn,
+		validation.Field(&conn.OfflineSince,
+			validation.When(conn.Offline, validation.Required)),
+	)
+}
+
+// EventType returns the type of event emitted for the connectivity change.
+func (conn DeviceConnectivity) EventType() EventType {
+	if conn.Offline {
+		return EventTypeDeviceOffline
+	}
+	return EventTypeDeviceOnline
+}
@@ -84,16 +84,22 @@ func (cred HTTPCredentials) validateURL(interface{}) error {
 	if err != nil {
 		return err
 	}
+	if !cred.validateAddr {
+		return nil
+	}
+	return validateHostAddress(uu.Hostname())
+}
+
+// validateHostAddress checks that the hostname only resolves to global
+// unicast addresses to mitigate SSRF attacks.
+func validateHostAddress(hostname string) error {
 	skipVerifyLoadOnce.Do(func() {
 		skipVerify = config.Config.GetBool(dconfig.SettingDomainSkipVerify)
 	})
 	if skipVerify {
 		return nil
 	}
-	if !cred.validateAddr {
-		return nil
-	}
-	ips, err := net.LookupIP(uu.Hostname())
+	ips, err := net.LookupIP(hostname)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,307 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/mendersoftware/mender-server/services/iot-manager/crypto"
+)
+
+// Placeholders supported by the MQTT topic templates.
+const (
+	MQTTPlaceholderTenantID = "{tenant_id}"
+	MQTTPlaceholderDeviceID = "{device_id}"
+	MQTTPlaceholderEvent    = "{event}"
+)
+
+// DefaultMQTTTopicTemplate is the topic device events are published to when
+// the integration does not define a topic template.
+const DefaultMQTTTopicTemplate = "mender/" + MQTTPlaceholderTenantID +
+	"/devices/" + MQTTPlaceholderDeviceID + "/" + MQTTPlaceholderEvent
+
+// DefaultMQTTQoS is the QoS level used when the credentials do not set it.
+const DefaultMQTTQoS byte = 1
+
+type MQTTDesiredStateTarget string
+
+const (
+	// MQTTDesiredStateDeviceConfig maps desired state messages to the
+	// device configuration.
+	MQTTDesiredStateDeviceConfig MQTTDesiredStateTarget = "deviceconfig"
+	// MQTTDesiredStateInventory maps desired state messages to the
+	// device inventory tags.
+	MQTTDesiredStateInventory MQTTDesiredStateTarget = "inventory"
+)
+
+var validateDesiredStateTarget = validation.In(
+	MQTTDesiredStateDeviceConfig,
+	MQTTDesiredStateInventory,
+)
+
+func (target MQTTDesiredStateTarget) Validate() error {
+	return validateDesiredStateTarget.Validate(target)
+}
+
+var (
+	mqttBrokerSchemes = map[string]bool{
+		"mqtt":  false,
+		"tcp":   false,
+		"mqtts": true,
+		"ssl":   true,
+		"tls":   true,
+	}
+	reTopicPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+)
+
+type MQTTCredentials struct {
+	// BrokerURL is the URL of the broker, for example
+	// mqtts://broker.example.com:8883
+	BrokerURL string  `json:"broker_url"         bson:"broker_url"`
+	Username  *string `json:"username,omitempty" bson:"username,omitempty"`
+	//nolint:lll
+	Password *crypto.String `json:"password,omitempty" bson:"password,omitempty"`
+
+	// ClientCertificate and ClientKey are the PEM encoded client
+	// certificate and private key used for TLS client authentication.
+	//nolint:lll
+	ClientCertificate *string `json:"client_certificate,omitempty" bson:"client_certificate,omitempty"`
+	//nolint:lll
+	ClientKey *crypto.String `json:"client_key,omitempty" bson:"client_key,omitempty"`
+	// CACertificate is a PEM encoded bundle of certificate authorities
+	// trusted in addition to the system pool.
+	//nolint:lll
+	CACertificate *string `json:"ca_certificate,omitempty" bson:"ca_certificate,omitempty"`
+
+	// TopicTemplate is the topic device events are published to.
+	//nolint:lll
+	TopicTemplate string `json:"topic_template,omitempty" bson:"topic_template,omitempty"`
+
+	// QoS is the quality of service level of the events published and of
+	// the desired state subscription.
+	QoS *byte `json:"qos,omitempty" bson:"qos,omitempty"`
+
+	// DesiredStateTopic is the topic to subscribe for desired state
+	// messages, the {device_id} placeholder must be an entire topic level.
+	//nolint:lll
+	DesiredStateTopic string `json:"desired_state_topic,omitempty" bson:"desired_state_topic,omitempty"`
+	// DesiredStateTarget selects the service desired state messages are
+	// applied to.
+	//nolint:lll
+	DesiredStateTarget MQTTDesiredStateTarget `json:"desired_state_target,omitempty" bson:"desired_state_target,omitempty"`
+
+	// private field toggling validation verbosity
+	// - only set if unmarshaled from JSON
+	validateAddr bool
+}
+
+func (cred *MQTTCredentials) UnmarshalJSON(b []byte) error {
+	type creds MQTTCredentials
+	if err := json.Unmarshal(b, (*creds)(cred)); err != nil {
+		return err
+	}
+	cred.validateAddr = true
+	return nil
+}
+
+// UseTLS returns true if the broker URL requires a TLS connection.
+func (cred MQTTCredentials) UseTLS() bool {
+	uu, err := url.Parse(cred.BrokerURL)
+	if err != nil {
+		return false
+	}
+	return mqttBrokerSchemes[strings.ToLower(uu.Scheme)]
+}
+
+func (cred MQTTCredentials) validateBrokerURL(interface{}) error {
+	uu, err := url.Parse(cred.BrokerURL)
+	if err != nil {
+		return err
+	}
+	if _, ok := mqttBrokerSchemes[strings.ToLower(uu.Scheme)]; !ok {
+		return fmt.Errorf("unsupported scheme %q", uu.Scheme)
+	}
+	if uu.Hostname() == "" {
+		return fmt.Errorf("missing broker hostname")
+	}
+	if !cred.validateAddr {
+		return nil
+	}
+	return validateHostAddress(uu.Hostname())
+}
+
+func (cred MQTTCredentials) validateClientCertificate(interface{}) error {
+	if cred.ClientCertificate == nil && cred.ClientKey == nil {
+		return nil
+	} else if cred.ClientCertificate == nil || cred.ClientKey == nil {
+		return fmt.Errorf("client_certificate and client_key must be set together")
+	}
+	_, err := tls.X509KeyPair(
+		[]byte(*cred.ClientCertificate),
+		[]byte(*cred.ClientKey),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid client certificate: %w", err)
+	}
+	return nil
+}
+
+func validateCACertificate(value interface{}) error {
+	ca, _ := value.(*string)
+	if ca == nil {
+		return nil
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(*ca)) {
+		return fmt.Errorf("no valid PEM certificates found")
+	}
+	return nil
+}
+
+func validateTopic(placeholders ...string) validation.RuleFunc {
+	return func(value interface{}) error {
+		topic, _ := value.(string)
+		if topic == "" {
+			return nil
+		}
+		if strings.ContainsAny(topic, "+#\x00") {
+			return fmt.Errorf("topic must not contain wildcards")
+		}
+		for _, match := range reTopicPlaceholder.FindAllString(topic, -1) {
+			var known bool
+			for _, placeholder := range placeholders {
+				if match == placeholder {
+					known = true
+					break
+				}
+			}
+			if !known {
+				return fmt.Errorf("unknown placeholder %s", match)
+			}
+		}
+		return nil
+	}
+}
+
+func validateDeviceIDLevel(value interface{}) error {
+	topic, _ := value.(string)
+	if topic == "" {
+		return nil
+	}
+	var count int
+	for _, level := range strings.Split(topic, "/") {
+		if level == MQTTPlaceholderDeviceID {
+			count++
+		} else if strings.Contains(level, MQTTPlaceholderDeviceID) {
+			return fmt.Errorf("%s must be an entire topic level",
+				MQTTPlaceholderDeviceID)
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("topic must contain exactly one %s placeholder",
+			MQTTPlaceholderDeviceID)
+	}
+	return nil
+}
+
+func (cred MQTTCredentials) Validate() error {
+	return validation.ValidateStruct(&cred,
+		validation.Field(&cred.BrokerURL,
+			validation.Required,
+			lenLessThan1024,
+			validation.By(cred.validateBrokerURL),
+		),
+		validation.Field(&cred.Username, validation.NilOrNotEmpty),
+		validation.Field(&cred.ClientCertificate,
+			validation.By(cred.validateClientCertificate)),
+		validation.Field(&cred.CACertificate,
+			validation.By(validateCACertificate)),
+		validation.Field(&cred.QoS, validation.In(byte(0), byte(1), byte(2)).
+			Error("must be 0, 1 or 2")),
+		validation.Field(&cred.TopicTemplate,
+			lenLessThan1024,
+			validation.By(validateTopic(
+				MQTTPlaceholderTenantID,
+				MQTTPlaceholderDeviceID,
+				MQTTPlaceholderEvent,
+			))),
+		validation.Field(&cred.DesiredStateTopic,
+			lenLessThan1024,
+			validation.By(validateTopic(
+				MQTTPlaceholderTenantID,
+				MQTTPlaceholderDeviceID,
+			)),
+			validation.By(validateDeviceIDLevel)),
+		validation.Field(&cred.DesiredStateTarget,
+			validation.When(cred.DesiredStateTopic != "", validation.Required)),
+	)
+}
+
+// QualityOfService returns the QoS level of the integration, defaulting
+// to DefaultMQTTQoS.
+func (cred MQTTCredentials) QualityOfService() byte {
+	if cred.QoS != nil {
+		return *cred.QoS
+	}
+	return DefaultMQTTQoS
+}
+
+// EventTopic returns the topic the event of the given type is published to.
+func (cred MQTTCredentials) EventTopic(tenantID, deviceID string, typ EventType) string {
+	template := cred.TopicTemplate
+	if template == "" {
+		template = DefaultMQTTTopicTemplate
+	}
+	return strings.NewReplacer(
+		MQTTPlaceholderTenantID, tenantID,
+		MQTTPlaceholderDeviceID, deviceID,
+		MQTTPlaceholderEvent, string(typ),
+	).Replace(template)
+}
+
+// DesiredStateSubscription returns the topic filter matching the desired
+// state messages for all the devices of the tenant.
+func (cred MQTTCredentials) DesiredStateSubscription(tenantID string) string {
+	return strings.NewReplacer(
+		MQTTPlaceholderTenantID, tenantID,
+		MQTTPlaceholderDeviceID, "+",
+	).Replace(cred.DesiredStateTopic)
+}
+
+// DeviceIDFromTopic extracts the device ID from a topic matching the
+// desired state subscription.
+func (cred MQTTCredentials) DeviceIDFromTopic(tenantID, topic string) (string, bool) {
+	filter := strings.Split(cred.DesiredStateSubscription(tenantID), "/")
+	levels := strings.Split(topic, "/")
+	if len(filter) != len(levels) {
+		return "", false
+	}
+	var deviceID string
+	for i, level := range strings.Split(cred.DesiredStateTopic, "/") {
+		if level == MQTTPlaceholderDeviceID {
+			deviceID = levels[i]
+		} else if filter[i] != levels[i] {
+			return "", false
+		}
+	}
+	return deviceID, deviceID != ""
+}
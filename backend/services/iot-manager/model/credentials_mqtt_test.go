@@ -0,0 +1,254 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T) (cert string, key string) {
+	t.Helper()
+	pkey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mender-iot-manager"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, pkey.Public(), pkey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(pkey)
+	require.NoError(t, err)
+	cert = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	key = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return cert, key
+}
+
+func TestMQTTCredentialsValidate(t *testing.T) {
+	t.Parallel()
+	cert, key := newTestCertificate(t)
+
+	testCases := map[string]struct {
+		creds MQTTCredentials
+		err   string
+	}{
+		"ok, minimal": {
+			creds: MQTTCredentials{
+				BrokerURL: "mqtt://localhost:1883",
+			},
+		},
+		"ok, full": {
+			creds: MQTTCredentials{
+				BrokerURL:          "mqtts://localhost:8883",
+				Username:           str2ptr("mender"),
+				Password:           str2cyptoptr("secret"),
+				ClientCertificate:  &cert,
+				ClientKey:          str2cyptoptr(key),
+				CACertificate:      &cert,
+				TopicTemplate:      "fleet/{tenant_id}/{device_id}/{event}",
+				QoS:                byte2ptr(2),
+				DesiredStateTopic:  "fleet/{tenant_id}/{device_id}/desired",
+				DesiredStateTarget: MQTTDesiredStateInventory,
+			},
+		},
+		"ok, QoS 0": {
+			creds: MQTTCredentials{
+				BrokerURL: "mqtt://localhost:1883",
+				QoS:       byte2ptr(0),
+			},
+		},
+		"ko, invalid QoS": {
+			creds: MQTTCredentials{
+				BrokerURL: "mqtt://localhost:1883",
+				QoS:       byte2ptr(3),
+			},
+			err: "qos: must be 0, 1 or 2.",
+		},
+		"ko, missing broker URL": {
+			err: "broker_url: cannot be blank.",
+		},
+		"ko, bad scheme": {
+			creds: MQTTCredentials{
+				BrokerURL: "http://localhost",
+			},
+			err: `broker_url: unsupported scheme "http".`,
+		},
+		"ko, missing host": {
+			creds: MQTTCredentials{
+				BrokerURL: "mqtt://",
+			},
+			err: "broker_url: missing broker hostname.",
+		},
+		"ko, certificate without key": {
+			creds: MQTTCredentials{
+				BrokerURL:         "mqtts://localhost",
+				ClientCertificate: &cert,
+			},
+			err: "client_certificate: client_certificate and " +
+				"client_key must be set together.",
+		},
+		"ko, invalid key pair": {
+			creds: MQTTCredentials{
+				BrokerURL:         "mqtts://localhost",
+				ClientCertificate: &cert,
+				ClientKey:         str2cyptoptr("garbage"),
+			},
+			err: "client_certificate: invalid client certificate",
+		},
+		"ko, invalid CA": {
+			creds: MQTTCredentials{
+				BrokerURL:     "mqtts://localhost",
+				CACertificate: str2ptr("garbage"),
+			},
+			err: "ca_certificate: no valid PEM certificates found.",
+		},
+		"ko, wildcard in topic template": {
+			creds: MQTTCredentials{
+				BrokerURL:     "mqtt://localhost",
+				TopicTemplate: "mender/+/{event}",
+			},
+			err: "topic_template: topic must not contain wildcards.",
+		},
+		"ko, unknown placeholder": {
+			creds: MQTTCredentials{
+				BrokerURL:     "mqtt://localhost",
+				TopicTemplate: "mender/{device_name}",
+			},
+			err: "topic_template: unknown placeholder {device_name}.",
+		},
+		"ko, desired state topic without device ID": {
+			creds: MQTTCredentials{
+				BrokerURL:          "mqtt://localhost",
+				DesiredStateTopic:  "mender/{tenant_id}/desired",
+				DesiredStateTarget: MQTTDesiredStateDeviceConfig,
+			},
+			err: "desired_state_topic: topic must contain exactly " +
+				"one {device_id} placeholder.",
+		},
+		"ko, device ID not a topic level": {
+			creds: MQTTCredentials{
+				BrokerURL:          "mqtt://localhost",
+				DesiredStateTopic:  "mender/dev-{device_id}",
+				DesiredStateTarget: MQTTDesiredStateDeviceConfig,
+			},
+			err: "desired_state_topic: {device_id} must be an entire topic level.",
+		},
+		"ko, missing desired state target": {
+			creds: MQTTCredentials{
+				BrokerURL:         "mqtt://localhost",
+				DesiredStateTopic: "mender/{device_id}",
+			},
+			err: "desired_state_target: cannot be blank.",
+		},
+		"ko, invalid desired state target": {
+			creds: MQTTCredentials{
+				BrokerURL:          "mqtt://localhost",
+				DesiredStateTopic:  "mender/{device_id}",
+				DesiredStateTarget: "deployments",
+			},
+			err: "desired_state_target: must be a valid value.",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.creds.Validate()
+			if tc.err != "" {
+				if assert.Error(t, err) {
+					assert.True(t, strings.HasPrefix(err.Error(), tc.err),
+						"%q does not start with %q", err.Error(), tc.err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMQTTCredentialsJSON(t *testing.T) {
+	t.Parallel()
+	var creds MQTTCredentials
+	err := json.Unmarshal([]byte(`{
+		"broker_url": "mqtts://localhost",
+		"username": "mender",
+		"password": "secret"
+	}`), &creds)
+	require.NoError(t, err)
+	assert.True(t, creds.validateAddr)
+	assert.True(t, creds.UseTLS())
+	assert.Equal(t, "secret", string(*creds.Password))
+
+	b, err := json.Marshal(creds)
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &doc))
+	assert.Equal(t, "<omitted>", doc["password"])
+
+	err = json.Unmarshal([]byte(`{"broker_url": 1234}`), &creds)
+	assert.Error(t, err)
+}
+
+func TestMQTTCredentialsTopics(t *testing.T) {
+	t.Parallel()
+	creds := MQTTCredentials{
+		BrokerURL:         "mqtt://localhost",
+		DesiredStateTopic: "fleet/{tenant_id}/{device_id}/desired",
+	}
+	assert.False(t, creds.UseTLS())
+	assert.Equal(t, DefaultMQTTQoS, creds.QualityOfService())
+	creds.QoS = byte2ptr(2)
+	assert.Equal(t, byte(2), creds.QualityOfService())
+	assert.Equal(t,
+		"mender/tenant/devices/device/device-provisioned",
+		creds.EventTopic("tenant", "device", EventTypeDeviceProvisioned),
+	)
+	creds.TopicTemplate = "{event}/{device_id}"
+	assert.Equal(t,
+		"device-decommissioned/device",
+		creds.EventTopic("tenant", "device", EventTypeDeviceDecommissioned),
+	)
+
+	assert.Equal(t, "fleet/tenant/+/desired",
+		creds.DesiredStateSubscription("tenant"))
+
+	deviceID, ok := creds.DeviceIDFromTopic("tenant", "fleet/tenant/device/desired")
+	assert.True(t, ok)
+	assert.Equal(t, "device", deviceID)
+
+	for _, topic := range []string{
+		"fleet/other/device/desired",
+		"fleet/tenant/device",
+		"fleet/tenant//desired",
+		"fleet/tenant/device/desired/extra",
+	} {
+		_, ok = creds.DeviceIDFromTopic("tenant", topic)
+		assert.False(t, ok, topic)
+	}
+}
@@ -22,4 +22,7 @@ type Device struct {
 	ID string `json:"id" bson:"_id"`
 	// Integrations contains the list of integrations for this device
 	IntegrationIDs []uuid.UUID `json:"integration_ids" bson:"integration_ids"`
+	// ConfigState is the configuration last synchronized with the
+	// integrations with configuration sync enabled.
+	ConfigState *DeviceConfigState `json:"-" bson:"config_state,omitempty"`
 }
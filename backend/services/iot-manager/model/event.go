@@ -63,12 +63,16 @@ const (
 	EventTypeDeviceProvisioned    EventType = "device-provisioned"
 	EventTypeDeviceDecommissioned EventType = "device-decommissioned"
 	EventTypeDeviceStatusChanged  EventType = "device-status-changed"
+	EventTypeDeviceOffline        EventType = "device-offline"
+	EventTypeDeviceOnline         EventType = "device-online"
 )
 
 var eventTypeRule = validation.In(
 	EventTypeDeviceProvisioned,
 	EventTypeDeviceDecommissioned,
 	EventTypeDeviceStatusChanged,
+	EventTypeDeviceOffline,
+	EventTypeDeviceOnline,
 )
 
 func (typ EventType) Validate() error {
@@ -97,6 +101,8 @@ type AuthSet struct {
 // For device decommissioning events, the DeviceEvent only contains the device
 // ID. For StatusChangeEvents, the Status is also included. For
 // ProvisioningEvents, the entire struct is expected to be populated.
+// Connectivity events include the CheckInTime and, for offline events, the
+// OfflineSince timestamp.
 type DeviceEvent struct {
 	// ID is the device ID
 	ID string `json:"id" bson:"id"`
@@ -106,4 +112,8 @@ type DeviceEvent struct {
 	AuthSets []AuthSet `json:"auth_sets,omitempty" bson:"auth_sets,omitempty"`
 	// CreatedTS is the time when the device was created.
 	CreatedTS *time.Time `json:"created_ts,omitempty" bson:"created_ts,omitempty"`
+	// CheckInTime is the last time the device checked in with the server.
+	CheckInTime *time.Time `json:"check_in_time,omitempty" bson:"check_in_time,omitempty"`
+	// OfflineSince is the time since when the device is considered offline.
+	OfflineSince *time.Time `json:"offline_since,omitempty" bson:"offline_since,omitempty"`
 }
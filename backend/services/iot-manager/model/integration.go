@@ -26,6 +26,13 @@ type Integration struct {
 	Provider    Provider    `json:"provider" bson:"provider"`
 	Credentials Credentials `json:"credentials" bson:"credentials"`
 	Description string      `json:"description,omitempty" bson:"description,omitempty"`
+	// InventorySync optionally copies reported state properties into the
+	// device inventory.
+	//nolint:lll
+	InventorySync *InventorySync `json:"inventory_sync,omitempty" bson:"inventory_sync,omitempty"`
+	// ConfigSync optionally mirrors the device configuration from
+	// deviceconfig to the desired state and the reported state back.
+	ConfigSync *ConfigSync `json:"config_sync,omitempty" bson:"config_sync,omitempty"`
 }
 
 var (
@@ -40,6 +47,12 @@ func (itg Integration) Validate() error {
 			validation.By(itg.compatibleCredentials)),
 		validation.Field(&itg.Credentials),
 		validation.Field(&itg.Description, lenLessThan1024),
+		validation.Field(&itg.InventorySync,
+			validation.When(!itg.Provider.HasDeviceState(),
+				validation.Nil.Error("not supported by the provider"))),
+		validation.Field(&itg.ConfigSync,
+			validation.When(!itg.Provider.HasDeviceState(),
+				validation.Nil.Error("not supported by the provider"))),
 	)
 }
 
@@ -57,6 +70,10 @@ func (itg Integration) compatibleCredentials(interface{}) error {
 		if itg.Credentials.Type == CredentialTypeHTTP {
 			return nil
 		}
+	case ProviderMQTT:
+		if itg.Credentials.Type == CredentialTypeMQTT {
+			return nil
+		}
 	}
 	return fmt.Errorf(
 		"'%s' incompatible with credential type '%s'",
@@ -71,12 +88,14 @@ const (
 	CredentialTypeAWS  CredentialType = "aws"
 	CredentialTypeSAS  CredentialType = "sas"
 	CredentialTypeHTTP CredentialType = "http"
+	CredentialTypeMQTT CredentialType = "mqtt"
 )
 
 var credentialTypeRule = validation.In(
 	CredentialTypeAWS,
 	CredentialTypeSAS,
 	CredentialTypeHTTP,
+	CredentialTypeMQTT,
 )
 
 func (typ CredentialType) Validate() error {
@@ -96,6 +115,9 @@ type Credentials struct {
 
 	// Webhooks
 	HTTP *HTTPCredentials `json:"http,omitempty" bson:"http,omitempty"`
+
+	// MQTT broker
+	MQTT *MQTTCredentials `json:"mqtt,omitempty" bson:"mqtt,omitempty"`
 }
 
 func (s Credentials) Validate() error {
@@ -107,6 +129,8 @@ func (s Credentials) Validate() error {
 			validation.When(s.Type == CredentialTypeAWS, validation.Required)),
 		validation.Field(&s.HTTP,
 			validation.When(s.Type == CredentialTypeHTTP, validation.Required)),
+		validation.Field(&s.MQTT,
+			validation.When(s.Type == CredentialTypeMQTT, validation.Required)),
 	)
 }
 
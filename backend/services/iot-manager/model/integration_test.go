@@ -33,6 +33,10 @@ func str2cyptoptr(s string) *crypto.String {
 	return &c
 }
 
+func byte2ptr(b byte) *byte {
+	return &b
+}
+
 func TestIntegrationValidate(t *testing.T) {
 	cs, _ := ParseConnectionString(
 		"HostName=mender-test-hub.azure-devices.net;DeviceId=7b478313-de33-4735-bf00-0ebc31851faf;" +
@@ -90,6 +94,74 @@ func TestIntegrationValidate(t *testing.T) {
 				},
 			},
 		},
+		"ok, MQTT": {
+			integration: &Integration{
+				Provider: ProviderMQTT,
+				Credentials: Credentials{
+					Type: CredentialTypeMQTT,
+					MQTT: &MQTTCredentials{
+						BrokerURL: "mqtt://localhost:1883",
+					},
+				},
+			},
+		},
+		"ko, MQTT with webhook credentials": {
+			integration: &Integration{
+				Provider: ProviderMQTT,
+				Credentials: Credentials{
+					Type: CredentialTypeHTTP,
+					HTTP: &HTTPCredentials{
+						URL: "http://localhost",
+					},
+				},
+			},
+			err: errors.New("provider: 'mqtt' incompatible with credential type 'http'."),
+		},
+		"ok, AWS IoT Core with inventory sync": {
+			integration: &Integration{
+				Provider: ProviderIoTCore,
+				Credentials: Credentials{
+					Type: CredentialTypeAWS,
+					AWSCredentials: &AWSCredentials{
+						AccessKeyID:      str2ptr("x"),
+						SecretAccessKey:  str2cyptoptr("x"),
+						Region:           str2ptr("us-east-1"),
+						DevicePolicyName: str2ptr("{\"Statement\": []}"),
+					},
+				},
+				InventorySync: &InventorySync{
+					Attributes: []InventorySyncAttribute{{
+						Property: "firmware.version",
+					}},
+				},
+			},
+		},
+		"ko, webhook with inventory sync": {
+			integration: &Integration{
+				Provider: ProviderWebhook,
+				Credentials: Credentials{
+					Type: CredentialTypeHTTP,
+					HTTP: &HTTPCredentials{
+						URL: "http://localhost",
+					},
+				},
+				InventorySync: &InventorySync{},
+			},
+			err: errors.New("inventory_sync: not supported by the provider."),
+		},
+		"ko, webhook with config sync": {
+			integration: &Integration{
+				Provider: ProviderWebhook,
+				Credentials: Credentials{
+					Type: CredentialTypeHTTP,
+					HTTP: &HTTPCredentials{
+						URL: "http://localhost",
+					},
+				},
+				ConfigSync: &ConfigSync{},
+			},
+			err: errors.New("config_sync: not supported by the provider."),
+		},
 		"ko, AWS IoT Core": {
 			integration: &Integration{
 				Provider: ProviderIoTCore,
@@ -0,0 +1,160 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// InventoryScopeIoT is the inventory scope receiving the attributes
+// copied from the reported device state.
+const InventoryScopeIoT = "iot"
+
+const maxInventorySyncAttributes = 100
+
+var (
+	errPropertyPath = errors.New(
+		"must be a dot separated path without empty elements")
+	errDuplicateAttribute = errors.New("duplicate attribute name")
+)
+
+// InventorySync configures which properties of the reported device state
+// (IoT Hub twin or AWS IoT Core shadow) are copied into the inventory.
+type InventorySync struct {
+	Attributes []InventorySyncAttribute `json:"attributes" bson:"attributes"`
+}
+
+// InventorySyncAttribute maps a reported property to an inventory
+// attribute.
+type InventorySyncAttribute struct {
+	// Property is the dot separated path of the reported property.
+	Property string `json:"property" bson:"property"`
+	// Attribute is the name of the inventory attribute, defaults to the
+	// property path.
+	Attribute string `json:"attribute,omitempty" bson:"attribute,omitempty"`
+}
+
+func validatePropertyPath(value interface{}) error {
+	path, _ := value.(string)
+	if path == "" {
+		return nil
+	}
+	for _, elem := range strings.Split(path, ".") {
+		if elem == "" {
+			return errPropertyPath
+		}
+	}
+	return nil
+}
+
+func (attr InventorySyncAttribute) Validate() error {
+	return validation.ValidateStruct(&attr,
+		validation.Field(&attr.Property,
+			validation.Required,
+			lenLessThan1024,
+			validation.By(validatePropertyPath)),
+		validation.Field(&attr.Attribute, lenLessThan1024),
+	)
+}
+
+// Name returns the name of the inventory attribute.
+func (attr InventorySyncAttribute) Name() string {
+	if attr.Attribute != "" {
+		return attr.Attribute
+	}
+	return attr.Property
+}
+
+func (sync InventorySync) uniqueAttributes(interface{}) error {
+	names := make(map[string]struct{}, len(sync.Attributes))
+	for _, attr := range sync.Attributes {
+		if _, ok := names[attr.Name()]; ok {
+			return errDuplicateAttribute
+		}
+		names[attr.Name()] = struct{}{}
+	}
+	return nil
+}
+
+func (sync InventorySync) Validate() error {
+	return validation.ValidateStruct(&sync,
+		validation.Field(&sync.Attributes,
+			validation.Length(0, maxInventorySyncAttributes),
+			validation.By(sync.uniqueAttributes)),
+	)
+}
+
+// Map extracts the configured properties from the reported state and
+// returns the values indexed by inventory attribute name. Inventory only
+// supports strings, numbers and homogeneous lists thereof; booleans are
+// converted to strings and any other value is serialized to JSON.
+func (sync InventorySync) Map(reported map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(sync.Attributes))
+	for _, attr := range sync.Attributes {
+		var (
+			value interface{} = reported
+			ok                = true
+		)
+		for _, elem := range strings.Split(attr.Property, ".") {
+			var obj map[string]interface{}
+			obj, ok = value.(map[string]interface{})
+			if !ok {
+				break
+			}
+			value, ok = obj[elem]
+			if !ok {
+				break
+			}
+		}
+		if !ok || value == nil {
+			continue
+		}
+		switch v := value.(type) {
+		case string, float64:
+		case bool:
+			value = strconv.FormatBool(v)
+		case []interface{}:
+			if !isHomogeneousList(v) {
+				b, _ := json.Marshal(v)
+				value = string(b)
+			}
+		default:
+			b, _ := json.Marshal(v)
+			value = string(b)
+		}
+		result[attr.Name()] = value
+	}
+	return result
+}
+
+func isHomogeneousList(list []interface{}) bool {
+	var strs, nums int
+	for _, elem := range list {
+		switch elem.(type) {
+		case string:
+			strs++
+		case float64:
+			nums++
+		default:
+			return false
+		}
+	}
+	return strs == 0 || nums == 0
+}
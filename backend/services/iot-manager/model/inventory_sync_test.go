@@ -0,0 +1,129 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventorySyncValidate(t *testing.T) {
+	t.Parallel()
+	tooMany := make([]InventorySyncAttribute, maxInventorySyncAttributes+1)
+	for i := range tooMany {
+		tooMany[i].Property = fmt.Sprintf("prop%d", i)
+	}
+	testCases := map[string]struct {
+		sync InventorySync
+		err  string
+	}{
+		"ok": {
+			sync: InventorySync{
+				Attributes: []InventorySyncAttribute{{
+					Property: "firmware.version",
+				}, {
+					Property:  "metrics.cpu",
+					Attribute: "cpu_load",
+				}},
+			},
+		},
+		"ok, empty": {},
+		"ko, missing property": {
+			sync: InventorySync{
+				Attributes: []InventorySyncAttribute{{
+					Attribute: "cpu_load",
+				}},
+			},
+			err: "attributes: (0: (property: cannot be blank.).).",
+		},
+		"ko, invalid path": {
+			sync: InventorySync{
+				Attributes: []InventorySyncAttribute{{
+					Property: "metrics..cpu",
+				}},
+			},
+			err: "attributes: (0: (property: " + errPropertyPath.Error() + ".).).",
+		},
+		"ko, duplicate attribute": {
+			sync: InventorySync{
+				Attributes: []InventorySyncAttribute{{
+					Property: "version",
+				}, {
+					Property:  "firmware.version",
+					Attribute: "version",
+				}},
+			},
+			err: "attributes: duplicate attribute name.",
+		},
+		"ko, too many attributes": {
+			sync: InventorySync{
+				Attributes: tooMany,
+			},
+			err: "attributes: the length must be no more than 100.",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.sync.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInventorySyncMap(t *testing.T) {
+	t.Parallel()
+	var reported map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"firmware": {"version": "1.2.3", "build": {"id": 42}},
+		"metrics": {"cpu": 0.5, "sensors": [1, 2, 3], "mixed": [1, "a"]},
+		"online": true,
+		"empty": null,
+		"scalar": "value"
+	}`), &reported)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sync := InventorySync{
+		Attributes: []InventorySyncAttribute{
+			{Property: "firmware.version", Attribute: "fw_version"},
+			{Property: "firmware.build"},
+			{Property: "metrics.cpu"},
+			{Property: "metrics.sensors"},
+			{Property: "metrics.mixed"},
+			{Property: "online"},
+			{Property: "empty"},
+			{Property: "missing.property"},
+			{Property: "scalar.property"},
+		},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"fw_version":      "1.2.3",
+		"firmware.build":  `{"id":42}`,
+		"metrics.cpu":     0.5,
+		"metrics.sensors": []interface{}{1.0, 2.0, 3.0},
+		"metrics.mixed":   `[1,"a"]`,
+		"online":          "true",
+	}, sync.Map(reported))
+	assert.Empty(t, sync.Map(nil))
+}
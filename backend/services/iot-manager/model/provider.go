@@ -32,14 +32,26 @@ const (
 	ProviderIoTHub  Provider = "iot-hub"
 	ProviderIoTCore Provider = "iot-core"
 	ProviderWebhook Provider = "webhook"
+	ProviderMQTT    Provider = "mqtt"
 )
 
-var validateProvider = validation.In(ProviderIoTHub, ProviderIoTCore, ProviderWebhook)
+var validateProvider = validation.In(
+	ProviderIoTHub,
+	ProviderIoTCore,
+	ProviderWebhook,
+	ProviderMQTT,
+)
 
 func (p Provider) Validate() error {
 	return validateProvider.Validate(p)
 }
 
+// SupportsInventorySync returns true if the provider exposes a reported
+// device state that can be synchronized to the inventory.
+func (p Provider) HasDeviceState() bool {
+	return p == ProviderIoTHub || p == ProviderIoTCore
+}
+
 // All crypto.PublicKey from standard library implements this interface:
 type publicKeyExt interface {
 	crypto.PublicKey
@@ -56,10 +56,26 @@ type DataStore interface {
 	) (newDevice *model.Device, err error)
 	DeleteDevice(ctx context.Context, deviceID string) error
 	SetIntegrationCredentials(context.Context, uuid.UUID, model.Credentials) error
+	// SetIntegrationInventorySync sets the inventory synchronization
+	// settings of the integration; a nil value disables synchronization.
+	SetIntegrationInventorySync(context.Context, uuid.UUID, *model.InventorySync) error
+	// SetIntegrationConfigSync sets the configuration synchronization
+	// settings of the integration; a nil value disables synchronization.
+	SetIntegrationConfigSync(context.Context, uuid.UUID, *model.ConfigSync) error
+	// SetDeviceConfigState sets the configuration state of the device as of
+	// the last synchronization with the integrations.
+	SetDeviceConfigState(
+		ctx context.Context,
+		deviceID string,
+		state *model.DeviceConfigState,
+	) error
 	RemoveIntegration(context.Context, uuid.UUID) error
 
 	// GetAllDevices returns an iterator over ALL devices sorted by tenant ID.
 	GetAllDevices(ctx context.Context) (Iterator, error)
+	// GetAllIntegrations returns an iterator over the integrations of ALL
+	// tenants with the given provider sorted by tenant ID.
+	GetAllIntegrations(ctx context.Context, provider model.Provider) (Iterator, error)
 
 	// GetEvents returns list of event objects
 	GetEvents(ctx context.Context, fltr model.EventsFilter) ([]model.Event, error)
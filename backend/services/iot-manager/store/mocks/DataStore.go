@@ -174,6 +174,36 @@ func (_m *DataStore) GetAllDevices(ctx context.Context) (store.Iterator, error)
 	return r0, r1
 }
 
+// GetAllIntegrations provides a mock function with given fields: ctx, provider
+func (_m *DataStore) GetAllIntegrations(ctx context.Context, provider model.Provider) (store.Iterator, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllIntegrations")
+	}
+
+	var r0 store.Iterator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Provider) (store.Iterator, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Provider) store.Iterator); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Iterator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Provider) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: ctx, deviceID
 func (_m *DataStore) GetDevice(ctx context.Context, deviceID string) (*model.Device, error) {
 	ret := _m.Called(ctx, deviceID)
@@ -406,6 +436,42 @@ func (_m *DataStore) SaveEvent(ctx context.Context, event model.Event) error {
 	return r0
 }
 
+// SetDeviceConfigState provides a mock function with given fields: ctx, deviceID, state
+func (_m *DataStore) SetDeviceConfigState(ctx context.Context, deviceID string, state *model.DeviceConfigState) error {
+	ret := _m.Called(ctx, deviceID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceConfigState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.DeviceConfigState) error); ok {
+		r0 = rf(ctx, deviceID, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetIntegrationConfigSync provides a mock function with given fields: _a0, _a1, _a2
+func (_m *DataStore) SetIntegrationConfigSync(_a0 context.Context, _a1 uuid.UUID, _a2 *model.ConfigSync) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIntegrationConfigSync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.ConfigSync) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetIntegrationCredentials provides a mock function with given fields: _a0, _a1, _a2
 func (_m *DataStore) SetIntegrationCredentials(_a0 context.Context, _a1 uuid.UUID, _a2 model.Credentials) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -424,6 +490,24 @@ func (_m *DataStore) SetIntegrationCredentials(_a0 context.Context, _a1 uuid.UUI
 	return r0
 }
 
+// SetIntegrationInventorySync provides a mock function with given fields: _a0, _a1, _a2
+func (_m *DataStore) SetIntegrationInventorySync(_a0 context.Context, _a1 uuid.UUID, _a2 *model.InventorySync) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIntegrationInventorySync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.InventorySync) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertDeviceIntegrations provides a mock function with given fields: ctx, deviceID, integrationIDs
 func (_m *DataStore) UpsertDeviceIntegrations(ctx context.Context, deviceID string, integrationIDs []uuid.UUID) (*model.Device, error) {
 	ret := _m.Called(ctx, deviceID, integrationIDs)
@@ -47,6 +47,9 @@ const (
 	KeyCredentials    = "credentials"
 	KeyStatus         = "status"
 	KeyIntegrationID  = "integration_id"
+	KeyInventorySync  = "inventory_sync"
+	KeyConfigSync     = "config_sync"
+	KeyConfigState    = "config_state"
 
 	ConnectTimeoutSeconds = 10
 	defaultAutomigrate    = false
@@ -342,6 +345,88 @@ func (db *DataStoreMongo) SetIntegrationCredentials(
 	return errors.Wrap(err, "mongo: failed to set integration credentials")
 }
 
+func (db *DataStoreMongo) SetIntegrationInventorySync(
+	ctx context.Context,
+	integrationID uuid.UUID,
+	inventorySync *model.InventorySync,
+) error {
+	collIntegrations := db.client.Database(*db.DbName).Collection(CollNameIntegrations)
+
+	fltr := bson.D{{
+		Key:   KeyID,
+		Value: integrationID,
+	}}
+
+	var update bson.M
+	if inventorySync != nil {
+		update = bson.M{
+			"$set": bson.D{{
+				Key:   KeyInventorySync,
+				Value: inventorySync,
+			}},
+		}
+	} else {
+		update = bson.M{
+			"$unset": bson.D{{
+				Key:   KeyInventorySync,
+				Value: "",
+			}},
+		}
+	}
+
+	result, err := collIntegrations.UpdateOne(ctx,
+		mstore.WithTenantID(ctx, fltr),
+		update,
+	)
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to set integration inventory sync")
+	} else if result.MatchedCount == 0 {
+		return store.ErrObjectNotFound
+	}
+	return nil
+}
+
+func (db *DataStoreMongo) SetIntegrationConfigSync(
+	ctx context.Context,
+	integrationID uuid.UUID,
+	configSync *model.ConfigSync,
+) error {
+	collIntegrations := db.client.Database(*db.DbName).Collection(CollNameIntegrations)
+
+	fltr := bson.D{{
+		Key:   KeyID,
+		Value: integrationID,
+	}}
+
+	var update bson.M
+	if configSync != nil {
+		update = bson.M{
+			"$set": bson.D{{
+				Key:   KeyConfigSync,
+				Value: configSync,
+			}},
+		}
+	} else {
+		update = bson.M{
+			"$unset": bson.D{{
+				Key:   KeyConfigSync,
+				Value: "",
+			}},
+		}
+	}
+
+	result, err := collIntegrations.UpdateOne(ctx,
+		mstore.WithTenantID(ctx, fltr),
+		update,
+	)
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to set integration config sync")
+	} else if result.MatchedCount == 0 {
+		return store.ErrObjectNotFound
+	}
+	return nil
+}
+
 func (db *DataStoreMongo) RemoveIntegration(ctx context.Context, integrationId uuid.UUID) error {
 	collIntegrations := db.client.Database(*db.DbName).Collection(CollNameIntegrations)
 	fltr := bson.D{{
@@ -465,6 +550,46 @@ func (db *DataStoreMongo) DeleteDevice(ctx context.Context, deviceID string) err
 	return nil
 }
 
+func (db *DataStoreMongo) SetDeviceConfigState(
+	ctx context.Context,
+	deviceID string,
+	state *model.DeviceConfigState,
+) error {
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	collDevices := db.Collection(CollNameDevices)
+
+	filter := bson.D{{
+		Key: KeyID, Value: deviceID,
+	}, {
+		Key: KeyTenantID, Value: tenantID,
+	}}
+	var update bson.D
+	if state != nil {
+		update = bson.D{{
+			Key: "$set", Value: bson.D{{
+				Key: KeyConfigState, Value: state,
+			}},
+		}}
+	} else {
+		update = bson.D{{
+			Key: "$unset", Value: bson.D{{
+				Key: KeyConfigState, Value: "",
+			}},
+		}}
+	}
+
+	res, err := collDevices.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to set device config state")
+	} else if res.MatchedCount == 0 {
+		return store.ErrObjectNotFound
+	}
+	return nil
+}
+
 func (db *DataStoreMongo) RemoveDevicesFromIntegration(
 	ctx context.Context,
 	integrationID uuid.UUID,
@@ -540,6 +665,19 @@ func (db *DataStoreMongo) GetAllDevices(ctx context.Context) (store.Iterator, er
 
 }
 
+func (db *DataStoreMongo) GetAllIntegrations(
+	ctx context.Context,
+	provider model.Provider,
+) (store.Iterator, error) {
+	collIntegrations := db.Collection(CollNameIntegrations)
+
+	return collIntegrations.Find(ctx,
+		bson.D{{Key: KeyProvider, Value: provider}},
+		mopts.Find().
+			SetSort(bson.D{{Key: KeyTenantID, Value: 1}}),
+	)
+}
+
 func (db *DataStoreMongo) DeleteTenantData(
 	ctx context.Context,
 ) error {
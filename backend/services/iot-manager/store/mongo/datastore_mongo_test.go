@@ -424,6 +424,86 @@ func TestGetIntegrations(t *testing.T) {
 	}
 }
 
+func TestGetAllIntegrations(t *testing.T) {
+	t.Parallel()
+	dbName := t.Name()
+	dbClient := db.Client()
+	defer dbClient.Database(dbName).Drop(context.Background())
+
+	integrations := []model.Integration{{
+		ID:       uuid.NewSHA1(uuid.NameSpaceOID, []byte{'1'}),
+		Provider: model.ProviderMQTT,
+		Credentials: model.Credentials{
+			Type: model.CredentialTypeMQTT,
+			MQTT: &model.MQTTCredentials{
+				BrokerURL: "mqtt://localhost",
+			},
+		},
+	}, {
+		ID:       uuid.NewSHA1(uuid.NameSpaceOID, []byte{'2'}),
+		Provider: model.ProviderMQTT,
+		Credentials: model.Credentials{
+			Type: model.CredentialTypeMQTT,
+			MQTT: &model.MQTTCredentials{
+				BrokerURL: "mqtts://localhost",
+			},
+		},
+	}, {
+		ID:       uuid.NewSHA1(uuid.NameSpaceOID, []byte{'3'}),
+		Provider: model.ProviderWebhook,
+		Credentials: model.Credentials{
+			Type: model.CredentialTypeHTTP,
+			HTTP: &model.HTTPCredentials{
+				URL: "http://localhost",
+			},
+		},
+	}}
+	tenants := []string{
+		"123456789012345678901235",
+		"123456789012345678901234",
+		"123456789012345678901234",
+	}
+	collIntegrations := dbClient.Database(dbName).Collection(CollNameIntegrations)
+	for i, integration := range integrations {
+		ctx := identity.WithContext(context.Background(), &identity.Identity{
+			Tenant: tenants[i],
+		})
+		_, err := collIntegrations.InsertOne(ctx, mstore.WithTenantID(ctx, integration))
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	ds := NewDataStoreWithClient(dbClient, NewConfig().SetDbName(dbName))
+	iter, err := ds.GetAllIntegrations(context.Background(), model.ProviderMQTT)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer iter.Close(context.Background())
+	type integrationWithTenantID struct {
+		model.Integration `bson:",inline"`
+		TenantID          string `bson:"tenant_id"`
+	}
+	var results []integrationWithTenantID
+	for iter.Next(context.Background()) {
+		var res integrationWithTenantID
+		if assert.NoError(t, iter.Decode(&res)) {
+			results = append(results, res)
+		}
+	}
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, tenants[1], results[0].TenantID)
+		assert.Equal(t, integrations[1], results[0].Integration)
+		assert.Equal(t, tenants[0], results[1].TenantID)
+		assert.Equal(t, integrations[0], results[1].Integration)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ds.GetAllIntegrations(ctx, model.ProviderMQTT)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestGetDevice(t *testing.T) {
 	t.Parallel()
 	dbClient := db.Client()
@@ -676,6 +756,218 @@ func TestSetIntegrationCredentials(t *testing.T) {
 	}
 }
 
+func TestSetIntegrationInventorySync(t *testing.T) {
+	t.Parallel()
+	dbClient := db.Client()
+	const tenantID = "123456789012345678901234"
+	integrationID := uuid.New()
+	testCases := []struct {
+		Name string
+
+		IntegrationID uuid.UUID
+		InventorySync *model.InventorySync
+		Error         error
+	}{{
+		Name: "ok",
+
+		IntegrationID: integrationID,
+		InventorySync: &model.InventorySync{
+			Attributes: []model.InventorySyncAttribute{{
+				Property:  "firmware.version",
+				Attribute: "firmware_version",
+			}},
+		},
+	}, {
+		Name: "ok, unset",
+
+		IntegrationID: integrationID,
+	}, {
+		Name: "error, integration not found",
+
+		IntegrationID: uuid.New(),
+		InventorySync: &model.InventorySync{},
+		Error:         store.ErrObjectNotFound,
+	}}
+	for i := range testCases {
+		dbName := fmt.Sprintf("%s-%d", t.Name(), i)
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			defer dbClient.Database(dbName).Drop(context.Background())
+			collIntegrations := dbClient.Database(dbName).Collection(CollNameIntegrations)
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+			_, err := collIntegrations.InsertOne(ctx,
+				mstore.WithTenantID(ctx, model.Integration{
+					ID:       integrationID,
+					Provider: model.ProviderIoTHub,
+					InventorySync: &model.InventorySync{
+						Attributes: []model.InventorySyncAttribute{{
+							Property: "version",
+						}},
+					},
+				}),
+			)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			db := NewDataStoreWithClient(dbClient, NewConfig().SetDbName(dbName))
+			err = db.SetIntegrationInventorySync(ctx, tc.IntegrationID, tc.InventorySync)
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			itg, err := db.GetIntegrationById(ctx, tc.IntegrationID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.InventorySync, itg.InventorySync)
+			}
+		})
+	}
+}
+
+func TestSetIntegrationConfigSync(t *testing.T) {
+	t.Parallel()
+	dbClient := db.Client()
+	const tenantID = "123456789012345678901234"
+	integrationID := uuid.New()
+	testCases := []struct {
+		Name string
+
+		IntegrationID uuid.UUID
+		ConfigSync    *model.ConfigSync
+		Error         error
+	}{{
+		Name: "ok",
+
+		IntegrationID: integrationID,
+		ConfigSync: &model.ConfigSync{
+			Property: "mender",
+			Conflict: model.ConfigSyncConflictCloud,
+		},
+	}, {
+		Name: "ok, unset",
+
+		IntegrationID: integrationID,
+	}, {
+		Name: "error, integration not found",
+
+		IntegrationID: uuid.New(),
+		ConfigSync:    &model.ConfigSync{},
+		Error:         store.ErrObjectNotFound,
+	}}
+	for i := range testCases {
+		dbName := fmt.Sprintf("%s-%d", t.Name(), i)
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			defer dbClient.Database(dbName).Drop(context.Background())
+			collIntegrations := dbClient.Database(dbName).Collection(CollNameIntegrations)
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+			_, err := collIntegrations.InsertOne(ctx,
+				mstore.WithTenantID(ctx, model.Integration{
+					ID:         integrationID,
+					Provider:   model.ProviderIoTHub,
+					ConfigSync: &model.ConfigSync{},
+				}),
+			)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			db := NewDataStoreWithClient(dbClient, NewConfig().SetDbName(dbName))
+			err = db.SetIntegrationConfigSync(ctx, tc.IntegrationID, tc.ConfigSync)
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			itg, err := db.GetIntegrationById(ctx, tc.IntegrationID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.ConfigSync, itg.ConfigSync)
+			}
+		})
+	}
+}
+
+func TestSetDeviceConfigState(t *testing.T) {
+	t.Parallel()
+	dbClient := db.Client()
+	const (
+		tenantID = "123456789012345678901234"
+		deviceID = "c9b0b2a4-8dd1-4b6b-a4a6-4c1c5c3a1d1f"
+	)
+	testCases := []struct {
+		Name string
+
+		DeviceID string
+		State    *model.DeviceConfigState
+		Error    error
+	}{{
+		Name: "ok",
+
+		DeviceID: deviceID,
+		State: &model.DeviceConfigState{
+			Desired: model.DeviceConfiguration{
+				"network.ssid": "mender",
+			},
+			Reported: model.DeviceConfiguration{
+				"network.ssid": "guest",
+			},
+		},
+	}, {
+		Name: "ok, unset",
+
+		DeviceID: deviceID,
+	}, {
+		Name: "error, device not found",
+
+		DeviceID: "not-found",
+		State:    &model.DeviceConfigState{},
+		Error:    store.ErrObjectNotFound,
+	}}
+	for i := range testCases {
+		dbName := fmt.Sprintf("%s-%d", t.Name(), i)
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			defer dbClient.Database(dbName).Drop(context.Background())
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+			db := NewDataStoreWithClient(dbClient, NewConfig().SetDbName(dbName))
+			_, err := db.UpsertDeviceIntegrations(ctx, deviceID, []uuid.UUID{uuid.New()})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = db.SetDeviceConfigState(ctx, tc.DeviceID, tc.State)
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			dev, err := db.GetDevice(ctx, tc.DeviceID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.State, dev.ConfigState)
+			}
+		})
+	}
+}
+
 func TestRemoveIntegration(t *testing.T) {
 	t.Parallel()
 	dbClient := db.Client()
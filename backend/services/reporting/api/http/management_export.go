@@ -0,0 +1,252 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/rbac"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/reporting/app/reporting"
+	"github.com/mendersoftware/mender-server/services/reporting/model"
+)
+
+const (
+	ParamAsync = "async"
+	ParamID    = "id"
+
+	hdrContentDisposition = "Content-Disposition"
+	hdrLocation           = "Location"
+)
+
+func (mc *ManagementController) ExportDevices(c *gin.Context) {
+	ctx := c.Request.Context()
+	params, err := parseExportDevicesParams(ctx, c)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	async, err := parseAsync(c)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if async {
+		export, err := mc.reporting.StartExportDevices(ctx, params)
+		renderExportStarted(c, export, err)
+		return
+	}
+
+	setExportHeaders(c, model.ExportTypeDevices, params.Format)
+	_, err = mc.reporting.ExportDevices(ctx, params, c.Writer)
+	renderExportError(c, err)
+}
+
+func parseExportDevicesParams(ctx context.Context, c *gin.Context) (
+	*model.ExportDevicesParams, error) {
+	var exportParams model.ExportDevicesParams
+
+	err := c.ShouldBindJSON(&exportParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if id := identity.FromContext(ctx); id != nil {
+		exportParams.TenantID = id.Tenant
+	} else {
+		return nil, errors.New("missing tenant ID from the context")
+	}
+
+	if scope := rbac.ExtractScopeFromHeader(c.Request); scope != nil {
+		exportParams.Groups = scope.DeviceGroups
+	}
+
+	if exportParams.Format == "" {
+		exportParams.Format = model.ExportFormatNDJSON
+	}
+
+	if err := exportParams.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &exportParams, nil
+}
+
+func (mc *ManagementController) ExportDeployments(c *gin.Context) {
+	ctx := c.Request.Context()
+	params, err := parseExportDeploymentsParams(ctx, c)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	async, err := parseAsync(c)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if async {
+		export, err := mc.reporting.StartExportDeployments(ctx, params)
+		renderExportStarted(c, export, err)
+		return
+	}
+
+	setExportHeaders(c, model.ExportTypeDeployments, params.Format)
+	_, err = mc.reporting.ExportDeployments(ctx, params, c.Writer)
+	renderExportError(c, err)
+}
+
+func parseExportDeploymentsParams(ctx context.Context, c *gin.Context) (
+	*model.ExportDeploymentsParams, error) {
+	var exportParams model.ExportDeploymentsParams
+
+	err := c.ShouldBindJSON(&exportParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if id := identity.FromContext(ctx); id != nil {
+		exportParams.TenantID = id.Tenant
+	} else {
+		return nil, errors.New("missing tenant ID from the context")
+	}
+
+	if scope := rbac.ExtractScopeFromHeader(c.Request); scope != nil {
+		exportParams.DeploymentGroups = scope.DeviceGroups
+	}
+
+	if exportParams.Format == "" {
+		exportParams.Format = model.ExportFormatNDJSON
+	}
+
+	if err := exportParams.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &exportParams, nil
+}
+
+func parseAsync(c *gin.Context) (bool, error) {
+	value := c.Query(ParamAsync)
+	if value == "" {
+		return false, nil
+	}
+	async, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.Errorf("invalid value for parameter %q", ParamAsync)
+	}
+	return async, nil
+}
+
+func setExportHeaders(c *gin.Context, typ model.ExportType, format model.ExportFormat) {
+	c.Header("Content-Type", format.ContentType())
+	c.Header(hdrContentDisposition,
+		fmt.Sprintf(`attachment; filename="%s.%s"`, typ, format))
+	c.Status(http.StatusOK)
+}
+
+// renderExportError renders the error of a synchronous export; once the
+// output is streamed the status can't change, the error is only logged
+func renderExportError(c *gin.Context, err error) {
+	if err == nil {
+		return
+	}
+	if c.Writer.Written() {
+		log.FromContext(c.Request.Context()).
+			Errorf("export interrupted: %s", err)
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del(hdrContentDisposition)
+	rest.RenderError(c, http.StatusInternalServerError, err)
+}
+
+func renderExportStarted(c *gin.Context, export *model.Export, err error) {
+	if err != nil {
+		rest.RenderError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.Header(hdrLocation, URIManagement+"/exports/"+export.ID)
+	c.JSON(http.StatusAccepted, export)
+}
+
+func (mc *ManagementController) GetExport(c *gin.Context) {
+	export, ok := mc.getExport(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, export)
+}
+
+func (mc *ManagementController) DownloadExport(c *gin.Context) {
+	export, ok := mc.getExport(c)
+	if !ok {
+		return
+	}
+	if export.Status != model.ExportStatusDone {
+		rest.RenderError(c,
+			http.StatusConflict,
+			errors.Errorf("export is %s", export.Status),
+		)
+		return
+	}
+
+	setExportHeaders(c, export.Type, export.Format)
+	err := mc.reporting.DownloadExport(c.Request.Context(), export, c.Writer)
+	renderExportError(c, err)
+}
+
+func (mc *ManagementController) getExport(c *gin.Context) (*model.Export, bool) {
+	ctx := c.Request.Context()
+
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	} else {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.New("missing tenant ID from the context"),
+		)
+		return nil, false
+	}
+
+	export, err := mc.reporting.GetExport(ctx, tenantID, c.Param(ParamID))
+	if errors.Is(err, reporting.ErrExportNotFound) {
+		rest.RenderError(c, http.StatusNotFound, err)
+		return nil, false
+	} else if err != nil {
+		rest.RenderError(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return export, true
+}
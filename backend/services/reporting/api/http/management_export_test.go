@@ -0,0 +1,542 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/rbac"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/reporting/app/reporting"
+	mapp "github.com/mendersoftware/mender-server/services/reporting/app/reporting/mocks"
+	"github.com/mendersoftware/mender-server/services/reporting/model"
+)
+
+var exportCtx = identity.WithContext(context.Background(),
+	&identity.Identity{
+		Subject: "851f90b3-cee5-425e-8f6e-b36de1993e7e",
+		Tenant:  "123456789012345678901234",
+	},
+)
+
+func assertExportResponse(t *testing.T, response interface{}, w *httptest.ResponseRecorder) {
+	switch res := response.(type) {
+	case string:
+		assert.Equal(t, res, w.Body.String())
+
+	case *model.Export:
+		b, _ := json.Marshal(res)
+		assert.JSONEq(t, string(b), w.Body.String())
+
+	case rest.Error:
+		var actual rest.Error
+		dec := json.NewDecoder(w.Body)
+		dec.DisallowUnknownFields()
+		err := dec.Decode(&actual)
+		if assert.NoError(t, err, "response schema did not match expected rest.Error") {
+			assert.EqualError(t, res, actual.Error())
+		}
+
+	default:
+		panic("[TEST ERR] Dunno what to compare!")
+	}
+}
+
+func TestManagementExportDevices(t *testing.T) {
+	t.Parallel()
+	export := &model.Export{
+		ID:        "5a3b4b3e-5e36-4f5b-9b6c-7e2c7c3a7a4e",
+		Type:      model.ExportTypeDevices,
+		Format:    model.ExportFormatCSV,
+		Status:    model.ExportStatusPending,
+		CreatedTs: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	type testCase struct {
+		Name string
+
+		App    func(*testing.T, testCase) *mapp.App
+		CTX    context.Context
+		Query  string
+		Params interface{} // *model.ExportDevicesParams
+
+		Code     int
+		Headers  map[string]string
+		Response interface{}
+	}
+	testCases := []testCase{{
+		Name: "ok, csv",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("ExportDevices",
+				contextMatcher,
+				mock.MatchedBy(func(params *model.ExportDevicesParams) bool {
+					return params.TenantID == "123456789012345678901234" &&
+						params.Format == model.ExportFormatCSV &&
+						assert.Equal(t, []string{"foo", "bar"}, params.Groups)
+				}),
+				mock.Anything).
+				Return(func(_ context.Context, _ *model.ExportDevicesParams,
+					w io.Writer) (int, error) {
+					_, err := io.WriteString(w, self.Response.(string))
+					return 1, err
+				})
+			return app
+		},
+		CTX: rbac.WithContext(exportCtx, &rbac.Scope{
+			DeviceGroups: []string{"foo", "bar"},
+		}),
+		Params: &model.ExportDevicesParams{
+			SearchParams: model.SearchParams{
+				Attributes: []model.SelectAttribute{{
+					Scope:     model.ScopeInventory,
+					Attribute: "mac",
+				}},
+			},
+			Format: model.ExportFormatCSV,
+		},
+
+		Code: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type":        "text/csv",
+			"Content-Disposition": `attachment; filename="devices.csv"`,
+		},
+		Response: "id,inventory:mac\n1,00:11:22:33:44:55\n",
+	}, {
+		Name: "ok, ndjson by default",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("ExportDevices",
+				contextMatcher,
+				mock.MatchedBy(func(params *model.ExportDevicesParams) bool {
+					return params.Format == model.ExportFormatNDJSON
+				}),
+				mock.Anything).
+				Return(func(_ context.Context, _ *model.ExportDevicesParams,
+					w io.Writer) (int, error) {
+					_, err := io.WriteString(w, self.Response.(string))
+					return 1, err
+				})
+			return app
+		},
+		CTX:    exportCtx,
+		Params: map[string]interface{}{},
+
+		Code: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type":        "application/x-ndjson",
+			"Content-Disposition": `attachment; filename="devices.ndjson"`,
+		},
+		Response: `{"id":"1"}` + "\n",
+	}, {
+		Name: "ok, async",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("StartExportDevices",
+				contextMatcher,
+				mock.MatchedBy(func(params *model.ExportDevicesParams) bool {
+					return params.TenantID == "123456789012345678901234"
+				})).
+				Return(export, nil)
+			return app
+		},
+		CTX:   exportCtx,
+		Query: "?async=true",
+		Params: &model.ExportDevicesParams{
+			SearchParams: model.SearchParams{
+				Attributes: []model.SelectAttribute{{
+					Scope:     model.ScopeInventory,
+					Attribute: "mac",
+				}},
+			},
+			Format: model.ExportFormatCSV,
+		},
+
+		Code: http.StatusAccepted,
+		Headers: map[string]string{
+			"Location": URIManagement + "/exports/" + export.ID,
+		},
+		Response: export,
+	}, {
+		Name: "error, async internal error",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("StartExportDevices", contextMatcher, mock.Anything).
+				Return(nil, errors.New("internal error"))
+			return app
+		},
+		CTX:    exportCtx,
+		Query:  "?async=1",
+		Params: map[string]interface{}{},
+
+		Code:     http.StatusInternalServerError,
+		Response: rest.Error{Err: "internal error"},
+	}, {
+		Name: "error, internal error",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("ExportDevices", contextMatcher, mock.Anything, mock.Anything).
+				Return(0, errors.New("internal error"))
+			return app
+		},
+		CTX:    exportCtx,
+		Params: map[string]interface{}{},
+
+		Code: http.StatusInternalServerError,
+		Headers: map[string]string{
+			"Content-Type":        "application/json; charset=utf-8",
+			"Content-Disposition": "",
+		},
+		Response: rest.Error{Err: "internal error"},
+	}, {
+		Name: "error, csv without attributes",
+
+		CTX: exportCtx,
+		Params: &model.ExportDevicesParams{
+			Format: model.ExportFormatCSV,
+		},
+
+		Code: http.StatusBadRequest,
+		Response: rest.Error{
+			Err: "malformed request body: attributes: cannot be blank when exporting to csv",
+		},
+	}, {
+		Name: "error, invalid async parameter",
+
+		CTX:    exportCtx,
+		Query:  "?async=maybe",
+		Params: map[string]interface{}{},
+
+		Code:     http.StatusBadRequest,
+		Response: rest.Error{Err: `invalid value for parameter "async"`},
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			var app *mapp.App
+			if tc.App == nil {
+				app = new(mapp.App)
+			} else {
+				app = tc.App(t, tc)
+			}
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			b, _ := json.Marshal(tc.Params)
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				URIManagement+URIInventoryExport+tc.Query,
+				bytes.NewReader(b),
+			)
+			if id := identity.FromContext(tc.CTX); id != nil {
+				req.Header.Set("Authorization", "Bearer "+GenerateJWT(*id))
+			}
+			if scope := rbac.FromContext(tc.CTX); scope != nil {
+				req.Header.Set(rbac.ScopeHeader, strings.Join(scope.DeviceGroups, ","))
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.Code, w.Code)
+			for key, value := range tc.Headers {
+				assert.Equal(t, value, w.Header().Get(key))
+			}
+			assertExportResponse(t, tc.Response, w)
+		})
+	}
+}
+
+func TestManagementExportDeployments(t *testing.T) {
+	t.Parallel()
+	export := &model.Export{
+		ID:        "5a3b4b3e-5e36-4f5b-9b6c-7e2c7c3a7a4e",
+		Type:      model.ExportTypeDeployments,
+		Format:    model.ExportFormatNDJSON,
+		Status:    model.ExportStatusPending,
+		CreatedTs: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	type testCase struct {
+		Name string
+
+		App    func(*testing.T, testCase) *mapp.App
+		CTX    context.Context
+		Query  string
+		Params interface{} // *model.ExportDeploymentsParams
+
+		Code     int
+		Headers  map[string]string
+		Response interface{}
+	}
+	testCases := []testCase{{
+		Name: "ok, ndjson",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("ExportDeployments",
+				contextMatcher,
+				mock.MatchedBy(func(params *model.ExportDeploymentsParams) bool {
+					return params.TenantID == "123456789012345678901234" &&
+						assert.Equal(t, []string{"foo"}, params.DeploymentGroups)
+				}),
+				mock.Anything).
+				Return(func(_ context.Context, _ *model.ExportDeploymentsParams,
+					w io.Writer) (int, error) {
+					_, err := io.WriteString(w, self.Response.(string))
+					return 1, err
+				})
+			return app
+		},
+		CTX: rbac.WithContext(exportCtx, &rbac.Scope{
+			DeviceGroups: []string{"foo"},
+		}),
+		Params: &model.ExportDeploymentsParams{
+			Format: model.ExportFormatNDJSON,
+		},
+
+		Code: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type":        "application/x-ndjson",
+			"Content-Disposition": `attachment; filename="deployments.ndjson"`,
+		},
+		Response: `{"id":"1"}` + "\n",
+	}, {
+		Name: "ok, async",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("StartExportDeployments", contextMatcher, mock.Anything).
+				Return(export, nil)
+			return app
+		},
+		CTX:    exportCtx,
+		Query:  "?async=true",
+		Params: map[string]interface{}{},
+
+		Code: http.StatusAccepted,
+		Headers: map[string]string{
+			"Location": URIManagement + "/exports/" + export.ID,
+		},
+		Response: export,
+	}, {
+		Name: "error, internal error",
+
+		App: func(t *testing.T, self testCase) *mapp.App {
+			app := new(mapp.App)
+			app.On("ExportDeployments", contextMatcher, mock.Anything, mock.Anything).
+				Return(0, errors.New("internal error"))
+			return app
+		},
+		CTX:    exportCtx,
+		Params: map[string]interface{}{},
+
+		Code:     http.StatusInternalServerError,
+		Response: rest.Error{Err: "internal error"},
+	}, {
+		Name: "error, invalid format",
+
+		CTX: exportCtx,
+		Params: map[string]interface{}{
+			"format": "xml",
+		},
+
+		Code:     http.StatusBadRequest,
+		Response: rest.Error{Err: "malformed request body: must be a valid value"},
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			var app *mapp.App
+			if tc.App == nil {
+				app = new(mapp.App)
+			} else {
+				app = tc.App(t, tc)
+			}
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			b, _ := json.Marshal(tc.Params)
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				URIManagement+URIDeploymentsExport+tc.Query,
+				bytes.NewReader(b),
+			)
+			if id := identity.FromContext(tc.CTX); id != nil {
+				req.Header.Set("Authorization", "Bearer "+GenerateJWT(*id))
+			}
+			if scope := rbac.FromContext(tc.CTX); scope != nil {
+				req.Header.Set(rbac.ScopeHeader, strings.Join(scope.DeviceGroups, ","))
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.Code, w.Code)
+			for key, value := range tc.Headers {
+				assert.Equal(t, value, w.Header().Get(key))
+			}
+			assertExportResponse(t, tc.Response, w)
+		})
+	}
+}
+
+func TestManagementGetExport(t *testing.T) {
+	t.Parallel()
+	const exportID = "5a3b4b3e-5e36-4f5b-9b6c-7e2c7c3a7a4e"
+	finished := time.Date(2026, 10, 1, 0, 1, 0, 0, time.UTC)
+	export := &model.Export{
+		ID:         exportID,
+		TenantID:   "123456789012345678901234",
+		Type:       model.ExportTypeDevices,
+		Format:     model.ExportFormatCSV,
+		Status:     model.ExportStatusDone,
+		Count:      2,
+		CreatedTs:  time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		FinishedTs: &finished,
+	}
+	pending := *export
+	pending.Status = model.ExportStatusPending
+	pending.FinishedTs = nil
+
+	type testCase struct {
+		Name string
+
+		Download  bool
+		Export    *model.Export
+		GetErr    error
+		Output    string
+		OutputErr error
+
+		Code     int
+		Headers  map[string]string
+		Response interface{}
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		Export: export,
+
+		Code:     http.StatusOK,
+		Response: export,
+	}, {
+		Name: "error, not found",
+
+		GetErr: reporting.ErrExportNotFound,
+
+		Code:     http.StatusNotFound,
+		Response: rest.Error{Err: reporting.ErrExportNotFound.Error()},
+	}, {
+		Name: "error, internal error",
+
+		GetErr: errors.New("internal error"),
+
+		Code:     http.StatusInternalServerError,
+		Response: rest.Error{Err: "internal error"},
+	}, {
+		Name: "ok, download",
+
+		Download: true,
+		Export:   export,
+		Output:   "id,inventory:mac\n1,00:11:22:33:44:55\n2,00:11:22:33:44:56\n",
+
+		Code: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type":        "text/csv",
+			"Content-Disposition": `attachment; filename="devices.csv"`,
+		},
+		Response: "id,inventory:mac\n1,00:11:22:33:44:55\n2,00:11:22:33:44:56\n",
+	}, {
+		Name: "error, download not ready",
+
+		Download: true,
+		Export:   &pending,
+
+		Code:     http.StatusConflict,
+		Response: rest.Error{Err: "export is pending"},
+	}, {
+		Name: "error, download not found",
+
+		Download: true,
+		GetErr:   reporting.ErrExportNotFound,
+
+		Code:     http.StatusNotFound,
+		Response: rest.Error{Err: reporting.ErrExportNotFound.Error()},
+	}, {
+		Name: "error, download internal error",
+
+		Download:  true,
+		Export:    export,
+		OutputErr: errors.New("internal error"),
+
+		Code:     http.StatusInternalServerError,
+		Response: rest.Error{Err: "internal error"},
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := new(mapp.App)
+			defer app.AssertExpectations(t)
+			app.On("GetExport", contextMatcher, "123456789012345678901234", exportID).
+				Return(tc.Export, tc.GetErr)
+			if tc.Download && tc.Export != nil &&
+				tc.Export.Status == model.ExportStatusDone {
+				app.On("DownloadExport", contextMatcher, tc.Export, mock.Anything).
+					Return(func(_ context.Context, _ *model.Export, w io.Writer) error {
+						if tc.OutputErr != nil {
+							return tc.OutputErr
+						}
+						_, err := io.WriteString(w, tc.Output)
+						return err
+					})
+			}
+			router := NewRouter(app)
+
+			uri := strings.Replace(URIExport, ":id", exportID, 1)
+			if tc.Download {
+				uri = strings.Replace(URIExportDownload, ":id", exportID, 1)
+			}
+			req, _ := http.NewRequest(http.MethodGet, URIManagement+uri, nil)
+			id := identity.FromContext(exportCtx)
+			req.Header.Set("Authorization", "Bearer "+GenerateJWT(*id))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.Code, w.Code)
+			for key, value := range tc.Headers {
+				assert.Equal(t, value, w.Header().Get(key))
+			}
+			assertExportResponse(t, tc.Response, w)
+		})
+	}
+}
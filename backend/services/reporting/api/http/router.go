@@ -34,9 +34,13 @@ const (
 	URIAlive                   = "/alive"
 	URIHealth                  = "/health"
 	URIDeploymentsAggregate    = "/deployments/devices/aggregate"
+	URIDeploymentsExport       = "/deployments/devices/export"
 	URIDeploymentsSearch       = "/deployments/devices/search"
+	URIExport                  = "/exports/:id"
+	URIExportDownload          = "/exports/:id/download"
 	URIInventoryAggregate      = "/devices/aggregate"
 	URIInventoryAttrs          = "/devices/attributes"
+	URIInventoryExport         = "/devices/export"
 	URIInventorySearch         = "/devices/search"
 	URIInventorySearchAttrs    = "/devices/search/attributes"
 	URIInventorySearchInternal = "/tenants/:tenant_id/devices/search"
@@ -85,11 +89,16 @@ func NewRouter(reporting reporting.App, options ...Option) *gin.Engine {
 	// devices
 	mgmtAPI.POST(URIInventoryAggregate, mgmt.AggregateDevices)
 	mgmtAPI.GET(URIInventoryAttrs, mgmt.DeviceAttrs)
+	mgmtAPI.POST(URIInventoryExport, mgmt.ExportDevices)
 	mgmtAPI.POST(URIInventorySearch, mgmt.SearchDevices)
 	mgmtAPI.GET(URIInventorySearchAttrs, mgmt.SearchDeviceAttrs)
 	// deployments
 	mgmtAPI.POST(URIDeploymentsAggregate, mgmt.AggregateDeployments)
 	mgmtAPI.POST(URIDeploymentsSearch, mgmt.SearchDeployments)
+	mgmtAPI.POST(URIDeploymentsExport, mgmt.ExportDeployments)
+	// exports
+	mgmtAPI.GET(URIExport, mgmt.GetExport)
+	mgmtAPI.GET(URIExportDownload, mgmt.DownloadExport)
 
 	return router
 }
@@ -18,8 +18,10 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
 	inventory "github.com/mendersoftware/mender-server/services/reporting/client/inventory"
+
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/mendersoftware/mender-server/services/reporting/model"
@@ -90,6 +92,128 @@ func (_m *App) AggregateDevices(ctx context.Context, aggregateParams *model.Aggr
 	return r0, r1
 }
 
+// DeleteExpiredExports provides a mock function with given fields: ctx
+func (_m *App) DeleteExpiredExports(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredExports")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadExport provides a mock function with given fields: ctx, export, w
+func (_m *App) DownloadExport(ctx context.Context, export *model.Export, w io.Writer) error {
+	ret := _m.Called(ctx, export, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Export, io.Writer) error); ok {
+		r0 = rf(ctx, export, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportDeployments provides a mock function with given fields: ctx, params, w
+func (_m *App) ExportDeployments(ctx context.Context, params *model.ExportDeploymentsParams, w io.Writer) (int, error) {
+	ret := _m.Called(ctx, params, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDeployments")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDeploymentsParams, io.Writer) (int, error)); ok {
+		return rf(ctx, params, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDeploymentsParams, io.Writer) int); ok {
+		r0 = rf(ctx, params, w)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ExportDeploymentsParams, io.Writer) error); ok {
+		r1 = rf(ctx, params, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportDevices provides a mock function with given fields: ctx, params, w
+func (_m *App) ExportDevices(ctx context.Context, params *model.ExportDevicesParams, w io.Writer) (int, error) {
+	ret := _m.Called(ctx, params, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDevices")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDevicesParams, io.Writer) (int, error)); ok {
+		return rf(ctx, params, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDevicesParams, io.Writer) int); ok {
+		r0 = rf(ctx, params, w)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ExportDevicesParams, io.Writer) error); ok {
+		r1 = rf(ctx, params, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExport provides a mock function with given fields: ctx, tenantID, id
+func (_m *App) GetExport(ctx context.Context, tenantID string, id string) (*model.Export, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExport")
+	}
+
+	var r0 *model.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Export, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Export); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMapping provides a mock function with given fields: ctx, tid
 func (_m *App) GetMapping(ctx context.Context, tid string) (*model.Mapping, error) {
 	ret := _m.Called(ctx, tid)
@@ -242,6 +366,66 @@ func (_m *App) SearchDevices(ctx context.Context, searchParams *model.SearchPara
 	return r0, r1, r2
 }
 
+// StartExportDeployments provides a mock function with given fields: ctx, params
+func (_m *App) StartExportDeployments(ctx context.Context, params *model.ExportDeploymentsParams) (*model.Export, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExportDeployments")
+	}
+
+	var r0 *model.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDeploymentsParams) (*model.Export, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDeploymentsParams) *model.Export); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ExportDeploymentsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartExportDevices provides a mock function with given fields: ctx, params
+func (_m *App) StartExportDevices(ctx context.Context, params *model.ExportDevicesParams) (*model.Export, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExportDevices")
+	}
+
+	var r0 *model.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDevicesParams) (*model.Export, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExportDevicesParams) *model.Export); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ExportDevicesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewApp creates a new instance of App. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApp(t interface {
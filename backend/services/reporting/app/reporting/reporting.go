@@ -17,7 +17,9 @@ package reporting
 import (
 	"context"
 	"errors"
+	"io"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/mendersoftware/mender-server/pkg/log"
@@ -41,6 +43,17 @@ type App interface {
 		[]model.DeviceAggregation, error)
 	SearchDeployments(ctx context.Context, searchParams *model.DeploymentsSearchParams) (
 		[]model.Deployment, int, error)
+	ExportDevices(ctx context.Context, params *model.ExportDevicesParams, w io.Writer) (
+		int, error)
+	ExportDeployments(ctx context.Context, params *model.ExportDeploymentsParams, w io.Writer) (
+		int, error)
+	StartExportDevices(ctx context.Context, params *model.ExportDevicesParams) (
+		*model.Export, error)
+	StartExportDeployments(ctx context.Context, params *model.ExportDeploymentsParams) (
+		*model.Export, error)
+	GetExport(ctx context.Context, tenantID, id string) (*model.Export, error)
+	DownloadExport(ctx context.Context, export *model.Export, w io.Writer) error
+	DeleteExpiredExports(ctx context.Context) error
 }
 
 type app struct {
@@ -132,10 +145,15 @@ func (a *app) storeToDeviceAggregations(
 ) ([]model.DeviceAggregation, error) {
 	aggs := []model.DeviceAggregation{}
 	for name, aggregationS := range aggregationsS {
-		if _, ok := aggregationS.(map[string]interface{}); !ok {
+		aggregationM, ok := aggregationS.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if agg, ok := storeToMetricAggregation(name, aggregationM); ok {
+			aggs = append(aggs, agg)
 			continue
 		}
-		bucketsS, ok := aggregationS.(map[string]interface{})["buckets"].([]interface{})
+		bucketsS, ok := aggregationM["buckets"].([]interface{})
 		if !ok {
 			continue
 		}
@@ -145,7 +163,7 @@ func (a *app) storeToDeviceAggregations(
 			if !ok {
 				return nil, errors.New("can't process store bucket item")
 			}
-			key, ok := bucketMap["key"].(string)
+			key, ok := storeToBucketKey(bucketMap)
 			if !ok {
 				return nil, errors.New("can't process store key attribute")
 			}
@@ -165,7 +183,7 @@ func (a *app) storeToDeviceAggregations(
 		}
 
 		otherCount := 0
-		if count, ok := aggregationS.(map[string]interface{})["sum_other_doc_count"].(float64); ok {
+		if count, ok := aggregationM["sum_other_doc_count"].(float64); ok {
 			otherCount = int(count)
 		}
 
@@ -178,19 +196,84 @@ func (a *app) storeToDeviceAggregations(
 	return aggs, nil
 }
 
+// storeToBucketKey returns the key of a bucket; date histogram buckets
+// are keyed by the formatted date.
+func storeToBucketKey(bucketMap map[string]interface{}) (string, bool) {
+	if key, ok := bucketMap["key_as_string"].(string); ok {
+		return key, true
+	}
+	switch key := bucketMap["key"].(type) {
+	case string:
+		return key, true
+	case float64:
+		return strconv.FormatFloat(key, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// storeToMetricAggregation translates the result of a metric aggregation,
+// which holds a single value or the values by percent.
+func storeToMetricAggregation(
+	name string, aggregationM map[string]interface{},
+) (model.DeviceAggregation, bool) {
+	agg := model.DeviceAggregation{
+		Name:  name,
+		Items: []model.DeviceAggregationItem{},
+	}
+	if value, ok := aggregationM["value"]; ok {
+		if v, ok := value.(float64); ok {
+			agg.Value = &v
+		}
+		return agg, true
+	}
+	if values, ok := aggregationM["values"].(map[string]interface{}); ok {
+		agg.Values = make(map[string]float64, len(values))
+		for percent, value := range values {
+			if v, ok := value.(float64); ok {
+				agg.Values[percent] = v
+			}
+		}
+		return agg, true
+	}
+	return agg, false
+}
+
 // SearchDevices searches device data
 func (app *app) SearchDevices(
 	ctx context.Context,
 	searchParams *model.SearchParams,
 ) ([]inventory.Device, int, error) {
-	if err := app.mapSearchParams(ctx, searchParams); err != nil {
+	query, err := app.buildDevicesQuery(ctx, searchParams)
+	if err != nil {
 		return nil, 0, err
 	}
-	query, err := model.BuildQuery(*searchParams)
+
+	esRes, err := app.store.SearchDevices(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, total, err := app.storeToInventoryDevs(ctx, searchParams.TenantID, esRes)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	return res, total, err
+}
+
+// buildDevicesQuery maps the search parameters and builds the devices query
+func (app *app) buildDevicesQuery(
+	ctx context.Context,
+	searchParams *model.SearchParams,
+) (model.Query, error) {
+	if err := app.mapSearchParams(ctx, searchParams); err != nil {
+		return nil, err
+	}
+	query, err := model.BuildQuery(*searchParams)
+	if err != nil {
+		return nil, err
+	}
+
 	if searchParams.TenantID != "" {
 		query = query.Must(model.M{
 			"term": model.M{
@@ -207,17 +290,7 @@ func (app *app) SearchDevices(
 		})
 	}
 
-	esRes, err := app.store.SearchDevices(ctx, query)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	res, total, err := app.storeToInventoryDevs(ctx, searchParams.TenantID, esRes)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return res, total, err
+	return query, nil
 }
 
 func (app *app) mapAggregations(ctx context.Context, tenantID string,
@@ -75,11 +75,31 @@ func (app *app) SearchDeployments(
 	ctx context.Context,
 	searchParams *model.DeploymentsSearchParams,
 ) ([]model.Deployment, int, error) {
-	query, err := model.BuildDeploymentsQuery(*searchParams)
+	query, err := buildDeploymentsQuery(searchParams)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	esRes, err := app.store.SearchDeployments(ctx, query)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	res, total, err := app.storeToDeployments(ctx, searchParams.TenantID, esRes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, total, err
+}
+
+// buildDeploymentsQuery builds the deployments query
+func buildDeploymentsQuery(searchParams *model.DeploymentsSearchParams) (model.Query, error) {
+	query, err := model.BuildDeploymentsQuery(*searchParams)
+	if err != nil {
+		return nil, err
+	}
+
 	if searchParams.TenantID != "" {
 		query = query.Must(model.M{
 			"term": model.M{
@@ -104,17 +124,7 @@ func (app *app) SearchDeployments(
 		})
 	}
 
-	esRes, err := app.store.SearchDeployments(ctx, query)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	res, total, err := app.storeToDeployments(ctx, searchParams.TenantID, esRes)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return res, total, err
+	return query, nil
 }
 
 // storeToInventoryDevs translates ES results directly to inventory devices
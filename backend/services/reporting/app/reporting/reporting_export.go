@@ -0,0 +1,344 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package reporting
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/reporting/model"
+	"github.com/mendersoftware/mender-server/services/reporting/store"
+)
+
+// exportPageSize is the number of items retrieved from the store at once
+const exportPageSize = 1000
+
+var ErrExportNotFound = store.ErrExportNotFound
+
+// ExportDevices writes all the devices matching the parameters to w,
+// returning the number of exported devices
+func (app *app) ExportDevices(
+	ctx context.Context,
+	params *model.ExportDevicesParams,
+	w io.Writer,
+) (int, error) {
+	columns := make([]string, 0, len(params.Attributes)+1)
+	columns = append(columns, model.FieldNameID)
+	for _, attr := range params.Attributes {
+		columns = append(columns, attr.Scope+":"+attr.Attribute)
+	}
+	enc, err := newExportEncoder(w, params.Format, columns)
+	if err != nil {
+		return 0, err
+	}
+
+	searchParams := params.SearchParams
+	searchParams.Page = 1
+	searchParams.PerPage = exportPageSize
+	query, err := app.buildDevicesQuery(ctx, &searchParams)
+	if err != nil {
+		return 0, err
+	}
+	// search_after requires a unique tiebreaker
+	query = query.WithSort(model.M{
+		model.FieldNameID: model.M{"order": model.SortOrderAsc},
+	})
+
+	count := 0
+	err = app.store.ExportDevices(ctx, query, func(hits []interface{}) error {
+		for _, hit := range hits {
+			dev, err := app.storeToInventoryDev(ctx, params.TenantID, hit)
+			if err != nil {
+				return err
+			}
+			attrs := make(map[string]interface{}, len(dev.Attributes))
+			for _, attr := range dev.Attributes {
+				attrs[attr.Scope+":"+attr.Name] = attr.Value
+			}
+			err = enc.Encode(dev, func(column int) interface{} {
+				if column == 0 {
+					return string(dev.ID)
+				}
+				return attrs[columns[column]]
+			})
+			if err != nil {
+				return err
+			}
+			count++
+		}
+		return enc.Flush()
+	})
+	if err == nil {
+		// the CSV header is still buffered if nothing matched
+		err = enc.Flush()
+	}
+	return count, err
+}
+
+// ExportDeployments writes all the deployments matching the parameters to w,
+// returning the number of exported deployments
+func (app *app) ExportDeployments(
+	ctx context.Context,
+	params *model.ExportDeploymentsParams,
+	w io.Writer,
+) (int, error) {
+	columns := make([]string, 0, len(params.Attributes)+1)
+	columns = append(columns, model.FieldNameID)
+	for _, attr := range params.Attributes {
+		columns = append(columns, attr.Attribute)
+	}
+	enc, err := newExportEncoder(w, params.Format, columns)
+	if err != nil {
+		return 0, err
+	}
+
+	searchParams := params.DeploymentsSearchParams
+	searchParams.Page = 1
+	searchParams.PerPage = exportPageSize
+	query, err := buildDeploymentsQuery(&searchParams)
+	if err != nil {
+		return 0, err
+	}
+	// search_after requires a unique tiebreaker
+	query = query.WithSort(model.M{
+		model.FieldNameID: model.M{"order": model.SortOrderAsc},
+	})
+
+	count := 0
+	err = app.store.ExportDeployments(ctx, query, func(hits []interface{}) error {
+		for _, hit := range hits {
+			depl, err := storeToDeploymentFields(hit)
+			if err != nil {
+				return err
+			}
+			err = enc.Encode(depl, func(column int) interface{} {
+				return depl[columns[column]]
+			})
+			if err != nil {
+				return err
+			}
+			count++
+		}
+		return enc.Flush()
+	})
+	if err == nil {
+		// the CSV header is still buffered if nothing matched
+		err = enc.Flush()
+	}
+	return count, err
+}
+
+// storeToDeploymentFields returns the fields of a deployment hit, unwrapping
+// the single values returned as arrays when the query selects the fields
+func storeToDeploymentFields(storeRes interface{}) (map[string]interface{}, error) {
+	resM, ok := storeRes.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("can't process individual hit")
+	}
+
+	sourceM, ok := resM["_source"].(map[string]interface{})
+	if ok {
+		return sourceM, nil
+	}
+	sourceM, ok = resM["fields"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("can't process hit's '_source' nor 'fields'")
+	}
+	for k, v := range sourceM {
+		if vArray, ok := v.([]interface{}); ok && len(vArray) == 1 {
+			sourceM[k] = vArray[0]
+		}
+	}
+	return sourceM, nil
+}
+
+// StartExportDevices creates an export job for the devices matching the
+// parameters, which runs in the background
+func (app *app) StartExportDevices(
+	ctx context.Context,
+	params *model.ExportDevicesParams,
+) (*model.Export, error) {
+	export := model.NewExport(params.TenantID, model.ExportTypeDevices, params.Format)
+	err := app.startExport(ctx, export, func(ctx context.Context, w io.Writer) (int, error) {
+		return app.ExportDevices(ctx, params, w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// StartExportDeployments creates an export job for the deployments matching
+// the parameters, which runs in the background
+func (app *app) StartExportDeployments(
+	ctx context.Context,
+	params *model.ExportDeploymentsParams,
+) (*model.Export, error) {
+	export := model.NewExport(params.TenantID, model.ExportTypeDeployments, params.Format)
+	err := app.startExport(ctx, export, func(ctx context.Context, w io.Writer) (int, error) {
+		return app.ExportDeployments(ctx, params, w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+type exportFunc func(ctx context.Context, w io.Writer) (int, error)
+
+func (app *app) startExport(ctx context.Context, export *model.Export, fn exportFunc) error {
+	if err := app.ds.InsertExport(ctx, export); err != nil {
+		return err
+	}
+	// the export outlives the request, keeping its identity and logger
+	go app.runExport(context.WithoutCancel(ctx), export, fn)
+	return nil
+}
+
+// runExport streams the output of the export to the data store and updates
+// the status of the export job when done
+func (app *app) runExport(ctx context.Context, export *model.Export, fn exportFunc) {
+	l := log.FromContext(ctx)
+
+	pr, pw := io.Pipe()
+	countC := make(chan int, 1)
+	go func() {
+		count, err := fn(ctx, pw)
+		_ = pw.CloseWithError(err)
+		countC <- count
+	}()
+	err := app.ds.UploadExport(ctx, export.ID, pr)
+	_ = pr.CloseWithError(err)
+	export.Count = <-countC
+
+	now := time.Now().UTC()
+	export.FinishedTs = &now
+	export.ExpiresTs = now.Add(model.ExportExpiration)
+	if err != nil {
+		l.Errorf("export %s failed: %s", export.ID, err)
+		export.Status = model.ExportStatusFailed
+		export.Error = err.Error()
+	} else {
+		export.Status = model.ExportStatusDone
+	}
+	if err := app.ds.UpdateExport(ctx, export); err != nil {
+		l.Errorf("failed to update the export %s: %s", export.ID, err)
+	}
+}
+
+// GetExport returns the export job, unless it expired
+func (app *app) GetExport(ctx context.Context, tenantID, id string) (*model.Export, error) {
+	export, err := app.ds.GetExport(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	} else if export.Expired(time.Now()) {
+		// the TTL index removes the expired jobs with a delay
+		return nil, ErrExportNotFound
+	}
+	return export, nil
+}
+
+// DeleteExpiredExports deletes the output of the expired export jobs
+func (app *app) DeleteExpiredExports(ctx context.Context) error {
+	deleted, err := app.ds.DeleteExpiredExports(ctx, time.Now())
+	if deleted > 0 {
+		log.FromContext(ctx).Infof("deleted %d expired exports", deleted)
+	}
+	return err
+}
+
+// DownloadExport writes the output of the export job to w
+func (app *app) DownloadExport(ctx context.Context, export *model.Export, w io.Writer) error {
+	return app.ds.DownloadExport(ctx, export.ID, w)
+}
+
+// exportEncoder writes the exported items in the requested format
+type exportEncoder struct {
+	columns []string
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+func newExportEncoder(
+	w io.Writer,
+	format model.ExportFormat,
+	columns []string,
+) (*exportEncoder, error) {
+	enc := &exportEncoder{
+		columns: columns,
+	}
+	if format != model.ExportFormatCSV {
+		enc.json = json.NewEncoder(w)
+		return enc, nil
+	}
+	enc.csv = csv.NewWriter(w)
+	if err := enc.csv.Write(columns); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+// Encode writes the item as a JSON line or, for CSV, the value of
+// each column
+func (e *exportEncoder) Encode(item interface{}, value func(column int) interface{}) error {
+	if e.csv == nil {
+		return e.json.Encode(item)
+	}
+	record := make([]string, len(e.columns))
+	for i := range record {
+		record[i] = formatExportValue(value(i))
+	}
+	return e.csv.Write(record)
+}
+
+func (e *exportEncoder) Flush() error {
+	if e.csv == nil {
+		return nil
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+// formatExportValue formats a value for CSV, joining the array values
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []interface{}:
+		values := make([]string, len(v))
+		for i := range v {
+			values[i] = formatExportValue(v[i])
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,532 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/reporting/model"
+	"github.com/mendersoftware/mender-server/services/reporting/store"
+	mstore "github.com/mendersoftware/mender-server/services/reporting/store/mocks"
+)
+
+// exportPages returns a store export implementation calling fn with pages
+func exportPages(pages ...[]interface{}) func(
+	context.Context, model.Query, store.ExportFunc) error {
+	return func(_ context.Context, _ model.Query, fn store.ExportFunc) error {
+		for _, page := range pages {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// uploadDataStore replaces the mocked upload, which would otherwise format
+// the reader concurrently with the export writing to it
+type uploadDataStore struct {
+	*mstore.DataStore
+	upload func(ctx context.Context, id string, r io.Reader) error
+}
+
+func (ds *uploadDataStore) UploadExport(ctx context.Context, id string, r io.Reader) error {
+	return ds.upload(ctx, id, r)
+}
+
+func TestExportDevices(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		Params       *model.ExportDevicesParams
+		MappedParams *model.SearchParams
+		Pages        [][]interface{}
+		StoreErr     error
+
+		Output string
+		Count  int
+		Error  error
+	}
+	testCases := []testCase{{
+		Name: "ok, csv",
+
+		Params: &model.ExportDevicesParams{
+			SearchParams: model.SearchParams{
+				Attributes: []model.SelectAttribute{{
+					Attribute: "foo",
+					Scope:     "inventory",
+				}, {
+					Attribute: "bar",
+					Scope:     "inventory",
+				}},
+				Filters: []model.FilterPredicate{{
+					Attribute: "foo",
+					Value:     "bar",
+					Scope:     "inventory",
+					Type:      "$eq",
+				}},
+			},
+			Format: model.ExportFormatCSV,
+		},
+		MappedParams: &model.SearchParams{
+			Page:    1,
+			PerPage: exportPageSize,
+			// unknown attributes are not mapped
+			Attributes: []model.SelectAttribute{{
+				Attribute: "attribute1",
+				Scope:     "inventory",
+			}},
+			Filters: []model.FilterPredicate{{
+				Attribute: "attribute1",
+				Value:     "bar",
+				Scope:     "inventory",
+				Type:      "$eq",
+			}},
+		},
+		Pages: [][]interface{}{{
+			map[string]interface{}{"fields": map[string]interface{}{
+				"id": []interface{}{"1"},
+				model.ToAttr("inventory", "attribute1", model.TypeStr): []interface{}{
+					"bar", "baz",
+				},
+			}},
+		}, {
+			map[string]interface{}{"fields": map[string]interface{}{
+				"id": []interface{}{"2"},
+				model.ToAttr("inventory", "attribute1", model.TypeNum): []interface{}{1.5},
+			}},
+		}},
+
+		Output: "id,inventory:foo,inventory:bar\n" +
+			"1,\"bar,baz\",\n" +
+			"2,1.5,\n",
+		Count: 2,
+	}, {
+		Name: "ok, ndjson",
+
+		Params: &model.ExportDevicesParams{
+			Format: model.ExportFormatNDJSON,
+		},
+		MappedParams: &model.SearchParams{
+			Page:    1,
+			PerPage: exportPageSize,
+		},
+		Pages: [][]interface{}{{
+			map[string]interface{}{"_source": map[string]interface{}{
+				"id": "1",
+				model.ToAttr("inventory", "attribute1", model.TypeStr): "bar",
+			}},
+			map[string]interface{}{"_source": map[string]interface{}{
+				"id": "2",
+			}},
+		}},
+
+		Output: `{"id":"1","attributes":[{"name":"foo","value":"bar","scope":"inventory"}],` +
+			`"created_ts":"0001-01-01T00:00:00Z","updated_ts":"0001-01-01T00:00:00Z"}` + "\n" +
+			`{"id":"2","created_ts":"0001-01-01T00:00:00Z",` +
+			`"updated_ts":"0001-01-01T00:00:00Z"}` + "\n",
+		Count: 2,
+	}, {
+		Name: "ok, csv without results",
+
+		Params: &model.ExportDevicesParams{
+			SearchParams: model.SearchParams{
+				Attributes: []model.SelectAttribute{{
+					Attribute: "bar",
+					Scope:     "inventory",
+				}},
+			},
+			Format: model.ExportFormatCSV,
+		},
+		MappedParams: &model.SearchParams{
+			Page:    1,
+			PerPage: exportPageSize,
+		},
+
+		Output: "id,inventory:bar\n",
+	}, {
+		Name: "ko, store error",
+
+		Params: &model.ExportDevicesParams{
+			Format: model.ExportFormatNDJSON,
+		},
+		MappedParams: &model.SearchParams{
+			Page:    1,
+			PerPage: exportPageSize,
+		},
+		StoreErr: errors.New("store error"),
+
+		Error: errors.New("store error"),
+	}, {
+		Name: "ko, malformed hit",
+
+		Params: &model.ExportDevicesParams{
+			Format: model.ExportFormatNDJSON,
+		},
+		MappedParams: &model.SearchParams{
+			Page:    1,
+			PerPage: exportPageSize,
+		},
+		Pages: [][]interface{}{{"foo"}},
+
+		Error: errors.New("can't process individual hit"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			q, _ := model.BuildQuery(*tc.MappedParams)
+			q = q.WithSort(model.M{"id": model.M{"order": "asc"}})
+			st := new(mstore.Store)
+			defer st.AssertExpectations(t)
+			if tc.StoreErr != nil {
+				st.On("ExportDevices", contextMatcher, q, mock.Anything).
+					Return(tc.StoreErr)
+			} else {
+				st.On("ExportDevices", contextMatcher, q, mock.Anything).
+					Return(exportPages(tc.Pages...))
+			}
+
+			ds := &mstore.DataStore{}
+			mapping := &model.Mapping{Inventory: []string{"inventory/foo"}}
+			ds.On("UpdateAndGetMapping", contextMatcher, "", mock.Anything).
+				Return(mapping, nil).Maybe()
+			ds.On("GetMapping", contextMatcher, "").
+				Return(mapping, nil).Maybe()
+
+			var out bytes.Buffer
+			app := NewApp(st, ds)
+			count, err := app.ExportDevices(context.Background(), tc.Params, &out)
+			if tc.Error != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.Error.Error(), err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Count, count)
+				assert.Equal(t, tc.Output, out.String())
+			}
+		})
+	}
+}
+
+func TestExportDeployments(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		Params   *model.ExportDeploymentsParams
+		Pages    [][]interface{}
+		StoreErr error
+
+		Output string
+		Count  int
+		Error  error
+	}
+	testCases := []testCase{{
+		Name: "ok, csv",
+
+		Params: &model.ExportDeploymentsParams{
+			DeploymentsSearchParams: model.DeploymentsSearchParams{
+				Attributes: []model.DeploymentsSelectAttribute{{
+					Attribute: "device_status",
+				}, {
+					Attribute: "deployment_groups",
+				}},
+			},
+			Format: model.ExportFormatCSV,
+		},
+		Pages: [][]interface{}{{
+			map[string]interface{}{"fields": map[string]interface{}{
+				"id":                []interface{}{"1"},
+				"device_status":     []interface{}{"success"},
+				"deployment_groups": []interface{}{"foo", "bar"},
+			}},
+			map[string]interface{}{"fields": map[string]interface{}{
+				"id":            []interface{}{"2"},
+				"device_status": []interface{}{"failure"},
+			}},
+		}},
+
+		Output: "id,device_status,deployment_groups\n" +
+			"1,success,\"foo,bar\"\n" +
+			"2,failure,\n",
+		Count: 2,
+	}, {
+		Name: "ok, ndjson",
+
+		Params: &model.ExportDeploymentsParams{
+			Format: model.ExportFormatNDJSON,
+		},
+		Pages: [][]interface{}{{
+			map[string]interface{}{"_source": map[string]interface{}{
+				"id":            "1",
+				"device_status": "success",
+			}},
+		}},
+
+		Output: `{"device_status":"success","id":"1"}` + "\n",
+		Count:  1,
+	}, {
+		Name: "ko, store error",
+
+		Params: &model.ExportDeploymentsParams{
+			Format: model.ExportFormatNDJSON,
+		},
+		StoreErr: errors.New("store error"),
+
+		Error: errors.New("store error"),
+	}, {
+		Name: "ko, malformed hit",
+
+		Params: &model.ExportDeploymentsParams{
+			Format: model.ExportFormatNDJSON,
+		},
+		Pages: [][]interface{}{{map[string]interface{}{}}},
+
+		Error: errors.New("can't process hit's '_source' nor 'fields'"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			params := tc.Params.DeploymentsSearchParams
+			params.Page = 1
+			params.PerPage = exportPageSize
+			q, _ := model.BuildDeploymentsQuery(params)
+			q = q.WithSort(model.M{"id": model.M{"order": "asc"}})
+			st := new(mstore.Store)
+			defer st.AssertExpectations(t)
+			if tc.StoreErr != nil {
+				st.On("ExportDeployments", contextMatcher, q, mock.Anything).
+					Return(tc.StoreErr)
+			} else {
+				st.On("ExportDeployments", contextMatcher, q, mock.Anything).
+					Return(exportPages(tc.Pages...))
+			}
+
+			var out bytes.Buffer
+			app := NewApp(st, &mstore.DataStore{})
+			count, err := app.ExportDeployments(context.Background(), tc.Params, &out)
+			if tc.Error != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.Error.Error(), err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Count, count)
+				assert.Equal(t, tc.Output, out.String())
+			}
+		})
+	}
+}
+
+func TestStartExportDeployments(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		Name string
+
+		Pages     [][]interface{}
+		StoreErr  error
+		InsertErr error
+		UploadErr error
+
+		Output string
+		Status model.ExportStatus
+		Count  int
+		Error  error
+	}
+	testCases := []testCase{{
+		Name: "ok",
+
+		Pages: [][]interface{}{{
+			map[string]interface{}{"_source": map[string]interface{}{"id": "1"}},
+			map[string]interface{}{"_source": map[string]interface{}{"id": "2"}},
+		}},
+
+		Output: `{"id":"1"}` + "\n" + `{"id":"2"}` + "\n",
+		Status: model.ExportStatusDone,
+		Count:  2,
+	}, {
+		Name: "ok, export failed",
+
+		StoreErr: errors.New("store error"),
+
+		Status: model.ExportStatusFailed,
+	}, {
+		Name: "ok, upload failed",
+
+		Pages: [][]interface{}{{
+			map[string]interface{}{"_source": map[string]interface{}{"id": "1"}},
+		}},
+		UploadErr: errors.New("upload error"),
+
+		Status: model.ExportStatusFailed,
+	}, {
+		Name: "ko, insert error",
+
+		InsertErr: errors.New("insert error"),
+
+		Error: errors.New("insert error"),
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			params := &model.ExportDeploymentsParams{
+				DeploymentsSearchParams: model.DeploymentsSearchParams{
+					TenantID: "tenant",
+				},
+				Format: model.ExportFormatNDJSON,
+			}
+
+			st := new(mstore.Store)
+			defer st.AssertExpectations(t)
+			ds := &mstore.DataStore{}
+			defer ds.AssertExpectations(t)
+
+			ds.On("InsertExport", contextMatcher, mock.MatchedBy(
+				func(export *model.Export) bool {
+					return export.TenantID == "tenant" &&
+						export.Type == model.ExportTypeDeployments &&
+						export.Format == model.ExportFormatNDJSON &&
+						export.Status == model.ExportStatusPending
+				})).Return(tc.InsertErr)
+
+			done := make(chan struct{})
+			upload := func(_ context.Context, _ string, r io.Reader) error {
+				if tc.UploadErr != nil {
+					return tc.UploadErr
+				}
+				b, err := io.ReadAll(r)
+				if err == nil {
+					assert.Equal(t, tc.Output, string(b))
+				}
+				return err
+			}
+			if tc.InsertErr == nil {
+				if tc.StoreErr != nil {
+					st.On("ExportDeployments", contextMatcher, mock.Anything, mock.Anything).
+						Return(tc.StoreErr)
+				} else {
+					st.On("ExportDeployments", contextMatcher, mock.Anything, mock.Anything).
+						Return(exportPages(tc.Pages...))
+				}
+				ds.On("UpdateExport", contextMatcher, mock.MatchedBy(
+					func(export *model.Export) bool {
+						return export.Status == tc.Status &&
+							export.Count == tc.Count &&
+							export.FinishedTs != nil &&
+							export.ExpiresTs.Equal(
+								export.FinishedTs.Add(model.ExportExpiration))
+					})).
+					Run(func(mock.Arguments) { close(done) }).
+					Return(nil)
+			} else {
+				close(done)
+			}
+
+			app := NewApp(st, &uploadDataStore{DataStore: ds, upload: upload})
+			export, err := app.StartExportDeployments(context.Background(), params)
+			<-done
+			if tc.Error != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.Error.Error(), err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, export.ID)
+			}
+		})
+	}
+}
+
+func TestGetExport(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	testCases := []struct {
+		Name string
+
+		Export   *model.Export
+		StoreErr error
+
+		Error error
+	}{{
+		Name: "ok",
+
+		Export: &model.Export{ID: "export", ExpiresTs: now.Add(time.Hour)},
+	}, {
+		Name: "ko, expired",
+
+		Export: &model.Export{ID: "export", ExpiresTs: now.Add(-time.Minute)},
+
+		Error: ErrExportNotFound,
+	}, {
+		Name: "ko, not found",
+
+		StoreErr: ErrExportNotFound,
+
+		Error: ErrExportNotFound,
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ds := &mstore.DataStore{}
+			defer ds.AssertExpectations(t)
+			ds.On("GetExport", contextMatcher, "tenant", "export").
+				Return(tc.Export, tc.StoreErr)
+
+			app := NewApp(nil, ds)
+			export, err := app.GetExport(context.Background(), "tenant", "export")
+			if tc.Error != nil {
+				assert.ErrorIs(t, err, tc.Error)
+				assert.Nil(t, export)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Export, export)
+			}
+		})
+	}
+}
+
+func TestDeleteExpiredExports(t *testing.T) {
+	t.Parallel()
+	ds := &mstore.DataStore{}
+	defer ds.AssertExpectations(t)
+	ds.On("DeleteExpiredExports", contextMatcher, mock.AnythingOfType("time.Time")).
+		Return(2, nil).Once()
+	ds.On("DeleteExpiredExports", contextMatcher, mock.AnythingOfType("time.Time")).
+		Return(0, errors.New("store error")).Once()
+
+	app := NewApp(nil, ds)
+	err := app.DeleteExpiredExports(context.Background())
+	assert.NoError(t, err)
+	err = app.DeleteExpiredExports(context.Background())
+	assert.EqualError(t, err, "store error")
+}
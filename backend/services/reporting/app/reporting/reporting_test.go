@@ -330,6 +330,216 @@ func TestAggregateDevices(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		Name: "ok, date histogram with metric subaggregation",
+
+		Params: &model.AggregateParams{
+			Filters: []model.FilterPredicate{{
+				Attribute: "foo",
+				Value:     "bar",
+				Scope:     "inventory",
+				Type:      "$eq",
+			}},
+			Aggregations: []model.AggregationTerm{
+				{
+					Name:      "per_hour",
+					Attribute: model.FieldNameCheckIn,
+					Scope:     model.ScopeSystem,
+					AggregationOptions: model.AggregationOptions{
+						Type:     model.AggregationTypeDateHistogram,
+						Interval: "hour",
+					},
+					Aggregations: []model.AggregationTerm{
+						{
+							Name:      "avg_mem",
+							Attribute: "attr",
+							Scope:     "inventory",
+							AggregationOptions: model.AggregationOptions{
+								Type: model.AggregationTypeAvg,
+							},
+						},
+					},
+				},
+			},
+			TenantID: tenantID,
+		},
+		MappedParams: &model.SearchParams{
+			Filters: []model.FilterPredicate{{
+				Attribute: "attribute1",
+				Value:     "bar",
+				Scope:     "inventory",
+				Type:      "$eq",
+			}},
+		},
+		MappedAggregatedParams: []model.AggregationTerm{
+			{
+				Name:      "per_hour",
+				Attribute: model.FieldNameCheckIn,
+				Scope:     model.ScopeSystem,
+				AggregationOptions: model.AggregationOptions{
+					Type:     model.AggregationTypeDateHistogram,
+					Interval: "hour",
+				},
+				Aggregations: []model.AggregationTerm{
+					{
+						Name:      "avg_mem",
+						Attribute: "attribute2",
+						Scope:     "inventory",
+						AggregationOptions: model.AggregationOptions{
+							Type: model.AggregationTypeAvg,
+						},
+					},
+				},
+			},
+		},
+		Store: func(t *testing.T, self testCase) *mstore.Store {
+			store := new(mstore.Store)
+			q, _ := model.BuildQuery(*self.MappedParams)
+			q.Must(model.M{
+				"term": model.M{
+					model.FieldNameTenantID: tenantID,
+				},
+			})
+			aggrs, _ := model.BuildAggregations(self.MappedAggregatedParams)
+			q = q.WithSize(0).With(map[string]interface{}{
+				"aggs": aggrs,
+			})
+			store.On("AggregateDevices", contextMatcher, q).
+				Return(model.M{
+					"aggregations": map[string]interface{}{
+						"per_hour": map[string]interface{}{
+							"buckets": []interface{}{
+								map[string]interface{}{
+									"key_as_string": "2023-01-01T00:00:00.000Z",
+									"key":           float64(1672531200000),
+									"doc_count":     float64(3),
+									"avg_mem": map[string]interface{}{
+										"value": float64(512),
+									},
+								},
+								map[string]interface{}{
+									"key_as_string": "2023-01-01T01:00:00.000Z",
+									"key":           float64(1672534800000),
+									"doc_count":     float64(0),
+									"avg_mem": map[string]interface{}{
+										"value": nil,
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+			return store
+		},
+		Mapping: model.Mapping{
+			TenantID:  "",
+			Inventory: []string{"inventory/foo", "inventory/attr"},
+		},
+		Result: []model.DeviceAggregation{
+			{
+				Name: "per_hour",
+				Items: []model.DeviceAggregationItem{
+					{
+						Key:   "2023-01-01T00:00:00.000Z",
+						Count: 3,
+						Aggregations: []model.DeviceAggregation{{
+							Name:  "avg_mem",
+							Items: []model.DeviceAggregationItem{},
+							Value: func() *float64 { v := 512.0; return &v }(),
+						}},
+					},
+					{
+						Key:   "2023-01-01T01:00:00.000Z",
+						Count: 0,
+						Aggregations: []model.DeviceAggregation{{
+							Name:  "avg_mem",
+							Items: []model.DeviceAggregationItem{},
+						}},
+					},
+				},
+			},
+		},
+	}, {
+		Name: "ok, percentiles",
+
+		Params: &model.AggregateParams{
+			Filters: []model.FilterPredicate{{
+				Attribute: "foo",
+				Value:     "bar",
+				Scope:     "inventory",
+				Type:      "$eq",
+			}},
+			Aggregations: []model.AggregationTerm{
+				{
+					Name:      "mem",
+					Attribute: "attr",
+					Scope:     "inventory",
+					AggregationOptions: model.AggregationOptions{
+						Type:     model.AggregationTypePercentiles,
+						Percents: []float64{50, 99},
+					},
+				},
+			},
+			TenantID: tenantID,
+		},
+		MappedParams: &model.SearchParams{
+			Filters: []model.FilterPredicate{{
+				Attribute: "attribute1",
+				Value:     "bar",
+				Scope:     "inventory",
+				Type:      "$eq",
+			}},
+		},
+		MappedAggregatedParams: []model.AggregationTerm{
+			{
+				Name:      "mem",
+				Attribute: "attribute2",
+				Scope:     "inventory",
+				AggregationOptions: model.AggregationOptions{
+					Type:     model.AggregationTypePercentiles,
+					Percents: []float64{50, 99},
+				},
+			},
+		},
+		Store: func(t *testing.T, self testCase) *mstore.Store {
+			store := new(mstore.Store)
+			q, _ := model.BuildQuery(*self.MappedParams)
+			q.Must(model.M{
+				"term": model.M{
+					model.FieldNameTenantID: tenantID,
+				},
+			})
+			aggrs, _ := model.BuildAggregations(self.MappedAggregatedParams)
+			q = q.WithSize(0).With(map[string]interface{}{
+				"aggs": aggrs,
+			})
+			store.On("AggregateDevices", contextMatcher, q).
+				Return(model.M{
+					"aggregations": map[string]interface{}{
+						"mem": map[string]interface{}{
+							"values": map[string]interface{}{
+								"50.0": float64(256),
+								"99.0": float64(1024),
+							},
+						},
+					},
+				}, nil)
+			return store
+		},
+		Mapping: model.Mapping{
+			TenantID:  "",
+			Inventory: []string{"inventory/foo", "inventory/attr"},
+		},
+		Result: []model.DeviceAggregation{
+			{
+				Name:  "mem",
+				Items: []model.DeviceAggregationItem{},
+				Values: map[string]float64{
+					"50.0": 256,
+					"99.0": 1024,
+				},
+			},
+		},
 	}}
 	for i := range testCases {
 		tc := testCases[i]
@@ -32,6 +32,10 @@ import (
 	"github.com/mendersoftware/mender-server/services/reporting/store"
 )
 
+// exportsCleanupInterval is the interval between the deletions of the
+// output of the expired export jobs
+const exportsCleanupInterval = time.Hour
+
 // InitAndRun initializes the server and runs it
 func InitAndRun(conf config.Reader, store store.Store, ds store.DataStore) error {
 	ctx := context.Background()
@@ -53,6 +57,8 @@ func InitAndRun(conf config.Reader, store store.Store, ds store.DataStore) error
 		}
 	}()
 
+	go deleteExpiredExports(ctx, reporting)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, unix.SIGINT, unix.SIGTERM)
 	<-quit
@@ -68,3 +74,21 @@ func InitAndRun(conf config.Reader, store store.Store, ds store.DataStore) error
 
 	return nil
 }
+
+// deleteExpiredExports periodically deletes the output of the expired
+// export jobs; the jobs themselves are removed by a TTL index
+func deleteExpiredExports(ctx context.Context, app reporting.App) {
+	l := log.FromContext(ctx)
+	ticker := time.NewTicker(exportsCleanupInterval)
+	defer ticker.Stop()
+	for {
+		if err := app.DeleteExpiredExports(ctx); err != nil {
+			l.Errorf("failed to delete the expired exports: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -15,6 +15,8 @@
 package model
 
 import (
+	"strings"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pkg/errors"
 )
@@ -23,8 +25,152 @@ const (
 	defaultAggregationLimit = 10
 	maxAggregationTerms     = 100
 	maxNestedAggregations   = 5
+	maxAggregationRanges    = 100
+	maxAggregationPercents  = 20
 )
 
+// AggregationType is the type of an aggregation.
+type AggregationType string
+
+const (
+	// AggregationTypeTerms groups the documents by the value of the
+	// attribute; the default.
+	AggregationTypeTerms AggregationType = "terms"
+	// AggregationTypeDateHistogram groups the documents by the calendar
+	// interval of a date attribute.
+	AggregationTypeDateHistogram AggregationType = "date_histogram"
+	// AggregationTypeRange groups the documents by ranges of a numeric
+	// attribute.
+	AggregationTypeRange AggregationType = "range"
+	// Metric aggregations over a numeric attribute.
+	AggregationTypeMin         AggregationType = "min"
+	AggregationTypeMax         AggregationType = "max"
+	AggregationTypeAvg         AggregationType = "avg"
+	AggregationTypePercentiles AggregationType = "percentiles"
+)
+
+var aggregationIntervals = []interface{}{
+	"minute", "hour", "day", "week", "month", "quarter", "year",
+}
+
+// IsMetric returns true if the aggregation computes a value instead of
+// bucketing the documents.
+func (typ AggregationType) IsMetric() bool {
+	switch typ {
+	case AggregationTypeMin,
+		AggregationTypeMax,
+		AggregationTypeAvg,
+		AggregationTypePercentiles:
+		return true
+	}
+	return false
+}
+
+// IsNumeric returns true if the aggregation requires a numeric attribute.
+func (typ AggregationType) IsNumeric() bool {
+	return typ == AggregationTypeRange || typ.IsMetric()
+}
+
+func (typ AggregationType) Validate() error {
+	return validation.In(
+		AggregationTypeTerms,
+		AggregationTypeDateHistogram,
+		AggregationTypeRange,
+		AggregationTypeMin,
+		AggregationTypeMax,
+		AggregationTypeAvg,
+		AggregationTypePercentiles,
+	).Validate(typ)
+}
+
+// AggregationRange is a bucket of a range aggregation; From is inclusive
+// and To is exclusive.
+type AggregationRange struct {
+	Key  string   `json:"key,omitempty"`
+	From *float64 `json:"from,omitempty"`
+	To   *float64 `json:"to,omitempty"`
+}
+
+var errAggregationRangeEmpty = errors.New("from or to must be set")
+
+func (r AggregationRange) Validate() error {
+	if r.From == nil && r.To == nil {
+		return errAggregationRangeEmpty
+	}
+	return nil
+}
+
+// AggregationOptions holds the options of the aggregation types other
+// than terms.
+type AggregationOptions struct {
+	// Type of the aggregation, defaults to terms.
+	Type AggregationType `json:"type,omitempty"`
+	// Interval is the calendar interval of a date histogram.
+	Interval string `json:"interval,omitempty"`
+	// Ranges are the buckets of a range aggregation.
+	Ranges []AggregationRange `json:"ranges,omitempty"`
+	// Percents are the percentiles to compute, defaults to
+	// 1, 5, 25, 50, 75, 95 and 99.
+	Percents []float64 `json:"percents,omitempty"`
+}
+
+func (o *AggregationOptions) fieldRules() []*validation.FieldRules {
+	return []*validation.FieldRules{
+		validation.Field(&o.Type),
+		validation.Field(&o.Interval,
+			validation.When(o.Type == AggregationTypeDateHistogram,
+				validation.Required,
+				validation.In(aggregationIntervals...),
+			).Else(validation.Empty),
+		),
+		validation.Field(&o.Ranges,
+			validation.When(o.Type == AggregationTypeRange,
+				validation.Required,
+				validation.Length(1, maxAggregationRanges),
+			).Else(validation.Empty),
+		),
+		validation.Field(&o.Percents,
+			validation.When(o.Type == AggregationTypePercentiles,
+				validation.Length(0, maxAggregationPercents),
+				validation.Each(validation.Min(0.0), validation.Max(100.0)),
+			).Else(validation.Empty),
+		),
+	}
+}
+
+func (o AggregationOptions) subAggregationsRule() validation.Rule {
+	return validation.When(o.Type.IsMetric(),
+		validation.Empty.Error("not supported by metric aggregations"))
+}
+
+// build returns the aggregation over the given field.
+func (o AggregationOptions) build(field string, limit int) map[string]interface{} {
+	params := map[string]interface{}{
+		"field": field,
+	}
+	typ := o.Type
+	switch typ {
+	case AggregationTypeDateHistogram:
+		params["calendar_interval"] = o.Interval
+	case AggregationTypeRange:
+		params["ranges"] = o.Ranges
+	case AggregationTypePercentiles:
+		if len(o.Percents) > 0 {
+			params["percents"] = o.Percents
+		}
+	case AggregationTypeMin, AggregationTypeMax, AggregationTypeAvg:
+	default:
+		typ = AggregationTypeTerms
+		if limit <= 0 {
+			limit = defaultAggregationLimit
+		}
+		params["size"] = limit
+	}
+	return map[string]interface{}{
+		string(typ): params,
+	}
+}
+
 type AggregateParams struct {
 	Aggregations         []AggregationTerm     `json:"aggregations"`
 	Filters              []FilterPredicate     `json:"filters"`
@@ -35,10 +181,11 @@ type AggregateParams struct {
 }
 
 type AggregationTerm struct {
-	Name         string            `json:"name"`
-	Attribute    string            `json:"attribute"`
-	Scope        string            `json:"scope"`
-	Limit        int               `json:"limit"`
+	Name      string `json:"name"`
+	Attribute string `json:"attribute"`
+	Scope     string `json:"scope"`
+	Limit     int    `json:"limit"`
+	AggregationOptions
 	Aggregations []AggregationTerm `json:"aggregations"`
 }
 
@@ -82,18 +229,57 @@ func (ap AggregateParams) Validate() error {
 	return nil
 }
 
+// errDateHistogramAttribute is returned for date histograms over attributes
+// which are not mapped as dates.
+var errDateHistogramAttribute = errors.New("date histograms are supported " +
+	"only by the check-in time and the system timestamp attributes")
+
+// hasDateField returns true if the attribute is mapped with a date sub-field
+// in the devices index: the check-in time and the system timestamps.
+func (f AggregationTerm) hasDateField() bool {
+	if f.Scope != ScopeSystem {
+		return false
+	}
+	return f.Attribute == FieldNameCheckIn ||
+		strings.HasSuffix(f.Attribute, timestampSuffix)
+}
+
 func (f AggregationTerm) Validate() error {
-	return validation.ValidateStruct(&f,
+	return validation.ValidateStruct(&f, append(
+		f.AggregationOptions.fieldRules(),
 		validation.Field(&f.Name, validation.Required),
-		validation.Field(&f.Attribute, validation.Required),
+		validation.Field(&f.Attribute, validation.Required,
+			validation.When(f.Type == AggregationTypeDateHistogram && !f.hasDateField(),
+				validation.By(func(interface{}) error {
+					return errDateHistogramAttribute
+				}),
+			)),
 		validation.Field(&f.Scope, validation.Required),
 		validation.Field(&f.Limit, validation.Min(0)),
-		validation.Field(&f.Aggregations, validation.When(
-			len(f.Aggregations) > 0,
-			validation.Length(0, maxAggregationTerms),
-			validation.By(checkMaxNestedAggregations),
-		)),
-	)
+		validation.Field(&f.Aggregations,
+			f.AggregationOptions.subAggregationsRule(),
+			validation.When(
+				len(f.Aggregations) > 0,
+				validation.Length(0, maxAggregationTerms),
+				validation.By(checkMaxNestedAggregations),
+			)),
+	)...)
+}
+
+// field returns the name of the field holding the attribute, depending
+// on the type of the aggregation.
+func (f AggregationTerm) field() string {
+	switch {
+	case f.Type == AggregationTypeDateHistogram:
+		if f.Scope == ScopeSystem && f.Attribute == FieldNameCheckIn {
+			return FieldNameCheckIn + "." + SubFieldDate
+		}
+		return ToAttr(f.Scope, f.Attribute, TypeStr) + "." + SubFieldDate
+	case f.Type.IsNumeric():
+		return ToAttr(f.Scope, f.Attribute, TypeNum)
+	default:
+		return ToAttr(f.Scope, f.Attribute, TypeStr)
+	}
 }
 
 type Aggregations map[string]interface{}
@@ -101,17 +287,7 @@ type Aggregations map[string]interface{}
 func BuildAggregations(terms []AggregationTerm) (*Aggregations, error) {
 	aggs := Aggregations{}
 	for _, term := range terms {
-		terms := map[string]interface{}{
-			"field": ToAttr(term.Scope, term.Attribute, TypeStr),
-		}
-		limit := term.Limit
-		if limit <= 0 {
-			limit = defaultAggregationLimit
-		}
-		terms["size"] = limit
-		agg := map[string]interface{}{
-			"terms": terms,
-		}
+		agg := term.build(term.field(), term.Limit)
 		if len(term.Aggregations) > 0 {
 			subaggs, err := BuildAggregations(term.Aggregations)
 			if err != nil {
@@ -128,6 +304,10 @@ type DeviceAggregation struct {
 	Name       string                  `json:"name"`
 	Items      []DeviceAggregationItem `json:"items"`
 	OtherCount int                     `json:"other_count"`
+	// Value is the result of the min, max and avg aggregations.
+	Value *float64 `json:"value,omitempty"`
+	// Values are the results of the percentiles aggregation by percent.
+	Values map[string]float64 `json:"values,omitempty"`
 }
 
 type DeviceAggregationItem struct {
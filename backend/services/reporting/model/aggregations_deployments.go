@@ -27,9 +27,10 @@ type AggregateDeploymentsParams struct {
 }
 
 type DeploymentsAggregationTerm struct {
-	Name         string                       `json:"name"`
-	Attribute    string                       `json:"attribute"`
-	Limit        int                          `json:"limit"`
+	Name      string `json:"name"`
+	Attribute string `json:"attribute"`
+	Limit     int    `json:"limit"`
+	AggregationOptions
 	Aggregations []DeploymentsAggregationTerm `json:"aggregations"`
 }
 
@@ -68,33 +69,39 @@ func (sp AggregateDeploymentsParams) Validate() error {
 	return nil
 }
 
+// deploymentsDateFields are the fields mapped as dates in the deployments
+// index.
+var deploymentsDateFields = []interface{}{
+	"deployment_created",
+	"device_created",
+	"device_finished",
+	"device_deleted",
+}
+
 func (f DeploymentsAggregationTerm) Validate() error {
-	return validation.ValidateStruct(&f,
+	return validation.ValidateStruct(&f, append(
+		f.AggregationOptions.fieldRules(),
 		validation.Field(&f.Name, validation.Required),
-		validation.Field(&f.Attribute, validation.Required),
+		validation.Field(&f.Attribute, validation.Required,
+			validation.When(f.Type == AggregationTypeDateHistogram,
+				validation.In(deploymentsDateFields...).
+					Error("date histograms are supported only by the date attributes"),
+			)),
 		validation.Field(&f.Limit, validation.Min(0)),
-		validation.Field(&f.Aggregations, validation.When(
-			len(f.Aggregations) > 0,
-			validation.Length(0, maxAggregationTerms),
-			validation.By(checkMaxNestedDeploymentsAggregations),
-		)),
-	)
+		validation.Field(&f.Aggregations,
+			f.AggregationOptions.subAggregationsRule(),
+			validation.When(
+				len(f.Aggregations) > 0,
+				validation.Length(0, maxAggregationTerms),
+				validation.By(checkMaxNestedDeploymentsAggregations),
+			)),
+	)...)
 }
 
 func BuildDeploymentsAggregations(terms []DeploymentsAggregationTerm) (*Aggregations, error) {
 	aggs := Aggregations{}
 	for _, term := range terms {
-		terms := map[string]interface{}{
-			"field": term.Attribute,
-		}
-		limit := term.Limit
-		if limit <= 0 {
-			limit = defaultAggregationLimit
-		}
-		terms["size"] = limit
-		agg := map[string]interface{}{
-			"terms": terms,
-		}
+		agg := term.build(term.Attribute, term.Limit)
 		if len(term.Aggregations) > 0 {
 			subaggs, err := BuildDeploymentsAggregations(term.Aggregations)
 			if err != nil {
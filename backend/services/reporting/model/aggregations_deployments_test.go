@@ -203,6 +203,36 @@ func TestAggregateDeploymentsParamsValidate(t *testing.T) {
 			},
 			err: errors.New("aggregations: (0: (aggregations: too many nested aggregations, limit is 5.).)."),
 		},
+		"ok, date histogram": {
+			params: AggregateDeploymentsParams{
+				Aggregations: []DeploymentsAggregationTerm{
+					{
+						Name:      "per_day",
+						Attribute: "device_finished",
+						AggregationOptions: AggregationOptions{
+							Type:     AggregationTypeDateHistogram,
+							Interval: "day",
+						},
+					},
+				},
+			},
+		},
+		"ko, date histogram over a keyword": {
+			params: AggregateDeploymentsParams{
+				Aggregations: []DeploymentsAggregationTerm{
+					{
+						Name:      "per_day",
+						Attribute: "device_status",
+						AggregationOptions: AggregationOptions{
+							Type:     AggregationTypeDateHistogram,
+							Interval: "day",
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (attribute: date histograms are " +
+				"supported only by the date attributes.).)."),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -286,6 +316,40 @@ func TestBuildDeploymentsAggregations(t *testing.T) {
 				},
 			},
 		},
+		"ok, failures per day by device type": {
+			terms: []DeploymentsAggregationTerm{
+				{
+					Name:      "per_day",
+					Attribute: "device_finished",
+					AggregationOptions: AggregationOptions{
+						Type:     AggregationTypeDateHistogram,
+						Interval: "day",
+					},
+					Aggregations: []DeploymentsAggregationTerm{
+						{
+							Name:      "device_type",
+							Attribute: "image_device_types",
+						},
+					},
+				},
+			},
+			res: &Aggregations{
+				"per_day": map[string]interface{}{
+					"date_histogram": map[string]interface{}{
+						"field":             "device_finished",
+						"calendar_interval": "day",
+					},
+					"aggs": &Aggregations{
+						"device_type": map[string]interface{}{
+							"terms": map[string]interface{}{
+								"field": "image_device_types",
+								"size":  defaultAggregationLimit,
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -221,6 +221,165 @@ func TestAggregateParamsValidate(t *testing.T) {
 			},
 			err: errors.New("aggregations: (0: (aggregations: too many nested aggregations, limit is 5.).)."),
 		},
+		"ok, date histogram with metrics": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "check_in",
+						Scope:     ScopeSystem,
+						Attribute: FieldNameCheckIn,
+						AggregationOptions: AggregationOptions{
+							Type:     AggregationTypeDateHistogram,
+							Interval: "hour",
+						},
+						Aggregations: []AggregationTerm{
+							{
+								Name:      "mem",
+								Scope:     ScopeInventory,
+								Attribute: "mem_total_kB",
+								AggregationOptions: AggregationOptions{
+									Type:     AggregationTypePercentiles,
+									Percents: []float64{50, 99.9},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"ko, invalid aggregation type": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "mem",
+						Scope:     ScopeInventory,
+						Attribute: "mem_total_kB",
+						AggregationOptions: AggregationOptions{
+							Type: "sum",
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (type: must be a valid value.).)."),
+		},
+		"ko, date histogram over an inventory attribute": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "installed",
+						Scope:     ScopeInventory,
+						Attribute: "installed_ts",
+						AggregationOptions: AggregationOptions{
+							Type:     AggregationTypeDateHistogram,
+							Interval: "day",
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (attribute: date histograms are supported " +
+				"only by the check-in time and the system timestamp attributes.).)."),
+		},
+		"ko, date histogram over a system attribute which is not a date": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "group",
+						Scope:     ScopeSystem,
+						Attribute: "group",
+						AggregationOptions: AggregationOptions{
+							Type:     AggregationTypeDateHistogram,
+							Interval: "day",
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (attribute: date histograms are supported " +
+				"only by the check-in time and the system timestamp attributes.).)."),
+		},
+		"ko, date histogram without interval": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "check_in",
+						Scope:     ScopeSystem,
+						Attribute: FieldNameCheckIn,
+						AggregationOptions: AggregationOptions{
+							Type: AggregationTypeDateHistogram,
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (interval: cannot be blank.).)."),
+		},
+		"ko, range without from and to": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "mem",
+						Scope:     ScopeInventory,
+						Attribute: "mem_total_kB",
+						AggregationOptions: AggregationOptions{
+							Type:   AggregationTypeRange,
+							Ranges: []AggregationRange{{Key: "all"}},
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (ranges: (0: from or to must be set.).).)."),
+		},
+		"ko, interval on terms aggregation": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "mac",
+						Scope:     ScopeIdentity,
+						Attribute: "mac",
+						AggregationOptions: AggregationOptions{
+							Interval: "day",
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (interval: must be blank.).)."),
+		},
+		"ko, metric with subaggregations": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "mem",
+						Scope:     ScopeInventory,
+						Attribute: "mem_total_kB",
+						AggregationOptions: AggregationOptions{
+							Type: AggregationTypeAvg,
+						},
+						Aggregations: []AggregationTerm{
+							{
+								Name:      "mac",
+								Scope:     ScopeIdentity,
+								Attribute: "mac",
+							},
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (aggregations: not supported by metric aggregations.).)."),
+		},
+		"ko, invalid percent": {
+			params: AggregateParams{
+				Aggregations: []AggregationTerm{
+					{
+						Name:      "mem",
+						Scope:     ScopeInventory,
+						Attribute: "mem_total_kB",
+						AggregationOptions: AggregationOptions{
+							Type:     AggregationTypePercentiles,
+							Percents: []float64{101},
+						},
+					},
+				},
+			},
+			err: errors.New("aggregations: (0: (percents: (0: must be no greater than 100.).).)."),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -308,6 +467,95 @@ func TestBuildAggregations(t *testing.T) {
 				},
 			},
 		},
+		"ok, date histogram": {
+			terms: []AggregationTerm{
+				{
+					Name:      "check_in",
+					Attribute: FieldNameCheckIn,
+					Scope:     ScopeSystem,
+					AggregationOptions: AggregationOptions{
+						Type:     AggregationTypeDateHistogram,
+						Interval: "hour",
+					},
+				},
+				{
+					Name:      "created",
+					Attribute: AttrNameCreatedAt,
+					Scope:     ScopeSystem,
+					AggregationOptions: AggregationOptions{
+						Type:     AggregationTypeDateHistogram,
+						Interval: "day",
+					},
+				},
+			},
+			res: &Aggregations{
+				"check_in": map[string]interface{}{
+					"date_histogram": map[string]interface{}{
+						"field":             FieldNameCheckIn + ".date",
+						"calendar_interval": "hour",
+					},
+				},
+				"created": map[string]interface{}{
+					"date_histogram": map[string]interface{}{
+						"field":             "system_created_ts_str.date",
+						"calendar_interval": "day",
+					},
+				},
+			},
+		},
+		"ok, range and metrics": {
+			terms: []AggregationTerm{
+				{
+					Name:      "ranges",
+					Attribute: "mem",
+					Scope:     "scope",
+					AggregationOptions: AggregationOptions{
+						Type: AggregationTypeRange,
+						Ranges: []AggregationRange{
+							{To: float64Ptr(1024)},
+						},
+					},
+				},
+				{
+					Name:      "max",
+					Attribute: "mem",
+					Scope:     "scope",
+					AggregationOptions: AggregationOptions{
+						Type: AggregationTypeMax,
+					},
+				},
+				{
+					Name:      "percentiles",
+					Attribute: "mem",
+					Scope:     "scope",
+					AggregationOptions: AggregationOptions{
+						Type:     AggregationTypePercentiles,
+						Percents: []float64{50},
+					},
+				},
+			},
+			res: &Aggregations{
+				"ranges": map[string]interface{}{
+					"range": map[string]interface{}{
+						"field": "scope_mem_num",
+						"ranges": []AggregationRange{
+							{To: float64Ptr(1024)},
+						},
+					},
+				},
+				"max": map[string]interface{}{
+					"max": map[string]interface{}{
+						"field": "scope_mem_num",
+					},
+				},
+				"percentiles": map[string]interface{}{
+					"percentiles": map[string]interface{}{
+						"field":    "scope_mem_num",
+						"percents": []float64{50},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -321,3 +569,7 @@ func TestBuildAggregations(t *testing.T) {
 		})
 	}
 }
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
@@ -31,12 +31,14 @@ const (
 	ScopeSystem    = "system"
 	ScopeTags      = "tags"
 	ScopeMonitor   = "monitor"
+	ScopeIoT       = "iot"
 )
 
 // attributes
 const (
 	AttrNameID                     = "id"
 	AttrNameGroup                  = "group"
+	AttrNameGroups                 = "groups"
 	AttrNameStatus                 = "status"
 	AttrNameCreatedAt              = "created_ts"
 	AttrNameUpdatedAt              = "updated_ts"
@@ -62,6 +64,14 @@ const (
 	typeBool = "bool"
 )
 
+// SubFieldDate is the date sub-field of the check-in time and the system
+// timestamp attributes
+const SubFieldDate = "date"
+
+// timestampSuffix is the suffix of the system timestamp attributes, which
+// are mapped with the date sub-field
+const timestampSuffix = "_ts"
+
 var (
 	attrSuffixes = map[Type]string{
 		TypeStr:  typeStr,
@@ -28,6 +28,7 @@ type Device struct {
 	IdentityAttributes  InventoryAttributes `json:"identity_attributes,omitempty"`
 	InventoryAttributes InventoryAttributes `json:"inventory_attributes,omitempty"`
 	MonitorAttributes   InventoryAttributes `json:"monitor_attributes,omitempty"`
+	IoTAttributes       InventoryAttributes `json:"iot_attributes,omitempty"`
 	SystemAttributes    InventoryAttributes `json:"system_attributes,omitempty"`
 	TagsAttributes      InventoryAttributes `json:"tags_attributes,omitempty"`
 	UpdatedAt           *time.Time          `json:"updated_at,omitempty"`
@@ -52,6 +53,9 @@ func (a *Device) AppendAttr(attr *InventoryAttribute) error {
 	case ScopeMonitor:
 		a.MonitorAttributes = append(a.MonitorAttributes, attr)
 		return nil
+	case ScopeIoT:
+		a.IoTAttributes = append(a.IoTAttributes, attr)
+		return nil
 	case ScopeSystem:
 		a.SystemAttributes = append(a.SystemAttributes, attr)
 		return nil
@@ -231,6 +235,7 @@ func (d *Device) MarshalJSON() ([]byte, error) {
 
 	attributes := append(d.IdentityAttributes, d.InventoryAttributes...)
 	attributes = append(attributes, d.MonitorAttributes...)
+	attributes = append(attributes, d.IoTAttributes...)
 	attributes = append(attributes, d.SystemAttributes...)
 	attributes = append(attributes, d.TagsAttributes...)
 
@@ -269,7 +274,7 @@ func MaybeParseAttr(field string) (string, string, error) {
 	name := ""
 
 	for _, s := range []string{ScopeIdentity, ScopeInventory, ScopeMonitor,
-		ScopeSystem, ScopeTags} {
+		ScopeIoT, ScopeSystem, ScopeTags} {
 		if strings.HasPrefix(field, s+"_") {
 			scope = s
 			break
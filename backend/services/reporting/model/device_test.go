@@ -65,6 +65,10 @@ func TestDevice(t *testing.T) {
 	err = device.AppendAttr(attr)
 	assert.Nil(t, err)
 
+	err = device.AppendAttr(NewInventoryAttribute(ScopeIoT).
+		SetName("firmware_version").SetVal("1.2.3"))
+	assert.Nil(t, err)
+
 	err = device.AppendAttr(NewInventoryAttribute(ScopeTags).
 		SetName("a4").SetVal([]interface{}{"a", "b"}))
 	assert.Nil(t, err)
@@ -86,4 +90,9 @@ func TestMaybeParseAttr(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "monitor", scope)
 	assert.Equal(t, "a1", name)
+
+	scope, name, err = MaybeParseAttr("iot_firmware_version_str")
+	assert.Nil(t, err)
+	assert.Equal(t, "iot", scope)
+	assert.Equal(t, "firmware_version", name)
 }
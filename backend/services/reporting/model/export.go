@@ -0,0 +1,141 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+var ErrExportAttributesRequired = errors.New(
+	"attributes: cannot be blank when exporting to csv",
+)
+
+func (f ExportFormat) Validate() error {
+	return validation.Validate(string(f),
+		validation.In(string(ExportFormatCSV), string(ExportFormatNDJSON)),
+	)
+}
+
+// ContentType returns the media type of the exported data
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+type ExportType string
+
+const (
+	ExportTypeDevices     ExportType = "devices"
+	ExportTypeDeployments ExportType = "deployments"
+)
+
+type ExportStatus string
+
+const (
+	ExportStatusPending ExportStatus = "pending"
+	ExportStatusDone    ExportStatus = "done"
+	ExportStatusFailed  ExportStatus = "failed"
+)
+
+// ExportDevicesParams selects the devices and the attributes to export;
+// pagination is ignored as all the matching devices are exported.
+type ExportDevicesParams struct {
+	SearchParams
+	Format ExportFormat `json:"format"`
+}
+
+func (p ExportDevicesParams) Validate() error {
+	if err := p.SearchParams.Validate(); err != nil {
+		return err
+	}
+	if err := p.Format.Validate(); err != nil {
+		return err
+	}
+	if p.Format == ExportFormatCSV && len(p.Attributes) == 0 {
+		return ErrExportAttributesRequired
+	}
+	return nil
+}
+
+// ExportDeploymentsParams selects the deployments and the attributes to
+// export; pagination is ignored as all the matching deployments are exported.
+type ExportDeploymentsParams struct {
+	DeploymentsSearchParams
+	Format ExportFormat `json:"format"`
+}
+
+func (p ExportDeploymentsParams) Validate() error {
+	if err := p.DeploymentsSearchParams.Validate(); err != nil {
+		return err
+	}
+	if err := p.Format.Validate(); err != nil {
+		return err
+	}
+	if p.Format == ExportFormatCSV && len(p.Attributes) == 0 {
+		return ErrExportAttributesRequired
+	}
+	return nil
+}
+
+// ExportExpiration is how long an export job and its output are kept
+// after the job is created and, again, after it finishes
+const ExportExpiration = 24 * time.Hour
+
+// Export is an asynchronous export job, its output can be downloaded
+// once the status is done and until the job expires.
+type Export struct {
+	ID         string       `json:"id" bson:"_id"`
+	TenantID   string       `json:"-" bson:"tenant_id"`
+	Type       ExportType   `json:"type" bson:"type"`
+	Format     ExportFormat `json:"format" bson:"format"`
+	Status     ExportStatus `json:"status" bson:"status"`
+	Count      int          `json:"count" bson:"count"`
+	Error      string       `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedTs  time.Time    `json:"created_ts" bson:"created_ts"`
+	FinishedTs *time.Time   `json:"finished_ts,omitempty" bson:"finished_ts,omitempty"`
+	ExpiresTs  time.Time    `json:"expires_ts" bson:"expires_ts"`
+}
+
+// Expired returns true if the export job and its output are no longer
+// available, even if they were not deleted yet
+func (e *Export) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresTs)
+}
+
+func NewExport(tenantID string, typ ExportType, format ExportFormat) *Export {
+	now := time.Now().UTC()
+	return &Export{
+		ID:        uuid.NewString(),
+		TenantID:  tenantID,
+		Type:      typ,
+		Format:    format,
+		Status:    ExportStatusPending,
+		CreatedTs: now,
+		ExpiresTs: now.Add(ExportExpiration),
+	}
+}
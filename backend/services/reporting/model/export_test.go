@@ -0,0 +1,166 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportDevicesParamsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		params ExportDevicesParams
+		err    error
+	}{
+		"ok, ndjson": {
+			params: ExportDevicesParams{
+				Format: ExportFormatNDJSON,
+			},
+		},
+		"ok, csv": {
+			params: ExportDevicesParams{
+				SearchParams: SearchParams{
+					Attributes: []SelectAttribute{{
+						Scope:     "inventory",
+						Attribute: "mac",
+					}},
+				},
+				Format: ExportFormatCSV,
+			},
+		},
+		"ko, csv without attributes": {
+			params: ExportDevicesParams{
+				Format: ExportFormatCSV,
+			},
+			err: ErrExportAttributesRequired,
+		},
+		"ko, invalid format": {
+			params: ExportDevicesParams{
+				Format: "xml",
+			},
+			err: errors.New("must be a valid value"),
+		},
+		"ko, invalid search params": {
+			params: ExportDevicesParams{
+				SearchParams: SearchParams{
+					Attributes: []SelectAttribute{{}},
+				},
+				Format: ExportFormatCSV,
+			},
+			err: errors.New("attribute: cannot be blank; scope: cannot be blank."),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.params.Validate()
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExportDeploymentsParamsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		params ExportDeploymentsParams
+		err    error
+	}{
+		"ok, ndjson": {
+			params: ExportDeploymentsParams{
+				Format: ExportFormatNDJSON,
+			},
+		},
+		"ok, csv": {
+			params: ExportDeploymentsParams{
+				DeploymentsSearchParams: DeploymentsSearchParams{
+					Attributes: []DeploymentsSelectAttribute{{
+						Attribute: "device_status",
+					}},
+				},
+				Format: ExportFormatCSV,
+			},
+		},
+		"ko, csv without attributes": {
+			params: ExportDeploymentsParams{
+				Format: ExportFormatCSV,
+			},
+			err: ErrExportAttributesRequired,
+		},
+		"ko, invalid format": {
+			params: ExportDeploymentsParams{
+				Format: "xml",
+			},
+			err: errors.New("must be a valid value"),
+		},
+		"ko, invalid search params": {
+			params: ExportDeploymentsParams{
+				DeploymentsSearchParams: DeploymentsSearchParams{
+					Filters: []DeploymentsFilterPredicate{{
+						Value: "",
+					}},
+				},
+				Format: ExportFormatNDJSON,
+			},
+			err: errors.New("attribute: cannot be blank; type: cannot be blank."),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.params.Validate()
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExportDevicesParamsUnmarshal(t *testing.T) {
+	var params ExportDevicesParams
+	err := json.Unmarshal([]byte(`{
+		"format": "csv",
+		"filters": [{"scope": "inventory", "attribute": "mac", "type": "$exists", "value": true}],
+		"attributes": [{"scope": "inventory", "attribute": "mac"}]
+	}`), &params)
+	assert.NoError(t, err)
+	assert.Equal(t, ExportFormatCSV, params.Format)
+	assert.Len(t, params.Filters, 1)
+	assert.Equal(t, []SelectAttribute{{
+		Scope:     "inventory",
+		Attribute: "mac",
+	}}, params.Attributes)
+}
+
+func TestExportFormatContentType(t *testing.T) {
+	assert.Equal(t, "text/csv", ExportFormatCSV.ContentType())
+	assert.Equal(t, "application/x-ndjson", ExportFormatNDJSON.ContentType())
+}
+
+func TestExportExpired(t *testing.T) {
+	export := NewExport("tenant", ExportTypeDevices, ExportFormatCSV)
+	assert.Equal(t, export.CreatedTs.Add(ExportExpiration), export.ExpiresTs)
+	assert.False(t, export.Expired(export.CreatedTs))
+	assert.False(t, export.Expired(export.ExpiresTs.Add(-time.Second)))
+	assert.True(t, export.Expired(export.ExpiresTs))
+}
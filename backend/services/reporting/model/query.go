@@ -17,6 +17,8 @@ package model
 import (
 	"encoding/json"
 	"errors"
+
+	"github.com/mendersoftware/mender-server/pkg/rbac"
 )
 
 const (
@@ -184,6 +186,11 @@ func (q *query) MarshalJSON() ([]byte, error) {
 
 // filter factory
 func getFilterPart(pred FilterPredicate) (QueryPart, error) {
+	if pred.Scope == ScopeSystem && pred.Attribute == AttrNameGroup {
+		if groups, ok := groupValues(pred); ok {
+			return NewDeviceGroupsFilter(groups), nil
+		}
+	}
 	switch pred.Type {
 	case "$eq":
 		return NewFilterEq(pred)
@@ -353,6 +360,78 @@ func (f *filterIn) AddTo(q Query) Query {
 	})
 }
 
+// filterGroups matches the groups, or the groups nested in them, in any
+// of the given fields
+type filterGroups struct {
+	attrs  []string
+	groups []string
+}
+
+// NewDeviceGroupsFilter matches the devices in the groups, either as
+// primary or additional group, including the devices in nested groups.
+func NewDeviceGroupsFilter(groups []string) *filterGroups {
+	return NewGroupsFilter(groups,
+		ToAttr(ScopeSystem, AttrNameGroup, TypeStr),
+		ToAttr(ScopeSystem, AttrNameGroups, TypeStr),
+	)
+}
+
+func NewGroupsFilter(groups []string, attrs ...string) *filterGroups {
+	return &filterGroups{
+		attrs:  attrs,
+		groups: groups,
+	}
+}
+
+func (f *filterGroups) AddTo(q Query) Query {
+	should := make([]interface{}, 0, len(f.attrs)*(len(f.groups)+1))
+	for _, attr := range f.attrs {
+		should = append(should, M{
+			"terms": M{
+				attr: f.groups,
+			},
+		})
+		for _, group := range f.groups {
+			should = append(should, M{
+				"prefix": M{
+					attr: group + rbac.DeviceGroupSeparator,
+				},
+			})
+		}
+	}
+	return q.Must(M{
+		"bool": M{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	})
+}
+
+// groupValues returns the group names of a $eq or $in group predicate.
+func groupValues(pred FilterPredicate) ([]string, bool) {
+	switch pred.Type {
+	case "$eq":
+		group, ok := pred.Value.(string)
+		return []string{group}, ok
+	case "$in":
+		switch v := pred.Value.(type) {
+		case []string:
+			return v, len(v) > 0
+		case []interface{}:
+			groups := make([]string, 0, len(v))
+			for _, elem := range v {
+				group, ok := elem.(string)
+				if !ok {
+					return nil, false
+				}
+				groups = append(groups, group)
+			}
+			return groups, len(groups) > 0
+		}
+	}
+	return nil, false
+}
+
 type filterNin struct {
 	*filter
 }
@@ -563,17 +642,7 @@ func BuildQuery(params SearchParams) (Query, error) {
 	}
 
 	if len(params.Groups) > 0 {
-		fp := FilterPredicate{
-			Scope:     ScopeSystem,
-			Attribute: AttrNameGroup,
-			Type:      "$in",
-			Value:     params.Groups,
-		}
-		fpart, err := NewFilterIn(fp)
-		if err != nil {
-			return nil, err
-		}
-		query = fpart.AddTo(query)
+		query = NewDeviceGroupsFilter(params.Groups).AddTo(query)
 	}
 
 	for _, s := range params.Sort {
@@ -30,15 +30,8 @@ func BuildDeploymentsQuery(params DeploymentsSearchParams) (Query, error) {
 	}
 
 	if len(params.DeploymentGroups) > 0 {
-		fpart, err := getFilterPart(FilterPredicate{
-			Attribute: FieldNameDeploymentGroups,
-			Type:      "$in",
-			Value:     params.DeploymentGroups,
-		})
-		if err != nil {
-			return nil, err
-		}
-		query = fpart.AddTo(query)
+		query = NewGroupsFilter(params.DeploymentGroups,
+			FieldNameDeploymentGroups).AddTo(query)
 	}
 
 	for _, s := range params.Sort {
@@ -41,8 +41,41 @@ func TestBuildQuery(t *testing.T) {
 				PerPage: defaultPerPage,
 			},
 			outQuery: NewQuery().Must(M{
-				"terms": M{
-					"system_group_str": []string{"group1", "group2"},
+				"bool": M{
+					"should": []interface{}{
+						M{"terms": M{"system_group_str": []string{"group1", "group2"}}},
+						M{"prefix": M{"system_group_str": "group1/"}},
+						M{"prefix": M{"system_group_str": "group2/"}},
+						M{"terms": M{"system_groups_str": []string{"group1", "group2"}}},
+						M{"prefix": M{"system_groups_str": "group1/"}},
+						M{"prefix": M{"system_groups_str": "group2/"}},
+					},
+					"minimum_should_match": 1,
+				},
+			}),
+		},
+		"filter group $eq": {
+			inParams: SearchParams{
+				Filters: []FilterPredicate{
+					{
+						Scope:     ScopeSystem,
+						Attribute: AttrNameGroup,
+						Type:      "$eq",
+						Value:     "europe/norway",
+					},
+				},
+				Page:    defaultPage,
+				PerPage: defaultPerPage,
+			},
+			outQuery: NewQuery().Must(M{
+				"bool": M{
+					"should": []interface{}{
+						M{"terms": M{"system_group_str": []string{"europe/norway"}}},
+						M{"prefix": M{"system_group_str": "europe/norway/"}},
+						M{"terms": M{"system_groups_str": []string{"europe/norway"}}},
+						M{"prefix": M{"system_groups_str": "europe/norway/"}},
+					},
+					"minimum_should_match": 1,
 				},
 			}),
 		},
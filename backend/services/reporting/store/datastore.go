@@ -16,10 +16,15 @@ package store
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/mendersoftware/mender-server/services/reporting/model"
 )
 
+var ErrExportNotFound = errors.New("export not found")
+
 // DataStore interface for DataStore services
 //
 //nolint:lll - skip line length check for interface declaration.
@@ -33,4 +38,10 @@ type DataStore interface {
 	GetMapping(ctx context.Context, tenantID string) (*model.Mapping, error)
 	UpdateAndGetMapping(ctx context.Context, tenantID string, inventory []string) (
 		*model.Mapping, error)
+	InsertExport(ctx context.Context, export *model.Export) error
+	UpdateExport(ctx context.Context, export *model.Export) error
+	GetExport(ctx context.Context, tenantID, id string) (*model.Export, error)
+	UploadExport(ctx context.Context, id string, r io.Reader) error
+	DownloadExport(ctx context.Context, id string, w io.Writer) error
+	DeleteExpiredExports(ctx context.Context, now time.Time) (int, error)
 }
@@ -18,9 +18,13 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
-	model "github.com/mendersoftware/mender-server/services/reporting/model"
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mendersoftware/mender-server/services/reporting/model"
+
+	time "time"
 )
 
 // DataStore is an autogenerated mock type for the DataStore type
@@ -46,6 +50,52 @@ func (_m *DataStore) Close(ctx context.Context) error {
 	return r0
 }
 
+// DeleteExpiredExports provides a mock function with given fields: ctx, now
+func (_m *DataStore) DeleteExpiredExports(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredExports")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DownloadExport provides a mock function with given fields: ctx, id, w
+func (_m *DataStore) DownloadExport(ctx context.Context, id string, w io.Writer) error {
+	ret := _m.Called(ctx, id, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer) error); ok {
+		r0 = rf(ctx, id, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DropDatabase provides a mock function with given fields: ctx
 func (_m *DataStore) DropDatabase(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -64,6 +114,36 @@ func (_m *DataStore) DropDatabase(ctx context.Context) error {
 	return r0
 }
 
+// GetExport provides a mock function with given fields: ctx, tenantID, id
+func (_m *DataStore) GetExport(ctx context.Context, tenantID string, id string) (*model.Export, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExport")
+	}
+
+	var r0 *model.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Export, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Export); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMapping provides a mock function with given fields: ctx, tenantID
 func (_m *DataStore) GetMapping(ctx context.Context, tenantID string) (*model.Mapping, error) {
 	ret := _m.Called(ctx, tenantID)
@@ -94,6 +174,24 @@ func (_m *DataStore) GetMapping(ctx context.Context, tenantID string) (*model.Ma
 	return r0, r1
 }
 
+// InsertExport provides a mock function with given fields: ctx, export
+func (_m *DataStore) InsertExport(ctx context.Context, export *model.Export) error {
+	ret := _m.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Export) error); ok {
+		r0 = rf(ctx, export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Migrate provides a mock function with given fields: ctx, version, automigrate
 func (_m *DataStore) Migrate(ctx context.Context, version string, automigrate bool) error {
 	ret := _m.Called(ctx, version, automigrate)
@@ -178,6 +276,42 @@ func (_m *DataStore) UpdateAndGetMapping(ctx context.Context, tenantID string, i
 	return r0, r1
 }
 
+// UpdateExport provides a mock function with given fields: ctx, export
+func (_m *DataStore) UpdateExport(ctx context.Context, export *model.Export) error {
+	ret := _m.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Export) error); ok {
+		r0 = rf(ctx, export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadExport provides a mock function with given fields: ctx, id, r
+func (_m *DataStore) UploadExport(ctx context.Context, id string, r io.Reader) error {
+	ret := _m.Called(ctx, id, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) error); ok {
+		r0 = rf(ctx, id, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewDataStore creates a new instance of DataStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDataStore(t interface {
@@ -21,6 +21,8 @@ import (
 
 	model "github.com/mendersoftware/mender-server/services/reporting/model"
 	mock "github.com/stretchr/testify/mock"
+
+	store "github.com/mendersoftware/mender-server/services/reporting/store"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -124,6 +126,42 @@ func (_m *Store) BulkIndexDevices(ctx context.Context, devices []*model.Device,
 	return r0
 }
 
+// ExportDeployments provides a mock function with given fields: ctx, query, fn
+func (_m *Store) ExportDeployments(ctx context.Context, query model.Query, fn store.ExportFunc) error {
+	ret := _m.Called(ctx, query, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDeployments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Query, store.ExportFunc) error); ok {
+		r0 = rf(ctx, query, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportDevices provides a mock function with given fields: ctx, query, fn
+func (_m *Store) ExportDevices(ctx context.Context, query model.Query, fn store.ExportFunc) error {
+	ret := _m.Called(ctx, query, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDevices")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Query, store.ExportFunc) error); ok {
+		r0 = rf(ctx, query, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetDeploymentsIndex provides a mock function with given fields: tid
 func (_m *Store) GetDeploymentsIndex(tid string) string {
 	ret := _m.Called(tid)
@@ -18,20 +18,28 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	mopts "go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/mendersoftware/mender-server/services/reporting/model"
+	"github.com/mendersoftware/mender-server/services/reporting/store"
 )
 
 const (
 	collNameMapping   = "mapping"
+	collNameExports   = "exports"
+	keyNameID         = "_id"
 	keyNameTenantID   = "tenant_id"
+	keyNameExpiresTs  = "expires_ts"
 	indexNameTenantID = "tenant_id_ndx"
+	indexNameExpires  = "expires_ts_ndx"
 )
 
 type MongoStoreConfig struct {
@@ -210,3 +218,145 @@ func (db *MongoStore) UpdateAndGetMapping(ctx context.Context, tenantID string,
 	}
 	return mapping, nil
 }
+
+// InsertExport stores a new export job
+func (db *MongoStore) InsertExport(ctx context.Context, export *model.Export) error {
+	_, err := db.client.
+		Database(db.config.DbName).
+		Collection(collNameExports).
+		InsertOne(ctx, export)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert the export")
+	}
+	return nil
+}
+
+// UpdateExport replaces the export job
+func (db *MongoStore) UpdateExport(ctx context.Context, export *model.Export) error {
+	query := bson.M{
+		keyNameID:       export.ID,
+		keyNameTenantID: export.TenantID,
+	}
+	res, err := db.client.
+		Database(db.config.DbName).
+		Collection(collNameExports).
+		ReplaceOne(ctx, query, export)
+	if err != nil {
+		return errors.Wrap(err, "failed to update the export")
+	} else if res.MatchedCount == 0 {
+		return store.ErrExportNotFound
+	}
+	return nil
+}
+
+// GetExport returns the export job of the tenant
+func (db *MongoStore) GetExport(ctx context.Context, tenantID, id string) (
+	*model.Export, error) {
+	query := bson.M{
+		keyNameID:       id,
+		keyNameTenantID: tenantID,
+	}
+	export := &model.Export{}
+	err := db.client.
+		Database(db.config.DbName).
+		Collection(collNameExports).
+		FindOne(ctx, query).
+		Decode(export)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrExportNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get the export")
+	}
+	return export, nil
+}
+
+func (db *MongoStore) exportsBucket(ctx context.Context) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(
+		db.client.Database(db.config.DbName),
+		mopts.GridFSBucket().SetName(collNameExports),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// the GridFS API is not context aware, use the deadline if any
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = bucket.SetReadDeadline(deadline)
+		_ = bucket.SetWriteDeadline(deadline)
+	}
+	return bucket, nil
+}
+
+// UploadExport stores the output of the export job
+func (db *MongoStore) UploadExport(ctx context.Context, id string, r io.Reader) error {
+	bucket, err := db.exportsBucket(ctx)
+	if err == nil {
+		err = bucket.UploadFromStreamWithID(id, id, r)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to upload the export")
+	}
+	return nil
+}
+
+// DownloadExport writes the output of the export job to w
+func (db *MongoStore) DownloadExport(ctx context.Context, id string, w io.Writer) error {
+	bucket, err := db.exportsBucket(ctx)
+	if err == nil {
+		_, err = bucket.DownloadToStream(id, w)
+	}
+	if err == gridfs.ErrFileNotFound {
+		return store.ErrExportNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to download the export")
+	}
+	return nil
+}
+
+// DeleteExpiredExports deletes the output of the export jobs which expired
+// or were removed by the TTL index, returning the number of deleted files
+func (db *MongoStore) DeleteExpiredExports(ctx context.Context, now time.Time) (int, error) {
+	pipeline := []bson.M{{
+		"$lookup": bson.M{
+			"from":         collNameExports,
+			"localField":   keyNameID,
+			"foreignField": keyNameID,
+			"as":           "export",
+		},
+	}, {
+		"$match": bson.M{
+			"$or": []bson.M{
+				{"export": bson.M{"$size": 0}},
+				{"export." + keyNameExpiresTs: bson.M{"$lte": now}},
+			},
+		},
+	}, {
+		"$project": bson.M{keyNameID: 1},
+	}}
+	cur, err := db.client.
+		Database(db.config.DbName).
+		Collection(collNameExports+".files").
+		Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find the expired exports")
+	}
+	var files []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cur.All(ctx, &files); err != nil {
+		return 0, errors.Wrap(err, "failed to find the expired exports")
+	}
+
+	bucket, err := db.exportsBucket(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete the expired exports")
+	}
+	deleted := 0
+	for _, file := range files {
+		err := bucket.Delete(file.ID)
+		if err != nil && err != gridfs.ErrFileNotFound {
+			return deleted, errors.Wrap(err, "failed to delete the expired exports")
+		}
+		deleted++
+	}
+	return deleted, nil
+}
@@ -15,6 +15,7 @@
 package mongo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/url"
@@ -25,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mendersoftware/mender-server/services/reporting/model"
+	"github.com/mendersoftware/mender-server/services/reporting/store"
 )
 
 func TestPing(t *testing.T) {
@@ -223,3 +225,78 @@ func TestUpdateAndGetMapping(t *testing.T) {
 	assert.Equal(t, tenantID, mapping.TenantID)
 	assert.Len(t, mapping.Inventory, 3+model.MaxMappingInventoryAttributes)
 }
+
+func TestExports(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestExports in short mode.")
+	}
+	ds := GetTestDataStore(t)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*10)
+	defer cancel()
+	defer ds.DropDatabase(ctx)
+
+	export := model.NewExport("tenant", model.ExportTypeDevices, model.ExportFormatCSV)
+	export.CreatedTs = export.CreatedTs.Truncate(time.Millisecond)
+	export.ExpiresTs = export.ExpiresTs.Truncate(time.Millisecond)
+
+	// not found
+	_, err := ds.GetExport(ctx, "tenant", export.ID)
+	assert.ErrorIs(t, err, store.ErrExportNotFound)
+	err = ds.UpdateExport(ctx, export)
+	assert.ErrorIs(t, err, store.ErrExportNotFound)
+
+	err = ds.InsertExport(ctx, export)
+	assert.NoError(t, err)
+
+	res, err := ds.GetExport(ctx, "tenant", export.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, export, res)
+
+	// other tenants can't get the export
+	_, err = ds.GetExport(ctx, "other", export.ID)
+	assert.ErrorIs(t, err, store.ErrExportNotFound)
+
+	// upload and update the export
+	const output = "id,inventory:mac\n1,00:11:22:33:44:55\n"
+	err = ds.UploadExport(ctx, export.ID, bytes.NewBufferString(output))
+	assert.NoError(t, err)
+
+	finished := time.Now().UTC().Truncate(time.Millisecond)
+	export.Status = model.ExportStatusDone
+	export.Count = 1
+	export.FinishedTs = &finished
+	err = ds.UpdateExport(ctx, export)
+	assert.NoError(t, err)
+
+	res, err = ds.GetExport(ctx, "tenant", export.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, export, res)
+
+	var buf bytes.Buffer
+	err = ds.DownloadExport(ctx, export.ID, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, output, buf.String())
+
+	err = ds.DownloadExport(ctx, "not-found", &buf)
+	assert.ErrorIs(t, err, store.ErrExportNotFound)
+
+	// the output is kept until the export expires
+	deleted, err := ds.DeleteExpiredExports(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = ds.DeleteExpiredExports(ctx, export.ExpiresTs)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	err = ds.DownloadExport(ctx, export.ID, &buf)
+	assert.ErrorIs(t, err, store.ErrExportNotFound)
+
+	// the output of the exports removed by the TTL index is deleted too
+	err = ds.UploadExport(ctx, "removed", bytes.NewBufferString(output))
+	assert.NoError(t, err)
+	deleted, err = ds.DeleteExpiredExports(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
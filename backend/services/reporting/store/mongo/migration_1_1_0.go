@@ -0,0 +1,68 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	"github.com/mendersoftware/mender-server/services/reporting/model"
+)
+
+// migration_1_1_0 expires the export jobs with a TTL index; their output
+// is deleted by DeleteExpiredExports.
+type migration_1_1_0 struct {
+	client *mongo.Client
+	db     string
+}
+
+func (m *migration_1_1_0) Up(from migrate.Version) error {
+	ctx := context.Background()
+	collection := m.client.
+		Database(m.db).
+		Collection(collNameExports)
+
+	// the export jobs created before expire as the new ones do
+	_, err := collection.UpdateMany(ctx, bson.M{
+		keyNameExpiresTs: bson.M{"$exists": false},
+	}, []bson.M{{
+		"$set": bson.M{
+			keyNameExpiresTs: bson.M{"$add": []interface{}{
+				"$created_ts", model.ExportExpiration.Milliseconds(),
+			}},
+		},
+	}})
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: keyNameExpiresTs, Value: 1},
+		},
+		Options: options.Index().
+			SetName(indexNameExpires).
+			SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (m *migration_1_1_0) Version() migrate.Version {
+	return migrate.MakeVersion(1, 1, 0)
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/migrate"
+	"github.com/mendersoftware/mender-server/services/reporting/model"
+)
+
+func TestMigration_1_1_0(t *testing.T) {
+	ctx := context.Background()
+	collection := client.Database(DbName).Collection(collNameExports)
+	createdTs := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := collection.InsertOne(ctx, bson.M{
+		keyNameID:       "legacy-export",
+		keyNameTenantID: "tenant",
+		"created_ts":    createdTs,
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, _ = collection.DeleteOne(ctx, bson.M{keyNameID: "legacy-export"})
+	}()
+
+	m := &migration_1_1_0{
+		client: client,
+		db:     DbName,
+	}
+	err = m.Up(migrate.MakeVersion(1, 0, 0))
+	require.NoError(t, err)
+
+	export := &model.Export{}
+	err = collection.FindOne(ctx, bson.M{keyNameID: "legacy-export"}).Decode(export)
+	require.NoError(t, err)
+	assert.Equal(t, createdTs.Add(model.ExportExpiration), export.ExpiresTs.UTC())
+
+	cur, err := collection.Indexes().List(ctx)
+	require.NoError(t, err)
+	var idxes []struct {
+		Keys               bson.D `bson:"key"`
+		Name               string `bson:"name"`
+		ExpireAfterSeconds *int32 `bson:"expireAfterSeconds"`
+	}
+	err = cur.All(ctx, &idxes)
+	require.NoError(t, err)
+	found := false
+	for _, idx := range idxes {
+		if idx.Name != indexNameExpires {
+			continue
+		}
+		found = true
+		assert.EqualValues(t, bson.D{
+			{Key: keyNameExpiresTs, Value: int32(1)},
+		}, idx.Keys)
+		if assert.NotNil(t, idx.ExpireAfterSeconds) {
+			assert.Equal(t, int32(0), *idx.ExpireAfterSeconds)
+		}
+	}
+	assert.True(t, found, "index %q not found", indexNameExpires)
+}
@@ -25,7 +25,7 @@ import (
 
 const (
 	// DbVersion is the current schema version
-	DbVersion = "1.0.0"
+	DbVersion = "1.1.0"
 
 	// DbName is the database name
 	DbName = "reporting"
@@ -53,6 +53,10 @@ func (db *MongoStore) Migrate(ctx context.Context, version string, automigrate b
 			client: db.client,
 			db:     db.config.DbName,
 		},
+		&migration_1_1_0{
+			client: db.client,
+			db:     db.config.DbName,
+		},
 	}
 	err = m.Apply(ctx, *ver, migrations)
 	if err != nil {
@@ -22,61 +22,93 @@ const indexDevicesTemplate = `{
 			"number_of_shards": %d,
 			"number_of_replicas": %d
 		},
-		"mappings": {
-			"dynamic": true,
-			"date_detection": false,
-			"numeric_detection": false,
-			"_source": {
-				"enabled": true
-			},
-			"properties": {
-				"id": {
-					"type": "keyword"
-				},
-				"tenantID": {
-					"type": "keyword"
-				},
-				"name": {
-					"type": "keyword"
+		"mappings": %s
+	}
+}`
+
+// indexDevicesMappings are the mappings of the devices index; the check-in
+// time keeps the default dynamic mapping of the indices created before it
+// was mapped explicitly, with a date sub-field for the date histograms.
+const indexDevicesMappings = `{
+	"dynamic": true,
+	"date_detection": false,
+	"numeric_detection": false,
+	"_source": {
+		"enabled": true
+	},
+	"properties": {
+		"id": {
+			"type": "keyword"
+		},
+		"tenantID": {
+			"type": "keyword"
+		},
+		"name": {
+			"type": "keyword"
+		},
+		"location": {
+			"type": "geo_point"
+		},
+		"check_in_time": {
+			"type": "text",
+			"fields": {
+				"keyword": {
+					"type": "keyword",
+					"ignore_above": 256
 				},
-				"location": {
-					"type": "geo_point"
+				"date": {
+					"type": "date",
+					"ignore_malformed": true
 				}
-			},
-			"dynamic_templates": [
-				{
-					"versions": {
-						"match": "*_version*",
-						"mapping": {
-							"type": "version"
-						}
-					}
-				},
-				{
-					"nums": {
-						"match": "*_num",
-						"mapping": {
-							"type": "double"
-						}
-					}
-				},
-				{
-					"strings": {
-						"match": "*_str",
-						"mapping": {
-							"type": "keyword"
-						}
-					}
-				},
-				{
-					"bools": {
-						"match": "*_bool",
-						"mapping": {
-							"type": "boolean"
+			}
+		}
+	},
+	"dynamic_templates": [
+		{
+			"versions": {
+				"match": "*_version*",
+				"mapping": {
+					"type": "version"
+				}
+			}
+		},
+		{
+			"timestamps": {
+				"match": "system_*_ts_str",
+				"mapping": {
+					"type": "keyword",
+					"fields": {
+						"date": {
+							"type": "date",
+							"ignore_malformed": true
 						}
 					}
 				}
-			]
+			}
+		},
+		{
+			"nums": {
+				"match": "*_num",
+				"mapping": {
+					"type": "double"
+				}
+			}
+		},
+		{
+			"strings": {
+				"match": "*_str",
+				"mapping": {
+					"type": "keyword"
+				}
+			}
+		},
+		{
+			"bools": {
+				"match": "*_bool",
+				"mapping": {
+					"type": "boolean"
+				}
+			}
 		}
-	}
+	]
 }`
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/opensearch-project/opensearch-go"
@@ -34,6 +35,10 @@ import (
 	"github.com/mendersoftware/mender-server/services/reporting/store"
 )
 
+// exportKeepAlive is how long a point in time is kept between the
+// requests of an export
+const exportKeepAlive = "1m"
+
 type StoreOption func(*opensearchStore)
 
 type opensearchStore struct {
@@ -268,11 +273,15 @@ func (s *opensearchStore) Migrate(ctx context.Context) error {
 		indexName,
 		s.devicesIndexShards,
 		s.devicesIndexReplicas,
+		indexDevicesMappings,
 	)
 	err := s.migratePutIndexTemplate(ctx, indexName, template)
 	if err == nil {
 		err = s.migrateCreateIndex(ctx, indexName)
 	}
+	if err == nil {
+		err = s.migrateDevicesDateFields(ctx, indexName)
+	}
 	if err == nil {
 		indexName = s.GetDeploymentsIndex("")
 		template = fmt.Sprintf(indexDeploymentsTemplate,
@@ -346,6 +355,115 @@ func (s *opensearchStore) migrateCreateIndex(ctx context.Context, indexName stri
 	return nil
 }
 
+// migrateDevicesDateFields adds the date sub-fields of the devices index
+// template to the fields of an index created before them, and updates the
+// documents of the index in the background to fill them in.
+func (s *opensearchStore) migrateDevicesDateFields(ctx context.Context,
+	indexName string) error {
+	l := log.FromContext(ctx)
+
+	index, err := s.getIndexMapping(ctx, "", indexName)
+	if err != nil {
+		return err
+	}
+	properties := map[string]interface{}{}
+	if mappings, ok := index["mappings"].(map[string]interface{}); ok {
+		properties, _ = mappings["properties"].(map[string]interface{})
+	}
+	var mappings struct {
+		Properties       map[string]interface{} `json:"properties"`
+		DynamicTemplates []interface{}          `json:"dynamic_templates"`
+	}
+	if err := json.Unmarshal([]byte(indexDevicesMappings), &mappings); err != nil {
+		return errors.Wrap(err, "failed to parse the index mappings")
+	}
+	update := devicesDateFieldsMapping(properties)
+	if _, ok := properties[model.FieldNameCheckIn]; !ok {
+		update[model.FieldNameCheckIn] = mappings.Properties[model.FieldNameCheckIn]
+	}
+	if len(update) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"dynamic_templates": mappings.DynamicTemplates,
+		"properties":        update,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the index mappings")
+	}
+
+	l.Infof("add the date sub-fields to the index %s", indexName)
+	req := opensearchapi.IndicesPutMappingRequest{
+		Index: []string{indexName},
+		Body:  bytes.NewReader(body),
+	}
+	res, err := req.Do(ctx, s.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to put the index mappings")
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return errors.Errorf("failed to put the index mappings: %s", string(body))
+	}
+
+	l.Infof("update the documents of the index %s", indexName)
+	waitForCompletion := false
+	updateReq := opensearchapi.UpdateByQueryRequest{
+		Index:             []string{indexName},
+		Conflicts:         "proceed",
+		WaitForCompletion: &waitForCompletion,
+	}
+	updateRes, err := updateReq.Do(ctx, s.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to update the documents")
+	}
+	defer updateRes.Body.Close()
+	if updateRes.IsError() {
+		body, _ := io.ReadAll(updateRes.Body)
+		return errors.Errorf("failed to update the documents: %s", string(body))
+	}
+	return nil
+}
+
+// devicesDateFieldsMapping returns the mappings of the check-in time and
+// system timestamp fields lacking the date sub-field, with the sub-field.
+func devicesDateFieldsMapping(
+	properties map[string]interface{},
+) map[string]interface{} {
+	update := map[string]interface{}{}
+	for name, value := range properties {
+		if name != model.FieldNameCheckIn &&
+			!(strings.HasPrefix(name, model.ScopeSystem+"_") &&
+				strings.HasSuffix(name, "_ts_str")) {
+			continue
+		}
+		field, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		subFields, _ := field["fields"].(map[string]interface{})
+		if _, ok := subFields[model.SubFieldDate]; ok {
+			continue
+		}
+		mapping := make(map[string]interface{}, len(field)+1)
+		for k, v := range field {
+			mapping[k] = v
+		}
+		fields := make(map[string]interface{}, len(subFields)+1)
+		for k, v := range subFields {
+			fields[k] = v
+		}
+		fields[model.SubFieldDate] = map[string]interface{}{
+			"type":             "date",
+			"ignore_malformed": true,
+		}
+		mapping["fields"] = fields
+		update[name] = mapping
+	}
+	return update
+}
+
 func (s *opensearchStore) Ping(ctx context.Context) error {
 	pingRequest := s.client.Ping.WithContext(ctx)
 	_, err := s.client.Ping(pingRequest)
@@ -462,6 +580,178 @@ func (s *opensearchStore) search(ctx context.Context, indexName, routingKey stri
 	return ret, nil
 }
 
+// ExportDevices calls fn with every page of devices matching the query;
+// the pages are retrieved using a point in time and search_after, the query
+// must be sorted on a unique field
+func (s *opensearchStore) ExportDevices(ctx context.Context, query model.Query,
+	fn store.ExportFunc) error {
+	id := identity.FromContext(ctx)
+	indexName := s.GetDevicesIndex(id.Tenant)
+	routingKey := s.GetDevicesRoutingKey(id.Tenant)
+	return s.export(ctx, indexName, routingKey, query, fn)
+}
+
+// ExportDeployments calls fn with every page of deployments matching the
+// query; the pages are retrieved using a point in time and search_after,
+// the query must be sorted on a unique field
+func (s *opensearchStore) ExportDeployments(ctx context.Context, query model.Query,
+	fn store.ExportFunc) error {
+	id := identity.FromContext(ctx)
+	indexName := s.GetDeploymentsIndex(id.Tenant)
+	routingKey := s.GetDeploymentsRoutingKey(id.Tenant)
+	return s.export(ctx, indexName, routingKey, query, fn)
+}
+
+func (s *opensearchStore) export(ctx context.Context, indexName, routingKey string,
+	query model.Query, fn store.ExportFunc) error {
+	pitID, err := s.createPointInTime(ctx, indexName, routingKey)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := s.deletePointInTime(context.WithoutCancel(ctx), pitID)
+		if err != nil {
+			log.FromContext(ctx).Warnf("failed to delete the point in time: %s", err)
+		}
+	}()
+
+	var searchAfter interface{}
+	for {
+		parts := map[string]interface{}{
+			"pit": model.M{
+				"id":         pitID,
+				"keep_alive": exportKeepAlive,
+			},
+		}
+		if searchAfter != nil {
+			parts["search_after"] = searchAfter
+		}
+		res, err := s.searchPointInTime(ctx, query.With(parts))
+		if err != nil {
+			return err
+		}
+		// the point in time id can change between requests
+		if id, ok := res["pit_id"].(string); ok && id != "" {
+			pitID = id
+		}
+
+		hitsM, ok := res["hits"].(map[string]interface{})
+		if !ok {
+			return errors.New("can't process store hits map")
+		}
+		hits, ok := hitsM["hits"].([]interface{})
+		if !ok {
+			return errors.New("can't process store hits slice")
+		}
+		if len(hits) == 0 {
+			return nil
+		}
+		if err := fn(hits); err != nil {
+			return err
+		}
+
+		lastHit, ok := hits[len(hits)-1].(map[string]interface{})
+		if !ok {
+			return errors.New("can't process individual hit")
+		}
+		searchAfter, ok = lastHit["sort"]
+		if !ok {
+			return errors.New("can't process the sort values of the hit")
+		}
+	}
+}
+
+func (s *opensearchStore) createPointInTime(ctx context.Context,
+	indexName, routingKey string) (string, error) {
+	params := url.Values{}
+	params.Set("keep_alive", exportKeepAlive)
+	if routingKey != "" {
+		params.Set("routing", routingKey)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"/"+indexName+"/_search/point_in_time?"+params.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var ret struct {
+		PitID string `json:"pit_id"`
+	}
+	if err := s.perform(req, &ret); err != nil {
+		return "", errors.Wrap(err, "failed to create the point in time")
+	}
+	return ret.PitID, nil
+}
+
+func (s *opensearchStore) deletePointInTime(ctx context.Context, pitID string) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(model.M{
+		"pit_id": []string{pitID},
+	}); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		"/_search/point_in_time", &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return s.perform(req, nil)
+}
+
+func (s *opensearchStore) searchPointInTime(ctx context.Context,
+	query model.Query) (model.M, error) {
+	l := log.FromContext(ctx)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, err
+	}
+
+	l.Debugf("es query: %v", buf.String())
+
+	// the index is given by the point in time
+	resp, err := s.client.Search(
+		s.client.Search.WithContext(ctx),
+		s.client.Search.WithBody(&buf),
+		s.client.Search.WithTrackTotalHits(false),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return nil, errors.New(resp.String())
+	}
+
+	var ret map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// perform sends a request for which the client has no API, decoding the
+// response in ret if not nil
+func (s *opensearchStore) perform(req *http.Request, ret interface{}) error {
+	resp, err := s.client.Perform(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(resp.Body)
+		return errors.Errorf("[%d] %s", resp.StatusCode, body)
+	}
+	if ret != nil {
+		return json.NewDecoder(resp.Body).Decode(ret)
+	}
+	return nil
+}
+
 // GetDevicesIndexMapping retrieves the "devices*" index definition for tenant 'tid'
 // existing fields, incl. inventory attributes, are found under 'properties'
 // see: https://opensearch.org/docs/latest/api-reference/index-apis/get-index/
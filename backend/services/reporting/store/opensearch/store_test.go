@@ -0,0 +1,69 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package opensearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevicesDateFieldsMapping(t *testing.T) {
+	t.Parallel()
+
+	dateField := map[string]interface{}{
+		"type":             "date",
+		"ignore_malformed": true,
+	}
+	properties := map[string]interface{}{
+		"id": map[string]interface{}{"type": "keyword"},
+		"check_in_time": map[string]interface{}{
+			"type": "text",
+			"fields": map[string]interface{}{
+				"keyword": map[string]interface{}{
+					"type":         "keyword",
+					"ignore_above": float64(256),
+				},
+			},
+		},
+		"system_created_ts_str": map[string]interface{}{"type": "keyword"},
+		"system_updated_ts_str": map[string]interface{}{
+			"type": "keyword",
+			"fields": map[string]interface{}{
+				"date": dateField,
+			},
+		},
+		"inventory_installed_ts_str": map[string]interface{}{"type": "keyword"},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"check_in_time": map[string]interface{}{
+			"type": "text",
+			"fields": map[string]interface{}{
+				"keyword": map[string]interface{}{
+					"type":         "keyword",
+					"ignore_above": float64(256),
+				},
+				"date": dateField,
+			},
+		},
+		"system_created_ts_str": map[string]interface{}{
+			"type": "keyword",
+			"fields": map[string]interface{}{
+				"date": dateField,
+			},
+		},
+	}, devicesDateFieldsMapping(properties))
+}
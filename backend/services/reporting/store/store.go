@@ -20,6 +20,9 @@ import (
 	"github.com/mendersoftware/mender-server/services/reporting/model"
 )
 
+// ExportFunc is called with every page of hits of an export
+type ExportFunc func(hits []interface{}) error
+
 //go:generate ../../../utils/mockgen.sh
 type Store interface {
 	BulkIndexDeployments(ctx context.Context, deployments []*model.Deployment) error
@@ -35,5 +38,7 @@ type Store interface {
 	AggregateDeployments(ctx context.Context, query model.Query) (model.M, error)
 	SearchDevices(ctx context.Context, query model.Query) (model.M, error)
 	SearchDeployments(ctx context.Context, query model.Query) (model.M, error)
+	ExportDevices(ctx context.Context, query model.Query, fn ExportFunc) error
+	ExportDeployments(ctx context.Context, query model.Query, fn ExportFunc) error
 	Ping(ctx context.Context) error
 }
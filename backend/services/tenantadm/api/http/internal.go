@@ -0,0 +1,238 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/tenantadm/app"
+	"github.com/mendersoftware/mender-server/services/tenantadm/model"
+	"github.com/mendersoftware/mender-server/services/tenantadm/store"
+)
+
+const (
+	hdrTotalCount    = "X-Total-Count"
+	hdrAuthorization = "Authorization"
+
+	paramName   = "name"
+	paramStatus = "status"
+)
+
+// InternalAPI is a namespace for the internal API handlers.
+type InternalAPI APIHandler
+
+func (api *InternalAPI) Alive(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+func (api *InternalAPI) Health(c *gin.Context) {
+	err := api.App.HealthCheck(c.Request.Context())
+	if err != nil {
+		rest.RenderError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// renderTenantError renders the errors common to the tenant handlers
+func renderTenantError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, store.ErrTenantNotFound):
+		rest.RenderError(c, http.StatusNotFound, err)
+	case errors.Is(err, store.ErrDuplicateTenantName):
+		rest.RenderError(c, http.StatusConflict, err)
+	default:
+		rest.RenderInternalError(c, err)
+	}
+}
+
+// CreateTenant responds to POST /tenants
+func (api *InternalAPI) CreateTenant(c *gin.Context) {
+	var req model.NewTenantRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	if err = req.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request body"),
+		)
+		return
+	}
+
+	tenant, err := api.App.CreateTenant(c.Request.Context(), req)
+	if err != nil {
+		renderTenantError(c, err)
+		return
+	}
+	c.Header("Location", URIInternal+"/tenants/"+tenant.ID)
+	c.JSON(http.StatusCreated, tenant)
+}
+
+// ListTenants responds to GET /tenants
+func (api *InternalAPI) ListTenants(c *gin.Context) {
+	page, perPage, err := rest.ParsePagingParameters(c.Request)
+	if err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	filter := model.TenantFilter{
+		Name:   c.Query(paramName),
+		Status: model.TenantStatus(c.Query(paramStatus)),
+		Skip:   (page - 1) * perPage,
+		Limit:  perPage,
+	}
+	if err = filter.Validate(); err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tenants, count, err := api.App.ListTenants(c.Request.Context(), filter)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	hints := rest.NewPagingHints().
+		SetPage(page).
+		SetPerPage(perPage).
+		SetTotalCount(count)
+	links, err := rest.MakePagingHeaders(c.Request, hints)
+	if err == nil {
+		for _, link := range links {
+			c.Writer.Header().Add("Link", link)
+		}
+	}
+	c.Writer.Header().Set(hdrTotalCount, strconv.FormatInt(count, 10))
+	c.JSON(http.StatusOK, tenants)
+}
+
+// GetTenant responds to GET /tenants/:tenant_id
+func (api *InternalAPI) GetTenant(c *gin.Context) {
+	tenant, err := api.App.GetTenant(c.Request.Context(), c.Param(pathParamTenantID))
+	if err != nil {
+		renderTenantError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tenant)
+}
+
+// UpdateTenant responds to PUT /tenants/:tenant_id
+func (api *InternalAPI) UpdateTenant(c *gin.Context) {
+	var update model.TenantUpdate
+	err := c.ShouldBindJSON(&update)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	if err = update.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request body"),
+		)
+		return
+	}
+
+	err = api.App.UpdateTenant(c.Request.Context(), c.Param(pathParamTenantID), update)
+	if err != nil {
+		renderTenantError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateTenantStatus responds to PUT /tenants/:tenant_id/status
+func (api *InternalAPI) UpdateTenantStatus(c *gin.Context) {
+	var update model.TenantStatusUpdate
+	err := c.ShouldBindJSON(&update)
+	if err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "malformed request body"),
+		)
+		return
+	}
+	if err = update.Validate(); err != nil {
+		rest.RenderError(c,
+			http.StatusBadRequest,
+			errors.Wrap(err, "invalid request body"),
+		)
+		return
+	}
+
+	err = api.App.UpdateTenantStatus(c.Request.Context(),
+		c.Param(pathParamTenantID), update.Status)
+	if err != nil {
+		renderTenantError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RotateTenantToken responds to POST /tenants/:tenant_id/tenant_token
+func (api *InternalAPI) RotateTenantToken(c *gin.Context) {
+	token, err := api.App.RotateTenantToken(c.Request.Context(), c.Param(pathParamTenantID))
+	if err != nil {
+		renderTenantError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.TenantTokenResponse{TenantToken: token})
+}
+
+// DeleteTenant responds to DELETE /tenants/:tenant_id; the tenant data
+// is removed from the other services asynchronously.
+func (api *InternalAPI) DeleteTenant(c *gin.Context) {
+	err := api.App.DeleteTenant(c.Request.Context(), c.Param(pathParamTenantID))
+	if err != nil {
+		renderTenantError(c, err)
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
+
+// VerifyTenantToken responds to POST /tenants/verify, the tenant token is
+// passed as a bearer token.
+func (api *InternalAPI) VerifyTenantToken(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader(hdrAuthorization), "Bearer ")
+	if !ok || token == "" {
+		rest.RenderError(c, http.StatusUnauthorized, app.ErrInvalidTenantToken)
+		return
+	}
+
+	tenant, err := api.App.VerifyTenantToken(c.Request.Context(), token)
+	if errors.Is(err, app.ErrInvalidTenantToken) {
+		rest.RenderError(c, http.StatusUnauthorized, err)
+		return
+	} else if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tenant)
+}
@@ -0,0 +1,409 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/tenantadm/app"
+	mapp "github.com/mendersoftware/mender-server/services/tenantadm/app/mocks"
+	"github.com/mendersoftware/mender-server/services/tenantadm/model"
+	"github.com/mendersoftware/mender-server/services/tenantadm/store"
+)
+
+var contextMatcher = mock.MatchedBy(func(_ context.Context) bool { return true })
+
+func newRequest(method, path string, body interface{}) *http.Request {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, URIInternal+path, bytes.NewReader(b))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+func TestAlive(t *testing.T) {
+	router := NewRouter(new(mapp.App))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("GET", URIAlive, nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHealth(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("HealthCheck", contextMatcher).Return(errors.New("mongo down")).Once()
+	app.On("HealthCheck", contextMatcher).Return(nil).Once()
+	router := NewRouter(app)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("GET", URIHealth, nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("GET", URIHealth, nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestCreateTenant(t *testing.T) {
+	t.Parallel()
+	tenant := &model.Tenant{
+		ID:          "tenant",
+		Name:        "acme",
+		Status:      model.TenantStatusActive,
+		TenantToken: "token",
+	}
+	testCases := []struct {
+		Name string
+
+		Body interface{}
+		App  func(t *testing.T) *mapp.App
+
+		Status   int
+		Location string
+	}{
+		{
+			Name: "ok",
+
+			Body: map[string]string{"name": "acme"},
+			App: func(t *testing.T) *mapp.App {
+				app := new(mapp.App)
+				app.On("CreateTenant", contextMatcher,
+					model.NewTenantRequest{Name: "acme"},
+				).Return(tenant, nil)
+				return app
+			},
+			Status:   http.StatusCreated,
+			Location: URIInternal + "/tenants/tenant",
+		},
+		{
+			Name: "error, malformed body",
+
+			Body:   "not a tenant",
+			App:    func(t *testing.T) *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, invalid body",
+
+			Body:   map[string]string{"name": ""},
+			App:    func(t *testing.T) *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, duplicate name",
+
+			Body: map[string]string{"name": "acme"},
+			App: func(t *testing.T) *mapp.App {
+				app := new(mapp.App)
+				app.On("CreateTenant", contextMatcher, mock.Anything).
+					Return(nil, store.ErrDuplicateTenantName)
+				return app
+			},
+			Status: http.StatusConflict,
+		},
+		{
+			Name: "error, internal error",
+
+			Body: map[string]string{"name": "acme"},
+			App: func(t *testing.T) *mapp.App {
+				app := new(mapp.App)
+				app.On("CreateTenant", contextMatcher, mock.Anything).
+					Return(nil, errors.New("mongo down"))
+				return app
+			},
+			Status: http.StatusInternalServerError,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t)
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newRequest("POST", URITenants, tc.Body))
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.Location != "" {
+				assert.Equal(t, tc.Location, w.Header().Get("Location"))
+				var res model.Tenant
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Equal(t, "token", res.TenantToken)
+			}
+		})
+	}
+}
+
+func TestListTenants(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name string
+
+		Query string
+		App   func(t *testing.T) *mapp.App
+
+		Status int
+	}{
+		{
+			Name: "ok",
+
+			Query: "?name=acme&status=suspended&page=2&per_page=10",
+			App: func(t *testing.T) *mapp.App {
+				app := new(mapp.App)
+				app.On("ListTenants", contextMatcher, model.TenantFilter{
+					Name:   "acme",
+					Status: model.TenantStatusSuspended,
+					Skip:   10,
+					Limit:  10,
+				}).Return([]model.Tenant{{ID: "tenant"}}, int64(11), nil)
+				return app
+			},
+			Status: http.StatusOK,
+		},
+		{
+			Name: "error, invalid status",
+
+			Query:  "?status=deleted",
+			App:    func(t *testing.T) *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, invalid paging",
+
+			Query:  "?page=0",
+			App:    func(t *testing.T) *mapp.App { return new(mapp.App) },
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "error, internal error",
+
+			App: func(t *testing.T) *mapp.App {
+				app := new(mapp.App)
+				app.On("ListTenants", contextMatcher, mock.Anything).
+					Return(nil, int64(-1), errors.New("mongo down"))
+				return app
+			},
+			Status: http.StatusInternalServerError,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t)
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newRequest("GET", URITenants+tc.Query, nil))
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.Status == http.StatusOK {
+				assert.Equal(t, "11", w.Header().Get(hdrTotalCount))
+				assert.NotEmpty(t, w.Header().Values("Link"))
+			}
+		})
+	}
+}
+
+func TestGetTenant(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("GetTenant", contextMatcher, "tenant").
+		Return(&model.Tenant{ID: "tenant"}, nil)
+	app.On("GetTenant", contextMatcher, "missing").
+		Return(nil, store.ErrTenantNotFound)
+	router := NewRouter(app)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("GET", "/tenants/tenant", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("GET", "/tenants/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateTenant(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("UpdateTenant", contextMatcher, "tenant",
+		model.TenantUpdate{Name: "ACME"}).Return(nil)
+	app.On("UpdateTenant", contextMatcher, "missing",
+		model.TenantUpdate{Name: "ACME"}).Return(store.ErrTenantNotFound)
+	router := NewRouter(app)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("PUT", "/tenants/tenant",
+		map[string]string{"name": "ACME"}))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("PUT", "/tenants/missing",
+		map[string]string{"name": "ACME"}))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("PUT", "/tenants/tenant",
+		map[string]string{"name": ""}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateTenantStatus(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("UpdateTenantStatus", contextMatcher, "tenant",
+		model.TenantStatusSuspended).Return(nil)
+	router := NewRouter(app)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("PUT", "/tenants/tenant/status",
+		map[string]string{"status": "suspended"}))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("PUT", "/tenants/tenant/status",
+		map[string]string{"status": "deleted"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRotateTenantToken(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("RotateTenantToken", contextMatcher, "tenant").Return("new-token", nil)
+	app.On("RotateTenantToken", contextMatcher, "missing").
+		Return("", store.ErrTenantNotFound)
+	router := NewRouter(app)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("POST", "/tenants/tenant/tenant_token", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"tenant_token": "new-token"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("POST", "/tenants/missing/tenant_token", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteTenant(t *testing.T) {
+	app := new(mapp.App)
+	defer app.AssertExpectations(t)
+	app.On("DeleteTenant", contextMatcher, "tenant").Return(nil)
+	app.On("DeleteTenant", contextMatcher, "missing").Return(store.ErrTenantNotFound)
+	router := NewRouter(app)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("DELETE", "/tenants/tenant", nil))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("DELETE", "/tenants/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestVerifyTenantToken(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name string
+
+		Authorization string
+		App           func(t *testing.T) *mapp.App
+
+		Status int
+		Body   string
+	}{
+		{
+			Name: "ok",
+
+			Authorization: "Bearer token",
+			App: func(t *testing.T) *mapp.App {
+				a := new(mapp.App)
+				a.On("VerifyTenantToken", contextMatcher, "token").
+					Return(&model.Tenant{
+						ID:     "tenant",
+						Name:   "acme",
+						Status: model.TenantStatusSuspended,
+					}, nil)
+				return a
+			},
+			Status: http.StatusOK,
+			Body: `{"id": "tenant", "name": "acme", "status": "suspended",` +
+				`"created_ts": "0001-01-01T00:00:00Z", "updated_ts": "0001-01-01T00:00:00Z"}`,
+		},
+		{
+			Name: "error, missing token",
+
+			App:    func(t *testing.T) *mapp.App { return new(mapp.App) },
+			Status: http.StatusUnauthorized,
+		},
+		{
+			Name: "error, invalid token",
+
+			Authorization: "Bearer other",
+			App: func(t *testing.T) *mapp.App {
+				a := new(mapp.App)
+				a.On("VerifyTenantToken", contextMatcher, "other").
+					Return(nil, app.ErrInvalidTenantToken)
+				return a
+			},
+			Status: http.StatusUnauthorized,
+		},
+		{
+			Name: "error, internal error",
+
+			Authorization: "Bearer token",
+			App: func(t *testing.T) *mapp.App {
+				a := new(mapp.App)
+				a.On("VerifyTenantToken", contextMatcher, "token").
+					Return(nil, errors.New("mongo down"))
+				return a
+			},
+			Status: http.StatusInternalServerError,
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			app := tc.App(t)
+			defer app.AssertExpectations(t)
+			router := NewRouter(app)
+
+			req := newRequest("POST", URITenantVerify, nil)
+			if tc.Authorization != "" {
+				req.Header.Set(hdrAuthorization, tc.Authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.Status, w.Code)
+			if tc.Body != "" {
+				assert.JSONEq(t, tc.Body, w.Body.String())
+			}
+		})
+	}
+}
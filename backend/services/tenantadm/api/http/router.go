@@ -0,0 +1,101 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/mendersoftware/mender-server/pkg/requestsize"
+	"github.com/mendersoftware/mender-server/pkg/routing"
+
+	"github.com/mendersoftware/mender-server/services/tenantadm/app"
+	tconfig "github.com/mendersoftware/mender-server/services/tenantadm/config"
+)
+
+// API URL used by the HTTP router
+const (
+	pathParamTenantID = "tenant_id"
+
+	URIInternal = "/api/internal/v1/tenantadm"
+
+	URITenants      = "/tenants"
+	URITenant       = "/tenants/:tenant_id"
+	URITenantStatus = "/tenants/:tenant_id/status"
+	URITenantToken  = "/tenants/:tenant_id/tenant_token"
+	URITenantVerify = "/tenants/verify"
+
+	URIAlive  = "/alive"
+	URIHealth = "/health"
+)
+
+type APIHandler struct {
+	App app.App
+}
+
+func NewAPIHandler(app app.App) *APIHandler {
+	return &APIHandler{
+		App: app,
+	}
+}
+
+type Config struct {
+	MaxRequestSize int64
+}
+
+func NewConfig() *Config {
+	return &Config{
+		MaxRequestSize: tconfig.SettingMaxRequestSizeDefault,
+	}
+}
+
+type Option func(c *Config)
+
+func SetMaxRequestSize(size int64) Option {
+	return func(c *Config) {
+		c.MaxRequestSize = size
+	}
+}
+
+// NewRouter initializes a new gin.Engine as a http.Handler
+func NewRouter(app app.App, options ...Option) http.Handler {
+	config := NewConfig()
+	for _, option := range options {
+		if option != nil {
+			option(config)
+		}
+	}
+
+	router := routing.NewGinRouter()
+	router.Use(requestsize.Middleware(config.MaxRequestSize))
+
+	apiHandler := NewAPIHandler(app)
+
+	intrnlAPI := (*InternalAPI)(apiHandler)
+	intrnlGrp := router.Group(URIInternal)
+
+	intrnlGrp.GET(URIAlive, intrnlAPI.Alive)
+	intrnlGrp.GET(URIHealth, intrnlAPI.Health)
+
+	intrnlGrp.POST(URITenants, intrnlAPI.CreateTenant)
+	intrnlGrp.GET(URITenants, intrnlAPI.ListTenants)
+	intrnlGrp.GET(URITenant, intrnlAPI.GetTenant)
+	intrnlGrp.PUT(URITenant, intrnlAPI.UpdateTenant)
+	intrnlGrp.DELETE(URITenant, intrnlAPI.DeleteTenant)
+	intrnlGrp.PUT(URITenantStatus, intrnlAPI.UpdateTenantStatus)
+	intrnlGrp.POST(URITenantToken, intrnlAPI.RotateTenantToken)
+	intrnlGrp.POST(URITenantVerify, intrnlAPI.VerifyTenantToken)
+
+	return router
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/tenantadm/client/workflows"
+	"github.com/mendersoftware/mender-server/services/tenantadm/model"
+	"github.com/mendersoftware/mender-server/services/tenantadm/store"
+)
+
+var (
+	// ErrInvalidTenantToken is returned when verifying a tenant token
+	// which does not belong to any tenant
+	ErrInvalidTenantToken = errors.New("invalid tenant token")
+)
+
+// App interface describes app objects
+//
+//nolint:lll
+//go:generate ../../../utils/mockgen.sh
+type App interface {
+	HealthCheck(ctx context.Context) error
+
+	// CreateTenant creates the tenant and starts its provisioning in all
+	// the services.
+	CreateTenant(ctx context.Context, req model.NewTenantRequest) (*model.Tenant, error)
+	GetTenant(ctx context.Context, id string) (*model.Tenant, error)
+	ListTenants(ctx context.Context, filter model.TenantFilter) ([]model.Tenant, int64, error)
+	UpdateTenant(ctx context.Context, id string, update model.TenantUpdate) error
+	// UpdateTenantStatus suspends or resumes the tenant; suspending a
+	// tenant revokes the tokens of its devices.
+	UpdateTenantStatus(ctx context.Context, id string, status model.TenantStatus) error
+	// RotateTenantToken replaces the tenant token and returns the new one.
+	RotateTenantToken(ctx context.Context, id string) (string, error)
+	// DeleteTenant starts the removal of the tenant data from all the
+	// services and deletes the tenant.
+	DeleteTenant(ctx context.Context, id string) error
+
+	// VerifyTenantToken returns the tenant owning the tenant token.
+	VerifyTenantToken(ctx context.Context, token string) (*model.Tenant, error)
+}
+
+// app is an app object
+type app struct {
+	store     store.DataStore
+	workflows workflows.Client
+}
+
+// New initializes a new tenantadm App
+func New(ds store.DataStore, wf workflows.Client) App {
+	return &app{
+		store:     ds,
+		workflows: wf,
+	}
+}
+
+// HealthCheck performs a health check and returns an error if it fails
+func (a *app) HealthCheck(ctx context.Context) error {
+	err := a.store.Ping(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error reaching MongoDB")
+	}
+	err = a.workflows.CheckHealth(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Workflows service unhealthy")
+	}
+	return nil
+}
+
+func (a *app) CreateTenant(
+	ctx context.Context,
+	req model.NewTenantRequest,
+) (*model.Tenant, error) {
+	tenant, err := model.NewTenant(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.store.InsertTenant(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	err = a.workflows.ProvisionTenant(ctx, tenant.ID)
+	if err != nil {
+		// don't leave behind a tenant which was never provisioned
+		if errDelete := a.store.DeleteTenant(ctx, tenant.ID); errDelete != nil {
+			log.FromContext(ctx).Errorf(
+				"failed to remove tenant %s after failing to provision it: %s",
+				tenant.ID, errDelete.Error())
+		}
+		return nil, errors.Wrap(err, "failed to provision the tenant")
+	}
+	return tenant, nil
+}
+
+func (a *app) GetTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	return a.store.GetTenant(ctx, id)
+}
+
+func (a *app) ListTenants(
+	ctx context.Context,
+	filter model.TenantFilter,
+) ([]model.Tenant, int64, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, -1, err
+	}
+	return a.store.ListTenants(ctx, filter)
+}
+
+func (a *app) UpdateTenant(ctx context.Context, id string, update model.TenantUpdate) error {
+	return a.store.UpdateTenant(ctx, id, update)
+}
+
+func (a *app) UpdateTenantStatus(
+	ctx context.Context,
+	id string,
+	status model.TenantStatus,
+) error {
+	if err := status.Validate(); err != nil {
+		return err
+	}
+	tenant, err := a.store.GetTenant(ctx, id)
+	if err != nil {
+		return err
+	} else if tenant.Status == status {
+		return nil
+	}
+
+	// update the status first, deviceauth refuses the authentication
+	// requests of suspended tenants
+	if err = a.store.UpdateTenantStatus(ctx, id, status); err != nil {
+		return err
+	}
+	if status == model.TenantStatusSuspended {
+		err = a.workflows.SuspendTenant(ctx, id)
+		if err != nil {
+			return errors.Wrap(err, "failed to revoke the device tokens")
+		}
+	}
+	return nil
+}
+
+func (a *app) RotateTenantToken(ctx context.Context, id string) (string, error) {
+	token, err := model.NewTenantToken()
+	if err != nil {
+		return "", err
+	}
+	if err = a.store.UpdateTenantToken(ctx, id, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (a *app) DeleteTenant(ctx context.Context, id string) error {
+	if _, err := a.store.GetTenant(ctx, id); err != nil {
+		return err
+	}
+	// the tenant is removed only once the workflow is submitted, so that
+	// the deletion can be retried on failure
+	err := a.workflows.DeleteTenant(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete the tenant data")
+	}
+	return a.store.DeleteTenant(ctx, id)
+}
+
+func (a *app) VerifyTenantToken(ctx context.Context, token string) (*model.Tenant, error) {
+	tenant, err := a.store.GetTenantByToken(ctx, token)
+	if errors.Is(err, store.ErrTenantNotFound) {
+		return nil, ErrInvalidTenantToken
+	} else if err != nil {
+		return nil, err
+	}
+	tenant.TenantToken = ""
+	return tenant, nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	wf_mocks "github.com/mendersoftware/mender-server/services/tenantadm/client/workflows/mocks"
+	"github.com/mendersoftware/mender-server/services/tenantadm/model"
+	"github.com/mendersoftware/mender-server/services/tenantadm/store"
+	"github.com/mendersoftware/mender-server/services/tenantadm/store/mocks"
+)
+
+func TestHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+	wf := new(wf_mocks.Client)
+	defer wf.AssertExpectations(t)
+
+	ds.On("Ping", ctx).Return(errors.New("connection refused")).Once()
+	err := New(ds, wf).HealthCheck(ctx)
+	assert.EqualError(t, err, "error reaching MongoDB: connection refused")
+
+	ds.On("Ping", ctx).Return(nil)
+	wf.On("CheckHealth", ctx).Return(errors.New("unavailable"))
+	err = New(ds, wf).HealthCheck(ctx)
+	assert.EqualError(t, err, "Workflows service unhealthy: unavailable")
+}
+
+func TestCreateTenant(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		InsertErr    error
+		ProvisionErr error
+
+		Error error
+	}{
+		"ok": {},
+		"error, duplicate name": {
+			InsertErr: store.ErrDuplicateTenantName,
+			Error:     store.ErrDuplicateTenantName,
+		},
+		"error, provisioning": {
+			ProvisionErr: errors.New("unavailable"),
+			Error:        errors.New("failed to provision the tenant: unavailable"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			ds := new(mocks.DataStore)
+			defer ds.AssertExpectations(t)
+			wf := new(wf_mocks.Client)
+			defer wf.AssertExpectations(t)
+
+			var tenantID string
+			ds.On("InsertTenant", ctx, mock.MatchedBy(func(tenant *model.Tenant) bool {
+				tenantID = tenant.ID
+				return tenant.Name == "acme" &&
+					tenant.Status == model.TenantStatusActive &&
+					tenant.TenantToken != ""
+			})).Return(tc.InsertErr)
+			if tc.InsertErr == nil {
+				wf.On("ProvisionTenant", ctx, mock.AnythingOfType("string")).
+					Return(tc.ProvisionErr)
+			}
+			if tc.ProvisionErr != nil {
+				ds.On("DeleteTenant", ctx, mock.AnythingOfType("string")).
+					Return(nil)
+			}
+
+			tenant, err := New(ds, wf).CreateTenant(ctx, model.NewTenantRequest{
+				Name: "acme",
+			})
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+				assert.Nil(t, tenant)
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, tenant) {
+					assert.Equal(t, tenantID, tenant.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestListTenants(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+
+	filter := model.TenantFilter{Status: model.TenantStatusActive, Limit: 20}
+	tenants := []model.Tenant{{ID: "1"}}
+	ds.On("ListTenants", ctx, filter).Return(tenants, int64(1), nil)
+
+	app := New(ds, nil)
+	res, count, err := app.ListTenants(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, tenants, res)
+	assert.Equal(t, int64(1), count)
+
+	_, _, err = app.ListTenants(ctx, model.TenantFilter{Status: "deleted"})
+	assert.ErrorIs(t, err, model.ErrInvalidTenantStatus)
+}
+
+func TestUpdateTenantStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		Status  model.TenantStatus
+		Current model.TenantStatus
+		GetErr  error
+
+		Update     bool
+		Suspend    bool
+		SuspendErr error
+
+		Error error
+	}{
+		"ok, suspend": {
+			Status:  model.TenantStatusSuspended,
+			Current: model.TenantStatusActive,
+			Update:  true,
+			Suspend: true,
+		},
+		"ok, resume": {
+			Status:  model.TenantStatusActive,
+			Current: model.TenantStatusSuspended,
+			Update:  true,
+		},
+		"ok, unchanged": {
+			Status:  model.TenantStatusSuspended,
+			Current: model.TenantStatusSuspended,
+		},
+		"error, invalid status": {
+			Status: "deleted",
+			Error:  model.ErrInvalidTenantStatus,
+		},
+		"error, not found": {
+			Status: model.TenantStatusSuspended,
+			GetErr: store.ErrTenantNotFound,
+			Error:  store.ErrTenantNotFound,
+		},
+		"error, revoking tokens": {
+			Status:     model.TenantStatusSuspended,
+			Current:    model.TenantStatusActive,
+			Update:     true,
+			Suspend:    true,
+			SuspendErr: errors.New("unavailable"),
+			Error:      errors.New("failed to revoke the device tokens: unavailable"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			ds := new(mocks.DataStore)
+			defer ds.AssertExpectations(t)
+			wf := new(wf_mocks.Client)
+			defer wf.AssertExpectations(t)
+
+			if tc.Current != "" || tc.GetErr != nil {
+				var tenant *model.Tenant
+				if tc.GetErr == nil {
+					tenant = &model.Tenant{ID: "tenant", Status: tc.Current}
+				}
+				ds.On("GetTenant", ctx, "tenant").Return(tenant, tc.GetErr)
+			}
+			if tc.Update {
+				ds.On("UpdateTenantStatus", ctx, "tenant", tc.Status).Return(nil)
+			}
+			if tc.Suspend {
+				wf.On("SuspendTenant", ctx, "tenant").Return(tc.SuspendErr)
+			}
+
+			err := New(ds, wf).UpdateTenantStatus(ctx, "tenant", tc.Status)
+			if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRotateTenantToken(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+
+	var stored string
+	ds.On("UpdateTenantToken", ctx, "tenant", mock.MatchedBy(func(token string) bool {
+		stored = token
+		return token != ""
+	})).Return(nil).Once()
+	ds.On("UpdateTenantToken", ctx, "missing", mock.AnythingOfType("string")).
+		Return(store.ErrTenantNotFound).Once()
+
+	app := New(ds, nil)
+	token, err := app.RotateTenantToken(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, stored, token)
+
+	_, err = app.RotateTenantToken(ctx, "missing")
+	assert.ErrorIs(t, err, store.ErrTenantNotFound)
+}
+
+func TestDeleteTenant(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+	wf := new(wf_mocks.Client)
+	defer wf.AssertExpectations(t)
+
+	ds.On("GetTenant", ctx, "tenant").Return(&model.Tenant{ID: "tenant"}, nil)
+	ds.On("GetTenant", ctx, "missing").Return(nil, store.ErrTenantNotFound)
+	ds.On("GetTenant", ctx, "failing").Return(&model.Tenant{ID: "failing"}, nil)
+	wf.On("DeleteTenant", ctx, "tenant").Return(nil)
+	wf.On("DeleteTenant", ctx, "failing").Return(errors.New("unavailable"))
+	ds.On("DeleteTenant", ctx, "tenant").Return(nil)
+
+	app := New(ds, wf)
+	assert.NoError(t, app.DeleteTenant(ctx, "tenant"))
+	assert.ErrorIs(t, app.DeleteTenant(ctx, "missing"), store.ErrTenantNotFound)
+	assert.EqualError(t, app.DeleteTenant(ctx, "failing"),
+		"failed to delete the tenant data: unavailable")
+}
+
+func TestVerifyTenantToken(t *testing.T) {
+	ctx := context.Background()
+	ds := new(mocks.DataStore)
+	defer ds.AssertExpectations(t)
+
+	ds.On("GetTenantByToken", ctx, "token").Return(&model.Tenant{
+		ID:          "tenant",
+		Status:      model.TenantStatusSuspended,
+		TenantToken: "token",
+	}, nil)
+	ds.On("GetTenantByToken", ctx, "other").Return(nil, store.ErrTenantNotFound)
+	ds.On("GetTenantByToken", ctx, "failing").Return(nil, errors.New("internal error"))
+
+	app := New(ds, nil)
+	tenant, err := app.VerifyTenantToken(ctx, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Tenant{
+		ID:     "tenant",
+		Status: model.TenantStatusSuspended,
+	}, tenant)
+
+	_, err = app.VerifyTenantToken(ctx, "other")
+	assert.ErrorIs(t, err, ErrInvalidTenantToken)
+	_, err = app.VerifyTenantToken(ctx, "failing")
+	assert.EqualError(t, err, "internal error")
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package workflows
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+)
+
+const (
+	HealthURI          = "/api/v1/health"
+	ProvisionTenantURI = "/api/v1/workflow/provision_tenant"
+	SuspendTenantURI   = "/api/v1/workflow/suspend_tenant"
+	DeleteTenantURI    = "/api/v1/workflow/delete_tenant"
+)
+
+const (
+	defaultTimeout = time.Duration(5) * time.Second
+)
+
+// Client is the workflows client
+//
+//go:generate ../../../../utils/mockgen.sh
+type Client interface {
+	CheckHealth(ctx context.Context) error
+	// ProvisionTenant starts the workflow initializing the tenant in
+	// all the services.
+	ProvisionTenant(ctx context.Context, tenantID string) error
+	// SuspendTenant starts the workflow revoking the device tokens of
+	// the tenant.
+	SuspendTenant(ctx context.Context, tenantID string) error
+	// DeleteTenant starts the workflow removing the tenant data from
+	// all the services.
+	DeleteTenant(ctx context.Context, tenantID string) error
+}
+
+type ClientOptions struct {
+	Client *http.Client
+}
+
+// NewClient returns a new workflows client
+func NewClient(url string, opts ...ClientOptions) Client {
+	// Initialize default options
+	var clientOpts = ClientOptions{
+		Client: &http.Client{},
+	}
+	// Merge options
+	for _, opt := range opts {
+		if opt.Client != nil {
+			clientOpts.Client = opt.Client
+		}
+	}
+
+	return &client{
+		url:    strings.TrimSuffix(url, "/"),
+		client: *clientOpts.Client,
+	}
+}
+
+type client struct {
+	url    string
+	client http.Client
+}
+
+func (c *client) CheckHealth(ctx context.Context) error {
+	var (
+		apiErr rest.Error
+	)
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	req, _ := http.NewRequestWithContext(
+		ctx, "GET", c.url+HealthURI, nil,
+	)
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode >= http.StatusOK && rsp.StatusCode < 300 {
+		return nil
+	}
+	decoder := json.NewDecoder(rsp.Body)
+	err = decoder.Decode(&apiErr)
+	if err != nil {
+		return errors.Errorf("health check HTTP error: %s", rsp.Status)
+	}
+	return &apiErr
+}
+
+func (c *client) ProvisionTenant(ctx context.Context, tenantID string) error {
+	return c.submitTenantWorkflow(ctx, ProvisionTenantURI, tenantID)
+}
+
+func (c *client) SuspendTenant(ctx context.Context, tenantID string) error {
+	return c.submitTenantWorkflow(ctx, SuspendTenantURI, tenantID)
+}
+
+func (c *client) DeleteTenant(ctx context.Context, tenantID string) error {
+	return c.submitTenantWorkflow(ctx, DeleteTenantURI, tenantID)
+}
+
+func (c *client) submitTenantWorkflow(ctx context.Context, uri, tenantID string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	wflow := TenantWorkflow{
+		RequestID: requestid.FromContext(ctx),
+		TenantID:  tenantID,
+	}
+	payload, _ := json.Marshal(wflow)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		c.url+uri,
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err, "workflows: error preparing HTTP request")
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to submit workflow")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 300 {
+		return nil
+	}
+
+	if rsp.StatusCode == http.StatusNotFound {
+		name := uri[strings.LastIndex(uri, "/")+1:]
+		return errors.Errorf(`workflows: workflow "%s" not defined`, name)
+	}
+
+	return errors.Errorf(
+		"workflows: unexpected HTTP status from workflows service: %s",
+		rsp.Status,
+	)
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+)
+
+func TestSubmitTenantWorkflow(t *testing.T) {
+	t.Parallel()
+
+	type submitFunc func(Client, context.Context, string) error
+	testCases := []struct {
+		Name string
+
+		Submit submitFunc
+		URI    string
+		Status int
+
+		Error string
+	}{
+		{
+			Name: "ok, provision tenant",
+
+			Submit: Client.ProvisionTenant,
+			URI:    ProvisionTenantURI,
+			Status: http.StatusCreated,
+		},
+		{
+			Name: "ok, suspend tenant",
+
+			Submit: Client.SuspendTenant,
+			URI:    SuspendTenantURI,
+			Status: http.StatusCreated,
+		},
+		{
+			Name: "ok, delete tenant",
+
+			Submit: Client.DeleteTenant,
+			URI:    DeleteTenantURI,
+			Status: http.StatusCreated,
+		},
+		{
+			Name: "error, workflow not defined",
+
+			Submit: Client.DeleteTenant,
+			URI:    DeleteTenantURI,
+			Status: http.StatusNotFound,
+			Error:  `workflows: workflow "delete_tenant" not defined`,
+		},
+		{
+			Name: "error, unexpected status",
+
+			Submit: Client.ProvisionTenant,
+			URI:    ProvisionTenantURI,
+			Status: http.StatusInternalServerError,
+			Error: "workflows: unexpected HTTP status from workflows service: " +
+				"500 Internal Server Error",
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, tc.URI, r.URL.Path)
+					var wflow TenantWorkflow
+					err := json.NewDecoder(r.Body).Decode(&wflow)
+					assert.NoError(t, err)
+					assert.Equal(t, "tenant", wflow.TenantID)
+					assert.Equal(t, "request-id", wflow.RequestID)
+					w.WriteHeader(tc.Status)
+				},
+			))
+			defer srv.Close()
+
+			ctx := requestid.WithContext(context.Background(), "request-id")
+			err := tc.Submit(NewClient(srv.URL+"/"), ctx, "tenant")
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name string
+
+		Status int
+		Body   string
+
+		Error string
+	}{
+		{
+			Name:   "ok",
+			Status: http.StatusNoContent,
+		},
+		{
+			Name:   "error, unhealthy",
+			Status: http.StatusServiceUnavailable,
+			Body:   `{"error": "nats unreachable"}`,
+			Error:  "nats unreachable",
+		},
+		{
+			Name:   "error, unexpected body",
+			Status: http.StatusBadGateway,
+			Error:  "health check HTTP error: 502 Bad Gateway",
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, HealthURI, r.URL.Path)
+					w.WriteHeader(tc.Status)
+					_, _ = w.Write([]byte(tc.Body))
+				},
+			))
+			defer srv.Close()
+
+			err := NewClient(srv.URL).CheckHealth(context.Background())
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
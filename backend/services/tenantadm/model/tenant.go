@@ -0,0 +1,159 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// TenantStatus is the status of a tenant
+type TenantStatus string
+
+const (
+	// TenantStatusActive is the status of tenants whose devices can
+	// authenticate.
+	TenantStatusActive TenantStatus = "active"
+	// TenantStatusSuspended is the status of tenants whose devices are
+	// refused by deviceauth.
+	TenantStatusSuspended TenantStatus = "suspended"
+
+	// tenantTokenLength is the number of random bytes of a tenant token
+	tenantTokenLength = 32
+
+	tenantNameMaxLength = 256
+)
+
+var (
+	ErrInvalidTenantStatus = errors.New("invalid tenant status")
+)
+
+func (s TenantStatus) Validate() error {
+	switch s {
+	case TenantStatusActive, TenantStatusSuspended:
+		return nil
+	default:
+		return ErrInvalidTenantStatus
+	}
+}
+
+// Tenant is an organization sharing the Mender installation with other
+// tenants; its devices authenticate using the tenant token.
+type Tenant struct {
+	ID          string       `json:"id" bson:"_id"`
+	Name        string       `json:"name" bson:"name"`
+	Status      TenantStatus `json:"status" bson:"status"`
+	TenantToken string       `json:"tenant_token,omitempty" bson:"tenant_token"`
+	CreatedTS   time.Time    `json:"created_ts" bson:"created_ts"`
+	UpdatedTS   time.Time    `json:"updated_ts" bson:"updated_ts"`
+}
+
+// NewTenant returns a new active tenant with a freshly generated tenant
+// token.
+func NewTenant(name string) (*Tenant, error) {
+	token, err := NewTenantToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &Tenant{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Status:      TenantStatusActive,
+		TenantToken: token,
+		CreatedTS:   now,
+		UpdatedTS:   now,
+	}, nil
+}
+
+// NewTenantToken generates a random tenant token.
+func NewTenantToken() (string, error) {
+	b := make([]byte, tenantTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate tenant token")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewTenantRequest is the request body for creating a tenant.
+type NewTenantRequest struct {
+	Name string `json:"name"`
+}
+
+func (t NewTenantRequest) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Name,
+			validation.Required,
+			validation.Length(1, tenantNameMaxLength),
+		),
+	)
+}
+
+// TenantUpdate holds the updatable attributes of a tenant.
+type TenantUpdate struct {
+	Name string `json:"name"`
+}
+
+func (t TenantUpdate) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Name,
+			validation.Required,
+			validation.Length(1, tenantNameMaxLength),
+		),
+	)
+}
+
+// TenantStatusUpdate is the request body for suspending or resuming a
+// tenant.
+type TenantStatusUpdate struct {
+	Status TenantStatus `json:"status"`
+}
+
+func (t TenantStatusUpdate) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Status, validation.Required),
+	)
+}
+
+// TenantTokenResponse is the response body for the tenant token rotation.
+type TenantTokenResponse struct {
+	TenantToken string `json:"tenant_token"`
+}
+
+// TenantFilter filters and paginates the list of tenants.
+type TenantFilter struct {
+	Name   string
+	Status TenantStatus
+
+	Skip  int64
+	Limit int64
+}
+
+func (f TenantFilter) Validate() error {
+	if f.Status != "" {
+		if err := f.Status.Validate(); err != nil {
+			return err
+		}
+	}
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Skip, validation.Min(int64(0))),
+		validation.Field(&f.Limit, validation.Min(int64(0))),
+	)
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTenant(t *testing.T) {
+	t.Parallel()
+
+	tenant, err := NewTenant("acme")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tenant.ID)
+	assert.Equal(t, "acme", tenant.Name)
+	assert.Equal(t, TenantStatusActive, tenant.Status)
+	assert.Len(t, tenant.TenantToken, 43)
+	assert.Equal(t, tenant.CreatedTS, tenant.UpdatedTS)
+
+	other, err := NewTenant("acme")
+	assert.NoError(t, err)
+	assert.NotEqual(t, tenant.ID, other.ID)
+	assert.NotEqual(t, tenant.TenantToken, other.TenantToken)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	longName := string(make([]byte, tenantNameMaxLength+1))
+	testCases := []struct {
+		Name string
+
+		Value interface{ Validate() error }
+		Error bool
+	}{{
+		Name:  "ok, new tenant",
+		Value: NewTenantRequest{Name: "acme"},
+	}, {
+		Name:  "error, new tenant without name",
+		Value: NewTenantRequest{},
+		Error: true,
+	}, {
+		Name:  "error, new tenant name too long",
+		Value: NewTenantRequest{Name: longName},
+		Error: true,
+	}, {
+		Name:  "ok, tenant update",
+		Value: TenantUpdate{Name: "ACME"},
+	}, {
+		Name:  "error, tenant update without name",
+		Value: TenantUpdate{},
+		Error: true,
+	}, {
+		Name:  "ok, suspend",
+		Value: TenantStatusUpdate{Status: TenantStatusSuspended},
+	}, {
+		Name:  "error, missing status",
+		Value: TenantStatusUpdate{},
+		Error: true,
+	}, {
+		Name:  "error, unknown status",
+		Value: TenantStatusUpdate{Status: "deleted"},
+		Error: true,
+	}, {
+		Name:  "ok, empty filter",
+		Value: TenantFilter{},
+	}, {
+		Name:  "ok, filter",
+		Value: TenantFilter{Name: "acme", Status: TenantStatusActive, Limit: 20},
+	}, {
+		Name:  "error, filter with unknown status",
+		Value: TenantFilter{Status: "deleted"},
+		Error: true,
+	}, {
+		Name:  "error, filter with negative skip",
+		Value: TenantFilter{Skip: -1},
+		Error: true,
+	}}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.Value.Validate()
+			if tc.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
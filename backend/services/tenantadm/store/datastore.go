@@ -0,0 +1,63 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mendersoftware/mender-server/services/tenantadm/model"
+)
+
+var (
+	// ErrTenantNotFound is returned when the tenant does not exist
+	ErrTenantNotFound = errors.New("tenant not found")
+	// ErrDuplicateTenantName is returned when creating or renaming a
+	// tenant with the name of another tenant
+	ErrDuplicateTenantName = errors.New("a tenant with the same name already exists")
+)
+
+// DataStore interface for DataStore services
+//
+//nolint:lll - skip line length check for interface declaration.
+//go:generate ../../../utils/mockgen.sh
+type DataStore interface {
+	Ping(ctx context.Context) error
+	Close(ctx context.Context) error
+
+	// Migrate applies the migrations up to the given version.
+	Migrate(ctx context.Context, version string, automigrate bool) error
+
+	// MigrateLatest calls Migrate with the latest schema version.
+	MigrateLatest(ctx context.Context) error
+
+	// InsertTenant stores a new tenant.
+	InsertTenant(ctx context.Context, tenant *model.Tenant) error
+	// GetTenant returns the tenant with the given ID.
+	GetTenant(ctx context.Context, id string) (*model.Tenant, error)
+	// GetTenantByToken returns the tenant owning the tenant token.
+	GetTenantByToken(ctx context.Context, token string) (*model.Tenant, error)
+	// ListTenants returns the page of tenants matching the filter together
+	// with the total number of matching tenants.
+	ListTenants(ctx context.Context, filter model.TenantFilter) ([]model.Tenant, int64, error)
+	// UpdateTenant updates the attributes of the tenant.
+	UpdateTenant(ctx context.Context, id string, update model.TenantUpdate) error
+	// UpdateTenantStatus sets the status of the tenant.
+	UpdateTenantStatus(ctx context.Context, id string, status model.TenantStatus) error
+	// UpdateTenantToken replaces the tenant token of the tenant.
+	UpdateTenantToken(ctx context.Context, id string, token string) error
+	// DeleteTenant removes the tenant.
+	DeleteTenant(ctx context.Context, id string) error
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopts "go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mendersoftware/mender-server/services/tenantadm/model"
+	"github.com/mendersoftware/mender-server/services/tenantadm/store"
+)
+
+const (
+	// CollTenants refers to the collection name for tenants
+	CollTenants = "tenants"
+	// fields
+	fieldID          = "_id"
+	fieldName        = "name"
+	fieldStatus      = "status"
+	fieldTenantToken = "tenant_token"
+	fieldUpdatedTS   = "updated_ts"
+)
+
+type MongoStoreConfig struct {
+	// MongoURL holds the URL to the MongoDB server.
+	MongoURL *url.URL
+	// TLSConfig holds optional tls configuration options for connecting
+	// to the MongoDB server.
+	TLSConfig *tls.Config
+	// Username holds the user id credential for authenticating with the
+	// MongoDB server.
+	Username string
+	// Password holds the password credential for authenticating with the
+	// MongoDB server.
+	Password string
+
+	// DbName contains the name of the tenantadm database.
+	DbName string
+}
+
+// newClient returns a mongo client
+func newClient(ctx context.Context, config MongoStoreConfig) (*mongo.Client, error) {
+	clientOptions := mopts.Client()
+	if config.MongoURL == nil {
+		return nil, errors.New("mongo: missing URL")
+	}
+	clientOptions.ApplyURI(config.MongoURL.String())
+
+	if config.Username != "" {
+		credentials := mopts.Credential{
+			Username: config.Username,
+		}
+		if config.Password != "" {
+			credentials.Password = config.Password
+			credentials.PasswordSet = true
+		}
+		clientOptions.SetAuth(credentials)
+	}
+
+	if config.TLSConfig != nil {
+		clientOptions.SetTLSConfig(config.TLSConfig)
+	}
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to connect with server")
+	}
+
+	// Validate connection
+	if err = client.Ping(ctx, nil); err != nil {
+		return nil, errors.Wrap(err, "mongo: error reaching mongo server")
+	}
+
+	return client, nil
+}
+
+// MongoStore is the data storage service
+type MongoStore struct {
+	// client holds the reference to the client used to communicate with the
+	// mongodb server.
+	client *mongo.Client
+
+	config MongoStoreConfig
+}
+
+// NewMongoStore connects to the database and returns the mongo data store
+func NewMongoStore(ctx context.Context, config MongoStoreConfig) (*MongoStore, error) {
+	dbClient, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return NewMongoStoreWithClient(dbClient, config), nil
+}
+
+// NewMongoStoreWithClient returns the mongo data store using an existing client
+func NewMongoStoreWithClient(client *mongo.Client, config MongoStoreConfig) *MongoStore {
+	if config.DbName == "" {
+		config.DbName = DbName
+	}
+	return &MongoStore{
+		client: client,
+		config: config,
+	}
+}
+
+func (db *MongoStore) Database(opt ...*mopts.DatabaseOptions) *mongo.Database {
+	return db.client.Database(db.config.DbName, opt...)
+}
+
+// Ping verifies the connection to the database
+func (db *MongoStore) Ping(ctx context.Context) error {
+	res := db.Database().RunCommand(ctx, bson.M{"ping": 1})
+	return res.Err()
+}
+
+// Close disconnects the client
+func (db *MongoStore) Close(ctx context.Context) error {
+	err := db.client.Disconnect(ctx)
+	return err
+}
+
+func (db *MongoStore) InsertTenant(ctx context.Context, tenant *model.Tenant) error {
+	collTenants := db.Database().Collection(CollTenants)
+
+	_, err := collTenants.InsertOne(ctx, tenant)
+	if mongo.IsDuplicateKeyError(err) {
+		return store.ErrDuplicateTenantName
+	}
+	return errors.Wrap(err, "mongo: failed to store tenant")
+}
+
+func (db *MongoStore) findTenant(ctx context.Context, filter bson.D) (*model.Tenant, error) {
+	collTenants := db.Database().Collection(CollTenants)
+
+	var tenant model.Tenant
+	err := collTenants.FindOne(ctx, filter).Decode(&tenant)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrTenantNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "mongo: failed to get tenant")
+	}
+	return &tenant, nil
+}
+
+func (db *MongoStore) GetTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	return db.findTenant(ctx, bson.D{{Key: fieldID, Value: id}})
+}
+
+func (db *MongoStore) GetTenantByToken(
+	ctx context.Context,
+	token string,
+) (*model.Tenant, error) {
+	if token == "" {
+		return nil, store.ErrTenantNotFound
+	}
+	return db.findTenant(ctx, bson.D{{Key: fieldTenantToken, Value: token}})
+}
+
+func (db *MongoStore) ListTenants(
+	ctx context.Context,
+	filter model.TenantFilter,
+) ([]model.Tenant, int64, error) {
+	collTenants := db.Database().Collection(CollTenants)
+
+	query := bson.D{}
+	if filter.Name != "" {
+		query = append(query, bson.E{Key: fieldName, Value: primitive.Regex{
+			Pattern: regexp.QuoteMeta(filter.Name),
+			Options: "i",
+		}})
+	}
+	if filter.Status != "" {
+		query = append(query, bson.E{Key: fieldStatus, Value: filter.Status})
+	}
+
+	count, err := collTenants.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to count tenants")
+	}
+
+	opts := mopts.Find().
+		SetSort(bson.D{{Key: fieldName, Value: 1}})
+	if filter.Skip > 0 {
+		opts.SetSkip(filter.Skip)
+	}
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+	cur, err := collTenants.Find(ctx, query, opts)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to query tenants")
+	}
+	tenants := []model.Tenant{}
+	if err = cur.All(ctx, &tenants); err != nil {
+		return nil, -1, errors.Wrap(err, "mongo: failed to decode tenants")
+	}
+	return tenants, count, nil
+}
+
+func (db *MongoStore) updateTenant(ctx context.Context, id string, set bson.D) error {
+	collTenants := db.Database().Collection(CollTenants)
+
+	set = append(set, bson.E{Key: fieldUpdatedTS, Value: time.Now().UTC()})
+	res, err := collTenants.UpdateOne(ctx,
+		bson.D{{Key: fieldID, Value: id}},
+		bson.D{{Key: "$set", Value: set}},
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return store.ErrDuplicateTenantName
+	} else if err != nil {
+		return errors.Wrap(err, "mongo: failed to update tenant")
+	} else if res.MatchedCount == 0 {
+		return store.ErrTenantNotFound
+	}
+	return nil
+}
+
+func (db *MongoStore) UpdateTenant(
+	ctx context.Context,
+	id string,
+	update model.TenantUpdate,
+) error {
+	return db.updateTenant(ctx, id, bson.D{{Key: fieldName, Value: update.Name}})
+}
+
+func (db *MongoStore) UpdateTenantStatus(
+	ctx context.Context,
+	id string,
+	status model.TenantStatus,
+) error {
+	return db.updateTenant(ctx, id, bson.D{{Key: fieldStatus, Value: status}})
+}
+
+func (db *MongoStore) UpdateTenantToken(ctx context.Context, id string, token string) error {
+	return db.updateTenant(ctx, id, bson.D{{Key: fieldTenantToken, Value: token}})
+}
+
+func (db *MongoStore) DeleteTenant(ctx context.Context, id string) error {
+	collTenants := db.Database().Collection(CollTenants)
+
+	res, err := collTenants.DeleteOne(ctx, bson.D{{Key: fieldID, Value: id}})
+	if err != nil {
+		return errors.Wrap(err, "mongo: failed to delete tenant")
+	} else if res.DeletedCount == 0 {
+		return store.ErrTenantNotFound
+	}
+	return nil
+}
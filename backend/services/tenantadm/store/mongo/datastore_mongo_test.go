@@ -0,0 +1,118 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/tenantadm/model"
+	"github.com/mendersoftware/mender-server/services/tenantadm/store"
+)
+
+func newTestStore(t *testing.T) *MongoStore {
+	if testing.Short() {
+		t.Skip("skipping mongo test in short mode")
+	}
+	db.Wipe()
+	ds := NewMongoStoreWithClient(db.Client(), MongoStoreConfig{
+		DbName: DbName,
+	})
+	require.NoError(t, ds.MigrateLatest(context.Background()))
+	return ds
+}
+
+func newTestTenant(t *testing.T, name string) *model.Tenant {
+	tenant, err := model.NewTenant(name)
+	require.NoError(t, err)
+	tenant.CreatedTS = tenant.CreatedTS.Truncate(time.Millisecond)
+	tenant.UpdatedTS = tenant.UpdatedTS.Truncate(time.Millisecond)
+	return tenant
+}
+
+func TestTenants(t *testing.T) {
+	ds := newTestStore(t)
+	ctx := context.Background()
+
+	acme := newTestTenant(t, "acme")
+	require.NoError(t, ds.InsertTenant(ctx, acme))
+	other := newTestTenant(t, "Other unit")
+	require.NoError(t, ds.InsertTenant(ctx, other))
+
+	err := ds.InsertTenant(ctx, newTestTenant(t, "acme"))
+	assert.ErrorIs(t, err, store.ErrDuplicateTenantName)
+
+	tenant, err := ds.GetTenant(ctx, acme.ID)
+	require.NoError(t, err)
+	assert.Equal(t, acme, tenant)
+
+	tenant, err = ds.GetTenantByToken(ctx, other.TenantToken)
+	require.NoError(t, err)
+	assert.Equal(t, other, tenant)
+
+	_, err = ds.GetTenant(ctx, "missing")
+	assert.ErrorIs(t, err, store.ErrTenantNotFound)
+	_, err = ds.GetTenantByToken(ctx, "")
+	assert.ErrorIs(t, err, store.ErrTenantNotFound)
+
+	tenants, count, err := ds.ListTenants(ctx, model.TenantFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []model.Tenant{*other, *acme}, tenants)
+
+	tenants, count, err = ds.ListTenants(ctx, model.TenantFilter{Name: "UNIT"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, []model.Tenant{*other}, tenants)
+
+	tenants, count, err = ds.ListTenants(ctx, model.TenantFilter{Skip: 1, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []model.Tenant{*acme}, tenants)
+
+	err = ds.UpdateTenant(ctx, acme.ID, model.TenantUpdate{Name: "Other unit"})
+	assert.ErrorIs(t, err, store.ErrDuplicateTenantName)
+	err = ds.UpdateTenant(ctx, acme.ID, model.TenantUpdate{Name: "ACME"})
+	assert.NoError(t, err)
+	err = ds.UpdateTenantStatus(ctx, acme.ID, model.TenantStatusSuspended)
+	assert.NoError(t, err)
+	err = ds.UpdateTenantToken(ctx, acme.ID, "token")
+	assert.NoError(t, err)
+	err = ds.UpdateTenantStatus(ctx, "missing", model.TenantStatusSuspended)
+	assert.ErrorIs(t, err, store.ErrTenantNotFound)
+
+	tenants, _, err = ds.ListTenants(ctx, model.TenantFilter{
+		Status: model.TenantStatusSuspended,
+	})
+	require.NoError(t, err)
+	if assert.Len(t, tenants, 1) {
+		assert.Equal(t, acme.ID, tenants[0].ID)
+		assert.Equal(t, "ACME", tenants[0].Name)
+		assert.Equal(t, "token", tenants[0].TenantToken)
+		assert.True(t, tenants[0].UpdatedTS.After(acme.UpdatedTS) ||
+			tenants[0].UpdatedTS.Equal(acme.UpdatedTS))
+	}
+
+	_, err = ds.GetTenantByToken(ctx, acme.TenantToken)
+	assert.ErrorIs(t, err, store.ErrTenantNotFound)
+
+	require.NoError(t, ds.DeleteTenant(ctx, acme.ID))
+	err = ds.DeleteTenant(ctx, acme.ID)
+	assert.ErrorIs(t, err, store.ErrTenantNotFound)
+}
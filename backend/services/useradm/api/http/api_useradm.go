@@ -518,6 +518,18 @@ func (u *UserAdmApiHandlers) CreateTenantHandler(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+func (u *UserAdmApiHandlers) DeleteTenantHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := u.userAdm.DeleteTenant(ctx, c.Param("id"))
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func getTenantContext(ctx context.Context, tenantId string) context.Context {
 	if ctx == nil {
 		ctx = context.Background()
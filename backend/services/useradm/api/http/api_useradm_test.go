@@ -1539,6 +1539,52 @@ func TestUserAdmApiCreateTenant(t *testing.T) {
 	}
 }
 
+func TestUserAdmApiDeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		uaError error
+
+		checker mt.ResponseChecker
+	}{
+		"ok": {
+			checker: mt.NewJSONResponse(
+				http.StatusNoContent,
+				nil,
+				nil,
+			),
+		},
+		"error: useradm internal": {
+			uaError: errors.New("some internal error"),
+
+			checker: mt.NewJSONResponse(
+				http.StatusInternalServerError,
+				nil,
+				restError("internal error"),
+			),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			uadm := &museradm.App{}
+			defer uadm.AssertExpectations(t)
+			uadm.On("DeleteTenant", mtesting.ContextMatcher(), "foobar").
+				Return(tc.uaError)
+
+			api := makeMockApiHandler(t, uadm, nil)
+
+			req := makeReq(http.MethodDelete,
+				"http://localhost/api/internal/v1/useradm/tenants/foobar",
+				"",
+				nil)
+
+			recorded := RunRequest(t, api, req)
+			mt.CheckHTTPResponse(t, tc.checker, recorded)
+		})
+	}
+}
+
 func TestUserAdmApiSaveSettings(t *testing.T) {
 	t.Parallel()
 
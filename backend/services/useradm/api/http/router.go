@@ -28,6 +28,7 @@ const (
 
 	uriInternalAuthVerify  = "/auth/verify"
 	uriInternalTenants     = "/tenants"
+	uriInternalTenant      = "/tenants/:id"
 	uriInternalTenantUsers = "/tenants/:id/users"
 	uriInternalTenantUser  = "/tenants/:id/users/:userid"
 	uriInternalTokens      = "/tokens"
@@ -76,6 +77,7 @@ func MakeRouter(i *UserAdmApiHandlers) http.Handler {
 		i.AuthVerifyHandler)
 
 	internal.POST(uriInternalTenants, i.CreateTenantHandler)
+	internal.DELETE(uriInternalTenant, i.DeleteTenantHandler)
 	internal.POST(uriInternalTenantUsers, i.CreateTenantUserHandler)
 	internal.DELETE(uriInternalTenantUser, i.DeleteTenantUserHandler)
 	internal.GET(uriInternalTenantUsers, i.GetTenantUsersHandler)
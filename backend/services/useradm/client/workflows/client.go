@@ -0,0 +1,126 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package workflows
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+)
+
+const (
+	AuditlogsURI = "/api/v1/workflow/emit_auditlog"
+)
+
+const (
+	defaultTimeout = time.Duration(5) * time.Second
+)
+
+// Client is the workflows client
+//
+//go:generate ../../../../utils/mockgen.sh
+type Client interface {
+	SubmitAuditLog(ctx context.Context, log AuditLog) error
+}
+
+type ClientOptions struct {
+	Client *http.Client
+}
+
+// NewClient returns a new workflows client
+func NewClient(url string, opts ...ClientOptions) Client {
+	// Initialize default options
+	var clientOpts = ClientOptions{
+		Client: &http.Client{},
+	}
+	// Merge options
+	for _, opt := range opts {
+		if opt.Client != nil {
+			clientOpts.Client = opt.Client
+		}
+	}
+
+	return &client{
+		url:    strings.TrimSuffix(url, "/"),
+		client: *clientOpts.Client,
+	}
+}
+
+type client struct {
+	url    string
+	client http.Client
+}
+
+// SubmitAuditLog starts the emit_auditlog workflow. The tenant is taken
+// from the identity in the context, if any.
+func (c *client) SubmitAuditLog(ctx context.Context, log AuditLog) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	if log.EventTS.IsZero() {
+		log.EventTS = time.Now()
+	}
+	if err := log.Validate(); err != nil {
+		return errors.Wrap(err, "workflows: invalid AuditLog entry")
+	}
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	wflow := AuditWorkflow{
+		RequestID: requestid.FromContext(ctx),
+		TenantID:  tenantID,
+		AuditLog:  log,
+	}
+	payload, _ := json.Marshal(wflow)
+	req, err := http.NewRequestWithContext(ctx,
+		"POST",
+		c.url+AuditlogsURI,
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return errors.Wrap(err, "workflows: error preparing HTTP request")
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "workflows: failed to submit auditlog")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 300 {
+		return nil
+	}
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return errors.New(`workflows: workflow "emit_auditlog" not defined`)
+	}
+
+	return errors.Errorf(
+		"workflows: unexpected HTTP status from workflows service: %s",
+		rsp.Status,
+	)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+)
+
+func TestSubmitAuditLog(t *testing.T) {
+	t.Parallel()
+
+	validLog := AuditLog{
+		Action: ActionLogin,
+		Actor: Actor{
+			ID:    "user-id",
+			Type:  ActorUser,
+			Email: "user@example.com",
+		},
+		Object: Object{
+			ID:   "user-id",
+			Type: ObjectUser,
+		},
+	}
+	testCases := []struct {
+		Name string
+
+		Identity *identity.Identity
+		Log      AuditLog
+		Status   int
+
+		Error string
+	}{
+		{
+			Name: "ok",
+
+			Identity: &identity.Identity{Tenant: "tenant"},
+			Log:      validLog,
+			Status:   http.StatusCreated,
+		},
+		{
+			Name: "ok, no tenant",
+
+			Log:    validLog,
+			Status: http.StatusCreated,
+		},
+		{
+			Name: "error, invalid log",
+
+			Log: AuditLog{
+				Action: ActionLogin,
+				Actor: Actor{
+					ID:    "user-id",
+					Type:  ActorUser,
+					Email: "not an email",
+				},
+				Object: validLog.Object,
+			},
+			Error: "workflows: invalid AuditLog entry: actor: (email: must be a valid email address.).",
+		},
+		{
+			Name: "error, workflow not defined",
+
+			Log:    validLog,
+			Status: http.StatusNotFound,
+			Error:  `workflows: workflow "emit_auditlog" not defined`,
+		},
+		{
+			Name: "error, unexpected status",
+
+			Log:    validLog,
+			Status: http.StatusInternalServerError,
+			Error: "workflows: unexpected HTTP status from workflows service: " +
+				"500 Internal Server Error",
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, AuditlogsURI, r.URL.Path)
+					var wflow AuditWorkflow
+					err := json.NewDecoder(r.Body).Decode(&wflow)
+					assert.NoError(t, err)
+					if tc.Identity != nil {
+						assert.Equal(t, tc.Identity.Tenant, wflow.TenantID)
+					} else {
+						assert.Empty(t, wflow.TenantID)
+					}
+					assert.Equal(t, "request-id", wflow.RequestID)
+					assert.Equal(t, tc.Log.Actor, wflow.AuditLog.Actor)
+					w.WriteHeader(tc.Status)
+				},
+			))
+			defer srv.Close()
+
+			ctx := requestid.WithContext(context.Background(), "request-id")
+			if tc.Identity != nil {
+				ctx = identity.WithContext(ctx, tc.Identity)
+			}
+			err := NewClient(srv.URL+"/").SubmitAuditLog(ctx, tc.Log)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -75,6 +75,12 @@ const (
 	// Max Request body size
 	SettingMaxRequestSize        = "request_size_limit"
 	SettingMaxRequestSizeDefault = 1024 * 1024 // 1 MiB
+
+	SettingWorkflowsURL        = "workflows_url"
+	SettingWorkflowsURLDefault = "http://mender-workflows-server:8080"
+
+	SettingEnableAudit        = "enable_audit"
+	SettingEnableAuditDefault = false
 )
 
 var (
@@ -101,5 +107,7 @@ var (
 		{Key: SettingPlanDefinitions,
 			Value: SettingPlanDefinitionsDefault},
 		{Key: SettingMaxRequestSize, Value: SettingMaxRequestSizeDefault},
+		{Key: SettingWorkflowsURL, Value: SettingWorkflowsURLDefault},
+		{Key: SettingEnableAudit, Value: SettingEnableAuditDefault},
 	}
 )
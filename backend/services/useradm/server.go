@@ -33,6 +33,7 @@ import (
 	"github.com/mendersoftware/mender-server/pkg/redis"
 
 	api_http "github.com/mendersoftware/mender-server/services/useradm/api/http"
+	"github.com/mendersoftware/mender-server/services/useradm/client/workflows"
 	"github.com/mendersoftware/mender-server/services/useradm/common"
 	. "github.com/mendersoftware/mender-server/services/useradm/config"
 	"github.com/mendersoftware/mender-server/services/useradm/jwt"
@@ -64,7 +65,11 @@ func RunServer(c config.Reader) error {
 			TokenLastUsedUpdateFreqMinutes: c.GetInt(SettingTokenLastUsedUpdateFreqMinutes),
 			PrivateKeyPath:                 c.GetString(SettingServerPrivKeyPath),
 			PrivateKeyFileNamePattern:      c.GetString(SettingServerPrivKeyFileNamePattern),
+			HaveAuditLogs:                  c.GetBool(SettingEnableAudit),
 		})
+	if c.GetBool(SettingEnableAudit) {
+		ua.WithWorkflows(workflows.NewClient(c.GetString(SettingWorkflowsURL)))
+	}
 
 	useradmapi := api_http.NewUserAdmApiHandlers(ua, db, jwtHandlers,
 		api_http.Config{
@@ -77,4 +77,7 @@ type DataStore interface {
 	GetSettings(ctx context.Context) (*model.Settings, error)
 	SaveUserSettings(ctx context.Context, userID string, s *model.Settings, etag string) error
 	GetUserSettings(ctx context.Context, userID string) (*model.Settings, error)
+
+	// deletes all the users, tokens and settings of the tenant
+	DeleteTenant(ctx context.Context, tenantID string) error
 }
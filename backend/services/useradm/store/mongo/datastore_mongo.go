@@ -725,3 +725,23 @@ func (db *DataStoreMongo) CountPersonalAccessTokens(
 	}
 	return count, nil
 }
+
+func (db *DataStoreMongo) DeleteTenant(ctx context.Context, tenantID string) error {
+	if tenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+	database := db.client.Database(DbName)
+	for _, collName := range []string{
+		DbUsersColl,
+		DbTokensColl,
+		DbSettingsColl,
+		DbUserSettingsColl,
+	} {
+		_, err := database.Collection(collName).
+			DeleteMany(ctx, bson.M{mstore.FieldTenantID: tenantID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove the tenant %s", collName)
+		}
+	}
+	return nil
+}
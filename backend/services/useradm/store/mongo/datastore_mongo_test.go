@@ -1710,6 +1710,48 @@ func TestMongoDeleteTokens(t *testing.T) {
 	}
 }
 
+func TestMongoDeleteTenant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+	}
+
+	db.Wipe()
+	ctx := context.Background()
+	client := db.Client()
+	store, err := NewDataStoreMongoWithClient(client)
+	assert.NoError(t, err)
+
+	collections := []string{
+		DbUsersColl,
+		DbTokensColl,
+		DbSettingsColl,
+		DbUserSettingsColl,
+	}
+	database := client.Database(DbName)
+	for _, tenantID := range []string{"foo", "bar"} {
+		for _, collName := range collections {
+			_, err := database.Collection(collName).InsertOne(ctx, bson.M{
+				"_id":                tenantID + "-" + collName,
+				mstore.FieldTenantID: tenantID,
+			})
+			assert.NoError(t, err)
+		}
+	}
+
+	assert.EqualError(t, store.DeleteTenant(ctx, ""), "tenant ID is required")
+	assert.NoError(t, store.DeleteTenant(ctx, "foo"))
+
+	for _, collName := range collections {
+		c := database.Collection(collName)
+		n, err := c.CountDocuments(ctx, bson.M{mstore.FieldTenantID: "foo"})
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+		n, err = c.CountDocuments(ctx, bson.M{mstore.FieldTenantID: "bar"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	}
+}
+
 func TestMongoDeleteTokensByUserId(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode.")
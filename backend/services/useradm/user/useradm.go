@@ -26,6 +26,7 @@ import (
 	"github.com/mendersoftware/mender-server/pkg/log"
 	"github.com/mendersoftware/mender-server/pkg/mongo/oid"
 
+	"github.com/mendersoftware/mender-server/services/useradm/client/workflows"
 	"github.com/mendersoftware/mender-server/services/useradm/common"
 	"github.com/mendersoftware/mender-server/services/useradm/jwt"
 	"github.com/mendersoftware/mender-server/services/useradm/model"
@@ -89,6 +90,7 @@ type App interface {
 	DeleteTokens(ctx context.Context, tenantId, userId string) error
 
 	CreateTenant(ctx context.Context, tenant model.NewTenant) error
+	DeleteTenant(ctx context.Context, tenantID string) error
 	GetPlans(ctx context.Context, skip, limit int) []model.Plan
 	GetPlanBinding(ctx context.Context) (*model.PlanBindingDetails, error)
 }
@@ -112,6 +114,8 @@ type Config struct {
 	// PrivateKeyFileNamePattern holds the regular expression used
 	// to get the key id from a filename
 	PrivateKeyFileNamePattern string
+	// HaveAuditLogs enables submitting audit log events
+	HaveAuditLogs bool
 }
 
 type UserAdm struct {
@@ -119,6 +123,7 @@ type UserAdm struct {
 	jwtHandlers map[int]jwt.Handler
 	db          store.DataStore
 	config      Config
+	workflows   workflows.Client
 }
 
 func NewUserAdm(jwtHandlers map[int]jwt.Handler, db store.DataStore, config Config) *UserAdm {
@@ -130,6 +135,12 @@ func NewUserAdm(jwtHandlers map[int]jwt.Handler, db store.DataStore, config Conf
 	}
 }
 
+// WithWorkflows sets the workflows client used for submitting audit logs.
+func (u *UserAdm) WithWorkflows(client workflows.Client) *UserAdm {
+	u.workflows = client
+	return u
+}
+
 func (u *UserAdm) HealthCheck(ctx context.Context) error {
 	err := u.db.Ping(ctx)
 	if err != nil {
@@ -192,9 +203,37 @@ func (u *UserAdm) Login(ctx context.Context, email model.Email, pass string,
 		l.Warnf("failed to update login timestamp: %s", err.Error())
 	}
 
+	u.submitLoginAuditLog(ctx, user)
+
 	return t, nil
 }
 
+// submitLoginAuditLog records a successful login in the audit logs.
+// The user is already logged in at this point, so failures are only logged.
+func (u *UserAdm) submitLoginAuditLog(ctx context.Context, user *model.User) {
+	if !u.config.HaveAuditLogs || u.workflows == nil {
+		return
+	}
+	err := u.workflows.SubmitAuditLog(ctx, workflows.AuditLog{
+		Action: workflows.ActionLogin,
+		Actor: workflows.Actor{
+			ID:    user.ID,
+			Type:  workflows.ActorUser,
+			Email: string(user.Email),
+		},
+		Object: workflows.Object{
+			ID:   user.ID,
+			Type: workflows.ObjectUser,
+		},
+		Change:  "User logged in",
+		EventTS: time.Now(),
+	})
+	if err != nil {
+		log.FromContext(ctx).
+			Errorf("failed to submit audit log for user login: %s", err.Error())
+	}
+}
+
 func (u *UserAdm) generateToken(subject, scope, tenant string,
 	noExpiry bool, keyId int) (*jwt.Token, error) {
 	id := oid.NewUUIDv4()
@@ -456,6 +495,13 @@ func (u *UserAdm) CreateTenant(ctx context.Context, tenant model.NewTenant) erro
 	return nil
 }
 
+func (ua *UserAdm) DeleteTenant(ctx context.Context, tenantID string) error {
+	if err := ua.db.DeleteTenant(ctx, tenantID); err != nil {
+		return errors.Wrapf(err, "useradm: failed to delete tenant %v", tenantID)
+	}
+	return nil
+}
+
 func (ua *UserAdm) SetPassword(ctx context.Context, uu model.UserUpdate) error {
 	u, err := ua.db.GetUserByEmail(ctx, uu.Email)
 	if err != nil {
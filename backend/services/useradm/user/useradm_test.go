@@ -29,6 +29,8 @@ import (
 	"github.com/mendersoftware/mender-server/pkg/identity"
 	"github.com/mendersoftware/mender-server/pkg/mongo/oid"
 
+	"github.com/mendersoftware/mender-server/services/useradm/client/workflows"
+	mworkflows "github.com/mendersoftware/mender-server/services/useradm/client/workflows/mocks"
 	"github.com/mendersoftware/mender-server/services/useradm/jwt"
 	mjwt "github.com/mendersoftware/mender-server/services/useradm/jwt/mocks"
 	"github.com/mendersoftware/mender-server/services/useradm/model"
@@ -301,6 +303,60 @@ func TestUserAdmLogin(t *testing.T) {
 
 }
 
+func TestUserAdmLoginAuditLog(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		submitErr error
+	}{
+		"ok": {},
+		"ok, audit log submission failed": {
+			submitErr: errors.New("workflows down"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			user := &model.User{
+				ID:       oid.NewUUIDv5("1234").String(),
+				Email:    "foo@bar.com",
+				Password: `$2a$10$wMW4kC6o1fY87DokgO.lDektJO7hBXydf4B.yIWmE8hR9jOiO8way`,
+			}
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("GetUserByEmail", ContextMatcher(), user.Email).Return(user, nil)
+			db.On("SaveToken", ContextMatcher(), mock.AnythingOfType("*jwt.Token")).
+				Return(nil)
+			db.On("UpdateLoginTs", ContextMatcher(), user.ID).Return(nil)
+
+			wf := &mworkflows.Client{}
+			defer wf.AssertExpectations(t)
+			wf.On("SubmitAuditLog", ContextMatcher(),
+				mock.MatchedBy(func(log workflows.AuditLog) bool {
+					return log.Action == workflows.ActionLogin &&
+						log.Actor.ID == user.ID &&
+						log.Actor.Email == string(user.Email) &&
+						log.Object.Type == workflows.ObjectUser
+				})).Return(tc.submitErr)
+
+			useradm := NewUserAdm(nil, db, Config{
+				Issuer:                "foobar",
+				ExpirationTimeSeconds: 10,
+				HaveAuditLogs:         true,
+			}).WithWorkflows(wf)
+
+			token, err := useradm.Login(ctx, user.Email,
+				"correcthorsebatterystaple", &LoginOptions{})
+			assert.NoError(t, err)
+			assert.NotNil(t, token)
+		})
+	}
+}
+
 func TestUserAdmLogout(t *testing.T) {
 	testCases := map[string]struct {
 		token            *jwt.Token
@@ -1294,6 +1350,42 @@ func TestUserAdmDeleteTokens(t *testing.T) {
 	}
 }
 
+func TestUserAdmDeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		dbErr error
+
+		outErr error
+	}{
+		"ok": {},
+		"db error": {
+			dbErr:  errors.New("db connection failed"),
+			outErr: errors.New("useradm: failed to delete tenant foo: db connection failed"),
+		},
+	}
+
+	for name := range testCases {
+		tc := testCases[name]
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db := &mstore.DataStore{}
+			defer db.AssertExpectations(t)
+			db.On("DeleteTenant", ContextMatcher(), "foo").Return(tc.dbErr)
+
+			useradm := NewUserAdm(nil, db, Config{})
+
+			err := useradm.DeleteTenant(ctx, "foo")
+			if tc.outErr != nil {
+				assert.EqualError(t, err, tc.outErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }